@@ -19,6 +19,7 @@
 binary/build: \
 	cmd/agent/sidecar/sidecar \
 	cmd/discoverer/k8s/discoverer \
+	cmd/discoverer/static/discoverer \
 	cmd/gateway/filter/filter \
 	cmd/gateway/lb/lb \
 	cmd/gateway/mirror/mirror \
@@ -56,6 +57,10 @@ cmd/discoverer/k8s/discoverer:
 	$(eval CGO_ENABLED = 0)
 	$(call go-build,discoverer/k8s,,-static,,,$@)
 
+cmd/discoverer/static/discoverer:
+	$(eval CGO_ENABLED = 0)
+	$(call go-build,discoverer/static,,-static,,,$@)
+
 cmd/gateway/lb/lb:
 	$(eval CGO_ENABLED = 0)
 	$(call go-build,gateway/lb,,-static,,,$@)
@@ -123,6 +128,7 @@ binary/build/zip: \
 	artifacts/vald-benchmark-job-$(GOOS)-$(GOARCH).zip \
 	artifacts/vald-benchmark-operator-$(GOOS)-$(GOARCH).zip \
 	artifacts/vald-discoverer-k8s-$(GOOS)-$(GOARCH).zip \
+	artifacts/vald-discoverer-static-$(GOOS)-$(GOARCH).zip \
 	artifacts/vald-example-client-$(GOOS)-$(GOARCH).zip \
 	artifacts/vald-filter-gateway-$(GOOS)-$(GOARCH).zip \
 	artifacts/vald-index-correction-$(GOOS)-$(GOARCH).zip \
@@ -151,6 +157,10 @@ artifacts/vald-discoverer-k8s-$(GOOS)-$(GOARCH).zip: cmd/discoverer/k8s/discover
 	$(call mkdir, $(dir $@))
 	zip --junk-paths $@ $<
 
+artifacts/vald-discoverer-static-$(GOOS)-$(GOARCH).zip: cmd/discoverer/static/discoverer
+	$(call mkdir, $(dir $@))
+	zip --junk-paths $@ $<
+
 artifacts/vald-lb-gateway-$(GOOS)-$(GOARCH).zip: cmd/gateway/lb/lb
 	$(call mkdir, $(dir $@))
 	zip --junk-paths $@ $<
@@ -165,6 +165,63 @@ func (Remove_Timestamp_Operator) EnumDescriptor() ([]byte, []int) {
 	return file_v1_payload_payload_proto_rawDescGZIP(), []int{5, 3, 0}
 }
 
+// Encoding is enum of each wire encoding of a vector.
+type Object_Vector_Encoding int32
+
+const (
+	// The vector is sent in the vector field.
+	Object_Vector_FLOAT32 Object_Vector_Encoding = 0
+	// The vector is sent in the data field as little-endian IEEE 754 half precision floats.
+	Object_Vector_FLOAT16 Object_Vector_Encoding = 1
+	// The vector is sent in the data field as int8 values, each multiplied by scale.
+	Object_Vector_INT8 Object_Vector_Encoding = 2
+	// The vector is sent in the data field as little-endian IEEE 754 single precision floats.
+	Object_Vector_BYTES Object_Vector_Encoding = 3
+)
+
+// Enum value maps for Object_Vector_Encoding.
+var (
+	Object_Vector_Encoding_name = map[int32]string{
+		0: "FLOAT32",
+		1: "FLOAT16",
+		2: "INT8",
+		3: "BYTES",
+	}
+	Object_Vector_Encoding_value = map[string]int32{
+		"FLOAT32": 0,
+		"FLOAT16": 1,
+		"INT8":    2,
+		"BYTES":   3,
+	}
+)
+
+func (x Object_Vector_Encoding) Enum() *Object_Vector_Encoding {
+	p := new(Object_Vector_Encoding)
+	*p = x
+	return p
+}
+
+func (x Object_Vector_Encoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Object_Vector_Encoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_payload_payload_proto_enumTypes[2].Descriptor()
+}
+
+func (Object_Vector_Encoding) Type() protoreflect.EnumType {
+	return &file_v1_payload_payload_proto_enumTypes[2]
+}
+
+func (x Object_Vector_Encoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Object_Vector_Encoding.Descriptor instead.
+func (Object_Vector_Encoding) EnumDescriptor() ([]byte, []int) {
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{7, 5, 0}
+}
+
 // Search related messages.
 type Search struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -645,6 +702,43 @@ func (*Meta) Descriptor() ([]byte, []int) {
 	return file_v1_payload_payload_proto_rawDescGZIP(), []int{12}
 }
 
+// Collection related messages.
+type Collection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	mi := &file_v1_payload_payload_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_payload_payload_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{13}
+}
+
 // Represent an empty message.
 type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -654,7 +748,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_v1_payload_payload_proto_msgTypes[13]
+	mi := &file_v1_payload_payload_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -666,7 +760,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[13]
+	mi := &file_v1_payload_payload_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -679,7 +773,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{13}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{14}
 }
 
 // Represent a search request.
@@ -695,7 +789,7 @@ type Search_Request struct {
 
 func (x *Search_Request) Reset() {
 	*x = Search_Request{}
-	mi := &file_v1_payload_payload_proto_msgTypes[14]
+	mi := &file_v1_payload_payload_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -707,7 +801,7 @@ func (x *Search_Request) String() string {
 func (*Search_Request) ProtoMessage() {}
 
 func (x *Search_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[14]
+	mi := &file_v1_payload_payload_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +842,7 @@ type Search_MultiRequest struct {
 
 func (x *Search_MultiRequest) Reset() {
 	*x = Search_MultiRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[15]
+	mi := &file_v1_payload_payload_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -760,7 +854,7 @@ func (x *Search_MultiRequest) String() string {
 func (*Search_MultiRequest) ProtoMessage() {}
 
 func (x *Search_MultiRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[15]
+	mi := &file_v1_payload_payload_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -796,7 +890,7 @@ type Search_IDRequest struct {
 
 func (x *Search_IDRequest) Reset() {
 	*x = Search_IDRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[16]
+	mi := &file_v1_payload_payload_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -808,7 +902,7 @@ func (x *Search_IDRequest) String() string {
 func (*Search_IDRequest) ProtoMessage() {}
 
 func (x *Search_IDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[16]
+	mi := &file_v1_payload_payload_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -849,7 +943,7 @@ type Search_MultiIDRequest struct {
 
 func (x *Search_MultiIDRequest) Reset() {
 	*x = Search_MultiIDRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[17]
+	mi := &file_v1_payload_payload_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -861,7 +955,7 @@ func (x *Search_MultiIDRequest) String() string {
 func (*Search_MultiIDRequest) ProtoMessage() {}
 
 func (x *Search_MultiIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[17]
+	mi := &file_v1_payload_payload_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -899,7 +993,7 @@ type Search_ObjectRequest struct {
 
 func (x *Search_ObjectRequest) Reset() {
 	*x = Search_ObjectRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[18]
+	mi := &file_v1_payload_payload_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -911,7 +1005,7 @@ func (x *Search_ObjectRequest) String() string {
 func (*Search_ObjectRequest) ProtoMessage() {}
 
 func (x *Search_ObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[18]
+	mi := &file_v1_payload_payload_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,7 +1053,7 @@ type Search_MultiObjectRequest struct {
 
 func (x *Search_MultiObjectRequest) Reset() {
 	*x = Search_MultiObjectRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[19]
+	mi := &file_v1_payload_payload_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -971,7 +1065,7 @@ func (x *Search_MultiObjectRequest) String() string {
 func (*Search_MultiObjectRequest) ProtoMessage() {}
 
 func (x *Search_MultiObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[19]
+	mi := &file_v1_payload_payload_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1018,14 +1112,16 @@ type Search_Config struct {
 	// Search ratio for agent return result number.
 	Ratio *wrapperspb.FloatValue `                   protobuf:"bytes,10,opt,name=ratio,proto3"                                                                                       json:"ratio,omitempty"`
 	// Search nprobe.
-	Nprobe        uint32 `                   protobuf:"varint,11,opt,name=nprobe,proto3"                                                                                     json:"nprobe,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Nprobe uint32 `                   protobuf:"varint,11,opt,name=nprobe,proto3"                                                                                     json:"nprobe,omitempty"`
+	// Include the uncommitted vectors in the insert queue in the search result.
+	IncludeUncommitted bool `                   protobuf:"varint,12,opt,name=include_uncommitted,json=includeUncommitted,proto3"                                                json:"include_uncommitted,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *Search_Config) Reset() {
 	*x = Search_Config{}
-	mi := &file_v1_payload_payload_proto_msgTypes[20]
+	mi := &file_v1_payload_payload_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1037,7 +1133,7 @@ func (x *Search_Config) String() string {
 func (*Search_Config) ProtoMessage() {}
 
 func (x *Search_Config) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[20]
+	mi := &file_v1_payload_payload_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1130,6 +1226,13 @@ func (x *Search_Config) GetNprobe() uint32 {
 	return 0
 }
 
+func (x *Search_Config) GetIncludeUncommitted() bool {
+	if x != nil {
+		return x.IncludeUncommitted
+	}
+	return false
+}
+
 // Represent a search response.
 type Search_Response struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1143,7 +1246,7 @@ type Search_Response struct {
 
 func (x *Search_Response) Reset() {
 	*x = Search_Response{}
-	mi := &file_v1_payload_payload_proto_msgTypes[21]
+	mi := &file_v1_payload_payload_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1155,7 +1258,7 @@ func (x *Search_Response) String() string {
 func (*Search_Response) ProtoMessage() {}
 
 func (x *Search_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[21]
+	mi := &file_v1_payload_payload_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1196,7 +1299,7 @@ type Search_Responses struct {
 
 func (x *Search_Responses) Reset() {
 	*x = Search_Responses{}
-	mi := &file_v1_payload_payload_proto_msgTypes[22]
+	mi := &file_v1_payload_payload_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1208,7 +1311,7 @@ func (x *Search_Responses) String() string {
 func (*Search_Responses) ProtoMessage() {}
 
 func (x *Search_Responses) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[22]
+	mi := &file_v1_payload_payload_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1245,7 +1348,7 @@ type Search_StreamResponse struct {
 
 func (x *Search_StreamResponse) Reset() {
 	*x = Search_StreamResponse{}
-	mi := &file_v1_payload_payload_proto_msgTypes[23]
+	mi := &file_v1_payload_payload_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1257,7 +1360,7 @@ func (x *Search_StreamResponse) String() string {
 func (*Search_StreamResponse) ProtoMessage() {}
 
 func (x *Search_StreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[23]
+	mi := &file_v1_payload_payload_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1329,7 +1432,7 @@ type Filter_Target struct {
 
 func (x *Filter_Target) Reset() {
 	*x = Filter_Target{}
-	mi := &file_v1_payload_payload_proto_msgTypes[24]
+	mi := &file_v1_payload_payload_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1341,7 +1444,7 @@ func (x *Filter_Target) String() string {
 func (*Filter_Target) ProtoMessage() {}
 
 func (x *Filter_Target) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[24]
+	mi := &file_v1_payload_payload_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1382,7 +1485,7 @@ type Filter_Config struct {
 
 func (x *Filter_Config) Reset() {
 	*x = Filter_Config{}
-	mi := &file_v1_payload_payload_proto_msgTypes[25]
+	mi := &file_v1_payload_payload_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1394,7 +1497,7 @@ func (x *Filter_Config) String() string {
 func (*Filter_Config) ProtoMessage() {}
 
 func (x *Filter_Config) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[25]
+	mi := &file_v1_payload_payload_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1430,7 +1533,7 @@ type Insert_Request struct {
 
 func (x *Insert_Request) Reset() {
 	*x = Insert_Request{}
-	mi := &file_v1_payload_payload_proto_msgTypes[26]
+	mi := &file_v1_payload_payload_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1442,7 +1545,7 @@ func (x *Insert_Request) String() string {
 func (*Insert_Request) ProtoMessage() {}
 
 func (x *Insert_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[26]
+	mi := &file_v1_payload_payload_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1483,7 +1586,7 @@ type Insert_MultiRequest struct {
 
 func (x *Insert_MultiRequest) Reset() {
 	*x = Insert_MultiRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[27]
+	mi := &file_v1_payload_payload_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1495,7 +1598,7 @@ func (x *Insert_MultiRequest) String() string {
 func (*Insert_MultiRequest) ProtoMessage() {}
 
 func (x *Insert_MultiRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[27]
+	mi := &file_v1_payload_payload_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1533,7 +1636,7 @@ type Insert_ObjectRequest struct {
 
 func (x *Insert_ObjectRequest) Reset() {
 	*x = Insert_ObjectRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[28]
+	mi := &file_v1_payload_payload_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1545,7 +1648,7 @@ func (x *Insert_ObjectRequest) String() string {
 func (*Insert_ObjectRequest) ProtoMessage() {}
 
 func (x *Insert_ObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[28]
+	mi := &file_v1_payload_payload_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1593,7 +1696,7 @@ type Insert_MultiObjectRequest struct {
 
 func (x *Insert_MultiObjectRequest) Reset() {
 	*x = Insert_MultiObjectRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[29]
+	mi := &file_v1_payload_payload_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1605,7 +1708,7 @@ func (x *Insert_MultiObjectRequest) String() string {
 func (*Insert_MultiObjectRequest) ProtoMessage() {}
 
 func (x *Insert_MultiObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[29]
+	mi := &file_v1_payload_payload_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1636,14 +1739,17 @@ type Insert_Config struct {
 	// Filter configurations.
 	Filters *Filter_Config `                   protobuf:"bytes,2,opt,name=filters,proto3"                                            json:"filters,omitempty"`
 	// Insert timestamp.
-	Timestamp     int64 `                   protobuf:"varint,3,opt,name=timestamp,proto3"                                         json:"timestamp,omitempty"`
+	Timestamp int64 `                   protobuf:"varint,3,opt,name=timestamp,proto3"                                         json:"timestamp,omitempty"`
+	// Time to live of the vector in seconds from the timestamp.
+	// 0 uses the default TTL of the agent, and a negative value disables the expiry.
+	Ttl           int64 `                   protobuf:"varint,4,opt,name=ttl,proto3"                                               json:"ttl,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Insert_Config) Reset() {
 	*x = Insert_Config{}
-	mi := &file_v1_payload_payload_proto_msgTypes[30]
+	mi := &file_v1_payload_payload_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1655,7 +1761,7 @@ func (x *Insert_Config) String() string {
 func (*Insert_Config) ProtoMessage() {}
 
 func (x *Insert_Config) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[30]
+	mi := &file_v1_payload_payload_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1692,6 +1798,13 @@ func (x *Insert_Config) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *Insert_Config) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
 // Represent the update request.
 type Update_Request struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1705,7 +1818,7 @@ type Update_Request struct {
 
 func (x *Update_Request) Reset() {
 	*x = Update_Request{}
-	mi := &file_v1_payload_payload_proto_msgTypes[31]
+	mi := &file_v1_payload_payload_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1717,7 +1830,7 @@ func (x *Update_Request) String() string {
 func (*Update_Request) ProtoMessage() {}
 
 func (x *Update_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[31]
+	mi := &file_v1_payload_payload_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1758,7 +1871,7 @@ type Update_MultiRequest struct {
 
 func (x *Update_MultiRequest) Reset() {
 	*x = Update_MultiRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[32]
+	mi := &file_v1_payload_payload_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1770,7 +1883,7 @@ func (x *Update_MultiRequest) String() string {
 func (*Update_MultiRequest) ProtoMessage() {}
 
 func (x *Update_MultiRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[32]
+	mi := &file_v1_payload_payload_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1808,7 +1921,7 @@ type Update_ObjectRequest struct {
 
 func (x *Update_ObjectRequest) Reset() {
 	*x = Update_ObjectRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[33]
+	mi := &file_v1_payload_payload_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1820,7 +1933,7 @@ func (x *Update_ObjectRequest) String() string {
 func (*Update_ObjectRequest) ProtoMessage() {}
 
 func (x *Update_ObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[33]
+	mi := &file_v1_payload_payload_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1868,7 +1981,7 @@ type Update_MultiObjectRequest struct {
 
 func (x *Update_MultiObjectRequest) Reset() {
 	*x = Update_MultiObjectRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[34]
+	mi := &file_v1_payload_payload_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1880,7 +1993,7 @@ func (x *Update_MultiObjectRequest) String() string {
 func (*Update_MultiObjectRequest) ProtoMessage() {}
 
 func (x *Update_MultiObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[34]
+	mi := &file_v1_payload_payload_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1918,7 +2031,7 @@ type Update_TimestampRequest struct {
 
 func (x *Update_TimestampRequest) Reset() {
 	*x = Update_TimestampRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[35]
+	mi := &file_v1_payload_payload_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1930,7 +2043,7 @@ func (x *Update_TimestampRequest) String() string {
 func (*Update_TimestampRequest) ProtoMessage() {}
 
 func (x *Update_TimestampRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[35]
+	mi := &file_v1_payload_payload_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1979,13 +2092,16 @@ type Update_Config struct {
 	// A flag to disable balanced update (split remove -> insert operation)
 	// during update operation.
 	DisableBalancedUpdate bool `                   protobuf:"varint,4,opt,name=disable_balanced_update,json=disableBalancedUpdate,proto3" json:"disable_balanced_update,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// Time to live of the vector in seconds from the timestamp.
+	// 0 uses the default TTL of the agent, and a negative value disables the expiry.
+	Ttl           int64 `                   protobuf:"varint,5,opt,name=ttl,proto3"                                                json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Update_Config) Reset() {
 	*x = Update_Config{}
-	mi := &file_v1_payload_payload_proto_msgTypes[36]
+	mi := &file_v1_payload_payload_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1997,7 +2113,7 @@ func (x *Update_Config) String() string {
 func (*Update_Config) ProtoMessage() {}
 
 func (x *Update_Config) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[36]
+	mi := &file_v1_payload_payload_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2041,6 +2157,13 @@ func (x *Update_Config) GetDisableBalancedUpdate() bool {
 	return false
 }
 
+func (x *Update_Config) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
 // Represent the upsert request.
 type Upsert_Request struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2054,7 +2177,7 @@ type Upsert_Request struct {
 
 func (x *Upsert_Request) Reset() {
 	*x = Upsert_Request{}
-	mi := &file_v1_payload_payload_proto_msgTypes[37]
+	mi := &file_v1_payload_payload_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2066,7 +2189,7 @@ func (x *Upsert_Request) String() string {
 func (*Upsert_Request) ProtoMessage() {}
 
 func (x *Upsert_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[37]
+	mi := &file_v1_payload_payload_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2107,7 +2230,7 @@ type Upsert_MultiRequest struct {
 
 func (x *Upsert_MultiRequest) Reset() {
 	*x = Upsert_MultiRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[38]
+	mi := &file_v1_payload_payload_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2119,7 +2242,7 @@ func (x *Upsert_MultiRequest) String() string {
 func (*Upsert_MultiRequest) ProtoMessage() {}
 
 func (x *Upsert_MultiRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[38]
+	mi := &file_v1_payload_payload_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2157,7 +2280,7 @@ type Upsert_ObjectRequest struct {
 
 func (x *Upsert_ObjectRequest) Reset() {
 	*x = Upsert_ObjectRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[39]
+	mi := &file_v1_payload_payload_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2169,7 +2292,7 @@ func (x *Upsert_ObjectRequest) String() string {
 func (*Upsert_ObjectRequest) ProtoMessage() {}
 
 func (x *Upsert_ObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[39]
+	mi := &file_v1_payload_payload_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2217,7 +2340,7 @@ type Upsert_MultiObjectRequest struct {
 
 func (x *Upsert_MultiObjectRequest) Reset() {
 	*x = Upsert_MultiObjectRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[40]
+	mi := &file_v1_payload_payload_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2229,7 +2352,7 @@ func (x *Upsert_MultiObjectRequest) String() string {
 func (*Upsert_MultiObjectRequest) ProtoMessage() {}
 
 func (x *Upsert_MultiObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[40]
+	mi := &file_v1_payload_payload_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2264,13 +2387,16 @@ type Upsert_Config struct {
 	// A flag to disable balanced update (split remove -> insert operation)
 	// during update operation.
 	DisableBalancedUpdate bool `                   protobuf:"varint,4,opt,name=disable_balanced_update,json=disableBalancedUpdate,proto3" json:"disable_balanced_update,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// Time to live of the vector in seconds from the timestamp.
+	// 0 uses the default TTL of the agent, and a negative value disables the expiry.
+	Ttl           int64 `                   protobuf:"varint,5,opt,name=ttl,proto3"                                                json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Upsert_Config) Reset() {
 	*x = Upsert_Config{}
-	mi := &file_v1_payload_payload_proto_msgTypes[41]
+	mi := &file_v1_payload_payload_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2282,7 +2408,7 @@ func (x *Upsert_Config) String() string {
 func (*Upsert_Config) ProtoMessage() {}
 
 func (x *Upsert_Config) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[41]
+	mi := &file_v1_payload_payload_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2326,6 +2452,13 @@ func (x *Upsert_Config) GetDisableBalancedUpdate() bool {
 	return false
 }
 
+func (x *Upsert_Config) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
 // Represent the remove request.
 type Remove_Request struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2339,7 +2472,7 @@ type Remove_Request struct {
 
 func (x *Remove_Request) Reset() {
 	*x = Remove_Request{}
-	mi := &file_v1_payload_payload_proto_msgTypes[42]
+	mi := &file_v1_payload_payload_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2351,7 +2484,7 @@ func (x *Remove_Request) String() string {
 func (*Remove_Request) ProtoMessage() {}
 
 func (x *Remove_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[42]
+	mi := &file_v1_payload_payload_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2392,7 +2525,7 @@ type Remove_MultiRequest struct {
 
 func (x *Remove_MultiRequest) Reset() {
 	*x = Remove_MultiRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[43]
+	mi := &file_v1_payload_payload_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2404,7 +2537,7 @@ func (x *Remove_MultiRequest) String() string {
 func (*Remove_MultiRequest) ProtoMessage() {}
 
 func (x *Remove_MultiRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[43]
+	mi := &file_v1_payload_payload_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2439,7 +2572,7 @@ type Remove_TimestampRequest struct {
 
 func (x *Remove_TimestampRequest) Reset() {
 	*x = Remove_TimestampRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[44]
+	mi := &file_v1_payload_payload_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2451,7 +2584,7 @@ func (x *Remove_TimestampRequest) String() string {
 func (*Remove_TimestampRequest) ProtoMessage() {}
 
 func (x *Remove_TimestampRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[44]
+	mi := &file_v1_payload_payload_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2487,7 +2620,7 @@ type Remove_Timestamp struct {
 
 func (x *Remove_Timestamp) Reset() {
 	*x = Remove_Timestamp{}
-	mi := &file_v1_payload_payload_proto_msgTypes[45]
+	mi := &file_v1_payload_payload_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2499,7 +2632,7 @@ func (x *Remove_Timestamp) String() string {
 func (*Remove_Timestamp) ProtoMessage() {}
 
 func (x *Remove_Timestamp) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[45]
+	mi := &file_v1_payload_payload_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2542,7 +2675,7 @@ type Remove_Config struct {
 
 func (x *Remove_Config) Reset() {
 	*x = Remove_Config{}
-	mi := &file_v1_payload_payload_proto_msgTypes[46]
+	mi := &file_v1_payload_payload_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2554,7 +2687,7 @@ func (x *Remove_Config) String() string {
 func (*Remove_Config) ProtoMessage() {}
 
 func (x *Remove_Config) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[46]
+	mi := &file_v1_payload_payload_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2592,7 +2725,7 @@ type Flush_Request struct {
 
 func (x *Flush_Request) Reset() {
 	*x = Flush_Request{}
-	mi := &file_v1_payload_payload_proto_msgTypes[47]
+	mi := &file_v1_payload_payload_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2604,7 +2737,7 @@ func (x *Flush_Request) String() string {
 func (*Flush_Request) ProtoMessage() {}
 
 func (x *Flush_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[47]
+	mi := &file_v1_payload_payload_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2633,7 +2766,7 @@ type Object_VectorRequest struct {
 
 func (x *Object_VectorRequest) Reset() {
 	*x = Object_VectorRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[48]
+	mi := &file_v1_payload_payload_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2645,7 +2778,7 @@ func (x *Object_VectorRequest) String() string {
 func (*Object_VectorRequest) ProtoMessage() {}
 
 func (x *Object_VectorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[48]
+	mi := &file_v1_payload_payload_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2688,7 +2821,7 @@ type Object_Distance struct {
 
 func (x *Object_Distance) Reset() {
 	*x = Object_Distance{}
-	mi := &file_v1_payload_payload_proto_msgTypes[49]
+	mi := &file_v1_payload_payload_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2700,7 +2833,7 @@ func (x *Object_Distance) String() string {
 func (*Object_Distance) ProtoMessage() {}
 
 func (x *Object_Distance) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[49]
+	mi := &file_v1_payload_payload_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2744,7 +2877,7 @@ type Object_StreamDistance struct {
 
 func (x *Object_StreamDistance) Reset() {
 	*x = Object_StreamDistance{}
-	mi := &file_v1_payload_payload_proto_msgTypes[50]
+	mi := &file_v1_payload_payload_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2756,7 +2889,7 @@ func (x *Object_StreamDistance) String() string {
 func (*Object_StreamDistance) ProtoMessage() {}
 
 func (x *Object_StreamDistance) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[50]
+	mi := &file_v1_payload_payload_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2825,7 +2958,7 @@ type Object_ID struct {
 
 func (x *Object_ID) Reset() {
 	*x = Object_ID{}
-	mi := &file_v1_payload_payload_proto_msgTypes[51]
+	mi := &file_v1_payload_payload_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2837,7 +2970,7 @@ func (x *Object_ID) String() string {
 func (*Object_ID) ProtoMessage() {}
 
 func (x *Object_ID) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[51]
+	mi := &file_v1_payload_payload_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2870,7 +3003,7 @@ type Object_IDs struct {
 
 func (x *Object_IDs) Reset() {
 	*x = Object_IDs{}
-	mi := &file_v1_payload_payload_proto_msgTypes[52]
+	mi := &file_v1_payload_payload_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2882,7 +3015,7 @@ func (x *Object_IDs) String() string {
 func (*Object_IDs) ProtoMessage() {}
 
 func (x *Object_IDs) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[52]
+	mi := &file_v1_payload_payload_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2909,18 +3042,24 @@ func (x *Object_IDs) GetIds() []string {
 type Object_Vector struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The vector ID.
-	Id string `                   protobuf:"bytes,1,opt,name=id,proto3"              json:"id,omitempty"`
-	// The vector.
-	Vector []float32 `                   protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`
+	Id string `                   protobuf:"bytes,1,opt,name=id,proto3"                                               json:"id,omitempty"`
+	// The vector. It is empty when the vector is sent in the data field.
+	Vector []float32 `                   protobuf:"fixed32,2,rep,packed,name=vector,proto3"                                  json:"vector,omitempty"`
 	// timestamp represents when this vector inserted.
-	Timestamp     int64 `                   protobuf:"varint,3,opt,name=timestamp,proto3"      json:"timestamp,omitempty"`
+	Timestamp int64 `                   protobuf:"varint,3,opt,name=timestamp,proto3"                                       json:"timestamp,omitempty"`
+	// The encoding of the vector.
+	Encoding Object_Vector_Encoding `                   protobuf:"varint,4,opt,name=encoding,proto3,enum=payload.v1.Object_Vector_Encoding" json:"encoding,omitempty"`
+	// The encoded vector, used unless the encoding is FLOAT32.
+	Data []byte `                   protobuf:"bytes,5,opt,name=data,proto3"                                             json:"data,omitempty"`
+	// The scale of the int8 values, used only by the INT8 encoding.
+	Scale         float32 `                   protobuf:"fixed32,6,opt,name=scale,proto3"                                          json:"scale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Object_Vector) Reset() {
 	*x = Object_Vector{}
-	mi := &file_v1_payload_payload_proto_msgTypes[53]
+	mi := &file_v1_payload_payload_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2932,7 +3071,7 @@ func (x *Object_Vector) String() string {
 func (*Object_Vector) ProtoMessage() {}
 
 func (x *Object_Vector) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[53]
+	mi := &file_v1_payload_payload_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2969,18 +3108,39 @@ func (x *Object_Vector) GetTimestamp() int64 {
 	return 0
 }
 
-// Represent a request to fetch vector meta data.
-type Object_TimestampRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The vector ID to be fetched.
-	Id            *Object_ID `                   protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Object_Vector) GetEncoding() Object_Vector_Encoding {
+	if x != nil {
+		return x.Encoding
+	}
+	return Object_Vector_FLOAT32
 }
 
-func (x *Object_TimestampRequest) Reset() {
-	*x = Object_TimestampRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[54]
+func (x *Object_Vector) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Object_Vector) GetScale() float32 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+// Represent a request to fetch vector meta data.
+type Object_TimestampRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The vector ID to be fetched.
+	Id            *Object_ID `                   protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Object_TimestampRequest) Reset() {
+	*x = Object_TimestampRequest{}
+	mi := &file_v1_payload_payload_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2992,7 +3152,7 @@ func (x *Object_TimestampRequest) String() string {
 func (*Object_TimestampRequest) ProtoMessage() {}
 
 func (x *Object_TimestampRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[54]
+	mi := &file_v1_payload_payload_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3028,7 +3188,7 @@ type Object_Timestamp struct {
 
 func (x *Object_Timestamp) Reset() {
 	*x = Object_Timestamp{}
-	mi := &file_v1_payload_payload_proto_msgTypes[55]
+	mi := &file_v1_payload_payload_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3040,7 +3200,7 @@ func (x *Object_Timestamp) String() string {
 func (*Object_Timestamp) ProtoMessage() {}
 
 func (x *Object_Timestamp) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[55]
+	mi := &file_v1_payload_payload_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3080,7 +3240,7 @@ type Object_Vectors struct {
 
 func (x *Object_Vectors) Reset() {
 	*x = Object_Vectors{}
-	mi := &file_v1_payload_payload_proto_msgTypes[56]
+	mi := &file_v1_payload_payload_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3092,7 +3252,7 @@ func (x *Object_Vectors) String() string {
 func (*Object_Vectors) ProtoMessage() {}
 
 func (x *Object_Vectors) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[56]
+	mi := &file_v1_payload_payload_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3129,7 +3289,7 @@ type Object_StreamVector struct {
 
 func (x *Object_StreamVector) Reset() {
 	*x = Object_StreamVector{}
-	mi := &file_v1_payload_payload_proto_msgTypes[57]
+	mi := &file_v1_payload_payload_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3141,7 +3301,7 @@ func (x *Object_StreamVector) String() string {
 func (*Object_StreamVector) ProtoMessage() {}
 
 func (x *Object_StreamVector) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[57]
+	mi := &file_v1_payload_payload_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3213,7 +3373,7 @@ type Object_ReshapeVector struct {
 
 func (x *Object_ReshapeVector) Reset() {
 	*x = Object_ReshapeVector{}
-	mi := &file_v1_payload_payload_proto_msgTypes[58]
+	mi := &file_v1_payload_payload_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3225,7 +3385,7 @@ func (x *Object_ReshapeVector) String() string {
 func (*Object_ReshapeVector) ProtoMessage() {}
 
 func (x *Object_ReshapeVector) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[58]
+	mi := &file_v1_payload_payload_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3268,7 +3428,7 @@ type Object_Blob struct {
 
 func (x *Object_Blob) Reset() {
 	*x = Object_Blob{}
-	mi := &file_v1_payload_payload_proto_msgTypes[59]
+	mi := &file_v1_payload_payload_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3280,7 +3440,7 @@ func (x *Object_Blob) String() string {
 func (*Object_Blob) ProtoMessage() {}
 
 func (x *Object_Blob) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[59]
+	mi := &file_v1_payload_payload_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3324,7 +3484,7 @@ type Object_StreamBlob struct {
 
 func (x *Object_StreamBlob) Reset() {
 	*x = Object_StreamBlob{}
-	mi := &file_v1_payload_payload_proto_msgTypes[60]
+	mi := &file_v1_payload_payload_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3336,7 +3496,7 @@ func (x *Object_StreamBlob) String() string {
 func (*Object_StreamBlob) ProtoMessage() {}
 
 func (x *Object_StreamBlob) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[60]
+	mi := &file_v1_payload_payload_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3410,7 +3570,7 @@ type Object_Location struct {
 
 func (x *Object_Location) Reset() {
 	*x = Object_Location{}
-	mi := &file_v1_payload_payload_proto_msgTypes[61]
+	mi := &file_v1_payload_payload_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3422,7 +3582,7 @@ func (x *Object_Location) String() string {
 func (*Object_Location) ProtoMessage() {}
 
 func (x *Object_Location) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[61]
+	mi := &file_v1_payload_payload_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3473,7 +3633,7 @@ type Object_StreamLocation struct {
 
 func (x *Object_StreamLocation) Reset() {
 	*x = Object_StreamLocation{}
-	mi := &file_v1_payload_payload_proto_msgTypes[62]
+	mi := &file_v1_payload_payload_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3485,7 +3645,7 @@ func (x *Object_StreamLocation) String() string {
 func (*Object_StreamLocation) ProtoMessage() {}
 
 func (x *Object_StreamLocation) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[62]
+	mi := &file_v1_payload_payload_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3554,7 +3714,7 @@ type Object_Locations struct {
 
 func (x *Object_Locations) Reset() {
 	*x = Object_Locations{}
-	mi := &file_v1_payload_payload_proto_msgTypes[63]
+	mi := &file_v1_payload_payload_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3566,7 +3726,7 @@ func (x *Object_Locations) String() string {
 func (*Object_Locations) ProtoMessage() {}
 
 func (x *Object_Locations) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[63]
+	mi := &file_v1_payload_payload_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3598,7 +3758,7 @@ type Object_List struct {
 
 func (x *Object_List) Reset() {
 	*x = Object_List{}
-	mi := &file_v1_payload_payload_proto_msgTypes[64]
+	mi := &file_v1_payload_payload_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3610,7 +3770,7 @@ func (x *Object_List) String() string {
 func (*Object_List) ProtoMessage() {}
 
 func (x *Object_List) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[64]
+	mi := &file_v1_payload_payload_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3634,7 +3794,7 @@ type Object_List_Request struct {
 
 func (x *Object_List_Request) Reset() {
 	*x = Object_List_Request{}
-	mi := &file_v1_payload_payload_proto_msgTypes[65]
+	mi := &file_v1_payload_payload_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3646,7 +3806,7 @@ func (x *Object_List_Request) String() string {
 func (*Object_List_Request) ProtoMessage() {}
 
 func (x *Object_List_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[65]
+	mi := &file_v1_payload_payload_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3675,7 +3835,7 @@ type Object_List_Response struct {
 
 func (x *Object_List_Response) Reset() {
 	*x = Object_List_Response{}
-	mi := &file_v1_payload_payload_proto_msgTypes[66]
+	mi := &file_v1_payload_payload_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3687,7 +3847,7 @@ func (x *Object_List_Response) String() string {
 func (*Object_List_Response) ProtoMessage() {}
 
 func (x *Object_List_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[66]
+	mi := &file_v1_payload_payload_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3757,7 +3917,7 @@ type Control_CreateIndexRequest struct {
 
 func (x *Control_CreateIndexRequest) Reset() {
 	*x = Control_CreateIndexRequest{}
-	mi := &file_v1_payload_payload_proto_msgTypes[67]
+	mi := &file_v1_payload_payload_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3769,7 +3929,7 @@ func (x *Control_CreateIndexRequest) String() string {
 func (*Control_CreateIndexRequest) ProtoMessage() {}
 
 func (x *Control_CreateIndexRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[67]
+	mi := &file_v1_payload_payload_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3807,7 +3967,7 @@ type Discoverer_Request struct {
 
 func (x *Discoverer_Request) Reset() {
 	*x = Discoverer_Request{}
-	mi := &file_v1_payload_payload_proto_msgTypes[68]
+	mi := &file_v1_payload_payload_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3819,7 +3979,7 @@ func (x *Discoverer_Request) String() string {
 func (*Discoverer_Request) ProtoMessage() {}
 
 func (x *Discoverer_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[68]
+	mi := &file_v1_payload_payload_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3865,7 +4025,7 @@ type Info_Index struct {
 
 func (x *Info_Index) Reset() {
 	*x = Info_Index{}
-	mi := &file_v1_payload_payload_proto_msgTypes[69]
+	mi := &file_v1_payload_payload_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3877,7 +4037,7 @@ func (x *Info_Index) String() string {
 func (*Info_Index) ProtoMessage() {}
 
 func (x *Info_Index) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[69]
+	mi := &file_v1_payload_payload_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3909,14 +4069,16 @@ type Info_Pod struct {
 	// The memory information of the pod.
 	Memory *Info_Memory `                   protobuf:"bytes,6,opt,name=memory,proto3"                json:"memory,omitempty"`
 	// The node information of the pod.
-	Node          *Info_Node `                   protobuf:"bytes,7,opt,name=node,proto3"                  json:"node,omitempty"`
+	Node *Info_Node `                   protobuf:"bytes,7,opt,name=node,proto3"                  json:"node,omitempty"`
+	// The topology of the node the pod runs on.
+	Topology      *Info_Topology `                   protobuf:"bytes,8,opt,name=topology,proto3"              json:"topology,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Info_Pod) Reset() {
 	*x = Info_Pod{}
-	mi := &file_v1_payload_payload_proto_msgTypes[70]
+	mi := &file_v1_payload_payload_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3928,7 +4090,7 @@ func (x *Info_Pod) String() string {
 func (*Info_Pod) ProtoMessage() {}
 
 func (x *Info_Pod) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[70]
+	mi := &file_v1_payload_payload_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3993,6 +4155,13 @@ func (x *Info_Pod) GetNode() *Info_Node {
 	return nil
 }
 
+func (x *Info_Pod) GetTopology() *Info_Topology {
+	if x != nil {
+		return x.Topology
+	}
+	return nil
+}
+
 // Represent the node information message.
 type Info_Node struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -4007,14 +4176,16 @@ type Info_Node struct {
 	// The memory information of the node.
 	Memory *Info_Memory `                   protobuf:"bytes,5,opt,name=memory,proto3"                          json:"memory,omitempty"`
 	// The pod information of the node.
-	Pods          *Info_Pods `                   protobuf:"bytes,6,opt,name=Pods,proto3"                            json:"Pods,omitempty"`
+	Pods *Info_Pods `                   protobuf:"bytes,6,opt,name=Pods,proto3"                            json:"Pods,omitempty"`
+	// The topology of the node.
+	Topology      *Info_Topology `                   protobuf:"bytes,7,opt,name=topology,proto3"                        json:"topology,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Info_Node) Reset() {
 	*x = Info_Node{}
-	mi := &file_v1_payload_payload_proto_msgTypes[71]
+	mi := &file_v1_payload_payload_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4026,7 +4197,7 @@ func (x *Info_Node) String() string {
 func (*Info_Node) ProtoMessage() {}
 
 func (x *Info_Node) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[71]
+	mi := &file_v1_payload_payload_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4084,6 +4255,77 @@ func (x *Info_Node) GetPods() *Info_Pods {
 	return nil
 }
 
+func (x *Info_Node) GetTopology() *Info_Topology {
+	if x != nil {
+		return x.Topology
+	}
+	return nil
+}
+
+// Represent the failure domains of a node.
+type Info_Topology struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The availability zone of the node.
+	Zone string `                   protobuf:"bytes,1,opt,name=zone,proto3"     json:"zone,omitempty"`
+	// The rack of the node.
+	Rack string `                   protobuf:"bytes,2,opt,name=rack,proto3"     json:"rack,omitempty"`
+	// The hostname of the node.
+	Hostname      string `                   protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Info_Topology) Reset() {
+	*x = Info_Topology{}
+	mi := &file_v1_payload_payload_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Info_Topology) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Info_Topology) ProtoMessage() {}
+
+func (x *Info_Topology) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_payload_payload_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Info_Topology.ProtoReflect.Descriptor instead.
+func (*Info_Topology) Descriptor() ([]byte, []int) {
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 3}
+}
+
+func (x *Info_Topology) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *Info_Topology) GetRack() string {
+	if x != nil {
+		return x.Rack
+	}
+	return ""
+}
+
+func (x *Info_Topology) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
 // Represent the service information message.
 type Info_Service struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -4105,7 +4347,7 @@ type Info_Service struct {
 
 func (x *Info_Service) Reset() {
 	*x = Info_Service{}
-	mi := &file_v1_payload_payload_proto_msgTypes[72]
+	mi := &file_v1_payload_payload_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4117,7 +4359,7 @@ func (x *Info_Service) String() string {
 func (*Info_Service) ProtoMessage() {}
 
 func (x *Info_Service) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[72]
+	mi := &file_v1_payload_payload_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4130,7 +4372,7 @@ func (x *Info_Service) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Service.ProtoReflect.Descriptor instead.
 func (*Info_Service) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 3}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 4}
 }
 
 func (x *Info_Service) GetName() string {
@@ -4188,7 +4430,7 @@ type Info_ServicePort struct {
 
 func (x *Info_ServicePort) Reset() {
 	*x = Info_ServicePort{}
-	mi := &file_v1_payload_payload_proto_msgTypes[73]
+	mi := &file_v1_payload_payload_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4200,7 +4442,7 @@ func (x *Info_ServicePort) String() string {
 func (*Info_ServicePort) ProtoMessage() {}
 
 func (x *Info_ServicePort) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[73]
+	mi := &file_v1_payload_payload_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4213,7 +4455,7 @@ func (x *Info_ServicePort) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_ServicePort.ProtoReflect.Descriptor instead.
 func (*Info_ServicePort) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 4}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 5}
 }
 
 func (x *Info_ServicePort) GetName() string {
@@ -4240,7 +4482,7 @@ type Info_Labels struct {
 
 func (x *Info_Labels) Reset() {
 	*x = Info_Labels{}
-	mi := &file_v1_payload_payload_proto_msgTypes[74]
+	mi := &file_v1_payload_payload_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4252,7 +4494,7 @@ func (x *Info_Labels) String() string {
 func (*Info_Labels) ProtoMessage() {}
 
 func (x *Info_Labels) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[74]
+	mi := &file_v1_payload_payload_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4265,7 +4507,7 @@ func (x *Info_Labels) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Labels.ProtoReflect.Descriptor instead.
 func (*Info_Labels) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 5}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 6}
 }
 
 func (x *Info_Labels) GetLabels() map[string]string {
@@ -4285,7 +4527,7 @@ type Info_Annotations struct {
 
 func (x *Info_Annotations) Reset() {
 	*x = Info_Annotations{}
-	mi := &file_v1_payload_payload_proto_msgTypes[75]
+	mi := &file_v1_payload_payload_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4297,7 +4539,7 @@ func (x *Info_Annotations) String() string {
 func (*Info_Annotations) ProtoMessage() {}
 
 func (x *Info_Annotations) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[75]
+	mi := &file_v1_payload_payload_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4310,7 +4552,7 @@ func (x *Info_Annotations) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Annotations.ProtoReflect.Descriptor instead.
 func (*Info_Annotations) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 6}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 7}
 }
 
 func (x *Info_Annotations) GetAnnotations() map[string]string {
@@ -4335,7 +4577,7 @@ type Info_CPU struct {
 
 func (x *Info_CPU) Reset() {
 	*x = Info_CPU{}
-	mi := &file_v1_payload_payload_proto_msgTypes[76]
+	mi := &file_v1_payload_payload_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4347,7 +4589,7 @@ func (x *Info_CPU) String() string {
 func (*Info_CPU) ProtoMessage() {}
 
 func (x *Info_CPU) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[76]
+	mi := &file_v1_payload_payload_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4360,7 +4602,7 @@ func (x *Info_CPU) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_CPU.ProtoReflect.Descriptor instead.
 func (*Info_CPU) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 7}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 8}
 }
 
 func (x *Info_CPU) GetLimit() float64 {
@@ -4399,7 +4641,7 @@ type Info_Memory struct {
 
 func (x *Info_Memory) Reset() {
 	*x = Info_Memory{}
-	mi := &file_v1_payload_payload_proto_msgTypes[77]
+	mi := &file_v1_payload_payload_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4411,7 +4653,7 @@ func (x *Info_Memory) String() string {
 func (*Info_Memory) ProtoMessage() {}
 
 func (x *Info_Memory) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[77]
+	mi := &file_v1_payload_payload_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4424,7 +4666,7 @@ func (x *Info_Memory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Memory.ProtoReflect.Descriptor instead.
 func (*Info_Memory) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 8}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 9}
 }
 
 func (x *Info_Memory) GetLimit() float64 {
@@ -4459,7 +4701,7 @@ type Info_Pods struct {
 
 func (x *Info_Pods) Reset() {
 	*x = Info_Pods{}
-	mi := &file_v1_payload_payload_proto_msgTypes[78]
+	mi := &file_v1_payload_payload_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4471,7 +4713,7 @@ func (x *Info_Pods) String() string {
 func (*Info_Pods) ProtoMessage() {}
 
 func (x *Info_Pods) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[78]
+	mi := &file_v1_payload_payload_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4484,7 +4726,7 @@ func (x *Info_Pods) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Pods.ProtoReflect.Descriptor instead.
 func (*Info_Pods) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 9}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 10}
 }
 
 func (x *Info_Pods) GetPods() []*Info_Pod {
@@ -4505,7 +4747,7 @@ type Info_Nodes struct {
 
 func (x *Info_Nodes) Reset() {
 	*x = Info_Nodes{}
-	mi := &file_v1_payload_payload_proto_msgTypes[79]
+	mi := &file_v1_payload_payload_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4517,7 +4759,7 @@ func (x *Info_Nodes) String() string {
 func (*Info_Nodes) ProtoMessage() {}
 
 func (x *Info_Nodes) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[79]
+	mi := &file_v1_payload_payload_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4530,7 +4772,7 @@ func (x *Info_Nodes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Nodes.ProtoReflect.Descriptor instead.
 func (*Info_Nodes) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 10}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 11}
 }
 
 func (x *Info_Nodes) GetNodes() []*Info_Node {
@@ -4551,7 +4793,7 @@ type Info_Services struct {
 
 func (x *Info_Services) Reset() {
 	*x = Info_Services{}
-	mi := &file_v1_payload_payload_proto_msgTypes[80]
+	mi := &file_v1_payload_payload_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4563,7 +4805,7 @@ func (x *Info_Services) String() string {
 func (*Info_Services) ProtoMessage() {}
 
 func (x *Info_Services) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[80]
+	mi := &file_v1_payload_payload_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4576,7 +4818,7 @@ func (x *Info_Services) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Services.ProtoReflect.Descriptor instead.
 func (*Info_Services) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 11}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 12}
 }
 
 func (x *Info_Services) GetServices() []*Info_Service {
@@ -4596,7 +4838,7 @@ type Info_IPs struct {
 
 func (x *Info_IPs) Reset() {
 	*x = Info_IPs{}
-	mi := &file_v1_payload_payload_proto_msgTypes[81]
+	mi := &file_v1_payload_payload_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4608,7 +4850,7 @@ func (x *Info_IPs) String() string {
 func (*Info_IPs) ProtoMessage() {}
 
 func (x *Info_IPs) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[81]
+	mi := &file_v1_payload_payload_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4621,7 +4863,7 @@ func (x *Info_IPs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_IPs.ProtoReflect.Descriptor instead.
 func (*Info_IPs) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 12}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 13}
 }
 
 func (x *Info_IPs) GetIp() []string {
@@ -4648,7 +4890,7 @@ type Info_Index_Count struct {
 
 func (x *Info_Index_Count) Reset() {
 	*x = Info_Index_Count{}
-	mi := &file_v1_payload_payload_proto_msgTypes[82]
+	mi := &file_v1_payload_payload_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4660,7 +4902,7 @@ func (x *Info_Index_Count) String() string {
 func (*Info_Index_Count) ProtoMessage() {}
 
 func (x *Info_Index_Count) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[82]
+	mi := &file_v1_payload_payload_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4719,7 +4961,7 @@ type Info_Index_Detail struct {
 
 func (x *Info_Index_Detail) Reset() {
 	*x = Info_Index_Detail{}
-	mi := &file_v1_payload_payload_proto_msgTypes[83]
+	mi := &file_v1_payload_payload_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4731,7 +4973,7 @@ func (x *Info_Index_Detail) String() string {
 func (*Info_Index_Detail) ProtoMessage() {}
 
 func (x *Info_Index_Detail) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[83]
+	mi := &file_v1_payload_payload_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4768,6 +5010,70 @@ func (x *Info_Index_Detail) GetLiveAgents() uint32 {
 	return 0
 }
 
+// Represent the index operation schedule of the index manager.
+type Info_Index_Schedule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The agents running an index operation.
+	Running []*Info_Index_Schedule_Entry `                   protobuf:"bytes,1,rep,name=running,proto3"  json:"running,omitempty"`
+	// The agents waiting for an index operation in the scheduled order.
+	Queue []*Info_Index_Schedule_Entry `                   protobuf:"bytes,2,rep,name=queue,proto3"    json:"queue,omitempty"`
+	// index replica of vald cluster, the replicas of a placement except one are kept out of indexing when it is more than one
+	Replica       uint32 `                   protobuf:"varint,3,opt,name=replica,proto3" json:"replica,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Info_Index_Schedule) Reset() {
+	*x = Info_Index_Schedule{}
+	mi := &file_v1_payload_payload_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Info_Index_Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Info_Index_Schedule) ProtoMessage() {}
+
+func (x *Info_Index_Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_payload_payload_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Info_Index_Schedule.ProtoReflect.Descriptor instead.
+func (*Info_Index_Schedule) Descriptor() ([]byte, []int) {
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 2}
+}
+
+func (x *Info_Index_Schedule) GetRunning() []*Info_Index_Schedule_Entry {
+	if x != nil {
+		return x.Running
+	}
+	return nil
+}
+
+func (x *Info_Index_Schedule) GetQueue() []*Info_Index_Schedule_Entry {
+	if x != nil {
+		return x.Queue
+	}
+	return nil
+}
+
+func (x *Info_Index_Schedule) GetReplica() uint32 {
+	if x != nil {
+		return x.Replica
+	}
+	return 0
+}
+
 // Represent the UUID message.
 type Info_Index_UUID struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -4777,7 +5083,7 @@ type Info_Index_UUID struct {
 
 func (x *Info_Index_UUID) Reset() {
 	*x = Info_Index_UUID{}
-	mi := &file_v1_payload_payload_proto_msgTypes[84]
+	mi := &file_v1_payload_payload_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4789,7 +5095,7 @@ func (x *Info_Index_UUID) String() string {
 func (*Info_Index_UUID) ProtoMessage() {}
 
 func (x *Info_Index_UUID) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[84]
+	mi := &file_v1_payload_payload_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4802,7 +5108,7 @@ func (x *Info_Index_UUID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Index_UUID.ProtoReflect.Descriptor instead.
 func (*Info_Index_UUID) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 2}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 3}
 }
 
 // Represents index Statistics
@@ -4841,13 +5147,16 @@ type Info_Index_Statistics struct {
 	IndegreeCount                    []int64                `                   protobuf:"varint,31,rep,packed,name=indegree_count,json=indegreeCount,proto3"                                   json:"indegree_count,omitempty"`
 	OutdegreeHistogram               []uint64               `                   protobuf:"varint,32,rep,packed,name=outdegree_histogram,json=outdegreeHistogram,proto3"                         json:"outdegree_histogram,omitempty"`
 	IndegreeHistogram                []uint64               `                   protobuf:"varint,33,rep,packed,name=indegree_histogram,json=indegreeHistogram,proto3"                           json:"indegree_histogram,omitempty"`
+	QuantizationSavedMemoryBytes     uint64                 `                   protobuf:"varint,34,opt,name=quantization_saved_memory_bytes,json=quantizationSavedMemoryBytes,proto3"          json:"quantization_saved_memory_bytes,omitempty"`
+	QuantizationRecall               float64                `                   protobuf:"fixed64,35,opt,name=quantization_recall,json=quantizationRecall,proto3"                               json:"quantization_recall,omitempty"`
+	QuantizationRecallSamples        uint64                 `                   protobuf:"varint,36,opt,name=quantization_recall_samples,json=quantizationRecallSamples,proto3"                 json:"quantization_recall_samples,omitempty"`
 	unknownFields                    protoimpl.UnknownFields
 	sizeCache                        protoimpl.SizeCache
 }
 
 func (x *Info_Index_Statistics) Reset() {
 	*x = Info_Index_Statistics{}
-	mi := &file_v1_payload_payload_proto_msgTypes[85]
+	mi := &file_v1_payload_payload_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4859,7 +5168,7 @@ func (x *Info_Index_Statistics) String() string {
 func (*Info_Index_Statistics) ProtoMessage() {}
 
 func (x *Info_Index_Statistics) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[85]
+	mi := &file_v1_payload_payload_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4872,7 +5181,7 @@ func (x *Info_Index_Statistics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Index_Statistics.ProtoReflect.Descriptor instead.
 func (*Info_Index_Statistics) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 3}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 4}
 }
 
 func (x *Info_Index_Statistics) GetValid() bool {
@@ -5106,6 +5415,27 @@ func (x *Info_Index_Statistics) GetIndegreeHistogram() []uint64 {
 	return nil
 }
 
+func (x *Info_Index_Statistics) GetQuantizationSavedMemoryBytes() uint64 {
+	if x != nil {
+		return x.QuantizationSavedMemoryBytes
+	}
+	return 0
+}
+
+func (x *Info_Index_Statistics) GetQuantizationRecall() float64 {
+	if x != nil {
+		return x.QuantizationRecall
+	}
+	return 0
+}
+
+func (x *Info_Index_Statistics) GetQuantizationRecallSamples() uint64 {
+	if x != nil {
+		return x.QuantizationRecallSamples
+	}
+	return 0
+}
+
 // Represents index Statistics for each Agents
 type Info_Index_StatisticsDetail struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -5117,7 +5447,7 @@ type Info_Index_StatisticsDetail struct {
 
 func (x *Info_Index_StatisticsDetail) Reset() {
 	*x = Info_Index_StatisticsDetail{}
-	mi := &file_v1_payload_payload_proto_msgTypes[86]
+	mi := &file_v1_payload_payload_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5129,7 +5459,7 @@ func (x *Info_Index_StatisticsDetail) String() string {
 func (*Info_Index_StatisticsDetail) ProtoMessage() {}
 
 func (x *Info_Index_StatisticsDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[86]
+	mi := &file_v1_payload_payload_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5142,7 +5472,7 @@ func (x *Info_Index_StatisticsDetail) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Index_StatisticsDetail.ProtoReflect.Descriptor instead.
 func (*Info_Index_StatisticsDetail) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 4}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 5}
 }
 
 func (x *Info_Index_StatisticsDetail) GetDetails() map[string]*Info_Index_Statistics {
@@ -5189,13 +5519,16 @@ type Info_Index_Property struct {
 	BuildTimeLimit                float32                `                   protobuf:"fixed32,32,opt,name=build_time_limit,json=buildTimeLimit,proto3"                                 json:"build_time_limit,omitempty"`
 	OutgoingEdge                  int32                  `                   protobuf:"varint,33,opt,name=outgoing_edge,json=outgoingEdge,proto3"                                       json:"outgoing_edge,omitempty"`
 	IncomingEdge                  int32                  `                   protobuf:"varint,34,opt,name=incoming_edge,json=incomingEdge,proto3"                                       json:"incoming_edge,omitempty"`
+	QuantizationType              string                 `                   protobuf:"bytes,35,opt,name=quantization_type,json=quantizationType,proto3"                                json:"quantization_type,omitempty"`
+	QuantizationRerankFactor      int32                  `                   protobuf:"varint,36,opt,name=quantization_rerank_factor,json=quantizationRerankFactor,proto3"              json:"quantization_rerank_factor,omitempty"`
+	QuantizedObjectSize           int32                  `                   protobuf:"varint,37,opt,name=quantized_object_size,json=quantizedObjectSize,proto3"                        json:"quantized_object_size,omitempty"`
 	unknownFields                 protoimpl.UnknownFields
 	sizeCache                     protoimpl.SizeCache
 }
 
 func (x *Info_Index_Property) Reset() {
 	*x = Info_Index_Property{}
-	mi := &file_v1_payload_payload_proto_msgTypes[87]
+	mi := &file_v1_payload_payload_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5207,7 +5540,7 @@ func (x *Info_Index_Property) String() string {
 func (*Info_Index_Property) ProtoMessage() {}
 
 func (x *Info_Index_Property) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[87]
+	mi := &file_v1_payload_payload_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5220,7 +5553,7 @@ func (x *Info_Index_Property) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Index_Property.ProtoReflect.Descriptor instead.
 func (*Info_Index_Property) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 5}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 6}
 }
 
 func (x *Info_Index_Property) GetDimension() int32 {
@@ -5461,6 +5794,27 @@ func (x *Info_Index_Property) GetIncomingEdge() int32 {
 	return 0
 }
 
+func (x *Info_Index_Property) GetQuantizationType() string {
+	if x != nil {
+		return x.QuantizationType
+	}
+	return ""
+}
+
+func (x *Info_Index_Property) GetQuantizationRerankFactor() int32 {
+	if x != nil {
+		return x.QuantizationRerankFactor
+	}
+	return 0
+}
+
+func (x *Info_Index_Property) GetQuantizedObjectSize() int32 {
+	if x != nil {
+		return x.QuantizedObjectSize
+	}
+	return 0
+}
+
 // Represents index Properties for each Agents
 type Info_Index_PropertyDetail struct {
 	state         protoimpl.MessageState          `protogen:"open.v1"`
@@ -5471,7 +5825,7 @@ type Info_Index_PropertyDetail struct {
 
 func (x *Info_Index_PropertyDetail) Reset() {
 	*x = Info_Index_PropertyDetail{}
-	mi := &file_v1_payload_payload_proto_msgTypes[88]
+	mi := &file_v1_payload_payload_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5483,7 +5837,7 @@ func (x *Info_Index_PropertyDetail) String() string {
 func (*Info_Index_PropertyDetail) ProtoMessage() {}
 
 func (x *Info_Index_PropertyDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[88]
+	mi := &file_v1_payload_payload_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5496,7 +5850,7 @@ func (x *Info_Index_PropertyDetail) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Index_PropertyDetail.ProtoReflect.Descriptor instead.
 func (*Info_Index_PropertyDetail) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 6}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 7}
 }
 
 func (x *Info_Index_PropertyDetail) GetDetails() map[string]*Info_Index_Property {
@@ -5506,6 +5860,79 @@ func (x *Info_Index_PropertyDetail) GetDetails() map[string]*Info_Index_Property
 	return nil
 }
 
+// Represent an agent running or waiting for an index operation.
+type Info_Index_Schedule_Entry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The agent address.
+	Addr string `                   protobuf:"bytes,1,opt,name=addr,proto3"         json:"addr,omitempty"`
+	// The placement group of the agent.
+	Placement string `                   protobuf:"bytes,2,opt,name=placement,proto3"    json:"placement,omitempty"`
+	// The index operation, CreateIndex or SaveIndex.
+	Operation string `                   protobuf:"bytes,3,opt,name=operation,proto3"    json:"operation,omitempty"`
+	// The uncommitted index count of the agent when it was scheduled.
+	Uncommitted   uint32 `                   protobuf:"varint,4,opt,name=uncommitted,proto3" json:"uncommitted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Info_Index_Schedule_Entry) Reset() {
+	*x = Info_Index_Schedule_Entry{}
+	mi := &file_v1_payload_payload_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Info_Index_Schedule_Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Info_Index_Schedule_Entry) ProtoMessage() {}
+
+func (x *Info_Index_Schedule_Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_payload_payload_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Info_Index_Schedule_Entry.ProtoReflect.Descriptor instead.
+func (*Info_Index_Schedule_Entry) Descriptor() ([]byte, []int) {
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 2, 0}
+}
+
+func (x *Info_Index_Schedule_Entry) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *Info_Index_Schedule_Entry) GetPlacement() string {
+	if x != nil {
+		return x.Placement
+	}
+	return ""
+}
+
+func (x *Info_Index_Schedule_Entry) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *Info_Index_Schedule_Entry) GetUncommitted() uint32 {
+	if x != nil {
+		return x.Uncommitted
+	}
+	return 0
+}
+
 // The committed UUID.
 type Info_Index_UUID_Committed struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -5516,7 +5943,7 @@ type Info_Index_UUID_Committed struct {
 
 func (x *Info_Index_UUID_Committed) Reset() {
 	*x = Info_Index_UUID_Committed{}
-	mi := &file_v1_payload_payload_proto_msgTypes[90]
+	mi := &file_v1_payload_payload_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5528,7 +5955,7 @@ func (x *Info_Index_UUID_Committed) String() string {
 func (*Info_Index_UUID_Committed) ProtoMessage() {}
 
 func (x *Info_Index_UUID_Committed) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[90]
+	mi := &file_v1_payload_payload_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5541,7 +5968,7 @@ func (x *Info_Index_UUID_Committed) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Index_UUID_Committed.ProtoReflect.Descriptor instead.
 func (*Info_Index_UUID_Committed) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 2, 0}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 3, 0}
 }
 
 func (x *Info_Index_UUID_Committed) GetUuid() string {
@@ -5561,7 +5988,7 @@ type Info_Index_UUID_Uncommitted struct {
 
 func (x *Info_Index_UUID_Uncommitted) Reset() {
 	*x = Info_Index_UUID_Uncommitted{}
-	mi := &file_v1_payload_payload_proto_msgTypes[91]
+	mi := &file_v1_payload_payload_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5573,7 +6000,7 @@ func (x *Info_Index_UUID_Uncommitted) String() string {
 func (*Info_Index_UUID_Uncommitted) ProtoMessage() {}
 
 func (x *Info_Index_UUID_Uncommitted) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[91]
+	mi := &file_v1_payload_payload_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5586,7 +6013,7 @@ func (x *Info_Index_UUID_Uncommitted) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Info_Index_UUID_Uncommitted.ProtoReflect.Descriptor instead.
 func (*Info_Index_UUID_Uncommitted) Descriptor() ([]byte, []int) {
-	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 2, 1}
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{10, 0, 3, 1}
 }
 
 func (x *Info_Index_UUID_Uncommitted) GetUuid() string {
@@ -5609,7 +6036,7 @@ type Mirror_Target struct {
 
 func (x *Mirror_Target) Reset() {
 	*x = Mirror_Target{}
-	mi := &file_v1_payload_payload_proto_msgTypes[96]
+	mi := &file_v1_payload_payload_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5621,7 +6048,7 @@ func (x *Mirror_Target) String() string {
 func (*Mirror_Target) ProtoMessage() {}
 
 func (x *Mirror_Target) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[96]
+	mi := &file_v1_payload_payload_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5662,7 +6089,7 @@ type Mirror_Targets struct {
 
 func (x *Mirror_Targets) Reset() {
 	*x = Mirror_Targets{}
-	mi := &file_v1_payload_payload_proto_msgTypes[97]
+	mi := &file_v1_payload_payload_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5674,7 +6101,7 @@ func (x *Mirror_Targets) String() string {
 func (*Mirror_Targets) ProtoMessage() {}
 
 func (x *Mirror_Targets) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[97]
+	mi := &file_v1_payload_payload_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5706,7 +6133,7 @@ type Meta_Key struct {
 
 func (x *Meta_Key) Reset() {
 	*x = Meta_Key{}
-	mi := &file_v1_payload_payload_proto_msgTypes[98]
+	mi := &file_v1_payload_payload_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5718,7 +6145,7 @@ func (x *Meta_Key) String() string {
 func (*Meta_Key) ProtoMessage() {}
 
 func (x *Meta_Key) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[98]
+	mi := &file_v1_payload_payload_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5750,7 +6177,7 @@ type Meta_Value struct {
 
 func (x *Meta_Value) Reset() {
 	*x = Meta_Value{}
-	mi := &file_v1_payload_payload_proto_msgTypes[99]
+	mi := &file_v1_payload_payload_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5762,7 +6189,7 @@ func (x *Meta_Value) String() string {
 func (*Meta_Value) ProtoMessage() {}
 
 func (x *Meta_Value) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[99]
+	mi := &file_v1_payload_payload_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5795,7 +6222,7 @@ type Meta_KeyValue struct {
 
 func (x *Meta_KeyValue) Reset() {
 	*x = Meta_KeyValue{}
-	mi := &file_v1_payload_payload_proto_msgTypes[100]
+	mi := &file_v1_payload_payload_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5807,7 +6234,7 @@ func (x *Meta_KeyValue) String() string {
 func (*Meta_KeyValue) ProtoMessage() {}
 
 func (x *Meta_KeyValue) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_payload_payload_proto_msgTypes[100]
+	mi := &file_v1_payload_payload_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5837,12 +6264,177 @@ func (x *Meta_KeyValue) GetValue() *Meta_Value {
 	return nil
 }
 
+// Represent the collection definition.
+type Collection_Config struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The collection name.
+	Name string `                   protobuf:"bytes,1,opt,name=name,proto3"                            json:"name,omitempty"`
+	// The vector dimension of the collection.
+	Dimension uint32 `                   protobuf:"varint,2,opt,name=dimension,proto3"                      json:"dimension,omitempty"`
+	// The distance type of the collection.
+	DistanceType string `                   protobuf:"bytes,3,opt,name=distance_type,json=distanceType,proto3" json:"distance_type,omitempty"`
+	// The object type of the collection.
+	ObjectType    string `                   protobuf:"bytes,4,opt,name=object_type,json=objectType,proto3"     json:"object_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collection_Config) Reset() {
+	*x = Collection_Config{}
+	mi := &file_v1_payload_payload_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection_Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection_Config) ProtoMessage() {}
+
+func (x *Collection_Config) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_payload_payload_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection_Config.ProtoReflect.Descriptor instead.
+func (*Collection_Config) Descriptor() ([]byte, []int) {
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{13, 0}
+}
+
+func (x *Collection_Config) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Collection_Config) GetDimension() uint32 {
+	if x != nil {
+		return x.Dimension
+	}
+	return 0
+}
+
+func (x *Collection_Config) GetDistanceType() string {
+	if x != nil {
+		return x.DistanceType
+	}
+	return ""
+}
+
+func (x *Collection_Config) GetObjectType() string {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ""
+}
+
+// Represent the collection name.
+type Collection_Name struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The collection name.
+	Name          string `                   protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collection_Name) Reset() {
+	*x = Collection_Name{}
+	mi := &file_v1_payload_payload_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection_Name) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection_Name) ProtoMessage() {}
+
+func (x *Collection_Name) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_payload_payload_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection_Name.ProtoReflect.Descriptor instead.
+func (*Collection_Name) Descriptor() ([]byte, []int) {
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{13, 1}
+}
+
+func (x *Collection_Name) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Represent the multiple collection definitions.
+type Collection_List struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The collection definitions.
+	Collections   []*Collection_Config `                   protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collection_List) Reset() {
+	*x = Collection_List{}
+	mi := &file_v1_payload_payload_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection_List) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection_List) ProtoMessage() {}
+
+func (x *Collection_List) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_payload_payload_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection_List.ProtoReflect.Descriptor instead.
+func (*Collection_List) Descriptor() ([]byte, []int) {
+	return file_v1_payload_payload_proto_rawDescGZIP(), []int{13, 2}
+}
+
+func (x *Collection_List) GetCollections() []*Collection_Config {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
 var File_v1_payload_payload_proto protoreflect.FileDescriptor
 
 const file_v1_payload_payload_proto_rawDesc = "" +
 	"\n" +
 	"\x18v1/payload/payload.proto\x12\n" +
-	"payload.v1\x1a\x1bbuf/validate/validate.proto\x1a\x19google/protobuf/any.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x17google/rpc/status.proto\"\xb8\v\n" +
+	"payload.v1\x1a\x1bbuf/validate/validate.proto\x1a\x19google/protobuf/any.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x17google/rpc/status.proto\"\xe9\v\n" +
 	"\x06Search\x1a^\n" +
 	"\aRequest\x12 \n" +
 	"\x06vector\x18\x01 \x03(\x02B\b\xbaH\x05\x92\x01\x02\b\x02R\x06vector\x121\n" +
@@ -5861,7 +6453,7 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"vectorizer\x18\x03 \x01(\v2\x19.payload.v1.Filter.TargetR\n" +
 	"vectorizer\x1aR\n" +
 	"\x12MultiObjectRequest\x12<\n" +
-	"\brequests\x18\x01 \x03(\v2 .payload.v1.Search.ObjectRequestR\brequests\x1a\xdf\x03\n" +
+	"\brequests\x18\x01 \x03(\v2 .payload.v1.Search.ObjectRequestR\brequests\x1a\x90\x04\n" +
 	"\x06Config\x12\x1d\n" +
 	"\n" +
 	"request_id\x18\x01 \x01(\tR\trequestId\x12\x19\n" +
@@ -5875,7 +6467,8 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"\x15aggregation_algorithm\x18\t \x01(\x0e2'.payload.v1.Search.AggregationAlgorithmR\x14aggregationAlgorithm\x121\n" +
 	"\x05ratio\x18\n" +
 	" \x01(\v2\x1b.google.protobuf.FloatValueR\x05ratio\x12\x16\n" +
-	"\x06nprobe\x18\v \x01(\rR\x06nprobe\x1a`\n" +
+	"\x06nprobe\x18\v \x01(\rR\x06nprobe\x12/\n" +
+	"\x13include_uncommitted\x18\f \x01(\bR\x12includeUncommitted\x1a`\n" +
 	"\bResponse\x12\x1d\n" +
 	"\n" +
 	"request_id\x18\x01 \x01(\tR\trequestId\x125\n" +
@@ -5897,7 +6490,7 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
 	"\x04port\x18\x02 \x01(\rR\x04port\x1a=\n" +
 	"\x06Config\x123\n" +
-	"\atargets\x18\x01 \x03(\v2\x19.payload.v1.Filter.TargetR\atargets\"\xe5\x04\n" +
+	"\atargets\x18\x01 \x03(\v2\x19.payload.v1.Filter.TargetR\atargets\"\xf7\x04\n" +
 	"\x06Insert\x1ay\n" +
 	"\aRequest\x12;\n" +
 	"\x06vector\x18\x01 \x01(\v2\x19.payload.v1.Object.VectorB\b\xbaH\x05\x92\x01\x02\b\x02R\x06vector\x121\n" +
@@ -5911,11 +6504,12 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"vectorizer\x18\x03 \x01(\v2\x19.payload.v1.Filter.TargetR\n" +
 	"vectorizer\x1aR\n" +
 	"\x12MultiObjectRequest\x12<\n" +
-	"\brequests\x18\x01 \x03(\v2 .payload.v1.Insert.ObjectRequestR\brequests\x1a\x92\x01\n" +
+	"\brequests\x18\x01 \x03(\v2 .payload.v1.Insert.ObjectRequestR\brequests\x1a\xa4\x01\n" +
 	"\x06Config\x125\n" +
 	"\x17skip_strict_exist_check\x18\x01 \x01(\bR\x14skipStrictExistCheck\x123\n" +
 	"\afilters\x18\x02 \x01(\v2\x19.payload.v1.Filter.ConfigR\afilters\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"\xfe\x05\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x10\n" +
+	"\x03ttl\x18\x04 \x01(\x03R\x03ttl\"\x90\x06\n" +
 	"\x06Update\x1ay\n" +
 	"\aRequest\x12;\n" +
 	"\x06vector\x18\x01 \x01(\v2\x19.payload.v1.Object.VectorB\b\xbaH\x05\x92\x01\x02\b\x02R\x06vector\x121\n" +
@@ -5933,12 +6527,13 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"\x10TimestampRequest\x12\x17\n" +
 	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x14\n" +
-	"\x05force\x18\x03 \x01(\bR\x05force\x1a\xca\x01\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\x1a\xdc\x01\n" +
 	"\x06Config\x125\n" +
 	"\x17skip_strict_exist_check\x18\x01 \x01(\bR\x14skipStrictExistCheck\x123\n" +
 	"\afilters\x18\x02 \x01(\v2\x19.payload.v1.Filter.ConfigR\afilters\x12\x1c\n" +
 	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x126\n" +
-	"\x17disable_balanced_update\x18\x04 \x01(\bR\x15disableBalancedUpdate\"\x9d\x05\n" +
+	"\x17disable_balanced_update\x18\x04 \x01(\bR\x15disableBalancedUpdate\x12\x10\n" +
+	"\x03ttl\x18\x05 \x01(\x03R\x03ttl\"\xaf\x05\n" +
 	"\x06Upsert\x1ay\n" +
 	"\aRequest\x12;\n" +
 	"\x06vector\x18\x01 \x01(\v2\x19.payload.v1.Object.VectorB\b\xbaH\x05\x92\x01\x02\b\x02R\x06vector\x121\n" +
@@ -5952,12 +6547,13 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"vectorizer\x18\x03 \x01(\v2\x19.payload.v1.Filter.TargetR\n" +
 	"vectorizer\x1aR\n" +
 	"\x12MultiObjectRequest\x12<\n" +
-	"\brequests\x18\x01 \x03(\v2 .payload.v1.Upsert.ObjectRequestR\brequests\x1a\xca\x01\n" +
+	"\brequests\x18\x01 \x03(\v2 .payload.v1.Upsert.ObjectRequestR\brequests\x1a\xdc\x01\n" +
 	"\x06Config\x125\n" +
 	"\x17skip_strict_exist_check\x18\x01 \x01(\bR\x14skipStrictExistCheck\x123\n" +
 	"\afilters\x18\x02 \x01(\v2\x19.payload.v1.Filter.ConfigR\afilters\x12\x1c\n" +
 	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x126\n" +
-	"\x17disable_balanced_update\x18\x04 \x01(\bR\x15disableBalancedUpdate\"\x91\x04\n" +
+	"\x17disable_balanced_update\x18\x04 \x01(\bR\x15disableBalancedUpdate\x12\x10\n" +
+	"\x03ttl\x18\x05 \x01(\x03R\x03ttl\"\x91\x04\n" +
 	"\x06Remove\x1ac\n" +
 	"\aRequest\x12%\n" +
 	"\x02id\x18\x01 \x01(\v2\x15.payload.v1.Object.IDR\x02id\x121\n" +
@@ -5982,7 +6578,7 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"\x17skip_strict_exist_check\x18\x01 \x01(\bR\x14skipStrictExistCheck\x12\x1c\n" +
 	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"\x12\n" +
 	"\x05Flush\x1a\t\n" +
-	"\aRequest\"\xb1\v\n" +
+	"\aRequest\"\xda\f\n" +
 	"\x06Object\x1au\n" +
 	"\rVectorRequest\x12/\n" +
 	"\x02id\x18\x01 \x01(\v2\x15.payload.v1.Object.IDB\b\xbaH\x05\x92\x01\x02\b\x02R\x02id\x123\n" +
@@ -5997,11 +6593,19 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"\x02ID\x12\x17\n" +
 	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\x1a\x17\n" +
 	"\x03IDs\x12\x10\n" +
-	"\x03ids\x18\x01 \x03(\tR\x03ids\x1aa\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x1a\x89\x02\n" +
 	"\x06Vector\x12\x17\n" +
-	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\x12 \n" +
-	"\x06vector\x18\x02 \x03(\x02B\b\xbaH\x05\x92\x01\x02\b\x02R\x06vector\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x1aC\n" +
+	"\x02id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02id\x12#\n" +
+	"\x06vector\x18\x02 \x03(\x02B\v\xbaH\b\xd8\x01\x01\x92\x01\x02\b\x02R\x06vector\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12>\n" +
+	"\bencoding\x18\x04 \x01(\x0e2\".payload.v1.Object.Vector.EncodingR\bencoding\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12\x14\n" +
+	"\x05scale\x18\x06 \x01(\x02R\x05scale\"9\n" +
+	"\bEncoding\x12\v\n" +
+	"\aFLOAT32\x10\x00\x12\v\n" +
+	"\aFLOAT16\x10\x01\x12\b\n" +
+	"\x04INT8\x10\x02\x12\t\n" +
+	"\x05BYTES\x10\x03\x1aC\n" +
 	"\x10TimestampRequest\x12/\n" +
 	"\x02id\x18\x01 \x01(\v2\x15.payload.v1.Object.IDB\b\xbaH\x05\x92\x01\x02\b\x02R\x02id\x1aB\n" +
 	"\tTimestamp\x12\x17\n" +
@@ -6048,8 +6652,8 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"\aRequest\x12\x1b\n" +
 	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x12\x1c\n" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
-	"\x04node\x18\x03 \x01(\tR\x04node\"\xc2+\n" +
-	"\x04Info\x1a\x80 \n" +
+	"\x04node\x18\x03 \x01(\tR\x04node\"\xf71\n" +
+	"\x04Info\x1a\xf7$\n" +
 	"\x05Index\x1au\n" +
 	"\x05Count\x12\x16\n" +
 	"\x06stored\x18\x01 \x01(\rR\x06stored\x12 \n" +
@@ -6063,12 +6667,21 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"liveAgents\x1aW\n" +
 	"\vCountsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x122\n" +
-	"\x05value\x18\x02 \x01(\v2\x1c.payload.v1.Info.Index.CountR\x05value:\x028\x01\x1aJ\n" +
+	"\x05value\x18\x02 \x01(\v2\x1c.payload.v1.Info.Index.CountR\x05value:\x028\x01\x1a\x9d\x02\n" +
+	"\bSchedule\x12?\n" +
+	"\arunning\x18\x01 \x03(\v2%.payload.v1.Info.Index.Schedule.EntryR\arunning\x12;\n" +
+	"\x05queue\x18\x02 \x03(\v2%.payload.v1.Info.Index.Schedule.EntryR\x05queue\x12\x18\n" +
+	"\areplica\x18\x03 \x01(\rR\areplica\x1ay\n" +
+	"\x05Entry\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x12\x1c\n" +
+	"\tplacement\x18\x02 \x01(\tR\tplacement\x12\x1c\n" +
+	"\toperation\x18\x03 \x01(\tR\toperation\x12 \n" +
+	"\vuncommitted\x18\x04 \x01(\rR\vuncommitted\x1aJ\n" +
 	"\x04UUID\x1a\x1f\n" +
 	"\tCommitted\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x1a!\n" +
 	"\vUncommitted\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x1a\x9d\r\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x1a\xd5\x0e\n" +
 	"\n" +
 	"Statistics\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12'\n" +
@@ -6106,12 +6719,15 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"\rc99_outdegree\x18\x1e \x01(\x01R\fc99Outdegree\x12%\n" +
 	"\x0eindegree_count\x18\x1f \x03(\x03R\rindegreeCount\x12/\n" +
 	"\x13outdegree_histogram\x18  \x03(\x04R\x12outdegreeHistogram\x12-\n" +
-	"\x12indegree_histogram\x18! \x03(\x04R\x11indegreeHistogram\x1a\xc1\x01\n" +
+	"\x12indegree_histogram\x18! \x03(\x04R\x11indegreeHistogram\x12E\n" +
+	"\x1fquantization_saved_memory_bytes\x18\" \x01(\x04R\x1cquantizationSavedMemoryBytes\x12/\n" +
+	"\x13quantization_recall\x18# \x01(\x01R\x12quantizationRecall\x12>\n" +
+	"\x1bquantization_recall_samples\x18$ \x01(\x04R\x19quantizationRecallSamples\x1a\xc1\x01\n" +
 	"\x10StatisticsDetail\x12N\n" +
 	"\adetails\x18\x01 \x03(\v24.payload.v1.Info.Index.StatisticsDetail.DetailsEntryR\adetails\x1a]\n" +
 	"\fDetailsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x127\n" +
-	"\x05value\x18\x02 \x01(\v2!.payload.v1.Info.Index.StatisticsR\x05value:\x028\x01\x1a\xaf\f\n" +
+	"\x05value\x18\x02 \x01(\v2!.payload.v1.Info.Index.StatisticsR\x05value:\x028\x01\x1a\xce\r\n" +
 	"\bProperty\x12\x1c\n" +
 	"\tdimension\x18\x01 \x01(\x05R\tdimension\x12(\n" +
 	"\x10thread_pool_size\x18\x02 \x01(\x05R\x0ethreadPoolSize\x12\x1f\n" +
@@ -6151,12 +6767,15 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"\x16dynamic_edge_size_rate\x18\x1f \x01(\x05R\x13dynamicEdgeSizeRate\x12(\n" +
 	"\x10build_time_limit\x18  \x01(\x02R\x0ebuildTimeLimit\x12#\n" +
 	"\routgoing_edge\x18! \x01(\x05R\foutgoingEdge\x12#\n" +
-	"\rincoming_edge\x18\" \x01(\x05R\fincomingEdge\x1a\xbb\x01\n" +
+	"\rincoming_edge\x18\" \x01(\x05R\fincomingEdge\x12+\n" +
+	"\x11quantization_type\x18# \x01(\tR\x10quantizationType\x12<\n" +
+	"\x1aquantization_rerank_factor\x18$ \x01(\x05R\x18quantizationRerankFactor\x122\n" +
+	"\x15quantized_object_size\x18% \x01(\x05R\x13quantizedObjectSize\x1a\xbb\x01\n" +
 	"\x0ePropertyDetail\x12L\n" +
 	"\adetails\x18\x01 \x03(\v22.payload.v1.Info.Index.PropertyDetail.DetailsEntryR\adetails\x1a[\n" +
 	"\fDetailsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x125\n" +
-	"\x05value\x18\x02 \x01(\v2\x1f.payload.v1.Info.Index.PropertyR\x05value:\x028\x01\x1a\xef\x01\n" +
+	"\x05value\x18\x02 \x01(\v2\x1f.payload.v1.Info.Index.PropertyR\x05value:\x028\x01\x1a\xa6\x02\n" +
 	"\x03Pod\x12\x19\n" +
 	"\bapp_name\x18\x01 \x01(\tR\aappName\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
@@ -6164,14 +6783,20 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"\x02ip\x18\x04 \x01(\tB\a\xbaH\x04r\x02x\x01R\x02ip\x12&\n" +
 	"\x03cpu\x18\x05 \x01(\v2\x14.payload.v1.Info.CPUR\x03cpu\x12/\n" +
 	"\x06memory\x18\x06 \x01(\v2\x17.payload.v1.Info.MemoryR\x06memory\x12)\n" +
-	"\x04node\x18\a \x01(\v2\x15.payload.v1.Info.NodeR\x04node\x1a\xe8\x01\n" +
+	"\x04node\x18\a \x01(\v2\x15.payload.v1.Info.NodeR\x04node\x125\n" +
+	"\btopology\x18\b \x01(\v2\x19.payload.v1.Info.TopologyR\btopology\x1a\x9f\x02\n" +
 	"\x04Node\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
 	"\rinternal_addr\x18\x02 \x01(\tR\finternalAddr\x12#\n" +
 	"\rexternal_addr\x18\x03 \x01(\tR\fexternalAddr\x12&\n" +
 	"\x03cpu\x18\x04 \x01(\v2\x14.payload.v1.Info.CPUR\x03cpu\x12/\n" +
 	"\x06memory\x18\x05 \x01(\v2\x17.payload.v1.Info.MemoryR\x06memory\x12)\n" +
-	"\x04Pods\x18\x06 \x01(\v2\x15.payload.v1.Info.PodsR\x04Pods\x1a\x82\x02\n" +
+	"\x04Pods\x18\x06 \x01(\v2\x15.payload.v1.Info.PodsR\x04Pods\x125\n" +
+	"\btopology\x18\a \x01(\v2\x19.payload.v1.Info.TopologyR\btopology\x1aN\n" +
+	"\bTopology\x12\x12\n" +
+	"\x04zone\x18\x01 \x01(\tR\x04zone\x12\x12\n" +
+	"\x04rack\x18\x02 \x01(\tR\x04rack\x12\x1a\n" +
+	"\bhostname\x18\x03 \x01(\tR\bhostname\x1a\x82\x02\n" +
 	"\aService\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
 	"\n" +
@@ -6223,7 +6848,19 @@ const file_v1_payload_payload_proto_rawDesc = "" +
 	"\x05value\x18\x01 \x01(\v2\x14.google.protobuf.AnyR\x05value\x1a`\n" +
 	"\bKeyValue\x12&\n" +
 	"\x03key\x18\x01 \x01(\v2\x14.payload.v1.Meta.KeyR\x03key\x12,\n" +
-	"\x05value\x18\x02 \x01(\v2\x16.payload.v1.Meta.ValueR\x05value\"\a\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.payload.v1.Meta.ValueR\x05value\"\x8f\x02\n" +
+	"\n" +
+	"Collection\x1a\x92\x01\n" +
+	"\x06Config\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x12%\n" +
+	"\tdimension\x18\x02 \x01(\rB\a\xbaH\x04*\x02(\x02R\tdimension\x12#\n" +
+	"\rdistance_type\x18\x03 \x01(\tR\fdistanceType\x12\x1f\n" +
+	"\vobject_type\x18\x04 \x01(\tR\n" +
+	"objectType\x1a#\n" +
+	"\x04Name\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x1aG\n" +
+	"\x04List\x12?\n" +
+	"\vcollections\x18\x01 \x03(\v2\x1d.payload.v1.Collection.ConfigR\vcollections\"\a\n" +
 	"\x05EmptyBd\n" +
 	"\x1dorg.vdaas.vald.api.v1.payloadB\vValdPayloadP\x01Z*github.com/vdaas/vald/apis/grpc/v1/payload\xa2\x02\aPayloadb\x06proto3"
 
@@ -6240,208 +6877,222 @@ func file_v1_payload_payload_proto_rawDescGZIP() []byte {
 }
 
 var (
-	file_v1_payload_payload_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-	file_v1_payload_payload_proto_msgTypes  = make([]protoimpl.MessageInfo, 101)
+	file_v1_payload_payload_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+	file_v1_payload_payload_proto_msgTypes  = make([]protoimpl.MessageInfo, 108)
 	file_v1_payload_payload_proto_goTypes   = []any{
 		(Search_AggregationAlgorithm)(0),    // 0: payload.v1.Search.AggregationAlgorithm
 		(Remove_Timestamp_Operator)(0),      // 1: payload.v1.Remove.Timestamp.Operator
-		(*Search)(nil),                      // 2: payload.v1.Search
-		(*Filter)(nil),                      // 3: payload.v1.Filter
-		(*Insert)(nil),                      // 4: payload.v1.Insert
-		(*Update)(nil),                      // 5: payload.v1.Update
-		(*Upsert)(nil),                      // 6: payload.v1.Upsert
-		(*Remove)(nil),                      // 7: payload.v1.Remove
-		(*Flush)(nil),                       // 8: payload.v1.Flush
-		(*Object)(nil),                      // 9: payload.v1.Object
-		(*Control)(nil),                     // 10: payload.v1.Control
-		(*Discoverer)(nil),                  // 11: payload.v1.Discoverer
-		(*Info)(nil),                        // 12: payload.v1.Info
-		(*Mirror)(nil),                      // 13: payload.v1.Mirror
-		(*Meta)(nil),                        // 14: payload.v1.Meta
-		(*Empty)(nil),                       // 15: payload.v1.Empty
-		(*Search_Request)(nil),              // 16: payload.v1.Search.Request
-		(*Search_MultiRequest)(nil),         // 17: payload.v1.Search.MultiRequest
-		(*Search_IDRequest)(nil),            // 18: payload.v1.Search.IDRequest
-		(*Search_MultiIDRequest)(nil),       // 19: payload.v1.Search.MultiIDRequest
-		(*Search_ObjectRequest)(nil),        // 20: payload.v1.Search.ObjectRequest
-		(*Search_MultiObjectRequest)(nil),   // 21: payload.v1.Search.MultiObjectRequest
-		(*Search_Config)(nil),               // 22: payload.v1.Search.Config
-		(*Search_Response)(nil),             // 23: payload.v1.Search.Response
-		(*Search_Responses)(nil),            // 24: payload.v1.Search.Responses
-		(*Search_StreamResponse)(nil),       // 25: payload.v1.Search.StreamResponse
-		(*Filter_Target)(nil),               // 26: payload.v1.Filter.Target
-		(*Filter_Config)(nil),               // 27: payload.v1.Filter.Config
-		(*Insert_Request)(nil),              // 28: payload.v1.Insert.Request
-		(*Insert_MultiRequest)(nil),         // 29: payload.v1.Insert.MultiRequest
-		(*Insert_ObjectRequest)(nil),        // 30: payload.v1.Insert.ObjectRequest
-		(*Insert_MultiObjectRequest)(nil),   // 31: payload.v1.Insert.MultiObjectRequest
-		(*Insert_Config)(nil),               // 32: payload.v1.Insert.Config
-		(*Update_Request)(nil),              // 33: payload.v1.Update.Request
-		(*Update_MultiRequest)(nil),         // 34: payload.v1.Update.MultiRequest
-		(*Update_ObjectRequest)(nil),        // 35: payload.v1.Update.ObjectRequest
-		(*Update_MultiObjectRequest)(nil),   // 36: payload.v1.Update.MultiObjectRequest
-		(*Update_TimestampRequest)(nil),     // 37: payload.v1.Update.TimestampRequest
-		(*Update_Config)(nil),               // 38: payload.v1.Update.Config
-		(*Upsert_Request)(nil),              // 39: payload.v1.Upsert.Request
-		(*Upsert_MultiRequest)(nil),         // 40: payload.v1.Upsert.MultiRequest
-		(*Upsert_ObjectRequest)(nil),        // 41: payload.v1.Upsert.ObjectRequest
-		(*Upsert_MultiObjectRequest)(nil),   // 42: payload.v1.Upsert.MultiObjectRequest
-		(*Upsert_Config)(nil),               // 43: payload.v1.Upsert.Config
-		(*Remove_Request)(nil),              // 44: payload.v1.Remove.Request
-		(*Remove_MultiRequest)(nil),         // 45: payload.v1.Remove.MultiRequest
-		(*Remove_TimestampRequest)(nil),     // 46: payload.v1.Remove.TimestampRequest
-		(*Remove_Timestamp)(nil),            // 47: payload.v1.Remove.Timestamp
-		(*Remove_Config)(nil),               // 48: payload.v1.Remove.Config
-		(*Flush_Request)(nil),               // 49: payload.v1.Flush.Request
-		(*Object_VectorRequest)(nil),        // 50: payload.v1.Object.VectorRequest
-		(*Object_Distance)(nil),             // 51: payload.v1.Object.Distance
-		(*Object_StreamDistance)(nil),       // 52: payload.v1.Object.StreamDistance
-		(*Object_ID)(nil),                   // 53: payload.v1.Object.ID
-		(*Object_IDs)(nil),                  // 54: payload.v1.Object.IDs
-		(*Object_Vector)(nil),               // 55: payload.v1.Object.Vector
-		(*Object_TimestampRequest)(nil),     // 56: payload.v1.Object.TimestampRequest
-		(*Object_Timestamp)(nil),            // 57: payload.v1.Object.Timestamp
-		(*Object_Vectors)(nil),              // 58: payload.v1.Object.Vectors
-		(*Object_StreamVector)(nil),         // 59: payload.v1.Object.StreamVector
-		(*Object_ReshapeVector)(nil),        // 60: payload.v1.Object.ReshapeVector
-		(*Object_Blob)(nil),                 // 61: payload.v1.Object.Blob
-		(*Object_StreamBlob)(nil),           // 62: payload.v1.Object.StreamBlob
-		(*Object_Location)(nil),             // 63: payload.v1.Object.Location
-		(*Object_StreamLocation)(nil),       // 64: payload.v1.Object.StreamLocation
-		(*Object_Locations)(nil),            // 65: payload.v1.Object.Locations
-		(*Object_List)(nil),                 // 66: payload.v1.Object.List
-		(*Object_List_Request)(nil),         // 67: payload.v1.Object.List.Request
-		(*Object_List_Response)(nil),        // 68: payload.v1.Object.List.Response
-		(*Control_CreateIndexRequest)(nil),  // 69: payload.v1.Control.CreateIndexRequest
-		(*Discoverer_Request)(nil),          // 70: payload.v1.Discoverer.Request
-		(*Info_Index)(nil),                  // 71: payload.v1.Info.Index
-		(*Info_Pod)(nil),                    // 72: payload.v1.Info.Pod
-		(*Info_Node)(nil),                   // 73: payload.v1.Info.Node
-		(*Info_Service)(nil),                // 74: payload.v1.Info.Service
-		(*Info_ServicePort)(nil),            // 75: payload.v1.Info.ServicePort
-		(*Info_Labels)(nil),                 // 76: payload.v1.Info.Labels
-		(*Info_Annotations)(nil),            // 77: payload.v1.Info.Annotations
-		(*Info_CPU)(nil),                    // 78: payload.v1.Info.CPU
-		(*Info_Memory)(nil),                 // 79: payload.v1.Info.Memory
-		(*Info_Pods)(nil),                   // 80: payload.v1.Info.Pods
-		(*Info_Nodes)(nil),                  // 81: payload.v1.Info.Nodes
-		(*Info_Services)(nil),               // 82: payload.v1.Info.Services
-		(*Info_IPs)(nil),                    // 83: payload.v1.Info.IPs
-		(*Info_Index_Count)(nil),            // 84: payload.v1.Info.Index.Count
-		(*Info_Index_Detail)(nil),           // 85: payload.v1.Info.Index.Detail
-		(*Info_Index_UUID)(nil),             // 86: payload.v1.Info.Index.UUID
-		(*Info_Index_Statistics)(nil),       // 87: payload.v1.Info.Index.Statistics
-		(*Info_Index_StatisticsDetail)(nil), // 88: payload.v1.Info.Index.StatisticsDetail
-		(*Info_Index_Property)(nil),         // 89: payload.v1.Info.Index.Property
-		(*Info_Index_PropertyDetail)(nil),   // 90: payload.v1.Info.Index.PropertyDetail
-		nil,                                 // 91: payload.v1.Info.Index.Detail.CountsEntry
-		(*Info_Index_UUID_Committed)(nil),   // 92: payload.v1.Info.Index.UUID.Committed
-		(*Info_Index_UUID_Uncommitted)(nil), // 93: payload.v1.Info.Index.UUID.Uncommitted
-		nil,                                 // 94: payload.v1.Info.Index.StatisticsDetail.DetailsEntry
-		nil,                                 // 95: payload.v1.Info.Index.PropertyDetail.DetailsEntry
-		nil,                                 // 96: payload.v1.Info.Labels.LabelsEntry
-		nil,                                 // 97: payload.v1.Info.Annotations.AnnotationsEntry
-		(*Mirror_Target)(nil),               // 98: payload.v1.Mirror.Target
-		(*Mirror_Targets)(nil),              // 99: payload.v1.Mirror.Targets
-		(*Meta_Key)(nil),                    // 100: payload.v1.Meta.Key
-		(*Meta_Value)(nil),                  // 101: payload.v1.Meta.Value
-		(*Meta_KeyValue)(nil),               // 102: payload.v1.Meta.KeyValue
-		(*wrapperspb.FloatValue)(nil),       // 103: google.protobuf.FloatValue
-		(*status.Status)(nil),               // 104: google.rpc.Status
-		(*anypb.Any)(nil),                   // 105: google.protobuf.Any
+		(Object_Vector_Encoding)(0),         // 2: payload.v1.Object.Vector.Encoding
+		(*Search)(nil),                      // 3: payload.v1.Search
+		(*Filter)(nil),                      // 4: payload.v1.Filter
+		(*Insert)(nil),                      // 5: payload.v1.Insert
+		(*Update)(nil),                      // 6: payload.v1.Update
+		(*Upsert)(nil),                      // 7: payload.v1.Upsert
+		(*Remove)(nil),                      // 8: payload.v1.Remove
+		(*Flush)(nil),                       // 9: payload.v1.Flush
+		(*Object)(nil),                      // 10: payload.v1.Object
+		(*Control)(nil),                     // 11: payload.v1.Control
+		(*Discoverer)(nil),                  // 12: payload.v1.Discoverer
+		(*Info)(nil),                        // 13: payload.v1.Info
+		(*Mirror)(nil),                      // 14: payload.v1.Mirror
+		(*Meta)(nil),                        // 15: payload.v1.Meta
+		(*Collection)(nil),                  // 16: payload.v1.Collection
+		(*Empty)(nil),                       // 17: payload.v1.Empty
+		(*Search_Request)(nil),              // 18: payload.v1.Search.Request
+		(*Search_MultiRequest)(nil),         // 19: payload.v1.Search.MultiRequest
+		(*Search_IDRequest)(nil),            // 20: payload.v1.Search.IDRequest
+		(*Search_MultiIDRequest)(nil),       // 21: payload.v1.Search.MultiIDRequest
+		(*Search_ObjectRequest)(nil),        // 22: payload.v1.Search.ObjectRequest
+		(*Search_MultiObjectRequest)(nil),   // 23: payload.v1.Search.MultiObjectRequest
+		(*Search_Config)(nil),               // 24: payload.v1.Search.Config
+		(*Search_Response)(nil),             // 25: payload.v1.Search.Response
+		(*Search_Responses)(nil),            // 26: payload.v1.Search.Responses
+		(*Search_StreamResponse)(nil),       // 27: payload.v1.Search.StreamResponse
+		(*Filter_Target)(nil),               // 28: payload.v1.Filter.Target
+		(*Filter_Config)(nil),               // 29: payload.v1.Filter.Config
+		(*Insert_Request)(nil),              // 30: payload.v1.Insert.Request
+		(*Insert_MultiRequest)(nil),         // 31: payload.v1.Insert.MultiRequest
+		(*Insert_ObjectRequest)(nil),        // 32: payload.v1.Insert.ObjectRequest
+		(*Insert_MultiObjectRequest)(nil),   // 33: payload.v1.Insert.MultiObjectRequest
+		(*Insert_Config)(nil),               // 34: payload.v1.Insert.Config
+		(*Update_Request)(nil),              // 35: payload.v1.Update.Request
+		(*Update_MultiRequest)(nil),         // 36: payload.v1.Update.MultiRequest
+		(*Update_ObjectRequest)(nil),        // 37: payload.v1.Update.ObjectRequest
+		(*Update_MultiObjectRequest)(nil),   // 38: payload.v1.Update.MultiObjectRequest
+		(*Update_TimestampRequest)(nil),     // 39: payload.v1.Update.TimestampRequest
+		(*Update_Config)(nil),               // 40: payload.v1.Update.Config
+		(*Upsert_Request)(nil),              // 41: payload.v1.Upsert.Request
+		(*Upsert_MultiRequest)(nil),         // 42: payload.v1.Upsert.MultiRequest
+		(*Upsert_ObjectRequest)(nil),        // 43: payload.v1.Upsert.ObjectRequest
+		(*Upsert_MultiObjectRequest)(nil),   // 44: payload.v1.Upsert.MultiObjectRequest
+		(*Upsert_Config)(nil),               // 45: payload.v1.Upsert.Config
+		(*Remove_Request)(nil),              // 46: payload.v1.Remove.Request
+		(*Remove_MultiRequest)(nil),         // 47: payload.v1.Remove.MultiRequest
+		(*Remove_TimestampRequest)(nil),     // 48: payload.v1.Remove.TimestampRequest
+		(*Remove_Timestamp)(nil),            // 49: payload.v1.Remove.Timestamp
+		(*Remove_Config)(nil),               // 50: payload.v1.Remove.Config
+		(*Flush_Request)(nil),               // 51: payload.v1.Flush.Request
+		(*Object_VectorRequest)(nil),        // 52: payload.v1.Object.VectorRequest
+		(*Object_Distance)(nil),             // 53: payload.v1.Object.Distance
+		(*Object_StreamDistance)(nil),       // 54: payload.v1.Object.StreamDistance
+		(*Object_ID)(nil),                   // 55: payload.v1.Object.ID
+		(*Object_IDs)(nil),                  // 56: payload.v1.Object.IDs
+		(*Object_Vector)(nil),               // 57: payload.v1.Object.Vector
+		(*Object_TimestampRequest)(nil),     // 58: payload.v1.Object.TimestampRequest
+		(*Object_Timestamp)(nil),            // 59: payload.v1.Object.Timestamp
+		(*Object_Vectors)(nil),              // 60: payload.v1.Object.Vectors
+		(*Object_StreamVector)(nil),         // 61: payload.v1.Object.StreamVector
+		(*Object_ReshapeVector)(nil),        // 62: payload.v1.Object.ReshapeVector
+		(*Object_Blob)(nil),                 // 63: payload.v1.Object.Blob
+		(*Object_StreamBlob)(nil),           // 64: payload.v1.Object.StreamBlob
+		(*Object_Location)(nil),             // 65: payload.v1.Object.Location
+		(*Object_StreamLocation)(nil),       // 66: payload.v1.Object.StreamLocation
+		(*Object_Locations)(nil),            // 67: payload.v1.Object.Locations
+		(*Object_List)(nil),                 // 68: payload.v1.Object.List
+		(*Object_List_Request)(nil),         // 69: payload.v1.Object.List.Request
+		(*Object_List_Response)(nil),        // 70: payload.v1.Object.List.Response
+		(*Control_CreateIndexRequest)(nil),  // 71: payload.v1.Control.CreateIndexRequest
+		(*Discoverer_Request)(nil),          // 72: payload.v1.Discoverer.Request
+		(*Info_Index)(nil),                  // 73: payload.v1.Info.Index
+		(*Info_Pod)(nil),                    // 74: payload.v1.Info.Pod
+		(*Info_Node)(nil),                   // 75: payload.v1.Info.Node
+		(*Info_Topology)(nil),               // 76: payload.v1.Info.Topology
+		(*Info_Service)(nil),                // 77: payload.v1.Info.Service
+		(*Info_ServicePort)(nil),            // 78: payload.v1.Info.ServicePort
+		(*Info_Labels)(nil),                 // 79: payload.v1.Info.Labels
+		(*Info_Annotations)(nil),            // 80: payload.v1.Info.Annotations
+		(*Info_CPU)(nil),                    // 81: payload.v1.Info.CPU
+		(*Info_Memory)(nil),                 // 82: payload.v1.Info.Memory
+		(*Info_Pods)(nil),                   // 83: payload.v1.Info.Pods
+		(*Info_Nodes)(nil),                  // 84: payload.v1.Info.Nodes
+		(*Info_Services)(nil),               // 85: payload.v1.Info.Services
+		(*Info_IPs)(nil),                    // 86: payload.v1.Info.IPs
+		(*Info_Index_Count)(nil),            // 87: payload.v1.Info.Index.Count
+		(*Info_Index_Detail)(nil),           // 88: payload.v1.Info.Index.Detail
+		(*Info_Index_Schedule)(nil),         // 89: payload.v1.Info.Index.Schedule
+		(*Info_Index_UUID)(nil),             // 90: payload.v1.Info.Index.UUID
+		(*Info_Index_Statistics)(nil),       // 91: payload.v1.Info.Index.Statistics
+		(*Info_Index_StatisticsDetail)(nil), // 92: payload.v1.Info.Index.StatisticsDetail
+		(*Info_Index_Property)(nil),         // 93: payload.v1.Info.Index.Property
+		(*Info_Index_PropertyDetail)(nil),   // 94: payload.v1.Info.Index.PropertyDetail
+		nil,                                 // 95: payload.v1.Info.Index.Detail.CountsEntry
+		(*Info_Index_Schedule_Entry)(nil),   // 96: payload.v1.Info.Index.Schedule.Entry
+		(*Info_Index_UUID_Committed)(nil),   // 97: payload.v1.Info.Index.UUID.Committed
+		(*Info_Index_UUID_Uncommitted)(nil), // 98: payload.v1.Info.Index.UUID.Uncommitted
+		nil,                                 // 99: payload.v1.Info.Index.StatisticsDetail.DetailsEntry
+		nil,                                 // 100: payload.v1.Info.Index.PropertyDetail.DetailsEntry
+		nil,                                 // 101: payload.v1.Info.Labels.LabelsEntry
+		nil,                                 // 102: payload.v1.Info.Annotations.AnnotationsEntry
+		(*Mirror_Target)(nil),               // 103: payload.v1.Mirror.Target
+		(*Mirror_Targets)(nil),              // 104: payload.v1.Mirror.Targets
+		(*Meta_Key)(nil),                    // 105: payload.v1.Meta.Key
+		(*Meta_Value)(nil),                  // 106: payload.v1.Meta.Value
+		(*Meta_KeyValue)(nil),               // 107: payload.v1.Meta.KeyValue
+		(*Collection_Config)(nil),           // 108: payload.v1.Collection.Config
+		(*Collection_Name)(nil),             // 109: payload.v1.Collection.Name
+		(*Collection_List)(nil),             // 110: payload.v1.Collection.List
+		(*wrapperspb.FloatValue)(nil),       // 111: google.protobuf.FloatValue
+		(*status.Status)(nil),               // 112: google.rpc.Status
+		(*anypb.Any)(nil),                   // 113: google.protobuf.Any
 	}
 )
 
 var file_v1_payload_payload_proto_depIdxs = []int32{
-	22,  // 0: payload.v1.Search.Request.config:type_name -> payload.v1.Search.Config
-	16,  // 1: payload.v1.Search.MultiRequest.requests:type_name -> payload.v1.Search.Request
-	22,  // 2: payload.v1.Search.IDRequest.config:type_name -> payload.v1.Search.Config
-	18,  // 3: payload.v1.Search.MultiIDRequest.requests:type_name -> payload.v1.Search.IDRequest
-	22,  // 4: payload.v1.Search.ObjectRequest.config:type_name -> payload.v1.Search.Config
-	26,  // 5: payload.v1.Search.ObjectRequest.vectorizer:type_name -> payload.v1.Filter.Target
-	20,  // 6: payload.v1.Search.MultiObjectRequest.requests:type_name -> payload.v1.Search.ObjectRequest
-	27,  // 7: payload.v1.Search.Config.ingress_filters:type_name -> payload.v1.Filter.Config
-	27,  // 8: payload.v1.Search.Config.egress_filters:type_name -> payload.v1.Filter.Config
+	24,  // 0: payload.v1.Search.Request.config:type_name -> payload.v1.Search.Config
+	18,  // 1: payload.v1.Search.MultiRequest.requests:type_name -> payload.v1.Search.Request
+	24,  // 2: payload.v1.Search.IDRequest.config:type_name -> payload.v1.Search.Config
+	20,  // 3: payload.v1.Search.MultiIDRequest.requests:type_name -> payload.v1.Search.IDRequest
+	24,  // 4: payload.v1.Search.ObjectRequest.config:type_name -> payload.v1.Search.Config
+	28,  // 5: payload.v1.Search.ObjectRequest.vectorizer:type_name -> payload.v1.Filter.Target
+	22,  // 6: payload.v1.Search.MultiObjectRequest.requests:type_name -> payload.v1.Search.ObjectRequest
+	29,  // 7: payload.v1.Search.Config.ingress_filters:type_name -> payload.v1.Filter.Config
+	29,  // 8: payload.v1.Search.Config.egress_filters:type_name -> payload.v1.Filter.Config
 	0,   // 9: payload.v1.Search.Config.aggregation_algorithm:type_name -> payload.v1.Search.AggregationAlgorithm
-	103, // 10: payload.v1.Search.Config.ratio:type_name -> google.protobuf.FloatValue
-	51,  // 11: payload.v1.Search.Response.results:type_name -> payload.v1.Object.Distance
-	23,  // 12: payload.v1.Search.Responses.responses:type_name -> payload.v1.Search.Response
-	23,  // 13: payload.v1.Search.StreamResponse.response:type_name -> payload.v1.Search.Response
-	104, // 14: payload.v1.Search.StreamResponse.status:type_name -> google.rpc.Status
-	26,  // 15: payload.v1.Filter.Config.targets:type_name -> payload.v1.Filter.Target
-	55,  // 16: payload.v1.Insert.Request.vector:type_name -> payload.v1.Object.Vector
-	32,  // 17: payload.v1.Insert.Request.config:type_name -> payload.v1.Insert.Config
-	28,  // 18: payload.v1.Insert.MultiRequest.requests:type_name -> payload.v1.Insert.Request
-	61,  // 19: payload.v1.Insert.ObjectRequest.object:type_name -> payload.v1.Object.Blob
-	32,  // 20: payload.v1.Insert.ObjectRequest.config:type_name -> payload.v1.Insert.Config
-	26,  // 21: payload.v1.Insert.ObjectRequest.vectorizer:type_name -> payload.v1.Filter.Target
-	30,  // 22: payload.v1.Insert.MultiObjectRequest.requests:type_name -> payload.v1.Insert.ObjectRequest
-	27,  // 23: payload.v1.Insert.Config.filters:type_name -> payload.v1.Filter.Config
-	55,  // 24: payload.v1.Update.Request.vector:type_name -> payload.v1.Object.Vector
-	38,  // 25: payload.v1.Update.Request.config:type_name -> payload.v1.Update.Config
-	33,  // 26: payload.v1.Update.MultiRequest.requests:type_name -> payload.v1.Update.Request
-	61,  // 27: payload.v1.Update.ObjectRequest.object:type_name -> payload.v1.Object.Blob
-	38,  // 28: payload.v1.Update.ObjectRequest.config:type_name -> payload.v1.Update.Config
-	26,  // 29: payload.v1.Update.ObjectRequest.vectorizer:type_name -> payload.v1.Filter.Target
-	35,  // 30: payload.v1.Update.MultiObjectRequest.requests:type_name -> payload.v1.Update.ObjectRequest
-	27,  // 31: payload.v1.Update.Config.filters:type_name -> payload.v1.Filter.Config
-	55,  // 32: payload.v1.Upsert.Request.vector:type_name -> payload.v1.Object.Vector
-	43,  // 33: payload.v1.Upsert.Request.config:type_name -> payload.v1.Upsert.Config
-	39,  // 34: payload.v1.Upsert.MultiRequest.requests:type_name -> payload.v1.Upsert.Request
-	61,  // 35: payload.v1.Upsert.ObjectRequest.object:type_name -> payload.v1.Object.Blob
-	43,  // 36: payload.v1.Upsert.ObjectRequest.config:type_name -> payload.v1.Upsert.Config
-	26,  // 37: payload.v1.Upsert.ObjectRequest.vectorizer:type_name -> payload.v1.Filter.Target
-	41,  // 38: payload.v1.Upsert.MultiObjectRequest.requests:type_name -> payload.v1.Upsert.ObjectRequest
-	27,  // 39: payload.v1.Upsert.Config.filters:type_name -> payload.v1.Filter.Config
-	53,  // 40: payload.v1.Remove.Request.id:type_name -> payload.v1.Object.ID
-	48,  // 41: payload.v1.Remove.Request.config:type_name -> payload.v1.Remove.Config
-	44,  // 42: payload.v1.Remove.MultiRequest.requests:type_name -> payload.v1.Remove.Request
-	47,  // 43: payload.v1.Remove.TimestampRequest.timestamps:type_name -> payload.v1.Remove.Timestamp
+	111, // 10: payload.v1.Search.Config.ratio:type_name -> google.protobuf.FloatValue
+	53,  // 11: payload.v1.Search.Response.results:type_name -> payload.v1.Object.Distance
+	25,  // 12: payload.v1.Search.Responses.responses:type_name -> payload.v1.Search.Response
+	25,  // 13: payload.v1.Search.StreamResponse.response:type_name -> payload.v1.Search.Response
+	112, // 14: payload.v1.Search.StreamResponse.status:type_name -> google.rpc.Status
+	28,  // 15: payload.v1.Filter.Config.targets:type_name -> payload.v1.Filter.Target
+	57,  // 16: payload.v1.Insert.Request.vector:type_name -> payload.v1.Object.Vector
+	34,  // 17: payload.v1.Insert.Request.config:type_name -> payload.v1.Insert.Config
+	30,  // 18: payload.v1.Insert.MultiRequest.requests:type_name -> payload.v1.Insert.Request
+	63,  // 19: payload.v1.Insert.ObjectRequest.object:type_name -> payload.v1.Object.Blob
+	34,  // 20: payload.v1.Insert.ObjectRequest.config:type_name -> payload.v1.Insert.Config
+	28,  // 21: payload.v1.Insert.ObjectRequest.vectorizer:type_name -> payload.v1.Filter.Target
+	32,  // 22: payload.v1.Insert.MultiObjectRequest.requests:type_name -> payload.v1.Insert.ObjectRequest
+	29,  // 23: payload.v1.Insert.Config.filters:type_name -> payload.v1.Filter.Config
+	57,  // 24: payload.v1.Update.Request.vector:type_name -> payload.v1.Object.Vector
+	40,  // 25: payload.v1.Update.Request.config:type_name -> payload.v1.Update.Config
+	35,  // 26: payload.v1.Update.MultiRequest.requests:type_name -> payload.v1.Update.Request
+	63,  // 27: payload.v1.Update.ObjectRequest.object:type_name -> payload.v1.Object.Blob
+	40,  // 28: payload.v1.Update.ObjectRequest.config:type_name -> payload.v1.Update.Config
+	28,  // 29: payload.v1.Update.ObjectRequest.vectorizer:type_name -> payload.v1.Filter.Target
+	37,  // 30: payload.v1.Update.MultiObjectRequest.requests:type_name -> payload.v1.Update.ObjectRequest
+	29,  // 31: payload.v1.Update.Config.filters:type_name -> payload.v1.Filter.Config
+	57,  // 32: payload.v1.Upsert.Request.vector:type_name -> payload.v1.Object.Vector
+	45,  // 33: payload.v1.Upsert.Request.config:type_name -> payload.v1.Upsert.Config
+	41,  // 34: payload.v1.Upsert.MultiRequest.requests:type_name -> payload.v1.Upsert.Request
+	63,  // 35: payload.v1.Upsert.ObjectRequest.object:type_name -> payload.v1.Object.Blob
+	45,  // 36: payload.v1.Upsert.ObjectRequest.config:type_name -> payload.v1.Upsert.Config
+	28,  // 37: payload.v1.Upsert.ObjectRequest.vectorizer:type_name -> payload.v1.Filter.Target
+	43,  // 38: payload.v1.Upsert.MultiObjectRequest.requests:type_name -> payload.v1.Upsert.ObjectRequest
+	29,  // 39: payload.v1.Upsert.Config.filters:type_name -> payload.v1.Filter.Config
+	55,  // 40: payload.v1.Remove.Request.id:type_name -> payload.v1.Object.ID
+	50,  // 41: payload.v1.Remove.Request.config:type_name -> payload.v1.Remove.Config
+	46,  // 42: payload.v1.Remove.MultiRequest.requests:type_name -> payload.v1.Remove.Request
+	49,  // 43: payload.v1.Remove.TimestampRequest.timestamps:type_name -> payload.v1.Remove.Timestamp
 	1,   // 44: payload.v1.Remove.Timestamp.operator:type_name -> payload.v1.Remove.Timestamp.Operator
-	53,  // 45: payload.v1.Object.VectorRequest.id:type_name -> payload.v1.Object.ID
-	27,  // 46: payload.v1.Object.VectorRequest.filters:type_name -> payload.v1.Filter.Config
-	51,  // 47: payload.v1.Object.StreamDistance.distance:type_name -> payload.v1.Object.Distance
-	104, // 48: payload.v1.Object.StreamDistance.status:type_name -> google.rpc.Status
-	53,  // 49: payload.v1.Object.TimestampRequest.id:type_name -> payload.v1.Object.ID
-	55,  // 50: payload.v1.Object.Vectors.vectors:type_name -> payload.v1.Object.Vector
-	55,  // 51: payload.v1.Object.StreamVector.vector:type_name -> payload.v1.Object.Vector
-	104, // 52: payload.v1.Object.StreamVector.status:type_name -> google.rpc.Status
-	61,  // 53: payload.v1.Object.StreamBlob.blob:type_name -> payload.v1.Object.Blob
-	104, // 54: payload.v1.Object.StreamBlob.status:type_name -> google.rpc.Status
-	63,  // 55: payload.v1.Object.StreamLocation.location:type_name -> payload.v1.Object.Location
-	104, // 56: payload.v1.Object.StreamLocation.status:type_name -> google.rpc.Status
-	63,  // 57: payload.v1.Object.Locations.locations:type_name -> payload.v1.Object.Location
-	55,  // 58: payload.v1.Object.List.Response.vector:type_name -> payload.v1.Object.Vector
-	104, // 59: payload.v1.Object.List.Response.status:type_name -> google.rpc.Status
-	78,  // 60: payload.v1.Info.Pod.cpu:type_name -> payload.v1.Info.CPU
-	79,  // 61: payload.v1.Info.Pod.memory:type_name -> payload.v1.Info.Memory
-	73,  // 62: payload.v1.Info.Pod.node:type_name -> payload.v1.Info.Node
-	78,  // 63: payload.v1.Info.Node.cpu:type_name -> payload.v1.Info.CPU
-	79,  // 64: payload.v1.Info.Node.memory:type_name -> payload.v1.Info.Memory
-	80,  // 65: payload.v1.Info.Node.Pods:type_name -> payload.v1.Info.Pods
-	75,  // 66: payload.v1.Info.Service.ports:type_name -> payload.v1.Info.ServicePort
-	76,  // 67: payload.v1.Info.Service.labels:type_name -> payload.v1.Info.Labels
-	77,  // 68: payload.v1.Info.Service.annotations:type_name -> payload.v1.Info.Annotations
-	96,  // 69: payload.v1.Info.Labels.labels:type_name -> payload.v1.Info.Labels.LabelsEntry
-	97,  // 70: payload.v1.Info.Annotations.annotations:type_name -> payload.v1.Info.Annotations.AnnotationsEntry
-	72,  // 71: payload.v1.Info.Pods.pods:type_name -> payload.v1.Info.Pod
-	73,  // 72: payload.v1.Info.Nodes.nodes:type_name -> payload.v1.Info.Node
-	74,  // 73: payload.v1.Info.Services.services:type_name -> payload.v1.Info.Service
-	91,  // 74: payload.v1.Info.Index.Detail.counts:type_name -> payload.v1.Info.Index.Detail.CountsEntry
-	94,  // 75: payload.v1.Info.Index.StatisticsDetail.details:type_name -> payload.v1.Info.Index.StatisticsDetail.DetailsEntry
-	95,  // 76: payload.v1.Info.Index.PropertyDetail.details:type_name -> payload.v1.Info.Index.PropertyDetail.DetailsEntry
-	84,  // 77: payload.v1.Info.Index.Detail.CountsEntry.value:type_name -> payload.v1.Info.Index.Count
-	87,  // 78: payload.v1.Info.Index.StatisticsDetail.DetailsEntry.value:type_name -> payload.v1.Info.Index.Statistics
-	89,  // 79: payload.v1.Info.Index.PropertyDetail.DetailsEntry.value:type_name -> payload.v1.Info.Index.Property
-	98,  // 80: payload.v1.Mirror.Targets.targets:type_name -> payload.v1.Mirror.Target
-	105, // 81: payload.v1.Meta.Value.value:type_name -> google.protobuf.Any
-	100, // 82: payload.v1.Meta.KeyValue.key:type_name -> payload.v1.Meta.Key
-	101, // 83: payload.v1.Meta.KeyValue.value:type_name -> payload.v1.Meta.Value
-	84,  // [84:84] is the sub-list for method output_type
-	84,  // [84:84] is the sub-list for method input_type
-	84,  // [84:84] is the sub-list for extension type_name
-	84,  // [84:84] is the sub-list for extension extendee
-	0,   // [0:84] is the sub-list for field type_name
+	55,  // 45: payload.v1.Object.VectorRequest.id:type_name -> payload.v1.Object.ID
+	29,  // 46: payload.v1.Object.VectorRequest.filters:type_name -> payload.v1.Filter.Config
+	53,  // 47: payload.v1.Object.StreamDistance.distance:type_name -> payload.v1.Object.Distance
+	112, // 48: payload.v1.Object.StreamDistance.status:type_name -> google.rpc.Status
+	2,   // 49: payload.v1.Object.Vector.encoding:type_name -> payload.v1.Object.Vector.Encoding
+	55,  // 50: payload.v1.Object.TimestampRequest.id:type_name -> payload.v1.Object.ID
+	57,  // 51: payload.v1.Object.Vectors.vectors:type_name -> payload.v1.Object.Vector
+	57,  // 52: payload.v1.Object.StreamVector.vector:type_name -> payload.v1.Object.Vector
+	112, // 53: payload.v1.Object.StreamVector.status:type_name -> google.rpc.Status
+	63,  // 54: payload.v1.Object.StreamBlob.blob:type_name -> payload.v1.Object.Blob
+	112, // 55: payload.v1.Object.StreamBlob.status:type_name -> google.rpc.Status
+	65,  // 56: payload.v1.Object.StreamLocation.location:type_name -> payload.v1.Object.Location
+	112, // 57: payload.v1.Object.StreamLocation.status:type_name -> google.rpc.Status
+	65,  // 58: payload.v1.Object.Locations.locations:type_name -> payload.v1.Object.Location
+	57,  // 59: payload.v1.Object.List.Response.vector:type_name -> payload.v1.Object.Vector
+	112, // 60: payload.v1.Object.List.Response.status:type_name -> google.rpc.Status
+	81,  // 61: payload.v1.Info.Pod.cpu:type_name -> payload.v1.Info.CPU
+	82,  // 62: payload.v1.Info.Pod.memory:type_name -> payload.v1.Info.Memory
+	75,  // 63: payload.v1.Info.Pod.node:type_name -> payload.v1.Info.Node
+	76,  // 64: payload.v1.Info.Pod.topology:type_name -> payload.v1.Info.Topology
+	81,  // 65: payload.v1.Info.Node.cpu:type_name -> payload.v1.Info.CPU
+	82,  // 66: payload.v1.Info.Node.memory:type_name -> payload.v1.Info.Memory
+	83,  // 67: payload.v1.Info.Node.Pods:type_name -> payload.v1.Info.Pods
+	76,  // 68: payload.v1.Info.Node.topology:type_name -> payload.v1.Info.Topology
+	78,  // 69: payload.v1.Info.Service.ports:type_name -> payload.v1.Info.ServicePort
+	79,  // 70: payload.v1.Info.Service.labels:type_name -> payload.v1.Info.Labels
+	80,  // 71: payload.v1.Info.Service.annotations:type_name -> payload.v1.Info.Annotations
+	101, // 72: payload.v1.Info.Labels.labels:type_name -> payload.v1.Info.Labels.LabelsEntry
+	102, // 73: payload.v1.Info.Annotations.annotations:type_name -> payload.v1.Info.Annotations.AnnotationsEntry
+	74,  // 74: payload.v1.Info.Pods.pods:type_name -> payload.v1.Info.Pod
+	75,  // 75: payload.v1.Info.Nodes.nodes:type_name -> payload.v1.Info.Node
+	77,  // 76: payload.v1.Info.Services.services:type_name -> payload.v1.Info.Service
+	95,  // 77: payload.v1.Info.Index.Detail.counts:type_name -> payload.v1.Info.Index.Detail.CountsEntry
+	96,  // 78: payload.v1.Info.Index.Schedule.running:type_name -> payload.v1.Info.Index.Schedule.Entry
+	96,  // 79: payload.v1.Info.Index.Schedule.queue:type_name -> payload.v1.Info.Index.Schedule.Entry
+	99,  // 80: payload.v1.Info.Index.StatisticsDetail.details:type_name -> payload.v1.Info.Index.StatisticsDetail.DetailsEntry
+	100, // 81: payload.v1.Info.Index.PropertyDetail.details:type_name -> payload.v1.Info.Index.PropertyDetail.DetailsEntry
+	87,  // 82: payload.v1.Info.Index.Detail.CountsEntry.value:type_name -> payload.v1.Info.Index.Count
+	91,  // 83: payload.v1.Info.Index.StatisticsDetail.DetailsEntry.value:type_name -> payload.v1.Info.Index.Statistics
+	93,  // 84: payload.v1.Info.Index.PropertyDetail.DetailsEntry.value:type_name -> payload.v1.Info.Index.Property
+	103, // 85: payload.v1.Mirror.Targets.targets:type_name -> payload.v1.Mirror.Target
+	113, // 86: payload.v1.Meta.Value.value:type_name -> google.protobuf.Any
+	105, // 87: payload.v1.Meta.KeyValue.key:type_name -> payload.v1.Meta.Key
+	106, // 88: payload.v1.Meta.KeyValue.value:type_name -> payload.v1.Meta.Value
+	108, // 89: payload.v1.Collection.List.collections:type_name -> payload.v1.Collection.Config
+	90,  // [90:90] is the sub-list for method output_type
+	90,  // [90:90] is the sub-list for method input_type
+	90,  // [90:90] is the sub-list for extension type_name
+	90,  // [90:90] is the sub-list for extension extendee
+	0,   // [0:90] is the sub-list for field type_name
 }
 
 func init() { file_v1_payload_payload_proto_init() }
@@ -6449,27 +7100,27 @@ func file_v1_payload_payload_proto_init() {
 	if File_v1_payload_payload_proto != nil {
 		return
 	}
-	file_v1_payload_payload_proto_msgTypes[23].OneofWrappers = []any{
+	file_v1_payload_payload_proto_msgTypes[24].OneofWrappers = []any{
 		(*Search_StreamResponse_Response)(nil),
 		(*Search_StreamResponse_Status)(nil),
 	}
-	file_v1_payload_payload_proto_msgTypes[50].OneofWrappers = []any{
+	file_v1_payload_payload_proto_msgTypes[51].OneofWrappers = []any{
 		(*Object_StreamDistance_Distance)(nil),
 		(*Object_StreamDistance_Status)(nil),
 	}
-	file_v1_payload_payload_proto_msgTypes[57].OneofWrappers = []any{
+	file_v1_payload_payload_proto_msgTypes[58].OneofWrappers = []any{
 		(*Object_StreamVector_Vector)(nil),
 		(*Object_StreamVector_Status)(nil),
 	}
-	file_v1_payload_payload_proto_msgTypes[60].OneofWrappers = []any{
+	file_v1_payload_payload_proto_msgTypes[61].OneofWrappers = []any{
 		(*Object_StreamBlob_Blob)(nil),
 		(*Object_StreamBlob_Status)(nil),
 	}
-	file_v1_payload_payload_proto_msgTypes[62].OneofWrappers = []any{
+	file_v1_payload_payload_proto_msgTypes[63].OneofWrappers = []any{
 		(*Object_StreamLocation_Location)(nil),
 		(*Object_StreamLocation_Status)(nil),
 	}
-	file_v1_payload_payload_proto_msgTypes[66].OneofWrappers = []any{
+	file_v1_payload_payload_proto_msgTypes[67].OneofWrappers = []any{
 		(*Object_List_Response_Vector)(nil),
 		(*Object_List_Response_Status)(nil),
 	}
@@ -6478,8 +7129,8 @@ func file_v1_payload_payload_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_payload_payload_proto_rawDesc), len(file_v1_payload_payload_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   101,
+			NumEnums:      3,
+			NumMessages:   108,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,417 @@
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package payload
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/vdaas/vald/internal/errors"
+)
+
+// EncodeVectors encodes the vector into the data field with enc.
+// The vector field is cleared unless enc is FLOAT32, which decodes the data field back into the vector field.
+// FLOAT16 and INT8 are lossy.
+func (v *Object_Vector) EncodeVectors(enc Object_Vector_Encoding) error {
+	if v == nil || v.GetEncoding() == enc && !v.HasDecodedVectors() {
+		return nil
+	}
+	if err := v.DecodeVectors(); err != nil {
+		return err
+	}
+	if enc == Object_Vector_FLOAT32 {
+		v.Encoding = enc
+		return nil
+	}
+	vec := v.GetVector()
+	var data []byte
+	switch enc {
+	case Object_Vector_FLOAT16:
+		data = make([]byte, len(vec)*2)
+		for i, f := range vec {
+			binary.LittleEndian.PutUint16(data[i*2:], float16bits(f))
+		}
+	case Object_Vector_INT8:
+		var peak float32
+		for _, f := range vec {
+			peak = max(peak, float32(math.Abs(float64(f))))
+		}
+		data = make([]byte, len(vec))
+		if peak != 0 {
+			v.Scale = peak / math.MaxInt8
+			for i, f := range vec {
+				data[i] = byte(int8(max(-math.MaxInt8, min(math.MaxInt8, math.Round(float64(f/v.Scale))))))
+			}
+		}
+	case Object_Vector_BYTES:
+		data = make([]byte, len(vec)*4)
+		for i, f := range vec {
+			binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(f))
+		}
+	default:
+		return errors.ErrUnsupportedVectorEncoding(enc.String())
+	}
+	v.Vector, v.Data, v.Encoding = nil, data, enc
+	return nil
+}
+
+// HasDecodedVectors reports whether the vector sent in a compact encoding has been decoded into the vector field.
+// The decoded vector keeps its encoding, so that it is encoded again when it is sent to the next hop.
+func (v *Object_Vector) HasDecodedVectors() bool {
+	return v.GetEncoding() != Object_Vector_FLOAT32 && len(v.GetData()) == 0 && len(v.GetVector()) != 0
+}
+
+// EncodeDecodedVectors encodes the decoded vector again with the encoding it was sent with.
+func (v *Object_Vector) EncodeDecodedVectors() error {
+	if !v.HasDecodedVectors() {
+		return nil
+	}
+	return v.EncodeVectors(v.GetEncoding())
+}
+
+// DecodeVectors decodes the data field into the vector field and clears the data field.
+// The encoding is kept, so that EncodeDecodedVectors encodes the vector again before it is forwarded.
+// The vector field is reused when it has enough capacity, so no intermediate slice is allocated.
+func (v *Object_Vector) DecodeVectors() error {
+	if v == nil || v.GetEncoding() == Object_Vector_FLOAT32 || v.HasDecodedVectors() {
+		return nil
+	}
+	var size int
+	switch v.GetEncoding() {
+	case Object_Vector_FLOAT16:
+		size = 2
+	case Object_Vector_INT8:
+		size = 1
+	case Object_Vector_BYTES:
+		size = 4
+	default:
+		return errors.ErrUnsupportedVectorEncoding(v.GetEncoding().String())
+	}
+	data := v.GetData()
+	if len(data)%size != 0 {
+		return errors.ErrInvalidEncodedVectorLength(v.GetEncoding().String(), len(data), size)
+	}
+	dim := len(data) / size
+	vec := v.Vector[:0]
+	if cap(vec) < dim {
+		vec = make([]float32, dim)
+	}
+	vec = vec[:dim]
+	switch v.GetEncoding() {
+	case Object_Vector_FLOAT16:
+		for i := range vec {
+			vec[i] = float16frombits(binary.LittleEndian.Uint16(data[i*2:]))
+		}
+	case Object_Vector_INT8:
+		for i := range vec {
+			vec[i] = float32(int8(data[i])) * v.GetScale()
+		}
+	case Object_Vector_BYTES:
+		for i := range vec {
+			vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+		}
+	}
+	v.Vector, v.Data, v.Scale = vec, nil, 0
+	return nil
+}
+
+// EncodeVectors encodes all vectors with enc.
+func (m *Object_Vectors) EncodeVectors(enc Object_Vector_Encoding) error {
+	return encodeVectors(enc, m.GetVectors()...)
+}
+
+// DecodeVectors decodes all encoded vectors.
+func (m *Object_Vectors) DecodeVectors() error {
+	return decodeVectors(m.GetVectors()...)
+}
+
+// HasDecodedVectors reports whether any vector has been decoded from a compact encoding.
+func (m *Object_Vectors) HasDecodedVectors() bool {
+	return hasDecodedVectors(m.GetVectors()...)
+}
+
+// EncodeDecodedVectors encodes the decoded vectors again with the encodings they were sent with.
+func (m *Object_Vectors) EncodeDecodedVectors() error {
+	return encodeDecodedVectors(m.GetVectors()...)
+}
+
+// EncodeVectors encodes the vector with enc.
+func (m *Insert_Request) EncodeVectors(enc Object_Vector_Encoding) error {
+	return m.GetVector().EncodeVectors(enc)
+}
+
+// DecodeVectors decodes the encoded vector.
+func (m *Insert_Request) DecodeVectors() error {
+	return m.GetVector().DecodeVectors()
+}
+
+// HasDecodedVectors reports whether the vector has been decoded from a compact encoding.
+func (m *Insert_Request) HasDecodedVectors() bool {
+	return m.GetVector().HasDecodedVectors()
+}
+
+// EncodeDecodedVectors encodes the decoded vector again with the encoding it was sent with.
+func (m *Insert_Request) EncodeDecodedVectors() error {
+	return m.GetVector().EncodeDecodedVectors()
+}
+
+// EncodeVectors encodes the vectors of all requests with enc.
+func (m *Insert_MultiRequest) EncodeVectors(enc Object_Vector_Encoding) error {
+	for _, req := range m.GetRequests() {
+		if err := req.EncodeVectors(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeVectors decodes the encoded vectors of all requests.
+func (m *Insert_MultiRequest) DecodeVectors() error {
+	for _, req := range m.GetRequests() {
+		if err := req.DecodeVectors(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasDecodedVectors reports whether the vector of any request has been decoded from a compact encoding.
+func (m *Insert_MultiRequest) HasDecodedVectors() bool {
+	for _, req := range m.GetRequests() {
+		if req.HasDecodedVectors() {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeDecodedVectors encodes the decoded vectors of all requests again with the encodings they were sent with.
+func (m *Insert_MultiRequest) EncodeDecodedVectors() error {
+	for _, req := range m.GetRequests() {
+		if err := req.EncodeDecodedVectors(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeVectors encodes the vector with enc.
+func (m *Update_Request) EncodeVectors(enc Object_Vector_Encoding) error {
+	return m.GetVector().EncodeVectors(enc)
+}
+
+// DecodeVectors decodes the encoded vector.
+func (m *Update_Request) DecodeVectors() error {
+	return m.GetVector().DecodeVectors()
+}
+
+// HasDecodedVectors reports whether the vector has been decoded from a compact encoding.
+func (m *Update_Request) HasDecodedVectors() bool {
+	return m.GetVector().HasDecodedVectors()
+}
+
+// EncodeDecodedVectors encodes the decoded vector again with the encoding it was sent with.
+func (m *Update_Request) EncodeDecodedVectors() error {
+	return m.GetVector().EncodeDecodedVectors()
+}
+
+// EncodeVectors encodes the vectors of all requests with enc.
+func (m *Update_MultiRequest) EncodeVectors(enc Object_Vector_Encoding) error {
+	for _, req := range m.GetRequests() {
+		if err := req.EncodeVectors(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeVectors decodes the encoded vectors of all requests.
+func (m *Update_MultiRequest) DecodeVectors() error {
+	for _, req := range m.GetRequests() {
+		if err := req.DecodeVectors(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasDecodedVectors reports whether the vector of any request has been decoded from a compact encoding.
+func (m *Update_MultiRequest) HasDecodedVectors() bool {
+	for _, req := range m.GetRequests() {
+		if req.HasDecodedVectors() {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeDecodedVectors encodes the decoded vectors of all requests again with the encodings they were sent with.
+func (m *Update_MultiRequest) EncodeDecodedVectors() error {
+	for _, req := range m.GetRequests() {
+		if err := req.EncodeDecodedVectors(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeVectors encodes the vector with enc.
+func (m *Upsert_Request) EncodeVectors(enc Object_Vector_Encoding) error {
+	return m.GetVector().EncodeVectors(enc)
+}
+
+// DecodeVectors decodes the encoded vector.
+func (m *Upsert_Request) DecodeVectors() error {
+	return m.GetVector().DecodeVectors()
+}
+
+// HasDecodedVectors reports whether the vector has been decoded from a compact encoding.
+func (m *Upsert_Request) HasDecodedVectors() bool {
+	return m.GetVector().HasDecodedVectors()
+}
+
+// EncodeDecodedVectors encodes the decoded vector again with the encoding it was sent with.
+func (m *Upsert_Request) EncodeDecodedVectors() error {
+	return m.GetVector().EncodeDecodedVectors()
+}
+
+// EncodeVectors encodes the vectors of all requests with enc.
+func (m *Upsert_MultiRequest) EncodeVectors(enc Object_Vector_Encoding) error {
+	for _, req := range m.GetRequests() {
+		if err := req.EncodeVectors(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeVectors decodes the encoded vectors of all requests.
+func (m *Upsert_MultiRequest) DecodeVectors() error {
+	for _, req := range m.GetRequests() {
+		if err := req.DecodeVectors(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasDecodedVectors reports whether the vector of any request has been decoded from a compact encoding.
+func (m *Upsert_MultiRequest) HasDecodedVectors() bool {
+	for _, req := range m.GetRequests() {
+		if req.HasDecodedVectors() {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeDecodedVectors encodes the decoded vectors of all requests again with the encodings they were sent with.
+func (m *Upsert_MultiRequest) EncodeDecodedVectors() error {
+	for _, req := range m.GetRequests() {
+		if err := req.EncodeDecodedVectors(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeVectors(enc Object_Vector_Encoding, vecs ...*Object_Vector) error {
+	for _, vec := range vecs {
+		if err := vec.EncodeVectors(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeVectors(vecs ...*Object_Vector) error {
+	for _, vec := range vecs {
+		if err := vec.DecodeVectors(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasDecodedVectors(vecs ...*Object_Vector) bool {
+	for _, vec := range vecs {
+		if vec.HasDecodedVectors() {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeDecodedVectors(vecs ...*Object_Vector) error {
+	for _, vec := range vecs {
+		if err := vec.EncodeDecodedVectors(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// float16bits returns the IEEE 754 half precision representation of f rounded to the nearest even.
+func float16bits(f float32) uint16 {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & 0x8000
+	exp := int(b>>23) & 0xff
+	mant := b & 0x7fffff
+	if exp == 0xff {
+		if mant != 0 {
+			return sign | 0x7e00
+		}
+		return sign | 0x7c00
+	}
+	exp = exp - 127 + 15
+	if exp >= 0x1f {
+		return sign | 0x7c00
+	}
+	if exp <= 0 {
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint(14 - exp)
+		h := mant >> shift
+		rem, half := mant&(1<<shift-1), uint32(1)<<(shift-1)
+		if rem > half || (rem == half && h&1 == 1) {
+			h++
+		}
+		return sign | uint16(h)
+	}
+	h := uint32(exp)<<10 | mant>>13
+	if rem := mant & 0x1fff; rem > 0x1000 || (rem == 0x1000 && h&1 == 1) {
+		h++
+	}
+	return sign | uint16(h)
+}
+
+// float16frombits returns the float32 value of the IEEE 754 half precision representation h.
+func float16frombits(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+	switch {
+	case exp == 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	case exp != 0:
+		return math.Float32frombits(sign | (exp+112)<<23 | mant<<13)
+	}
+	f := float32(mant) / (1 << 24)
+	if sign != 0 {
+		return -f
+	}
+	return f
+}
@@ -43,13 +43,14 @@ var File_v1_vald_index_proto protoreflect.FileDescriptor
 
 const file_v1_vald_index_proto_rawDesc = "" +
 	"\n" +
-	"\x13v1/vald/index.proto\x12\avald.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x18v1/payload/payload.proto2\xf1\x03\n" +
+	"\x13v1/vald/index.proto\x12\avald.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x18v1/payload/payload.proto2\xcf\x04\n" +
 	"\x05Index\x12Q\n" +
 	"\tIndexInfo\x12\x11.payload.v1.Empty\x1a\x1c.payload.v1.Info.Index.Count\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/index/info\x12V\n" +
 	"\vIndexDetail\x12\x11.payload.v1.Empty\x1a\x1d.payload.v1.Info.Index.Detail\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/index/detail\x12b\n" +
 	"\x0fIndexStatistics\x12\x11.payload.v1.Empty\x1a!.payload.v1.Info.Index.Statistics\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/index/statistics\x12u\n" +
 	"\x15IndexStatisticsDetail\x12\x11.payload.v1.Empty\x1a'.payload.v1.Info.Index.StatisticsDetail\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/index/statistics/detail\x12b\n" +
-	"\rIndexProperty\x12\x11.payload.v1.Empty\x1a%.payload.v1.Info.Index.PropertyDetail\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/index/propertyBR\n" +
+	"\rIndexProperty\x12\x11.payload.v1.Empty\x1a%.payload.v1.Info.Index.PropertyDetail\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/index/property\x12\\\n" +
+	"\rIndexSchedule\x12\x11.payload.v1.Empty\x1a\x1f.payload.v1.Info.Index.Schedule\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/index/scheduleBR\n" +
 	"\x1aorg.vdaas.vald.api.v1.valdB\tValdIndexP\x01Z'github.com/vdaas/vald/apis/grpc/v1/valdb\x06proto3"
 
 var file_v1_vald_index_proto_goTypes = []any{
@@ -59,6 +60,7 @@ var file_v1_vald_index_proto_goTypes = []any{
 	(*payload.Info_Index_Statistics)(nil),       // 3: payload.v1.Info.Index.Statistics
 	(*payload.Info_Index_StatisticsDetail)(nil), // 4: payload.v1.Info.Index.StatisticsDetail
 	(*payload.Info_Index_PropertyDetail)(nil),   // 5: payload.v1.Info.Index.PropertyDetail
+	(*payload.Info_Index_Schedule)(nil),         // 6: payload.v1.Info.Index.Schedule
 }
 
 var file_v1_vald_index_proto_depIdxs = []int32{
@@ -67,13 +69,15 @@ var file_v1_vald_index_proto_depIdxs = []int32{
 	0, // 2: vald.v1.Index.IndexStatistics:input_type -> payload.v1.Empty
 	0, // 3: vald.v1.Index.IndexStatisticsDetail:input_type -> payload.v1.Empty
 	0, // 4: vald.v1.Index.IndexProperty:input_type -> payload.v1.Empty
-	1, // 5: vald.v1.Index.IndexInfo:output_type -> payload.v1.Info.Index.Count
-	2, // 6: vald.v1.Index.IndexDetail:output_type -> payload.v1.Info.Index.Detail
-	3, // 7: vald.v1.Index.IndexStatistics:output_type -> payload.v1.Info.Index.Statistics
-	4, // 8: vald.v1.Index.IndexStatisticsDetail:output_type -> payload.v1.Info.Index.StatisticsDetail
-	5, // 9: vald.v1.Index.IndexProperty:output_type -> payload.v1.Info.Index.PropertyDetail
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
+	0, // 5: vald.v1.Index.IndexSchedule:input_type -> payload.v1.Empty
+	1, // 6: vald.v1.Index.IndexInfo:output_type -> payload.v1.Info.Index.Count
+	2, // 7: vald.v1.Index.IndexDetail:output_type -> payload.v1.Info.Index.Detail
+	3, // 8: vald.v1.Index.IndexStatistics:output_type -> payload.v1.Info.Index.Statistics
+	4, // 9: vald.v1.Index.IndexStatisticsDetail:output_type -> payload.v1.Info.Index.StatisticsDetail
+	5, // 10: vald.v1.Index.IndexProperty:output_type -> payload.v1.Info.Index.PropertyDetail
+	6, // 11: vald.v1.Index.IndexSchedule:output_type -> payload.v1.Info.Index.Schedule
+	6, // [6:12] is the sub-list for method output_type
+	0, // [0:6] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
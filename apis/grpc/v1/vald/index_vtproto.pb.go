@@ -57,6 +57,9 @@ type IndexClient interface {
 	// Overview
 	// Represent the RPC to get the index property.
 	IndexProperty(ctx context.Context, in *payload.Empty, opts ...grpc.CallOption) (*payload.Info_Index_PropertyDetail, error)
+	// Overview
+	// Represent the RPC to get the index operation schedule and queue of the index manager.
+	IndexSchedule(ctx context.Context, in *payload.Empty, opts ...grpc.CallOption) (*payload.Info_Index_Schedule, error)
 }
 
 type indexClient struct {
@@ -122,6 +125,17 @@ func (c *indexClient) IndexProperty(
 	return out, nil
 }
 
+func (c *indexClient) IndexSchedule(
+	ctx context.Context, in *payload.Empty, opts ...grpc.CallOption,
+) (*payload.Info_Index_Schedule, error) {
+	out := new(payload.Info_Index_Schedule)
+	err := c.cc.Invoke(ctx, "/vald.v1.Index/IndexSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IndexServer is the server API for Index service.
 // All implementations must embed UnimplementedIndexServer
 // for forward compatibility
@@ -141,6 +155,9 @@ type IndexServer interface {
 	// Overview
 	// Represent the RPC to get the index property.
 	IndexProperty(context.Context, *payload.Empty) (*payload.Info_Index_PropertyDetail, error)
+	// Overview
+	// Represent the RPC to get the index operation schedule and queue of the index manager.
+	IndexSchedule(context.Context, *payload.Empty) (*payload.Info_Index_Schedule, error)
 	mustEmbedUnimplementedIndexServer()
 }
 
@@ -176,6 +193,12 @@ func (UnimplementedIndexServer) IndexProperty(
 ) (*payload.Info_Index_PropertyDetail, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IndexProperty not implemented")
 }
+
+func (UnimplementedIndexServer) IndexSchedule(
+	context.Context, *payload.Empty,
+) (*payload.Info_Index_Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IndexSchedule not implemented")
+}
 func (UnimplementedIndexServer) mustEmbedUnimplementedIndexServer() {}
 
 // UnsafeIndexServer may be embedded to opt out of forward compatibility for this service.
@@ -289,6 +312,26 @@ func _Index_IndexProperty_Handler(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Index_IndexSchedule_Handler(
+	srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor,
+) (any, error) {
+	in := new(payload.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).IndexSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vald.v1.Index/IndexSchedule",
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(IndexServer).IndexSchedule(ctx, req.(*payload.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Index_ServiceDesc is the grpc.ServiceDesc for Index service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -316,6 +359,10 @@ var Index_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IndexProperty",
 			Handler:    _Index_IndexProperty_Handler,
 		},
+		{
+			MethodName: "IndexSchedule",
+			Handler:    _Index_IndexSchedule_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/vald/index.proto",
@@ -70,15 +70,16 @@ type ClientWithFilter interface {
 const PackageName = "vald.v1"
 
 const (
-	FilterRPCServiceName = "Filter"
-	FlushRPCServiceName  = "Flush"
-	IndexRPCServiceName  = "Index"
-	InsertRPCServiceName = "Insert"
-	ObjectRPCServiceName = "Object"
-	RemoveRPCServiceName = "Remove"
-	SearchRPCServiceName = "Search"
-	UpdateRPCServiceName = "Update"
-	UpsertRPCServiceName = "Upsert"
+	CollectionRPCServiceName = "Collection"
+	FilterRPCServiceName     = "Filter"
+	FlushRPCServiceName      = "Flush"
+	IndexRPCServiceName      = "Index"
+	InsertRPCServiceName     = "Insert"
+	ObjectRPCServiceName     = "Object"
+	RemoveRPCServiceName     = "Remove"
+	SearchRPCServiceName     = "Search"
+	UpdateRPCServiceName     = "Update"
+	UpsertRPCServiceName     = "Upsert"
 )
 
 const (
@@ -141,6 +142,11 @@ const (
 	IndexStatisticsRPCName       = "IndexStatistics"
 	IndexStatisticsDetailRPCName = "IndexStatisticsDetail"
 	IndexPropertyRPCName         = "IndexProperty"
+	IndexScheduleRPCName         = "IndexSchedule"
+
+	CreateCollectionRPCName = "CreateCollection"
+	DropCollectionRPCName   = "DropCollection"
+	ListCollectionsRPCName  = "ListCollections"
 )
 
 type client struct {
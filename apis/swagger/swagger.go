@@ -0,0 +1,124 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package swagger provides the OpenAPI documents generated from the vald protobuf definitions.
+package swagger
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"slices"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+)
+
+// FS holds every generated *.swagger.json document under the v1 directory.
+//
+//go:embed v1
+var FS embed.FS
+
+// ValdServices represents the swagger documents of the services served by the LB gateway.
+var ValdServices = []string{
+	"v1/vald/collection.swagger.json",
+	"v1/vald/flush.swagger.json",
+	"v1/vald/index.swagger.json",
+	"v1/vald/insert.swagger.json",
+	"v1/vald/object.swagger.json",
+	"v1/vald/remove.swagger.json",
+	"v1/vald/search.swagger.json",
+	"v1/vald/update.swagger.json",
+	"v1/vald/upsert.swagger.json",
+}
+
+// excludedPaths represents the paths of the documents in ValdServices which are served only by the index manager.
+var excludedPaths = map[string]struct{}{
+	"/index/schedule": {},
+}
+
+type document struct {
+	Swagger     string                    `json:"swagger"`
+	Info        map[string]any            `json:"info"`
+	Tags        []map[string]any          `json:"tags,omitempty"`
+	Consumes    []string                  `json:"consumes,omitempty"`
+	Produces    []string                  `json:"produces,omitempty"`
+	Paths       map[string]map[string]any `json:"paths"`
+	Definitions map[string]any            `json:"definitions"`
+}
+
+// Merge reads the swagger documents stored in fsys at the given names and
+// merges their paths, definitions and tags into a single OpenAPI v2 document titled title.
+// The paths served only by the index manager are not merged.
+func Merge(fsys fs.FS, title, version string, names ...string) ([]byte, error) {
+	merged := &document{
+		Swagger: "2.0",
+		Info: map[string]any{
+			"title":   title,
+			"version": version,
+		},
+		Paths:       make(map[string]map[string]any),
+		Definitions: make(map[string]any),
+	}
+	tags := make(map[string]map[string]any)
+	mimes := make(map[string]struct{})
+	for _, name := range names {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read swagger document %s", path.Clean(name))
+		}
+		var doc document
+		if err = json.Unmarshal(b, &doc); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode swagger document %s", path.Clean(name))
+		}
+		for p, ops := range doc.Paths {
+			if _, ok := excludedPaths[p]; ok {
+				continue
+			}
+			if _, ok := merged.Paths[p]; !ok {
+				merged.Paths[p] = make(map[string]any, len(ops))
+			}
+			for method, op := range ops {
+				merged.Paths[p][method] = op
+			}
+		}
+		for k, def := range doc.Definitions {
+			merged.Definitions[k] = def
+		}
+		for _, tag := range doc.Tags {
+			if n, ok := tag["name"].(string); ok {
+				tags[n] = tag
+			}
+		}
+		for _, mime := range append(doc.Consumes, doc.Produces...) {
+			mimes[mime] = struct{}{}
+		}
+	}
+	names = make([]string, 0, len(tags))
+	for n := range tags {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	for _, n := range names {
+		merged.Tags = append(merged.Tags, tags[n])
+	}
+	for mime := range mimes {
+		merged.Consumes = append(merged.Consumes, mime)
+	}
+	slices.Sort(merged.Consumes)
+	merged.Produces = merged.Consumes
+	return json.Marshal(merged)
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package swagger
+
+import (
+	"testing"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/test/goleak"
+)
+
+func TestMerge(t *testing.T) {
+	type args struct {
+		title   string
+		version string
+		names   []string
+	}
+	type test struct {
+		name      string
+		args      args
+		checkFunc func([]byte, error) error
+	}
+	tests := []test{
+		{
+			name: "returns merged document of all vald services",
+			args: args{
+				title:   "vald",
+				version: "v1",
+				names:   ValdServices,
+			},
+			checkFunc: func(b []byte, err error) error {
+				if err != nil {
+					return errors.Errorf("unexpected error: %v", err)
+				}
+				var doc document
+				if err := json.Unmarshal(b, &doc); err != nil {
+					return err
+				}
+				if doc.Swagger != "2.0" {
+					return errors.Errorf("swagger version not equals. want: 2.0, got: %s", doc.Swagger)
+				}
+				if got := doc.Info["title"]; got != "vald" {
+					return errors.Errorf("title not equals. want: vald, got: %v", got)
+				}
+				for _, p := range []string{
+					"/search",
+					"/linearsearch/id/multiple",
+					"/insert/multiple",
+					"/update/timestamp",
+					"/upsert",
+					"/remove/timestamp",
+					"/flush",
+					"/exists/{id}",
+					"/object/list",
+					"/object/meta/{id.id}",
+					"/index/property",
+					"/collection",
+					"/collection/{name}",
+				} {
+					if _, ok := doc.Paths[p]; !ok {
+						return errors.Errorf("path %s not found", p)
+					}
+				}
+				if _, ok := doc.Paths["/index/schedule"]; ok {
+					return errors.New("path /index/schedule served only by the index manager found")
+				}
+				if _, ok := doc.Definitions["rpcStatus"]; !ok {
+					return errors.New("definition rpcStatus not found")
+				}
+				return nil
+			},
+		},
+		{
+			name: "returns error when document does not exist",
+			args: args{
+				names: []string{"v1/vald/notfound.swagger.json"},
+			},
+			checkFunc: func(_ []byte, err error) error {
+				if err == nil {
+					return errors.New("expected error, got nil")
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			defer goleak.VerifyNone(tt, goleak.IgnoreCurrent())
+			got, err := Merge(FS, test.args.title, test.args.version, test.args.names...)
+			if err := test.checkFunc(got, err); err != nil {
+				tt.Errorf("error = %v", err)
+			}
+		})
+	}
+}
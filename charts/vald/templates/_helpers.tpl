@@ -335,6 +335,7 @@ servers:
       write_buffer_size: {{ default .default.servers.grpc.server.grpc.write_buffer_size .Values.servers.grpc.server.grpc.write_buffer_size }}
       read_buffer_size: {{ default .default.servers.grpc.server.grpc.read_buffer_size .Values.servers.grpc.server.grpc.read_buffer_size }}
       connection_timeout: {{ default .default.servers.grpc.server.grpc.connection_timeout .Values.servers.grpc.server.grpc.connection_timeout | quote }}
+      compressor: {{ default .default.servers.grpc.server.grpc.compressor .Values.servers.grpc.server.grpc.compressor | quote }}
       max_header_list_size: {{ default .default.servers.grpc.server.grpc.max_header_list_size .Values.servers.grpc.server.grpc.max_header_list_size }}
       header_table_size: {{ default .default.servers.grpc.server.grpc.header_table_size .Values.servers.grpc.server.grpc.header_table_size }}
       {{- if .Values.servers.grpc.server.grpc.interceptors }}
@@ -544,6 +545,7 @@ call_option:
   max_retry_rpc_buffer_size: {{ default .default.call_option.max_retry_rpc_buffer_size .Values.call_option.max_retry_rpc_buffer_size }}
   max_recv_msg_size: {{ default .default.call_option.max_recv_msg_size .Values.call_option.max_recv_msg_size }}
   max_send_msg_size: {{ default .default.call_option.max_send_msg_size .Values.call_option.max_send_msg_size }}
+  compressor: {{ default .default.call_option.compressor .Values.call_option.compressor | quote }}
   {{- else }}
   {{- toYaml .default.call_option | nindent 2 }}
   {{- end }}
@@ -0,0 +1,68 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides the authentication and authorization of API requests.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+
+	"github.com/vdaas/vald/internal/errors"
+)
+
+type apiKey struct {
+	keys map[[sha256.Size]byte]Identity
+}
+
+// APIKeyName is the name of the static API key authenticator.
+const APIKeyName = "apikey"
+
+// NewAPIKey returns the authenticator which identifies the caller by a static API key.
+// keys maps each API key to the identity it authenticates.
+func NewAPIKey(keys map[string]Identity) Authenticator {
+	a := &apiKey{
+		keys: make(map[[sha256.Size]byte]Identity, len(keys)),
+	}
+	for key, id := range keys {
+		if key != "" {
+			a.keys[sha256.Sum256([]byte(key))] = id
+		}
+	}
+	return a
+}
+
+func (*apiKey) Name() string {
+	return APIKeyName
+}
+
+func (a *apiKey) Authenticate(_ context.Context, creds *Credentials) (*Identity, error) {
+	if creds == nil || creds.APIKey == "" {
+		return nil, errors.ErrNoCredentials
+	}
+	// the keys are compared by their digests so that the lookup does not leak the key length or prefix.
+	sum := sha256.Sum256([]byte(creds.APIKey))
+	for digest, id := range a.keys {
+		if subtle.ConstantTimeCompare(digest[:], sum[:]) == 1 {
+			return &Identity{
+				Subject: id.Subject,
+				Groups:  id.Groups,
+			}, nil
+		}
+	}
+	return nil, errors.ErrInvalidAPIKey
+}
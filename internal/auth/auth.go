@@ -0,0 +1,130 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides the authentication and authorization of API requests.
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+type (
+	// Identity represents the authenticated caller of a request.
+	Identity struct {
+		Subject string
+		Groups  []string
+		// Method is the name of the authenticator which verified the identity.
+		Method string
+	}
+
+	// Credentials represents the credentials extracted from a request, independent of the transport.
+	Credentials struct {
+		// Token is the bearer token of the Authorization header.
+		Token string
+		// APIKey is the value of the API key header.
+		APIKey string
+		// Certificates is the verified client certificate chain, the leaf comes first.
+		Certificates []*x509.Certificate
+	}
+
+	// Authenticator verifies the credentials of a request.
+	// It returns errors.ErrNoCredentials when the request does not carry the kind of credentials it handles.
+	Authenticator interface {
+		Name() string
+		Authenticate(ctx context.Context, creds *Credentials) (*Identity, error)
+	}
+)
+
+const (
+	// AuthorizationKey is the metadata key and HTTP header carrying the bearer token.
+	AuthorizationKey = "authorization"
+	// APIKeyKey is the metadata key and HTTP header carrying the API key.
+	APIKeyKey = "x-api-key"
+
+	bearerPrefix = "bearer "
+
+	healthMethodPrefix = "/grpc.health.v1.Health/"
+)
+
+type identityKey struct{}
+
+// NewContext returns the context carrying the identity.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the identity stored in the context.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok && id != nil
+}
+
+type authorizerKey struct{}
+
+// Authorizer authorizes an operation of an authenticated request as the method with the IDs the operation carries.
+type Authorizer func(method string, ids []string) error
+
+// NewAuthorizerContext returns the context carrying the authorizer of the operations of the request,
+// which is used by the handlers serving several operations by a request, such as the GraphQL handler.
+func NewAuthorizerContext(ctx context.Context, a Authorizer) context.Context {
+	return context.WithValue(ctx, authorizerKey{}, a)
+}
+
+// AuthorizeOperation authorizes the operation of the request by the authorizer stored in the context.
+// It returns nil when the context has no authorizer, i.e. the request is not authorized per operation.
+func AuthorizeOperation(ctx context.Context, method string, ids []string) error {
+	a, ok := ctx.Value(authorizerKey{}).(Authorizer)
+	if !ok || a == nil {
+		return nil
+	}
+	return a(method, ids)
+}
+
+// IsExempt reports whether the method is served without authentication and authorization,
+// such as the health checks of the orchestrator.
+func IsExempt(method string) bool {
+	return strings.HasPrefix(method, healthMethodPrefix)
+}
+
+// BearerToken returns the token of a "Bearer" authorization value.
+func BearerToken(authorization string) string {
+	if len(authorization) > len(bearerPrefix) && strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return strings.TrimSpace(authorization[len(bearerPrefix):])
+	}
+	return ""
+}
+
+// Authenticate verifies the credentials with the authenticators in order and returns the first accepted identity.
+func Authenticate(ctx context.Context, creds *Credentials, authns ...Authenticator) (id *Identity, err error) {
+	for _, authn := range authns {
+		id, aerr := authn.Authenticate(ctx, creds)
+		if aerr == nil && id != nil {
+			id.Method = authn.Name()
+			return id, nil
+		}
+		if aerr != nil && !errors.Is(aerr, errors.ErrNoCredentials) {
+			err = errors.Join(err, errors.Wrap(aerr, authn.Name()))
+		}
+	}
+	if err != nil {
+		return nil, errors.Join(errors.ErrUnauthenticated, err)
+	}
+	return nil, errors.ErrUnauthenticated
+}
@@ -0,0 +1,120 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides the authentication and authorization of API requests.
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/vdaas/vald/internal/errors"
+)
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		creds  *Credentials
+		authns []Authenticator
+	}
+	type want struct {
+		subject string
+		method  string
+		err     error
+	}
+	type test struct {
+		name string
+		args args
+		want want
+	}
+	authns := []Authenticator{
+		NewMTLS(),
+		NewAPIKey(map[string]Identity{
+			"secret": {Subject: "indexer", Groups: []string{"writer"}},
+		}),
+	}
+	tests := []test{
+		{
+			name: "return the identity of the registered api key",
+			args: args{
+				creds:  &Credentials{APIKey: "secret"},
+				authns: authns,
+			},
+			want: want{
+				subject: "indexer",
+				method:  APIKeyName,
+			},
+		},
+		{
+			name: "return the identity of the client certificate before the api key",
+			args: args{
+				creds: &Credentials{
+					APIKey: "secret",
+					Certificates: []*x509.Certificate{
+						{Subject: pkix.Name{CommonName: "gateway", OrganizationalUnit: []string{"admin"}}},
+					},
+				},
+				authns: authns,
+			},
+			want: want{
+				subject: "gateway",
+				method:  MTLSName,
+			},
+		},
+		{
+			name: "return error when the api key is not registered",
+			args: args{
+				creds:  &Credentials{APIKey: "guess"},
+				authns: authns,
+			},
+			want: want{
+				err: errors.ErrInvalidAPIKey,
+			},
+		},
+		{
+			name: "return error when the request carries no credentials",
+			args: args{
+				creds:  new(Credentials),
+				authns: authns,
+			},
+			want: want{
+				err: errors.ErrUnauthenticated,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			id, err := Authenticate(context.Background(), test.args.creds, test.args.authns...)
+			if test.want.err != nil {
+				if !errors.Is(err, test.want.err) {
+					tt.Errorf("got error: %v, want: %v", err, test.want.err)
+				}
+				return
+			}
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if id.Subject != test.want.subject || id.Method != test.want.method {
+				tt.Errorf("got: %#v, want subject: %s, method: %s", id, test.want.subject, test.want.method)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides the authentication and authorization of API requests.
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"os"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+type jwtAuth struct {
+	path           string
+	issuer         string
+	audience       string
+	subjectClaim   string
+	groupsClaim    string
+	leeway         time.Duration
+	reloadInterval time.Duration
+
+	mu       sync.RWMutex
+	keys     []verificationKey
+	modTime  time.Time
+	reloaded time.Time
+}
+
+// JWTName is the name of the JWT authenticator.
+const JWTName = "jwt"
+
+// NewJWT returns the authenticator which verifies the bearer token as a JWT signed by a key of the local JWKS file.
+// The file is read again when a token refers to an unknown key id, so that rotated keys are picked up without restart.
+func NewJWT(opts ...JWTOption) (Authenticator, error) {
+	j := new(jwtAuth)
+	for _, opt := range append(defaultJWTOptions, opts...) {
+		if err := opt(j); err != nil {
+			werr := errors.ErrOptionFailed(err, reflect.ValueOf(opt))
+			e := new(errors.ErrCriticalOption)
+			if errors.As(err, &e) {
+				log.Error(werr)
+				return nil, werr
+			}
+			log.Warn(werr)
+		}
+	}
+	if j.path == "" {
+		return nil, errors.NewErrInvalidOption("jwksPath", j.path)
+	}
+	if err := j.reload(true); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (*jwtAuth) Name() string {
+	return JWTName
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func (j *jwtAuth) Authenticate(_ context.Context, creds *Credentials) (*Identity, error) {
+	if creds == nil || creds.Token == "" {
+		return nil, errors.ErrNoCredentials
+	}
+	parts := strings.Split(creds.Token, ".")
+	if len(parts) != 3 {
+		return nil, errors.ErrInvalidToken("malformed token")
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errors.ErrInvalidToken("malformed header")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.ErrInvalidToken("malformed signature")
+	}
+	key, err := j.key(header)
+	if err != nil {
+		return nil, err
+	}
+	if err = verify(header.Alg, key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+	var claims map[string]any
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, errors.ErrInvalidToken("malformed claims")
+	}
+	if err = j.validate(claims); err != nil {
+		return nil, err
+	}
+	id := &Identity{
+		Groups: claimStrings(claims[j.groupsClaim]),
+	}
+	id.Subject, _ = claims[j.subjectClaim].(string)
+	if id.Subject == "" {
+		return nil, errors.ErrInvalidToken("missing " + j.subjectClaim + " claim")
+	}
+	return id, nil
+}
+
+// key returns the verification key of the token, the JWKS file is reloaded once when the key id is unknown.
+func (j *jwtAuth) key(header jwtHeader) (crypto.PublicKey, error) {
+	for i := 0; i < 2; i++ {
+		j.mu.RLock()
+		for _, k := range j.keys {
+			if (header.Kid == "" || k.kid == header.Kid) && (k.alg == "" || k.alg == header.Alg) && compatible(header.Alg, k.key) {
+				j.mu.RUnlock()
+				return k.key, nil
+			}
+		}
+		j.mu.RUnlock()
+		if i == 0 {
+			if err := j.reload(false); err != nil {
+				log.Warnf("failed to reload jwks %s: %v", j.path, err)
+				break
+			}
+		}
+	}
+	return nil, errors.ErrJWKNotFound(header.Kid)
+}
+
+// reload reads the JWKS file when it has changed, at most once per reload interval unless force is set.
+func (j *jwtAuth) reload(force bool) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now()
+	if !force && now.Sub(j.reloaded) < j.reloadInterval {
+		return nil
+	}
+	j.reloaded = now
+	fi, err := os.Stat(j.path)
+	if err != nil {
+		return err
+	}
+	if !force && fi.ModTime().Equal(j.modTime) {
+		return nil
+	}
+	keys, err := loadJWKS(j.path)
+	if err != nil {
+		return err
+	}
+	j.keys, j.modTime = keys, fi.ModTime()
+	return nil
+}
+
+func (j *jwtAuth) validate(claims map[string]any) error {
+	now := time.Now()
+	exp, ok := claimTime(claims["exp"])
+	if !ok {
+		return errors.ErrInvalidToken("missing exp claim")
+	}
+	if now.After(exp.Add(j.leeway)) {
+		return errors.ErrInvalidToken("token is expired")
+	}
+	if nbf, ok := claimTime(claims["nbf"]); ok && now.Add(j.leeway).Before(nbf) {
+		return errors.ErrInvalidToken("token is not valid yet")
+	}
+	if j.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != j.issuer {
+			return errors.ErrInvalidToken("unexpected issuer")
+		}
+	}
+	if j.audience != "" && !slices.Contains(claimAudience(claims["aud"]), j.audience) {
+		return errors.ErrInvalidToken("unexpected audience")
+	}
+	return nil
+}
+
+func compatible(alg string, key crypto.PublicKey) bool {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		return strings.HasPrefix(alg, "ES")
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	}
+	return false
+}
+
+func verify(alg string, key crypto.PublicKey, input, sig []byte) error {
+	var h crypto.Hash
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		h = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		h = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		h = crypto.SHA512
+	case "EdDSA":
+		if pub, ok := key.(ed25519.PublicKey); ok && ed25519.Verify(pub, input, sig) {
+			return nil
+		}
+		return errors.ErrInvalidToken("signature mismatch")
+	default:
+		return errors.ErrUnsupportedTokenAlgorithm(alg)
+	}
+	hh := h.New()
+	hh.Write(input)
+	digest := hh.Sum(nil)
+	var err error
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if strings.HasPrefix(alg, "PS") {
+			err = rsa.VerifyPSS(pub, h, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			err = rsa.VerifyPKCS1v15(pub, h, digest, sig)
+		}
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size ||
+			!ecdsa.Verify(pub, digest, new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])) {
+			err = errors.New("ecdsa verification failed")
+		}
+	default:
+		err = errors.ErrUnsupportedTokenAlgorithm(alg)
+	}
+	if err != nil {
+		return errors.ErrInvalidToken("signature mismatch")
+	}
+	return nil
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func claimTime(v any) (time.Time, bool) {
+	if f, ok := v.(float64); ok {
+		return time.Unix(int64(f), 0), true
+	}
+	return time.Time{}, false
+}
+
+// claimAudience reads the aud claim which is either a single StringOrURI or an array of them (RFC 7519 section 4.1.3).
+func claimAudience(v any) []string {
+	if s, ok := v.(string); ok {
+		return []string{s}
+	}
+	return claimStrings(v)
+}
+
+// claimStrings reads a claim which is either a string array or a space separated string such as the scope claim.
+func claimStrings(v any) []string {
+	switch c := v.(type) {
+	case string:
+		return strings.Fields(c)
+	case []any:
+		ss := make([]string, 0, len(c))
+		for _, s := range c {
+			if s, ok := s.(string); ok {
+				ss = append(ss, s)
+			}
+		}
+		return ss
+	}
+	return nil
+}
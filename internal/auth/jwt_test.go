@@ -0,0 +1,231 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides the authentication and authorization of API requests.
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+)
+
+func signJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+	enc := func(v any) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	input := enc(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + enc(claims)
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(input))
+	sum := digest.Sum(nil)
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, sum)
+		if err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, sum)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestJWT_Authenticate(t *testing.T) {
+	t.Parallel()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := func(b []byte) string {
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa",
+				"alg": "RS256",
+				"n":   b64(rsaKey.N.Bytes()),
+				"e":   b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec",
+				"crv": "P-256",
+				"x":   b64(ecKey.X.FillBytes(make([]byte, 32))),
+				"y":   b64(ecKey.Y.FillBytes(make([]byte, 32))),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err = os.WriteFile(path, jwks, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	authn, err := NewJWT(
+		WithJWKSPath(path),
+		WithJWTIssuer("https://issuer.example"),
+		WithJWTAudience("vald"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := func(mod func(map[string]any)) map[string]any {
+		c := map[string]any{
+			"sub":    "alice",
+			"iss":    "https://issuer.example",
+			"aud":    []string{"vald", "other"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"reader"},
+		}
+		if mod != nil {
+			mod(c)
+		}
+		return c
+	}
+
+	type want struct {
+		subject string
+		groups  []string
+		err     bool
+	}
+	type test struct {
+		name  string
+		token string
+		want  want
+	}
+	tests := []test{
+		{
+			name:  "return the identity of the token signed by the rsa key",
+			token: signJWT(t, "RS256", "rsa", rsaKey, claims(nil)),
+			want: want{
+				subject: "alice",
+				groups:  []string{"reader"},
+			},
+		},
+		{
+			name:  "return the identity of the token signed by the ec key",
+			token: signJWT(t, "ES256", "ec", ecKey, claims(nil)),
+			want: want{
+				subject: "alice",
+				groups:  []string{"reader"},
+			},
+		},
+		{
+			name:  "return error when the token is signed by an unknown key",
+			token: signJWT(t, "ES256", "ec", otherKey, claims(nil)),
+			want: want{
+				err: true,
+			},
+		},
+		{
+			name:  "return error when the token is expired",
+			token: signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() })),
+			want: want{
+				err: true,
+			},
+		},
+		{
+			name:  "return the identity of the token whose audience is a single string",
+			token: signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { c["aud"] = "vald" })),
+			want: want{
+				subject: "alice",
+				groups:  []string{"reader"},
+			},
+		},
+		{
+			name:  "return error when the audience string is not split on whitespace",
+			token: signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { c["aud"] = "vald other" })),
+			want: want{
+				err: true,
+			},
+		},
+		{
+			name:  "return error when the audience does not match",
+			token: signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { c["aud"] = "other" })),
+			want: want{
+				err: true,
+			},
+		},
+		{
+			name:  "return error when the issuer does not match",
+			token: signJWT(t, "RS256", "rsa", rsaKey, claims(func(c map[string]any) { c["iss"] = "https://evil.example" })),
+			want: want{
+				err: true,
+			},
+		},
+		{
+			name:  "return error when the token uses an unsupported algorithm",
+			token: b64([]byte(`{"alg":"none"}`)) + "." + b64([]byte(`{"sub":"alice"}`)) + ".",
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			id, err := authn.Authenticate(context.Background(), &Credentials{Token: test.token})
+			if test.want.err {
+				if err == nil {
+					tt.Errorf("got no error, identity: %#v", id)
+				}
+				return
+			}
+			if err != nil {
+				tt.Fatalf("unexpected error: %v", err)
+			}
+			if id.Subject != test.want.subject || len(id.Groups) != len(test.want.groups) || id.Groups[0] != test.want.groups[0] {
+				tt.Error(errors.Errorf("got: %#v, want: %#v", id, test.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides the authentication and authorization of API requests.
+package auth
+
+import (
+	"context"
+
+	"github.com/vdaas/vald/internal/errors"
+)
+
+type mtls struct{}
+
+// MTLSName is the name of the client certificate authenticator.
+const MTLSName = "mtls"
+
+// NewMTLS returns the authenticator which identifies the caller by the client certificate verified during the TLS handshake.
+// The subject is the certificate common name, or its first URI, DNS or email SAN when the common name is empty,
+// and the groups are the organizational units of the certificate subject.
+func NewMTLS() Authenticator {
+	return new(mtls)
+}
+
+func (*mtls) Name() string {
+	return MTLSName
+}
+
+func (*mtls) Authenticate(_ context.Context, creds *Credentials) (*Identity, error) {
+	if creds == nil || len(creds.Certificates) == 0 || creds.Certificates[0] == nil {
+		return nil, errors.ErrNoCredentials
+	}
+	cert := creds.Certificates[0]
+	id := &Identity{
+		Subject: cert.Subject.CommonName,
+		Groups:  cert.Subject.OrganizationalUnit,
+	}
+	switch {
+	case id.Subject != "":
+	case len(cert.URIs) != 0:
+		id.Subject = cert.URIs[0].String()
+	case len(cert.DNSNames) != 0:
+		id.Subject = cert.DNSNames[0]
+	case len(cert.EmailAddresses) != 0:
+		id.Subject = cert.EmailAddresses[0]
+	default:
+		return nil, errors.ErrNoClientCertificate
+	}
+	return id, nil
+}
@@ -0,0 +1,112 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides the authentication and authorization of API requests.
+package auth
+
+import (
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/timeutil"
+)
+
+// JWTOption represents the functional option for the JWT authenticator.
+type JWTOption func(*jwtAuth) error
+
+var defaultJWTOptions = []JWTOption{
+	WithJWTSubjectClaim("sub"),
+	WithJWTGroupsClaim("groups"),
+	WithJWTLeeway("30s"),
+	WithJWKSReloadInterval("10s"),
+}
+
+// WithJWKSPath returns the option to set the path of the JWKS file.
+func WithJWKSPath(path string) JWTOption {
+	return func(j *jwtAuth) error {
+		if path == "" {
+			return errors.NewErrCriticalOption("jwksPath", path)
+		}
+		j.path = path
+		return nil
+	}
+}
+
+// WithJWTIssuer returns the option to set the issuer the iss claim must match.
+func WithJWTIssuer(iss string) JWTOption {
+	return func(j *jwtAuth) error {
+		j.issuer = iss
+		return nil
+	}
+}
+
+// WithJWTAudience returns the option to set the audience the aud claim must contain.
+func WithJWTAudience(aud string) JWTOption {
+	return func(j *jwtAuth) error {
+		j.audience = aud
+		return nil
+	}
+}
+
+// WithJWTSubjectClaim returns the option to set the claim used as the identity subject.
+func WithJWTSubjectClaim(claim string) JWTOption {
+	return func(j *jwtAuth) error {
+		if claim == "" {
+			return errors.NewErrInvalidOption("subjectClaim", claim)
+		}
+		j.subjectClaim = claim
+		return nil
+	}
+}
+
+// WithJWTGroupsClaim returns the option to set the claim used as the identity groups.
+func WithJWTGroupsClaim(claim string) JWTOption {
+	return func(j *jwtAuth) error {
+		if claim == "" {
+			return errors.NewErrInvalidOption("groupsClaim", claim)
+		}
+		j.groupsClaim = claim
+		return nil
+	}
+}
+
+// WithJWTLeeway returns the option to set the allowed clock skew of the exp and nbf claims.
+func WithJWTLeeway(dur string) JWTOption {
+	return func(j *jwtAuth) error {
+		if dur == "" {
+			return errors.NewErrInvalidOption("leeway", dur)
+		}
+		d, err := timeutil.Parse(dur)
+		if err != nil {
+			return errors.NewErrInvalidOption("leeway", dur, err)
+		}
+		j.leeway = d
+		return nil
+	}
+}
+
+// WithJWKSReloadInterval returns the option to set the minimum interval between JWKS file reloads.
+func WithJWKSReloadInterval(dur string) JWTOption {
+	return func(j *jwtAuth) error {
+		if dur == "" {
+			return errors.NewErrInvalidOption("reloadInterval", dur)
+		}
+		d, err := timeutil.Parse(dur)
+		if err != nil {
+			return errors.NewErrInvalidOption("reloadInterval", dur, err)
+		}
+		j.reloadInterval = d
+		return nil
+	}
+}
@@ -0,0 +1,135 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides the authentication and authorization of API requests.
+package auth
+
+import (
+	"path"
+	"slices"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+type (
+	// Rule grants the identities it matches access to a set of methods, collections and ID prefixes.
+	// Empty lists match everything, and a rule without subjects and groups applies to every authenticated identity.
+	Rule struct {
+		Subjects []string
+		Groups   []string
+		// Methods are path.Match patterns of gRPC full method names such as "/vald.v1.Search/*",
+		// or of REST paths for the endpoints which have no gRPC binding. "*" matches every method.
+		Methods []string
+		// Collections are the collection names the rule grants, "" is the default index and "*" is every collection.
+		Collections []string
+		// IDPrefixes restricts the vector IDs the request may carry.
+		// A rule with prefixes only grants requests whose IDs are all known and all match one of them.
+		IDPrefixes []string
+	}
+
+	// Request represents the attributes of a request evaluated by the policy.
+	Request struct {
+		Method     string
+		Collection string
+		IDs        []string
+		// CheckIDs reports whether IDs holds the request IDs. Stream RPCs are first authorized without the IDs,
+		// which are then checked for every received message.
+		CheckIDs bool
+	}
+
+	// Policy decides whether an identity is allowed to perform a request.
+	Policy interface {
+		Authorize(id *Identity, req *Request) error
+	}
+
+	policy struct {
+		rules []Rule
+	}
+)
+
+// NewPolicy returns the policy which allows a request when any of the rules grants it, and denies it otherwise.
+func NewPolicy(rules ...Rule) Policy {
+	return &policy{
+		rules: rules,
+	}
+}
+
+func (p *policy) Authorize(id *Identity, req *Request) error {
+	if id == nil {
+		return errors.ErrUnauthenticated
+	}
+	for i := range p.rules {
+		if p.rules[i].grants(id, req) {
+			return nil
+		}
+	}
+	return errors.ErrPermissionDenied(id.Subject, req.Method)
+}
+
+func (r *Rule) grants(id *Identity, req *Request) bool {
+	return r.matchIdentity(id) &&
+		r.matchMethod(req.Method) &&
+		(len(r.Collections) == 0 || slices.Contains(r.Collections, "*") || slices.Contains(r.Collections, req.Collection)) &&
+		r.matchIDs(req)
+}
+
+func (r *Rule) matchIdentity(id *Identity) bool {
+	if len(r.Subjects) == 0 && len(r.Groups) == 0 {
+		return true
+	}
+	if slices.Contains(r.Subjects, "*") || slices.Contains(r.Subjects, id.Subject) {
+		return true
+	}
+	for _, g := range id.Groups {
+		if slices.Contains(r.Groups, g) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchMethod(method string) bool {
+	if len(r.Methods) == 0 {
+		return true
+	}
+	for _, pattern := range r.Methods {
+		if pattern == "*" || pattern == method {
+			return true
+		}
+		if ok, err := path.Match(pattern, method); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchIDs(req *Request) bool {
+	if len(r.IDPrefixes) == 0 || !req.CheckIDs {
+		return true
+	}
+	if len(req.IDs) == 0 {
+		return false
+	}
+	for _, id := range req.IDs {
+		if !slices.ContainsFunc(r.IDPrefixes, func(prefix string) bool {
+			return strings.HasPrefix(id, prefix)
+		}) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,196 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides the authentication and authorization of API requests.
+package auth
+
+import (
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+)
+
+func TestPolicy_Authorize(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		id  *Identity
+		req *Request
+	}
+	type test struct {
+		name  string
+		rules []Rule
+		args  args
+		want  bool
+	}
+	reader := Rule{
+		Groups:  []string{"reader"},
+		Methods: []string{"/vald.v1.Search/*", "/vald.v1.Object/*"},
+	}
+	tenant := Rule{
+		Subjects:    []string{"tenant-a"},
+		Methods:     []string{"/vald.v1.Insert/*"},
+		Collections: []string{"images"},
+		IDPrefixes:  []string{"a-"},
+	}
+	tests := []test{
+		{
+			name:  "allow the method matched by the rule of the identity group",
+			rules: []Rule{reader},
+			args: args{
+				id:  &Identity{Subject: "alice", Groups: []string{"reader"}},
+				req: &Request{Method: "/vald.v1.Search/Search", CheckIDs: true},
+			},
+			want: true,
+		},
+		{
+			name:  "deny the method not matched by any rule",
+			rules: []Rule{reader},
+			args: args{
+				id:  &Identity{Subject: "alice", Groups: []string{"reader"}},
+				req: &Request{Method: "/vald.v1.Flush/Flush", CheckIDs: true},
+			},
+		},
+		{
+			name:  "deny the identity not matched by any rule",
+			rules: []Rule{reader},
+			args: args{
+				id:  &Identity{Subject: "bob"},
+				req: &Request{Method: "/vald.v1.Search/Search", CheckIDs: true},
+			},
+		},
+		{
+			name:  "allow every authenticated identity by the rule without subjects and groups",
+			rules: []Rule{{Methods: []string{"*"}}},
+			args: args{
+				id:  &Identity{Subject: "bob"},
+				req: &Request{Method: "/vald.v1.Flush/Flush", CheckIDs: true},
+			},
+			want: true,
+		},
+		{
+			name:  "allow the request whose ids all match the prefixes in the granted collection",
+			rules: []Rule{tenant},
+			args: args{
+				id:  &Identity{Subject: "tenant-a"},
+				req: &Request{Method: "/vald.v1.Insert/MultiInsert", Collection: "images", IDs: []string{"a-1", "a-2"}, CheckIDs: true},
+			},
+			want: true,
+		},
+		{
+			name:  "deny the request carrying an id outside the prefixes",
+			rules: []Rule{tenant},
+			args: args{
+				id:  &Identity{Subject: "tenant-a"},
+				req: &Request{Method: "/vald.v1.Insert/MultiInsert", Collection: "images", IDs: []string{"a-1", "b-2"}, CheckIDs: true},
+			},
+		},
+		{
+			name:  "deny the request without ids when the rule restricts prefixes",
+			rules: []Rule{tenant},
+			args: args{
+				id:  &Identity{Subject: "tenant-a"},
+				req: &Request{Method: "/vald.v1.Insert/Insert", Collection: "images", CheckIDs: true},
+			},
+		},
+		{
+			name:  "allow the stream to be opened before the ids are known",
+			rules: []Rule{tenant},
+			args: args{
+				id:  &Identity{Subject: "tenant-a"},
+				req: &Request{Method: "/vald.v1.Insert/StreamInsert", Collection: "images"},
+			},
+			want: true,
+		},
+		{
+			name:  "deny the collection not granted by the rule",
+			rules: []Rule{tenant},
+			args: args{
+				id:  &Identity{Subject: "tenant-a"},
+				req: &Request{Method: "/vald.v1.Insert/Insert", IDs: []string{"a-1"}, CheckIDs: true},
+			},
+		},
+		{
+			name: "deny every request when no rule is configured",
+			args: args{
+				id:  &Identity{Subject: "alice"},
+				req: &Request{Method: "/vald.v1.Search/Search", CheckIDs: true},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			err := NewPolicy(test.rules...).Authorize(test.args.id, test.args.req)
+			if got := err == nil; got != test.want {
+				tt.Errorf("got allowed: %v, want: %v, err: %v", got, test.want, err)
+			}
+		})
+	}
+}
+
+func TestRequestIDs(t *testing.T) {
+	t.Parallel()
+	type test struct {
+		name string
+		req  any
+		want []string
+	}
+	tests := []test{
+		{
+			name: "return the id of the object id",
+			req:  &payload.Object_ID{Id: "a-1"},
+			want: []string{"a-1"},
+		},
+		{
+			name: "return the vector ids of the multi insert request",
+			req: &payload.Insert_MultiRequest{
+				Requests: []*payload.Insert_Request{
+					{Vector: &payload.Object_Vector{Id: "a-1"}},
+					{Vector: &payload.Object_Vector{Id: "a-2"}},
+				},
+			},
+			want: []string{"a-1", "a-2"},
+		},
+		{
+			name: "return no id for the search by vector request",
+			req:  &payload.Search_Request{Vector: []float32{1, 2}},
+		},
+		{
+			name: "return no id for the request which is not a proto message",
+			req:  "a-1",
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			got := RequestIDs(test.req)
+			if len(got) != len(test.want) {
+				tt.Errorf("got: %v, want: %v", got, test.want)
+				return
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					tt.Error(errors.Errorf("got: %v, want: %v", got, test.want))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides the authentication and authorization of API requests.
+package auth
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RequestIDs returns the vector IDs carried by a request message.
+// Every string field named "id" and repeated string field named "ids" is collected, including the nested and repeated messages,
+// so that single, multi and object requests are covered by the same walk.
+func RequestIDs(req any) []string {
+	msg, ok := req.(proto.Message)
+	if !ok || msg == nil {
+		return nil
+	}
+	var ids []string
+	collectIDs(msg.ProtoReflect(), &ids)
+	return ids
+}
+
+func collectIDs(m protoreflect.Message, ids *[]string) {
+	if !m.IsValid() {
+		return
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+		case fd.Kind() == protoreflect.StringKind && fd.IsList() && fd.Name() == "ids":
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				*ids = append(*ids, list.Get(i).String())
+			}
+		case fd.Kind() == protoreflect.StringKind && fd.Name() == "id":
+			*ids = append(*ids, v.String())
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				collectIDs(list.Get(i).Message(), ids)
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			collectIDs(v.Message(), ids)
+		}
+		return true
+	})
+}
@@ -18,10 +18,8 @@
 package discoverer
 
 import (
-	"cmp"
 	"context"
 	"reflect"
-	"slices"
 	"sync/atomic"
 	"time"
 
@@ -48,6 +46,12 @@ type Client interface {
 	// Internally, this API round robin between c.client and c.readClient with the ratio of
 	// agent replicas and read replica agent replicas.
 	GetReadClient() grpc.Client
+
+	// GetTopology returns the topology of the agent serving addr, or nil when it is unknown.
+	GetTopology(addr string) *payload.Info_Topology
+
+	// GetNodeTopology returns the topology of the named node, or nil when it is unknown.
+	GetNodeTopology(name string) *payload.Info_Topology
 }
 
 type client struct {
@@ -66,6 +70,8 @@ type client struct {
 	name         string
 	namespace    string
 	nodeName     string
+	topologies   atomic.Pointer[map[string]*payload.Info_Topology]
+	nodeTopology atomic.Pointer[map[string]*payload.Info_Topology]
 	// read replica related members below
 	readClient          grpc.Client
 	readReplicaReplicas uint64
@@ -229,6 +235,20 @@ func (c *client) GetReadClient() grpc.Client {
 	return c.readClient
 }
 
+func (c *client) GetTopology(addr string) *payload.Info_Topology {
+	if t := c.topologies.Load(); t != nil {
+		return (*t)[addr]
+	}
+	return nil
+}
+
+func (c *client) GetNodeTopology(name string) *payload.Info_Topology {
+	if t := c.nodeTopology.Load(); t != nil {
+		return (*t)[name]
+	}
+	return nil
+}
+
 func (c *client) connect(ctx context.Context, addr string) (err error) {
 	if c.autoconn && c.client != nil {
 		_, err = c.client.Connect(ctx, addr, c.client.GetDialOption()...)
@@ -356,12 +376,7 @@ func (c *client) discoverNodes(ctx context.Context) (nodes *payload.Info_Nodes,
 	if err != nil {
 		return nil, err
 	}
-	slices.SortFunc(nodes.Nodes, func(left, right *payload.Info_Node) int {
-		if left.GetMemory() == nil || right.GetMemory() == nil {
-			return 0 // Default comparison value; adjust as needed.
-		}
-		return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
-	})
+	// nodes and their pods are already ordered by the ranking strategy of the discoverer.
 	return nodes, nil
 }
 
@@ -373,22 +388,23 @@ func (c *client) discoverAddrs(
 	}
 	maxPodLen := 0
 	podLength := 0
-	for i, node := range nodes.GetNodes() {
+	for _, node := range nodes.GetNodes() {
 		if node != nil && node.GetPods() != nil && node.GetPods().GetPods() != nil {
 			l := len(node.GetPods().GetPods())
 			podLength += l
 			if l > maxPodLen {
 				maxPodLen = l
 			}
-			slices.SortFunc(nodes.Nodes[i].Pods.Pods, func(left, right *payload.Info_Pod) int {
-				if left.GetMemory() == nil || right.GetMemory() == nil {
-					return 0 // Default comparison value; adjust as needed.
-				}
-				return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
-			})
 		}
 	}
 	addrs = make([]string, 0, podLength)
+	topologies := make(map[string]*payload.Info_Topology, podLength)
+	nodeTopology := make(map[string]*payload.Info_Topology, len(nodes.GetNodes()))
+	for _, node := range nodes.GetNodes() {
+		if node.GetTopology() != nil {
+			nodeTopology[node.GetName()] = node.GetTopology()
+		}
+	}
 	for i := 0; i < maxPodLen; i++ {
 		for _, node := range nodes.GetNodes() {
 			select {
@@ -405,12 +421,19 @@ func (c *client) discoverAddrs(
 						err = nil
 					} else {
 						addrs = append(addrs, addr)
+						if t := node.GetPods().GetPods()[i].GetTopology(); t != nil {
+							topologies[addr] = t
+						} else if node.GetTopology() != nil {
+							topologies[addr] = node.GetTopology()
+						}
 					}
 
 				}
 			}
 		}
 	}
+	c.topologies.Store(&topologies)
+	c.nodeTopology.Store(&nodeTopology)
 	return addrs, nil
 }
 
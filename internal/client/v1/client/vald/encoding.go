@@ -0,0 +1,59 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package vald provides vald gRPC client library
+package vald
+
+import (
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+// VectorEncoder represents the requests whose vectors can be sent in a compact encoding.
+type VectorEncoder[M any] interface {
+	CloneVT() M
+	EncodeVectors(payload.Object_Vector_Encoding) error
+}
+
+// EncodeVectors returns a copy of in whose vectors are encoded with enc, in itself is not modified.
+// in is returned as it is when enc is FLOAT32.
+func EncodeVectors[M VectorEncoder[M]](in M, enc payload.Object_Vector_Encoding) (M, error) {
+	if enc == payload.Object_Vector_FLOAT32 {
+		return in, nil
+	}
+	out := in.CloneVT()
+	if err := out.EncodeVectors(enc); err != nil {
+		var zero M
+		return zero, err
+	}
+	return out, nil
+}
+
+// ParseVectorEncoding returns the vector encoding of the name.
+func ParseVectorEncoding(name string) (payload.Object_Vector_Encoding, error) {
+	switch strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name)) {
+	case "", "float32", "float", "f32":
+		return payload.Object_Vector_FLOAT32, nil
+	case "float16", "half", "f16", "fp16":
+		return payload.Object_Vector_FLOAT16, nil
+	case "int8", "i8":
+		return payload.Object_Vector_INT8, nil
+	case "bytes", "raw":
+		return payload.Object_Vector_BYTES, nil
+	}
+	return payload.Object_Vector_FLOAT32, errors.ErrUnsupportedVectorEncoding(name)
+}
@@ -0,0 +1,261 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package vald provides vald gRPC client library
+package vald
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+)
+
+func TestEncodeVectors(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		in  *payload.Insert_Request
+		enc payload.Object_Vector_Encoding
+	}
+	type want struct {
+		encoded *payload.Object_Vector
+		decoded []float32
+		delta   float64
+		err     error
+	}
+	type test struct {
+		name      string
+		args      args
+		want      want
+		checkFunc func(test, *payload.Insert_Request, error) error
+	}
+	defaultCheckFunc := func(t test, got *payload.Insert_Request, err error) error {
+		if !errors.Is(err, t.want.err) {
+			return errors.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, t.want.err)
+		}
+		if err != nil {
+			return nil
+		}
+		if t.want.encoded != nil && !got.GetVector().EqualVT(t.want.encoded) {
+			return errors.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", got.GetVector(), t.want.encoded)
+		}
+		if got.GetVector().GetEncoding() != t.args.enc {
+			return errors.Errorf("got encoding: %s, want: %s", got.GetVector().GetEncoding(), t.args.enc)
+		}
+		if t.args.enc != payload.Object_Vector_FLOAT32 && len(t.args.in.GetVector().GetVector()) != len(t.want.decoded) {
+			return errors.New("the original request is modified")
+		}
+		// decode through the same path as the gRPC codec to check the round trip.
+		b, err := got.MarshalVT()
+		if err != nil {
+			return err
+		}
+		dec := new(payload.Insert_Request)
+		if err := dec.UnmarshalVT(b); err != nil {
+			return err
+		}
+		if err := dec.DecodeVectors(); err != nil {
+			return err
+		}
+		vec := dec.GetVector().GetVector()
+		if len(vec) != len(t.want.decoded) {
+			return errors.Errorf("got dimension: %d, want: %d", len(vec), len(t.want.decoded))
+		}
+		for i := range vec {
+			if math.Abs(float64(vec[i]-t.want.decoded[i])) > t.want.delta {
+				return errors.Errorf("got: %v,\n\t\t\t\twant: %v", vec, t.want.decoded)
+			}
+		}
+		return nil
+	}
+	newReq := func(vec ...float32) *payload.Insert_Request {
+		return &payload.Insert_Request{
+			Vector: &payload.Object_Vector{
+				Id:     "1",
+				Vector: vec,
+			},
+		}
+	}
+	tests := []test{
+		{
+			name: "return the request as it is when the encoding is float32",
+			args: args{
+				in:  newReq(0.5, -1.25),
+				enc: payload.Object_Vector_FLOAT32,
+			},
+			want: want{
+				encoded: newReq(0.5, -1.25).GetVector(),
+				decoded: []float32{0.5, -1.25},
+			},
+		},
+		{
+			name: "return the float16 encoded request",
+			args: args{
+				in:  newReq(1, -2.5, 65504, 1.0/(1<<24)),
+				enc: payload.Object_Vector_FLOAT16,
+			},
+			want: want{
+				encoded: &payload.Object_Vector{
+					Id:       "1",
+					Encoding: payload.Object_Vector_FLOAT16,
+					Data:     []byte{0x00, 0x3c, 0x00, 0xc1, 0xff, 0x7b, 0x01, 0x00},
+				},
+				decoded: []float32{1, -2.5, 65504, 1.0 / (1 << 24)},
+			},
+		},
+		{
+			name: "return the float16 encoded request rounded to the nearest value",
+			args: args{
+				in:  newReq(0.1, 1e6, -1e-10),
+				enc: payload.Object_Vector_FLOAT16,
+			},
+			want: want{
+				decoded: []float32{0.1, float32(math.Inf(1)), 0},
+				delta:   1e-4,
+			},
+		},
+		{
+			name: "return the int8 encoded request",
+			args: args{
+				in:  newReq(1.27, -0.635, 0),
+				enc: payload.Object_Vector_INT8,
+			},
+			want: want{
+				decoded: []float32{1.27, -0.635, 0},
+				delta:   0.01,
+			},
+		},
+		{
+			name: "return the int8 encoded request of the zero vector",
+			args: args{
+				in:  newReq(0, 0),
+				enc: payload.Object_Vector_INT8,
+			},
+			want: want{
+				encoded: &payload.Object_Vector{
+					Id:       "1",
+					Encoding: payload.Object_Vector_INT8,
+					Data:     []byte{0, 0},
+				},
+				decoded: []float32{0, 0},
+			},
+		},
+		{
+			name: "return the bytes encoded request",
+			args: args{
+				in:  newReq(1, -0.1),
+				enc: payload.Object_Vector_BYTES,
+			},
+			want: want{
+				encoded: &payload.Object_Vector{
+					Id:       "1",
+					Encoding: payload.Object_Vector_BYTES,
+					Data:     []byte{0x00, 0x00, 0x80, 0x3f, 0xcd, 0xcc, 0xcc, 0xbd},
+				},
+				decoded: []float32{1, -0.1},
+			},
+		},
+		{
+			name: "return error when the encoding is unknown",
+			args: args{
+				in:  newReq(1, 2),
+				enc: payload.Object_Vector_Encoding(10),
+			},
+			want: want{
+				err: errors.ErrUnsupportedVectorEncoding("10"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			checkFunc := test.checkFunc
+			if test.checkFunc == nil {
+				checkFunc = defaultCheckFunc
+			}
+
+			got, err := EncodeVectors(test.args.in, test.args.enc)
+			if err := checkFunc(test, got, err); err != nil {
+				tt.Errorf("error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseVectorEncoding(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		want payload.Object_Vector_Encoding
+		err  error
+	}
+	tests := []struct {
+		name string
+		arg  string
+		want want
+	}{
+		{
+			name: "return float32 when the name is empty",
+			want: want{
+				want: payload.Object_Vector_FLOAT32,
+			},
+		},
+		{
+			name: "return float16 when the name is fp16",
+			arg:  "FP16",
+			want: want{
+				want: payload.Object_Vector_FLOAT16,
+			},
+		},
+		{
+			name: "return int8 when the name is int8",
+			arg:  "int8",
+			want: want{
+				want: payload.Object_Vector_INT8,
+			},
+		},
+		{
+			name: "return bytes when the name is raw",
+			arg:  "raw",
+			want: want{
+				want: payload.Object_Vector_BYTES,
+			},
+		},
+		{
+			name: "return error when the name is unknown",
+			arg:  "bfloat16",
+			want: want{
+				err: errors.ErrUnsupportedVectorEncoding("bfloat16"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			got, err := ParseVectorEncoding(test.arg)
+			if !errors.Is(err, test.want.err) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want.err)
+			}
+			if got != test.want.want {
+				tt.Errorf("got: %s, want: %s", got, test.want.want)
+			}
+		})
+	}
+}
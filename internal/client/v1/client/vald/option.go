@@ -17,7 +17,10 @@
 // Package vald provides vald gRPC client library
 package vald
 
-import "github.com/vdaas/vald/internal/net/grpc"
+import (
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/net/grpc"
+)
 
 type Option func(*client) error
 
@@ -45,3 +48,12 @@ func WithClient(cl grpc.Client) Option {
 		return nil
 	}
 }
+
+// WithVectorEncoding returns the option to set the encoding of the vectors sent by the unary and multi insert, update and upsert RPCs.
+// Stream RPCs send the requests as they are, use EncodeVectors to encode them.
+func WithVectorEncoding(enc payload.Object_Vector_Encoding) Option {
+	return func(c *client) error {
+		c.enc = enc
+		return nil
+	}
+}
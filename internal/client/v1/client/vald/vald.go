@@ -38,6 +38,7 @@ type Client interface {
 type client struct {
 	addrs []string
 	c     grpc.Client
+	enc   payload.Object_Vector_Encoding
 }
 
 type singleClient struct {
@@ -376,6 +377,10 @@ func (c *client) Insert(
 			span.End()
 		}
 	}()
+	in, err = EncodeVectors(in, c.enc)
+	if err != nil {
+		return nil, err
+	}
 	_, err = c.c.RoundRobin(ctx, func(ctx context.Context,
 		conn *grpc.ClientConn,
 		copts ...grpc.CallOption,
@@ -420,6 +425,10 @@ func (c *client) MultiInsert(
 			span.End()
 		}
 	}()
+	in, err = EncodeVectors(in, c.enc)
+	if err != nil {
+		return nil, err
+	}
 	_, err = c.c.RoundRobin(ctx, func(ctx context.Context,
 		conn *grpc.ClientConn,
 		copts ...grpc.CallOption,
@@ -442,6 +451,10 @@ func (c *client) Update(
 			span.End()
 		}
 	}()
+	in, err = EncodeVectors(in, c.enc)
+	if err != nil {
+		return nil, err
+	}
 	_, err = c.c.RoundRobin(ctx, func(ctx context.Context,
 		conn *grpc.ClientConn,
 		copts ...grpc.CallOption,
@@ -486,6 +499,10 @@ func (c *client) MultiUpdate(
 			span.End()
 		}
 	}()
+	in, err = EncodeVectors(in, c.enc)
+	if err != nil {
+		return nil, err
+	}
 	_, err = c.c.RoundRobin(ctx, func(ctx context.Context,
 		conn *grpc.ClientConn,
 		copts ...grpc.CallOption,
@@ -530,6 +547,10 @@ func (c *client) Upsert(
 			span.End()
 		}
 	}()
+	in, err = EncodeVectors(in, c.enc)
+	if err != nil {
+		return nil, err
+	}
 	_, err = c.c.RoundRobin(ctx, func(ctx context.Context,
 		conn *grpc.ClientConn,
 		copts ...grpc.CallOption,
@@ -574,6 +595,10 @@ func (c *client) MultiUpsert(
 			span.End()
 		}
 	}()
+	in, err = EncodeVectors(in, c.enc)
+	if err != nil {
+		return nil, err
+	}
 	_, err = c.c.RoundRobin(ctx, func(ctx context.Context,
 		conn *grpc.ClientConn,
 		copts ...grpc.CallOption,
@@ -872,6 +897,28 @@ func (c *client) IndexProperty(
 	return res, nil
 }
 
+func (c *client) IndexSchedule(
+	ctx context.Context, in *payload.Empty, opts ...grpc.CallOption,
+) (res *payload.Info_Index_Schedule, err error) {
+	ctx, span := trace.StartSpan(grpc.WrapGRPCMethod(ctx, "internal/client/"+vald.IndexScheduleRPCName), apiName+"/"+vald.IndexScheduleRPCName)
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	_, err = c.c.RoundRobin(ctx, func(ctx context.Context,
+		conn *grpc.ClientConn,
+		copts ...grpc.CallOption,
+	) (any, error) {
+		res, err = vald.NewValdClient(conn).IndexSchedule(ctx, in, append(copts, opts...)...)
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (c *client) GetTimestamp(
 	ctx context.Context, in *payload.Object_TimestampRequest, opts ...grpc.CallOption,
 ) (res *payload.Object_Timestamp, err error) {
@@ -1338,6 +1385,18 @@ func (c *singleClient) IndexProperty(
 	return c.vc.IndexProperty(ctx, in, opts...)
 }
 
+func (c *singleClient) IndexSchedule(
+	ctx context.Context, in *payload.Empty, opts ...grpc.CallOption,
+) (res *payload.Info_Index_Schedule, err error) {
+	ctx, span := trace.StartSpan(grpc.WrapGRPCMethod(ctx, "internal/singleClient/"+vald.IndexScheduleRPCName), apiName+"/"+vald.IndexScheduleRPCName)
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	return c.vc.IndexSchedule(ctx, in, opts...)
+}
+
 func (c *singleClient) GetTimestamp(
 	ctx context.Context, in *payload.Object_TimestampRequest, opts ...grpc.CallOption,
 ) (res *payload.Object_Timestamp, err error) {
@@ -0,0 +1,154 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package config providers configuration type and load configuration logic
+package config
+
+import (
+	"github.com/vdaas/vald/internal/auth"
+	"github.com/vdaas/vald/internal/servers/server"
+)
+
+// Auth represents the authentication and authorization configuration of the API servers.
+// The client certificate authenticator needs no configuration and is always available when TLS verifies client certificates.
+type Auth struct {
+	// APIKeys represent the static API keys and the identities they authenticate.
+	APIKeys []*APIKey `json:"api_keys,omitempty" yaml:"api_keys"`
+
+	// JWT represent the JWT authenticator verifying bearer tokens against a local JWKS file.
+	JWT *JWTAuth `json:"jwt,omitempty" yaml:"jwt"`
+
+	// Policy represent the rules of the authorization interceptor, a request is allowed when any rule grants it.
+	Policy []*AuthRule `json:"policy,omitempty" yaml:"policy"`
+}
+
+// APIKey represents a static API key.
+type APIKey struct {
+	// Key represent the API key, usually an environment variable key or file:// reference.
+	Key     string   `json:"key,omitempty"     yaml:"key"`
+	Subject string   `json:"subject,omitempty" yaml:"subject"`
+	Groups  []string `json:"groups,omitempty"  yaml:"groups"`
+}
+
+// JWTAuth represents the JWT authenticator configuration.
+type JWTAuth struct {
+	JWKSPath       string `json:"jwks_path,omitempty"       yaml:"jwks_path"`
+	Issuer         string `json:"issuer,omitempty"          yaml:"issuer"`
+	Audience       string `json:"audience,omitempty"        yaml:"audience"`
+	SubjectClaim   string `json:"subject_claim,omitempty"   yaml:"subject_claim"`
+	GroupsClaim    string `json:"groups_claim,omitempty"    yaml:"groups_claim"`
+	Leeway         string `json:"leeway,omitempty"          yaml:"leeway"`
+	ReloadInterval string `json:"reload_interval,omitempty" yaml:"reload_interval"`
+}
+
+// AuthRule represents an authorization rule, empty lists match everything.
+type AuthRule struct {
+	Subjects    []string `json:"subjects,omitempty"    yaml:"subjects"`
+	Groups      []string `json:"groups,omitempty"      yaml:"groups"`
+	Methods     []string `json:"methods,omitempty"     yaml:"methods"`
+	Collections []string `json:"collections,omitempty" yaml:"collections"`
+	IDPrefixes  []string `json:"id_prefixes,omitempty" yaml:"id_prefixes"`
+}
+
+// Bind binds the actual value from the Auth struct field.
+func (a *Auth) Bind() *Auth {
+	for _, k := range a.APIKeys {
+		if k != nil {
+			k.Key = GetActualValue(k.Key)
+			k.Subject = GetActualValue(k.Subject)
+			k.Groups = GetActualValues(k.Groups)
+		}
+	}
+	if a.JWT != nil {
+		a.JWT.JWKSPath = GetActualValue(a.JWT.JWKSPath)
+		a.JWT.Issuer = GetActualValue(a.JWT.Issuer)
+		a.JWT.Audience = GetActualValue(a.JWT.Audience)
+		a.JWT.SubjectClaim = GetActualValue(a.JWT.SubjectClaim)
+		a.JWT.GroupsClaim = GetActualValue(a.JWT.GroupsClaim)
+		a.JWT.Leeway = GetActualValue(a.JWT.Leeway)
+		a.JWT.ReloadInterval = GetActualValue(a.JWT.ReloadInterval)
+	}
+	for _, r := range a.Policy {
+		if r != nil {
+			r.Subjects = GetActualValues(r.Subjects)
+			r.Groups = GetActualValues(r.Groups)
+			r.Methods = GetActualValues(r.Methods)
+			r.Collections = GetActualValues(r.Collections)
+			r.IDPrefixes = GetActualValues(r.IDPrefixes)
+		}
+	}
+	return a
+}
+
+// Opts returns the server options registering the configured authenticators and the authorization policy.
+func (a *Auth) Opts() ([]server.Option, error) {
+	authns := []auth.Authenticator{auth.NewMTLS()}
+	if len(a.APIKeys) != 0 {
+		keys := make(map[string]auth.Identity, len(a.APIKeys))
+		for _, k := range a.APIKeys {
+			if k != nil && k.Key != "" {
+				keys[k.Key] = auth.Identity{
+					Subject: k.Subject,
+					Groups:  k.Groups,
+				}
+			}
+		}
+		authns = append(authns, auth.NewAPIKey(keys))
+	}
+	if a.JWT != nil && a.JWT.JWKSPath != "" {
+		opts := []auth.JWTOption{
+			auth.WithJWKSPath(a.JWT.JWKSPath),
+			auth.WithJWTIssuer(a.JWT.Issuer),
+			auth.WithJWTAudience(a.JWT.Audience),
+		}
+		if a.JWT.SubjectClaim != "" {
+			opts = append(opts, auth.WithJWTSubjectClaim(a.JWT.SubjectClaim))
+		}
+		if a.JWT.GroupsClaim != "" {
+			opts = append(opts, auth.WithJWTGroupsClaim(a.JWT.GroupsClaim))
+		}
+		if a.JWT.Leeway != "" {
+			opts = append(opts, auth.WithJWTLeeway(a.JWT.Leeway))
+		}
+		if a.JWT.ReloadInterval != "" {
+			opts = append(opts, auth.WithJWKSReloadInterval(a.JWT.ReloadInterval))
+		}
+		jwt, err := auth.NewJWT(opts...)
+		if err != nil {
+			return nil, err
+		}
+		authns = append(authns, jwt)
+	}
+	sopts := []server.Option{
+		server.WithAuthenticator(authns...),
+	}
+	if len(a.Policy) != 0 {
+		rules := make([]auth.Rule, 0, len(a.Policy))
+		for _, r := range a.Policy {
+			if r != nil {
+				rules = append(rules, auth.Rule{
+					Subjects:    r.Subjects,
+					Groups:      r.Groups,
+					Methods:     r.Methods,
+					Collections: r.Collections,
+					IDPrefixes:  r.IDPrefixes,
+				})
+			}
+		}
+		sopts = append(sopts, server.WithAuthorizationPolicy(auth.NewPolicy(rules...)))
+	}
+	return sopts, nil
+}
@@ -19,11 +19,49 @@ package config
 
 // Discoverer represents the Discoverer configurations.
 type Discoverer struct {
-	Name              string     `json:"name,omitempty"               yaml:"name"`
-	Namespace         string     `json:"namespace,omitempty"          yaml:"namespace"`
-	DiscoveryDuration string     `json:"discovery_duration,omitempty" yaml:"discovery_duration"`
-	Net               *Net       `json:"net,omitempty"                yaml:"net"`
-	Selectors         *Selectors `json:"selectors,omitempty"          yaml:"selectors"`
+	Name              string            `json:"name,omitempty"               yaml:"name"`
+	Namespace         string            `json:"namespace,omitempty"          yaml:"namespace"`
+	DiscoveryDuration string            `json:"discovery_duration,omitempty" yaml:"discovery_duration"`
+	Net               *Net              `json:"net,omitempty"                yaml:"net"`
+	Selectors         *Selectors        `json:"selectors,omitempty"          yaml:"selectors"`
+	Static            *StaticDiscoverer `json:"static,omitempty"             yaml:"static"`
+	TopologyLabels    *TopologyLabels   `json:"topology_labels,omitempty"    yaml:"topology_labels"`
+	Ranking           *Ranking          `json:"ranking,omitempty"            yaml:"ranking"`
+}
+
+// Ranking represents the configurations for ranking agents.
+type Ranking struct {
+	Strategy         string          `json:"strategy,omitempty"          yaml:"strategy"`
+	Weights          *RankingWeights `json:"weights,omitempty"           yaml:"weights"`
+	AgentPort        int             `json:"agent_port,omitempty"        yaml:"agent_port"`
+	ProbeTimeout     string          `json:"probe_timeout,omitempty"     yaml:"probe_timeout"`
+	ProbeConcurrency int             `json:"probe_concurrency,omitempty" yaml:"probe_concurrency"`
+	AgentClient      *GRPCClient     `json:"agent_client,omitempty"      yaml:"agent_client"`
+}
+
+// RankingWeights represents the weight of each metric used by the weighted ranking strategy.
+type RankingWeights struct {
+	MemoryRatio float64 `json:"memory_ratio,omitempty" yaml:"memory_ratio"`
+	Stored      float64 `json:"stored,omitempty"       yaml:"stored"`
+	Uncommitted float64 `json:"uncommitted,omitempty"  yaml:"uncommitted"`
+	CPUHeadroom float64 `json:"cpu_headroom,omitempty" yaml:"cpu_headroom"`
+}
+
+// TopologyLabels represents the node label keys which hold the topology of a node.
+type TopologyLabels struct {
+	Zone     string `json:"zone,omitempty"     yaml:"zone"`
+	Rack     string `json:"rack,omitempty"     yaml:"rack"`
+	Hostname string `json:"hostname,omitempty" yaml:"hostname"`
+}
+
+// StaticDiscoverer represents the configurations for discovering agents outside Kubernetes.
+type StaticDiscoverer struct {
+	InventoryPath    string      `json:"inventory_path,omitempty"    yaml:"inventory_path"`
+	SRVRecords       []string    `json:"srv_records,omitempty"       yaml:"srv_records"`
+	AgentPort        int         `json:"agent_port,omitempty"        yaml:"agent_port"`
+	ProbeTimeout     string      `json:"probe_timeout,omitempty"     yaml:"probe_timeout"`
+	ProbeConcurrency int         `json:"probe_concurrency,omitempty" yaml:"probe_concurrency"`
+	AgentClient      *GRPCClient `json:"agent_client,omitempty"      yaml:"agent_client"`
 }
 
 type Selectors struct {
@@ -159,9 +197,54 @@ func (d *Discoverer) Bind() *Discoverer {
 		d.Selectors = new(Selectors)
 	}
 
+	if d.Static != nil {
+		d.Static.Bind()
+	}
+
+	if d.TopologyLabels != nil {
+		d.TopologyLabels.Bind()
+	}
+
+	if d.Ranking != nil {
+		d.Ranking.Bind()
+	}
+
 	return d
 }
 
+// Bind binds the actual data from the TopologyLabels receiver field.
+func (t *TopologyLabels) Bind() *TopologyLabels {
+	t.Zone = GetActualValue(t.Zone)
+	t.Rack = GetActualValue(t.Rack)
+	t.Hostname = GetActualValue(t.Hostname)
+	return t
+}
+
+// Bind binds the actual data from the Ranking receiver field.
+func (r *Ranking) Bind() *Ranking {
+	r.Strategy = GetActualValue(r.Strategy)
+	r.ProbeTimeout = GetActualValue(r.ProbeTimeout)
+	if r.AgentClient != nil {
+		r.AgentClient.Bind()
+	} else {
+		r.AgentClient = newGRPCClientConfig()
+	}
+	return r
+}
+
+// Bind binds the actual data from the StaticDiscoverer receiver field.
+func (s *StaticDiscoverer) Bind() *StaticDiscoverer {
+	s.InventoryPath = GetActualValue(s.InventoryPath)
+	s.SRVRecords = GetActualValues(s.SRVRecords)
+	s.ProbeTimeout = GetActualValue(s.ProbeTimeout)
+	if s.AgentClient != nil {
+		s.AgentClient.Bind()
+	} else {
+		s.AgentClient = newGRPCClientConfig()
+	}
+	return s
+}
+
 // Bind binds the actual data from the Selectors receiver field.
 func (s *Selectors) Bind() *Selectors {
 	if s == nil {
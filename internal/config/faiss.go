@@ -81,6 +81,15 @@ type Faiss struct {
 
 	// KVSDB represents the faiss bidirectional kv store configuration
 	KVSDB *KVSDB `json:"kvsdb,omitempty" yaml:"kvsdb"`
+
+	// IsReadReplica represents whether the faiss is read replica or not
+	IsReadReplica bool `json:"is_readreplica" yaml:"is_readreplica"`
+
+	// ReadReplicaSource represents the source of the read replica index, snapshot or blob
+	ReadReplicaSource string `json:"readreplica_source,omitempty" yaml:"readreplica_source"`
+
+	// ReadReplicaReloadDuration represents checking loop duration for reloading the index generation pulled from blob storage
+	ReadReplicaReloadDuration string `json:"readreplica_reload_duration,omitempty" yaml:"readreplica_reload_duration"`
 }
 
 //// KVSDB represent the faiss vector bidirectional kv store configuration
@@ -108,6 +117,8 @@ func (f *Faiss) Bind() *Faiss {
 	f.MinLoadIndexTimeout = GetActualValue(f.MinLoadIndexTimeout)
 	f.MaxLoadIndexTimeout = GetActualValue(f.MaxLoadIndexTimeout)
 	f.LoadIndexTimeoutFactor = GetActualValue(f.LoadIndexTimeoutFactor)
+	f.ReadReplicaSource = GetActualValue(f.ReadReplicaSource)
+	f.ReadReplicaReloadDuration = GetActualValue(f.ReadReplicaReloadDuration)
 
 	if f.VQueue == nil {
 		f.VQueue = new(VQueue)
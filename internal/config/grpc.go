@@ -20,6 +20,7 @@ package config
 import (
 	"github.com/vdaas/vald/internal/backoff"
 	"github.com/vdaas/vald/internal/circuitbreaker"
+	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/net"
 	"github.com/vdaas/vald/internal/net/grpc"
 	"github.com/vdaas/vald/internal/tls"
@@ -44,6 +45,7 @@ type CallOption struct {
 	MaxRecvMsgSize        int    `json:"max_recv_msg_size"         yaml:"max_recv_msg_size"`
 	MaxSendMsgSize        int    `json:"max_send_msg_size"         yaml:"max_send_msg_size"`
 	ContentSubtype        string `json:"content_subtype"           yaml:"content_subtype"`
+	Compressor            string `json:"compressor"                yaml:"compressor"`
 }
 
 // DialOption represents the configurations for dial option.
@@ -153,6 +155,7 @@ func (g *GRPCClientKeepalive) Bind() *GRPCClientKeepalive {
 // Bind binds the actual data from the CallOption receiver fields.
 func (c *CallOption) Bind() *CallOption {
 	c.ContentSubtype = GetActualValue(c.ContentSubtype)
+	c.Compressor = GetActualValue(c.Compressor)
 	return c
 }
 
@@ -229,7 +232,11 @@ func (g *GRPCClient) Opts() ([]grpc.Option, error) {
 	}
 
 	if g.CallOption != nil {
+		if !grpc.IsCompressorRegistered(g.CallOption.Compressor) {
+			return nil, errors.ErrGRPCCompressorNotFound(g.CallOption.Compressor)
+		}
 		opts = append(opts,
+			grpc.WithCallCompressor(g.CallOption.Compressor),
 			grpc.WithCallContentSubtype(g.CallOption.ContentSubtype),
 			grpc.WithMaxRecvMsgSize(g.CallOption.MaxRecvMsgSize),
 			grpc.WithMaxRetryRPCBufferSize(g.CallOption.MaxRetryRPCBufferSize),
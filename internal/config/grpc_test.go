@@ -706,7 +706,7 @@ func TestGRPCClient_Opts(t *testing.T) {
 	}
 	tests := []test{
 		{
-			name: "return 32 grpc.Option and nil error when all parameters are set",
+			name: "return 33 grpc.Option and nil error when all parameters are set",
 			fields: fields{
 				Addrs: []string{
 					"10.40.3.342",
@@ -735,6 +735,7 @@ func TestGRPCClient_Opts(t *testing.T) {
 					MaxRetryRPCBufferSize: 100,
 					MaxRecvMsgSize:        1000,
 					MaxSendMsgSize:        1000,
+					Compressor:            "zstd",
 				},
 				DialOption: &DialOption{
 					WriteBufferSize:             10000,
@@ -778,7 +779,18 @@ func TestGRPCClient_Opts(t *testing.T) {
 				},
 			},
 			want: want{
-				want: make([]grpc.Option, 32),
+				want: make([]grpc.Option, 33),
+			},
+		},
+		{
+			name: "return nil grpc.Option and an error when the compressor is not registered",
+			fields: fields{
+				CallOption: &CallOption{
+					Compressor: "brotli",
+				},
+			},
+			want: want{
+				err: errors.ErrGRPCCompressorNotFound("brotli"),
 			},
 		},
 		{
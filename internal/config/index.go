@@ -55,6 +55,14 @@ type Indexer struct {
 	// NodeName represents node name
 	NodeName string `json:"node_name" yaml:"node_name"`
 
+	// IndexReplica represents the number of index replicas of the cluster.
+	// When it is more than 1, CreateIndex and SaveIndex are not executed on the agents holding the replicas of the same vector at the same time.
+	IndexReplica int `json:"index_replica" yaml:"index_replica"`
+
+	// IndexByZone represents whether the agents in the same zone are indexed at the same time.
+	// It must be enabled only when the replicas of every vector are stored in distinct zones, otherwise the agents are indexed one by one.
+	IndexByZone bool `json:"index_by_zone" yaml:"index_by_zone"`
+
 	// Discoverer represent agent discoverer service configuration
 	Discoverer *DiscovererClient `json:"discoverer" yaml:"discoverer"`
 }
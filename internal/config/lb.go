@@ -48,6 +48,15 @@ type LB struct {
 
 	// MultiOperationConcurrency
 	MultiOperationConcurrency int `json:"multi_operation_concurrency" yaml:"multi_operation_concurrency"`
+
+	// SearchTimeout represents the default timeout of the search requests which do not set their own timeout
+	SearchTimeout string `json:"search_timeout" yaml:"search_timeout"`
+
+	// Meta represents the metadata service client configuration used by the GraphQL metadata join
+	Meta *GRPCClient `json:"meta" yaml:"meta"`
+
+	// Topology represents the failure domain aware placement configuration
+	Topology *LBTopology `json:"topology" yaml:"topology"`
 }
 
 // Bind binds the actual data from the LB receiver fields.
@@ -56,13 +65,44 @@ func (g *LB) Bind() *LB {
 	g.AgentNamespace = GetActualValue(g.AgentNamespace)
 	g.AgentDNS = GetActualValue(g.AgentDNS)
 	g.NodeName = GetActualValue(g.NodeName)
+	g.SearchTimeout = GetActualValue(g.SearchTimeout)
 
 	if g.Discoverer != nil {
 		g.Discoverer = g.Discoverer.Bind()
 	}
+
+	if g.Meta != nil {
+		g.Meta = g.Meta.Bind()
+	}
+
+	if g.Topology != nil {
+		g.Topology = g.Topology.Bind()
+	}
 	return g
 }
 
+// LBTopology represents the configuration for spreading replicas across failure domains.
+type LBTopology struct {
+	// SpreadReplicas spreads the index replicas across distinct zones, racks and hosts
+	SpreadReplicas bool `json:"spread_replicas" yaml:"spread_replicas"`
+
+	// PreferSameZone searches only the agents in the gateway's zone when every zone holds a replica
+	PreferSameZone bool `json:"prefer_same_zone" yaml:"prefer_same_zone"`
+
+	// Zone represents the zone the gateway runs in
+	Zone string `json:"zone" yaml:"zone"`
+
+	// NodeName represents the node the gateway runs on, used to look up its zone when Zone is empty
+	NodeName string `json:"node_name" yaml:"node_name"`
+}
+
+// Bind binds the actual data from the LBTopology receiver fields.
+func (t *LBTopology) Bind() *LBTopology {
+	t.Zone = GetActualValue(t.Zone)
+	t.NodeName = GetActualValue(t.NodeName)
+	return t
+}
+
 // ReadReplicaClient represents a configuration of grpc client for read replica.
 type ReadReplicaClient struct {
 	Duration           string      `json:"duration"             yaml:"duration"`
@@ -104,6 +104,12 @@ type NGT struct {
 	// IsReadReplica represents whether the ngt is read replica or not
 	IsReadReplica bool `json:"is_readreplica" yaml:"is_readreplica"`
 
+	// ReadReplicaSource represents the source of the read replica index, snapshot or blob
+	ReadReplicaSource string `json:"readreplica_source,omitempty" yaml:"readreplica_source"`
+
+	// ReadReplicaReloadDuration represents checking loop duration for reloading the index generation pulled from blob storage
+	ReadReplicaReloadDuration string `json:"readreplica_reload_duration,omitempty" yaml:"readreplica_reload_duration"`
+
 	// EnableExportIndexInfoToK8s represents whether the ngt index info is exported to k8s or not
 	EnableExportIndexInfoToK8s bool `json:"enable_export_index_info_to_k8s" yaml:"enable_export_index_info_to_k8s"`
 
@@ -112,6 +118,45 @@ type NGT struct {
 
 	// EnableStatistics represents whether the ngt index statistics load or not
 	EnableStatistics bool `json:"enable_statistics" yaml:"enable_statistics"`
+
+	// Quantization represents the ngt vector quantization configuration
+	Quantization *Quantization `json:"quantization,omitempty" yaml:"quantization"`
+
+	// UncommittedSearchLimit represents the maximum number of the uncommitted vectors scanned by the search requests with include_uncommitted
+	UncommittedSearchLimit int `json:"uncommitted_search_limit,omitempty" yaml:"uncommitted_search_limit"`
+
+	// RealtimeIndex represents the ngt real-time incremental indexing configuration
+	RealtimeIndex *RealtimeIndex `json:"realtime_index,omitempty" yaml:"realtime_index"`
+
+	// DefaultTTL represents the time to live of the vectors inserted without ttl, the vectors never expire if it is empty
+	DefaultTTL string `json:"default_ttl,omitempty" yaml:"default_ttl"`
+
+	// TTLSweepDuration represents the duration to delete the expired vectors
+	TTLSweepDuration string `json:"ttl_sweep_duration,omitempty" yaml:"ttl_sweep_duration"`
+}
+
+// RealtimeIndex represents the ngt real-time incremental indexing configuration.
+type RealtimeIndex struct {
+	// Mode represents the real-time indexing mode. it should be `disabled`, `sync` or `batch`
+	Mode string `json:"mode,omitempty" yaml:"mode"`
+
+	// BatchSize represents the number of queued writes which triggers a micro-batch commit in the batch mode
+	BatchSize int `json:"batch_size,omitempty" yaml:"batch_size"`
+
+	// BatchInterval represents the maximum duration the queued writes wait for a micro-batch commit in the batch mode
+	BatchInterval string `json:"batch_interval,omitempty" yaml:"batch_interval"`
+}
+
+// Quantization represents the ngt vector quantization configuration.
+type Quantization struct {
+	// Type represents the quantization type. it should be `none` or `scalar8`
+	Type string `info:"quantization_type" json:"type,omitempty" yaml:"type"`
+
+	// RerankFactor represents the multiplier of the number of quantized candidates re-ranked with the exact float32 distance
+	RerankFactor int `json:"rerank_factor,omitempty" yaml:"rerank_factor"`
+
+	// TrainingSize represents the maximum number of vectors used to learn the quantization ranges
+	TrainingSize int `json:"training_size,omitempty" yaml:"training_size"`
 }
 
 // KVSDB represent the ngt vector bidirectional kv store configuration.
@@ -134,6 +179,8 @@ func (n *NGT) Bind() *NGT {
 	n.PodName = GetActualValue(n.PodName)
 	n.PodNamespace = GetActualValue(n.PodNamespace)
 	n.IndexPath = GetActualValue(n.IndexPath)
+	n.ReadReplicaSource = GetActualValue(n.ReadReplicaSource)
+	n.ReadReplicaReloadDuration = GetActualValue(n.ReadReplicaReloadDuration)
 	n.DistanceType = GetActualValue(n.DistanceType)
 	n.ObjectType = GetActualValue(n.ObjectType)
 	n.AutoIndexCheckDuration = GetActualValue(n.AutoIndexCheckDuration)
@@ -150,6 +197,17 @@ func (n *NGT) Bind() *NGT {
 	if n.KVSDB == nil {
 		n.KVSDB = new(KVSDB)
 	}
+	if n.Quantization == nil {
+		n.Quantization = new(Quantization)
+	}
+	n.Quantization.Type = GetActualValue(n.Quantization.Type)
+	if n.RealtimeIndex == nil {
+		n.RealtimeIndex = new(RealtimeIndex)
+	}
+	n.RealtimeIndex.Mode = GetActualValue(n.RealtimeIndex.Mode)
+	n.RealtimeIndex.BatchInterval = GetActualValue(n.RealtimeIndex.BatchInterval)
+	n.DefaultTTL = GetActualValue(n.DefaultTTL)
+	n.TTLSweepDuration = GetActualValue(n.TTLSweepDuration)
 
 	return n
 }
@@ -96,6 +96,8 @@ func TestNGT_Bind(t *testing.T) {
 					EnableInMemoryMode:      false,
 					VQueue:                  new(VQueue),
 					KVSDB:                   new(KVSDB),
+					Quantization:            new(Quantization),
+					RealtimeIndex:           new(RealtimeIndex),
 				},
 			},
 		},
@@ -145,6 +147,8 @@ func TestNGT_Bind(t *testing.T) {
 					EnableInMemoryMode:      false,
 					VQueue:                  new(VQueue),
 					KVSDB:                   new(KVSDB),
+					Quantization:            new(Quantization),
+					RealtimeIndex:           new(RealtimeIndex),
 				},
 			},
 		},
@@ -152,8 +156,10 @@ func TestNGT_Bind(t *testing.T) {
 			name: "returns NGT when all fields are empty",
 			want: want{
 				want: &NGT{
-					VQueue: new(VQueue),
-					KVSDB:  new(KVSDB),
+					VQueue:        new(VQueue),
+					KVSDB:         new(KVSDB),
+					Quantization:  new(Quantization),
+					RealtimeIndex: new(RealtimeIndex),
 				},
 			},
 		},
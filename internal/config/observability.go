@@ -19,10 +19,11 @@ package config
 
 // Observability represents the configuration for the observability.
 type Observability struct {
-	Enabled bool     `json:"enabled" yaml:"enabled"`
-	OTLP    *OTLP    `json:"otlp"    yaml:"otlp"`
-	Metrics *Metrics `json:"metrics" yaml:"metrics"`
-	Trace   *Trace   `json:"trace"   yaml:"trace"`
+	Enabled    bool        `json:"enabled"    yaml:"enabled"`
+	OTLP       *OTLP       `json:"otlp"       yaml:"otlp"`
+	Prometheus *Prometheus `json:"prometheus" yaml:"prometheus"`
+	Metrics    *Metrics    `json:"metrics"    yaml:"metrics"`
+	Trace      *Trace      `json:"trace"      yaml:"trace"`
 }
 
 type OTLP struct {
@@ -43,9 +44,19 @@ type OTLPAttribute struct {
 	ServiceName string `json:"service_name" yaml:"service_name"`
 }
 
+// Prometheus represents the configuration for the Prometheus pull exporter.
+// The metrics are served by the metrics server named "prometheus".
+type Prometheus struct {
+	Enabled   bool   `json:"enabled"   yaml:"enabled"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+}
+
 // Trace represents the configuration for the trace.
 type Trace struct {
 	Enabled bool `json:"enabled" yaml:"enabled"`
+	// SamplingRate is the ratio of the root traces to be sampled, zero samples every trace and negative samples none.
+	// The traces of the request IDs enabled by the admin API are always sampled.
+	SamplingRate float64 `json:"sampling_rate" yaml:"sampling_rate"`
 }
 
 // Metrics represents the configuration for the metrics.
@@ -84,6 +95,12 @@ func (o *Observability) Bind() *Observability {
 		o.OTLP.Attribute = new(OTLPAttribute)
 	}
 
+	if o.Prometheus != nil {
+		o.Prometheus.Namespace = GetActualValue(o.Prometheus.Namespace)
+	} else {
+		o.Prometheus = new(Prometheus)
+	}
+
 	if o.Metrics != nil {
 		o.Metrics.VersionInfoLabels = GetActualValues(o.Metrics.VersionInfoLabels)
 	} else {
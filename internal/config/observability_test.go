@@ -27,10 +27,11 @@ import (
 
 func TestObservability_Bind(t *testing.T) {
 	type fields struct {
-		Enabled bool
-		OTLP    *OTLP
-		Metrics *Metrics
-		Trace   *Trace
+		Enabled    bool
+		OTLP       *OTLP
+		Prometheus *Prometheus
+		Metrics    *Metrics
+		Trace      *Trace
 	}
 	type want struct {
 		want *Observability
@@ -62,8 +63,9 @@ func TestObservability_Bind(t *testing.T) {
 						OTLP: &OTLP{
 							Attribute: new(OTLPAttribute),
 						},
-						Metrics: new(Metrics),
-						Trace:   new(Trace),
+						Prometheus: new(Prometheus),
+						Metrics:    new(Metrics),
+						Trace:      new(Trace),
 					},
 				},
 			}
@@ -94,9 +96,10 @@ func TestObservability_Bind(t *testing.T) {
 				},
 				want: want{
 					want: &Observability{
-						Enabled: false,
-						Metrics: new(Metrics),
-						Trace:   new(Trace),
+						Enabled:    false,
+						Metrics:    new(Metrics),
+						Trace:      new(Trace),
+						Prometheus: new(Prometheus),
 						OTLP: &OTLP{
 							CollectorEndpoint:       collectorEndpoint,
 							TraceBatchTimeout:       traceBatchTimeout,
@@ -148,9 +151,10 @@ func TestObservability_Bind(t *testing.T) {
 				},
 				want: want{
 					want: &Observability{
-						Enabled: false,
-						Metrics: new(Metrics),
-						Trace:   new(Trace),
+						Enabled:    false,
+						Metrics:    new(Metrics),
+						Trace:      new(Trace),
+						Prometheus: new(Prometheus),
 						OTLP: &OTLP{
 							CollectorEndpoint:     collectorEndpoint,
 							TraceBatchTimeout:     traceBatchTimeout,
@@ -163,6 +167,38 @@ func TestObservability_Bind(t *testing.T) {
 				},
 			}
 		}(),
+		func() test {
+			namespace := "vald"
+			key := "OBSERVABILITY_BIND_PROMETHEUS_NAMESPACE"
+			return test{
+				name: "return Observability when the prometheus namespace is loaded environment variable",
+				fields: fields{
+					Enabled: true,
+					Prometheus: &Prometheus{
+						Enabled:   true,
+						Namespace: "_" + key + "_",
+					},
+				},
+				beforeFunc: func(t *testing.T) {
+					t.Helper()
+					t.Setenv(key, namespace)
+				},
+				want: want{
+					want: &Observability{
+						Enabled: true,
+						OTLP: &OTLP{
+							Attribute: new(OTLPAttribute),
+						},
+						Prometheus: &Prometheus{
+							Enabled:   true,
+							Namespace: namespace,
+						},
+						Metrics: new(Metrics),
+						Trace:   new(Trace),
+					},
+				},
+			}
+		}(),
 	}
 
 	for _, tc := range tests {
@@ -180,9 +216,10 @@ func TestObservability_Bind(t *testing.T) {
 				checkFunc = defaultCheckFunc
 			}
 			o := &Observability{
-				Enabled: test.fields.Enabled,
-				Trace:   test.fields.Trace,
-				OTLP:    test.fields.OTLP,
+				Enabled:    test.fields.Enabled,
+				Trace:      test.fields.Trace,
+				OTLP:       test.fields.OTLP,
+				Prometheus: test.fields.Prometheus,
 			}
 
 			got := o.Bind()
@@ -0,0 +1,78 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package config providers configuration type and load configuration logic
+package config
+
+import "github.com/vdaas/vald/internal/net/grpc/interceptor/server/ratelimit"
+
+// RateLimit represents the configuration of the rate limit interceptor.
+// Each client has its own read and write token bucket. The client is identified by the authenticated subject,
+// or by the KeyMetadata value for an unauthenticated or a trusted caller, or by the peer address.
+type RateLimit struct {
+	// Read represent the token bucket of the read RPCs such as Search and GetObject.
+	Read *TokenBucket `json:"read,omitempty" yaml:"read"`
+
+	// Write represent the token bucket of the RPCs modifying the index such as Insert and Flush.
+	Write *TokenBucket `json:"write,omitempty" yaml:"write"`
+
+	// KeyMetadata represent the metadata key identifying the client.
+	KeyMetadata string `json:"key_metadata,omitempty" yaml:"key_metadata"`
+
+	// TrustedSubjects represent the authenticated subjects allowed to identify the client by the KeyMetadata value.
+	TrustedSubjects []string `json:"trusted_subjects,omitempty" yaml:"trusted_subjects"`
+
+	// StreamMaxWait represent how long a stream message over the limit waits before the stream fails.
+	StreamMaxWait string `json:"stream_max_wait,omitempty" yaml:"stream_max_wait"`
+
+	// IdleTimeout represent how long the buckets of an idle client are kept.
+	IdleTimeout string `json:"idle_timeout,omitempty" yaml:"idle_timeout"`
+}
+
+// TokenBucket represents a token bucket, a zero rate disables the limit.
+type TokenBucket struct {
+	// Rate represent the tokens added per second, a Multi request takes one token per request it carries.
+	Rate float64 `json:"rate,omitempty" yaml:"rate"`
+
+	// Burst represent the bucket size.
+	Burst int `json:"burst,omitempty" yaml:"burst"`
+}
+
+// Bind binds the actual value from the RateLimit struct field.
+func (r *RateLimit) Bind() *RateLimit {
+	r.KeyMetadata = GetActualValue(r.KeyMetadata)
+	r.TrustedSubjects = GetActualValues(r.TrustedSubjects)
+	r.StreamMaxWait = GetActualValue(r.StreamMaxWait)
+	r.IdleTimeout = GetActualValue(r.IdleTimeout)
+	return r
+}
+
+// Opts returns []ratelimit.Option object whose every value is field value.
+func (r *RateLimit) Opts() []ratelimit.Option {
+	opts := []ratelimit.Option{
+		ratelimit.WithKeyMetadata(r.KeyMetadata),
+		ratelimit.WithTrustedSubjects(r.TrustedSubjects...),
+		ratelimit.WithStreamMaxWait(r.StreamMaxWait),
+		ratelimit.WithIdleTimeout(r.IdleTimeout),
+	}
+	if r.Read != nil {
+		opts = append(opts, ratelimit.WithReadLimit(r.Read.Rate, r.Read.Burst))
+	}
+	if r.Write != nil {
+		opts = append(opts, ratelimit.WithWriteLimit(r.Write.Rate, r.Write.Burst))
+	}
+	return opts
+}
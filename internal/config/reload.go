@@ -0,0 +1,112 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package config providers configuration type and load configuration logic
+package config
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+// Changes returns the paths of the fields which differ between the two configurations.
+// A path consists of the json field names joined by ".", e.g. "ngt.auto_index_length".
+// Lists are compared as a whole.
+func Changes(prev, next any) (paths []string, err error) {
+	p, err := toTree(prev)
+	if err != nil {
+		return nil, err
+	}
+	n, err := toTree(next)
+	if err != nil {
+		return nil, err
+	}
+	paths = diffTree("", p, n, paths)
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// CheckReloadable returns an error listing the changed paths which are not matched by the reloadable patterns.
+// A pattern matches the path itself and all of its descendants, and "*" matches any single path element.
+func CheckReloadable(changes []string, reloadable ...string) error {
+	var unsafe []string
+	for _, path := range changes {
+		if !slices.ContainsFunc(reloadable, func(pattern string) bool {
+			return matchPath(pattern, path)
+		}) {
+			unsafe = append(unsafe, path)
+		}
+	}
+	if len(unsafe) != 0 {
+		return errors.ErrUnreloadableConfig(unsafe...)
+	}
+	return nil
+}
+
+func toTree(cfg any) (tree any, err error) {
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(b, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func diffTree(path string, prev, next any, paths []string) []string {
+	pm, pok := prev.(map[string]any)
+	nm, nok := next.(map[string]any)
+	if !pok || !nok {
+		if !reflect.DeepEqual(prev, next) {
+			paths = append(paths, path)
+		}
+		return paths
+	}
+	join := func(key string) string {
+		if len(path) == 0 {
+			return key
+		}
+		return path + "." + key
+	}
+	for key, pv := range pm {
+		paths = diffTree(join(key), pv, nm[key], paths)
+	}
+	for key, nv := range nm {
+		if _, ok := pm[key]; !ok {
+			paths = diffTree(join(key), nil, nv, paths)
+		}
+	}
+	return paths
+}
+
+func matchPath(pattern, path string) bool {
+	pes := strings.Split(pattern, ".")
+	es := strings.Split(path, ".")
+	if len(pes) > len(es) {
+		return false
+	}
+	for i, pe := range pes {
+		if pe != "*" && pe != es[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vdaas/vald/internal/errors"
+)
+
+func TestChanges(t *testing.T) {
+	type args struct {
+		prev any
+		next any
+	}
+	type want struct {
+		paths []string
+		err   error
+	}
+	type test struct {
+		name string
+		args args
+		want want
+	}
+	type data struct {
+		GlobalConfig `json:",inline" yaml:",inline"`
+		NGT          *NGT `json:"ngt" yaml:"ngt"`
+	}
+	base := func() *data {
+		return &data{
+			GlobalConfig: GlobalConfig{
+				Version: "v0.0.0",
+				Logging: &Logging{
+					Level: "info",
+				},
+			},
+			NGT: &NGT{
+				AutoIndexLength: 100,
+				Dimension:       128,
+			},
+		}
+	}
+	tests := []test{
+		{
+			name: "return no paths when nothing is changed",
+			args: args{
+				prev: base(),
+				next: base(),
+			},
+		},
+		{
+			name: "return the sorted paths of the changed fields",
+			args: args{
+				prev: base(),
+				next: func() *data {
+					d := base()
+					d.NGT.AutoIndexLength = 200
+					d.NGT.Dimension = 256
+					d.Logging.Level = "debug"
+					return d
+				}(),
+			},
+			want: want{
+				paths: []string{
+					"logging.level",
+					"ngt.auto_index_length",
+					"ngt.dimension",
+				},
+			},
+		},
+		{
+			name: "return the paths of the added fields",
+			args: args{
+				prev: base(),
+				next: func() *data {
+					d := base()
+					d.NGT.AutoIndexCheckDuration = "1s"
+					return d
+				}(),
+			},
+			want: want{
+				paths: []string{
+					"ngt.auto_index_check_duration",
+				},
+			},
+		},
+		{
+			name: "return the path of the changed list as a whole",
+			args: args{
+				prev: &Observability{Metrics: &Metrics{VersionInfoLabels: []string{"a"}}},
+				next: &Observability{Metrics: &Metrics{VersionInfoLabels: []string{"a", "b"}}},
+			},
+			want: want{
+				paths: []string{
+					"metrics.version_info_labels",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := Changes(test.args.prev, test.args.next)
+			if !errors.Is(err, test.want.err) {
+				tt.Errorf("got_error: %v, want: %v", err, test.want.err)
+			}
+			if !reflect.DeepEqual(got, test.want.paths) {
+				tt.Errorf("got: %#v, want: %#v", got, test.want.paths)
+			}
+		})
+	}
+}
+
+func TestCheckReloadable(t *testing.T) {
+	type args struct {
+		changes    []string
+		reloadable []string
+	}
+	type test struct {
+		name string
+		args args
+		want error
+	}
+	tests := []test{
+		{
+			name: "return nil when every change is reloadable",
+			args: args{
+				changes:    []string{"logging.level", "ngt.auto_index_length"},
+				reloadable: []string{"logging.level", "ngt.auto_index_length"},
+			},
+		},
+		{
+			name: "return nil when the change is a descendant of the pattern",
+			args: args{
+				changes:    []string{"gateway.lb.index_replica"},
+				reloadable: []string{"gateway"},
+			},
+		},
+		{
+			name: "return nil when the wildcard matches the element",
+			args: args{
+				changes:    []string{"server_config.servers.grpc.port"},
+				reloadable: []string{"server_config.*.grpc"},
+			},
+		},
+		{
+			name: "return error with the unreloadable changes",
+			args: args{
+				changes:    []string{"logging.level", "ngt.dimension", "ngt.distance_type"},
+				reloadable: []string{"logging.level"},
+			},
+			want: errors.ErrUnreloadableConfig("ngt.dimension", "ngt.distance_type"),
+		},
+		{
+			name: "return error when the pattern is longer than the change",
+			args: args{
+				changes:    []string{"ngt"},
+				reloadable: []string{"ngt.auto_index_length"},
+			},
+			want: errors.ErrUnreloadableConfig("ngt"),
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			err := CheckReloadable(test.args.changes, test.args.reloadable...)
+			if !errors.Is(err, test.want) {
+				tt.Errorf("got_error: %v, want: %v", err, test.want)
+			}
+		})
+	}
+}
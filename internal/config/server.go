@@ -31,6 +31,12 @@ type Servers struct {
 	// TLS represent server tls configuration.
 	TLS *TLS `json:"tls" yaml:"tls"`
 
+	// Auth represent the authenticators and the authorization policy selectable by the server interceptors.
+	Auth *Auth `json:"auth,omitempty" yaml:"auth"`
+
+	// RateLimit represent the per client token buckets of the rate limit interceptor.
+	RateLimit *RateLimit `json:"rate_limit,omitempty" yaml:"rate_limit"`
+
 	// FullShutdownDuration represent summary duration of shutdown time
 	FullShutdownDuration string `json:"full_shutdown_duration" yaml:"full_shutdown_duration"`
 
@@ -67,13 +73,14 @@ type Server struct {
 
 // HTTP represents the configuration for HTTP.
 type HTTP struct {
-	HTTP2             *HTTP2 `json:"http2"               yaml:"http2"`
-	ShutdownDuration  string `json:"shutdown_duration"   yaml:"shutdown_duration"`
-	HandlerTimeout    string `json:"handler_timeout"     yaml:"handler_timeout"`
-	IdleTimeout       string `json:"idle_timeout"        yaml:"idle_timeout"`
-	ReadHeaderTimeout string `json:"read_header_timeout" yaml:"read_header_timeout"`
-	ReadTimeout       string `json:"read_timeout"        yaml:"read_timeout"`
-	WriteTimeout      string `json:"write_timeout"       yaml:"write_timeout"`
+	HTTP2             *HTTP2   `json:"http2"                  yaml:"http2"`
+	ShutdownDuration  string   `json:"shutdown_duration"      yaml:"shutdown_duration"`
+	HandlerTimeout    string   `json:"handler_timeout"        yaml:"handler_timeout"`
+	IdleTimeout       string   `json:"idle_timeout"           yaml:"idle_timeout"`
+	ReadHeaderTimeout string   `json:"read_header_timeout"    yaml:"read_header_timeout"`
+	ReadTimeout       string   `json:"read_timeout"           yaml:"read_timeout"`
+	WriteTimeout      string   `json:"write_timeout"          yaml:"write_timeout"`
+	Interceptors      []string `json:"interceptors,omitempty" yaml:"interceptors"` // authentication, authorization and rate limit only
 }
 
 // HTTP2 represents the configuration for HTTP2.
@@ -108,6 +115,7 @@ type GRPC struct {
 	ReadBufferSize                 int            `json:"read_buffer_size,omitempty"                 yaml:"read_buffer_size"`
 	WriteBufferSize                int            `json:"write_buffer_size,omitempty"                yaml:"write_buffer_size"`
 	ConnectionTimeout              string         `json:"connection_timeout,omitempty"               yaml:"connection_timeout"`
+	Compressor                     string         `json:"compressor,omitempty"                       yaml:"compressor"`
 	Interceptors                   []string       `json:"interceptors,omitempty"                     yaml:"interceptors"`
 	Keepalive                      *GRPCKeepalive `json:"keepalive,omitempty"                        yaml:"keepalive"`
 }
@@ -172,6 +180,14 @@ func (s *Servers) Bind() *Servers {
 			Enabled: false,
 		}
 	}
+
+	if s.Auth != nil {
+		s.Auth.Bind()
+	}
+
+	if s.RateLimit != nil {
+		s.RateLimit.Bind()
+	}
 	return s
 }
 
@@ -193,11 +209,13 @@ func (h *HTTP) Bind() *HTTP {
 	h.ReadTimeout = GetActualValue(h.ReadTimeout)
 	h.WriteTimeout = GetActualValue(h.WriteTimeout)
 	h.IdleTimeout = GetActualValue(h.IdleTimeout)
+	h.Interceptors = GetActualValues(h.Interceptors)
 	return h
 }
 
 // Bind binds the actual value from the GRPC struct field.
 func (g *GRPC) Bind() *GRPC {
+	g.Compressor = GetActualValue(g.Compressor)
 	g.ConnectionTimeout = GetActualValue(g.ConnectionTimeout)
 	for i, ic := range g.Interceptors {
 		g.Interceptors[i] = GetActualValue(ic)
@@ -275,6 +293,9 @@ func (s *Server) Opts() []server.Option {
 				server.WithIdleTimeout(s.HTTP.IdleTimeout),
 				server.WithShutdownDuration(s.HTTP.ShutdownDuration),
 			)
+			if len(s.HTTP.Interceptors) != 0 {
+				opts = append(opts, server.WithHTTPInterceptors(s.HTTP.Interceptors...))
+			}
 			if s.HTTP.HTTP2 != nil && s.HTTP.HTTP2.Enabled {
 				opts = append(opts,
 					server.WithHTTP2Enabled(s.HTTP.HTTP2.Enabled),
@@ -296,6 +317,7 @@ func (s *Server) Opts() []server.Option {
 		if s.GRPC != nil {
 			opts = append(opts,
 				server.WithServerMode(mode),
+				server.WithGRPCCompressor(s.GRPC.Compressor),
 				server.WithGRPCConnectionTimeout(s.GRPC.ConnectionTimeout),
 				server.WithGRPCHeaderTableSize(s.GRPC.HeaderTableSize),
 				server.WithGRPCInitialConnWindowSize(s.GRPC.InitialConnWindowSize),
@@ -1369,7 +1369,7 @@ func TestServer_Opts(t *testing.T) {
 			},
 		},
 		{
-			name: "return 31 server.Options when NETWORK is empty, MODE is GRPC",
+			name: "return 32 server.Options when NETWORK is empty, MODE is GRPC",
 			fields: fields{
 				Name:          "vald-agent-ngt",
 				Host:          "0.0.0.0",
@@ -1424,7 +1424,7 @@ func TestServer_Opts(t *testing.T) {
 				Restart: false,
 			},
 			want: want{
-				want: make([]server.Option, 31),
+				want: make([]server.Option, 32),
 			},
 		},
 	}
@@ -57,12 +57,61 @@ type AgentSidecar struct {
 
 	// Client represent HTTP client configurations
 	Client *Client `json:"client" yaml:"client"`
+
+	// Versioning represent versioned backup configurations
+	Versioning *BackupVersioning `json:"versioning" yaml:"versioning"`
+
+	// Encryption represent backup encryption configurations
+	Encryption *BackupEncryption `json:"encryption" yaml:"encryption"`
+
+	// ReadReplicaPullDuration represent checking loop duration for pulling the latest backup generation in readreplica mode
+	ReadReplicaPullDuration string `json:"readreplica_pull_duration" yaml:"readreplica_pull_duration"`
+}
+
+// BackupVersioning represents versioned backup configurations.
+type BackupVersioning struct {
+	// Enabled represent backups are stored as timestamped generations listed in the manifest or not
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// KeepLast represent the number of the latest valid generations to keep
+	KeepLast int `json:"keep_last" yaml:"keep_last"`
+
+	// KeepDaily represent the number of the days whose latest valid generation is kept
+	KeepDaily int `json:"keep_daily" yaml:"keep_daily"`
+
+	// KeepWeekly represent the number of the weeks whose latest valid generation is kept
+	KeepWeekly int `json:"keep_weekly" yaml:"keep_weekly"`
+
+	// RestoreGeneration represent the generation ID to restore. the latest valid generation is restored when it is empty or latest
+	RestoreGeneration string `json:"restore_generation" yaml:"restore_generation"`
+
+	// RestoreBefore represent the time in RFC3339 format to restore the latest valid generation taken before it
+	RestoreBefore string `json:"restore_before" yaml:"restore_before"`
+
+	// Incremental represent generations are stored as snapshots of content-defined chunks which are uploaded only when absent or not
+	Incremental bool `json:"incremental" yaml:"incremental"`
+
+	// ChunkSize represent the average size of the content-defined chunks
+	ChunkSize string `json:"chunk_size" yaml:"chunk_size"`
+
+	// Concurrency represent the number of chunks uploaded or downloaded concurrently
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+}
+
+// BackupEncryption represents backup encryption configurations.
+type BackupEncryption struct {
+	// Enabled represent backups are encrypted by AES-GCM before they are uploaded or not
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// KeyFile represent the path of the key file which has the encryption keys and the active key ID
+	KeyFile string `json:"key_file" yaml:"key_file"`
 }
 
 // Bind binds the actual data from the AgentSidecar receiver fields.
 func (s *AgentSidecar) Bind() *AgentSidecar {
 	s.Mode = GetActualValue(s.Mode)
 	s.WatchDir = GetActualValue(s.WatchDir)
+	s.ReadReplicaPullDuration = GetActualValue(s.ReadReplicaPullDuration)
 	s.AutoBackupDuration = GetActualValue(s.AutoBackupDuration)
 	s.PostStopTimeout = GetActualValue(s.PostStopTimeout)
 	s.Filename = GetActualValue(s.Filename)
@@ -92,5 +141,31 @@ func (s *AgentSidecar) Bind() *AgentSidecar {
 		s.Client = new(Client)
 	}
 
+	if s.Versioning != nil {
+		s.Versioning = s.Versioning.Bind()
+	} else {
+		s.Versioning = new(BackupVersioning)
+	}
+
+	if s.Encryption != nil {
+		s.Encryption = s.Encryption.Bind()
+	} else {
+		s.Encryption = new(BackupEncryption)
+	}
+
 	return s
 }
+
+// Bind binds the actual data from the BackupVersioning receiver fields.
+func (b *BackupVersioning) Bind() *BackupVersioning {
+	b.RestoreGeneration = GetActualValue(b.RestoreGeneration)
+	b.RestoreBefore = GetActualValue(b.RestoreBefore)
+	b.ChunkSize = GetActualValue(b.ChunkSize)
+	return b
+}
+
+// Bind binds the actual data from the BackupEncryption receiver fields.
+func (b *BackupEncryption) Bind() *BackupEncryption {
+	b.KeyFile = GetActualValue(b.KeyFile)
+	return b
+}
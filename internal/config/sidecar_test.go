@@ -110,6 +110,8 @@ func TestAgentSidecar_Bind(t *testing.T) {
 						Client: &Client{
 							Net: new(Net),
 						},
+						Versioning: new(BackupVersioning),
+						Encryption: new(BackupEncryption),
 					},
 				},
 			}
@@ -143,6 +145,8 @@ func TestAgentSidecar_Bind(t *testing.T) {
 						Compress:           new(CompressCore),
 						RestoreBackoff:     new(Backoff),
 						Client:             new(Client),
+						Versioning:         new(BackupVersioning),
+						Encryption:         new(BackupEncryption),
 					},
 				},
 			}
@@ -192,6 +196,8 @@ func TestAgentSidecar_Bind(t *testing.T) {
 						Compress:           new(CompressCore),
 						RestoreBackoff:     new(Backoff),
 						Client:             new(Client),
+						Versioning:         new(BackupVersioning),
+						Encryption:         new(BackupEncryption),
 					},
 				},
 			}
@@ -206,6 +212,8 @@ func TestAgentSidecar_Bind(t *testing.T) {
 						Compress:       new(CompressCore),
 						RestoreBackoff: new(Backoff),
 						Client:         new(Client),
+						Versioning:     new(BackupVersioning),
+						Encryption:     new(BackupEncryption),
 					},
 				},
 			}
@@ -35,6 +35,9 @@ type TLS struct {
 
 	// InsecureSkipVerify represent enable/disable skip SSL certificate verification
 	InsecureSkipVerify bool `json:"insecure_skip_verify" yaml:"insecure_skip_verify"`
+
+	// ReloadInterval represent the minimum interval to check the certificate files for rotation, empty disables reloading.
+	ReloadInterval string `json:"reload_interval" yaml:"reload_interval"`
 }
 
 // Bind returns TLS object whose every value except Enabled is field value of environment value.
@@ -42,6 +45,7 @@ func (t *TLS) Bind() *TLS {
 	t.Cert = GetActualValue(t.Cert)
 	t.Key = GetActualValue(t.Key)
 	t.CA = GetActualValue(t.CA)
+	t.ReloadInterval = GetActualValue(t.ReloadInterval)
 	return t
 }
 
@@ -52,5 +56,6 @@ func (t *TLS) Opts() []tls.Option {
 		tls.WithCert(t.Cert),
 		tls.WithKey(t.Key),
 		tls.WithInsecureSkipVerify(t.InsecureSkipVerify),
+		tls.WithReloadInterval(t.ReloadInterval),
 	}
 }
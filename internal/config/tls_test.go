@@ -27,10 +27,11 @@ import (
 
 func TestTLS_Bind(t *testing.T) {
 	type fields struct {
-		Enabled bool
-		Cert    string
-		Key     string
-		CA      string
+		Enabled        bool
+		Cert           string
+		Key            string
+		CA             string
+		ReloadInterval string
 	}
 	type want struct {
 		want *TLS
@@ -70,23 +71,26 @@ func TestTLS_Bind(t *testing.T) {
 		{
 			name: "returns TLS with environment variable when it contains `_` prefix and suffix",
 			fields: fields{
-				Enabled: true,
-				Cert:    "_TLS_BIND_CERT_",
-				Key:     "_TLS_BIND_KEY_",
-				CA:      "_TLS_BIND_CA_",
+				Enabled:        true,
+				Cert:           "_TLS_BIND_CERT_",
+				Key:            "_TLS_BIND_KEY_",
+				CA:             "_TLS_BIND_CA_",
+				ReloadInterval: "_TLS_BIND_RELOAD_INTERVAL_",
 			},
 			beforeFunc: func(t *testing.T) {
 				t.Helper()
 				t.Setenv("TLS_BIND_CERT", "tls_cert")
 				t.Setenv("TLS_BIND_KEY", "tls_key")
 				t.Setenv("TLS_BIND_CA", "tls_ca")
+				t.Setenv("TLS_BIND_RELOAD_INTERVAL", "1m")
 			},
 			want: want{
 				want: &TLS{
-					Enabled: true,
-					Cert:    "tls_cert",
-					Key:     "tls_key",
-					CA:      "tls_ca",
+					Enabled:        true,
+					Cert:           "tls_cert",
+					Key:            "tls_key",
+					CA:             "tls_ca",
+					ReloadInterval: "1m",
 				},
 			},
 		},
@@ -112,10 +116,11 @@ func TestTLS_Bind(t *testing.T) {
 				checkFunc = defaultCheckFunc
 			}
 			t := &TLS{
-				Enabled: test.fields.Enabled,
-				Cert:    test.fields.Cert,
-				Key:     test.fields.Key,
-				CA:      test.fields.CA,
+				Enabled:        test.fields.Enabled,
+				Cert:           test.fields.Cert,
+				Key:            test.fields.Key,
+				CA:             test.fields.CA,
+				ReloadInterval: test.fields.ReloadInterval,
 			}
 
 			got := t.Bind()
@@ -165,6 +170,7 @@ func TestTLS_Opts(t *testing.T) {
 					tls.WithCert("cert"),
 					tls.WithKey("key"),
 					tls.WithInsecureSkipVerify(false),
+					tls.WithReloadInterval(""),
 				},
 			},
 		},
@@ -176,6 +182,7 @@ func TestTLS_Opts(t *testing.T) {
 					tls.WithCert(""),
 					tls.WithKey(""),
 					tls.WithInsecureSkipVerify(false),
+					tls.WithReloadInterval(""),
 				},
 			},
 		},
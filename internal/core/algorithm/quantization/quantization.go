@@ -0,0 +1,159 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package quantization provides vector quantizers, a raw vector store for exact re-ranking and the exact distance functions.
+package quantization
+
+import (
+	"math"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+// Type represents the quantization type.
+type Type uint8
+
+const (
+	// None represents that vectors are stored without quantization.
+	None Type = iota
+	// Scalar8 represents the scalar quantization which encodes each dimension into 8 bits with learned per-dimension ranges.
+	Scalar8
+)
+
+// String returns the name of the quantization type.
+func (t Type) String() string {
+	switch t {
+	case None:
+		return "none"
+	case Scalar8:
+		return "scalar8"
+	}
+	return "unknown"
+}
+
+// CodeSize returns the number of bytes of the code of a dim-dimensional vector.
+func (t Type) CodeSize(dim int) int {
+	switch t {
+	case Scalar8:
+		return dim
+	}
+	return dim * 4
+}
+
+// ParseType returns the quantization type of the name.
+func ParseType(name string) (Type, error) {
+	switch strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name)) {
+	case "", "none", "float", "float32":
+		return None, nil
+	case "scalar8", "scalar", "sq8", "sq", "int8", "uint8":
+		return Scalar8, nil
+	}
+	return None, errors.ErrUnsupportedQuantizationType(name)
+}
+
+// Distance represents the exact distance function used to re-rank the quantized search results.
+type Distance func(x, y []float32) float32
+
+// NewDistance returns the exact distance function of the NGT distance type name.
+// The returned distances match the values that NGT returns for float32 objects.
+func NewDistance(name string) (Distance, error) {
+	switch strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name)) {
+	case "l1":
+		return l1, nil
+	case "l2":
+		return l2, nil
+	case "normalizedl2", "norml2", "nol2", "nl2":
+		return normalized(l2), nil
+	case "angle", "ang":
+		return angle, nil
+	case "normalizedangle", "normalizedang", "normang", "nang", "nangle":
+		return normalized(angle), nil
+	case "cosine", "cos":
+		return cosine, nil
+	case "normalizedcosine", "normalizedcos", "normcos", "ncos", "ncosine":
+		return normalized(cosine), nil
+	case "dotproduct", "dotp", "dproduct", "dp", "innerproduct", "innerp", "iproduct", "ip":
+		return innerProduct, nil
+	}
+	return nil, errors.ErrUnsupportedQuantizationDistanceType(name)
+}
+
+func l1(x, y []float32) (d float32) {
+	for i := range x {
+		d += float32(math.Abs(float64(x[i] - y[i])))
+	}
+	return d
+}
+
+func l2(x, y []float32) float32 {
+	var d float64
+	for i := range x {
+		diff := float64(x[i] - y[i])
+		d += diff * diff
+	}
+	return float32(math.Sqrt(d))
+}
+
+func cos(x, y []float32) float64 {
+	var dot, nx, ny float64
+	for i := range x {
+		dot += float64(x[i]) * float64(y[i])
+		nx += float64(x[i]) * float64(x[i])
+		ny += float64(y[i]) * float64(y[i])
+	}
+	if nx == 0 || ny == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(nx*ny)
+}
+
+func angle(x, y []float32) float32 {
+	return float32(math.Acos(math.Max(-1, math.Min(1, cos(x, y)))))
+}
+
+func cosine(x, y []float32) float32 {
+	return float32(1 - cos(x, y))
+}
+
+func innerProduct(x, y []float32) (d float32) {
+	for i := range x {
+		d += x[i] * y[i]
+	}
+	return -d
+}
+
+func normalized(f Distance) Distance {
+	return func(x, y []float32) float32 {
+		return f(normalize(x), normalize(y))
+	}
+}
+
+func normalize(x []float32) []float32 {
+	var n float64
+	for _, v := range x {
+		n += float64(v) * float64(v)
+	}
+	if n == 0 {
+		return x
+	}
+	n = math.Sqrt(n)
+	res := make([]float32, len(x))
+	for i, v := range x {
+		res[i] = float32(float64(v) / n)
+	}
+	return res
+}
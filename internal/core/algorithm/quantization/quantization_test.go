@@ -0,0 +1,213 @@
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package quantization
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+)
+
+func TestScalar(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		codes   []float32
+		decoded []float32
+		err     error
+	}
+	type test struct {
+		name    string
+		train   [][]float32
+		trained bool
+		vec     []float32
+		want    want
+	}
+	tests := []test{
+		{
+			name:    "returns codes within the learned ranges",
+			train:   [][]float32{{0, -1}, {1, 1}},
+			trained: true,
+			vec:     []float32{0.5, 0},
+			want: want{
+				codes:   []float32{128, 128},
+				decoded: []float32{128.0 / 255, 1 - 2*127.0/255},
+			},
+		},
+		{
+			name:    "returns clamped codes when the vector is out of the learned ranges",
+			train:   [][]float32{{0, 0}, {1, 1}},
+			trained: true,
+			vec:     []float32{2, -1},
+			want: want{
+				codes:   []float32{255, 0},
+				decoded: []float32{1, 0},
+			},
+		},
+		{
+			name:  "returns error when the quantizer is not trained",
+			train: [][]float32{{0, 0}},
+			vec:   []float32{0, 0},
+			want: want{
+				err: errors.ErrQuantizerNotTrained,
+			},
+		},
+		{
+			name:    "returns error when the dimension does not match",
+			train:   [][]float32{{0, 0}, {1, 1}},
+			trained: true,
+			vec:     []float32{0},
+			want: want{
+				err: errors.ErrIncompatibleDimensionSize(1, 2),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			s := NewScalar(2)
+			for _, v := range test.train {
+				s.Observe(v)
+			}
+			if test.trained {
+				s.Train()
+			}
+			codes, err := s.Encode(test.vec)
+			if !errors.Is(err, test.want.err) {
+				tt.Fatalf("err not equals. want: %v, got: %v", test.want.err, err)
+			}
+			if test.want.err != nil {
+				return
+			}
+			if !reflect.DeepEqual(codes, test.want.codes) {
+				tt.Errorf("codes not equals. want: %v, got: %v", test.want.codes, codes)
+			}
+			decoded, err := s.Decode(codes)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			for i := range decoded {
+				if math.Abs(float64(decoded[i]-test.want.decoded[i])) > 1e-6 {
+					tt.Errorf("decoded not equals. want: %v, got: %v", test.want.decoded, decoded)
+				}
+			}
+			mins, maxs := s.Ranges()
+			loaded, err := LoadScalar(mins, maxs)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if got, _ := loaded.Encode(test.vec); !reflect.DeepEqual(got, codes) {
+				tt.Errorf("loaded codes not equals. want: %v, got: %v", codes, got)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := OpenStore(file.Join(dir, "raw"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if err = s.Put(2, []float32{1.5, -2}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := s.Get(2); err != nil || !reflect.DeepEqual(got, []float32{1.5, -2}) {
+		t.Errorf("vector not equals. want: %v, got: %v, err: %v", []float32{1.5, -2}, got, err)
+	}
+	if _, err = s.Get(3); !errors.Is(err, errors.ErrRawVectorNotFound(3)) {
+		t.Errorf("err not equals. want: %v, got: %v", errors.ErrRawVectorNotFound(3), err)
+	}
+	if got := s.Size(); got != 16 {
+		t.Errorf("size not equals. want: %d, got: %d", 16, got)
+	}
+
+	snapshot := file.Join(dir, "snapshot")
+	if err = s.Snapshot(ctx, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Put(2, []float32{0, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Restore(ctx, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := s.Get(2); err != nil || !reflect.DeepEqual(got, []float32{1.5, -2}) {
+		t.Errorf("restored vector not equals. want: %v, got: %v, err: %v", []float32{1.5, -2}, got, err)
+	}
+
+	// restoring from the store file itself must keep the vectors
+	if err = s.Restore(ctx, file.Join(dir, "raw")); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := s.Get(2); err != nil || !reflect.DeepEqual(got, []float32{1.5, -2}) {
+		t.Errorf("vector not equals after restoring from itself. want: %v, got: %v, err: %v", []float32{1.5, -2}, got, err)
+	}
+}
+
+func TestScalar_SetRanges(t *testing.T) {
+	t.Parallel()
+	s := NewScalar(2)
+	s.Observe([]float32{0, 0})
+	s.Observe([]float32{1, 1})
+	s.Train()
+	if err := s.SetRanges([]float32{0, 0}, []float32{2, 2}); err != nil {
+		t.Fatal(err)
+	}
+	codes, err := s.Encode([]float32{2, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float32{255, 128}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("codes not equals. want: %v, got: %v", want, codes)
+	}
+	if err = s.SetRanges([]float32{0}, []float32{1}); !errors.Is(err, errors.ErrIncompatibleDimensionSize(1, 2)) {
+		t.Errorf("err not equals. want: %v, got: %v", errors.ErrIncompatibleDimensionSize(1, 2), err)
+	}
+}
+
+func TestNewDistance(t *testing.T) {
+	t.Parallel()
+	x, y := []float32{1, 0}, []float32{0, 2}
+	tests := map[string]float32{
+		"l1":               3,
+		"l2":               float32(math.Sqrt(5)),
+		"angle":            math.Pi / 2,
+		"cosine":           1,
+		"normalizedl2":     float32(math.Sqrt2),
+		"innerproduct":     0,
+		"normalizedcosine": 1,
+	}
+	for name, want := range tests {
+		d, err := NewDistance(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := d(x, y); math.Abs(float64(got-want)) > 1e-6 {
+			t.Errorf("%s distance not equals. want: %v, got: %v", name, want, got)
+		}
+	}
+	if _, err := NewDistance("hamming"); !errors.Is(err, errors.ErrUnsupportedQuantizationDistanceType("hamming")) {
+		t.Errorf("err not equals. want: %v, got: %v", errors.ErrUnsupportedQuantizationDistanceType("hamming"), err)
+	}
+}
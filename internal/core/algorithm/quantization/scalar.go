@@ -0,0 +1,161 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package quantization
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+// scalarLevels is the maximum code of the 8 bit scalar quantization.
+const scalarLevels = math.MaxUint8
+
+// Scalar represents the 8 bit scalar quantizer.
+// It learns the minimum and maximum value of each dimension from the observed vectors,
+// and encodes each dimension linearly into the codes 0 to 255 after it is trained.
+type Scalar struct {
+	mu      sync.RWMutex
+	dim     int
+	min     []float32
+	max     []float32
+	trained atomic.Bool
+}
+
+// NewScalar returns an untrained scalar quantizer for dim-dimensional vectors.
+func NewScalar(dim int) *Scalar {
+	s := &Scalar{
+		dim: dim,
+		min: make([]float32, dim),
+		max: make([]float32, dim),
+	}
+	for i := range dim {
+		s.min[i] = math.MaxFloat32
+		s.max[i] = -math.MaxFloat32
+	}
+	return s
+}
+
+// LoadScalar returns the trained scalar quantizer with the learned ranges.
+func LoadScalar(mins, maxs []float32) (*Scalar, error) {
+	if len(mins) != len(maxs) {
+		return nil, errors.ErrIncompatibleDimensionSize(len(maxs), len(mins))
+	}
+	s := &Scalar{
+		dim: len(mins),
+		min: append([]float32(nil), mins...),
+		max: append([]float32(nil), maxs...),
+	}
+	s.trained.Store(true)
+	return s, nil
+}
+
+// Observe widens the per-dimension ranges to include vec. It does nothing after the quantizer is trained.
+func (s *Scalar) Observe(vec []float32) {
+	if s.trained.Load() || len(vec) != s.dim {
+		return
+	}
+	s.mu.Lock()
+	for i, v := range vec {
+		if v < s.min[i] {
+			s.min[i] = v
+		}
+		if v > s.max[i] {
+			s.max[i] = v
+		}
+	}
+	s.mu.Unlock()
+}
+
+// Train freezes the learned ranges. Values outside of the ranges are clamped by Encode afterwards.
+func (s *Scalar) Train() {
+	s.mu.Lock()
+	for i := range s.dim {
+		if s.min[i] > s.max[i] {
+			s.min[i], s.max[i] = 0, 0
+		}
+	}
+	s.mu.Unlock()
+	s.trained.Store(true)
+}
+
+// SetRanges replaces the learned per-dimension ranges, e.g. by the ranges of the quantizer retrained with more vectors.
+// The quantizer is trained after it.
+func (s *Scalar) SetRanges(mins, maxs []float32) error {
+	if len(mins) != s.dim || len(maxs) != s.dim {
+		return errors.ErrIncompatibleDimensionSize(len(mins), s.dim)
+	}
+	s.mu.Lock()
+	copy(s.min, mins)
+	copy(s.max, maxs)
+	s.mu.Unlock()
+	s.trained.Store(true)
+	return nil
+}
+
+// Trained reports whether the ranges are learned.
+func (s *Scalar) Trained() bool {
+	return s.trained.Load()
+}
+
+// Ranges returns copies of the learned per-dimension minimum and maximum values.
+func (s *Scalar) Ranges() (mins, maxs []float32) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]float32(nil), s.min...), append([]float32(nil), s.max...)
+}
+
+// Encode returns the codes of vec as float32 values so that they can be passed to the float32 API of the index.
+func (s *Scalar) Encode(vec []float32) ([]float32, error) {
+	if !s.trained.Load() {
+		return nil, errors.ErrQuantizerNotTrained
+	}
+	if len(vec) != s.dim {
+		return nil, errors.ErrIncompatibleDimensionSize(len(vec), s.dim)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	codes := make([]float32, s.dim)
+	for i, v := range vec {
+		width := s.max[i] - s.min[i]
+		if width <= 0 {
+			continue
+		}
+		c := math.Round(float64((v - s.min[i]) / width * scalarLevels))
+		codes[i] = float32(math.Max(0, math.Min(scalarLevels, c)))
+	}
+	return codes, nil
+}
+
+// Decode returns the approximated vector of the codes.
+func (s *Scalar) Decode(codes []float32) ([]float32, error) {
+	if !s.trained.Load() {
+		return nil, errors.ErrQuantizerNotTrained
+	}
+	if len(codes) != s.dim {
+		return nil, errors.ErrIncompatibleDimensionSize(len(codes), s.dim)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	vec := make([]float32, s.dim)
+	for i, c := range codes {
+		vec[i] = s.min[i] + c/scalarLevels*(s.max[i]-s.min[i])
+	}
+	return vec, nil
+}
@@ -0,0 +1,187 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package quantization
+
+import (
+	"context"
+	"encoding/binary"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+	"github.com/vdaas/vald/internal/io"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+const tempStoreFileName = "raw-vectors.bin"
+
+// Store represents the file backed store of the raw float32 vectors used to re-rank the quantized search results.
+// The vector of an object id is stored at the fixed offset of the id, so that it is read by a single positional read
+// without keeping the vectors in memory.
+type Store struct {
+	mu   sync.RWMutex
+	f    *os.File
+	path string
+	dim  int
+	temp bool
+}
+
+// OpenStore opens or creates the store file at path for dim-dimensional vectors.
+// When path is empty, the store is created as a temporary file which is removed on Close.
+func OpenStore(path string, dim int) (s *Store, err error) {
+	s = &Store{
+		path: path,
+		dim:  dim,
+	}
+	if path == "" {
+		dir, err := file.MkdirTemp("")
+		if err != nil {
+			return nil, err
+		}
+		s.path = file.Join(dir, tempStoreFileName)
+		s.temp = true
+	}
+	s.f, err = file.Open(s.path, os.O_RDWR|os.O_CREATE, fs.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) recordSize() int64 {
+	return int64(s.dim) * 4
+}
+
+func (s *Store) offset(oid uint32) int64 {
+	return int64(oid-1) * s.recordSize()
+}
+
+// Put stores vec as the raw vector of the object id oid.
+func (s *Store) Put(oid uint32, vec []float32) error {
+	if oid == 0 {
+		return errors.ErrRawVectorNotFound(oid)
+	}
+	if len(vec) != s.dim {
+		return errors.ErrIncompatibleDimensionSize(len(vec), s.dim)
+	}
+	buf := make([]byte, s.recordSize())
+	for i, v := range vec {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, err := s.f.WriteAt(buf, s.offset(oid))
+	return err
+}
+
+// Get returns the raw vector of the object id oid.
+func (s *Store) Get(oid uint32) ([]float32, error) {
+	if oid == 0 {
+		return nil, errors.ErrRawVectorNotFound(oid)
+	}
+	buf := make([]byte, s.recordSize())
+	s.mu.RLock()
+	_, err := s.f.ReadAt(buf, s.offset(oid))
+	s.mu.RUnlock()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.ErrRawVectorNotFound(oid)
+		}
+		return nil, err
+	}
+	vec := make([]float32, s.dim)
+	for i := range vec {
+		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:]))
+	}
+	return vec, nil
+}
+
+// Size returns the size of the store file in bytes.
+func (s *Store) Size() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fi, err := s.f.Stat()
+	if err != nil {
+		return 0
+	}
+	return fi.Size()
+}
+
+// Snapshot flushes the store and copies it to dst.
+func (s *Store) Snapshot(ctx context.Context, dst string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.f.Sync(); err != nil {
+		return err
+	}
+	if fi, err := s.f.Stat(); err == nil && fi.Size() == 0 {
+		f, err := file.Open(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fs.ModePerm)
+		if err != nil {
+			return err
+		}
+		return f.Close()
+	}
+	_, err := file.CopyFile(ctx, s.path, dst)
+	return err
+}
+
+// Restore replaces the contents of the store by the snapshot at src.
+// It does nothing when src is the store file itself.
+func (s *Store) Restore(ctx context.Context, src string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sfi, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if fi, err := s.f.Stat(); err == nil && os.SameFile(sfi, fi) {
+		return nil
+	}
+	if sfi.Size() == 0 {
+		return s.f.Truncate(0)
+	}
+	if err = s.f.Close(); err != nil {
+		return err
+	}
+	_, err = file.CopyFile(ctx, src, s.path)
+	if err != nil {
+		return err
+	}
+	s.f, err = file.Open(s.path, os.O_RDWR|os.O_CREATE, fs.ModePerm)
+	return err
+}
+
+// Truncate removes all the stored vectors.
+func (s *Store) Truncate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Truncate(0)
+}
+
+// Close closes the store file, and removes it when it is a temporary store.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.f.Close()
+	if s.temp {
+		err = errors.Join(err, os.RemoveAll(filepath.Dir(s.path)))
+	}
+	return err
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"reflect"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/vdaas/vald/internal/backoff"
@@ -117,3 +118,29 @@ func (c *client) Writer(ctx context.Context, key string) (wc io.WriteCloser, err
 	}
 	return c.writer, nil
 }
+
+// Delete deletes the object of key.
+func (c *client) Delete(ctx context.Context, key string) error {
+	_, err := c.service.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+// List returns the keys of the objects which have the prefix.
+func (c *client) List(ctx context.Context, prefix string) (keys []string, err error) {
+	err = c.service.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucket),
+		Prefix: aws.String(prefix),
+	}, func(out *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range out.Contents {
+			keys = append(keys, aws.StringValue(obj.Key))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
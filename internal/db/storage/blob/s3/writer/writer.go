@@ -40,8 +40,9 @@ type writer struct {
 	contentType string
 	maxPartSize int64
 
-	pw io.WriteCloser
-	wg *sync.WaitGroup
+	pw   io.WriteCloser
+	wg   *sync.WaitGroup
+	uerr error // result of the upload, read after wg is done
 }
 
 // Writer represents an interface to write to s3.
@@ -68,6 +69,7 @@ func New(opts ...Option) Writer {
 // Open method returns an error to align the interface, but it doesn't actually return an error.
 func (w *writer) Open(ctx context.Context, key string) (err error) {
 	w.wg = new(sync.WaitGroup)
+	w.uerr = nil
 
 	var pr io.ReadCloser
 
@@ -75,27 +77,32 @@ func (w *writer) Open(ctx context.Context, key string) (err error) {
 
 	w.wg.Add(1)
 
-	w.eg.Go(safety.RecoverFunc(func() (err error) {
+	w.eg.Go(func() error {
 		defer w.wg.Done()
-		defer pr.Close()
+		w.uerr = safety.RecoverFunc(func() error {
+			defer pr.Close()
 
-		return w.upload(ctx, key, pr)
-	}))
+			return w.upload(ctx, key, pr)
+		})()
+		return w.uerr
+	})
 
 	return err
 }
 
-// Close closes the writer.
-func (w *writer) Close() error {
+// Close closes the writer and waits for the upload to finish.
+// It returns the error of the upload as well as the error of closing the writer.
+func (w *writer) Close() (err error) {
 	if w.pw != nil {
-		return w.pw.Close()
+		err = w.pw.Close()
 	}
 
 	if w.wg != nil {
 		w.wg.Wait()
+		err = errors.Join(err, w.uerr)
 	}
 
-	return nil
+	return err
 }
 
 // Write writes len(p) bytes from p to the underlying data stream. The written data will be uploaded to s3.
@@ -236,6 +236,7 @@ func Test_writer_Close(t *testing.T) {
 		maxPartSize int64
 		pw          io.WriteCloser
 		wg          *sync.WaitGroup
+		uerr        error
 	}
 	type want struct {
 		err error
@@ -284,6 +285,22 @@ func Test_writer_Close(t *testing.T) {
 			},
 		},
 
+		{
+			name: "returns error when upload error occurs",
+			fields: fields{
+				pw: &MockWriteCloser{
+					CloseFunc: func() error {
+						return nil
+					},
+				},
+				wg:   new(sync.WaitGroup),
+				uerr: errors.New("upload err"),
+			},
+			want: want{
+				err: errors.New("upload err"),
+			},
+		},
+
 		{
 			name: "returns nil when no error occurs and writer dose not exist",
 			fields: fields{
@@ -316,6 +333,7 @@ func Test_writer_Close(t *testing.T) {
 				maxPartSize: test.fields.maxPartSize,
 				pw:          test.fields.pw,
 				wg:          test.fields.wg,
+				uerr:        test.fields.uerr,
 			}
 
 			err := w.Close()
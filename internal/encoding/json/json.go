@@ -21,6 +21,21 @@ import (
 	"github.com/vdaas/vald/internal/io"
 )
 
+type (
+	Encoder = json.Encoder
+	Decoder = json.Decoder
+)
+
+// NewEncoder returns an Encoder that writes successive JSON values to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return json.NewEncoder(w)
+}
+
+// NewDecoder returns a Decoder that reads successive JSON values from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return json.NewDecoder(r)
+}
+
 func Encode(w io.Writer, data any) (err error) {
 	return json.NewEncoder(w).Encode(data)
 }
@@ -0,0 +1,373 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package encrypt provides encrypt functions
+package encrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"reflect"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+)
+
+const (
+	// magic is written at the head of the encrypted stream to identify the format.
+	magic = "VALDENC1"
+
+	// saltSize is the size of the random salt of the stream, from which the key of the stream is derived.
+	saltSize = 32
+
+	// noncePrefixSize is the size of the zero prefix of the nonce.
+	// The nonce of each segment is the prefix, the 4 bytes segment counter and the 1 byte final flag,
+	// which is unique since every stream is encrypted by its own key.
+	noncePrefixSize = 7
+
+	// finalFlag is set to the length of the last segment.
+	finalFlag = 1 << 31
+
+	// maxSegmentSize is the maximum plaintext size of a segment.
+	maxSegmentSize = 16 << 20
+)
+
+// KeyFile represents the key file, which has the keys by their IDs and the ID of the active key used to encrypt.
+// The keys are base64 encoded in JSON.
+type KeyFile struct {
+	Active string            `json:"active"`
+	Keys   map[string][]byte `json:"keys"`
+}
+
+// aesGCM encrypts the stream by AES-GCM per segment.
+//
+// The stream consists of the header and the segments:
+//
+//	header:  magic | key ID length (1 byte) | key ID | salt (32 bytes)
+//	segment: ciphertext length (4 bytes, the most significant bit is set for the last segment) | ciphertext
+//
+// The segments are encrypted by the key derived from the key of the key ID and the salt by HKDF-SHA256,
+// so that the nonces never repeat under the same key across the streams.
+// The header is authenticated as the additional data of every segment,
+// and the counter and the final flag in the nonce detect reordered, dropped and truncated segments.
+type aesGCM struct {
+	active      string
+	keys        map[string][]byte
+	segmentSize int
+}
+
+// NewAESGCM returns Encryptor implementation.
+func NewAESGCM(opts ...AESGCMOption) (Encryptor, error) {
+	e := &aesGCM{
+		keys: make(map[string][]byte),
+	}
+	for _, opt := range append(defaultAESGCMOpts, opts...) {
+		if err := opt(e); err != nil {
+			return nil, errors.ErrOptionFailed(err, reflect.ValueOf(opt))
+		}
+	}
+
+	if _, ok := e.keys[e.active]; !ok {
+		return nil, errors.ErrEncryptionKeyNotFound(e.active)
+	}
+
+	return e, nil
+}
+
+func (e *aesGCM) addKey(id string, key []byte) error {
+	if len(id) == 0 || len(id) > math.MaxUint8 {
+		return errors.ErrInvalidEncryptionKey(id, len(key))
+	}
+	_, err := aes.NewCipher(key)
+	if err != nil {
+		return errors.ErrInvalidEncryptionKey(id, len(key))
+	}
+	e.keys[id] = key
+	return nil
+}
+
+// newAEAD returns the AEAD of the stream keyed by the key derived from key and salt.
+func newAEAD(id string, key, salt []byte) (cipher.AEAD, error) {
+	sk, err := hkdf.Key(sha256.New, key, salt, magic+id, len(key))
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(sk)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Reader returns io.ReadCloser implementation which decrypts src by the key of the key ID in its header.
+func (e *aesGCM) Reader(src io.ReadCloser) (io.ReadCloser, error) {
+	return &aesGCMReader{
+		src:  src,
+		keys: e.keys,
+	}, nil
+}
+
+// Writer returns io.WriteCloser implementation which encrypts the data to dst by the active key.
+func (e *aesGCM) Writer(dst io.WriteCloser) (io.WriteCloser, error) {
+	salt := make([]byte, saltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(e.active, e.keys[e.active], salt)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, len(magic)+1+len(e.active)+saltSize)
+	header = append(header, magic...)
+	header = append(header, byte(len(e.active)))
+	header = append(header, e.active...)
+	header = append(header, salt...)
+
+	return &aesGCMWriter{
+		dst:    dst,
+		aead:   aead,
+		header: header,
+		buf:    make([]byte, 0, e.segmentSize),
+		out:    make([]byte, 0, 4+e.segmentSize+aead.Overhead()),
+		nonce:  make([]byte, 0, aead.NonceSize()),
+	}, nil
+}
+
+// nonce returns the nonce of the segment.
+func nonce(dst []byte, counter uint32, final bool) []byte {
+	dst = append(dst[:0], make([]byte, noncePrefixSize)...)
+	dst = binary.BigEndian.AppendUint32(dst, counter)
+	if final {
+		return append(dst, 1)
+	}
+	return append(dst, 0)
+}
+
+type aesGCMWriter struct {
+	dst    io.WriteCloser
+	aead   cipher.AEAD
+	header []byte
+	buf    []byte
+	out    []byte
+	nonce  []byte
+
+	counter     uint32
+	wroteHeader bool
+	closed      bool
+}
+
+// Write returns the number of bytes written from p (0 <= n <= len(p)).
+// If any errors occurs, it will return an error.
+func (w *aesGCMWriter) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		// the full segment is sealed only when more data comes, so that the last segment is always sealed by Close
+		if len(w.buf) == cap(w.buf) {
+			err = w.seal(false)
+			if err != nil {
+				return n, err
+			}
+		}
+		m := copy(w.buf[len(w.buf):cap(w.buf)], p)
+		w.buf = w.buf[:len(w.buf)+m]
+		p = p[m:]
+		n += m
+	}
+	return n, nil
+}
+
+func (w *aesGCMWriter) seal(final bool) (err error) {
+	if !w.wroteHeader {
+		_, err = w.dst.Write(w.header)
+		if err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	if w.counter == math.MaxUint32 {
+		return errors.ErrEncryptedSegmentLimitExceeded
+	}
+
+	w.nonce = nonce(w.nonce, w.counter, final)
+	w.out = w.aead.Seal(w.out[:4], w.nonce, w.buf, w.header)
+	length := uint32(len(w.out) - 4)
+	if final {
+		length |= finalFlag
+	}
+	binary.BigEndian.PutUint32(w.out[:4], length)
+
+	_, err = w.dst.Write(w.out)
+	if err != nil {
+		return err
+	}
+	w.counter++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// Close seals the last segment and closes the writer.
+func (w *aesGCMWriter) Close() (err error) {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	err = w.seal(true)
+	if err != nil {
+		return errors.Join(w.dst.Close(), err)
+	}
+
+	return w.dst.Close()
+}
+
+type aesGCMReader struct {
+	src  io.ReadCloser
+	keys map[string][]byte
+
+	aead   cipher.AEAD
+	header []byte
+	buf    []byte
+	plain  []byte
+	nonce  []byte
+
+	counter uint32
+	final   bool
+	err     error
+}
+
+// Read returns the number of bytes for read p (0 <= n <= len(p)).
+// If any errors occurs, it will return an error.
+func (r *aesGCMReader) Read(p []byte) (n int, err error) {
+	for len(r.plain) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.next()
+	}
+	n = copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+// next decrypts the next segment, and returns io.EOF after the last segment.
+func (r *aesGCMReader) next() error {
+	if r.aead == nil {
+		return r.readHeader()
+	}
+
+	var lb [4]byte
+	if r.final {
+		// no data is allowed after the last segment
+		_, err := io.ReadFull(r.src, lb[:1])
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		if err != nil {
+			return err
+		}
+		return errors.ErrInvalidEncryptedData
+	}
+
+	_, err := io.ReadFull(r.src, lb[:])
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			// the stream is truncated before the last segment
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	length := binary.BigEndian.Uint32(lb[:])
+	final := length&finalFlag != 0
+	length &^= finalFlag
+	if int(length) < r.aead.Overhead() || int(length) > maxSegmentSize+r.aead.Overhead() {
+		return errors.ErrInvalidEncryptedData
+	}
+
+	if cap(r.buf) < int(length) {
+		r.buf = make([]byte, length)
+	}
+	r.buf = r.buf[:length]
+	_, err = io.ReadFull(r.src, r.buf)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	r.nonce = nonce(r.nonce, r.counter, final)
+	// the plaintext is decrypted in place, since the ciphertext is no longer used
+	r.plain, err = r.aead.Open(r.buf[:0], r.nonce, r.buf, r.header)
+	if err != nil {
+		return errors.ErrInvalidEncryptedData
+	}
+	r.counter++
+	r.final = final
+	return nil
+}
+
+func (r *aesGCMReader) readHeader() error {
+	head := make([]byte, len(magic)+1)
+	_, err := io.ReadFull(r.src, head)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			// the encrypted stream always has the header even if the data is empty
+			return errors.ErrEmptyEncryptedData
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return errors.ErrInvalidEncryptedData
+		}
+		return err
+	}
+	if string(head[:len(magic)]) != magic {
+		return errors.ErrInvalidEncryptedData
+	}
+
+	rest := make([]byte, int(head[len(magic)])+saltSize)
+	_, err = io.ReadFull(r.src, rest)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	id := string(rest[:len(rest)-saltSize])
+	key, ok := r.keys[id]
+	if !ok {
+		return errors.ErrEncryptionKeyNotFound(id)
+	}
+	aead, err := newAEAD(id, key, rest[len(rest)-saltSize:])
+	if err != nil {
+		return err
+	}
+
+	r.aead = aead
+	r.header = append(head, rest...)
+	return nil
+}
+
+// Close closes the reader.
+func (r *aesGCMReader) Close() (err error) {
+	return r.src.Close()
+}
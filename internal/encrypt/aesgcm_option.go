@@ -0,0 +1,87 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package encrypt provides encrypt functions
+package encrypt
+
+import (
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+)
+
+// AESGCMOption represents the functional option for aesGCM.
+type AESGCMOption func(e *aesGCM) error
+
+var defaultAESGCMOpts = []AESGCMOption{
+	WithAESGCMSegmentSize(64 << 10),
+}
+
+// WithAESGCMKey represents the option to add the key of the key ID.
+func WithAESGCMKey(id string, key []byte) AESGCMOption {
+	return func(e *aesGCM) error {
+		return e.addKey(id, key)
+	}
+}
+
+// WithAESGCMActiveKey represents the option to set the key ID of the key used to encrypt.
+func WithAESGCMActiveKey(id string) AESGCMOption {
+	return func(e *aesGCM) error {
+		if id != "" {
+			e.active = id
+		}
+		return nil
+	}
+}
+
+// WithAESGCMKeyFile represents the option to add the keys and set the active key ID from the key file.
+func WithAESGCMKeyFile(path string) AESGCMOption {
+	return func(e *aesGCM) error {
+		if path == "" {
+			return errors.ErrPathNotSpecified
+		}
+		b, err := file.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var kf KeyFile
+		err = json.Unmarshal(b, &kf)
+		if err != nil {
+			return err
+		}
+		for id, key := range kf.Keys {
+			err = e.addKey(id, key)
+			if err != nil {
+				return err
+			}
+		}
+		if kf.Active != "" {
+			e.active = kf.Active
+		}
+		return nil
+	}
+}
+
+// WithAESGCMSegmentSize represents the option to set the plaintext size of the encrypted segments.
+func WithAESGCMSegmentSize(size int) AESGCMOption {
+	return func(e *aesGCM) error {
+		if size <= 0 || size > maxSegmentSize {
+			return errors.NewErrInvalidOption("segmentSize", size)
+		}
+		e.segmentSize = size
+		return nil
+	}
+}
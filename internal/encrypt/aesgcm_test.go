@@ -0,0 +1,287 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package encrypt provides encrypt functions
+package encrypt
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+)
+
+var (
+	oldKey = bytes.Repeat([]byte{1}, 32)
+	newKey = bytes.Repeat([]byte{2}, 16)
+)
+
+type nopWriteCloser struct {
+	*bytes.Buffer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func encrypt(t *testing.T, e Encryptor, data []byte) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	w, err := e.Writer(nopWriteCloser{buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = w.Write(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decrypt(e Encryptor, data []byte) ([]byte, error) {
+	r, err := e.Reader(io.NopCloser(bytes.NewReader(data)))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func TestNewAESGCM(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "keys.json")
+	type test struct {
+		name    string
+		opts    []AESGCMOption
+		content string
+		want    error
+	}
+	tests := []test{
+		{
+			name: "return no error when the active key is added",
+			opts: []AESGCMOption{
+				WithAESGCMKey("old", oldKey),
+				WithAESGCMActiveKey("old"),
+			},
+		},
+		{
+			name: "return no error when the keys are loaded from the key file",
+			opts: []AESGCMOption{
+				WithAESGCMKeyFile(keyFile),
+			},
+		},
+		{
+			name: "return an error when the active key is not added",
+			opts: []AESGCMOption{
+				WithAESGCMKey("old", oldKey),
+				WithAESGCMActiveKey("new"),
+			},
+			want: errors.ErrEncryptionKeyNotFound("new"),
+		},
+		{
+			name: "return an error when the key size is invalid",
+			opts: []AESGCMOption{
+				WithAESGCMKey("old", oldKey[:10]),
+			},
+			want: errors.ErrInvalidEncryptionKey("old", 10),
+		},
+	}
+	err := writeKeyFile(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			_, err := NewAESGCM(test.opts...)
+			if !errors.Is(err, test.want) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want)
+			}
+		})
+	}
+}
+
+func writeKeyFile(path string) error {
+	data := []byte(`{"active": "new", "keys": {"old": "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=", "new": "AgICAgICAgICAgICAgICAg=="}}`)
+	return os.WriteFile(path, data, 0o600)
+}
+
+func Test_aesGCM_RoundTrip(t *testing.T) {
+	t.Parallel()
+	data := make([]byte, 300)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	type test struct {
+		name string
+		data []byte
+	}
+	tests := []test{
+		{
+			name: "round trip the empty data",
+			data: []byte{},
+		},
+		{
+			name: "round trip the data smaller than a segment",
+			data: data[:10],
+		},
+		{
+			name: "round trip the data of exactly a segment",
+			data: data[:100],
+		},
+		{
+			name: "round trip the data of multiple segments",
+			data: data,
+		},
+	}
+	e, err := NewAESGCM(WithAESGCMKey("old", oldKey), WithAESGCMActiveKey("old"), WithAESGCMSegmentSize(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			enc := encrypt(tt, e, test.data)
+			if len(test.data) > 0 && bytes.Contains(enc, test.data) {
+				tt.Error("encrypted data contains the plaintext")
+			}
+			got, err := decrypt(e, enc)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if !bytes.Equal(got, test.data) {
+				tt.Errorf("got: %v, want: %v", got, test.data)
+			}
+			if bytes.Equal(encrypt(tt, e, test.data), enc) {
+				tt.Error("the same data is encrypted to the same ciphertext")
+			}
+		})
+	}
+}
+
+func Test_aesGCMReader_Read(t *testing.T) {
+	t.Parallel()
+	data := make([]byte, 300)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	old, err := NewAESGCM(WithAESGCMKey("old", oldKey), WithAESGCMActiveKey("old"), WithAESGCMSegmentSize(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := NewAESGCM(
+		WithAESGCMKey("old", oldKey),
+		WithAESGCMKey("new", newKey),
+		WithAESGCMActiveKey("new"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewAESGCM(WithAESGCMKey("old", newKey), WithAESGCMActiveKey("old"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown, err := NewAESGCM(WithAESGCMKey("unknown", newKey), WithAESGCMActiveKey("unknown"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc := encrypt(t, old, data)
+
+	type test struct {
+		name string
+		e    Encryptor
+		data []byte
+		want error
+	}
+	tests := []test{
+		{
+			name: "return no error when the data is read after the key rotation",
+			e:    rotated,
+			data: enc,
+		},
+		{
+			name: "return an error when the data is empty",
+			e:    old,
+			data: []byte{},
+			want: errors.ErrEmptyEncryptedData,
+		},
+		{
+			name: "return an error when the header is truncated",
+			e:    old,
+			data: enc[:len(magic)+1+len("old")+saltSize-1],
+			want: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "return an error when the key of the key ID is different",
+			e:    other,
+			data: enc,
+			want: errors.ErrInvalidEncryptedData,
+		},
+		{
+			name: "return an error when the key of the key ID is not found",
+			e:    rotated,
+			data: encrypt(t, unknown, data),
+			want: errors.ErrEncryptionKeyNotFound("unknown"),
+		},
+		{
+			name: "return an error when the data is tampered",
+			e:    old,
+			data: func() []byte {
+				b := bytes.Clone(enc)
+				b[len(b)-1] ^= 1
+				return b
+			}(),
+			want: errors.ErrInvalidEncryptedData,
+		},
+		{
+			name: "return an error when the last segment is dropped",
+			e:    old,
+			data: enc[:len(enc)-(4+100+16)],
+			want: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "return an error when the data is appended after the last segment",
+			e:    old,
+			data: append(bytes.Clone(enc), 0),
+			want: errors.ErrInvalidEncryptedData,
+		},
+		{
+			name: "return an error when the data is not encrypted",
+			e:    old,
+			data: data,
+			want: errors.ErrInvalidEncryptedData,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			_, err := decrypt(test.e, test.data)
+			if !errors.Is(err, test.want) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want)
+			}
+		})
+	}
+}
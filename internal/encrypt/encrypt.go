@@ -0,0 +1,26 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package encrypt provides encryptor interface
+package encrypt
+
+import "github.com/vdaas/vald/internal/io"
+
+// Encryptor represents the interface to encrypt and decrypt streams.
+type Encryptor interface {
+	Reader(src io.ReadCloser) (io.ReadCloser, error)
+	Writer(dst io.WriteCloser) (io.WriteCloser, error)
+}
@@ -116,6 +116,24 @@ var (
 	// ErrWriteOperationToReadReplica represents an error that when a write operation is made to read replica.
 	ErrWriteOperationToReadReplica = New("write operation to read replica is not possible")
 
+	// ErrUnsupportedReadReplicaSource represents a function to generate an error that the read replica source is not supported.
+	ErrUnsupportedReadReplicaSource = func(src string) error {
+		return Errorf("unsupported read replica source: %s", src)
+	}
+
+	// ErrInvalidIndexGeneration represents a function to generate an error that the index generation ID is invalid.
+	ErrInvalidIndexGeneration = func(id string) error {
+		return Errorf("invalid index generation: %q", id)
+	}
+
+	// ErrIndexGenerationNotLoaded represents an error that the read replica has not loaded any index generation yet.
+	ErrIndexGenerationNotLoaded = New("no index generation is loaded to the read replica yet")
+
+	// ErrIndexCountMismatch represents a function to generate an error that the loaded index count differs from the metadata.
+	ErrIndexCountMismatch = func(loaded, stored uint64) error {
+		return Errorf("loaded index count %d does not match the metadata index count %d", loaded, stored)
+	}
+
 	// ErrInvalidTimestamp represents a function to generate an error that the timestamp is invalid.
 	ErrInvalidTimestamp = func(ts int64) error {
 		return Errorf("invalid timestamp detected: %d", ts)
@@ -124,6 +142,14 @@ var (
 	// ErrFlushingIsInProgress represents an error that the flushing is in progress, but any request has been received.
 	ErrFlushingIsInProgress = New("flush is in progress")
 
+	// ErrAutoIndexingToggled represents an error that the auto indexing is requested to be enabled or disabled at runtime.
+	ErrAutoIndexingToggled = New("auto indexing can not be enabled or disabled at runtime")
+
+	// ErrUnsupportedRealtimeIndexMode represents a function to generate an error that the real-time index mode is unsupported.
+	ErrUnsupportedRealtimeIndexMode = func(mode string) error {
+		return Errorf("unsupported real-time index mode %s", mode)
+	}
+
 	// ErrUUIDAlreadyExists represents a function to generate an error that the uuid already exists.
 	ErrUUIDAlreadyExists = func(uuid string) error {
 		return Errorf("uuid %s index already exists", uuid)
@@ -0,0 +1,67 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package errors provides error types and function
+package errors
+
+var (
+	// ErrNoCredentials represents an error that the request does not carry the credentials the authenticator handles.
+	ErrNoCredentials = New("no credentials found in the request")
+
+	// ErrUnauthenticated represents an error that no authenticator accepted the request credentials.
+	ErrUnauthenticated = New("request is not authenticated")
+
+	// ErrInvalidAPIKey represents an error that the API key is not registered.
+	ErrInvalidAPIKey = New("invalid api key")
+
+	// ErrNoClientCertificate represents an error that the client did not present a verified certificate.
+	ErrNoClientCertificate = New("no verified client certificate")
+
+	// ErrInvalidToken represents a function to generate an error that the bearer token is rejected.
+	ErrInvalidToken = func(reason string) error {
+		return Errorf("invalid token: %s", reason)
+	}
+
+	// ErrUnsupportedTokenAlgorithm represents a function to generate an error that the token signing algorithm is not supported.
+	ErrUnsupportedTokenAlgorithm = func(alg string) error {
+		return Errorf("unsupported token signing algorithm %q", alg)
+	}
+
+	// ErrJWKNotFound represents a function to generate an error that the JWKS does not contain the signing key.
+	ErrJWKNotFound = func(kid string) error {
+		return Errorf("signing key %q not found in jwks", kid)
+	}
+
+	// ErrInvalidJWK represents a function to generate an error that the JWKS entry cannot be parsed.
+	ErrInvalidJWK = func(kid string, err error) error {
+		return Wrapf(err, "invalid jwk %q", kid)
+	}
+
+	// ErrPermissionDenied represents a function to generate an error that the identity is not allowed to call the method.
+	ErrPermissionDenied = func(subject, method string) error {
+		return Errorf("%q is not allowed to call %s", subject, method)
+	}
+
+	// ErrUnmappedHTTPRoute represents a function to generate an error that the HTTP request is not routed to any RPC.
+	ErrUnmappedHTTPRoute = func(method, path string) error {
+		return Errorf("%s %s is not routed to any RPC", method, path)
+	}
+
+	// ErrUnknownAuthenticator represents a function to generate an error that the interceptor refers to an unconfigured authenticator.
+	ErrUnknownAuthenticator = func(name string) error {
+		return Errorf("authenticator %q is not configured", name)
+	}
+)
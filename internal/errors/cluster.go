@@ -0,0 +1,34 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package errors provides error types and function
+package errors
+
+var (
+	// ErrClusterAgentFactoryNotFound represents an error that the agent factory of the test cluster is not set.
+	ErrClusterAgentFactoryNotFound = New("cluster agent factory not found")
+
+	// ErrClusterAlreadyStarted represents an error that the test cluster is already started.
+	ErrClusterAlreadyStarted = New("cluster already started")
+
+	// ErrClusterNotStarted represents an error that the test cluster is not started yet.
+	ErrClusterNotStarted = New("cluster not started")
+
+	// ErrClusterAgentNotFound represents a function to generate an error that the agent of the test cluster is not found.
+	ErrClusterAgentNotFound = func(idx int) error {
+		return Errorf("cluster agent %d not found", idx)
+	}
+)
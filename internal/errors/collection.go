@@ -0,0 +1,35 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package errors provides error types and function
+package errors
+
+var (
+	// ErrCollectionNotFound represents a function to generate an error that the collection does not exist.
+	ErrCollectionNotFound = func(name string) error {
+		return Errorf("collection %q not found", name)
+	}
+
+	// ErrCollectionAlreadyExists represents a function to generate an error that the collection already exists.
+	ErrCollectionAlreadyExists = func(name string) error {
+		return Errorf("collection %q already exists", name)
+	}
+
+	// ErrInvalidCollectionName represents a function to generate an error that the collection name cannot be used.
+	ErrInvalidCollectionName = func(name string) error {
+		return Errorf("invalid collection name %q: only alphanumerics, '-' and '_' are allowed", name)
+	}
+)
@@ -41,4 +41,14 @@ var (
 	ErrDeepMergeKind = func(kind string, nf string, err error) error {
 		return Errorf("error in %s at %s: %w", kind, nf, err)
 	}
+
+	// ErrUnreloadableConfig represents a function to generate an error that the fields can not be changed without restart.
+	ErrUnreloadableConfig = func(paths ...string) error {
+		return Errorf("configuration fields can not be changed at runtime, restart is required: %v", paths)
+	}
+
+	// ErrInvalidReloadedConfig represents a function to generate an error that the reloaded value of the field is invalid.
+	ErrInvalidReloadedConfig = func(path string, val any) error {
+		return Errorf("configuration field %s can not be reloaded with the invalid value: %v", path, val)
+	}
 )
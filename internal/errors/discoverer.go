@@ -45,4 +45,12 @@ var (
 
 	// ErrInvalidDiscoveryCache represents an error that type conversion of discovery cache failed.
 	ErrInvalidDiscoveryCache = New("cache type cast failed")
+
+	// ErrDiscoveryTargetNotSpecified represents an error that neither an inventory nor SRV records are configured for the static discoverer.
+	ErrDiscoveryTargetNotSpecified = New("neither inventory path nor srv records are specified")
+
+	// ErrUnknownRankingStrategy represents a function to generate an error that the ranking strategy is unknown.
+	ErrUnknownRankingStrategy = func(name string) error {
+		return Errorf("unknown ranking strategy: %s", name)
+	}
 )
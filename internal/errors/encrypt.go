@@ -0,0 +1,39 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package errors provides error types and function
+package errors
+
+var (
+	// ErrEncryptionKeyNotFound represents a function to generate an error of the encryption key not found.
+	ErrEncryptionKeyNotFound = func(id string) error {
+		return Errorf("encryption key %q not found", id)
+	}
+
+	// ErrInvalidEncryptionKey represents a function to generate an error of the invalid encryption key.
+	ErrInvalidEncryptionKey = func(id string, size int) error {
+		return Errorf("invalid encryption key %q: key ID must be 1 to 255 bytes and key must be 16, 24 or 32 bytes, but key is %d bytes", id, size)
+	}
+
+	// ErrInvalidEncryptedData represents an error that the data is not encrypted by the encryptor or is tampered.
+	ErrInvalidEncryptedData = New("invalid encrypted data")
+
+	// ErrEmptyEncryptedData represents an error that the encrypted data is empty, which has not even the header.
+	ErrEmptyEncryptedData = New("empty encrypted data")
+
+	// ErrEncryptedSegmentLimitExceeded represents an error that the stream exceeds the number of the segments encrypted by a key.
+	ErrEncryptedSegmentLimitExceeded = New("encrypted segment limit exceeded")
+)
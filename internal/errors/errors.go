@@ -83,6 +83,21 @@ var (
 		return Wrapf(err, "failed to output %s logs", str)
 	}
 
+	// ErrInvalidLogLevel represents a function to generate an error that the log level is not supported.
+	ErrInvalidLogLevel = func(lv string) error {
+		return Errorf("invalid log level: %q", lv)
+	}
+
+	// ErrInvalidLogFormat represents a function to generate an error that the log format is not supported.
+	ErrInvalidLogFormat = func(fm string) error {
+		return Errorf("invalid log format: %q", fm)
+	}
+
+	// ErrLogOverrideNotFound represents a function to generate an error that the log level override of the prefix does not exist.
+	ErrLogOverrideNotFound = func(prefix string) error {
+		return Errorf("log level override for prefix %q not found", prefix)
+	}
+
 	ErrUnimplemented = func(name string) error {
 		return Errorf("%s is unimplemented", name)
 	}
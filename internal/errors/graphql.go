@@ -0,0 +1,86 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package errors provides error types and function
+package errors
+
+var (
+	// ErrGraphQLSyntax represents a function to generate an error that the GraphQL document has a syntax error at pos.
+	ErrGraphQLSyntax = func(pos int, msg string) error {
+		return Errorf("graphql syntax error at %d: %s", pos, msg)
+	}
+
+	// ErrGraphQLOperationNotFound represents a function to generate an error that the requested GraphQL operation is not found.
+	ErrGraphQLOperationNotFound = func(name string) error {
+		return Errorf("graphql operation %q not found", name)
+	}
+
+	// ErrGraphQLAmbiguousOperation represents an error that the operation name is required because the document has multiple operations.
+	ErrGraphQLAmbiguousOperation = New("graphql operation name is required for documents with multiple operations")
+
+	// ErrGraphQLOperationNotSupported represents a function to generate an error that the schema does not support the operation type.
+	ErrGraphQLOperationNotSupported = func(op string) error {
+		return Errorf("graphql schema does not support %s operations", op)
+	}
+
+	// ErrGraphQLFieldNotFound represents a function to generate an error that the field is not defined on the type.
+	ErrGraphQLFieldNotFound = func(typ, field string) error {
+		return Errorf("graphql field %q is not defined on type %s", field, typ)
+	}
+
+	// ErrGraphQLFragmentNotFound represents a function to generate an error that the fragment is not defined.
+	ErrGraphQLFragmentNotFound = func(name string) error {
+		return Errorf("graphql fragment %q is not defined", name)
+	}
+
+	// ErrGraphQLFragmentCycle represents a function to generate an error that the fragment spreads itself directly or indirectly.
+	ErrGraphQLFragmentCycle = func(name string) error {
+		return Errorf("graphql fragment %q must not form a cycle", name)
+	}
+
+	// ErrGraphQLSelectionDepthExceeded represents a function to generate an error that the selection sets are nested deeper than the limit.
+	ErrGraphQLSelectionDepthExceeded = func(limit int) error {
+		return Errorf("graphql selection sets are nested deeper than the limit of %d", limit)
+	}
+
+	// ErrGraphQLSelectionRequired represents a function to generate an error that the field of object type has no selection set.
+	ErrGraphQLSelectionRequired = func(field, typ string) error {
+		return Errorf("graphql field %q of type %s must have a selection of subfields", field, typ)
+	}
+
+	// ErrGraphQLSelectionNotAllowed represents a function to generate an error that the field of leaf type has a selection set.
+	ErrGraphQLSelectionNotAllowed = func(field, typ string) error {
+		return Errorf("graphql field %q of type %s must not have a selection of subfields", field, typ)
+	}
+
+	// ErrGraphQLInvalidValue represents a function to generate an error that the input value is not valid for the type.
+	ErrGraphQLInvalidValue = func(name, typ string, val any) error {
+		return Errorf("graphql value %v of %q is not valid for type %s", val, name, typ)
+	}
+
+	// ErrGraphQLMutationNotAllowed represents an error that the mutation operation is requested by a GET request.
+	ErrGraphQLMutationNotAllowed = New("graphql mutation operations must be requested by POST requests")
+
+	// ErrGraphQLBatchSizeExceeded represents a function to generate an error that the batch has more requests than the limit.
+	ErrGraphQLBatchSizeExceeded = func(size, limit int) error {
+		return Errorf("graphql batch of %d requests exceeds the limit of %d", size, limit)
+	}
+
+	// ErrGraphQLNullValue represents a function to generate an error that the non-null value is null.
+	ErrGraphQLNullValue = func(name, typ string) error {
+		return Errorf("graphql value of %q must not be null for type %s", name, typ)
+	}
+)
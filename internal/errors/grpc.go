@@ -80,4 +80,14 @@ var (
 	ErrServerStreamServerSend = func(err error) error {
 		return Wrap(err, "gRPC server failed to send to stream")
 	}
+
+	// ErrRateLimitExceeded represents a function to generate an error that the client exceeded the request budget.
+	ErrRateLimitExceeded = func(budget string) error {
+		return Errorf("%s rate limit exceeded", budget)
+	}
+
+	// ErrGRPCCompressorNotFound represents a function to generate an error that the gRPC compressor is not registered.
+	ErrGRPCCompressorNotFound = func(name string) error {
+		return Errorf("gRPC compressor %s is not registered", name)
+	}
 )
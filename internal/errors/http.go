@@ -54,6 +54,9 @@ var (
 	// ErrTransportRetryable represents an error that the transport is retryable.
 	ErrTransportRetryable = New("transport is retryable")
 
+	// ErrHTTPStreamAlreadyStarted represents an error that the http stream response has already been started.
+	ErrHTTPStreamAlreadyStarted = New("http stream response already started")
+
 	// ErrInvalidStatusCode represents a function to generate an error that the http status code is invalid.
 	ErrInvalidStatusCode = func(code int) error {
 		return Errorf("invalid status code: %d", code)
@@ -17,7 +17,18 @@
 // Package errors provides error types and function
 package errors
 
-// ErrCollectorNotFound represents an error that the observability collector is not found.
 var (
+	// ErrCollectorNotFound represents an error that the observability collector is not found.
 	ErrCollectorNotFound = New("observability.collector not found")
+
+	// ErrPrometheusExporterNotStarted represents an error that the prometheus exporter is not started.
+	ErrPrometheusExporterNotStarted = New("prometheus exporter is not started")
+
+	// ErrEmptyRequestID represents an error that the request ID to be sampled is empty.
+	ErrEmptyRequestID = New("request id for trace sampling is empty")
+
+	// ErrTraceSamplingNotFound represents a function to generate an error that the trace sampling of the request ID is not enabled.
+	ErrTraceSamplingNotFound = func(id string) error {
+		return Errorf("trace sampling for request id %q is not enabled", id)
+	}
 )
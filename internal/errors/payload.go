@@ -0,0 +1,30 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package errors provides error types and function
+package errors
+
+var (
+	// ErrUnsupportedVectorEncoding represents a function to generate an error that the vector encoding is not supported.
+	ErrUnsupportedVectorEncoding = func(enc string) error {
+		return Errorf("unsupported vector encoding: %s", enc)
+	}
+
+	// ErrInvalidEncodedVectorLength represents a function to generate an error that the length of the encoded vector is not a multiple of the element size.
+	ErrInvalidEncodedVectorLength = func(enc string, l, size int) error {
+		return Errorf("length %d of the %s encoded vector is not a multiple of %d", l, enc, size)
+	}
+)
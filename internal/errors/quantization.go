@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package errors provides error types and function
+package errors
+
+var (
+	// ErrUnsupportedQuantizationType represents a function to generate an error that the quantization type is not supported.
+	ErrUnsupportedQuantizationType = func(qt string) error {
+		return Errorf("unsupported quantization type: %s", qt)
+	}
+
+	// ErrUnsupportedQuantizationDistanceType represents a function to generate an error that the distance type cannot be re-ranked with quantization.
+	ErrUnsupportedQuantizationDistanceType = func(dt string) error {
+		return Errorf("distance type %s is not supported by quantization", dt)
+	}
+
+	// ErrQuantizationMismatch represents a function to generate an error that the stored index was created with another quantization type.
+	ErrQuantizationMismatch = func(stored, configured string) error {
+		return Errorf("stored index quantization type %q does not match the configured quantization type %q", stored, configured)
+	}
+
+	// ErrQuantizerNotTrained represents an error that the quantizer has not learned its ranges yet.
+	ErrQuantizerNotTrained = New("quantizer is not trained")
+
+	// ErrRawVectorNotFound represents a function to generate an error that the raw vector of the object id is not stored.
+	ErrRawVectorNotFound = func(oid uint32) error {
+		return Errorf("raw vector of object id %d not found", oid)
+	}
+)
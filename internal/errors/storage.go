@@ -17,6 +17,8 @@
 // Package errors provides error types and function
 package errors
 
+import "time"
+
 var (
 	ErrInvalidStorageType = New("invalid storage type")
 
@@ -24,4 +26,27 @@ var (
 	ErrStorageWriterNotOpened = New("writer not opened")
 
 	ErrBucketNotOpened = New("bucket not opened")
+
+	// ErrBackupGenerationNotFound represents a function to generate an error that the backup generation is not found in the manifest.
+	ErrBackupGenerationNotFound = func(id string) error {
+		return Errorf("backup generation %s not found", id)
+	}
+
+	// ErrNoValidBackupGeneration represents a function to generate an error that no valid backup generation taken before the time is listed in the manifest.
+	ErrNoValidBackupGeneration = func(before time.Time) error {
+		if before.IsZero() {
+			return New("no valid backup generation found")
+		}
+		return Errorf("no valid backup generation taken before %s found", before.Format(time.RFC3339))
+	}
+
+	// ErrBackupChecksumMismatch represents a function to generate an error that the checksum of the backup generation does not match the manifest.
+	ErrBackupChecksumMismatch = func(id, want, got string) error {
+		return Errorf("checksum of backup generation %s mismatched: want %s, got %s", id, want, got)
+	}
+
+	// ErrBackupChunkNotFound represents a function to generate an error that the chunks referred by the backup generation are not stored.
+	ErrBackupChunkNotFound = func(id string, hashes ...string) error {
+		return Errorf("%d chunks of backup generation %s are not found: %v", len(hashes), id, hashes)
+	}
 )
@@ -41,6 +41,8 @@ var (
 	EOF              = io.EOF
 	NopCloser        = io.NopCloser
 	Discard          = io.Discard
+	MultiWriter      = io.MultiWriter
+	ReadFull         = io.ReadFull
 	ErrUnexpectedEOF = io.ErrUnexpectedEOF
 	ErrClosedPipe    = io.ErrClosedPipe
 	ErrNoProgress    = io.ErrNoProgress
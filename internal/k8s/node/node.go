@@ -52,6 +52,7 @@ type Node struct {
 	CPURemain    float64
 	MemCapacity  float64
 	MemRemain    float64
+	Labels       map[string]string
 }
 
 func New(opts ...Option) NodeWatcher {
@@ -139,6 +140,7 @@ func (r *reconciler) Reconcile(
 			CPURemain:    float64(remain.Cpu().Value()),
 			MemCapacity:  float64(limit.Memory().Value()),
 			MemRemain:    float64(remain.Memory().Value()),
+			Labels:       node.GetLabels(),
 		})
 	}
 	if r.onReconcile != nil {
@@ -17,6 +17,8 @@
 package log
 
 import (
+	"sync/atomic"
+
 	"github.com/vdaas/vald/internal/log/format"
 	"github.com/vdaas/vald/internal/log/glg"
 	"github.com/vdaas/vald/internal/log/level"
@@ -27,13 +29,19 @@ import (
 	"github.com/vdaas/vald/internal/sync"
 )
 
+// holder wraps the Logger so that the loggers of different types can be swapped atomically.
+type holder struct {
+	logger.Logger
+}
+
 var (
-	l    logger.Logger
-	once sync.Once
+	l       atomic.Pointer[holder]
+	current atomic.Pointer[option]
+	once    sync.Once
 )
 
 func init() {
-	l = glg.New(
+	set(glg.New(
 		glg.WithLevel(level.DEBUG.String()),
 		glg.WithFormat(format.RAW.String()),
 		glg.WithRetry(
@@ -42,7 +50,18 @@ func init() {
 				retry.WithWarn(Warn),
 			),
 		),
-	)
+	))
+}
+
+func get() logger.Logger {
+	if lg := overridden(); lg != nil {
+		return lg
+	}
+	return l.Load().Logger
+}
+
+func set(lg logger.Logger) {
+	l.Store(&holder{lg})
 }
 
 func Init(opts ...Option) {
@@ -51,15 +70,28 @@ func Init(opts ...Option) {
 		for _, opt := range append(defaultOptions, opts...) {
 			opt(o)
 		}
-		l = getLogger(o)
+		current.Store(o)
+		set(getLogger(o))
 	})
 }
 
+// Reload replaces the running logger with the one built from the options, e.g. to change the level at runtime.
+// The previous logger is not closed because the calls in flight may still use it.
+func Reload(opts ...Option) {
+	o := new(option)
+	for _, opt := range append(defaultOptions, opts...) {
+		opt(o)
+	}
+	current.Store(o)
+	set(getLogger(o))
+}
+
 func Close() error {
-	return l.Close()
+	return l.Load().Close()
 }
 
-func getLogger(o *option) logger.Logger {
+// getLogger builds the logger of the type set in the option, the glg options are appended to build an isolated glg instance.
+func getLogger(o *option, gopts ...glg.Option) logger.Logger {
 	switch o.logType {
 	case logger.NOP:
 		return nop.New()
@@ -77,7 +109,7 @@ func getLogger(o *option) logger.Logger {
 	case logger.GLG:
 		fallthrough
 	default:
-		return glg.New(
+		return glg.New(append([]glg.Option{
 			glg.WithLevel(o.level.String()),
 			glg.WithFormat(o.format.String()),
 			glg.WithRetry(
@@ -86,8 +118,7 @@ func getLogger(o *option) logger.Logger {
 					retry.WithWarn(Warn),
 				),
 			),
-		)
-
+		}, gopts...)...)
 	}
 }
 
@@ -96,61 +127,61 @@ func Bold(str string) string {
 }
 
 func Debug(vals ...any) {
-	l.Debug(vals...)
+	get().Debug(vals...)
 }
 
 func Debugf(format string, vals ...any) {
-	l.Debugf(format, vals...)
+	get().Debugf(format, vals...)
 }
 
 func Debugd(msg string, details ...any) {
-	l.Debugd(msg, details...)
+	get().Debugd(msg, details...)
 }
 
 func Info(vals ...any) {
-	l.Info(vals...)
+	get().Info(vals...)
 }
 
 func Infof(format string, vals ...any) {
-	l.Infof(format, vals...)
+	get().Infof(format, vals...)
 }
 
 func Infod(msg string, details ...any) {
-	l.Infod(msg, details...)
+	get().Infod(msg, details...)
 }
 
 func Warn(vals ...any) {
-	l.Warn(vals...)
+	get().Warn(vals...)
 }
 
 func Warnf(format string, vals ...any) {
-	l.Warnf(format, vals...)
+	get().Warnf(format, vals...)
 }
 
 func Warnd(msg string, details ...any) {
-	l.Warnd(msg, details...)
+	get().Warnd(msg, details...)
 }
 
 func Error(vals ...any) {
-	l.Error(vals...)
+	get().Error(vals...)
 }
 
 func Errorf(format string, vals ...any) {
-	l.Errorf(format, vals...)
+	get().Errorf(format, vals...)
 }
 
 func Errord(msg string, details ...any) {
-	l.Errord(msg, details...)
+	get().Errord(msg, details...)
 }
 
 func Fatal(vals ...any) {
-	l.Fatal(vals...)
+	get().Fatal(vals...)
 }
 
 func Fatalf(format string, vals ...any) {
-	l.Fatalf(format, vals...)
+	get().Fatalf(format, vals...)
 }
 
 func Fatald(msg string, details ...any) {
-	l.Fatald(msg, details...)
+	get().Fatald(msg, details...)
 }
@@ -51,7 +51,7 @@ func TestInit(t *testing.T) {
 		afterFunc  func(*testing.T, args)
 	}
 	defaultCheckFunc := func(w want, got logger.Logger) error {
-		if !reflect.DeepEqual(got, l) {
+		if !reflect.DeepEqual(got, get()) {
 			return errors.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", got, w.l)
 		}
 		return nil
@@ -109,7 +109,7 @@ func TestInit(t *testing.T) {
 			}
 
 			Init(test.args.opts...)
-			if err := checkFunc(test.want, l); err != nil {
+			if err := checkFunc(test.want, get()); err != nil {
 				tt.Errorf("error = %v", err)
 			}
 		})
@@ -325,11 +325,11 @@ func TestDebug(t *testing.T) {
 				want: w,
 				beforeFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = ml
+					set(ml)
 				},
 				afterFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = nil
+					set(nil)
 				},
 				checkFunc: func(w want) error {
 					if !reflect.DeepEqual(got, w.vals) {
@@ -406,11 +406,11 @@ func TestDebugf(t *testing.T) {
 				want: w,
 				beforeFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = ml
+					set(ml)
 				},
 				afterFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = nil
+					set(nil)
 				},
 				checkFunc: func(w want) error {
 					if !reflect.DeepEqual(gotFormat, w.format) {
@@ -483,11 +483,11 @@ func TestInfo(t *testing.T) {
 				want: w,
 				beforeFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = ml
+					set(ml)
 				},
 				afterFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = nil
+					set(nil)
 				},
 				checkFunc: func(want) error {
 					if !reflect.DeepEqual(got, w.vals) {
@@ -564,11 +564,11 @@ func TestInfof(t *testing.T) {
 				want: w,
 				beforeFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = ml
+					set(ml)
 				},
 				afterFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = nil
+					set(nil)
 				},
 				checkFunc: func(w want) error {
 					if !reflect.DeepEqual(gotFormat, w.format) {
@@ -641,11 +641,11 @@ func TestWarn(t *testing.T) {
 				want: w,
 				beforeFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = ml
+					set(ml)
 				},
 				afterFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = nil
+					set(nil)
 				},
 				checkFunc: func(want) error {
 					if !reflect.DeepEqual(got, w.vals) {
@@ -722,11 +722,11 @@ func TestWarnf(t *testing.T) {
 				want: w,
 				beforeFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = ml
+					set(ml)
 				},
 				afterFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = nil
+					set(nil)
 				},
 				checkFunc: func(w want) error {
 					if !reflect.DeepEqual(gotFormat, w.format) {
@@ -799,11 +799,11 @@ func TestError(t *testing.T) {
 				want: w,
 				beforeFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = ml
+					set(ml)
 				},
 				afterFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = nil
+					set(nil)
 				},
 				checkFunc: func(w want) error {
 					if !reflect.DeepEqual(got, w.vals) {
@@ -880,11 +880,11 @@ func TestErrorf(t *testing.T) {
 				want: w,
 				beforeFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = ml
+					set(ml)
 				},
 				afterFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = nil
+					set(nil)
 				},
 				checkFunc: func(w want) error {
 					if !reflect.DeepEqual(gotFormat, w.format) {
@@ -957,11 +957,11 @@ func TestFatal(t *testing.T) {
 				want: w,
 				beforeFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = ml
+					set(ml)
 				},
 				afterFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = nil
+					set(nil)
 				},
 				checkFunc: func(w want) error {
 					if !reflect.DeepEqual(got, w.vals) {
@@ -1038,11 +1038,11 @@ func TestFatalf(t *testing.T) {
 				want: w,
 				beforeFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = ml
+					set(ml)
 				},
 				afterFunc: func(t *testing.T, _ args) {
 					t.Helper()
-					l = nil
+					set(nil)
 				},
 				checkFunc: func(w want) error {
 					if !reflect.DeepEqual(gotFormat, w.format) {
@@ -0,0 +1,213 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package log
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	kglg "github.com/kpango/glg"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log/format"
+	"github.com/vdaas/vald/internal/log/glg"
+	"github.com/vdaas/vald/internal/log/level"
+	logger "github.com/vdaas/vald/internal/log/logger"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+// modulePrefix is trimmed from the caller function name so that the prefix can be given relative to the module, e.g. pkg/agent.
+const modulePrefix = "github.com/vdaas/vald/"
+
+// Override represents the level and the format used instead of the configured ones
+// for the log calls from the packages whose import path starts with the prefix.
+// The empty prefix matches every package, and the empty ExpireAt means the override is kept until it is deleted.
+type Override struct {
+	Prefix   string `json:"prefix"`
+	Level    string `json:"level"`
+	Format   string `json:"format"`
+	ExpireAt string `json:"expire_at,omitempty"`
+}
+
+type override struct {
+	Override
+	logger logger.Logger
+	timer  *time.Timer
+}
+
+// overrides is the immutable snapshot of the registered overrides ordered by the longest prefix first.
+type overrides struct {
+	list []*override
+	// callers caches the index of the override matched by the caller program counter, -1 for no match.
+	callers sync.Map[uintptr, int]
+}
+
+var (
+	omu  sync.Mutex
+	ovrs atomic.Pointer[overrides]
+)
+
+// SetOverride changes the level and the format of the logs from the packages matching the prefix.
+// The empty format keeps the configured one. The override is reverted after the duration when it is positive.
+func SetOverride(prefix, lv, fm string, dur time.Duration) (Override, error) {
+	l := level.Atol(lv)
+	if l == level.Unknown {
+		return Override{}, errors.ErrInvalidLogLevel(lv)
+	}
+	o := new(option)
+	if cur := current.Load(); cur != nil {
+		*o = *cur
+	} else {
+		for _, opt := range defaultOptions {
+			opt(o)
+		}
+	}
+	o.level = l
+	if fm != "" {
+		f := format.Atof(fm)
+		if f == format.Unknown {
+			return Override{}, errors.ErrInvalidLogFormat(fm)
+		}
+		o.format = f
+	}
+
+	ov := &override{
+		Override: Override{
+			Prefix: strings.TrimPrefix(prefix, modulePrefix),
+			Level:  o.level.String(),
+			Format: o.format.String(),
+		},
+		// the global glg instance is shared by the configured logger, so the override uses its own instance.
+		logger: getLogger(o, glg.WithGlg(kglg.New())),
+	}
+	if dur > 0 {
+		ov.ExpireAt = time.Now().Add(dur).Format(time.RFC3339)
+		ov.timer = time.AfterFunc(dur, func() {
+			remove(func(cur *override) bool { return cur == ov })
+		})
+	}
+
+	omu.Lock()
+	defer omu.Unlock()
+	list := make([]*override, 0, len(snapshot())+1)
+	for _, cur := range snapshot() {
+		if cur.Prefix == ov.Prefix {
+			cur.stop()
+			continue
+		}
+		list = append(list, cur)
+	}
+	store(append(list, ov))
+	return ov.Override, nil
+}
+
+// DeleteOverride reverts the override of the prefix and reports whether it existed.
+func DeleteOverride(prefix string) bool {
+	prefix = strings.TrimPrefix(prefix, modulePrefix)
+	return remove(func(cur *override) bool { return cur.Prefix == prefix })
+}
+
+// Overrides returns the registered overrides ordered by the longest prefix first.
+func Overrides() []Override {
+	list := snapshot()
+	res := make([]Override, 0, len(list))
+	for _, ov := range list {
+		res = append(res, ov.Override)
+	}
+	return res
+}
+
+func remove(match func(*override) bool) (removed bool) {
+	omu.Lock()
+	defer omu.Unlock()
+	list := make([]*override, 0, len(snapshot()))
+	for _, cur := range snapshot() {
+		if match(cur) {
+			cur.stop()
+			removed = true
+			continue
+		}
+		list = append(list, cur)
+	}
+	if removed {
+		store(list)
+	}
+	return removed
+}
+
+func (ov *override) stop() {
+	if ov.timer != nil {
+		ov.timer.Stop()
+	}
+}
+
+func snapshot() []*override {
+	if snap := ovrs.Load(); snap != nil {
+		return snap.list
+	}
+	return nil
+}
+
+func store(list []*override) {
+	if len(list) == 0 {
+		ovrs.Store(nil)
+		return
+	}
+	slices.SortFunc(list, func(a, b *override) int {
+		return cmp.Compare(len(b.Prefix), len(a.Prefix))
+	})
+	ovrs.Store(&overrides{list: list})
+}
+
+// overridden returns the logger of the override matching the package of the caller of the exported log function,
+// or nil when no override matches.
+func overridden() logger.Logger {
+	snap := ovrs.Load()
+	if snap == nil {
+		return nil
+	}
+	// the overrides are ordered by the longest prefix, so the empty prefix is the only one when it comes first.
+	if snap.list[0].Prefix == "" {
+		return snap.list[0].logger
+	}
+	// 0: overridden, 1: get, 2: exported log function, 3: the caller.
+	pc, _, _, ok := runtime.Caller(3)
+	if !ok {
+		return nil
+	}
+	idx, ok := snap.callers.Load(pc)
+	if !ok {
+		idx = -1
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			name := strings.TrimPrefix(fn.Name(), modulePrefix)
+			for i, ov := range snap.list {
+				if strings.HasPrefix(name, ov.Prefix) {
+					idx = i
+					break
+				}
+			}
+		}
+		snap.callers.Store(pc, idx)
+	}
+	if idx < 0 {
+		return nil
+	}
+	return snap.list[idx].logger
+}
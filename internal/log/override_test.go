@@ -0,0 +1,200 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/internal/errors"
+	logger "github.com/vdaas/vald/internal/log/logger"
+	"github.com/vdaas/vald/internal/test/goleak"
+)
+
+// caller mimics the exported log functions so that overridden sees this test package as the caller.
+func caller() logger.Logger {
+	return get()
+}
+
+func TestSetOverride(t *testing.T) {
+	type args struct {
+		prefix string
+		lv     string
+		fm     string
+		dur    time.Duration
+	}
+	type want struct {
+		want       Override
+		err        error
+		overridden bool
+	}
+	type test struct {
+		name      string
+		args      args
+		want      want
+		checkFunc func(want, Override, error) error
+	}
+	defaultCheckFunc := func(w want, got Override, err error) error {
+		if !errors.Is(err, w.err) {
+			return errors.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, w.err)
+		}
+		if got != w.want {
+			return errors.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", got, w.want)
+		}
+		if lg := caller(); (lg != l.Load().Logger) != w.overridden {
+			return errors.Errorf("overridden: %v, want: %v", lg != l.Load().Logger, w.overridden)
+		}
+		return nil
+	}
+	tests := []test{
+		{
+			name: "overrides the level of every package when the prefix is empty",
+			args: args{
+				lv: "debug",
+				fm: "json",
+			},
+			want: want{
+				want: Override{
+					Level:  "DEBUG",
+					Format: "json",
+				},
+				overridden: true,
+			},
+		},
+		{
+			name: "overrides the level of the package matching the prefix relative to the module",
+			args: args{
+				prefix: "github.com/vdaas/vald/internal/log",
+				lv:     "warn",
+			},
+			want: want{
+				want: Override{
+					Prefix: "internal/log",
+					Level:  "WARN",
+					Format: "unknown",
+				},
+				overridden: true,
+			},
+		},
+		{
+			name: "keeps the configured logger for the package not matching the prefix",
+			args: args{
+				prefix: "pkg/agent",
+				lv:     "debug",
+			},
+			want: want{
+				want: Override{
+					Prefix: "pkg/agent",
+					Level:  "DEBUG",
+					Format: "unknown",
+				},
+			},
+		},
+		{
+			name: "reverts the override after the duration",
+			args: args{
+				lv:  "debug",
+				dur: time.Millisecond,
+			},
+			checkFunc: func(w want, got Override, err error) error {
+				if err != nil {
+					return err
+				}
+				if got.ExpireAt == "" {
+					return errors.New("expire_at is empty")
+				}
+				for range 100 {
+					if len(Overrides()) == 0 {
+						return nil
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+				return errors.Errorf("override is not reverted: %v", Overrides())
+			},
+		},
+		{
+			name: "returns error when the level is invalid",
+			args: args{
+				lv: "verbose",
+			},
+			want: want{
+				err: errors.ErrInvalidLogLevel("verbose"),
+			},
+		},
+		{
+			name: "returns error when the format is invalid",
+			args: args{
+				lv: "debug",
+				fm: "xml",
+			},
+			want: want{
+				err: errors.ErrInvalidLogFormat("xml"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			defer goleak.VerifyNone(tt, goleakIgnoreOptions...)
+			defer func() {
+				for _, ov := range Overrides() {
+					DeleteOverride(ov.Prefix)
+				}
+			}()
+			checkFunc := test.checkFunc
+			if test.checkFunc == nil {
+				checkFunc = defaultCheckFunc
+			}
+
+			got, err := SetOverride(test.args.prefix, test.args.lv, test.args.fm, test.args.dur)
+			if err := checkFunc(test.want, got, err); err != nil {
+				tt.Errorf("error = %v", err)
+			}
+		})
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	defer goleak.VerifyNone(t, goleakIgnoreOptions...)
+	for _, prefix := range []string{"", "pkg/agent/core", "pkg", "pkg/agent/core"} {
+		if _, err := SetOverride(prefix, "debug", "", time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := Overrides()
+	want := []string{"pkg/agent/core", "pkg", ""}
+	if len(got) != len(want) {
+		t.Fatalf("got %d overrides, want %d: %v", len(got), len(want), got)
+	}
+	for i, ov := range got {
+		if ov.Prefix != want[i] {
+			t.Errorf("got[%d].Prefix = %q, want %q", i, ov.Prefix, want[i])
+		}
+	}
+	for _, prefix := range want {
+		if !DeleteOverride(prefix) {
+			t.Errorf("override %q is not deleted", prefix)
+		}
+	}
+	if DeleteOverride("pkg") {
+		t.Error("deleted the override which does not exist")
+	}
+	if got := Overrides(); len(got) != 0 {
+		t.Errorf("overrides remain: %v", got)
+	}
+}
@@ -394,7 +394,7 @@ func (d *dialer) tlsHandshake(
 	}
 	if conn != nil {
 		d.tmu.RLock()
-		tconn = tls.Client(conn, d.tlsConfig)
+		tconn = tls.Client(conn, tls.Clone(d.tlsConfig))
 		d.tmu.RUnlock()
 	}
 	var tctx context.Context
@@ -422,7 +422,7 @@ func (d *dialer) tlsHandshake(
 			d.tmu.RLock()
 			tder := &tls.Dialer{
 				NetDialer: d.der,
-				Config:    d.tlsConfig,
+				Config:    tls.Clone(d.tlsConfig),
 			}
 			d.tmu.RUnlock()
 			conn, err = tder.DialContext(tctx, network, addr)
@@ -434,7 +434,7 @@ func (d *dialer) tlsHandshake(
 			err = safety.RecoverWithoutPanicFunc(func() error {
 				d.tmu.RLock()
 				tder := &tls.Dialer{
-					Config: d.tlsConfig,
+					Config: tls.Clone(d.tlsConfig),
 				}
 				d.tmu.RUnlock()
 				conn, err = tder.DialContext(ttctx, network, addr)
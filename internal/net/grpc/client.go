@@ -90,6 +90,7 @@ type Client interface {
 	GetCallOption() []CallOption
 	GetBackoff() backoff.Backoff
 	SetDisableResolveDNSAddr(addr string, disabled bool)
+	SetPoolSize(ctx context.Context, size int)
 	ConnectedAddrs(context.Context) []string
 	Close(ctx context.Context) error
 }
@@ -953,6 +954,24 @@ func (g *gRPCClient) SetDisableResolveDNSAddr(addr string, disabled bool) {
 	g.disableResolveDNSAddrs.Store(addr, disabled)
 }
 
+// SetPoolSize changes the connection pool size of the connected addresses and of the ones connected later.
+// The sizes less than 1 reset the pools to the default size, the same as leaving the size unconfigured at startup.
+func (g *gRPCClient) SetPoolSize(ctx context.Context, size int) {
+	if size < 1 {
+		size = defaultPoolSize
+	}
+	atomic.StoreUint64(&g.poolSize, uint64(size))
+	g.conns.Range(func(addr string, p pool.Conn) bool {
+		if p == nil || p.Size() == uint64(size) {
+			return true
+		}
+		if _, err := p.Resize(ctx, uint64(size)); err != nil {
+			log.Warnf("failed to resize the connection pool of %s to %d: %v", addr, size, err)
+		}
+		return true
+	})
+}
+
 func (g *gRPCClient) Connect(
 	ctx context.Context, addr string, dopts ...DialOption,
 ) (conn pool.Conn, err error) {
@@ -980,7 +999,7 @@ func (g *gRPCClient) Connect(
 		log.Warnf("creating new connection pool for addr = %s", addr)
 		opts := []pool.Option{
 			pool.WithAddr(addr),
-			pool.WithSize(g.poolSize),
+			pool.WithSize(atomic.LoadUint64(&g.poolSize)),
 			pool.WithDialOptions(append(g.dopts, dopts...)...),
 			pool.WithResolveDNS(func() bool {
 				disabled, ok := g.disableResolveDNSAddrs.Load(addr)
@@ -989,6 +1008,7 @@ func (g *gRPCClient) Connect(
 				}
 				return g.resolveDNS
 			}()),
+			pool.WithOldConnCloseDelay(g.roccd),
 		}
 		if g.bo != nil {
 			opts = append(opts, pool.WithBackoff(g.bo))
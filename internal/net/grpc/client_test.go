@@ -17,6 +17,158 @@
 // Package grpc provides generic functionality for grpc
 package grpc
 
+import (
+	"context"
+	"testing"
+
+	"github.com/vdaas/vald/internal/net"
+)
+
+func Test_gRPCClient_Connect(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		size uint64
+	}
+	tests := []struct {
+		name string
+		size int
+		want want
+	}{
+		{
+			name: "connection pool is connected with the pool size of the client",
+			size: 1,
+			want: want{
+				size: 1,
+			},
+		},
+		{
+			name: "connection pool is connected with the larger pool size of the client",
+			size: 3,
+			want: want{
+				size: 3,
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			lis, err := net.Listen(net.TCP.String(), "127.0.0.1:0")
+			if err != nil {
+				tt.Fatal(err)
+			}
+			srv := NewServer()
+			defer srv.Stop()
+			go srv.Serve(lis)
+
+			addr := lis.Addr().String()
+			g := New(
+				WithAddrs(addr),
+				WithInsecure(true),
+				WithConnectionPoolSize(test.size),
+				WithResolveDNS(false),
+				WithOldConnCloseDelay("10ms"),
+			)
+			defer g.Close(ctx)
+			conn, err := g.Connect(ctx, addr)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if got := conn.Size(); got != test.want.size {
+				tt.Errorf("got size: %d, want: %d", got, test.want.size)
+			}
+			if got := conn.Len(); got != test.want.size {
+				tt.Errorf("got slots: %d, want: %d", got, test.want.size)
+			}
+			if !conn.IsHealthy(ctx) {
+				tt.Error("connection pool is unhealthy after connecting")
+			}
+			again, err := g.Connect(ctx, addr)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if again != conn {
+				tt.Error("connection pool is not reused by the second connect")
+			}
+		})
+	}
+}
+
+func Test_gRPCClient_SetPoolSize(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		size uint64
+	}
+	tests := []struct {
+		name string
+		size int
+		want want
+	}{
+		{
+			name: "connection pool grows to the new size",
+			size: 4,
+			want: want{
+				size: 4,
+			},
+		},
+		{
+			name: "connection pool shrinks to the new size",
+			size: 1,
+			want: want{
+				size: 1,
+			},
+		},
+		{
+			name: "connection pool is reset to the default size when the new size is not set",
+			size: 0,
+			want: want{
+				size: defaultPoolSize,
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			lis, err := net.Listen(net.TCP.String(), "127.0.0.1:0")
+			if err != nil {
+				tt.Fatal(err)
+			}
+			srv := NewServer()
+			defer srv.Stop()
+			go srv.Serve(lis)
+
+			addr := lis.Addr().String()
+			g := New(
+				WithAddrs(addr),
+				WithInsecure(true),
+				WithConnectionPoolSize(2),
+				WithResolveDNS(false),
+				WithOldConnCloseDelay("10ms"),
+			)
+			defer g.Close(ctx)
+			conn, err := g.Connect(ctx, addr)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			g.SetPoolSize(ctx, test.size)
+			if got := conn.Size(); got != test.want.size {
+				tt.Errorf("got size: %d, want: %d", got, test.want.size)
+			}
+			if got := conn.Len(); got != test.want.size {
+				tt.Errorf("got slots: %d, want: %d", got, test.want.size)
+			}
+			if !conn.IsHealthy(ctx) {
+				tt.Error("connection pool is unhealthy after resizing")
+			}
+		})
+	}
+}
+
 // NOT IMPLEMENTED BELOW
 //
 // func TestNew(t *testing.T) {
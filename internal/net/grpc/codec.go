@@ -33,8 +33,31 @@ type vtprotoMessage interface {
 	UnmarshalVT([]byte) error
 }
 
+// vectorDecoder is implemented by the messages whose vectors may be sent in a compact encoding.
+type vectorDecoder interface {
+	DecodeVectors() error
+}
+
+// vectorEncoder is implemented by the messages whose vectors decoded from a compact encoding are encoded again
+// when they are forwarded, so that the encoding is kept on every hop.
+type vectorEncoder interface {
+	HasDecodedVectors() bool
+	EncodeDecodedVectors() error
+	CloneMessageVT() proto.Message
+}
+
 // Marshal returns byte slice representing the proto message marshalling result.
+// The vectors decoded from a compact encoding are marshaled in the encoding, obj itself is not modified.
 func (Codec) Marshal(obj any) (data []byte, err error) {
+	if v, ok := obj.(vectorEncoder); ok && v.HasDecodedVectors() {
+		c, ok := v.CloneMessageVT().(vectorEncoder)
+		if ok {
+			if err = c.EncodeDecodedVectors(); err != nil {
+				return nil, err
+			}
+			obj = c
+		}
+	}
 	switch v := obj.(type) {
 	case vtprotoMessage:
 		data, err = v.MarshalVT()
@@ -49,7 +72,7 @@ func (Codec) Marshal(obj any) (data []byte, err error) {
 	return data, nil
 }
 
-// Unmarshal parses the byte stream data into v.
+// Unmarshal parses the byte stream data into v and decodes the vectors sent in a compact encoding.
 func (Codec) Unmarshal(data []byte, obj any) (err error) {
 	switch v := obj.(type) {
 	case vtprotoMessage:
@@ -59,7 +82,13 @@ func (Codec) Unmarshal(data []byte, obj any) (err error) {
 	default:
 		err = errors.ErrInvalidProtoMessageType(v)
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	if v, ok := obj.(vectorDecoder); ok {
+		return v.DecodeVectors()
+	}
+	return nil
 }
 
 func (Codec) Name() string {
@@ -52,6 +52,13 @@ func TestCodec_Marshal(t *testing.T) {
 		}
 		return nil
 	}
+	decoded := &payload.Insert_Request{
+		Vector: &payload.Object_Vector{
+			Id:       "1",
+			Vector:   []float32{1.0, 2.5},
+			Encoding: payload.Object_Vector_FLOAT16,
+		},
+	}
 	tests := []test{
 		{
 			name: "return marshal result when val is vtproto message",
@@ -71,6 +78,33 @@ func TestCodec_Marshal(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name: "return marshal result encoding the decoded vector again without modifying val",
+			args: args{
+				v: decoded,
+			},
+			want: want{
+				want: func() []byte {
+					b, _ := (&payload.Insert_Request{
+						Vector: &payload.Object_Vector{
+							Id:       "1",
+							Encoding: payload.Object_Vector_FLOAT16,
+							Data:     []byte{0x00, 0x3c, 0x00, 0x41},
+						},
+					}).MarshalVT()
+					return b
+				}(),
+			},
+			checkFunc: func(w want, b []byte, e error) error {
+				if err := defaultCheckFunc(w, b, e); err != nil {
+					return err
+				}
+				if len(decoded.GetVector().GetVector()) != 2 || len(decoded.GetVector().GetData()) != 0 {
+					return errors.New("the marshaled request is modified")
+				}
+				return nil
+			},
+		},
 		{
 			name: "return marshal result when val is empty proto message",
 			args: args{
@@ -164,6 +198,52 @@ func TestCodec_Unmarshal(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name: "unmarshal success to decode the float16 encoded vector",
+			args: args{
+				data: func() []byte {
+					b, _ := Codec{}.Marshal(&payload.Insert_Request{
+						Vector: &payload.Object_Vector{
+							Id:       "1",
+							Encoding: payload.Object_Vector_FLOAT16,
+							Data:     []byte{0x00, 0x3c, 0x00, 0x41},
+						},
+					})
+					return b
+				}(),
+				v: &payload.Insert_Request{},
+			},
+			checkFunc: func(t test, e error) error {
+				if e != nil {
+					return e
+				}
+				if !reflect.DeepEqual(t.args.v.(*payload.Insert_Request).GetVector(), &payload.Object_Vector{
+					Id:       "1",
+					Vector:   []float32{1.0, 2.5},
+					Encoding: payload.Object_Vector_FLOAT16,
+				}) {
+					return errors.New("unmarshal result is not correct")
+				}
+				return nil
+			},
+		},
+		{
+			name: "return error when the encoded vector is invalid",
+			args: args{
+				data: func() []byte {
+					b, _ := Codec{}.Marshal(&payload.Object_Vector{
+						Id:       "1",
+						Encoding: payload.Object_Vector_BYTES,
+						Data:     []byte{0, 1, 2},
+					})
+					return b
+				}(),
+				v: &payload.Object_Vector{},
+			},
+			want: want{
+				err: errors.ErrInvalidEncodedVectorLength("BYTES", 3, 4),
+			},
+		},
 		{
 			name: "return error when data is invalid",
 			args: args{
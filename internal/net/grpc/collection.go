@@ -0,0 +1,70 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package grpc provides generic functionality for grpc
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// CollectionMetadataKey is the request metadata key which selects the collection an RPC operates on.
+// An empty or missing value selects the default collection.
+const CollectionMetadataKey = "vald-collection"
+
+// WithCollection returns a copy of ctx whose outgoing metadata selects the collection name.
+func WithCollection(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		md = md.Copy()
+		md.Set(CollectionMetadataKey, name)
+		return metadata.NewOutgoingContext(ctx, md)
+	}
+	return metadata.AppendToOutgoingContext(ctx, CollectionMetadataKey, name)
+}
+
+// WithIncomingCollection returns a copy of ctx whose incoming metadata selects the collection name.
+// It is used by the REST handlers which call the gRPC handlers directly.
+func WithIncomingCollection(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	md.Set(CollectionMetadataKey, name)
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// CollectionFromIncomingContext returns the collection name selected by the incoming request metadata.
+func CollectionFromIncomingContext(ctx context.Context) string {
+	if vals := metadata.ValueFromIncomingContext(ctx, CollectionMetadataKey); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// ForwardCollection returns a copy of ctx whose outgoing metadata selects the same collection as the incoming request.
+func ForwardCollection(ctx context.Context) context.Context {
+	return WithCollection(ctx, CollectionFromIncomingContext(ctx))
+}
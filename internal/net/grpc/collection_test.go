@@ -0,0 +1,148 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package grpc provides generic functionality for grpc
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/vdaas/vald/internal/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestForwardCollection(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		ctx context.Context
+	}
+	type want struct {
+		want []string
+	}
+	type test struct {
+		name      string
+		args      args
+		want      want
+		checkFunc func(want, context.Context) error
+	}
+	defaultCheckFunc := func(w want, got context.Context) error {
+		md, _ := metadata.FromOutgoingContext(got)
+		if vals := md.Get(CollectionMetadataKey); !reflect.DeepEqual(vals, w.want) {
+			return errors.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", vals, w.want)
+		}
+		return nil
+	}
+	tests := []test{
+		{
+			name: "return the context without collection when the incoming request selects no collection",
+			args: args{
+				ctx: context.Background(),
+			},
+		},
+		{
+			name: "return the context forwarding the collection selected by the incoming request",
+			args: args{
+				ctx: WithIncomingCollection(context.Background(), "images"),
+			},
+			want: want{
+				want: []string{"images"},
+			},
+		},
+		{
+			name: "return the context overriding the collection already set to the outgoing metadata",
+			args: args{
+				ctx: WithIncomingCollection(
+					metadata.AppendToOutgoingContext(context.Background(), CollectionMetadataKey, "texts", "other", "value"),
+					"images",
+				),
+			},
+			want: want{
+				want: []string{"images"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			checkFunc := test.checkFunc
+			if test.checkFunc == nil {
+				checkFunc = defaultCheckFunc
+			}
+			got := ForwardCollection(test.args.ctx)
+			if err := checkFunc(test.want, got); err != nil {
+				tt.Errorf("error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCollectionFromIncomingContext(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		ctx context.Context
+	}
+	type want struct {
+		want string
+	}
+	type test struct {
+		name string
+		args args
+		want want
+	}
+	tests := []test{
+		{
+			name: "return empty string when the context has no metadata",
+			args: args{
+				ctx: context.Background(),
+			},
+		},
+		{
+			name: "return the collection name set to the incoming metadata",
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(CollectionMetadataKey, "images")),
+			},
+			want: want{
+				want: "images",
+			},
+		},
+		{
+			name: "return the collection name replaced by WithIncomingCollection",
+			args: args{
+				ctx: WithIncomingCollection(
+					metadata.NewIncomingContext(context.Background(), metadata.Pairs(CollectionMetadataKey, "texts")),
+					"images",
+				),
+			},
+			want: want{
+				want: "images",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			if got := CollectionFromIncomingContext(test.args.ctx); got != test.want.want {
+				tt.Errorf("got: %q, want: %q", got, test.want.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package grpc provides generic functionality for grpc
+package grpc
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/vdaas/vald/internal/compress"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/sync"
+	"google.golang.org/grpc/encoding"
+)
+
+// The names of the compressors registered with gRPC.
+// A client selects one by the call option and the server replies with the compressor of the request by default.
+const (
+	GzipCompressor = "gzip"
+	LZ4Compressor  = "lz4"
+	ZstdCompressor = "zstd"
+)
+
+// The directions of the compression statistics.
+const (
+	CompressDirection   = "compress"
+	DecompressDirection = "decompress"
+)
+
+func init() {
+	for name, fn := range map[string]func() (compress.Compressor, error){
+		GzipCompressor: func() (compress.Compressor, error) { return compress.NewGzip() },
+		LZ4Compressor:  func() (compress.Compressor, error) { return compress.NewLZ4() },
+		ZstdCompressor: func() (compress.Compressor, error) { return compress.NewZstd() },
+	} {
+		c, err := fn()
+		if err != nil {
+			log.Warnf("failed to initialize the %s gRPC compressor: %v", name, err)
+			continue
+		}
+		encoding.RegisterCompressor(&compressor{name: name, c: c})
+	}
+}
+
+// IsCompressorRegistered returns true when the compressor of the name is registered with gRPC.
+// The empty name and "identity" represent no compression.
+func IsCompressorRegistered(name string) bool {
+	return name == "" || name == "identity" || encoding.GetCompressor(name) != nil
+}
+
+// CompressionKey identifies the statistics of a compressor in one direction.
+type CompressionKey struct {
+	Compressor string
+	Direction  string
+}
+
+// CompressionStats represents the cumulative statistics of a compressor in one direction.
+type CompressionStats struct {
+	// Messages is the number of compressed or decompressed messages.
+	Messages uint64
+	// Uncompressed is the total size of the uncompressed messages in bytes.
+	Uncompressed uint64
+	// Compressed is the total size of the compressed messages in bytes.
+	Compressed uint64
+	// Duration is the total time spent in the compressor.
+	Duration time.Duration
+}
+
+var (
+	compressionMu    sync.Mutex
+	compressionStats = make(map[CompressionKey]CompressionStats)
+)
+
+// CompressionMetrics returns a snapshot of the compression statistics since the process started.
+func CompressionMetrics(context.Context) map[CompressionKey]CompressionStats {
+	compressionMu.Lock()
+	defer compressionMu.Unlock()
+	return maps.Clone(compressionStats)
+}
+
+func recordCompression(name, direction string, uncompressed, compressed int, d time.Duration) {
+	compressionMu.Lock()
+	defer compressionMu.Unlock()
+	key := CompressionKey{Compressor: name, Direction: direction}
+	s := compressionStats[key]
+	s.Messages++
+	s.Uncompressed += uint64(uncompressed)
+	s.Compressed += uint64(compressed)
+	s.Duration += d
+	compressionStats[key] = s
+}
+
+// compressor adapts the stream compressors of the compress package to gRPC.
+type compressor struct {
+	name string
+	c    compress.Compressor
+}
+
+// Compress returns the writer which compresses the message into w.
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	cw := &countWriter{w: w}
+	zw, err := c.c.Writer(nopWriteCloser{cw})
+	if err != nil {
+		return nil, err
+	}
+	return &compressWriter{name: c.name, w: zw, dst: cw}, nil
+}
+
+// Decompress returns the reader which decompresses the message read from r.
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	start := time.Now()
+	cr := &countReader{r: r}
+	zr, err := c.c.Reader(io.NopCloser(cr))
+	if err != nil {
+		return nil, err
+	}
+	return &decompressReader{name: c.name, r: zr, src: cr, elapsed: time.Since(start)}, nil
+}
+
+// Name returns the name used in the grpc-encoding header.
+func (c *compressor) Name() string {
+	return c.name
+}
+
+type compressWriter struct {
+	name    string
+	w       io.WriteCloser
+	dst     *countWriter
+	n       int
+	elapsed time.Duration
+}
+
+func (w *compressWriter) Write(p []byte) (n int, err error) {
+	start := time.Now()
+	n, err = w.w.Write(p)
+	w.elapsed += time.Since(start)
+	w.n += n
+	return n, err
+}
+
+// Close flushes the compressed message and records the statistics.
+func (w *compressWriter) Close() error {
+	start := time.Now()
+	err := w.w.Close()
+	w.elapsed += time.Since(start)
+	if err == nil {
+		recordCompression(w.name, CompressDirection, w.n, w.dst.n, w.elapsed)
+	}
+	return err
+}
+
+type decompressReader struct {
+	name    string
+	r       io.ReadCloser
+	src     *countReader
+	n       int
+	elapsed time.Duration
+	done    bool
+}
+
+// Read reads the decompressed message and records the statistics when the message is fully read.
+// gRPC never closes the reader, so it is closed at the end of the message.
+func (r *decompressReader) Read(p []byte) (n int, err error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	start := time.Now()
+	n, err = r.r.Read(p)
+	r.elapsed += time.Since(start)
+	r.n += n
+	if err != nil {
+		r.done = true
+		if cerr := r.r.Close(); errors.Is(err, io.EOF) && cerr == nil {
+			recordCompression(r.name, DecompressDirection, r.n, r.src.n, r.elapsed)
+		}
+	}
+	return n, err
+}
+
+type countWriter struct {
+	w io.Writer
+	n int
+}
+
+func (w *countWriter) Write(p []byte) (n int, err error) {
+	n, err = w.w.Write(p)
+	w.n += n
+	return n, err
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+type countReader struct {
+	r io.Reader
+	n int
+}
+
+func (r *countReader) Read(p []byte) (n int, err error) {
+	n, err = r.r.Read(p)
+	r.n += n
+	return n, err
+}
@@ -0,0 +1,140 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package grpc provides generic functionality for grpc
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+	"google.golang.org/grpc/encoding"
+)
+
+func Test_compressor(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		err error
+	}
+	type test struct {
+		name string
+		msg  []byte
+		want want
+	}
+	tests := []test{
+		{
+			name: "compress and decompress the repetitive message",
+			msg:  bytes.Repeat([]byte("vald"), 1024),
+		},
+		{
+			name: "compress and decompress the empty message",
+			msg:  []byte{},
+		},
+	}
+
+	// the compressors are tested sequentially because they share the global statistics.
+	for _, name := range []string{GzipCompressor, LZ4Compressor, ZstdCompressor} {
+		c := encoding.GetCompressor(name)
+		if c == nil {
+			t.Fatalf("compressor %s is not registered", name)
+		}
+		for _, test := range tests {
+			t.Run(name+"/"+test.name, func(tt *testing.T) {
+				before := CompressionMetrics(context.Background())
+
+				buf := new(bytes.Buffer)
+				w, err := c.Compress(buf)
+				if err != nil {
+					tt.Fatalf("failed to create the writer: %v", err)
+				}
+				if _, err := w.Write(test.msg); err != nil {
+					tt.Fatalf("failed to write: %v", err)
+				}
+				if err := w.Close(); err != nil {
+					tt.Fatalf("failed to close the writer: %v", err)
+				}
+				compressed := buf.Len()
+
+				r, err := c.Decompress(buf)
+				if err != nil {
+					tt.Fatalf("failed to create the reader: %v", err)
+				}
+				got, err := io.ReadAll(r)
+				if !errors.Is(err, test.want.err) {
+					tt.Fatalf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want.err)
+				}
+				if !bytes.Equal(got, test.msg) {
+					tt.Errorf("got %d bytes, want %d bytes", len(got), len(test.msg))
+				}
+
+				after := CompressionMetrics(context.Background())
+				for dir, key := range map[string]CompressionKey{
+					CompressDirection:   {Compressor: name, Direction: CompressDirection},
+					DecompressDirection: {Compressor: name, Direction: DecompressDirection},
+				} {
+					s, b := after[key], before[key]
+					if s.Messages != b.Messages+1 ||
+						s.Uncompressed-b.Uncompressed != uint64(len(test.msg)) ||
+						s.Compressed-b.Compressed != uint64(compressed) {
+						tt.Errorf("%s statistics got: %+v, before: %+v, message: %d bytes, compressed: %d bytes",
+							dir, s, b, len(test.msg), compressed)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestIsCompressorRegistered(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		arg  string
+		want bool
+	}{
+		{
+			name: "return true when the name is empty",
+			want: true,
+		},
+		{
+			name: "return true when the name is identity",
+			arg:  "identity",
+			want: true,
+		},
+		{
+			name: "return true when the name is zstd",
+			arg:  ZstdCompressor,
+			want: true,
+		},
+		{
+			name: "return false when the name is not registered",
+			arg:  "brotli",
+			want: false,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			if got := IsCompressorRegistered(test.arg); got != test.want {
+				tt.Errorf("got: %v, want: %v", got, test.want)
+			}
+		})
+	}
+}
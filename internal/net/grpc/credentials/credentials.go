@@ -18,8 +18,11 @@
 package credentials
 
 import (
+	"context"
 	"crypto/tls"
+	"net"
 
+	itls "github.com/vdaas/vald/internal/tls"
 	"google.golang.org/grpc/credentials"
 )
 
@@ -27,3 +30,27 @@ import (
 func NewTLS(c *tls.Config) credentials.TransportCredentials {
 	return credentials.NewTLS(c)
 }
+
+// NewClientTLS returns the client TLS credentials which copy c for every handshake,
+// so that the root CAs rotated by the reloading of c are used to verify the servers.
+func NewClientTLS(c *tls.Config) credentials.TransportCredentials {
+	return &clientTLS{
+		TransportCredentials: credentials.NewTLS(c),
+		cfg:                  c,
+	}
+}
+
+type clientTLS struct {
+	credentials.TransportCredentials
+	cfg *tls.Config
+}
+
+func (c *clientTLS) ClientHandshake(
+	ctx context.Context, authority string, conn net.Conn,
+) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(itls.Clone(c.cfg)).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *clientTLS) Clone() credentials.TransportCredentials {
+	return NewClientTLS(c.cfg)
+}
@@ -20,6 +20,7 @@ package health
 import (
 	"github.com/vdaas/vald/internal/log"
 	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/strings"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
@@ -34,3 +35,8 @@ func Register(srv *grpc.Server) {
 	}
 	hsrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 }
+
+// IsHealthCheck reports whether the gRPC full method belongs to the health check service.
+func IsHealthCheck(method string) bool {
+	return strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
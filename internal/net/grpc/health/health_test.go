@@ -89,3 +89,33 @@ func TestRegister(t *testing.T) {
 }
 
 // NOT IMPLEMENTED BELOW
+
+func TestIsHealthCheck(t *testing.T) {
+	t.Parallel()
+	type test struct {
+		name   string
+		method string
+		want   bool
+	}
+	tests := []test{
+		{
+			name:   "return true for the health check method",
+			method: "/grpc.health.v1.Health/Check",
+			want:   true,
+		},
+		{
+			name:   "return false for the other method",
+			method: "/vald.v1.Search/Search",
+			want:   false,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			if got := IsHealthCheck(test.method); got != test.want {
+				tt.Errorf("got: %v, want: %v", got, test.want)
+			}
+		})
+	}
+}
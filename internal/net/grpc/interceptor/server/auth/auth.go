@@ -0,0 +1,192 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides gRPC interceptors for authentication and authorization
+package auth
+
+import (
+	"context"
+
+	"github.com/vdaas/vald/internal/auth"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// AuthInterceptor returns the unary interceptor which authenticates the request with the authenticators in order
+// and stores the identity in the context. The health checks are served without authentication.
+func AuthInterceptor(authns ...auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if auth.IsExempt(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, info.FullMethod, authns)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor returns the stream interceptor which authenticates the stream with the authenticators in order
+// and stores the identity in the stream context.
+func AuthStreamInterceptor(authns ...auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if auth.IsExempt(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), info.FullMethod, authns)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &stream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// AuthorizationInterceptor returns the unary interceptor which checks the authenticated identity against the policy.
+// The health checks are served without authorization.
+func AuthorizationInterceptor(p auth.Policy) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if auth.IsExempt(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if err := authorize(ctx, p, &auth.Request{
+			Method:     info.FullMethod,
+			Collection: grpc.CollectionFromIncomingContext(ctx),
+			IDs:        auth.RequestIDs(req),
+			CheckIDs:   true,
+		}); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizationStreamInterceptor returns the stream interceptor which checks the authenticated identity against the policy
+// when the stream is opened, and the IDs of every message received on the stream.
+func AuthorizationStreamInterceptor(p auth.Policy) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if auth.IsExempt(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx := ss.Context()
+		req := &auth.Request{
+			Method:     info.FullMethod,
+			Collection: grpc.CollectionFromIncomingContext(ctx),
+		}
+		if err := authorize(ctx, p, req); err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{ServerStream: ss, policy: p, req: *req})
+	}
+}
+
+// Credentials extracts the bearer token, the API key and the verified client certificates of the request.
+func Credentials(ctx context.Context) *auth.Credentials {
+	creds := new(auth.Credentials)
+	if md, ok := grpc.FromIncomingContext(ctx); ok {
+		if vals := md.Get(auth.AuthorizationKey); len(vals) != 0 {
+			creds.Token = auth.BearerToken(vals[0])
+		}
+		if vals := md.Get(auth.APIKeyKey); len(vals) != 0 {
+			creds.APIKey = vals[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.AuthInfo != nil {
+		if ti, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			// only the chains verified against the client CA identify the caller.
+			if len(ti.State.VerifiedChains) != 0 {
+				creds.Certificates = ti.State.VerifiedChains[0]
+			}
+		}
+	}
+	return creds
+}
+
+func authenticate(ctx context.Context, method string, authns []auth.Authenticator) (context.Context, error) {
+	id, err := auth.Authenticate(ctx, Credentials(ctx), authns...)
+	if err != nil {
+		err = status.WrapWithUnauthenticated(method+" API request is not authenticated", err)
+		log.Debug(err)
+		return nil, err
+	}
+	return auth.NewContext(ctx, id), nil
+}
+
+func authorize(ctx context.Context, p auth.Policy, req *auth.Request) error {
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		err := status.WrapWithUnauthenticated(req.Method+" API request is not authenticated", errors.ErrUnauthenticated)
+		log.Debug(err)
+		return err
+	}
+	if err := p.Authorize(id, req); err != nil {
+		err = status.WrapWithPermissionDenied(req.Method+" API permission denied", err)
+		log.Debug(err)
+		return err
+	}
+	return nil
+}
+
+// stream overrides the context of a server stream with the authenticated one.
+type stream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *stream) Context() context.Context {
+	return s.ctx
+}
+
+// authorizedStream checks the IDs of every received message against the policy.
+type authorizedStream struct {
+	grpc.ServerStream
+	policy auth.Policy
+	req    auth.Request
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	req := s.req
+	req.IDs = auth.RequestIDs(m)
+	req.CheckIDs = true
+	return authorize(s.Context(), s.policy, &req)
+}
@@ -0,0 +1,120 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package auth provides gRPC interceptors for authentication and authorization
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/auth"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/codes"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuthInterceptors(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		ctx    context.Context
+		method string
+		req    any
+	}
+	type test struct {
+		name string
+		args args
+		want codes.Code
+	}
+	authn := AuthInterceptor(auth.NewAPIKey(map[string]auth.Identity{
+		"secret": {Subject: "indexer"},
+	}))
+	authz := AuthorizationInterceptor(auth.NewPolicy(auth.Rule{
+		Subjects:   []string{"indexer"},
+		Methods:    []string{"/vald.v1.Insert/*"},
+		IDPrefixes: []string{"a-"},
+	}))
+	handler := func(ctx context.Context, req any) (any, error) {
+		return req, nil
+	}
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.APIKeyKey, key))
+	}
+	tests := []test{
+		{
+			name: "call the handler when the request is authenticated and authorized",
+			args: args{
+				ctx: withKey("secret"),
+				req: &payload.Insert_Request{Vector: &payload.Object_Vector{Id: "a-1"}},
+			},
+			want: codes.OK,
+		},
+		{
+			name: "return Unauthenticated when the request carries no credentials",
+			args: args{
+				ctx: context.Background(),
+				req: &payload.Insert_Request{Vector: &payload.Object_Vector{Id: "a-1"}},
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "return Unauthenticated when the api key is unknown",
+			args: args{
+				ctx: withKey("guess"),
+				req: &payload.Insert_Request{Vector: &payload.Object_Vector{Id: "a-1"}},
+			},
+			want: codes.Unauthenticated,
+		},
+		{
+			name: "return PermissionDenied when the id is outside the granted prefixes",
+			args: args{
+				ctx: withKey("secret"),
+				req: &payload.Insert_Request{Vector: &payload.Object_Vector{Id: "b-1"}},
+			},
+			want: codes.PermissionDenied,
+		},
+		{
+			name: "call the handler of the health check without credentials",
+			args: args{
+				ctx:    context.Background(),
+				method: "/grpc.health.v1.Health/Check",
+			},
+			want: codes.OK,
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			info := &grpc.UnaryServerInfo{
+				FullMethod: "/vald.v1.Insert/Insert",
+			}
+			if test.args.method != "" {
+				info.FullMethod = test.args.method
+			}
+			_, err := authn(test.args.ctx, test.args.req, info, func(ctx context.Context, req any) (any, error) {
+				return authz(ctx, req, info, handler)
+			})
+			st, _ := status.FromError(err)
+			if got := st.Code(); got != test.want {
+				tt.Errorf("got code: %v, want: %v, err: %v", got, test.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package compress provides gRPC interceptors selecting the compressor of the response messages
+package compress
+
+import (
+	"context"
+	"slices"
+
+	"github.com/vdaas/vald/internal/net/grpc"
+)
+
+// CompressInterceptor returns the interceptor which compresses the response with the compressor of the name
+// when the client accepts it, otherwise the response is compressed with the compressor of the request.
+func CompressInterceptor(name string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp any, err error) {
+		setSendCompressor(ctx, name)
+		return handler(ctx, req)
+	}
+}
+
+// CompressStreamInterceptor returns the stream interceptor which compresses the response messages with the compressor of the name
+// when the client accepts it, otherwise the messages are compressed with the compressor of the request.
+func CompressStreamInterceptor(name string) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		setSendCompressor(ss.Context(), name)
+		return handler(srv, ss)
+	}
+}
+
+func setSendCompressor(ctx context.Context, name string) {
+	names, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil || !slices.Contains(names, name) {
+		return
+	}
+	// the error is ignored because the response is still sent with the compressor of the request.
+	_ = grpc.SetSendCompressor(ctx, name)
+}
@@ -0,0 +1,50 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package ratelimit provides gRPC interceptors limiting the request rate of each client
+package ratelimit
+
+import (
+	"context"
+	"maps"
+
+	"github.com/vdaas/vald/internal/sync"
+)
+
+// ThrottleKey identifies a throttled counter. Unit is "request" for unary calls and "message" for stream messages.
+type ThrottleKey struct {
+	Method string
+	Budget string
+	Unit   string
+}
+
+var (
+	mu        sync.Mutex
+	throttles = make(map[ThrottleKey]int64)
+)
+
+func throttled(method string, b Budget, unit string) {
+	mu.Lock()
+	throttles[ThrottleKey{Method: method, Budget: b.String(), Unit: unit}]++
+	mu.Unlock()
+}
+
+// Metrics returns the number of throttled requests and stream messages since the process started.
+func Metrics(context.Context) map[ThrottleKey]int64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return maps.Clone(throttles)
+}
@@ -0,0 +1,84 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package ratelimit provides gRPC interceptors limiting the request rate of each client
+package ratelimit
+
+import (
+	"github.com/vdaas/vald/internal/timeutil"
+	"golang.org/x/time/rate"
+)
+
+// Option represents the functional option for the limiter.
+type Option func(*limiter)
+
+var defaultOptions = []Option{
+	WithIdleTimeout("10m"),
+}
+
+// WithReadLimit returns the option to set the read token bucket refilled by r tokens per second up to burst tokens.
+func WithReadLimit(r float64, burst int) Option {
+	return withLimit(Read, r, burst)
+}
+
+// WithWriteLimit returns the option to set the write token bucket refilled by r tokens per second up to burst tokens.
+func WithWriteLimit(r float64, burst int) Option {
+	return withLimit(Write, r, burst)
+}
+
+func withLimit(b Budget, r float64, burst int) Option {
+	return func(l *limiter) {
+		if r <= 0 {
+			return
+		}
+		if burst <= 0 {
+			burst = max(int(r), 1)
+		}
+		l.limits[b], l.bursts[b] = rate.Limit(r), burst
+	}
+}
+
+// WithKeyMetadata returns the option to identify the unauthenticated and the trusted clients by the value of the metadata key
+// instead of the peer address or the authenticated subject.
+func WithKeyMetadata(key string) Option {
+	return func(l *limiter) {
+		l.keyMetadata = key
+	}
+}
+
+// WithTrustedSubjects returns the option to set the authenticated subjects allowed to identify the client by the key metadata,
+// such as a gateway forwarding the requests of its own clients.
+func WithTrustedSubjects(subjects ...string) Option {
+	return func(l *limiter) {
+		l.trusted = append(l.trusted, subjects...)
+	}
+}
+
+// WithStreamMaxWait returns the option to set how long a stream message over the limit waits before the stream fails.
+func WithStreamMaxWait(dur string) Option {
+	return func(l *limiter) {
+		l.maxWait = timeutil.ParseWithDefault(dur, l.maxWait)
+	}
+}
+
+// WithIdleTimeout returns the option to set how long the buckets of an idle client are kept.
+func WithIdleTimeout(dur string) Option {
+	return func(l *limiter) {
+		if d := timeutil.ParseWithDefault(dur, 0); d > 0 {
+			l.idleTimeout = d
+		}
+	}
+}
@@ -0,0 +1,401 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package ratelimit provides gRPC interceptors limiting the request rate of each client
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/vdaas/vald/internal/auth"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/errdetails"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/sync"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Budget is the kind of token bucket a request is charged to.
+type Budget uint8
+
+const (
+	Read Budget = iota
+	Write
+)
+
+func (b Budget) String() string {
+	if b == Write {
+		return "write"
+	}
+	return "read"
+}
+
+// Limiter holds a read and a write token bucket per client.
+type Limiter interface {
+	// Take charges n tokens of the budget to the client and returns how long the client should wait when the budget is exhausted.
+	// A request larger than the burst of the budget is never served.
+	Take(key string, b Budget, n int) (retryAfter time.Duration, ok bool)
+	UnaryInterceptor() grpc.UnaryServerInterceptor
+	StreamInterceptor() grpc.StreamServerInterceptor
+	// NewHTTPContext returns the context of the HTTP request r which charges the requests passed to Charge and ChargeMessage
+	// to the client of r. method is the gRPC full method bound to r, it is used when they are called without a method.
+	NewHTTPContext(r *http.Request, method string) context.Context
+}
+
+type limiter struct {
+	limits      [2]rate.Limit
+	bursts      [2]int
+	keyMetadata string
+	trusted     []string
+	maxWait     time.Duration
+	idleTimeout time.Duration
+	buckets     sync.Map[string, *bucket]
+	calls       atomic.Uint64
+}
+
+type bucket struct {
+	limiters [2]*rate.Limiter
+	lastSeen atomic.Int64
+}
+
+// cleanupInterval is the number of calls between the scans dropping the buckets of idle clients.
+const cleanupInterval = 4096
+
+// New returns the limiter. A budget whose rate is zero is not limited.
+func New(opts ...Option) Limiter {
+	l := new(limiter)
+	for _, opt := range append(defaultOptions, opts...) {
+		opt(l)
+	}
+	return l
+}
+
+func (l *limiter) Take(key string, b Budget, n int) (time.Duration, bool) {
+	if l.limits[b] <= 0 || n <= 0 {
+		return 0, true
+	}
+	now := time.Now()
+	if l.calls.Add(1)%cleanupInterval == 0 {
+		l.cleanup(now)
+	}
+	bk, ok := l.buckets.Load(key)
+	if !ok {
+		bk = new(bucket)
+		for i := range bk.limiters {
+			if l.limits[i] > 0 {
+				bk.limiters[i] = rate.NewLimiter(l.limits[i], l.bursts[i])
+			}
+		}
+		bk, _ = l.buckets.LoadOrStore(key, bk)
+	}
+	bk.lastSeen.Store(now.UnixNano())
+	r := bk.limiters[b].ReserveN(now, n)
+	if !r.OK() {
+		return time.Second, false
+	}
+	if d := r.DelayFrom(now); d > 0 {
+		r.CancelAt(now)
+		return d, false
+	}
+	return 0, true
+}
+
+func (l *limiter) cleanup(now time.Time) {
+	deadline := now.Add(-l.idleTimeout).UnixNano()
+	l.buckets.Range(func(key string, bk *bucket) bool {
+		if bk.lastSeen.Load() < deadline {
+			l.buckets.Delete(key)
+		}
+		return true
+	})
+}
+
+// UnaryInterceptor returns the interceptor charging each request the number of requests it carries.
+func (l *limiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := l.charge(l.key(ctx), info.FullMethod, requestCount(req)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// charge charges the n requests of method to the client. It fails when the budget is exhausted,
+// and when n exceeds the burst of the budget since such a request could never be served at once.
+func (l *limiter) charge(key, method string, n int) error {
+	b := BudgetOf(method)
+	if l.limits[b] > 0 && n > l.bursts[b] {
+		return tooLarge(method, b, n, l.bursts[b])
+	}
+	if d, ok := l.Take(key, b, n); !ok {
+		return exhausted(method, b, d, "request")
+	}
+	return nil
+}
+
+// wait charges a stream message of method to the client, waiting up to the configured maximum wait when the budget is exhausted.
+func (l *limiter) wait(ctx context.Context, key, method string) error {
+	b := BudgetOf(method)
+	for waited := time.Duration(0); ; {
+		d, ok := l.Take(key, b, 1)
+		if ok {
+			return nil
+		}
+		if waited+d > l.maxWait {
+			return exhausted(method, b, d, "message")
+		}
+		waited += d
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// StreamInterceptor returns the interceptor charging each received stream message one token.
+// A message over the limit waits up to the configured maximum wait, and fails the stream after that.
+func (l *limiter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, &stream{
+			ServerStream: ss,
+			limiter:      l,
+			key:          l.key(ss.Context()),
+			method:       info.FullMethod,
+		})
+	}
+}
+
+type stream struct {
+	grpc.ServerStream
+	limiter *limiter
+	key     string
+	method  string
+}
+
+func (s *stream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.limiter.wait(s.Context(), s.key, s.method)
+}
+
+type httpKey struct{}
+
+// httpCharger charges the requests of an HTTP request to its client.
+type httpCharger struct {
+	limiter *limiter
+	key     string
+	method  string
+}
+
+// NewHTTPContext returns the context of the HTTP request r which charges the requests to the client of r.
+// The client is identified the same as a gRPC request, the header named as the key metadata is read instead of the metadata.
+func (l *limiter) NewHTTPContext(r *http.Request, method string) context.Context {
+	key := l.key(r.Context())
+	if sub, ok := l.metadataKeyAllowed(r.Context()); ok {
+		if v := r.Header.Get(l.keyMetadata); v != "" {
+			key = "md:" + sub + v
+		}
+	}
+	if key == "" {
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			key = "ip:" + host
+		} else {
+			key = "ip:" + r.RemoteAddr
+		}
+	}
+	return context.WithValue(r.Context(), httpKey{}, &httpCharger{
+		limiter: l,
+		key:     key,
+		method:  method,
+	})
+}
+
+// Charge charges req of the gRPC full method to the client of the HTTP request of ctx, the same as the unary interceptor.
+// An empty method is the method bound to the HTTP request.
+// It returns nil when the context has no limiter, i.e. the HTTP request is not limited or is served by gRPC.
+func Charge(ctx context.Context, method string, req any) error {
+	c, ok := ctx.Value(httpKey{}).(*httpCharger)
+	if !ok || c == nil {
+		return nil
+	}
+	if method == "" {
+		method = c.method
+	}
+	return c.limiter.charge(c.key, method, requestCount(req))
+}
+
+// ChargeMessage charges a stream message to the client of the HTTP request of ctx, the same as the stream interceptor.
+// It returns nil when the context has no limiter.
+func ChargeMessage(ctx context.Context) error {
+	c, ok := ctx.Value(httpKey{}).(*httpCharger)
+	if !ok || c == nil {
+		return nil
+	}
+	return c.limiter.wait(ctx, c.key, c.method)
+}
+
+// key returns the authenticated subject identifying the client, or the metadata value, or the peer address.
+// The metadata value is accepted only from an unauthenticated or a trusted caller, so that an authenticated client
+// cannot get fresh buckets by rotating it.
+func (l *limiter) key(ctx context.Context) string {
+	if sub, ok := l.metadataKeyAllowed(ctx); ok {
+		if md, ok := grpc.FromIncomingContext(ctx); ok {
+			if vals := md.Get(l.keyMetadata); len(vals) != 0 && vals[0] != "" {
+				return "md:" + sub + vals[0]
+			}
+		}
+	}
+	if id, ok := auth.FromContext(ctx); ok && id.Subject != "" {
+		return "id:" + id.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "ip:" + host
+		}
+		return "ip:" + p.Addr.String()
+	}
+	return ""
+}
+
+// metadataKeyAllowed reports whether the caller of ctx may be identified by the key metadata.
+// The returned prefix scopes the metadata value to the trusted subject forwarding it.
+func (l *limiter) metadataKeyAllowed(ctx context.Context) (prefix string, ok bool) {
+	if l.keyMetadata == "" {
+		return "", false
+	}
+	id, ok := auth.FromContext(ctx)
+	if !ok || id.Subject == "" {
+		return "", true
+	}
+	if slices.Contains(l.trusted, id.Subject) {
+		return id.Subject + ":", true
+	}
+	return "", false
+}
+
+var writeMethods = []string{
+	"Insert",
+	"Update",
+	"Upsert",
+	"Remove",
+	"Flush",
+	"CreateIndex",
+	"SaveIndex",
+	"CreateAndSaveIndex",
+	"CreateCollection",
+	"DropCollection",
+}
+
+// BudgetOf returns the budget of the gRPC full method, the methods modifying the index are charged to the write budget.
+func BudgetOf(fullMethod string) Budget {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, w := range writeMethods {
+		if strings.Contains(name, w) {
+			return Write
+		}
+	}
+	return Read
+}
+
+// requestCount returns the number of requests of a Multi request, and 1 for the others.
+// req may also be a pointer to the request, such as the decoding target of a REST request.
+func requestCount(req any) int {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		if rv := reflect.ValueOf(req); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			msg, ok = rv.Elem().Interface().(proto.Message)
+		}
+	}
+	if !ok || msg == nil || !msg.ProtoReflect().IsValid() {
+		return 1
+	}
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName("requests")
+	if fd == nil || !fd.IsList() || fd.Kind() != protoreflect.MessageKind {
+		return 1
+	}
+	if n := m.Get(fd).List().Len(); n > 0 {
+		return n
+	}
+	return 1
+}
+
+func tooLarge(method string, b Budget, n, burst int) error {
+	throttled(method, b, "request")
+	err := status.WrapWithResourceExhausted(
+		fmt.Sprintf("%s API request of %d exceeds the %s burst of %d, split it into smaller requests", method, n, b, burst),
+		errors.ErrRateLimitExceeded(b.String()),
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailureViolation{
+				{
+					Subject:     b.String(),
+					Description: fmt.Sprintf("per client %s request burst of %d", b, burst),
+				},
+			},
+		},
+	)
+	log.Debug(err)
+	return err
+}
+
+func exhausted(method string, b Budget, retryAfter time.Duration, unit string) error {
+	throttled(method, b, unit)
+	err := status.WrapWithResourceExhausted(
+		fmt.Sprintf("%s API %s budget exhausted, retry after %s", method, b, retryAfter),
+		errors.ErrRateLimitExceeded(b.String()),
+		&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(retryAfter),
+		},
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailureViolation{
+				{
+					Subject:     b.String(),
+					Description: "per client " + b.String() + " " + unit + " rate limit",
+				},
+			},
+		},
+	)
+	log.Debug(err)
+	return err
+}
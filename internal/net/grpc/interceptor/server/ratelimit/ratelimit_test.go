@@ -0,0 +1,244 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package ratelimit provides gRPC interceptors limiting the request rate of each client
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/auth"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/codes"
+	"github.com/vdaas/vald/internal/net/grpc/errdetails"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestBudgetOf(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		method string
+		want   Budget
+	}{
+		{method: "/vald.v1.Search/Search", want: Read},
+		{method: "/vald.v1.Search/StreamSearchByID", want: Read},
+		{method: "/vald.v1.Object/GetObject", want: Read},
+		{method: "/vald.v1.Insert/MultiInsert", want: Write},
+		{method: "/vald.v1.Remove/RemoveByTimestamp", want: Write},
+		{method: "/vald.v1.Flush/Flush", want: Write},
+		{method: "/vald.v1.Filter/UpsertObject", want: Write},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.method, func(tt *testing.T) {
+			tt.Parallel()
+			if got := BudgetOf(test.method); got != test.want {
+				tt.Errorf("got: %s, want: %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLimiter_UnaryInterceptor(t *testing.T) {
+	t.Parallel()
+	type call struct {
+		client string
+		method string
+		req    any
+		want   codes.Code
+		// tooLarge is true when the request exceeds the burst, which is rejected without retry info.
+		tooLarge bool
+	}
+	type test struct {
+		name  string
+		opts  []Option
+		calls []call
+	}
+	insert := &payload.Insert_Request{}
+	multi := &payload.Insert_MultiRequest{
+		Requests: []*payload.Insert_Request{{}, {}, {}},
+	}
+	search := &payload.Search_Request{}
+	tests := []test{
+		{
+			name: "reject the request over the write budget of the client",
+			opts: []Option{WithKeyMetadata("client-id"), WithWriteLimit(0.001, 2)},
+			calls: []call{
+				{client: "a", method: "/vald.v1.Insert/Insert", req: insert, want: codes.OK},
+				{client: "a", method: "/vald.v1.Insert/Insert", req: insert, want: codes.OK},
+				{client: "a", method: "/vald.v1.Insert/Insert", req: insert, want: codes.ResourceExhausted},
+				{client: "b", method: "/vald.v1.Insert/Insert", req: insert, want: codes.OK},
+			},
+		},
+		{
+			name: "keep the read and write budgets separate",
+			opts: []Option{WithKeyMetadata("client-id"), WithReadLimit(0.001, 1), WithWriteLimit(0.001, 1)},
+			calls: []call{
+				{client: "a", method: "/vald.v1.Insert/Insert", req: insert, want: codes.OK},
+				{client: "a", method: "/vald.v1.Search/Search", req: search, want: codes.OK},
+				{client: "a", method: "/vald.v1.Search/Search", req: search, want: codes.ResourceExhausted},
+			},
+		},
+		{
+			name: "charge a multi request the number of requests it carries",
+			opts: []Option{WithKeyMetadata("client-id"), WithWriteLimit(0.001, 4)},
+			calls: []call{
+				{client: "a", method: "/vald.v1.Insert/MultiInsert", req: multi, want: codes.OK},
+				{client: "a", method: "/vald.v1.Insert/MultiInsert", req: multi, want: codes.ResourceExhausted},
+				{client: "a", method: "/vald.v1.Insert/Insert", req: insert, want: codes.OK},
+			},
+		},
+		{
+			name: "reject a multi request larger than the burst without charging it",
+			opts: []Option{WithKeyMetadata("client-id"), WithWriteLimit(0.001, 2)},
+			calls: []call{
+				{client: "a", method: "/vald.v1.Insert/MultiInsert", req: multi, want: codes.ResourceExhausted, tooLarge: true},
+				{client: "a", method: "/vald.v1.Insert/Insert", req: insert, want: codes.OK},
+				{client: "a", method: "/vald.v1.Insert/Insert", req: insert, want: codes.OK},
+			},
+		},
+		{
+			name: "never limit the budget without rate",
+			opts: []Option{WithKeyMetadata("client-id"), WithWriteLimit(0.001, 1)},
+			calls: []call{
+				{client: "a", method: "/vald.v1.Search/Search", req: search, want: codes.OK},
+				{client: "a", method: "/vald.v1.Search/Search", req: search, want: codes.OK},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			intercept := New(test.opts...).UnaryInterceptor()
+			for i, c := range test.calls {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("client-id", c.client))
+				_, err := intercept(ctx, c.req, &grpc.UnaryServerInfo{FullMethod: c.method}, func(context.Context, any) (any, error) {
+					return nil, nil
+				})
+				st, _ := status.FromError(err)
+				if st.Code() != c.want {
+					tt.Fatalf("call %d got code: %v, want: %v, err: %v", i, st.Code(), c.want, err)
+				}
+				if c.want != codes.ResourceExhausted {
+					continue
+				}
+				var retry bool
+				for _, d := range st.Details() {
+					if ri, ok := d.(*errdetails.RetryInfo); ok && ri.GetRetryDelay().AsDuration() > 0 {
+						retry = true
+					}
+				}
+				if retry == c.tooLarge {
+					tt.Errorf("call %d got retry info: %v, want: %v, details: %v", i, retry, !c.tooLarge, st.Details())
+				}
+			}
+		})
+	}
+}
+
+func TestCharge(t *testing.T) {
+	t.Parallel()
+	l := New(WithKeyMetadata("client-id"), WithWriteLimit(0.001, 4))
+	newRequest := func(client string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/insert", nil)
+		r.Header.Set("client-id", client)
+		return r
+	}
+	multi := &payload.Insert_MultiRequest{
+		Requests: []*payload.Insert_Request{{}, {}, {}},
+	}
+
+	if err := Charge(context.Background(), "/vald.v1.Insert/MultiInsert", multi); err != nil {
+		t.Fatalf("the context without limiter is limited: %v", err)
+	}
+	ctx := l.NewHTTPContext(newRequest("a"), "/vald.v1.Insert/MultiInsert")
+	if err := Charge(ctx, "", multi); err != nil {
+		t.Fatalf("the first request is limited: %v", err)
+	}
+	if st, _ := status.FromError(Charge(ctx, "", multi)); st.Code() != codes.ResourceExhausted {
+		t.Errorf("got code: %v, want: %v", st.Code(), codes.ResourceExhausted)
+	}
+	if err := Charge(ctx, "/vald.v1.Search/Search", &payload.Search_Request{}); err != nil {
+		t.Errorf("the read request is limited: %v", err)
+	}
+	if err := Charge(l.NewHTTPContext(newRequest("b"), "/vald.v1.Insert/MultiInsert"), "", multi); err != nil {
+		t.Errorf("the request of another client is limited: %v", err)
+	}
+}
+
+func Test_limiter_key(t *testing.T) {
+	t.Parallel()
+	type test struct {
+		name    string
+		subject string
+		client  string
+		want    string
+	}
+	tests := []test{
+		{
+			name:   "identify the unauthenticated caller by the key metadata",
+			client: "a",
+			want:   "md:a",
+		},
+		{
+			name:    "identify the authenticated caller by its subject ignoring the key metadata",
+			subject: "alice",
+			client:  "a",
+			want:    "id:alice",
+		},
+		{
+			name:    "identify the client of the trusted caller by the key metadata scoped to the caller",
+			subject: "gateway",
+			client:  "a",
+			want:    "md:gateway:a",
+		},
+		{
+			name:    "identify the trusted caller by its subject without the key metadata",
+			subject: "gateway",
+			want:    "id:gateway",
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			l := New(WithKeyMetadata("client-id"), WithTrustedSubjects("gateway")).(*limiter)
+			ctx := context.Background()
+			if test.subject != "" {
+				ctx = auth.NewContext(ctx, &auth.Identity{Subject: test.subject})
+			}
+			r := httptest.NewRequest(http.MethodPost, "/insert", nil).WithContext(ctx)
+			if test.client != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("client-id", test.client))
+				r.Header.Set("client-id", test.client)
+			}
+			if got := l.key(ctx); got != test.want {
+				tt.Errorf("got key: %s, want: %s", got, test.want)
+			}
+			c, _ := l.NewHTTPContext(r, "").Value(httpKey{}).(*httpCharger)
+			if c == nil || c.key != test.want {
+				tt.Errorf("got HTTP charger: %+v, want key: %s", c, test.want)
+			}
+		})
+	}
+}
@@ -14,14 +14,73 @@
 package trace
 
 import (
+	"context"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
 	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/observability/trace"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc/metadata"
 )
 
+// RequestIDMetadataKey is the request metadata key of the request ID used to enable the sampling of a specific request.
+const RequestIDMetadataKey = "x-request-id"
+
 func TraceInterceptor() grpc.UnaryServerInterceptor {
-	return otelgrpc.UnaryServerInterceptor()
+	ui := otelgrpc.UnaryServerInterceptor()
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return ui(withRequestID(ctx, req), req, info, handler)
+	}
 }
 
 func TraceStreamInterceptor() grpc.StreamServerInterceptor {
-	return otelgrpc.StreamServerInterceptor()
+	si := otelgrpc.StreamServerInterceptor()
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		if rctx := withRequestID(ctx, nil); rctx != ctx {
+			ss = &wrappedStream{ServerStream: ss, ctx: rctx}
+		}
+		return si(srv, ss, info, handler)
+	}
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
+
+// withRequestID marks the context to be sampled when the sampling is enabled for the request ID
+// carried by the request metadata or the search configuration of the request message.
+func withRequestID(ctx context.Context, req any) context.Context {
+	if vals := metadata.ValueFromIncomingContext(ctx, RequestIDMetadataKey); len(vals) > 0 {
+		if rctx := trace.WithRequestID(ctx, vals[0]); rctx != ctx {
+			return rctx
+		}
+	}
+	switch r := req.(type) {
+	case searchRequest:
+		return withSearchRequestID(ctx, r)
+	case *payload.Search_MultiRequest:
+		return withSearchRequestID(ctx, r.GetRequests()...)
+	case *payload.Search_MultiIDRequest:
+		return withSearchRequestID(ctx, r.GetRequests()...)
+	}
+	return ctx
+}
+
+type searchRequest interface {
+	GetConfig() *payload.Search_Config
+}
+
+func withSearchRequestID[R searchRequest](ctx context.Context, reqs ...R) context.Context {
+	for _, req := range reqs {
+		if rctx := trace.WithRequestID(ctx, req.GetConfig().GetRequestId()); rctx != ctx {
+			return rctx
+		}
+	}
+	return ctx
 }
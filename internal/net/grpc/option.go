@@ -26,6 +26,7 @@ import (
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/log"
 	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/net/grpc/credentials"
 	"github.com/vdaas/vald/internal/net/grpc/interceptor/client/metric"
 	"github.com/vdaas/vald/internal/net/grpc/interceptor/client/trace"
 	"github.com/vdaas/vald/internal/strings"
@@ -33,15 +34,17 @@ import (
 	"github.com/vdaas/vald/internal/timeutil"
 	"google.golang.org/grpc"
 	gbackoff "google.golang.org/grpc/backoff"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 )
 
 type Option func(*gRPCClient)
 
+// defaultPoolSize is the connection pool size used when the size is not configured.
+const defaultPoolSize = 3
+
 var defaultOptions = []Option{
-	WithConnectionPoolSize(3),
+	WithConnectionPoolSize(defaultPoolSize),
 	WithEnableConnectionPoolRebalance(false),
 	WithConnectionPoolRebalanceDuration("1h"),
 	WithErrGroup(errgroup.Get()),
@@ -222,6 +225,7 @@ API References https://pkg.go.dev/google.golang.org/grpc#CallOption
 - func MaxCallRecvMsgSize(bytes int) CallOption
 - func MaxCallSendMsgSize(bytes int) CallOption
 - func MaxRetryRPCBufferSize(bytes int) CallOption
+- func UseCompressor(name string) CallOption
 - func WaitForReady(waitForReady bool) CallOption
 
 2. Unnecessary for this package APIs
@@ -235,7 +239,6 @@ API References https://pkg.go.dev/google.golang.org/grpc#CallOption
 - func ForceCodec(codec encoding.Codec) CallOption
 - func ForceCodecV2(codec encoding.CodecV2) CallOption
 - func OnFinish(onFinish func(err error)) CallOption
-- func UseCompressor(name string) CallOption
 
 4. Deprecated APIs
 - func CallCustomCodec(codec Codec) CallOption
@@ -262,6 +265,19 @@ func WithCallContentSubtype(contentSubtype string) Option {
 	}
 }
 
+// WithCallCompressor returns the option to compress the request messages with the registered compressor of the name.
+func WithCallCompressor(name string) Option {
+	return func(g *gRPCClient) {
+		if name == "" {
+			return
+		}
+		if g.copts == nil && cap(g.copts) == 0 {
+			g.copts = make([]grpc.CallOption, 0, defaultCallOptionLength)
+		}
+		g.copts = append(g.copts, grpc.UseCompressor(name))
+	}
+}
+
 func WithMaxRecvMsgSize(size int) Option {
 	return func(g *gRPCClient) {
 		if size > 1 {
@@ -518,7 +534,7 @@ func WithTLSConfig(cfg *tls.Config) Option {
 				g.dopts = make([]grpc.DialOption, 0, defaultDialOptionLength)
 			}
 			g.dopts = append(g.dopts,
-				grpc.WithTransportCredentials(credentials.NewTLS(cfg)),
+				grpc.WithTransportCredentials(credentials.NewClientTLS(cfg)),
 			)
 		}
 	}
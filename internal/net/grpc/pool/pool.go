@@ -66,6 +66,8 @@ type Conn interface {
 	Size() uint64
 	// Reconnect re-establishes connections if the pool is unhealthy or if forced.
 	Reconnect(context.Context, bool) (Conn, error)
+	// Resize changes the number of connection slots, dialing the added slots and closing the removed ones.
+	Resize(context.Context, uint64) (Conn, error)
 	// String returns a string representation of the pool's state.
 	String() string
 }
@@ -491,6 +493,47 @@ func (p *pool) Reconnect(ctx context.Context, force bool) (Conn, error) {
 	return p.Connect(ctx)
 }
 
+// Resize changes the number of connection slots to size.
+// The added slots are dialed at once, and the connections of the removed slots are closed after the old connection close delay.
+func (p *pool) Resize(ctx context.Context, size uint64) (Conn, error) {
+	if p == nil || p.closing.Load() || size < 1 {
+		return p, nil
+	}
+	slots := p.getSlots()
+	if slots == nil {
+		return p, nil
+	}
+	old := *slots
+	current := uint64(len(old))
+	if size > current {
+		p.grow(size)
+		return p.Connect(ctx)
+	}
+	if size == current {
+		return p, nil
+	}
+	newSlots := make([]atomic.Pointer[poolConn], size)
+	for idx := range newSlots {
+		newSlots[idx].Store(old[idx].Load())
+	}
+	p.connSlots.Store(&newSlots)
+	p.poolSize.Store(size)
+	for idx := size; idx < current; idx++ {
+		pc := old[idx].Load()
+		if pc == nil || pc.conn == nil {
+			continue
+		}
+		p.errGroup.Go(func() error {
+			log.Debugf("closing connection pool %d/%d for addr: %s removed by resizing to %d", idx+1, current, pc.addr, size)
+			if err := pc.Close(ctx, p.oldConnCloseDelay); err != nil {
+				log.Errorf("failed to close connection pool %d/%d addr = %s\terror = %v", idx+1, current, pc.addr, err)
+			}
+			return nil
+		})
+	}
+	return p, nil
+}
+
 // Disconnect gracefully closes all connections in the pool.
 func (p *pool) Disconnect(ctx context.Context) (err error) {
 	log.Debug("Disconnecting pool...")
@@ -18,6 +18,7 @@
 package grpc
 
 import (
+	"context"
 	"time"
 
 	"google.golang.org/grpc"
@@ -49,6 +50,16 @@ func NewServer(opts ...ServerOption) *Server {
 	return grpc.NewServer(opts...)
 }
 
+// SetSendCompressor is a alias of grpc.SetSendCompressor that sets the compressor of the response messages.
+func SetSendCompressor(ctx context.Context, name string) error {
+	return grpc.SetSendCompressor(ctx, name)
+}
+
+// ClientSupportedCompressors is a alias of grpc.ClientSupportedCompressors that returns the compressors the client accepts.
+func ClientSupportedCompressors(ctx context.Context) ([]string, error) {
+	return grpc.ClientSupportedCompressors(ctx)
+}
+
 // Creds is a alias of grpc.Creds that sets credentials for server connections.
 func Creds(c credentials.TransportCredentials) ServerOption {
 	return grpc.Creds(c)
@@ -20,13 +20,16 @@ package status
 import (
 	"encoding/json"
 	"reflect"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/info"
 	"github.com/vdaas/vald/internal/net/grpc/codes"
 	"github.com/vdaas/vald/internal/net/grpc/errdetails"
 	"github.com/vdaas/vald/internal/test/goleak"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func TestMain(m *testing.M) {
@@ -152,6 +155,103 @@ func TestParseError(t *testing.T) {
 	}
 }
 
+func Test_withDetails(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		st      *Status
+		err     error
+		details []any
+	}
+	type want struct {
+		types []string
+		delay time.Duration
+	}
+	type test struct {
+		name string
+		args args
+		want want
+	}
+	defaultCheckFunc := func(w want, got *Status) error {
+		types := make([]string, 0, len(got.Details()))
+		var delay time.Duration
+		for _, d := range got.Details() {
+			types = append(types, reflect.TypeOf(d).String())
+			if r, ok := d.(*errdetails.RetryInfo); ok {
+				delay = r.GetRetryDelay().AsDuration()
+			}
+		}
+		slices.Sort(types)
+		if !reflect.DeepEqual(types, w.types) {
+			return errors.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", types, w.types)
+		}
+		if delay != w.delay {
+			return errors.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", delay, w.delay)
+		}
+		return nil
+	}
+	withRequestInfo := func() *Status {
+		st, err := New(codes.Internal, "internal").WithDetails(&errdetails.RequestInfo{
+			RequestId: "sample request ID",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return st
+	}
+	tests := []test{
+		{
+			name: "return the status with each detail when the status has no detail",
+			args: args{
+				st:  New(codes.ResourceExhausted, "exhausted"),
+				err: errors.New("rate limit exceeded"),
+				details: []any{
+					&errdetails.RetryInfo{
+						RetryDelay: durationpb.New(time.Second),
+					},
+					&errdetails.QuotaFailure{
+						Violations: []*errdetails.QuotaFailureViolation{
+							{
+								Subject: "read",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				types: []string{"*errdetails.ErrorInfo", "*errdetails.QuotaFailure", "*errdetails.RetryInfo"},
+				delay: time.Second,
+			},
+		},
+		{
+			name: "return the status with each detail merged into the details of the status",
+			args: args{
+				st:  withRequestInfo(),
+				err: errors.New("rate limit exceeded"),
+				details: []any{
+					&errdetails.RetryInfo{
+						RetryDelay: durationpb.New(time.Second),
+					},
+				},
+			},
+			want: want{
+				types: []string{"*errdetails.ErrorInfo", "*errdetails.RequestInfo", "*errdetails.RetryInfo"},
+				delay: time.Second,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			got := withDetails(test.args.st, test.args.err, test.args.details...)
+			if err := defaultCheckFunc(test.want, got); err != nil {
+				tt.Errorf("error = %v", err)
+			}
+		})
+	}
+}
+
 // NOT IMPLEMENTED BELOW
 //
 // func TestNew(t *testing.T) {
@@ -0,0 +1,178 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package graphql provides a GraphQL query executor over typed schemas and its HTTP handler.
+package graphql
+
+// Document represents a parsed GraphQL executable document.
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// OperationType represents the type of a GraphQL operation.
+type OperationType uint8
+
+const (
+	// Query represents a read-only GraphQL operation.
+	Query OperationType = iota
+	// Mutation represents a GraphQL operation whose top-level fields are executed serially.
+	Mutation
+)
+
+func (o OperationType) String() string {
+	switch o {
+	case Query:
+		return "query"
+	case Mutation:
+		return "mutation"
+	}
+	return "unknown"
+}
+
+// Operation represents a query or mutation definition.
+type Operation struct {
+	Type         OperationType
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+}
+
+// VariableDefinition represents a variable declared by an operation.
+type VariableDefinition struct {
+	Name    string
+	Type    *TypeRef
+	Default Value
+}
+
+// TypeRef represents a type reference written in a variable definition.
+type TypeRef struct {
+	Name    string
+	Elem    *TypeRef
+	NonNull bool
+}
+
+// Fragment represents a named fragment definition.
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+}
+
+// Selection represents one of *FieldSelection, *FragmentSpread and *InlineFragment.
+type Selection interface {
+	directives() []*Directive
+}
+
+// FieldSelection represents a selected field.
+type FieldSelection struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+}
+
+// ResponseKey returns the key of the field in the response.
+func (f *FieldSelection) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// FragmentSpread represents a named fragment spread.
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+}
+
+// InlineFragment represents an inline fragment.
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+}
+
+func (f *FieldSelection) directives() []*Directive { return f.Directives }
+func (f *FragmentSpread) directives() []*Directive { return f.Directives }
+func (f *InlineFragment) directives() []*Directive { return f.Directives }
+
+// Argument represents a named argument of a field or directive.
+type Argument struct {
+	Name  string
+	Value Value
+}
+
+// Directive represents a directive such as @skip or @include.
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+}
+
+// Value represents one of the literal values, a list, an object or a variable reference.
+type Value interface {
+	// Resolve returns the Go representation of the value using vars for variable references.
+	Resolve(vars map[string]any) any
+}
+
+type (
+	// IntValue represents an integer literal.
+	IntValue int64
+	// FloatValue represents a float literal.
+	FloatValue float64
+	// StringValue represents a string literal.
+	StringValue string
+	// BooleanValue represents a boolean literal.
+	BooleanValue bool
+	// EnumValue represents an enum literal.
+	EnumValue string
+	// NullValue represents the null literal.
+	NullValue struct{}
+	// ListValue represents a list literal.
+	ListValue []Value
+	// ObjectValue represents an input object literal.
+	ObjectValue []*Argument
+	// Variable represents a variable reference.
+	Variable string
+)
+
+func (v IntValue) Resolve(map[string]any) any     { return int64(v) }
+func (v FloatValue) Resolve(map[string]any) any   { return float64(v) }
+func (v StringValue) Resolve(map[string]any) any  { return string(v) }
+func (v BooleanValue) Resolve(map[string]any) any { return bool(v) }
+func (v EnumValue) Resolve(map[string]any) any    { return string(v) }
+func (NullValue) Resolve(map[string]any) any      { return nil }
+
+func (v ListValue) Resolve(vars map[string]any) any {
+	l := make([]any, 0, len(v))
+	for _, e := range v {
+		l = append(l, e.Resolve(vars))
+	}
+	return l
+}
+
+func (v ObjectValue) Resolve(vars map[string]any) any {
+	m := make(map[string]any, len(v))
+	for _, f := range v {
+		m[f.Name] = f.Value.Resolve(vars)
+	}
+	return m
+}
+
+func (v Variable) Resolve(vars map[string]any) any {
+	return vars[string(v)]
+}
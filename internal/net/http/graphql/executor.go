@@ -0,0 +1,564 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"slices"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+)
+
+const typeNameField = "__typename"
+
+// Request represents a GraphQL request.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response represents a GraphQL response.
+type Response struct {
+	Data   any      `json:"data"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Error represents an error of a GraphQL response.
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// errNullPropagation is returned when a non-null position resolved to null and its parent must become null.
+var errNullPropagation = errors.New("graphql non-null value resolved to null")
+
+type executor struct {
+	schema *Schema
+	doc    *Document
+	vars   map[string]any
+
+	// validated is the deepest depth at which each fragment has been validated.
+	validated map[string]int
+
+	mu     sync.Mutex
+	errors []*Error
+}
+
+type collectedField struct {
+	key    string
+	fields []*FieldSelection
+}
+
+// orderedMap keeps the order of the response keys as requested.
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+// MarshalJSON encodes the map with its keys in insertion order.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		kb, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(kb)
+		buf.WriteByte(':')
+		vb, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(vb)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Execute parses and executes req against the schema.
+// Top-level query fields are resolved concurrently, while top-level mutation fields are resolved serially.
+func (s *Schema) Execute(ctx context.Context, req *Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	var root *Object
+	switch op.Type {
+	case Query:
+		root = s.Query
+	case Mutation:
+		root = s.Mutation
+	}
+	if root == nil {
+		return &Response{Errors: []*Error{{Message: errors.ErrGraphQLOperationNotSupported(op.Type.String()).Error()}}}
+	}
+	if err = doc.checkFragmentCycles(); err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	e := &executor{
+		schema:    s,
+		doc:       doc,
+		vars:      make(map[string]any, len(op.Variables)),
+		validated: make(map[string]int, len(doc.Fragments)),
+	}
+	for _, def := range op.Variables {
+		v, ok := req.Variables[def.Name]
+		if !ok && def.Default != nil {
+			v, ok = def.Default.Resolve(nil), true
+		}
+		if ok {
+			e.vars[def.Name] = v
+		}
+	}
+	if err = e.validate(root, op.SelectionSet, 1); err != nil {
+		return &Response{Errors: []*Error{{Message: err.Error()}}}
+	}
+	data, err := e.executeSelectionSet(ctx, root, nil, op.SelectionSet, nil, op.Type == Query)
+	if err != nil {
+		data = nil
+	}
+	res := &Response{
+		Errors: e.errors,
+	}
+	if data != nil {
+		res.Data = data
+	}
+	return res
+}
+
+func (d *Document) operation(name string) (*Operation, error) {
+	if name == "" {
+		if len(d.Operations) != 1 {
+			return nil, errors.ErrGraphQLAmbiguousOperation
+		}
+		return d.Operations[0], nil
+	}
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, errors.ErrGraphQLOperationNotFound(name)
+}
+
+// checkFragmentCycles fails when a fragment spreads itself directly or indirectly.
+func (d *Document) checkFragmentCycles() error {
+	const (
+		visiting = iota + 1
+		done
+	)
+	state := make(map[string]int, len(d.Fragments))
+	var visit func(sels []Selection) error
+	visit = func(sels []Selection) error {
+		for _, sel := range sels {
+			switch x := sel.(type) {
+			case *FieldSelection:
+				if err := visit(x.SelectionSet); err != nil {
+					return err
+				}
+			case *InlineFragment:
+				if err := visit(x.SelectionSet); err != nil {
+					return err
+				}
+			case *FragmentSpread:
+				switch state[x.Name] {
+				case visiting:
+					return errors.ErrGraphQLFragmentCycle(x.Name)
+				case done:
+					continue
+				}
+				f, ok := d.Fragments[x.Name]
+				if !ok {
+					continue
+				}
+				state[x.Name] = visiting
+				if err := visit(f.SelectionSet); err != nil {
+					return err
+				}
+				state[x.Name] = done
+			}
+		}
+		return nil
+	}
+	names := make([]string, 0, len(d.Fragments))
+	for name := range d.Fragments {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if state[name] == done {
+			continue
+		}
+		state[name] = visiting
+		if err := visit(d.Fragments[name].SelectionSet); err != nil {
+			return err
+		}
+		state[name] = done
+	}
+	return nil
+}
+
+// validate checks that every selected field exists on its type, that leaf and object fields are selected correctly,
+// and that the selection sets are not nested deeper than the limit of the schema.
+// The fragments must not form cycles, and a fragment already validated at the same or a deeper depth is skipped.
+func (e *executor) validate(obj *Object, sels []Selection, depth int) error {
+	if limit := e.schema.maxDepth(); depth > limit {
+		return errors.ErrGraphQLSelectionDepthExceeded(limit)
+	}
+	for _, sel := range sels {
+		switch x := sel.(type) {
+		case *FieldSelection:
+			if x.Name == typeNameField {
+				continue
+			}
+			f := e.field(obj, x.Name)
+			if f == nil {
+				return errors.ErrGraphQLFieldNotFound(obj.Name, x.Name)
+			}
+			for _, arg := range x.Arguments {
+				if !slicesContainsArg(f.Args, arg.Name) {
+					return errors.ErrGraphQLFieldNotFound(obj.Name+"."+x.Name, arg.Name)
+				}
+			}
+			child, isObj := namedType(f.Type).(*Object)
+			switch {
+			case isObj && len(x.SelectionSet) == 0:
+				return errors.ErrGraphQLSelectionRequired(x.Name, f.Type.String())
+			case !isObj && len(x.SelectionSet) != 0:
+				return errors.ErrGraphQLSelectionNotAllowed(x.Name, f.Type.String())
+			case isObj:
+				if err := e.validate(child, x.SelectionSet, depth+1); err != nil {
+					return err
+				}
+			}
+		case *FragmentSpread:
+			f, ok := e.doc.Fragments[x.Name]
+			if !ok {
+				return errors.ErrGraphQLFragmentNotFound(x.Name)
+			}
+			if f.TypeCondition != obj.Name {
+				continue
+			}
+			if d, ok := e.validated[x.Name]; ok && d >= depth {
+				continue
+			}
+			if err := e.validate(obj, f.SelectionSet, depth); err != nil {
+				return err
+			}
+			e.validated[x.Name] = depth
+		case *InlineFragment:
+			if x.TypeCondition == "" || x.TypeCondition == obj.Name {
+				if err := e.validate(obj, x.SelectionSet, depth); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// field returns the field name of obj, or the introspection field name when obj is the query root type.
+func (e *executor) field(obj *Object, name string) *Field {
+	if f := obj.Field(name); f != nil {
+		return f
+	}
+	if obj == e.schema.Query {
+		return e.schema.metaField(name)
+	}
+	return nil
+}
+
+func slicesContainsArg(args []*InputValue, name string) bool {
+	for _, arg := range args {
+		if arg.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// collectFields flattens fragments and groups the selected fields by their response keys.
+func (e *executor) collectFields(obj *Object, sels []Selection, fields []*collectedField, visited map[string]bool) []*collectedField {
+	for _, sel := range sels {
+		if !e.included(sel) {
+			continue
+		}
+		switch x := sel.(type) {
+		case *FieldSelection:
+			key := x.ResponseKey()
+			found := false
+			for _, cf := range fields {
+				if cf.key == key {
+					cf.fields = append(cf.fields, x)
+					found = true
+					break
+				}
+			}
+			if !found {
+				fields = append(fields, &collectedField{
+					key:    key,
+					fields: []*FieldSelection{x},
+				})
+			}
+		case *FragmentSpread:
+			if visited[x.Name] {
+				continue
+			}
+			visited[x.Name] = true
+			f, ok := e.doc.Fragments[x.Name]
+			if !ok || f.TypeCondition != obj.Name {
+				continue
+			}
+			fields = e.collectFields(obj, f.SelectionSet, fields, visited)
+		case *InlineFragment:
+			if x.TypeCondition != "" && x.TypeCondition != obj.Name {
+				continue
+			}
+			fields = e.collectFields(obj, x.SelectionSet, fields, visited)
+		}
+	}
+	return fields
+}
+
+// included evaluates the @skip and @include directives of the selection.
+func (e *executor) included(sel Selection) bool {
+	for _, d := range sel.directives() {
+		var cond bool
+		for _, arg := range d.Arguments {
+			if arg.Name == "if" {
+				cond, _ = arg.Value.Resolve(e.vars).(bool)
+			}
+		}
+		switch d.Name {
+		case "skip":
+			if cond {
+				return false
+			}
+		case "include":
+			if !cond {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (e *executor) executeSelectionSet(
+	ctx context.Context, obj *Object, source any, sels []Selection, path []any, concurrent bool,
+) (*orderedMap, error) {
+	fields := e.collectFields(obj, sels, nil, make(map[string]bool))
+	res := &orderedMap{
+		keys:   make([]string, 0, len(fields)),
+		values: make(map[string]any, len(fields)),
+	}
+	for _, cf := range fields {
+		res.keys = append(res.keys, cf.key)
+	}
+	if !concurrent || len(fields) < 2 {
+		for _, cf := range fields {
+			v, err := e.executeField(ctx, obj, source, cf, path)
+			if err != nil {
+				return nil, err
+			}
+			res.values[cf.key] = v
+		}
+		return res, nil
+	}
+	var mu sync.Mutex
+	var propagated bool
+	eg, egctx := errgroup.New(ctx)
+	eg.SetLimit(e.schema.maxConcurrency())
+	for _, cf := range fields {
+		eg.Go(safety.RecoverFunc(func() error {
+			v, err := e.executeField(egctx, obj, source, cf, path)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				propagated = true
+				return nil
+			}
+			res.values[cf.key] = v
+			return nil
+		}))
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	if propagated {
+		return nil, errNullPropagation
+	}
+	return res, nil
+}
+
+func (e *executor) executeField(
+	ctx context.Context, obj *Object, source any, cf *collectedField, path []any,
+) (any, error) {
+	sel := cf.fields[0]
+	fpath := append(append(make([]any, 0, len(path)+1), path...), cf.key)
+	if sel.Name == typeNameField {
+		return obj.Name, nil
+	}
+	f := e.field(obj, sel.Name)
+	args := make(map[string]any, len(f.Args))
+	for _, def := range f.Args {
+		var (
+			v       any
+			present bool
+		)
+		for _, arg := range sel.Arguments {
+			if arg.Name == def.Name {
+				if vr, ok := arg.Value.(Variable); ok {
+					v, present = e.vars[string(vr)]
+				} else {
+					v, present = arg.Value.Resolve(e.vars), true
+				}
+			}
+		}
+		if !present && def.Default != nil {
+			v, present = def.Default, true
+		}
+		c, err := coerceInput(def.Name, def.Type, v, present)
+		if err != nil {
+			e.addError(err, fpath)
+			return e.nullable(f.Type)
+		}
+		if present {
+			args[def.Name] = c
+		}
+	}
+	resolve := f.Resolve
+	if resolve == nil {
+		resolve = DefaultResolve
+	}
+	v, err := resolve(ctx, ResolveParams{
+		Source:    source,
+		Args:      args,
+		FieldName: sel.Name,
+	})
+	if err != nil {
+		e.addError(err, fpath)
+		return e.nullable(f.Type)
+	}
+	var sub []Selection
+	for _, fs := range cf.fields {
+		sub = append(sub, fs.SelectionSet...)
+	}
+	c, err := e.completeValue(ctx, f.Type, sub, v, fpath)
+	if err != nil {
+		if isNil(v) {
+			e.addError(errors.ErrGraphQLNullValue(sel.Name, f.Type.String()), fpath)
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// nullable returns the null value of a field of type t, or errNullPropagation when t is non-null.
+func (*executor) nullable(t Type) (any, error) {
+	if _, ok := t.(*NonNull); ok {
+		return nil, errNullPropagation
+	}
+	return nil, nil
+}
+
+func (e *executor) completeValue(ctx context.Context, t Type, sels []Selection, v any, path []any) (any, error) {
+	if nn, ok := t.(*NonNull); ok {
+		c, err := e.completeValue(ctx, nn.Of, sels, v, path)
+		if err != nil || c == nil {
+			return nil, errNullPropagation
+		}
+		return c, nil
+	}
+	if isNil(v) {
+		return nil, nil
+	}
+	switch x := t.(type) {
+	case *List:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, nil
+		}
+		l := make([]any, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item := rv.Index(i).Interface()
+			ipath := append(append(make([]any, 0, len(path)+1), path...), i)
+			c, err := e.completeValue(ctx, x.Of, sels, item, ipath)
+			if err != nil {
+				if isNil(item) {
+					e.addError(errors.ErrGraphQLNullValue("item", x.Of.String()), ipath)
+				}
+				return nil, nil
+			}
+			l = append(l, c)
+		}
+		return l, nil
+	case *Object:
+		m, err := e.executeSelectionSet(ctx, x, v, sels, path, false)
+		if err != nil {
+			return nil, nil
+		}
+		return m, nil
+	}
+	return v, nil
+}
+
+func (e *executor) addError(err error, path []any) {
+	e.mu.Lock()
+	e.errors = append(e.errors, &Error{
+		Message: err.Error(),
+		Path:    path,
+	})
+	e.mu.Unlock()
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// DefaultResolve resolves the field from a map or protobuf message source.
+func DefaultResolve(_ context.Context, p ResolveParams) (any, error) {
+	switch src := p.Source.(type) {
+	case map[string]any:
+		return src[p.FieldName], nil
+	case *orderedMap:
+		return src.values[p.FieldName], nil
+	}
+	return protoField(p.Source, p.FieldName), nil
+}
@@ -0,0 +1,307 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+)
+
+func testSchema() *Schema {
+	types := NewTypes("payload.v1.")
+	query := NewObject("Query", "")
+	query.AddField("search", &Field{
+		Type: types.Object(new(payload.Search_Response)),
+		Args: types.Args(new(payload.Search_Request)),
+		Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+			req := new(payload.Search_Request)
+			if err := UnmarshalArgs(p.Args, req); err != nil {
+				return nil, err
+			}
+			return &payload.Search_Response{
+				RequestId: req.GetConfig().GetRequestId(),
+				Results: []*payload.Object_Distance{
+					{Id: "vald", Distance: float32(len(req.GetVector()))},
+				},
+			}, nil
+		},
+	})
+	query.AddField("hello", &Field{
+		Type: &NonNull{Of: String},
+		Args: []*InputValue{{Name: "name", Type: String, Default: "vald"}},
+		Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+			return "hello " + p.Args["name"].(string), nil
+		},
+	})
+	query.AddField("fail", &Field{
+		Type: &NonNull{Of: String},
+		Resolve: func(context.Context, ResolveParams) (any, error) {
+			return nil, errors.ErrInvalidRequest
+		},
+	})
+	return &Schema{Query: query}
+}
+
+func TestSchema_Execute(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		body string
+	}
+	type test struct {
+		name     string
+		req      *Request
+		maxDepth int
+		want     want
+	}
+	tests := []test{
+		{
+			name: "returns fields in the requested order with aliases and defaults",
+			req: &Request{
+				Query: `{ b: hello(name: "agent") a: hello __typename }`,
+			},
+			want: want{
+				body: `{"data":{"b":"hello agent","a":"hello vald","__typename":"Query"}}`,
+			},
+		},
+		{
+			name: "returns protobuf message fields using variables and fragments",
+			req: &Request{
+				Query: `query q($v: [Float!]) {
+					search(vector: $v, config: {requestId: "r", num: 1}) { ...res }
+				}
+				fragment res on Search_Response {
+					requestId
+					results { id distance @include(if: true) }
+				}`,
+				Variables: map[string]any{"v": []any{0.1, 0.2}},
+			},
+			want: want{
+				body: `{"data":{"search":{"requestId":"r","results":[{"id":"vald","distance":2}]}}}`,
+			},
+		},
+		{
+			name: "returns null data when a non-null field fails",
+			req: &Request{
+				Query: `{ hello fail }`,
+			},
+			want: want{
+				body: `{"data":null,"errors":[{"message":"invalid request","path":["fail"]}]}`,
+			},
+		},
+		{
+			name: "returns error when the field is not defined",
+			req: &Request{
+				Query: `{ unknown }`,
+			},
+			want: want{
+				body: `{"data":null,"errors":[{"message":"graphql field \"unknown\" is not defined on type Query"}]}`,
+			},
+		},
+		{
+			name: "returns error when the document has a syntax error",
+			req: &Request{
+				Query: `{ hello(`,
+			},
+			want: want{
+				body: `{"data":null,"errors":[{"message":"graphql syntax error at 8: unexpected end of document"}]}`,
+			},
+		},
+		{
+			name: "returns error when the fragment spreads itself",
+			req: &Request{
+				Query: `query { ...F } fragment F on Query { hello ...F }`,
+			},
+			want: want{
+				body: `{"data":null,"errors":[{"message":"graphql fragment \"F\" must not form a cycle"}]}`,
+			},
+		},
+		{
+			name: "returns error when the fragments spread each other",
+			req: &Request{
+				Query: `query { ...A } fragment A on Query { ...B } fragment B on Query { hello ...A }`,
+			},
+			want: want{
+				body: `{"data":null,"errors":[{"message":"graphql fragment \"A\" must not form a cycle"}]}`,
+			},
+		},
+		{
+			name: "returns error when the selection sets are nested deeper than the limit",
+			req: &Request{
+				Query: `query { search(vector: [0.1]) { ...res } } fragment res on Search_Response { results { id } }`,
+			},
+			maxDepth: 2,
+			want: want{
+				body: `{"data":null,"errors":[{"message":"graphql selection sets are nested deeper than the limit of 2"}]}`,
+			},
+		},
+		{
+			name: "returns error when the document is nested deeper than the parser accepts",
+			req: &Request{
+				Query: "{" + strings.Repeat("... {", maxNestingDepth) + " hello" + strings.Repeat("}", maxNestingDepth) + "}",
+			},
+			want: want{
+				body: `{"data":null,"errors":[{"message":"graphql syntax error at 320: nesting too deep"}]}`,
+			},
+		},
+		{
+			name: "returns the type of the schema by introspection",
+			req: &Request{
+				Query: `{
+					__type(name: "Query") {
+						kind name
+						fields { name args { name defaultValue type { kind name ofType { kind name } } } type { kind name ofType { name } } }
+					}
+					missing: __type(name: "Missing") { name }
+				}`,
+			},
+			want: want{
+				body: `{"data":{"__type":{"kind":"OBJECT","name":"Query","fields":[` +
+					`{"name":"search","args":[` +
+					`{"name":"vector","defaultValue":null,"type":{"kind":"LIST","name":null,"ofType":{"kind":"NON_NULL","name":null}}},` +
+					`{"name":"config","defaultValue":null,"type":{"kind":"INPUT_OBJECT","name":"Search_ConfigInput","ofType":null}}` +
+					`],"type":{"kind":"OBJECT","name":"Search_Response","ofType":null}},` +
+					`{"name":"hello","args":[{"name":"name","defaultValue":"\"vald\"","type":{"kind":"SCALAR","name":"String","ofType":null}}],` +
+					`"type":{"kind":"NON_NULL","name":null,"ofType":{"name":"String"}}},` +
+					`{"name":"fail","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"name":"String"}}}` +
+					`]},"missing":null}}`,
+			},
+		},
+		{
+			name: "returns the root operation types and directives of the schema by introspection",
+			req: &Request{
+				Query: `query IntrospectionQuery {
+					__schema {
+						queryType { name }
+						mutationType { name }
+						subscriptionType { name }
+						directives { name locations args { name type { kind ofType { name } } } }
+					}
+				}`,
+			},
+			want: want{
+				body: `{"data":{"__schema":{"queryType":{"name":"Query"},"mutationType":null,"subscriptionType":null,"directives":[` +
+					`{"name":"skip","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"args":[{"name":"if","type":{"kind":"NON_NULL","ofType":{"name":"Boolean"}}}]},` +
+					`{"name":"include","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"args":[{"name":"if","type":{"kind":"NON_NULL","ofType":{"name":"Boolean"}}}]}` +
+					`]}}}`,
+			},
+		},
+		{
+			name: "returns error when the schema has no mutation type",
+			req: &Request{
+				Query: `mutation { hello }`,
+			},
+			want: want{
+				body: `{"data":null,"errors":[{"message":"graphql schema does not support mutation operations"}]}`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			s := testSchema()
+			s.MaxDepth = test.maxDepth
+			b, err := json.Marshal(s.Execute(context.Background(), test.req))
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if got := string(b); got != test.want.body {
+				tt.Errorf("body not equals. want: %s, got: %s", test.want.body, got)
+			}
+		})
+	}
+}
+
+// introspectionQuery is the introspection query sent by GraphiQL and the code generators.
+const introspectionQuery = `query IntrospectionQuery {
+	__schema {
+		queryType { name }
+		mutationType { name }
+		subscriptionType { name }
+		types { ...FullType }
+		directives { name description locations args { ...InputValue } }
+	}
+}
+fragment FullType on __Type {
+	kind name description specifiedByURL
+	fields(includeDeprecated: true) { name description args { ...InputValue } type { ...TypeRef } isDeprecated deprecationReason }
+	inputFields { ...InputValue }
+	interfaces { ...TypeRef }
+	enumValues(includeDeprecated: true) { name description isDeprecated deprecationReason }
+	possibleTypes { ...TypeRef }
+}
+fragment InputValue on __InputValue {
+	name description type { ...TypeRef } defaultValue
+}
+fragment TypeRef on __Type {
+	kind name
+	ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } } }
+}`
+
+func TestSchema_Execute_introspection(t *testing.T) {
+	t.Parallel()
+	res := testSchema().Execute(context.Background(), &Request{Query: introspectionQuery})
+	if len(res.Errors) != 0 {
+		t.Fatalf("got errors: %v", res.Errors[0].Message)
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Data struct {
+			Schema struct {
+				Types []struct {
+					Kind       string `json:"kind"`
+					Name       string `json:"name"`
+					EnumValues []struct {
+						Name string `json:"name"`
+					} `json:"enumValues"`
+				} `json:"types"`
+			} `json:"__schema"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	kinds := make(map[string]string, len(got.Data.Schema.Types))
+	for _, typ := range got.Data.Schema.Types {
+		kinds[typ.Name] = typ.Kind
+		if typ.Name == "__TypeKind" && len(typ.EnumValues) != 8 {
+			t.Errorf("got %d values of __TypeKind, want: 8", len(typ.EnumValues))
+		}
+	}
+	for name, kind := range map[string]string{
+		"Query":              "OBJECT",
+		"Search_Response":    "OBJECT",
+		"Search_ConfigInput": "INPUT_OBJECT",
+		"String":             "SCALAR",
+		"Boolean":            "SCALAR",
+		"__Schema":           "OBJECT",
+		"__TypeKind":         "ENUM",
+	} {
+		if kinds[name] != kind {
+			t.Errorf("got kind of %s: %q, want: %q", name, kinds[name], kind)
+		}
+	}
+}
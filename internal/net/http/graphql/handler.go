@@ -0,0 +1,136 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package graphql
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+	"github.com/vdaas/vald/internal/net/http/rest"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+)
+
+// Handler executes the GraphQL request of r against the schema.
+// GET requests read the query, operationName and variables URL parameters, and they are rejected when they request a mutation.
+// POST requests accept a body of up to MaxBodySize bytes with a request object or an array of up to MaxBatchSize request objects,
+// which are executed concurrently as a batch by up to MaxConcurrency goroutines.
+// The response status is 200 OK for every executed request, even when it has field errors.
+func (s *Schema) Handler(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var (
+		reqs  []*Request
+		batch bool
+	)
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req := &Request{
+			Query:         q.Get("query"),
+			OperationName: q.Get("operationName"),
+		}
+		if vars := q.Get("variables"); vars != "" {
+			if err = json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				return http.StatusBadRequest, err
+			}
+		}
+		if isMutation(req) {
+			w.Header().Set("Allow", http.MethodPost)
+			return http.StatusMethodNotAllowed, errors.ErrGraphQLMutationNotAllowed
+		}
+		reqs = []*Request{req}
+	case http.MethodPost:
+		var body []byte
+		body, err = io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxBodySize()))
+		if err != nil {
+			var mbe *http.MaxBytesError
+			if errors.As(err, &mbe) {
+				return http.StatusRequestEntityTooLarge, err
+			}
+			return http.StatusBadRequest, err
+		}
+		body = bytes.TrimSpace(body)
+		if len(body) != 0 && body[0] == '[' {
+			batch = true
+			err = json.Unmarshal(body, &reqs)
+		} else {
+			req := new(Request)
+			err = json.Unmarshal(body, req)
+			reqs = []*Request{req}
+		}
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		if limit := s.maxBatchSize(); len(reqs) > limit {
+			return http.StatusBadRequest, errors.ErrGraphQLBatchSizeExceeded(len(reqs), limit)
+		}
+	default:
+		return http.StatusMethodNotAllowed, errors.ErrInvalidRequest
+	}
+
+	res := make([]*Response, len(reqs))
+	eg, ctx := errgroup.New(r.Context())
+	eg.SetLimit(s.maxConcurrency())
+	for i, req := range reqs {
+		if req == nil || req.Query == "" {
+			res[i] = &Response{Errors: []*Error{{Message: errors.ErrInvalidRequest.Error()}}}
+			continue
+		}
+		eg.Go(safety.RecoverFunc(func() error {
+			res[i] = s.Execute(ctx, req)
+			return nil
+		}))
+	}
+	if err = eg.Wait(); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	w.Header().Set(rest.ContentType, rest.ApplicationJSON+";"+rest.CharsetUTF8)
+	w.WriteHeader(http.StatusOK)
+	if batch {
+		err = json.Encode(w, res)
+	} else {
+		err = json.Encode(w, res[0])
+	}
+	if err != nil {
+		return http.StatusServiceUnavailable, err
+	}
+	return http.StatusOK, nil
+}
+
+// isMutation reports whether req requests a mutation operation.
+// The invalid requests are reported as not mutations, and they are rejected by the execution.
+func isMutation(req *Request) bool {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return false
+	}
+	op, err := doc.operation(req.OperationName)
+	return err == nil && op.Type == Mutation
+}
+
+// SDLHandler responds with the schema definition language representation of the schema.
+func (s *Schema) SDLHandler(w http.ResponseWriter, _ *http.Request) (code int, err error) {
+	w.Header().Set(rest.ContentType, rest.TextPlain+";"+rest.CharsetUTF8)
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write([]byte(s.SDL())); err != nil {
+		return http.StatusServiceUnavailable, err
+	}
+	return http.StatusOK, nil
+}
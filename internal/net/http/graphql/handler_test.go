@@ -0,0 +1,151 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vdaas/vald/internal/errors"
+)
+
+func TestSchema_Handler(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		method string
+		query  string
+		body   string
+	}
+	type want struct {
+		code int
+		err  error
+		body string
+	}
+	type test struct {
+		name string
+		args args
+		want want
+	}
+	schema := func() *Schema {
+		s := testSchema()
+		mutation := NewObject("Mutation", "")
+		mutation.AddField("hello", &Field{
+			Type: &NonNull{Of: String},
+			Resolve: func(context.Context, ResolveParams) (any, error) {
+				return "hello", nil
+			},
+		})
+		s.Mutation = mutation
+		s.MaxBatchSize = 2
+		s.MaxBodySize = 128
+		return s
+	}
+	tests := []test{
+		{
+			name: "execute the query requested by a GET request",
+			args: args{
+				method: http.MethodGet,
+				query:  `{ hello }`,
+			},
+			want: want{
+				code: http.StatusOK,
+				body: `{"data":{"hello":"hello vald"}}`,
+			},
+		},
+		{
+			name: "reject the mutation requested by a GET request",
+			args: args{
+				method: http.MethodGet,
+				query:  `mutation { hello }`,
+			},
+			want: want{
+				code: http.StatusMethodNotAllowed,
+				err:  errors.ErrGraphQLMutationNotAllowed,
+			},
+		},
+		{
+			name: "execute the mutation requested by a POST request",
+			args: args{
+				method: http.MethodPost,
+				body:   `{"query": "mutation { hello }"}`,
+			},
+			want: want{
+				code: http.StatusOK,
+				body: `{"data":{"hello":"hello"}}`,
+			},
+		},
+		{
+			name: "execute the batch within the limit",
+			args: args{
+				method: http.MethodPost,
+				body:   `[{"query": "{ hello }"}, {"query": "mutation { hello }"}]`,
+			},
+			want: want{
+				code: http.StatusOK,
+				body: `[{"data":{"hello":"hello vald"}},{"data":{"hello":"hello"}}]`,
+			},
+		},
+		{
+			name: "reject the batch exceeding the limit",
+			args: args{
+				method: http.MethodPost,
+				body:   `[{"query": "{ hello }"}, {"query": "{ hello }"}, {"query": "{ hello }"}]`,
+			},
+			want: want{
+				code: http.StatusBadRequest,
+				err:  errors.ErrGraphQLBatchSizeExceeded(3, 2),
+			},
+		},
+		{
+			name: "reject the body exceeding the limit",
+			args: args{
+				method: http.MethodPost,
+				body:   `{"query": "{ hello` + strings.Repeat(" hello", 32) + ` }"}`,
+			},
+			want: want{
+				code: http.StatusRequestEntityTooLarge,
+				err:  &http.MaxBytesError{Limit: 128},
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			target := "/graphql"
+			if test.args.query != "" {
+				target += "?query=" + url.QueryEscape(test.args.query)
+			}
+			r := httptest.NewRequest(test.args.method, target, strings.NewReader(test.args.body))
+			w := httptest.NewRecorder()
+			code, err := schema().Handler(w, r)
+			if code != test.want.code {
+				tt.Errorf("got_code: %d,\n\t\t\t\twant: %d", code, test.want.code)
+			}
+			if (err == nil) != (test.want.err == nil) || (err != nil && err.Error() != test.want.err.Error()) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want.err)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != test.want.body {
+				tt.Errorf("got_body: %s,\n\t\t\t\twant: %s", got, test.want.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,442 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+// The meta fields of the query root type serving the schema introspection.
+const (
+	schemaField = "__schema"
+	typeField   = "__type"
+)
+
+// introspection holds the introspection types, which are shared by every schema.
+type introspection struct {
+	schema     *Object
+	typ        *Object
+	field      *Object
+	inputValue *Object
+	enumValue  *Object
+	directive  *Object
+}
+
+// namedField is the source of a __Field, a field of an object type with its name.
+type namedField struct {
+	name string
+	*Field
+}
+
+// directive is the source of a __Directive.
+type directive struct {
+	name        string
+	description string
+	locations   []string
+	args        []*InputValue
+}
+
+// directives are the directives supported by the executor.
+var directives = []*directive{
+	{
+		name:        "skip",
+		description: "Directs the executor to skip this field or fragment when the `if` argument is true.",
+		locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		args:        []*InputValue{{Name: "if", Type: &NonNull{Of: Boolean}}},
+	},
+	{
+		name:        "include",
+		description: "Directs the executor to include this field or fragment only when the `if` argument is true.",
+		locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		args:        []*InputValue{{Name: "if", Type: &NonNull{Of: Boolean}}},
+	},
+}
+
+var (
+	metaOnce  sync.Once
+	metaTypes *introspection
+)
+
+// meta returns the introspection types, which are built on the first call.
+func meta() *introspection {
+	metaOnce.Do(func() {
+		metaTypes = newIntrospection()
+	})
+	return metaTypes
+}
+
+func newIntrospection() *introspection {
+	typeKind := &Enum{
+		Name:   "__TypeKind",
+		Values: []string{"SCALAR", "OBJECT", "INTERFACE", "UNION", "ENUM", "INPUT_OBJECT", "LIST", "NON_NULL"},
+	}
+	directiveLocation := &Enum{
+		Name: "__DirectiveLocation",
+		Values: []string{
+			"QUERY", "MUTATION", "SUBSCRIPTION", "FIELD", "FRAGMENT_DEFINITION", "FRAGMENT_SPREAD", "INLINE_FRAGMENT",
+		},
+	}
+	in := &introspection{
+		schema:     NewObject("__Schema", "A GraphQL schema exposing its types, root operation types and directives."),
+		typ:        NewObject("__Type", "A type of the schema, or a list or non-null wrapper of a type."),
+		field:      NewObject("__Field", "A field of an object type."),
+		inputValue: NewObject("__InputValue", "An argument of a field or a directive, or a field of an input object type."),
+		enumValue:  NewObject("__EnumValue", "A value of an enum type."),
+		directive:  NewObject("__Directive", "A directive supported by the schema."),
+	}
+	typ := &NonNull{Of: in.typ}
+	types := &List{Of: typ}
+	inputValues := &NonNull{Of: &List{Of: &NonNull{Of: in.inputValue}}}
+	includeDeprecated := []*InputValue{{Name: "includeDeprecated", Type: Boolean, Default: false}}
+	nonNullString := &NonNull{Of: String}
+	notDeprecated := &NonNull{Of: Boolean}
+	null := func(context.Context, ResolveParams) (any, error) {
+		return nil, nil
+	}
+	isFalse := func(context.Context, ResolveParams) (any, error) {
+		return false, nil
+	}
+
+	in.schema.
+		AddField("description", &Field{Type: String, Resolve: null}).
+		AddField("types", &Field{
+			Type: &NonNull{Of: types},
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source.(*Schema).introspectionTypes(), nil
+			},
+		}).
+		AddField("queryType", &Field{
+			Type: typ,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source.(*Schema).Query, nil
+			},
+		}).
+		AddField("mutationType", &Field{
+			Type: in.typ,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				if s := p.Source.(*Schema); s.Mutation != nil {
+					return s.Mutation, nil
+				}
+				return nil, nil
+			},
+		}).
+		AddField("subscriptionType", &Field{Type: in.typ, Resolve: null}).
+		AddField("directives", &Field{
+			Type: &NonNull{Of: &List{Of: &NonNull{Of: in.directive}}},
+			Resolve: func(context.Context, ResolveParams) (any, error) {
+				return directives, nil
+			},
+		})
+
+	in.typ.
+		AddField("kind", &Field{
+			Type: &NonNull{Of: typeKind},
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return typeKindOf(p.Source.(Type)), nil
+			},
+		}).
+		AddField("name", &Field{
+			Type: String,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				switch p.Source.(type) {
+				case *List, *NonNull:
+					return nil, nil
+				}
+				return p.Source.(Type).String(), nil
+			},
+		}).
+		AddField("description", &Field{
+			Type: String,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				if o, ok := p.Source.(*Object); ok && o.Description != "" {
+					return o.Description, nil
+				}
+				return nil, nil
+			},
+		}).
+		AddField("fields", &Field{
+			Type: &List{Of: &NonNull{Of: in.field}},
+			Args: includeDeprecated,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				o, ok := p.Source.(*Object)
+				if !ok {
+					return nil, nil
+				}
+				fields := make([]*namedField, 0, len(o.names))
+				for _, name := range o.names {
+					fields = append(fields, &namedField{name: name, Field: o.fields[name]})
+				}
+				return fields, nil
+			},
+		}).
+		AddField("interfaces", &Field{
+			Type: types,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				if _, ok := p.Source.(*Object); ok {
+					return []Type{}, nil
+				}
+				return nil, nil
+			},
+		}).
+		AddField("possibleTypes", &Field{Type: types, Resolve: null}).
+		AddField("enumValues", &Field{
+			Type: &List{Of: &NonNull{Of: in.enumValue}},
+			Args: includeDeprecated,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				if e, ok := p.Source.(*Enum); ok {
+					return e.Values, nil
+				}
+				return nil, nil
+			},
+		}).
+		AddField("inputFields", &Field{
+			Type: &List{Of: &NonNull{Of: in.inputValue}},
+			Args: includeDeprecated,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				o, ok := p.Source.(*InputObject)
+				if !ok {
+					return nil, nil
+				}
+				fields := make([]*InputValue, 0, len(o.names))
+				for _, name := range o.names {
+					fields = append(fields, o.fields[name])
+				}
+				return fields, nil
+			},
+		}).
+		AddField("ofType", &Field{
+			Type: in.typ,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				switch x := p.Source.(type) {
+				case *List:
+					return x.Of, nil
+				case *NonNull:
+					return x.Of, nil
+				}
+				return nil, nil
+			},
+		}).
+		AddField("specifiedByURL", &Field{Type: String, Resolve: null})
+
+	in.field.
+		AddField("name", &Field{
+			Type: nonNullString,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source.(*namedField).name, nil
+			},
+		}).
+		AddField("description", &Field{
+			Type: String,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				if f := p.Source.(*namedField); f.Description != "" {
+					return f.Description, nil
+				}
+				return nil, nil
+			},
+		}).
+		AddField("args", &Field{
+			Type: inputValues,
+			Args: includeDeprecated,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				if args := p.Source.(*namedField).Args; args != nil {
+					return args, nil
+				}
+				return []*InputValue{}, nil
+			},
+		}).
+		AddField("type", &Field{
+			Type: typ,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source.(*namedField).Type, nil
+			},
+		}).
+		AddField("isDeprecated", &Field{Type: notDeprecated, Resolve: isFalse}).
+		AddField("deprecationReason", &Field{Type: String, Resolve: null})
+
+	in.inputValue.
+		AddField("name", &Field{
+			Type: nonNullString,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source.(*InputValue).Name, nil
+			},
+		}).
+		AddField("description", &Field{Type: String, Resolve: null}).
+		AddField("type", &Field{
+			Type: typ,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source.(*InputValue).Type, nil
+			},
+		}).
+		AddField("defaultValue", &Field{
+			Type: String,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				v := p.Source.(*InputValue)
+				if v.Default == nil {
+					return nil, nil
+				}
+				return literal(v.Type, v.Default), nil
+			},
+		}).
+		AddField("isDeprecated", &Field{Type: notDeprecated, Resolve: isFalse}).
+		AddField("deprecationReason", &Field{Type: String, Resolve: null})
+
+	in.enumValue.
+		AddField("name", &Field{
+			Type: nonNullString,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source, nil
+			},
+		}).
+		AddField("description", &Field{Type: String, Resolve: null}).
+		AddField("isDeprecated", &Field{Type: notDeprecated, Resolve: isFalse}).
+		AddField("deprecationReason", &Field{Type: String, Resolve: null})
+
+	in.directive.
+		AddField("name", &Field{
+			Type: nonNullString,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source.(*directive).name, nil
+			},
+		}).
+		AddField("description", &Field{
+			Type: String,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source.(*directive).description, nil
+			},
+		}).
+		AddField("locations", &Field{
+			Type: &NonNull{Of: &List{Of: &NonNull{Of: directiveLocation}}},
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source.(*directive).locations, nil
+			},
+		}).
+		AddField("args", &Field{
+			Type: inputValues,
+			Args: includeDeprecated,
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				return p.Source.(*directive).args, nil
+			},
+		}).
+		AddField("isRepeatable", &Field{Type: notDeprecated, Resolve: isFalse})
+	return in
+}
+
+// metaField returns the introspection field name of the query root type, or nil.
+func (s *Schema) metaField(name string) *Field {
+	in := meta()
+	switch name {
+	case schemaField:
+		return &Field{
+			Type: &NonNull{Of: in.schema},
+			Resolve: func(context.Context, ResolveParams) (any, error) {
+				return s, nil
+			},
+		}
+	case typeField:
+		return &Field{
+			Type: in.typ,
+			Args: []*InputValue{{Name: "name", Type: &NonNull{Of: String}}},
+			Resolve: func(_ context.Context, p ResolveParams) (any, error) {
+				name, _ := p.Args["name"].(string)
+				if t, ok := s.introspectionTypeMap()[name]; ok {
+					return t, nil
+				}
+				return nil, nil
+			},
+		}
+	}
+	return nil
+}
+
+// introspectionTypeMap returns every named type of the schema including the built-in scalars and the introspection types.
+func (s *Schema) introspectionTypeMap() map[string]Type {
+	roots := []Type{String, Boolean, meta().schema}
+	if s.Query != nil {
+		roots = append(roots, s.Query)
+	}
+	if s.Mutation != nil {
+		roots = append(roots, s.Mutation)
+	}
+	return collectTypes(roots...)
+}
+
+// introspectionTypes returns the types of introspectionTypeMap sorted by their names.
+func (s *Schema) introspectionTypes() []Type {
+	m := s.introspectionTypeMap()
+	types := make([]Type, 0, len(m))
+	for _, t := range m {
+		types = append(types, t)
+	}
+	slices.SortFunc(types, func(a, b Type) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return types
+}
+
+func typeKindOf(t Type) string {
+	switch t.(type) {
+	case *Object:
+		return "OBJECT"
+	case *Enum:
+		return "ENUM"
+	case *InputObject:
+		return "INPUT_OBJECT"
+	case *List:
+		return "LIST"
+	case *NonNull:
+		return "NON_NULL"
+	}
+	return "SCALAR"
+}
+
+// literal returns the GraphQL literal of the input value v of type t.
+func literal(t Type, v any) string {
+	switch x := namedType(t).(type) {
+	case *Enum:
+		if s, ok := v.(string); ok {
+			return s
+		}
+	case *InputObject:
+		if m, ok := v.(map[string]any); ok {
+			fields := make([]string, 0, len(m))
+			for _, name := range x.names {
+				if fv, ok := m[name]; ok {
+					fields = append(fields, name+": "+literal(x.fields[name].Type, fv))
+				}
+			}
+			return "{" + strings.Join(fields, ", ") + "}"
+		}
+	}
+	if l, ok := v.([]any); ok {
+		items := make([]string, 0, len(l))
+		for _, item := range l {
+			items = append(items, literal(t, item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,615 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package graphql
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+type tokenKind uint8
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind tokenKind
+	val  string
+	pos  int
+}
+
+// maxNestingDepth is the maximum nesting depth of the selection sets and values the parser accepts,
+// which bounds the recursion of the parser and of the validation of the parsed document.
+const maxNestingDepth = 64
+
+type parser struct {
+	src   string
+	pos   int
+	tok   token
+	depth int
+}
+
+// Parse parses src as a GraphQL executable document.
+func Parse(src string) (doc *Document, err error) {
+	p := &parser{src: src}
+	if err = p.next(); err != nil {
+		return nil, err
+	}
+	doc = &Document{
+		Fragments: make(map[string]*Fragment),
+	}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			sel, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{
+				Type:         Query,
+				SelectionSet: sel,
+			})
+		case p.peekName("query"), p.peekName("mutation"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.peekName("fragment"):
+			f, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[f.Name]; ok {
+				return nil, errors.ErrGraphQLSyntax(p.tok.pos, "duplicate fragment "+f.Name)
+			}
+			doc.Fragments[f.Name] = f
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, errors.ErrGraphQLSyntax(p.pos, "no operation found")
+	}
+	return doc, nil
+}
+
+func (p *parser) parseOperation() (op *Operation, err error) {
+	op = new(Operation)
+	if p.tok.val == "mutation" {
+		op.Type = Mutation
+	}
+	if err = p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		op.Name = p.tok.val
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		if op.Variables, err = p.parseVariableDefinitions(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if op.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) parseFragment() (f *Fragment, err error) {
+	if err = p.next(); err != nil {
+		return nil, err
+	}
+	f = new(Fragment)
+	if f.Name, err = p.expectName(); err != nil {
+		return nil, err
+	}
+	if !p.peekName("on") {
+		return nil, p.unexpected()
+	}
+	if err = p.next(); err != nil {
+		return nil, err
+	}
+	if f.TypeCondition, err = p.expectName(); err != nil {
+		return nil, err
+	}
+	if _, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if f.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (p *parser) parseVariableDefinitions() (defs []*VariableDefinition, err error) {
+	if err = p.expect("("); err != nil {
+		return nil, err
+	}
+	for !p.peek(")") {
+		if err = p.expect("$"); err != nil {
+			return nil, err
+		}
+		def := new(VariableDefinition)
+		if def.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+		if err = p.expect(":"); err != nil {
+			return nil, err
+		}
+		if def.Type, err = p.parseTypeRef(); err != nil {
+			return nil, err
+		}
+		if p.peek("=") {
+			if err = p.next(); err != nil {
+				return nil, err
+			}
+			if def.Default, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs, p.expect(")")
+}
+
+func (p *parser) parseTypeRef() (t *TypeRef, err error) {
+	t = new(TypeRef)
+	if p.peek("[") {
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+		if t.Elem, err = p.parseTypeRef(); err != nil {
+			return nil, err
+		}
+		if err = p.expect("]"); err != nil {
+			return nil, err
+		}
+	} else if t.Name, err = p.expectName(); err != nil {
+		return nil, err
+	}
+	if p.peek("!") {
+		t.NonNull = true
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (p *parser) parseSelectionSet() (sels []Selection, err error) {
+	if err = p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	if err = p.expect("{"); err != nil {
+		return nil, err
+	}
+	for !p.peek("}") {
+		if p.tok.kind == tokenEOF {
+			return nil, p.unexpected()
+		}
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, errors.ErrGraphQLSyntax(p.tok.pos, "empty selection set")
+	}
+	return sels, p.expect("}")
+}
+
+func (p *parser) parseSelection() (sel Selection, err error) {
+	if p.peek("...") {
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenName && p.tok.val != "on" {
+			fs := &FragmentSpread{Name: p.tok.val}
+			if err = p.next(); err != nil {
+				return nil, err
+			}
+			if fs.Directives, err = p.parseDirectives(); err != nil {
+				return nil, err
+			}
+			return fs, nil
+		}
+		inf := new(InlineFragment)
+		if p.peekName("on") {
+			if err = p.next(); err != nil {
+				return nil, err
+			}
+			if inf.TypeCondition, err = p.expectName(); err != nil {
+				return nil, err
+			}
+		}
+		if inf.Directives, err = p.parseDirectives(); err != nil {
+			return nil, err
+		}
+		if inf.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+		return inf, nil
+	}
+	f := new(FieldSelection)
+	if f.Name, err = p.expectName(); err != nil {
+		return nil, err
+	}
+	if p.peek(":") {
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+		f.Alias = f.Name
+		if f.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		if f.Arguments, err = p.parseArguments(false); err != nil {
+			return nil, err
+		}
+	}
+	if f.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		if f.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) parseArguments(constant bool) (args []*Argument, err error) {
+	if err = p.expect("("); err != nil {
+		return nil, err
+	}
+	for !p.peek(")") {
+		arg := new(Argument)
+		if arg.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+		if err = p.expect(":"); err != nil {
+			return nil, err
+		}
+		if arg.Value, err = p.parseValue(constant); err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, p.expect(")")
+}
+
+func (p *parser) parseDirectives() (dirs []*Directive, err error) {
+	for p.peek("@") {
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+		d := new(Directive)
+		if d.Name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+		if p.peek("(") {
+			if d.Arguments, err = p.parseArguments(false); err != nil {
+				return nil, err
+			}
+		}
+		dirs = append(dirs, d)
+	}
+	return dirs, nil
+}
+
+func (p *parser) parseValue(constant bool) (v Value, err error) {
+	if err = p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	tok := p.tok
+	switch tok.kind {
+	case tokenPunct:
+		switch tok.val {
+		case "$":
+			if constant {
+				return nil, p.unexpected()
+			}
+			if err = p.next(); err != nil {
+				return nil, err
+			}
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			return Variable(name), nil
+		case "[":
+			if err = p.next(); err != nil {
+				return nil, err
+			}
+			l := ListValue{}
+			for !p.peek("]") {
+				e, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				l = append(l, e)
+			}
+			return l, p.expect("]")
+		case "{":
+			if err = p.next(); err != nil {
+				return nil, err
+			}
+			o := ObjectValue{}
+			for !p.peek("}") {
+				f := new(Argument)
+				if f.Name, err = p.expectName(); err != nil {
+					return nil, err
+				}
+				if err = p.expect(":"); err != nil {
+					return nil, err
+				}
+				if f.Value, err = p.parseValue(constant); err != nil {
+					return nil, err
+				}
+				o = append(o, f)
+			}
+			return o, p.expect("}")
+		}
+	case tokenInt:
+		i, err := strconv.ParseInt(tok.val, 10, 64)
+		if err != nil {
+			return nil, errors.ErrGraphQLSyntax(tok.pos, err.Error())
+		}
+		return IntValue(i), p.next()
+	case tokenFloat:
+		f, err := strconv.ParseFloat(tok.val, 64)
+		if err != nil {
+			return nil, errors.ErrGraphQLSyntax(tok.pos, err.Error())
+		}
+		return FloatValue(f), p.next()
+	case tokenString:
+		return StringValue(tok.val), p.next()
+	case tokenName:
+		switch tok.val {
+		case "true":
+			v = BooleanValue(true)
+		case "false":
+			v = BooleanValue(false)
+		case "null":
+			v = NullValue{}
+		default:
+			v = EnumValue(tok.val)
+		}
+		return v, p.next()
+	}
+	return nil, p.unexpected()
+}
+
+// enter increments the nesting depth and fails when it exceeds maxNestingDepth.
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxNestingDepth {
+		return errors.ErrGraphQLSyntax(p.tok.pos, "nesting too deep")
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == tokenPunct && p.tok.val == punct
+}
+
+func (p *parser) peekName(name string) bool {
+	return p.tok.kind == tokenName && p.tok.val == name
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.peek(punct) {
+		return p.unexpected()
+	}
+	return p.next()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.val
+	return name, p.next()
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return errors.ErrGraphQLSyntax(p.tok.pos, "unexpected end of document")
+	}
+	return errors.ErrGraphQLSyntax(p.tok.pos, "unexpected token "+strconv.Quote(p.tok.val))
+}
+
+// next reads the next token, skipping ignored tokens such as whitespace, commas and comments.
+func (p *parser) next() error {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case c == ' ', c == '\t', c == '\n', c == '\r', c == ',':
+			p.pos++
+			continue
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '\r' {
+				p.pos++
+			}
+			continue
+		case strings.HasPrefix(p.src[p.pos:], "\ufeff"):
+			p.pos += len("\ufeff")
+			continue
+		}
+		break
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokenEOF, pos: start}
+		return nil
+	}
+	c := p.src[p.pos]
+	switch {
+	case strings.ContainsRune("!$&():=@[]{}|", rune(c)):
+		p.pos++
+		p.tok = token{kind: tokenPunct, val: string(c), pos: start}
+		return nil
+	case c == '.':
+		if !strings.HasPrefix(p.src[p.pos:], "...") {
+			return errors.ErrGraphQLSyntax(start, "unexpected character '.'")
+		}
+		p.pos += 3
+		p.tok = token{kind: tokenPunct, val: "...", pos: start}
+		return nil
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{kind: tokenName, val: p.src[start:p.pos], pos: start}
+		return nil
+	case c == '-' || isDigit(c):
+		return p.readNumber(start)
+	case c == '"':
+		return p.readString(start)
+	}
+	return errors.ErrGraphQLSyntax(start, "unexpected character "+strconv.QuoteRune(rune(c)))
+}
+
+func (p *parser) readNumber(start int) error {
+	kind := tokenInt
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	digits := func() int {
+		s := p.pos
+		for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			p.pos++
+		}
+		return p.pos - s
+	}
+	if digits() == 0 {
+		return errors.ErrGraphQLSyntax(start, "invalid number")
+	}
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		kind = tokenFloat
+		p.pos++
+		if digits() == 0 {
+			return errors.ErrGraphQLSyntax(start, "invalid number")
+		}
+	}
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		kind = tokenFloat
+		p.pos++
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		if digits() == 0 {
+			return errors.ErrGraphQLSyntax(start, "invalid number")
+		}
+	}
+	p.tok = token{kind: kind, val: p.src[start:p.pos], pos: start}
+	return nil
+}
+
+func (p *parser) readString(start int) error {
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		end := strings.Index(p.src[p.pos+3:], `"""`)
+		if end < 0 {
+			return errors.ErrGraphQLSyntax(start, "unterminated block string")
+		}
+		p.tok = token{kind: tokenString, val: p.src[p.pos+3 : p.pos+3+end], pos: start}
+		p.pos += 3 + end + 3
+		return nil
+	}
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch c {
+		case '"':
+			p.pos++
+			p.tok = token{kind: tokenString, val: sb.String(), pos: start}
+			return nil
+		case '\n', '\r':
+			return errors.ErrGraphQLSyntax(start, "unterminated string")
+		case '\\':
+			if p.pos+1 >= len(p.src) {
+				return errors.ErrGraphQLSyntax(start, "unterminated string")
+			}
+			p.pos++
+			switch e := p.src[p.pos]; e {
+			case '"', '\\', '/':
+				sb.WriteByte(e)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				if p.pos+5 > len(p.src) {
+					return errors.ErrGraphQLSyntax(start, "invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(p.src[p.pos+1:p.pos+5], 16, 32)
+				if err != nil {
+					return errors.ErrGraphQLSyntax(start, "invalid unicode escape")
+				}
+				sb.WriteRune(rune(r))
+				p.pos += 4
+			default:
+				return errors.ErrGraphQLSyntax(start, "invalid escape sequence")
+			}
+			p.pos++
+		default:
+			r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+			sb.WriteRune(r)
+			p.pos += size
+		}
+	}
+	return errors.ErrGraphQLSyntax(start, "unterminated string")
+}
+
+func isLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,247 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package graphql
+
+import (
+	"encoding/base64"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/sync"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Types derives GraphQL types from protobuf message descriptors.
+// Derived types are cached by their full names so that recursive and shared messages are built only once.
+type Types struct {
+	mu      sync.Mutex
+	prefix  string
+	objects map[protoreflect.FullName]*Object
+	inputs  map[protoreflect.FullName]*InputObject
+	enums   map[protoreflect.FullName]*Enum
+}
+
+// NewTypes returns a Types which strips prefix from the full names of the messages to name the GraphQL types.
+func NewTypes(prefix string) *Types {
+	return &Types{
+		prefix:  prefix,
+		objects: make(map[protoreflect.FullName]*Object),
+		inputs:  make(map[protoreflect.FullName]*InputObject),
+		enums:   make(map[protoreflect.FullName]*Enum),
+	}
+}
+
+func (t *Types) name(n protoreflect.FullName) string {
+	return strings.ReplaceAll(strings.TrimPrefix(string(n), t.prefix), ".", "_")
+}
+
+// Object returns the output object type of the message m.
+func (t *Types) Object(m proto.Message) *Object {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.object(m.ProtoReflect().Descriptor())
+}
+
+// Input returns the input object type of the message m.
+func (t *Types) Input(m proto.Message) *InputObject {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.input(m.ProtoReflect().Descriptor())
+}
+
+// Args returns the arguments of a field which take the fields of the message m.
+func (t *Types) Args(m proto.Message) []*InputValue {
+	in := t.Input(m)
+	args := make([]*InputValue, 0, len(in.names))
+	for _, name := range in.names {
+		args = append(args, in.fields[name])
+	}
+	return args
+}
+
+func (t *Types) object(md protoreflect.MessageDescriptor) *Object {
+	if o, ok := t.objects[md.FullName()]; ok {
+		return o
+	}
+	o := NewObject(t.name(md.FullName()), "")
+	t.objects[md.FullName()] = o
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		o.AddField(fd.JSONName(), &Field{
+			Type: t.fieldType(fd, false),
+		})
+	}
+	return o
+}
+
+func (t *Types) input(md protoreflect.MessageDescriptor) *InputObject {
+	if in, ok := t.inputs[md.FullName()]; ok {
+		return in
+	}
+	in := NewInputObject(t.name(md.FullName()) + "Input")
+	t.inputs[md.FullName()] = in
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		in.AddField(&InputValue{
+			Name: fd.JSONName(),
+			Type: t.fieldType(fd, true),
+		})
+	}
+	return in
+}
+
+func (t *Types) fieldType(fd protoreflect.FieldDescriptor, input bool) Type {
+	if fd.IsMap() {
+		return JSON
+	}
+	var typ Type
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		typ = Boolean
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		typ = Int
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		typ = Float
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		typ = String
+	case protoreflect.EnumKind:
+		typ = t.enum(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch {
+		case strings.HasPrefix(string(fd.Message().FullName()), "google.protobuf."):
+			typ = JSON
+		case input:
+			typ = t.input(fd.Message())
+		default:
+			typ = t.object(fd.Message())
+		}
+	default:
+		typ = JSON
+	}
+	if fd.IsList() {
+		return &List{Of: &NonNull{Of: typ}}
+	}
+	return typ
+}
+
+func (t *Types) enum(ed protoreflect.EnumDescriptor) *Enum {
+	if e, ok := t.enums[ed.FullName()]; ok {
+		return e
+	}
+	values := ed.Values()
+	e := &Enum{
+		Name:   t.name(ed.FullName()),
+		Values: make([]string, 0, values.Len()),
+	}
+	for i := 0; i < values.Len(); i++ {
+		e.Values = append(e.Values, string(values.Get(i).Name()))
+	}
+	t.enums[ed.FullName()] = e
+	return e
+}
+
+// UnmarshalArgs fills the message m with the coerced arguments args.
+func UnmarshalArgs(args map[string]any, m proto.Message) error {
+	b, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(b, m)
+}
+
+// protoField returns the value of the field whose JSON name is name, or nil when src is not a message.
+func protoField(src any, name string) any {
+	m, ok := src.(proto.Message)
+	if !ok || isNil(m) {
+		return nil
+	}
+	pm := m.ProtoReflect()
+	fd := pm.Descriptor().Fields().ByJSONName(name)
+	if fd == nil {
+		return nil
+	}
+	if fd.Message() != nil && !fd.IsList() && !fd.IsMap() && !pm.Has(fd) {
+		return nil
+	}
+	return protoValue(fd, pm.Get(fd))
+}
+
+func protoValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch {
+	case fd.IsList():
+		l := v.List()
+		res := make([]any, 0, l.Len())
+		for i := 0; i < l.Len(); i++ {
+			res = append(res, protoScalar(fd, l.Get(i)))
+		}
+		return res
+	case fd.IsMap():
+		res := make(map[string]any, v.Map().Len())
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			res[k.String()] = protoScalar(fd.MapValue(), mv)
+			return true
+		})
+		return res
+	}
+	return protoScalar(fd, v)
+}
+
+func protoScalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.StringKind:
+		return v.String()
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int64(v.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		m := v.Message().Interface()
+		if strings.HasPrefix(string(fd.Message().FullName()), "google.protobuf.") {
+			b, err := protojson.Marshal(m)
+			if err != nil {
+				return nil
+			}
+			var res any
+			if json.Unmarshal(b, &res) != nil {
+				return nil
+			}
+			return res
+		}
+		return m
+	}
+	return nil
+}
@@ -0,0 +1,459 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package graphql
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+// Type represents a GraphQL type.
+type Type interface {
+	String() string
+}
+
+// ResolveFunc resolves the value of a field.
+type ResolveFunc func(ctx context.Context, p ResolveParams) (any, error)
+
+// ResolveParams represents the parameters passed to a ResolveFunc.
+type ResolveParams struct {
+	// Source is the resolved value of the parent object.
+	Source any
+	// Args are the coerced arguments of the field.
+	Args map[string]any
+	// FieldName is the name of the resolved field.
+	FieldName string
+}
+
+// Default limits of the requests served by Schema.Handler.
+const (
+	DefaultMaxBatchSize   = 32
+	DefaultMaxConcurrency = 8
+	DefaultMaxDepth       = 16
+	DefaultMaxBodySize    = 16 << 20
+)
+
+// Schema represents a GraphQL schema with its root operation types.
+type Schema struct {
+	Query    *Object
+	Mutation *Object
+
+	// MaxBatchSize is the maximum number of the requests in a batch, DefaultMaxBatchSize is used if it is not positive.
+	MaxBatchSize int
+	// MaxConcurrency is the maximum number of the requests of a batch, and of the top-level query fields of a request,
+	// which are executed concurrently. DefaultMaxConcurrency is used if it is not positive.
+	MaxConcurrency int
+	// MaxDepth is the maximum nesting depth of the selection sets of a request, DefaultMaxDepth is used if it is not positive.
+	MaxDepth int
+	// MaxBodySize is the maximum size in bytes of a POST request body, DefaultMaxBodySize is used if it is not positive.
+	MaxBodySize int64
+}
+
+func (s *Schema) maxBatchSize() int {
+	if s.MaxBatchSize > 0 {
+		return s.MaxBatchSize
+	}
+	return DefaultMaxBatchSize
+}
+
+func (s *Schema) maxConcurrency() int {
+	if s.MaxConcurrency > 0 {
+		return s.MaxConcurrency
+	}
+	return DefaultMaxConcurrency
+}
+
+func (s *Schema) maxDepth() int {
+	if s.MaxDepth > 0 {
+		return s.MaxDepth
+	}
+	return DefaultMaxDepth
+}
+
+func (s *Schema) maxBodySize() int64 {
+	if s.MaxBodySize > 0 {
+		return s.MaxBodySize
+	}
+	return DefaultMaxBodySize
+}
+
+// Scalar represents a GraphQL scalar type.
+type Scalar struct {
+	Name string
+	// ParseValue coerces an input value. It returns false when the value is invalid.
+	ParseValue func(v any) (any, bool)
+}
+
+// Enum represents a GraphQL enum type whose values are serialized as their names.
+type Enum struct {
+	Name   string
+	Values []string
+}
+
+// Object represents a GraphQL object type.
+type Object struct {
+	Name        string
+	Description string
+	fields      map[string]*Field
+	names       []string
+}
+
+// Field represents a field of an object type.
+type Field struct {
+	Description string
+	Type        Type
+	Args        []*InputValue
+	Resolve     ResolveFunc
+}
+
+// InputObject represents a GraphQL input object type.
+type InputObject struct {
+	Name   string
+	fields map[string]*InputValue
+	names  []string
+}
+
+// InputValue represents an argument of a field or a field of an input object.
+type InputValue struct {
+	Name    string
+	Type    Type
+	Default any
+}
+
+// List represents a GraphQL list type.
+type List struct {
+	Of Type
+}
+
+// NonNull represents a GraphQL non-null type.
+type NonNull struct {
+	Of Type
+}
+
+var (
+	// Int represents the built-in Int scalar. It accepts 64-bit integers.
+	Int = &Scalar{
+		Name: "Int",
+		ParseValue: func(v any) (any, bool) {
+			switch x := v.(type) {
+			case int64:
+				return x, true
+			case int:
+				return int64(x), true
+			case float64:
+				if x == math.Trunc(x) {
+					return int64(x), true
+				}
+			}
+			return nil, false
+		},
+	}
+
+	// Float represents the built-in Float scalar.
+	Float = &Scalar{
+		Name: "Float",
+		ParseValue: func(v any) (any, bool) {
+			switch x := v.(type) {
+			case float64:
+				return x, true
+			case int64:
+				return float64(x), true
+			case int:
+				return float64(x), true
+			}
+			return nil, false
+		},
+	}
+
+	// String represents the built-in String scalar.
+	String = &Scalar{
+		Name: "String",
+		ParseValue: func(v any) (any, bool) {
+			s, ok := v.(string)
+			return s, ok
+		},
+	}
+
+	// Boolean represents the built-in Boolean scalar.
+	Boolean = &Scalar{
+		Name: "Boolean",
+		ParseValue: func(v any) (any, bool) {
+			b, ok := v.(bool)
+			return b, ok
+		},
+	}
+
+	// JSON represents an arbitrary JSON value.
+	JSON = &Scalar{
+		Name: "JSON",
+		ParseValue: func(v any) (any, bool) {
+			return v, true
+		},
+	}
+)
+
+// NewObject returns an empty object type.
+func NewObject(name, description string) *Object {
+	return &Object{
+		Name:        name,
+		Description: description,
+		fields:      make(map[string]*Field),
+	}
+}
+
+// AddField adds or replaces the field name of the object.
+func (o *Object) AddField(name string, f *Field) *Object {
+	if _, ok := o.fields[name]; !ok {
+		o.names = append(o.names, name)
+	}
+	o.fields[name] = f
+	return o
+}
+
+// Field returns the field name of the object or nil.
+func (o *Object) Field(name string) *Field {
+	return o.fields[name]
+}
+
+// NewInputObject returns an empty input object type.
+func NewInputObject(name string) *InputObject {
+	return &InputObject{
+		Name:   name,
+		fields: make(map[string]*InputValue),
+	}
+}
+
+// AddField adds or replaces a field of the input object.
+func (o *InputObject) AddField(f *InputValue) *InputObject {
+	if _, ok := o.fields[f.Name]; !ok {
+		o.names = append(o.names, f.Name)
+	}
+	o.fields[f.Name] = f
+	return o
+}
+
+func (s *Scalar) String() string      { return s.Name }
+func (e *Enum) String() string        { return e.Name }
+func (o *Object) String() string      { return o.Name }
+func (o *InputObject) String() string { return o.Name }
+func (l *List) String() string        { return "[" + l.Of.String() + "]" }
+func (n *NonNull) String() string     { return n.Of.String() + "!" }
+
+// collectTypes returns the named types reachable from the roots by their names.
+func collectTypes(roots ...Type) map[string]Type {
+	types := make(map[string]Type)
+	var walk func(t Type)
+	walk = func(t Type) {
+		switch x := t.(type) {
+		case *List:
+			walk(x.Of)
+		case *NonNull:
+			walk(x.Of)
+		case *Scalar:
+			types[x.Name] = x
+		case *Enum:
+			types[x.Name] = x
+		case *Object:
+			if _, ok := types[x.Name]; ok {
+				return
+			}
+			types[x.Name] = x
+			for _, name := range x.names {
+				f := x.fields[name]
+				walk(f.Type)
+				for _, arg := range f.Args {
+					walk(arg.Type)
+				}
+			}
+		case *InputObject:
+			if _, ok := types[x.Name]; ok {
+				return
+			}
+			types[x.Name] = x
+			for _, name := range x.names {
+				walk(x.fields[name].Type)
+			}
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+	return types
+}
+
+// isBuiltin reports whether t is a built-in scalar, which is not declared by the schema definition language.
+func isBuiltin(t Type) bool {
+	switch t {
+	case Int, Float, String, Boolean:
+		return true
+	}
+	return false
+}
+
+// SDL returns the schema definition language representation of the schema and every type reachable from it.
+func (s *Schema) SDL() string {
+	var roots []Type
+	if s.Query != nil {
+		roots = append(roots, s.Query)
+	}
+	if s.Mutation != nil {
+		roots = append(roots, s.Mutation)
+	}
+	types := collectTypes(roots...)
+	var sb strings.Builder
+	sb.WriteString("schema {\n")
+	if s.Query != nil {
+		sb.WriteString("  query: " + s.Query.Name + "\n")
+	}
+	if s.Mutation != nil {
+		sb.WriteString("  mutation: " + s.Mutation.Name + "\n")
+	}
+	sb.WriteString("}\n")
+
+	names := make([]string, 0, len(types))
+	for name, t := range types {
+		if !isBuiltin(t) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		sb.WriteString("\n")
+		switch x := types[name].(type) {
+		case *Scalar:
+			sb.WriteString("scalar " + x.Name + "\n")
+		case *Enum:
+			sb.WriteString("enum " + x.Name + " {\n")
+			for _, v := range x.Values {
+				sb.WriteString("  " + v + "\n")
+			}
+			sb.WriteString("}\n")
+		case *Object:
+			if x.Description != "" {
+				sb.WriteString("\"\"\"" + x.Description + "\"\"\"\n")
+			}
+			sb.WriteString("type " + x.Name + " {\n")
+			for _, fname := range x.names {
+				f := x.fields[fname]
+				sb.WriteString("  " + fname)
+				if len(f.Args) != 0 {
+					args := make([]string, 0, len(f.Args))
+					for _, arg := range f.Args {
+						args = append(args, arg.Name+": "+arg.Type.String())
+					}
+					sb.WriteString("(" + strings.Join(args, ", ") + ")")
+				}
+				sb.WriteString(": " + f.Type.String() + "\n")
+			}
+			sb.WriteString("}\n")
+		case *InputObject:
+			sb.WriteString("input " + x.Name + " {\n")
+			for _, fname := range x.names {
+				sb.WriteString("  " + fname + ": " + x.fields[fname].Type.String() + "\n")
+			}
+			sb.WriteString("}\n")
+		}
+	}
+	return sb.String()
+}
+
+// namedType returns the innermost type of list and non-null wrappers.
+func namedType(t Type) Type {
+	for {
+		switch x := t.(type) {
+		case *List:
+			t = x.Of
+		case *NonNull:
+			t = x.Of
+		default:
+			return t
+		}
+	}
+}
+
+// coerceInput coerces v to the input type t. present reports whether the value was provided.
+func coerceInput(name string, t Type, v any, present bool) (any, error) {
+	if nn, ok := t.(*NonNull); ok {
+		if !present || v == nil {
+			return nil, errors.ErrGraphQLNullValue(name, t.String())
+		}
+		return coerceInput(name, nn.Of, v, present)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	switch x := t.(type) {
+	case *List:
+		l, ok := v.([]any)
+		if !ok {
+			// a single value is coerced to a list of one element.
+			l = []any{v}
+		}
+		res := make([]any, 0, len(l))
+		for _, e := range l {
+			c, err := coerceInput(name, x.Of, e, true)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, c)
+		}
+		return res, nil
+	case *Scalar:
+		c, ok := x.ParseValue(v)
+		if !ok {
+			return nil, errors.ErrGraphQLInvalidValue(name, t.String(), v)
+		}
+		return c, nil
+	case *Enum:
+		s, ok := v.(string)
+		if !ok || !slices.Contains(x.Values, s) {
+			return nil, errors.ErrGraphQLInvalidValue(name, t.String(), v)
+		}
+		return s, nil
+	case *InputObject:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, errors.ErrGraphQLInvalidValue(name, t.String(), v)
+		}
+		for k := range m {
+			if _, ok := x.fields[k]; !ok {
+				return nil, errors.ErrGraphQLInvalidValue(name+"."+k, t.String(), m[k])
+			}
+		}
+		res := make(map[string]any, len(m))
+		for _, fname := range x.names {
+			f := x.fields[fname]
+			fv, ok := m[fname]
+			if !ok && f.Default != nil {
+				fv, ok = f.Default, true
+			}
+			c, err := coerceInput(name+"."+fname, f.Type, fv, ok)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				res[fname] = c
+			}
+		}
+		return res, nil
+	}
+	return nil, errors.ErrGraphQLInvalidValue(name, t.String(), v)
+}
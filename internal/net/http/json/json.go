@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"net/http"
+	"reflect"
 
 	"github.com/vdaas/vald/internal/encoding/json"
 	"github.com/vdaas/vald/internal/errors"
@@ -107,7 +108,49 @@ func DecodeRequest(r *http.Request, data any) (err error) {
 	return nil
 }
 
+// vectorDecoder is implemented by the requests whose vectors may be sent in a compact encoding.
+type vectorDecoder interface {
+	DecodeVectors() error
+}
+
+// decodeVectors decodes the vectors of the request data sent in a compact encoding, the same as the gRPC codec does.
+// data may be a pointer to the request.
+func decodeVectors(data any) error {
+	v, ok := data.(vectorDecoder)
+	if !ok {
+		if rv := reflect.ValueOf(data); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			v, ok = rv.Elem().Interface().(vectorDecoder)
+		}
+	}
+	if !ok {
+		return nil
+	}
+	return v.DecodeVectors()
+}
+
+type requestHookKey struct{}
+
+// RequestHook inspects the requests decoded from an HTTP request before they are served, such as to rate limit them.
+type RequestHook interface {
+	// Request is called with the request decoded by Handler, the request is rejected with code when it returns an error.
+	Request(ctx context.Context, req any) (code int, err error)
+	// Message is called with each message received by a ServerStream, the stream fails when it returns an error.
+	Message(ctx context.Context, msg any) error
+}
+
+// WithRequestHook returns a copy of ctx carrying the hook called with the requests decoded by Handler and ServerStream.
+func WithRequestHook(ctx context.Context, h RequestHook) context.Context {
+	return context.WithValue(ctx, requestHookKey{}, h)
+}
+
+func requestHook(ctx context.Context) RequestHook {
+	h, _ := ctx.Value(requestHookKey{}).(RequestHook)
+	return h
+}
+
 // Handler responds to an HTTP request to perform a logic function.
+// The vectors of the decoded request sent in a compact encoding are decoded before logic is called.
+// The decoded request is passed to the RequestHook of the HTTP request context if any.
 func Handler(
 	w http.ResponseWriter, r *http.Request, data any, logic func() (any,
 		error),
@@ -116,6 +159,14 @@ func Handler(
 	if err != nil {
 		return http.StatusBadRequest, err
 	}
+	if err = decodeVectors(data); err != nil {
+		return http.StatusBadRequest, err
+	}
+	if h := requestHook(r.Context()); h != nil {
+		if code, err = h.Request(r.Context(), data); err != nil {
+			return code, err
+		}
+	}
 	res, err := logic()
 	if err != nil {
 		return http.StatusInternalServerError, err
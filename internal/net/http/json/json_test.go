@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/io"
 	"github.com/vdaas/vald/internal/log"
@@ -332,6 +333,101 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func Test_decodeVectors(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		data any
+	}
+	type want struct {
+		vec *payload.Object_Vector
+		err error
+	}
+	type test struct {
+		name string
+		args args
+		want want
+	}
+	newReq := func(enc payload.Object_Vector_Encoding, data ...byte) *payload.Insert_Request {
+		return &payload.Insert_Request{
+			Vector: &payload.Object_Vector{
+				Id:       "1",
+				Encoding: enc,
+				Data:     data,
+			},
+		}
+	}
+	tests := []test{
+		func() test {
+			req := newReq(payload.Object_Vector_FLOAT16, 0x00, 0x3c, 0x00, 0x41)
+			return test{
+				name: "decode the vector of the request decoded by the handler",
+				args: args{
+					data: &req,
+				},
+				want: want{
+					vec: &payload.Object_Vector{
+						Id:       "1",
+						Vector:   []float32{1.0, 2.5},
+						Encoding: payload.Object_Vector_FLOAT16,
+					},
+				},
+			}
+		}(),
+		{
+			name: "decode the vector of the request",
+			args: args{
+				data: newReq(payload.Object_Vector_BYTES, 0x00, 0x00, 0x80, 0x3f),
+			},
+			want: want{
+				vec: &payload.Object_Vector{
+					Id:       "1",
+					Vector:   []float32{1.0},
+					Encoding: payload.Object_Vector_BYTES,
+				},
+			},
+		},
+		func() test {
+			req := newReq(payload.Object_Vector_BYTES, 0, 1, 2)
+			return test{
+				name: "return error when the encoded vector is invalid",
+				args: args{
+					data: &req,
+				},
+				want: want{
+					err: errors.ErrInvalidEncodedVectorLength("BYTES", 3, 4),
+				},
+			}
+		}(),
+		{
+			name: "return nil when the data has no vectors",
+			args: args{
+				data: &map[string]string{},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			err := decodeVectors(test.args.data)
+			if !errors.Is(err, test.want.err) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want.err)
+			}
+			if test.want.vec == nil {
+				return
+			}
+			req, ok := test.args.data.(*payload.Insert_Request)
+			if !ok {
+				req = *test.args.data.(**payload.Insert_Request)
+			}
+			if got := req.GetVector(); !reflect.DeepEqual(got, test.want.vec) {
+				tt.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", got, test.want.vec)
+			}
+		})
+	}
+}
+
 func TestErrorHandler(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -0,0 +1,297 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package json
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/codes"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+	"github.com/vdaas/vald/internal/net/http/rest"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+const (
+	// metadataHeaderPrefix is the HTTP header prefix of the gRPC metadata set by the stream handlers.
+	metadataHeaderPrefix = "Grpc-Metadata-"
+
+	sseResultEvent = "result"
+	sseErrorEvent  = "error"
+)
+
+type streamTimeoutKey struct{}
+
+type streamTimeout struct {
+	read  time.Duration
+	write time.Duration
+}
+
+// WithStreamTimeout returns a copy of ctx carrying the read and write timeouts
+// that are applied to every message of a streaming request instead of the whole request.
+func WithStreamTimeout(ctx context.Context, read, write time.Duration) context.Context {
+	return context.WithValue(ctx, streamTimeoutKey{}, streamTimeout{
+		read:  read,
+		write: write,
+	})
+}
+
+// ServerStream adapts an HTTP request and response to a typed gRPC server stream.
+// Requests are read from a newline-delimited JSON body and responses are written
+// as newline-delimited JSON or as Server-Sent Events when the client accepts text/event-stream.
+// Every message is flushed synchronously, so a slow client applies back-pressure to the stream handler.
+type ServerStream[Q, R any] struct {
+	ctx     context.Context
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	dec     *json.Decoder
+	sse     bool
+	timeout streamTimeout
+
+	rmu     sync.Mutex
+	smu     sync.Mutex
+	started bool
+}
+
+type streamFrame struct {
+	Result any          `json:"result,omitempty"`
+	Error  *streamError `json:"error,omitempty"`
+}
+
+type streamError struct {
+	Code    int32  `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewServerStream returns a ServerStream reading from r and writing to w.
+func NewServerStream[Q, R any](w http.ResponseWriter, r *http.Request) *ServerStream[Q, R] {
+	s := &ServerStream[Q, R]{
+		ctx: r.Context(),
+		w:   w,
+		rc:  http.NewResponseController(w),
+		sse: strings.Contains(r.Header.Get(rest.Accept), rest.TextEventStream),
+	}
+	if t, ok := r.Context().Value(streamTimeoutKey{}).(streamTimeout); ok {
+		s.timeout = t
+	}
+	if r.Body != nil && r.ContentLength != 0 {
+		s.dec = json.NewDecoder(r.Body)
+		if r.ProtoMajor == 1 {
+			// HTTP/1.1 does not allow reading the request body after the response has started without full duplex mode.
+			if err := s.rc.EnableFullDuplex(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				log.Warn(err)
+			}
+		}
+	}
+	return s
+}
+
+// StreamHandler serves logic as a streaming endpoint.
+// Errors returned before the first message is sent are reported through the usual error handler,
+// while errors after that are written to the stream as an error frame.
+func StreamHandler[Q, R any](
+	w http.ResponseWriter, r *http.Request, logic func(*ServerStream[Q, R]) error,
+) (code int, err error) {
+	s := NewServerStream[Q, R](w, r)
+	err = logic(s)
+	s.smu.Lock()
+	defer s.smu.Unlock()
+	if err != nil {
+		if !s.started {
+			return http.StatusInternalServerError, err
+		}
+		if serr := s.write(sseErrorEvent, &streamFrame{
+			Error: newStreamError(err),
+		}); serr != nil {
+			log.Error(errors.Join(err, serr))
+		}
+		return http.StatusOK, nil
+	}
+	if !s.started {
+		s.start()
+	}
+	return http.StatusOK, nil
+}
+
+// Context returns the context of the underlying HTTP request.
+func (s *ServerStream[Q, R]) Context() context.Context {
+	return s.ctx
+}
+
+// SetHeader sets the metadata as HTTP response headers. It fails after the response has started.
+func (s *ServerStream[Q, R]) SetHeader(md grpc.MD) error {
+	s.smu.Lock()
+	defer s.smu.Unlock()
+	if s.started {
+		return errors.ErrHTTPStreamAlreadyStarted
+	}
+	for k, vs := range md {
+		for _, v := range vs {
+			s.w.Header().Add(metadataHeaderPrefix+k, v)
+		}
+	}
+	return nil
+}
+
+// SendHeader sets the metadata as HTTP response headers and starts the response.
+func (s *ServerStream[Q, R]) SendHeader(md grpc.MD) error {
+	err := s.SetHeader(md)
+	if err != nil {
+		return err
+	}
+	s.smu.Lock()
+	defer s.smu.Unlock()
+	s.start()
+	return s.flush()
+}
+
+// SetTrailer sets the metadata as HTTP trailers.
+func (s *ServerStream[Q, R]) SetTrailer(md grpc.MD) {
+	s.smu.Lock()
+	defer s.smu.Unlock()
+	for k, vs := range md {
+		for _, v := range vs {
+			s.w.Header().Add(http.TrailerPrefix+metadataHeaderPrefix+k, v)
+		}
+	}
+}
+
+// SendMsg writes m to the response as a single result frame and flushes it.
+func (s *ServerStream[Q, R]) SendMsg(m any) error {
+	s.smu.Lock()
+	defer s.smu.Unlock()
+	if !s.started {
+		s.start()
+	}
+	return s.write(sseResultEvent, &streamFrame{
+		Result: m,
+	})
+}
+
+// RecvMsg reads the next JSON value of the request body into m and decodes its vectors sent in a compact encoding.
+// It returns io.EOF when the request body is exhausted.
+func (s *ServerStream[Q, R]) RecvMsg(m any) error {
+	s.rmu.Lock()
+	defer s.rmu.Unlock()
+	if s.dec == nil {
+		return io.EOF
+	}
+	if s.timeout.read > 0 {
+		if err := s.rc.SetReadDeadline(time.Now().Add(s.timeout.read)); err != nil &&
+			!errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+	}
+	err := s.dec.Decode(m)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		return status.WrapWithInvalidArgument("failed to decode stream request", err)
+	}
+	if err = decodeVectors(m); err != nil {
+		return status.WrapWithInvalidArgument("failed to decode stream request vectors", err)
+	}
+	if h := requestHook(s.ctx); h != nil {
+		return h.Message(s.ctx, m)
+	}
+	return nil
+}
+
+// Recv reads the next request message.
+func (s *ServerStream[Q, R]) Recv() (*Q, error) {
+	q := new(Q)
+	if err := s.RecvMsg(q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Send writes a response message.
+func (s *ServerStream[Q, R]) Send(r *R) error {
+	return s.SendMsg(r)
+}
+
+// start writes the response header. It must be called with smu held.
+func (s *ServerStream[Q, R]) start() {
+	s.started = true
+	if s.sse {
+		s.w.Header().Set(rest.ContentType, rest.TextEventStream)
+		s.w.Header().Set("Cache-Control", "no-cache")
+	} else {
+		s.w.Header().Add(rest.ContentType, rest.ApplicationNDJSON)
+		s.w.Header().Add(rest.ContentType, rest.CharsetUTF8)
+	}
+	s.w.WriteHeader(http.StatusOK)
+}
+
+// write encodes a frame and flushes it. It must be called with smu held.
+func (s *ServerStream[Q, R]) write(event string, f *streamFrame) (err error) {
+	if s.timeout.write > 0 {
+		if err = s.rc.SetWriteDeadline(time.Now().Add(s.timeout.write)); err != nil &&
+			!errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+	}
+	if s.sse {
+		var data []byte
+		if f.Error != nil {
+			data, err = json.Marshal(f.Error)
+		} else {
+			data, err = json.Marshal(f.Result)
+		}
+		if err != nil {
+			return err
+		}
+		_, err = s.w.Write([]byte("event: " + event + "\ndata: " + string(data) + "\n\n"))
+	} else {
+		err = json.Encode(s.w, f)
+	}
+	if err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *ServerStream[Q, R]) flush() error {
+	err := s.rc.Flush()
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+func newStreamError(err error) *streamError {
+	st, ok := status.FromError(err)
+	if !ok || st == nil {
+		return &streamError{
+			Code:    int32(codes.Unknown),
+			Message: err.Error(),
+		}
+	}
+	return &streamError{
+		Code:    int32(st.Code()),
+		Message: st.Message(),
+	}
+}
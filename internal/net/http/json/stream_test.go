@@ -0,0 +1,152 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package json
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+	"github.com/vdaas/vald/internal/net/grpc/codes"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+	"github.com/vdaas/vald/internal/net/http/rest"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+type streamMessage struct {
+	Name string `json:"name"`
+}
+
+func echo(s *ServerStream[streamMessage, streamMessage]) error {
+	for {
+		msg, err := s.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err = s.Send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func TestStreamHandler(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		body   string
+		accept string
+		logic  func(*ServerStream[streamMessage, streamMessage]) error
+	}
+	type want struct {
+		code        int
+		err         error
+		contentType string
+		body        string
+	}
+	type test struct {
+		name string
+		args args
+		want want
+	}
+	tests := []test{
+		{
+			name: "returns newline-delimited results for each request",
+			args: args{
+				body:  "{\"name\":\"vald\"}\n{\"name\":\"agent\"}\n",
+				logic: echo,
+			},
+			want: want{
+				code:        http.StatusOK,
+				contentType: rest.ApplicationNDJSON,
+				body:        "{\"result\":{\"name\":\"vald\"}}\n{\"result\":{\"name\":\"agent\"}}\n",
+			},
+		},
+		{
+			name: "returns server-sent events when the client accepts event stream",
+			args: args{
+				body:   "{\"name\":\"vald\"}",
+				accept: rest.TextEventStream,
+				logic:  echo,
+			},
+			want: want{
+				code:        http.StatusOK,
+				contentType: rest.TextEventStream,
+				body:        "event: result\ndata: {\"name\":\"vald\"}\n\n",
+			},
+		},
+		{
+			name: "returns error frame when the stream fails after the first message",
+			args: args{
+				logic: func(s *ServerStream[streamMessage, streamMessage]) error {
+					if err := s.Send(&streamMessage{Name: "vald"}); err != nil {
+						return err
+					}
+					return status.Error(codes.Internal, "failed")
+				},
+			},
+			want: want{
+				code:        http.StatusOK,
+				contentType: rest.ApplicationNDJSON,
+				body:        "{\"result\":{\"name\":\"vald\"}}\n{\"error\":{\"code\":13,\"message\":\"failed\"}}\n",
+			},
+		},
+		{
+			name: "returns error when the stream fails before the first message",
+			args: args{
+				logic: func(*ServerStream[streamMessage, streamMessage]) error {
+					return errors.ErrInvalidRequest
+				},
+			},
+			want: want{
+				code: http.StatusInternalServerError,
+				err:  errors.ErrInvalidRequest,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.args.body))
+			if test.args.accept != "" {
+				r.Header.Set(rest.Accept, test.args.accept)
+			}
+			w := httptest.NewRecorder()
+			code, err := StreamHandler(w, r, test.args.logic)
+			if !errors.Is(err, test.want.err) {
+				tt.Errorf("err not equals. want: %v, got: %v", test.want.err, err)
+			}
+			if code != test.want.code {
+				tt.Errorf("code not equals. want: %d, got: %d", test.want.code, code)
+			}
+			if test.want.err != nil {
+				return
+			}
+			if got := w.Header().Get(rest.ContentType); got != test.want.contentType {
+				tt.Errorf("content-type not equals. want: %s, got: %s", test.want.contentType, got)
+			}
+			if got := w.Body.String(); got != test.want.body {
+				tt.Errorf("body not equals. want: %q, got: %q", test.want.body, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package metrics
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net/http/json"
+	"github.com/vdaas/vald/internal/net/http/rest"
+	"github.com/vdaas/vald/internal/net/http/routing"
+	"github.com/vdaas/vald/internal/observability/trace"
+	"github.com/vdaas/vald/internal/timeutil"
+)
+
+// LogRequest represents the request to change the log level and format of the packages matching the prefix.
+// The empty duration keeps the change until it is deleted.
+type LogRequest struct {
+	Prefix   string `json:"prefix"`
+	Level    string `json:"level"`
+	Format   string `json:"format"`
+	Duration string `json:"duration"`
+}
+
+// TraceRequest represents the request to sample the traces of the request ID regardless of the sampling rate.
+// The empty duration keeps the sampling until it is deleted.
+type TraceRequest struct {
+	RequestID string `json:"request_id"`
+	Duration  string `json:"duration"`
+}
+
+// TraceSampling represents the request ID sampled regardless of the sampling rate.
+type TraceSampling struct {
+	RequestID string `json:"request_id"`
+	ExpireAt  string `json:"expire_at,omitempty"`
+}
+
+// GetAdminRoutes returns the routes which change the log level and the trace sampling at runtime.
+func GetAdminRoutes() []routing.Route {
+	return []routing.Route{
+		{
+			Name: "Admin log level",
+			Methods: []string{
+				http.MethodGet,
+				http.MethodPut,
+				http.MethodPost,
+				http.MethodDelete,
+			},
+			Pattern:     "/admin/log",
+			RPC:         "/admin/log",
+			HandlerFunc: logLevel,
+		},
+		{
+			Name: "Admin trace sampling",
+			Methods: []string{
+				http.MethodGet,
+				http.MethodPut,
+				http.MethodPost,
+				http.MethodDelete,
+			},
+			Pattern:     "/admin/trace",
+			RPC:         "/admin/trace",
+			HandlerFunc: traceSampling,
+		},
+	}
+}
+
+// NewAdminHandler returns the admin API handler.
+func NewAdminHandler() http.Handler {
+	return routing.New(
+		routing.WithRoutes(GetAdminRoutes()...))
+}
+
+func logLevel(w http.ResponseWriter, r *http.Request) (int, error) {
+	if r.Method == http.MethodGet {
+		return respond(w, log.Overrides())
+	}
+	var req LogRequest
+	if err := json.DecodeRequest(r, &req); err != nil {
+		return http.StatusBadRequest, err
+	}
+	if r.Method == http.MethodDelete {
+		if !log.DeleteOverride(req.Prefix) {
+			return http.StatusNotFound, errors.ErrLogOverrideNotFound(req.Prefix)
+		}
+		log.Infof("log level override for prefix %q is deleted by admin API", req.Prefix)
+		return respond(w, log.Overrides())
+	}
+	dur, err := parseDuration(req.Duration)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	ov, err := log.SetOverride(req.Prefix, req.Level, req.Format, dur)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	log.Infof("log level override is set by admin API: %#v", ov)
+	return respond(w, ov)
+}
+
+func traceSampling(w http.ResponseWriter, r *http.Request) (int, error) {
+	if r.Method == http.MethodGet {
+		return respond(w, sampledRequests())
+	}
+	var req TraceRequest
+	if err := json.DecodeRequest(r, &req); err != nil {
+		return http.StatusBadRequest, err
+	}
+	if req.RequestID == "" {
+		return http.StatusBadRequest, errors.ErrEmptyRequestID
+	}
+	if r.Method == http.MethodDelete {
+		if !trace.DisableRequestSampling(req.RequestID) {
+			return http.StatusNotFound, errors.ErrTraceSamplingNotFound(req.RequestID)
+		}
+		log.Infof("trace sampling for request %q is disabled by admin API", req.RequestID)
+		return respond(w, sampledRequests())
+	}
+	dur, err := parseDuration(req.Duration)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	res := TraceSampling{
+		RequestID: req.RequestID,
+	}
+	if expire := trace.EnableRequestSampling(req.RequestID, dur); !expire.IsZero() {
+		res.ExpireAt = expire.Format(time.RFC3339)
+	}
+	log.Infof("trace sampling is enabled by admin API: %#v", res)
+	return respond(w, res)
+}
+
+func sampledRequests() []TraceSampling {
+	reqs := trace.SampledRequests()
+	res := make([]TraceSampling, 0, len(reqs))
+	for id, expire := range reqs {
+		ts := TraceSampling{
+			RequestID: id,
+		}
+		if !expire.IsZero() {
+			ts.ExpireAt = expire.Format(time.RFC3339)
+		}
+		res = append(res, ts)
+	}
+	slices.SortFunc(res, func(a, b TraceSampling) int {
+		return cmp.Compare(a.RequestID, b.RequestID)
+	})
+	return res
+}
+
+func parseDuration(dur string) (time.Duration, error) {
+	if dur == "" {
+		return 0, nil
+	}
+	return timeutil.Parse(dur)
+}
+
+func respond(w http.ResponseWriter, data any) (int, error) {
+	if err := json.EncodeResponse(w, data, http.StatusOK, rest.ApplicationJSON, rest.CharsetUTF8); err != nil {
+		return http.StatusServiceUnavailable, err
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,139 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vdaas/vald/internal/strings"
+)
+
+func TestNewAdminHandler(t *testing.T) {
+	server := httptest.NewServer(NewAdminHandler())
+	defer server.Close()
+
+	do := func(t *testing.T, method, path, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to make %s request: %v", method, err)
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, string(b)
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "sets the log level of the package prefix",
+			method:   http.MethodPut,
+			path:     "/admin/log",
+			body:     `{"prefix":"pkg/agent","level":"debug","duration":"1m"}`,
+			wantCode: http.StatusOK,
+			wantBody: `"level":"DEBUG"`,
+		},
+		{
+			name:     "lists the log level overrides",
+			method:   http.MethodGet,
+			path:     "/admin/log",
+			wantCode: http.StatusOK,
+			wantBody: `"prefix":"pkg/agent"`,
+		},
+		{
+			name:     "rejects the invalid log level",
+			method:   http.MethodPut,
+			path:     "/admin/log",
+			body:     `{"level":"verbose"}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `invalid log level`,
+		},
+		{
+			name:     "deletes the log level override",
+			method:   http.MethodDelete,
+			path:     "/admin/log",
+			body:     `{"prefix":"pkg/agent"}`,
+			wantCode: http.StatusOK,
+			wantBody: `[]`,
+		},
+		{
+			name:     "returns not found when the log level override does not exist",
+			method:   http.MethodDelete,
+			path:     "/admin/log",
+			body:     `{"prefix":"pkg/agent"}`,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "enables the trace sampling of the request id",
+			method:   http.MethodPost,
+			path:     "/admin/trace",
+			body:     `{"request_id":"req-1","duration":"10m"}`,
+			wantCode: http.StatusOK,
+			wantBody: `"request_id":"req-1","expire_at"`,
+		},
+		{
+			name:     "lists the sampled request ids",
+			method:   http.MethodGet,
+			path:     "/admin/trace",
+			wantCode: http.StatusOK,
+			wantBody: `"request_id":"req-1"`,
+		},
+		{
+			name:     "rejects the empty request id",
+			method:   http.MethodPost,
+			path:     "/admin/trace",
+			body:     `{"duration":"10m"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "disables the trace sampling of the request id",
+			method:   http.MethodDelete,
+			path:     "/admin/trace",
+			body:     `{"request_id":"req-1"}`,
+			wantCode: http.StatusOK,
+			wantBody: `[]`,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			code, body := do(tt, test.method, test.path, test.body)
+			if code != test.wantCode {
+				tt.Errorf("Expected status code %d, got %d: %s", test.wantCode, code, body)
+			}
+			if !strings.Contains(body, test.wantBody) {
+				tt.Errorf("Expected %s in the body, got %s", test.wantBody, body)
+			}
+		})
+	}
+}
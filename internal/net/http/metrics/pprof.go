@@ -35,6 +35,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/",
+			RPC:         "/debug/pprof/",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Index),
 		},
 		{
@@ -43,6 +44,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/cmdline",
+			RPC:         "/debug/pprof/cmdline",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Cmdline),
 		},
 		{
@@ -51,6 +53,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/profile",
+			RPC:         "/debug/pprof/profile",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Profile),
 		},
 		{
@@ -59,6 +62,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/symbol",
+			RPC:         "/debug/pprof/symbol",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Symbol),
 		},
 		{
@@ -67,6 +71,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/trace",
+			RPC:         "/debug/pprof/trace",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Trace),
 		},
 		{
@@ -75,6 +80,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/allocs",
+			RPC:         "/debug/pprof/allocs",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Handler("allocs").ServeHTTP),
 		},
 		{
@@ -83,6 +89,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/heap",
+			RPC:         "/debug/pprof/heap",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Handler("heap").ServeHTTP),
 		},
 		{
@@ -91,6 +98,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/goroutine",
+			RPC:         "/debug/pprof/goroutine",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Handler("goroutine").ServeHTTP),
 		},
 		{
@@ -99,6 +107,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/threadcreate",
+			RPC:         "/debug/pprof/threadcreate",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Handler("threadcreate").ServeHTTP),
 		},
 		{
@@ -107,6 +116,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/block",
+			RPC:         "/debug/pprof/block",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Handler("block").ServeHTTP),
 		},
 		{
@@ -115,6 +125,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/mutex",
+			RPC:         "/debug/pprof/mutex",
 			HandlerFunc: rest.HandlerToRestFunc(pprof.Handler("mutex").ServeHTTP),
 		},
 		{
@@ -123,6 +134,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/delta_heap",
+			RPC:         "/debug/pprof/delta_heap",
 			HandlerFunc: rest.HandlerToRestFunc(pyprof.Heap),
 		},
 		{
@@ -131,6 +143,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/delta_mutex",
+			RPC:         "/debug/pprof/delta_mutex",
 			HandlerFunc: rest.HandlerToRestFunc(pyprof.Mutex),
 		},
 		{
@@ -139,6 +152,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/pprof/delta_block",
+			RPC:         "/debug/pprof/delta_block",
 			HandlerFunc: rest.HandlerToRestFunc(pyprof.Block),
 		},
 		{
@@ -147,6 +161,7 @@ func GetProfileRoutes() (r []routing.Route) {
 				http.MethodGet,
 			},
 			Pattern:     "/debug/fgprof",
+			RPC:         "/debug/fgprof",
 			HandlerFunc: rest.HandlerToRestFunc(fgprof.Handler().ServeHTTP),
 		},
 	}
@@ -0,0 +1,46 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/vdaas/vald/internal/net/http/rest"
+	"github.com/vdaas/vald/internal/net/http/routing"
+	"github.com/vdaas/vald/internal/observability/exporter/prometheus"
+)
+
+// GetPrometheusRoutes returns the routes which serve the metrics collected by the Prometheus exporter.
+func GetPrometheusRoutes() []routing.Route {
+	return []routing.Route{
+		{
+			Name: "Prometheus metrics",
+			Methods: []string{
+				http.MethodGet,
+			},
+			Pattern:     "/metrics",
+			RPC:         "/metrics",
+			HandlerFunc: rest.HandlerToRestFunc(prometheus.Handler().ServeHTTP),
+		},
+	}
+}
+
+// NewPrometheusHandler returns the Prometheus scrape endpoint handler.
+func NewPrometheusHandler() http.Handler {
+	return routing.New(
+		routing.WithRoutes(GetPrometheusRoutes()...))
+}
@@ -0,0 +1,69 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vdaas/vald/internal/observability/exporter/prometheus"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+func TestNewPrometheusHandler(t *testing.T) {
+	server := httptest.NewServer(NewPrometheusHandler())
+	defer server.Close()
+
+	get := func(t *testing.T) (int, string) {
+		t.Helper()
+		resp, err := http.Get(server.URL + "/metrics")
+		if err != nil {
+			t.Fatalf("Failed to make GET request: %v", err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, string(body)
+	}
+
+	if code, _ := get(t); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status code 503 before the exporter starts, got %d", code)
+	}
+
+	ctx := context.Background()
+	e, err := prometheus.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Stop(ctx)
+
+	code, body := get(t)
+	if code != http.StatusOK {
+		t.Errorf("Expected status code 200, got %d", code)
+	}
+	if !strings.Contains(body, "target_info") {
+		t.Errorf("Expected target_info in the body, got %s", body)
+	}
+}
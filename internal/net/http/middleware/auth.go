@@ -0,0 +1,112 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package middleware provides rest.Func Middleware
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/vdaas/vald/internal/auth"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net/http/json"
+)
+
+// RPCResolver resolves the RPC of the route serving a request.
+type RPCResolver interface {
+	// RPC returns the RPC of the route matching the request, or false when the request matches no route with an RPC.
+	RPC(r *http.Request) (rpc string, ok bool)
+}
+
+// NewAuthHandler returns the handler which authenticates every request with the authenticators in order,
+// and checks the identity against the policy when it is not nil.
+// REST requests are authorized as the RPC of their route resolved by rpcs, and the requests without one are rejected.
+// The request body is not inspected, so rules restricting ID prefixes never grant REST requests.
+// The requests to operationPaths, such as the GraphQL endpoint, are not authorized by their paths. Instead,
+// the handler authorizes each operation of them by auth.AuthorizeOperation with the identity and the policy.
+// The health checks are served without authentication.
+func NewAuthHandler(
+	h http.Handler, rpcs RPCResolver, authns []auth.Authenticator, p auth.Policy, operationPaths ...string,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rpc, mapped := resolveRPC(rpcs, r)
+		if mapped && auth.IsExempt(rpc) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		id, err := auth.Authenticate(r.Context(), httpCredentials(r), authns...)
+		if err != nil {
+			log.Debug(err)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			if err = json.ErrorHandler(w, r, "Unauthenticated", http.StatusUnauthorized, err); err != nil {
+				log.Error(err)
+			}
+			return
+		}
+		ctx := auth.NewContext(r.Context(), id)
+		switch {
+		case p == nil:
+		case slices.Contains(operationPaths, r.URL.Path):
+			collection := r.Header.Get(CollectionHeader)
+			ctx = auth.NewAuthorizerContext(ctx, func(method string, ids []string) error {
+				return p.Authorize(id, &auth.Request{
+					Method:     method,
+					Collection: collection,
+					IDs:        ids,
+					CheckIDs:   true,
+				})
+			})
+		default:
+			if mapped {
+				err = p.Authorize(id, &auth.Request{
+					Method:     rpc,
+					Collection: r.Header.Get(CollectionHeader),
+					CheckIDs:   true,
+				})
+			} else {
+				err = errors.ErrUnmappedHTTPRoute(r.Method, r.URL.Path)
+			}
+			if err != nil {
+				log.Debug(err)
+				if err = json.ErrorHandler(w, r, "Permission Denied", http.StatusForbidden, err); err != nil {
+					log.Error(err)
+				}
+				return
+			}
+		}
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func httpCredentials(r *http.Request) *auth.Credentials {
+	creds := &auth.Credentials{
+		Token:  auth.BearerToken(r.Header.Get(auth.AuthorizationKey)),
+		APIKey: r.Header.Get(auth.APIKeyKey),
+	}
+	if r.TLS != nil && len(r.TLS.VerifiedChains) != 0 {
+		creds.Certificates = r.TLS.VerifiedChains[0]
+	}
+	return creds
+}
+
+func resolveRPC(rpcs RPCResolver, r *http.Request) (rpc string, ok bool) {
+	if rpcs == nil {
+		return "", false
+	}
+	return rpcs.RPC(r)
+}
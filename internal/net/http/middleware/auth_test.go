@@ -0,0 +1,166 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vdaas/vald/internal/auth"
+)
+
+func TestNewAuthHandler(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		path   string
+		apiKey string
+	}
+	type want struct {
+		code int
+		// operations maps the methods authorized by the handler to whether they are granted.
+		operations map[string]bool
+	}
+	type test struct {
+		name string
+		args args
+		want want
+	}
+	const (
+		readerKey = "reader-key"
+		search    = "/vald.v1.Search/Search"
+		insert    = "/vald.v1.Insert/Insert"
+		health    = "/grpc.health.v1.Health/Check"
+	)
+	rpcs := routes{
+		"/search":         search,
+		"/graphql/schema": "/graphql/schema",
+		"/health":         health,
+	}
+	authns := []auth.Authenticator{
+		auth.NewAPIKey(map[string]auth.Identity{
+			readerKey: {Subject: "reader", Groups: []string{"reader"}},
+		}),
+	}
+	policy := auth.NewPolicy(auth.Rule{
+		Groups:  []string{"reader"},
+		Methods: []string{"/vald.v1.Search/*", "/graphql/schema"},
+	})
+	tests := []test{
+		{
+			name: "reject the request without credentials",
+			args: args{
+				path: "/graphql",
+			},
+			want: want{
+				code: http.StatusUnauthorized,
+			},
+		},
+		{
+			name: "authorize the request as the RPC of its route",
+			args: args{
+				path:   "/search",
+				apiKey: readerKey,
+			},
+			want: want{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "reject the request to the path not routed to any RPC",
+			args: args{
+				path:   "/insert",
+				apiKey: readerKey,
+			},
+			want: want{
+				code: http.StatusForbidden,
+			},
+		},
+		{
+			name: "serve the health check without credentials",
+			args: args{
+				path: "/health",
+			},
+			want: want{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "authorize the request to the route serving no gRPC method by its pattern",
+			args: args{
+				path:   "/graphql/schema",
+				apiKey: readerKey,
+			},
+			want: want{
+				code: http.StatusOK,
+				operations: map[string]bool{
+					search: true,
+					insert: true,
+				},
+			},
+		},
+		{
+			name: "authorize each operation of the request to the operation path by its method",
+			args: args{
+				path:   "/graphql",
+				apiKey: readerKey,
+			},
+			want: want{
+				code: http.StatusOK,
+				operations: map[string]bool{
+					search: true,
+					insert: false,
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			operations := make(map[string]bool)
+			h := NewAuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for _, m := range []string{search, insert} {
+					operations[m] = auth.AuthorizeOperation(r.Context(), m, nil) == nil
+				}
+				w.WriteHeader(http.StatusOK)
+			}), rpcs, authns, policy, "/graphql")
+			r := httptest.NewRequest(http.MethodPost, test.args.path, nil)
+			if test.args.apiKey != "" {
+				r.Header.Set(auth.APIKeyKey, test.args.apiKey)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != test.want.code {
+				tt.Errorf("got_code: %d,\n\t\t\t\twant: %d", w.Code, test.want.code)
+			}
+			for m, want := range test.want.operations {
+				if got := operations[m]; got != want {
+					tt.Errorf("got_granted of %s: %v,\n\t\t\t\twant: %v", m, got, want)
+				}
+			}
+		})
+	}
+}
+
+// routes resolves the RPCs of the request paths.
+type routes map[string]string
+
+func (rs routes) RPC(r *http.Request) (string, bool) {
+	rpc, ok := rs[r.URL.Path]
+	return rpc, ok
+}
@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package middleware provides rest.Func Middleware
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/http/rest"
+)
+
+// CollectionHeader is the HTTP header which selects the collection a REST request operates on.
+const CollectionHeader = "Vald-Collection"
+
+type collection struct{}
+
+// NewCollection returns the middleware which passes the collection selected by the CollectionHeader
+// to the gRPC handlers as request metadata.
+func NewCollection() Wrapper {
+	return new(collection)
+}
+
+func (*collection) Wrap(h rest.Func) rest.Func {
+	return func(w http.ResponseWriter, r *http.Request) (int, error) {
+		if name := r.Header.Get(CollectionHeader); name != "" {
+			r = r.WithContext(grpc.WithIncomingCollection(r.Context(), name))
+		}
+		return h(w, r)
+	}
+}
@@ -0,0 +1,56 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package middleware provides rest.Func Middleware
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/ratelimit"
+	"github.com/vdaas/vald/internal/net/http/json"
+)
+
+// NewRateLimitHandler returns the handler which charges the requests of the REST and GraphQL APIs to the budgets of the client,
+// the same as the rate limit interceptor of the gRPC APIs. REST requests are charged as the RPC of their route resolved by rpcs
+// when their body is decoded, and each message of a streaming request is charged as the stream interceptor does.
+// The requests to operationPaths, such as the GraphQL endpoint, are charged per operation as the gRPC method it calls.
+func NewRateLimitHandler(h http.Handler, rpcs RPCResolver, l ratelimit.Limiter, operationPaths ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var method string
+		if !slices.Contains(operationPaths, r.URL.Path) {
+			method, _ = resolveRPC(rpcs, r)
+		}
+		ctx := json.WithRequestHook(l.NewHTTPContext(r, method), rateLimitHook{})
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// rateLimitHook charges the requests decoded by the json handlers to the client of the HTTP request.
+type rateLimitHook struct{}
+
+func (rateLimitHook) Request(ctx context.Context, req any) (int, error) {
+	if err := ratelimit.Charge(ctx, "", req); err != nil {
+		return http.StatusTooManyRequests, err
+	}
+	return http.StatusOK, nil
+}
+
+func (rateLimitHook) Message(ctx context.Context, _ any) error {
+	return ratelimit.ChargeMessage(ctx)
+}
@@ -0,0 +1,91 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/ratelimit"
+	"github.com/vdaas/vald/internal/net/http/json"
+)
+
+func TestNewRateLimitHandler(t *testing.T) {
+	t.Parallel()
+	type call struct {
+		path string
+		body string
+		want int
+	}
+	type test struct {
+		name  string
+		calls []call
+	}
+	tests := []test{
+		{
+			name: "charge the REST request the number of requests it carries as the bound method",
+			calls: []call{
+				{path: "/insert/multiple", body: `{"requests": [{}, {}]}`, want: http.StatusOK},
+				{path: "/insert", body: `{}`, want: http.StatusTooManyRequests},
+				{path: "/search", body: `{}`, want: http.StatusOK},
+			},
+		},
+		{
+			name: "reject the REST request larger than the burst",
+			calls: []call{
+				{path: "/insert/multiple", body: `{"requests": [{}, {}, {}]}`, want: http.StatusTooManyRequests},
+				{path: "/insert", body: `{}`, want: http.StatusOK},
+			},
+		},
+		{
+			name: "leave the operation paths to be charged per operation",
+			calls: []call{
+				{path: "/graphql", body: `{"requests": [{}, {}, {}]}`, want: http.StatusOK},
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			h := NewRateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req *payload.Insert_MultiRequest
+				code, err := json.Handler(w, r, &req, func() (any, error) {
+					return new(payload.Empty), nil
+				})
+				if err != nil {
+					w.WriteHeader(code)
+				}
+			}), routes{
+				"/insert":          "/vald.v1.Insert/Insert",
+				"/insert/multiple": "/vald.v1.Insert/MultiInsert",
+				"/search":          "/vald.v1.Search/Search",
+			}, ratelimit.New(ratelimit.WithWriteLimit(0.001, 2)), "/graphql")
+			for i, c := range test.calls {
+				r := httptest.NewRequest(http.MethodPost, c.path, strings.NewReader(c.body))
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, r)
+				if w.Code != c.want {
+					tt.Errorf("call %d got code: %d, want: %d", i, w.Code, c.want)
+				}
+			}
+		})
+	}
+}
@@ -25,9 +25,18 @@ const (
 	// ContentType represents a HTTP header name "Content-Type".
 	ContentType = "Content-Type"
 
+	// Accept represents a HTTP header name "Accept".
+	Accept = "Accept"
+
 	// ApplicationJSON represents a HTTP content type "application/json".
 	ApplicationJSON = "application/json"
 
+	// ApplicationNDJSON represents a HTTP content type "application/x-ndjson" for newline-delimited JSON streams.
+	ApplicationNDJSON = "application/x-ndjson"
+
+	// TextEventStream represents a HTTP content type "text/event-stream" for Server-Sent Events.
+	TextEventStream = "text/event-stream"
+
 	// ProblemJSON represents a HTTP content type "application/problem+json".
 	ProblemJSON = "application/problem+json"
 
@@ -34,6 +34,12 @@ type router struct {
 	routes      []Route
 }
 
+// handler serves the routes and resolves the RPC of the route matching a request.
+type handler struct {
+	*mux.Router
+	rpcs map[*mux.Route]string
+}
+
 // New returns Routed http.Handler.
 func New(opts ...Option) http.Handler {
 	r := new(router)
@@ -44,17 +50,38 @@ func New(opts ...Option) http.Handler {
 	http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost = 32
 
 	rt := mux.NewRouter().StrictSlash(true)
+	rt.MethodNotAllowedHandler = r.routing("", "", nil, nil)
+	h := &handler{
+		Router: rt,
+		rpcs:   make(map[*mux.Route]string, len(r.routes)),
+	}
 	for _, route := range r.routes {
 		for _, mw := range r.middlewares {
 			route.HandlerFunc = mw.Wrap(route.HandlerFunc)
 		}
 
-		rt.Handle(route.Pattern,
+		mr := rt.Handle(route.Pattern,
 			r.routing(route.Name, route.Pattern,
 				route.Methods, route.HandlerFunc)).Name(route.Name)
+		if len(route.Methods) != 0 {
+			// the routes sharing a pattern are told apart by their methods.
+			mr.Methods(route.Methods...)
+		}
+		h.rpcs[mr] = route.RPC
 	}
 
-	return rt
+	return h
+}
+
+// RPC returns the RPC of the route matching the request.
+// It returns false when no route matches the request or the matching route has no RPC.
+func (h *handler) RPC(r *http.Request) (rpc string, ok bool) {
+	var m mux.RouteMatch
+	if !h.Match(r, &m) || m.Route == nil {
+		return "", false
+	}
+	rpc = h.rpcs[m.Route]
+	return rpc, rpc != ""
 }
 
 // routing wraps the handler.Func and returns a new http.Handler.
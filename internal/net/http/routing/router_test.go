@@ -184,6 +184,130 @@ func TestRouting(t *testing.T) {
 	}
 }
 
+func TestHandler_RPC(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		method string
+		path   string
+	}
+	type want struct {
+		rpc string
+		ok  bool
+	}
+	type test struct {
+		name string
+		args args
+		want want
+	}
+	h := func(w http.ResponseWriter, r *http.Request) (int, error) {
+		return http.StatusOK, nil
+	}
+	hdr := New(WithRoutes(
+		Route{
+			Name:        "List",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/collection",
+			RPC:         "/vald.v1.Collection/ListCollections",
+			HandlerFunc: h,
+		},
+		Route{
+			Name:        "Create",
+			Methods:     []string{http.MethodPost},
+			Pattern:     "/collection",
+			RPC:         "/vald.v1.Collection/CreateCollection",
+			HandlerFunc: h,
+		},
+		Route{
+			Name:        "Object",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/object/{id}",
+			RPC:         "/vald.v1.Object/GetObject",
+			HandlerFunc: h,
+		},
+		Route{
+			Name:        "Unmapped",
+			Methods:     []string{http.MethodGet},
+			Pattern:     "/unmapped",
+			HandlerFunc: h,
+		},
+	)).(interface {
+		RPC(r *http.Request) (string, bool)
+	})
+	tests := []test{
+		{
+			name: "return the rpc of the route matching the method of the request",
+			args: args{
+				method: http.MethodPost,
+				path:   "/collection",
+			},
+			want: want{
+				rpc: "/vald.v1.Collection/CreateCollection",
+				ok:  true,
+			},
+		},
+		{
+			name: "return the rpc of the route matching the path variables",
+			args: args{
+				method: http.MethodGet,
+				path:   "/object/id-1",
+			},
+			want: want{
+				rpc: "/vald.v1.Object/GetObject",
+				ok:  true,
+			},
+		},
+		{
+			name: "return false when the method of the request is not routed",
+			args: args{
+				method: http.MethodDelete,
+				path:   "/collection",
+			},
+		},
+		{
+			name: "return false when the path of the request is not routed",
+			args: args{
+				method: http.MethodGet,
+				path:   "/unknown",
+			},
+		},
+		{
+			name: "return false when the route has no rpc",
+			args: args{
+				method: http.MethodGet,
+				path:   "/unmapped",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			rpc, ok := hdr.RPC(httptest.NewRequest(test.args.method, test.args.path, nil))
+			if rpc != test.want.rpc || ok != test.want.ok {
+				tt.Errorf("got: (%q, %v), want: (%q, %v)", rpc, ok, test.want.rpc, test.want.ok)
+			}
+		})
+	}
+}
+
+func TestHandler_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+	hdr := New(WithRoutes(Route{
+		Name:    "List",
+		Methods: []string{http.MethodGet},
+		Pattern: "/collection",
+		HandlerFunc: func(w http.ResponseWriter, r *http.Request) (int, error) {
+			return http.StatusOK, nil
+		},
+	}))
+	w := httptest.NewRecorder()
+	hdr.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/collection", nil))
+	if got, want := w.Code, http.StatusMethodNotAllowed; got != want {
+		t.Errorf("status code not equals. want: %v, got: %v", want, got)
+	}
+}
+
 // NOT IMPLEMENTED BELOW
 //
 // func Test_router_routing(t *testing.T) {
@@ -17,12 +17,25 @@
 // Package routing provides implementation of Go API for routing http Handler wrapped by rest.Func
 package routing
 
-import "github.com/vdaas/vald/internal/net/http/rest"
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/vdaas/vald/internal/net/http/rest"
+)
 
 // Route struct.
 type Route struct {
-	Name        string
-	Methods     []string
-	Pattern     string
+	Name    string
+	Methods []string
+	Pattern string
+	// RPC is the method the requests to the route are authorized and rate limited as.
+	// It is the gRPC full method served by the route, or the pattern of the route when it serves no gRPC method.
+	RPC         string
 	HandlerFunc rest.Func
 }
+
+// Vars returns the route variables of the current request, if any.
+func Vars(r *http.Request) map[string]string {
+	return mux.Vars(r)
+}
@@ -49,6 +49,9 @@ type (
 	// Resolver is an alias of net.Resolver.
 	Resolver = net.Resolver
 
+	// SRV is an alias of net.SRV.
+	SRV = net.SRV
+
 	// UDPConn is an alias of net.UDPConn.
 	UDPConn = net.UDPConn
 
@@ -18,6 +18,8 @@ import (
 
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/observability/attribute"
+	"github.com/vdaas/vald/internal/observability/metrics"
+	"go.opentelemetry.io/otel/sdk/metric"
 )
 
 type Option func(*exp) error
@@ -99,6 +101,15 @@ func WithTraceMaxQueueSize(size int) Option {
 	}
 }
 
+// WithTraceSamplingRate returns the option to set the ratio of the root traces to be sampled.
+// See trace.NewSampler for the meaning of zero and negative ratio.
+func WithTraceSamplingRate(ratio float64) Option {
+	return func(e *exp) error {
+		e.tSamplingRate = ratio
+		return nil
+	}
+}
+
 func WithMetricsExportInterval(s string) Option {
 	return func(e *exp) error {
 		if len(s) == 0 {
@@ -126,3 +137,24 @@ func WithMetricsExportTimeout(s string) Option {
 		return nil
 	}
 }
+
+func WithMetricsViews(views ...metrics.View) Option {
+	return func(e *exp) error {
+		if len(views) == 0 {
+			return errors.NewErrInvalidOption("metricsViews", views)
+		}
+		e.metricsViews = append(e.metricsViews, views...)
+		return nil
+	}
+}
+
+// WithMetricReaders returns the option to register additional metric readers, e.g. a pull exporter, with the MeterProvider.
+func WithMetricReaders(readers ...metric.Reader) Option {
+	return func(e *exp) error {
+		if len(readers) == 0 {
+			return errors.NewErrInvalidOption("metricReaders", readers)
+		}
+		e.metricReaders = append(e.metricReaders, readers...)
+		return nil
+	}
+}
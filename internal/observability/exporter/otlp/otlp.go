@@ -23,6 +23,7 @@ import (
 	"github.com/vdaas/vald/internal/observability/attribute"
 	"github.com/vdaas/vald/internal/observability/exporter"
 	"github.com/vdaas/vald/internal/observability/metrics"
+	vtrace "github.com/vdaas/vald/internal/observability/trace"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
@@ -55,10 +56,12 @@ type exp struct {
 	tExportTimeout      time.Duration
 	tMaxExportBatchSize int
 	tMaxQueueSize       int
+	tSamplingRate       float64
 
 	metricsExporter metric.Exporter
 	meterProvider   *metric.MeterProvider
 	metricsViews    []metrics.View
+	metricReaders   []metric.Reader
 
 	mExportInterval time.Duration
 	mExportTimeout  time.Duration
@@ -100,6 +103,7 @@ func (e *exp) initTracer(ctx context.Context) (err error) {
 			trace.WithMaxExportBatchSize(e.tMaxExportBatchSize),
 			trace.WithMaxQueueSize(e.tMaxQueueSize),
 		),
+		trace.WithSampler(vtrace.NewSampler(e.tSamplingRate)),
 		// Record information about this application in a Resource.
 		trace.WithResource(resource.NewWithAttributes(
 			semconv.SchemaURL,
@@ -117,7 +121,7 @@ func (e *exp) initMeter(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	e.meterProvider = metric.NewMeterProvider(
+	opts := []metric.Option{
 		metric.WithReader(metric.NewPeriodicReader(
 			e.metricsExporter,
 			metric.WithInterval(e.mExportInterval),
@@ -128,7 +132,11 @@ func (e *exp) initMeter(ctx context.Context) (err error) {
 			semconv.SchemaURL,
 			e.attributes...,
 		)),
-	)
+	}
+	for _, r := range e.metricReaders {
+		opts = append(opts, metric.WithReader(r))
+	}
+	e.meterProvider = metric.NewMeterProvider(opts...)
 	return nil
 }
 
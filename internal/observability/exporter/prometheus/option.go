@@ -0,0 +1,69 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package prometheus
+
+import (
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/observability/attribute"
+	"github.com/vdaas/vald/internal/observability/metrics"
+)
+
+// Option represents the functional option for the Prometheus exporter.
+type Option func(*exp) error
+
+var defaultOpts = []Option{
+	WithMeterProvider(true),
+}
+
+// WithNamespace returns the option to set the namespace prefixed to every metric name.
+func WithNamespace(ns string) Option {
+	return func(e *exp) error {
+		e.namespace = ns
+		return nil
+	}
+}
+
+// WithAttributes returns the option to set the resource attributes exposed as target_info.
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return func(e *exp) error {
+		if len(attrs) == 0 {
+			return errors.NewErrInvalidOption("attributes", attrs)
+		}
+		e.attributes = append(e.attributes, attrs...)
+		return nil
+	}
+}
+
+// WithMetricsViews returns the option to set the metrics views.
+func WithMetricsViews(views ...metrics.View) Option {
+	return func(e *exp) error {
+		if len(views) == 0 {
+			return errors.NewErrInvalidOption("metricsViews", views)
+		}
+		e.metricsViews = append(e.metricsViews, views...)
+		return nil
+	}
+}
+
+// WithMeterProvider returns the option to set whether the exporter creates and installs its own global MeterProvider.
+// Disable it when Reader is registered with the MeterProvider of another exporter.
+func WithMeterProvider(enabled bool) Option {
+	return func(e *exp) error {
+		e.ownProvider = enabled
+		return nil
+	}
+}
@@ -0,0 +1,151 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package prometheus provides a Prometheus pull exporter for the OpenTelemetry metrics.
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/observability/attribute"
+	"github.com/vdaas/vald/internal/observability/exporter"
+	"github.com/vdaas/vald/internal/observability/metrics"
+	"go.opentelemetry.io/otel"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Exporter represents the Prometheus exporter.
+// Reader returns the metric reader so that it can be shared with the MeterProvider of another exporter.
+type Exporter interface {
+	exporter.Exporter
+	Reader() metric.Reader
+}
+
+type exp struct {
+	namespace     string
+	meterProvider *metric.MeterProvider
+	metricsViews  []metrics.View
+	attributes    []attribute.KeyValue
+	ownProvider   bool
+
+	registry *prometheus.Registry
+	reader   *otelprom.Exporter
+}
+
+// registry holds the registry of the running exporter which is served by Handler.
+var registry atomic.Pointer[prometheus.Registry]
+
+// New returns the Prometheus exporter.
+func New(opts ...Option) (Exporter, error) {
+	e := new(exp)
+	for _, opt := range append(defaultOpts, opts...) {
+		if err := opt(e); err != nil {
+			oerr := errors.ErrOptionFailed(err, reflect.ValueOf(opt))
+			e := &errors.ErrCriticalOption{}
+			if errors.As(err, &e) {
+				log.Error(oerr)
+				return nil, oerr
+			}
+			log.Warn(oerr)
+		}
+	}
+
+	e.registry = prometheus.NewRegistry()
+	popts := []otelprom.Option{
+		otelprom.WithRegisterer(e.registry),
+		// keep the metric names identical to the views defined in the metrics packages.
+		otelprom.WithoutUnits(),
+		otelprom.WithoutScopeInfo(),
+	}
+	if len(e.namespace) != 0 {
+		popts = append(popts, otelprom.WithNamespace(e.namespace))
+	}
+	var err error
+	e.reader, err = otelprom.New(popts...)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reader returns the metric reader of the exporter.
+func (e *exp) Reader() metric.Reader {
+	return e.reader
+}
+
+// Start starts serving the collected metrics through Handler.
+// When the exporter owns its MeterProvider, it is set as the global MeterProvider.
+func (e *exp) Start(context.Context) error {
+	if e.ownProvider {
+		e.meterProvider = metric.NewMeterProvider(
+			metric.WithReader(e.reader),
+			metric.WithView(e.metricsViews...),
+			metric.WithResource(resource.NewWithAttributes(
+				semconv.SchemaURL,
+				e.attributes...,
+			)),
+		)
+		otel.SetMeterProvider(e.meterProvider)
+	}
+	registry.Store(e.registry)
+	return nil
+}
+
+// Stop stops serving the metrics and shuts down the reader.
+func (e *exp) Stop(ctx context.Context) error {
+	registry.CompareAndSwap(e.registry, nil)
+	if e.meterProvider != nil {
+		if err := e.meterProvider.Shutdown(ctx); err != nil {
+			log.Errorf("failed to shutdown meter provider: %v", err)
+		}
+		return nil
+	}
+	if err := e.reader.Shutdown(ctx); err != nil {
+		log.Errorf("failed to shutdown prometheus reader: %v", err)
+	}
+	return nil
+}
+
+// Handler returns the http.Handler which serves the metrics of the running exporter in the Prometheus exposition format.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reg := registry.Load()
+		if reg == nil {
+			http.Error(w, errors.ErrPrometheusExporterNotStarted.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		promhttp.HandlerFor(reg, promhttp.HandlerOpts{
+			ErrorLog:      promLogger{},
+			ErrorHandling: promhttp.ContinueOnError,
+		}).ServeHTTP(w, r)
+	})
+}
+
+type promLogger struct{}
+
+func (promLogger) Println(v ...any) {
+	log.Warn(v...)
+}
@@ -0,0 +1,161 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package prometheus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vdaas/vald/internal/observability/attribute"
+	"github.com/vdaas/vald/internal/observability/metrics"
+	"github.com/vdaas/vald/internal/strings"
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+func scrape(t *testing.T) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rec.Code, string(body)
+}
+
+func TestExporter(t *testing.T) {
+	type want struct {
+		code     int
+		contains []string
+		excludes []string
+	}
+	type test struct {
+		name   string
+		opts   []Option
+		shared bool
+		want   want
+	}
+	tests := []test{
+		{
+			name: "expose the metrics through its own MeterProvider",
+			opts: []Option{
+				WithAttributes(attribute.String("target_pod", "vald-agent-0")),
+			},
+			want: want{
+				code: http.StatusOK,
+				contains: []string{
+					`vald_test_requests_total{grpc_method="/vald.v1.Search/Search"} 3`,
+					`target_info{target_pod="vald-agent-0"} 1`,
+				},
+				excludes: []string{
+					"otel_scope_info",
+				},
+			},
+		},
+		{
+			name: "expose the metrics with the namespace",
+			opts: []Option{
+				WithNamespace("vald"),
+			},
+			want: want{
+				code: http.StatusOK,
+				contains: []string{
+					`vald_vald_test_requests_total{grpc_method="/vald.v1.Search/Search"} 3`,
+				},
+			},
+		},
+		{
+			name: "expose the metrics through the shared MeterProvider",
+			opts: []Option{
+				WithMeterProvider(false),
+			},
+			shared: true,
+			want: want{
+				code: http.StatusOK,
+				contains: []string{
+					`vald_test_requests_total{grpc_method="/vald.v1.Search/Search"} 3`,
+				},
+			},
+		},
+		{
+			name: "drop the attributes filtered by the metrics views",
+			opts: []Option{
+				WithMetricsViews(metric.NewView(
+					metric.Instrument{Name: "vald_test_requests"},
+					metric.Stream{AttributeFilter: otelattr.NewDenyKeysFilter("grpc_method")},
+				)),
+			},
+			want: want{
+				code: http.StatusOK,
+				contains: []string{
+					"vald_test_requests_total 3",
+				},
+				excludes: []string{
+					"grpc_method",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			ctx := context.Background()
+			e, err := New(test.opts...)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if code, _ := scrape(tt); code != http.StatusServiceUnavailable {
+				tt.Errorf("code before start = %d, want %d", code, http.StatusServiceUnavailable)
+			}
+			if err := e.Start(ctx); err != nil {
+				tt.Fatal(err)
+			}
+			defer e.Stop(ctx)
+
+			meter := metrics.GetMeter()
+			if test.shared {
+				mp := metric.NewMeterProvider(metric.WithReader(e.Reader()))
+				meter = mp.Meter(metrics.ValdOrg)
+			}
+			counter, err := meter.Int64Counter("vald_test_requests")
+			if err != nil {
+				tt.Fatal(err)
+			}
+			counter.Add(ctx, 3, metrics.WithAttributes(attribute.String("grpc_method", "/vald.v1.Search/Search")))
+
+			code, body := scrape(tt)
+			if code != test.want.code {
+				tt.Errorf("code = %d, want %d", code, test.want.code)
+			}
+			for _, s := range test.want.contains {
+				if !strings.Contains(body, s) {
+					tt.Errorf("body does not contain %q:\n%s", s, body)
+				}
+			}
+			for _, s := range test.want.excludes {
+				if strings.Contains(body, s) {
+					tt.Errorf("body contains %q:\n%s", s, body)
+				}
+			}
+		})
+	}
+}
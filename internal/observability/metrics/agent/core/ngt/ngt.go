@@ -42,6 +42,9 @@ const (
 	executedProactiveGCTotalMetricsName        = "agent_core_ngt_executed_proactive_gc_total"
 	executedProactiveGCTotalMetricsDescription = "The cumulative count of proactive GC execution"
 
+	expiredTotalMetricsName        = "agent_core_ngt_expired_total"
+	expiredTotalMetricsDescription = "The cumulative count of objects deleted by the TTL expiry"
+
 	isIndexingMetricsName        = "agent_core_ngt_is_indexing"
 	isIndexingMetricsDescription = "Currently indexing or no"
 
@@ -146,6 +149,24 @@ const (
 
 	indegreeHistogramMetricsName        = "agent_core_ngt_indegree_histogram"
 	indegreeHistogramMetricsDescription = "Indegree histogram"
+
+	realtimeCommitTotalMetricsName        = "agent_core_ngt_realtime_commit_total"
+	realtimeCommitTotalMetricsDescription = "The cumulative count of succeeded real-time index commits"
+
+	realtimeCommitFailureTotalMetricsName        = "agent_core_ngt_realtime_commit_failure_total"
+	realtimeCommitFailureTotalMetricsDescription = "The cumulative count of failed real-time index commits"
+
+	realtimeCommittedVectorsTotalMetricsName        = "agent_core_ngt_realtime_committed_vectors_total"
+	realtimeCommittedVectorsTotalMetricsDescription = "The cumulative count of inserts and deletes indexed by the real-time index commits"
+
+	realtimeLastBatchSizeMetricsName        = "agent_core_ngt_realtime_last_batch_size"
+	realtimeLastBatchSizeMetricsDescription = "Number of inserts and deletes indexed by the last real-time index commit"
+
+	realtimeCommitSecondsTotalMetricsName        = "agent_core_ngt_realtime_commit_seconds_total"
+	realtimeCommitSecondsTotalMetricsDescription = "The cumulative duration of the real-time index commits"
+
+	realtimeIndexLatencySecondsTotalMetricsName        = "agent_core_ngt_realtime_index_latency_seconds_total"
+	realtimeIndexLatencySecondsTotalMetricsDescription = "The cumulative duration from the write timestamps to the end of the real-time index commits"
 )
 
 type ngtMetrics struct {
@@ -214,6 +235,15 @@ func (n *ngtMetrics) View() (mv []metrics.View, err error) {
 				Aggregation: view.AggregationLastValue{},
 			},
 		),
+		view.NewView(
+			view.Instrument{
+				Name:        expiredTotalMetricsName,
+				Description: expiredTotalMetricsDescription,
+			},
+			view.Stream{
+				Aggregation: view.AggregationLastValue{},
+			},
+		),
 		view.NewView(
 			view.Instrument{
 				Name:        isIndexingMetricsName,
@@ -534,6 +564,29 @@ func (n *ngtMetrics) View() (mv []metrics.View, err error) {
 				},
 			))
 	}
+
+	if _, ok := n.ngt.RealtimeIndexStats(); ok {
+		for _, v := range []struct {
+			name, description string
+		}{
+			{realtimeCommitTotalMetricsName, realtimeCommitTotalMetricsDescription},
+			{realtimeCommitFailureTotalMetricsName, realtimeCommitFailureTotalMetricsDescription},
+			{realtimeCommittedVectorsTotalMetricsName, realtimeCommittedVectorsTotalMetricsDescription},
+			{realtimeLastBatchSizeMetricsName, realtimeLastBatchSizeMetricsDescription},
+			{realtimeCommitSecondsTotalMetricsName, realtimeCommitSecondsTotalMetricsDescription},
+			{realtimeIndexLatencySecondsTotalMetricsName, realtimeIndexLatencySecondsTotalMetricsDescription},
+		} {
+			mv = append(mv, view.NewView(
+				view.Instrument{
+					Name:        v.name,
+					Description: v.description,
+				},
+				view.Stream{
+					Aggregation: view.AggregationLastValue{},
+				},
+			))
+		}
+	}
 	return mv, nil
 }
 
@@ -544,6 +597,7 @@ func (n *ngtMetrics) Register(m metrics.Meter) (err error) {
 		deleteVQueueCount,
 		completedCreateIndexTotal,
 		executedProactiveGCTotal,
+		expiredTotal,
 		isIndexing,
 		isSaving,
 		brokenIndexCount metrics.Int64ObservableGauge
@@ -602,6 +656,15 @@ func (n *ngtMetrics) Register(m metrics.Meter) (err error) {
 		return err
 	}
 
+	expiredTotal, err = m.Int64ObservableGauge(
+		expiredTotalMetricsName,
+		metrics.WithDescription(expiredTotalMetricsDescription),
+		metrics.WithUnit(metrics.Dimensionless),
+	)
+	if err != nil {
+		return err
+	}
+
 	isIndexing, err = m.Int64ObservableGauge(
 		isIndexingMetricsName,
 		metrics.WithDescription(isIndexingMetricsDescription),
@@ -636,6 +699,7 @@ func (n *ngtMetrics) Register(m metrics.Meter) (err error) {
 		deleteVQueueCount,
 		completedCreateIndexTotal,
 		executedProactiveGCTotal,
+		expiredTotal,
 		isIndexing,
 		isSaving,
 		brokenIndexCount,
@@ -1001,6 +1065,55 @@ func (n *ngtMetrics) Register(m metrics.Meter) (err error) {
 		)
 	}
 
+	var (
+		realtimeCommitTotal,
+		realtimeCommitFailureTotal,
+		realtimeCommittedVectorsTotal,
+		realtimeLastBatchSize metrics.Int64ObservableGauge
+
+		realtimeCommitSecondsTotal,
+		realtimeIndexLatencySecondsTotal metrics.Float64ObservableGauge
+	)
+	_, realtime := n.ngt.RealtimeIndexStats()
+	if realtime {
+		for _, g := range []struct {
+			gauge             *metrics.Int64ObservableGauge
+			name, description string
+		}{
+			{&realtimeCommitTotal, realtimeCommitTotalMetricsName, realtimeCommitTotalMetricsDescription},
+			{&realtimeCommitFailureTotal, realtimeCommitFailureTotalMetricsName, realtimeCommitFailureTotalMetricsDescription},
+			{&realtimeCommittedVectorsTotal, realtimeCommittedVectorsTotalMetricsName, realtimeCommittedVectorsTotalMetricsDescription},
+			{&realtimeLastBatchSize, realtimeLastBatchSizeMetricsName, realtimeLastBatchSizeMetricsDescription},
+		} {
+			*g.gauge, err = m.Int64ObservableGauge(
+				g.name,
+				metrics.WithDescription(g.description),
+				metrics.WithUnit(metrics.Dimensionless),
+			)
+			if err != nil {
+				return err
+			}
+			instruments = append(instruments, *g.gauge)
+		}
+		for _, g := range []struct {
+			gauge             *metrics.Float64ObservableGauge
+			name, description string
+		}{
+			{&realtimeCommitSecondsTotal, realtimeCommitSecondsTotalMetricsName, realtimeCommitSecondsTotalMetricsDescription},
+			{&realtimeIndexLatencySecondsTotal, realtimeIndexLatencySecondsTotalMetricsName, realtimeIndexLatencySecondsTotalMetricsDescription},
+		} {
+			*g.gauge, err = m.Float64ObservableGauge(
+				g.name,
+				metrics.WithDescription(g.description),
+				metrics.WithUnit("s"),
+			)
+			if err != nil {
+				return err
+			}
+			instruments = append(instruments, *g.gauge)
+		}
+	}
+
 	_, err = m.RegisterCallback(
 		func(_ context.Context, o api.Observer) error {
 			var indexing int64
@@ -1017,10 +1130,20 @@ func (n *ngtMetrics) Register(m metrics.Meter) (err error) {
 			o.ObserveInt64(deleteVQueueCount, int64(int64(n.ngt.DeleteVQueueBufferLen())))
 			o.ObserveInt64(completedCreateIndexTotal, int64(n.ngt.NumberOfCreateIndexExecution()))
 			o.ObserveInt64(executedProactiveGCTotal, int64(n.ngt.NumberOfProactiveGCExecution()))
+			o.ObserveInt64(expiredTotal, int64(n.ngt.NumberOfExpiredObjects()))
 			o.ObserveInt64(isIndexing, int64(indexing))
 			o.ObserveInt64(isSaving, int64(saving))
 			o.ObserveInt64(brokenIndexCount, int64(n.ngt.BrokenIndexCount()))
 
+			if stats, ok := n.ngt.RealtimeIndexStats(); realtime && ok {
+				o.ObserveInt64(realtimeCommitTotal, int64(stats.Commits))
+				o.ObserveInt64(realtimeCommitFailureTotal, int64(stats.Failures))
+				o.ObserveInt64(realtimeCommittedVectorsTotal, int64(stats.Vectors))
+				o.ObserveInt64(realtimeLastBatchSize, int64(stats.LastBatchSize))
+				o.ObserveFloat64(realtimeCommitSecondsTotal, stats.CommitDuration.Seconds())
+				o.ObserveFloat64(realtimeIndexLatencySecondsTotal, stats.IndexLatency.Seconds())
+			}
+
 			if n.ngt.IsStatisticsEnabled() {
 				stats, err := n.ngt.IndexStatistics()
 				if err == nil {
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package rank
+
+import (
+	"context"
+
+	"github.com/vdaas/vald/internal/observability/attribute"
+	"github.com/vdaas/vald/internal/observability/metrics"
+	"github.com/vdaas/vald/pkg/discoverer/k8s/service"
+	api "go.opentelemetry.io/otel/metric"
+	view "go.opentelemetry.io/otel/sdk/metric"
+)
+
+const (
+	positionMetricsName        = "discoverer_agent_rank_position"
+	positionMetricsDescription = "Position of the agent in the discoverer ranking, 0 is the most preferred"
+
+	scoreMetricsName        = "discoverer_agent_rank_score"
+	scoreMetricsDescription = "Score of the agent given by the ranking strategy, lower is preferred"
+
+	namespaceKey = "namespace"
+	nameKey      = "name"
+	strategyKey  = "strategy"
+)
+
+type rankMetrics struct {
+	d service.Discoverer
+}
+
+func New(d service.Discoverer) metrics.Metric {
+	return &rankMetrics{
+		d: d,
+	}
+}
+
+func (*rankMetrics) View() ([]metrics.View, error) {
+	return []metrics.View{
+		view.NewView(
+			view.Instrument{
+				Name:        positionMetricsName,
+				Description: positionMetricsDescription,
+			},
+			view.Stream{
+				Aggregation: view.AggregationLastValue{},
+			},
+		),
+		view.NewView(
+			view.Instrument{
+				Name:        scoreMetricsName,
+				Description: scoreMetricsDescription,
+			},
+			view.Stream{
+				Aggregation: view.AggregationLastValue{},
+			},
+		),
+	}, nil
+}
+
+func (rm *rankMetrics) Register(m metrics.Meter) error {
+	position, err := m.Int64ObservableGauge(
+		positionMetricsName,
+		metrics.WithDescription(positionMetricsDescription),
+		metrics.WithUnit(metrics.Dimensionless),
+	)
+	if err != nil {
+		return err
+	}
+	score, err := m.Float64ObservableGauge(
+		scoreMetricsName,
+		metrics.WithDescription(scoreMetricsDescription),
+		metrics.WithUnit(metrics.Dimensionless),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.RegisterCallback(
+		func(_ context.Context, o api.Observer) error {
+			rm.d.RangeRanks(func(r service.Rank) bool {
+				attrs := api.WithAttributes(
+					attribute.String(namespaceKey, r.Namespace),
+					attribute.String(nameKey, r.Name),
+					attribute.String(strategyKey, r.Strategy),
+				)
+				o.ObserveInt64(position, int64(r.Position), attrs)
+				o.ObserveFloat64(score, r.Score, attrs)
+				return true
+			})
+			return nil
+		},
+		position, score,
+	)
+	return err
+}
@@ -16,6 +16,7 @@ package grpc
 import (
 	"context"
 
+	"github.com/vdaas/vald/internal/net/grpc"
 	"github.com/vdaas/vald/internal/net/grpc/pool"
 	"github.com/vdaas/vald/internal/observability/attribute"
 	"github.com/vdaas/vald/internal/observability/metrics"
@@ -32,15 +33,33 @@ const (
 
 	poolConnMetricsName        = "server_pool_conn"
 	poolConnMetricsDescription = "Count of healthy pool connections by target address"
+
+	compressionBytesMetricsName        = "grpc_compression_bytes_total"
+	compressionBytesMetricsDescription = "Total size of the messages passed through the gRPC compressors, by compressor, direction and whether the size is before or after compression"
+
+	compressionRatioMetricsName        = "grpc_compression_ratio"
+	compressionRatioMetricsDescription = "Compressed size divided by uncompressed size of all messages since the process started, by compressor and direction"
+
+	compressionCPUMetricsName        = "grpc_compression_cpu_seconds_total"
+	compressionCPUMetricsDescription = "Total time spent compressing and decompressing gRPC messages, by compressor and direction"
+
+	sizeUncompressed = "uncompressed"
+	sizeCompressed   = "compressed"
 )
 
 type grpcServerMetrics struct {
 	poolTargetAddrKey string
+	compressorKey     string
+	directionKey      string
+	sizeKey           string
 }
 
 func New() metrics.Metric {
 	return &grpcServerMetrics{
-		"target_address",
+		poolTargetAddrKey: "target_address",
+		compressorKey:     "compressor",
+		directionKey:      "direction",
+		sizeKey:           "size",
 	}
 }
 
@@ -75,6 +94,33 @@ func (*grpcServerMetrics) View() ([]metrics.View, error) {
 				Aggregation: view.AggregationSum{},
 			},
 		),
+		view.NewView(
+			view.Instrument{
+				Name:        compressionBytesMetricsName,
+				Description: compressionBytesMetricsDescription,
+			},
+			view.Stream{
+				Aggregation: view.AggregationSum{},
+			},
+		),
+		view.NewView(
+			view.Instrument{
+				Name:        compressionRatioMetricsName,
+				Description: compressionRatioMetricsDescription,
+			},
+			view.Stream{
+				Aggregation: view.AggregationLastValue{},
+			},
+		),
+		view.NewView(
+			view.Instrument{
+				Name:        compressionCPUMetricsName,
+				Description: compressionCPUMetricsDescription,
+			},
+			view.Stream{
+				Aggregation: view.AggregationSum{},
+			},
+		),
 	}, nil
 }
 
@@ -100,5 +146,56 @@ func (gm *grpcServerMetrics) Register(m metrics.Meter) error {
 			return nil
 		}, healthyConn,
 	)
+	if err != nil {
+		return err
+	}
+	return gm.registerCompression(m)
+}
+
+func (gm *grpcServerMetrics) registerCompression(m metrics.Meter) error {
+	size, err := m.Int64ObservableCounter(
+		compressionBytesMetricsName,
+		metrics.WithDescription(compressionBytesMetricsDescription),
+		metrics.WithUnit(metrics.Bytes),
+	)
+	if err != nil {
+		return err
+	}
+	ratio, err := m.Float64ObservableGauge(
+		compressionRatioMetricsName,
+		metrics.WithDescription(compressionRatioMetricsDescription),
+		metrics.WithUnit(metrics.Dimensionless),
+	)
+	if err != nil {
+		return err
+	}
+	cpu, err := m.Float64ObservableCounter(
+		compressionCPUMetricsName,
+		metrics.WithDescription(compressionCPUMetricsDescription),
+		metrics.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = m.RegisterCallback(
+		func(ctx context.Context, o api.Observer) error {
+			for key, stats := range grpc.CompressionMetrics(ctx) {
+				attrs := []attribute.KeyValue{
+					attribute.String(gm.compressorKey, key.Compressor),
+					attribute.String(gm.directionKey, key.Direction),
+				}
+				o.ObserveInt64(size, int64(stats.Uncompressed),
+					api.WithAttributes(append(attrs, attribute.String(gm.sizeKey, sizeUncompressed))...))
+				o.ObserveInt64(size, int64(stats.Compressed),
+					api.WithAttributes(append(attrs, attribute.String(gm.sizeKey, sizeCompressed))...))
+				if stats.Uncompressed != 0 {
+					o.ObserveFloat64(ratio, float64(stats.Compressed)/float64(stats.Uncompressed),
+						api.WithAttributes(attrs...))
+				}
+				o.ObserveFloat64(cpu, stats.Duration.Seconds(), api.WithAttributes(attrs...))
+			}
+			return nil
+		}, size, ratio, cpu,
+	)
 	return err
 }
@@ -0,0 +1,85 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/ratelimit"
+	"github.com/vdaas/vald/internal/observability/attribute"
+	"github.com/vdaas/vald/internal/observability/metrics"
+	api "go.opentelemetry.io/otel/metric"
+	view "go.opentelemetry.io/otel/sdk/metric"
+)
+
+const (
+	metricsName        = "server_throttled_total"
+	metricsDescription = "Count of requests and stream messages rejected by the rate limiter, by method, budget and unit"
+)
+
+type throttleMetrics struct {
+	methodKey string
+	budgetKey string
+	unitKey   string
+}
+
+func New() metrics.Metric {
+	return &throttleMetrics{
+		methodKey: "grpc_method",
+		budgetKey: "budget",
+		unitKey:   "unit",
+	}
+}
+
+func (*throttleMetrics) View() ([]metrics.View, error) {
+	return []metrics.View{
+		view.NewView(
+			view.Instrument{
+				Name:        metricsName,
+				Description: metricsDescription,
+			},
+			view.Stream{
+				Aggregation: view.AggregationSum{},
+			},
+		),
+	}, nil
+}
+
+func (tm *throttleMetrics) Register(m metrics.Meter) error {
+	throttled, err := m.Int64ObservableCounter(
+		metricsName,
+		metrics.WithDescription(metricsDescription),
+		metrics.WithUnit(metrics.Dimensionless),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.RegisterCallback(
+		func(ctx context.Context, o api.Observer) error {
+			for key, cnt := range ratelimit.Metrics(ctx) {
+				o.ObserveInt64(throttled, cnt,
+					api.WithAttributes(
+						attribute.String(tm.methodKey, key.Method),
+						attribute.String(tm.budgetKey, key.Budget),
+						attribute.String(tm.unitKey, key.Unit)))
+			}
+			return nil
+		}, throttled,
+	)
+	return err
+}
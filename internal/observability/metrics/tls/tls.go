@@ -0,0 +1,113 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tls
+
+import (
+	"context"
+
+	"github.com/vdaas/vald/internal/observability/attribute"
+	"github.com/vdaas/vald/internal/observability/metrics"
+	"github.com/vdaas/vald/internal/tls"
+	api "go.opentelemetry.io/otel/metric"
+	view "go.opentelemetry.io/otel/sdk/metric"
+)
+
+const (
+	reloadMetricsName        = "tls_certificate_reload_total"
+	reloadMetricsDescription = "Count of TLS credential reloads triggered by modified certificate files, by certificate path and result"
+
+	expiryMetricsName        = "tls_certificate_expiry_timestamp_seconds"
+	expiryMetricsDescription = "Expiry of the TLS certificate currently in use as a unix timestamp, by certificate path"
+
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+type tlsMetrics struct {
+	pathKey   string
+	resultKey string
+}
+
+func New() metrics.Metric {
+	return &tlsMetrics{
+		pathKey:   "cert_path",
+		resultKey: "result",
+	}
+}
+
+func (*tlsMetrics) View() ([]metrics.View, error) {
+	return []metrics.View{
+		view.NewView(
+			view.Instrument{
+				Name:        reloadMetricsName,
+				Description: reloadMetricsDescription,
+			},
+			view.Stream{
+				Aggregation: view.AggregationSum{},
+			},
+		),
+		view.NewView(
+			view.Instrument{
+				Name:        expiryMetricsName,
+				Description: expiryMetricsDescription,
+			},
+			view.Stream{
+				Aggregation: view.AggregationLastValue{},
+			},
+		),
+	}, nil
+}
+
+func (tm *tlsMetrics) Register(m metrics.Meter) error {
+	reload, err := m.Int64ObservableCounter(
+		reloadMetricsName,
+		metrics.WithDescription(reloadMetricsDescription),
+		metrics.WithUnit(metrics.Dimensionless),
+	)
+	if err != nil {
+		return err
+	}
+	expiry, err := m.Int64ObservableGauge(
+		expiryMetricsName,
+		metrics.WithDescription(expiryMetricsDescription),
+		metrics.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.RegisterCallback(
+		func(ctx context.Context, o api.Observer) error {
+			for path, stats := range tls.Metrics(ctx) {
+				o.ObserveInt64(reload, int64(stats.Success),
+					api.WithAttributes(
+						attribute.String(tm.pathKey, path),
+						attribute.String(tm.resultKey, resultSuccess)))
+				o.ObserveInt64(reload, int64(stats.Failure),
+					api.WithAttributes(
+						attribute.String(tm.pathKey, path),
+						attribute.String(tm.resultKey, resultFailure)))
+				if !stats.NotAfter.IsZero() {
+					o.ObserveInt64(expiry, stats.NotAfter.Unix(),
+						api.WithAttributes(attribute.String(tm.pathKey, path)))
+				}
+			}
+			return nil
+		}, reload, expiry,
+	)
+	return err
+}
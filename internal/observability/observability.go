@@ -20,13 +20,16 @@ import (
 	"github.com/vdaas/vald/internal/config"
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/observability/attribute"
 	"github.com/vdaas/vald/internal/observability/exporter"
 	"github.com/vdaas/vald/internal/observability/exporter/otlp"
+	"github.com/vdaas/vald/internal/observability/exporter/prometheus"
 	"github.com/vdaas/vald/internal/observability/metrics"
 	"github.com/vdaas/vald/internal/observability/metrics/grpc"
 	"github.com/vdaas/vald/internal/observability/metrics/mem"
 	"github.com/vdaas/vald/internal/observability/metrics/runtime/cgo"
 	"github.com/vdaas/vald/internal/observability/metrics/runtime/goroutine"
+	tlsmetrics "github.com/vdaas/vald/internal/observability/metrics/tls"
 	"github.com/vdaas/vald/internal/observability/metrics/version"
 	"github.com/vdaas/vald/internal/observability/trace"
 	"github.com/vdaas/vald/internal/sync/errgroup"
@@ -50,7 +53,7 @@ func NewWithConfig(cfg *config.Observability, ms ...metrics.Metric) (Observabili
 	exps := make([]exporter.Exporter, 0)
 
 	if cfg.Metrics != nil {
-		ms = append(ms, grpc.New())
+		ms = append(ms, grpc.New(), tlsmetrics.New())
 		if cfg.Metrics.EnableCGO {
 			ms = append(ms, cgo.New())
 		}
@@ -73,28 +76,77 @@ func NewWithConfig(cfg *config.Observability, ms ...metrics.Metric) (Observabili
 		opts = append(opts, WithTracer(tr))
 	}
 
-	if cfg.OTLP != nil {
-		e, err := otlp.New(
+	views := make([]metrics.View, 0, len(ms))
+	for _, m := range ms {
+		vs, err := m.View()
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, vs...)
+	}
+
+	var attrs []attribute.KeyValue
+	if cfg.OTLP != nil && cfg.OTLP.Attribute != nil {
+		attrs = []attribute.KeyValue{
+			otlp.ServiceNameKey.String(cfg.OTLP.Attribute.ServiceName),
+			otlp.NamespaceKey.String(cfg.OTLP.Attribute.Namespace),
+			otlp.TargetPodNameKey.String(cfg.OTLP.Attribute.PodName),
+			otlp.TargetNodeNameKey.String(cfg.OTLP.Attribute.NodeName),
+			otlp.AppNameKey.String(cfg.OTLP.Attribute.ServiceName),
+		}
+	}
+
+	promEnabled := cfg.Prometheus != nil && cfg.Prometheus.Enabled
+	// the OTLP exporter is optional when the metrics are scraped by Prometheus.
+	otlpEnabled := cfg.OTLP != nil && (!promEnabled || len(cfg.OTLP.CollectorEndpoint) != 0)
+
+	var pe prometheus.Exporter
+	if promEnabled {
+		// the Prometheus reader shares the MeterProvider of the OTLP exporter when both are enabled.
+		popts := []prometheus.Option{
+			prometheus.WithNamespace(cfg.Prometheus.Namespace),
+			prometheus.WithMeterProvider(!otlpEnabled),
+		}
+		if len(views) != 0 {
+			popts = append(popts, prometheus.WithMetricsViews(views...))
+		}
+		if len(attrs) != 0 {
+			popts = append(popts, prometheus.WithAttributes(attrs...))
+		}
+		e, err := prometheus.New(popts...)
+		if err != nil {
+			return nil, err
+		}
+		pe = e
+	}
+
+	if otlpEnabled {
+		oopts := []otlp.Option{
 			otlp.WithCollectorEndpoint(cfg.OTLP.CollectorEndpoint),
 			otlp.WithTraceBatchTimeout(cfg.OTLP.TraceBatchTimeout),
 			otlp.WithTraceExportTimeout(cfg.OTLP.TraceExportTimeout),
 			otlp.WithTraceMaxExportBatchSize(cfg.OTLP.TraceMaxExportBatchSize),
 			otlp.WithTraceMaxQueueSize(cfg.OTLP.TraceMaxQueueSize),
+			otlp.WithTraceSamplingRate(cfg.Trace.SamplingRate),
 			otlp.WithMetricsExportInterval(cfg.OTLP.MetricsExportInterval),
 			otlp.WithMetricsExportTimeout(cfg.OTLP.MetricsExportTimeout),
-			otlp.WithAttributes(
-				otlp.ServiceNameKey.String(cfg.OTLP.Attribute.ServiceName),
-				otlp.NamespaceKey.String(cfg.OTLP.Attribute.Namespace),
-				otlp.TargetPodNameKey.String(cfg.OTLP.Attribute.PodName),
-				otlp.TargetNodeNameKey.String(cfg.OTLP.Attribute.NodeName),
-				otlp.AppNameKey.String(cfg.OTLP.Attribute.ServiceName),
-			),
-		)
+			otlp.WithAttributes(attrs...),
+		}
+		if len(views) != 0 {
+			oopts = append(oopts, otlp.WithMetricsViews(views...))
+		}
+		if pe != nil {
+			oopts = append(oopts, otlp.WithMetricReaders(pe.Reader()))
+		}
+		e, err := otlp.New(oopts...)
 		if err != nil {
 			return nil, err
 		}
 		exps = append(exps, e)
 	}
+	if pe != nil {
+		exps = append(exps, pe)
+	}
 
 	opts = append(
 		opts,
@@ -0,0 +1,123 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package trace provides trace functions.
+package trace
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/vdaas/vald/internal/sync"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDAttributeKey is the span attribute key of the request ID which forced the sampling.
+const RequestIDAttributeKey = "vald.request_id"
+
+type requestIDKey struct{}
+
+var (
+	rmu sync.RWMutex
+	// requests holds the request IDs to be sampled and their expiry, the zero expiry never expires.
+	requests = make(map[string]time.Time)
+)
+
+// EnableRequestSampling makes the spans of the request carrying the ID sampled regardless of the sampling rate,
+// until the duration elapses when it is positive. It returns the expiry, or the zero time when it never expires.
+func EnableRequestSampling(id string, dur time.Duration) (expire time.Time) {
+	if dur > 0 {
+		expire = time.Now().Add(dur)
+	}
+	rmu.Lock()
+	requests[id] = expire
+	rmu.Unlock()
+	return expire
+}
+
+// DisableRequestSampling stops sampling the request ID and reports whether it was enabled.
+func DisableRequestSampling(id string) bool {
+	rmu.Lock()
+	defer rmu.Unlock()
+	_, ok := requests[id]
+	delete(requests, id)
+	return ok
+}
+
+// SampledRequests returns the request IDs sampled regardless of the sampling rate and their expiry.
+func SampledRequests() map[string]time.Time {
+	now := time.Now()
+	rmu.Lock()
+	defer rmu.Unlock()
+	maps.DeleteFunc(requests, func(_ string, expire time.Time) bool {
+		return !expire.IsZero() && expire.Before(now)
+	})
+	return maps.Clone(requests)
+}
+
+// WithRequestID returns the context marking the spans started from it to be sampled when the sampling is enabled for the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	rmu.RLock()
+	expire, ok := requests[id]
+	rmu.RUnlock()
+	if !ok || (!expire.IsZero() && expire.Before(time.Now())) {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+type sampler struct {
+	sdktrace.Sampler
+}
+
+// NewSampler returns the sampler which samples the given ratio of the root spans and follows the parent sampling decision,
+// except the spans of the requests enabled by EnableRequestSampling which are always sampled.
+// The ratio of zero or more than one samples every span, and the negative ratio samples only the enabled requests.
+func NewSampler(ratio float64) sdktrace.Sampler {
+	var root sdktrace.Sampler
+	switch {
+	case ratio < 0:
+		root = sdktrace.NeverSample()
+	case ratio == 0, ratio >= 1:
+		root = sdktrace.AlwaysSample()
+	default:
+		root = sdktrace.TraceIDRatioBased(ratio)
+	}
+	return &sampler{
+		Sampler: sdktrace.ParentBased(root),
+	}
+}
+
+func (s *sampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if id, ok := p.ParentContext.Value(requestIDKey{}).(string); ok {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Attributes: []attribute.KeyValue{attribute.String(RequestIDAttributeKey, id)},
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+	return s.Sampler.ShouldSample(p)
+}
+
+func (s *sampler) Description() string {
+	return "RequestIDSampler{" + s.Sampler.Description() + "}"
+}
@@ -0,0 +1,126 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package trace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/internal/errors"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestNewSampler(t *testing.T) {
+	type args struct {
+		ratio float64
+		id    string
+	}
+	type want struct {
+		decision sdktrace.SamplingDecision
+	}
+	type test struct {
+		name       string
+		args       args
+		want       want
+		beforeFunc func(*testing.T)
+	}
+	tests := []test{
+		{
+			name: "samples every root span when the ratio is zero",
+			want: want{
+				decision: sdktrace.RecordAndSample,
+			},
+		},
+		{
+			name: "drops the root span when the ratio is negative",
+			args: args{
+				ratio: -1,
+				id:    "req-1",
+			},
+			want: want{
+				decision: sdktrace.Drop,
+			},
+		},
+		{
+			name: "samples the span of the enabled request when the ratio is negative",
+			args: args{
+				ratio: -1,
+				id:    "req-1",
+			},
+			beforeFunc: func(t *testing.T) {
+				t.Helper()
+				EnableRequestSampling("req-1", time.Minute)
+				t.Cleanup(func() { DisableRequestSampling("req-1") })
+			},
+			want: want{
+				decision: sdktrace.RecordAndSample,
+			},
+		},
+		{
+			name: "drops the span of the request whose sampling expired",
+			args: args{
+				ratio: -1,
+				id:    "req-1",
+			},
+			beforeFunc: func(t *testing.T) {
+				t.Helper()
+				EnableRequestSampling("req-1", time.Nanosecond)
+				t.Cleanup(func() { DisableRequestSampling("req-1") })
+				time.Sleep(time.Millisecond)
+			},
+			want: want{
+				decision: sdktrace.Drop,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			if test.beforeFunc != nil {
+				test.beforeFunc(tt)
+			}
+			ctx := WithRequestID(context.Background(), test.args.id)
+			res := NewSampler(test.args.ratio).ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: ctx,
+				Name:          "test",
+			})
+			if res.Decision != test.want.decision {
+				tt.Errorf("error = %v", errors.Errorf("got: %v, want: %v", res.Decision, test.want.decision))
+			}
+		})
+	}
+}
+
+func TestSampledRequests(t *testing.T) {
+	EnableRequestSampling("req-1", 0)
+	EnableRequestSampling("req-2", time.Nanosecond)
+	defer DisableRequestSampling("req-1")
+	time.Sleep(time.Millisecond)
+
+	got := SampledRequests()
+	if expire, ok := got["req-1"]; !ok || !expire.IsZero() {
+		t.Errorf("req-1 is not sampled without expiry: %v", got)
+	}
+	if _, ok := got["req-2"]; ok {
+		t.Errorf("expired req-2 is still sampled: %v", got)
+	}
+	if DisableRequestSampling("req-2") {
+		t.Error("disabled the expired request")
+	}
+}
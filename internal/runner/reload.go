@@ -0,0 +1,185 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package runner
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"syscall"
+
+	"github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file/watch"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+)
+
+// Reloader is implemented by the daemons which apply a part of the configuration without restart.
+type Reloader interface {
+	// Reloadable returns the patterns of the configuration fields which Reload applies, see config.CheckReloadable.
+	Reloadable() []string
+	// Reload applies the reloadable fields of the new configuration.
+	// It must validate them before applying anything and leave the daemon untouched on error.
+	Reload(ctx context.Context, cfg any) error
+}
+
+// globalReloadable is the GlobalConfig fields applied by the runner itself.
+var globalReloadable = []string{
+	"logging.level",
+	"logging.format",
+}
+
+// reloader runs the daemon and reloads the configuration when the file changes or SIGHUP is received.
+type reloader struct {
+	Runner
+	path string
+	load func(string) (any, *config.GlobalConfig, error)
+	w    watch.Watcher
+	mu   sync.Mutex
+	cfg  any
+}
+
+func newReloader(
+	daemon Runner, path string, load func(string) (any, *config.GlobalConfig, error), cfg any,
+) *reloader {
+	return &reloader{
+		Runner: daemon,
+		path:   path,
+		load:   load,
+		cfg:    cfg,
+	}
+}
+
+// Reloadable returns the reloadable fields of the runner and the daemon.
+func (r *reloader) Reloadable() []string {
+	if rl, ok := r.Runner.(Reloader); ok {
+		return slices.Concat(globalReloadable, rl.Reloadable())
+	}
+	return globalReloadable
+}
+
+// Reload applies the configuration to the daemon when it supports reloading.
+func (r *reloader) Reload(ctx context.Context, cfg any) error {
+	if rl, ok := r.Runner.(Reloader); ok {
+		return rl.Reload(ctx, cfg)
+	}
+	return nil
+}
+
+func (r *reloader) Start(ctx context.Context) (<-chan error, error) {
+	ech, err := r.Runner.Start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// watch the directory because the ConfigMap volume replaces the file through a symlink swap.
+	r.w, err = watch.New(
+		watch.WithErrGroup(errgroup.Get()),
+		watch.WithDirs(filepath.Dir(r.path)),
+		watch.WithOnChange(func(ctx context.Context, _ string) error {
+			r.reload(ctx, "file change")
+			return nil
+		}),
+	)
+	if err != nil {
+		log.Warnf("configuration file watcher is disabled, reload by SIGHUP only: %v", err)
+	}
+	var wech <-chan error
+	if r.w != nil {
+		wech, err = r.w.Start(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	errgroup.Go(safety.RecoverFunc(func() error {
+		defer signal.Stop(sig)
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-sig:
+				r.reload(ctx, "SIGHUP")
+			case err, ok := <-wech:
+				if !ok {
+					wech = nil
+				} else if err != nil {
+					log.Warnf("configuration file watcher error: %v", err)
+				}
+			}
+		}
+	}))
+	return ech, nil
+}
+
+func (r *reloader) Stop(ctx context.Context) error {
+	if r.w != nil {
+		if err := r.w.Stop(ctx); err != nil {
+			log.Warnf("failed to stop configuration file watcher: %v", err)
+		}
+	}
+	return r.Runner.Stop(ctx)
+}
+
+// reload loads the configuration file and applies it when every changed field is reloadable.
+// The running configuration is kept and the reason is logged when the new one is rejected.
+func (r *reloader) reload(ctx context.Context, trigger string) {
+	if err := r.apply(ctx); err != nil {
+		log.Errorf("configuration reload triggered by %s is rejected, keeping the running configuration: %v", trigger, err)
+	}
+}
+
+func (r *reloader) apply(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg, ccfg, err := r.load(r.path)
+	if err != nil {
+		return err
+	}
+	changes, err := config.Changes(r.cfg, cfg)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if err = config.CheckReloadable(changes, r.Reloadable()...); err != nil {
+		return err
+	}
+	if err = r.Reload(ctx, cfg); err != nil {
+		return errors.Wrap(err, "failed to apply the configuration")
+	}
+	if ccfg != nil && ccfg.Logging != nil && slices.ContainsFunc(changes, func(path string) bool {
+		return strings.HasPrefix(path, "logging.")
+	}) {
+		lcfg := ccfg.Logging
+		log.Reload(
+			log.WithLoggerType(lcfg.Logger),
+			log.WithLevel(lcfg.Level),
+			log.WithFormat(lcfg.Format),
+		)
+	}
+	r.cfg = cfg
+	log.Infof("configuration reloaded: %v", changes)
+	return nil
+}
@@ -0,0 +1,283 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+)
+
+type reloadConfig struct {
+	config.GlobalConfig `json:",inline" yaml:",inline"`
+
+	Interval string `json:"interval" yaml:"interval"`
+	Size     int    `json:"size"     yaml:"size"`
+}
+
+type reloadableMock struct {
+	runnerMock
+	ReloadableFunc func() []string
+	ReloadFunc     func(ctx context.Context, cfg any) error
+}
+
+func (m *reloadableMock) Reloadable() []string {
+	return m.ReloadableFunc()
+}
+
+func (m *reloadableMock) Reload(ctx context.Context, cfg any) error {
+	return m.ReloadFunc(ctx, cfg)
+}
+
+func Test_reloader_apply(t *testing.T) {
+	initial := &reloadConfig{
+		GlobalConfig: config.GlobalConfig{
+			Version: "v0.0.0",
+			Logging: &config.Logging{
+				Logger: "nop",
+				Level:  "info",
+			},
+		},
+		Interval: "1s",
+		Size:     10,
+	}
+	type want struct {
+		err      error
+		reloaded bool
+		cfg      *reloadConfig
+	}
+	type test struct {
+		name      string
+		next      *reloadConfig
+		reloadErr error
+		want      want
+	}
+	tests := []test{
+		{
+			name: "keep the configuration when nothing is changed",
+			next: initial,
+			want: want{
+				cfg: initial,
+			},
+		},
+		{
+			name: "apply the reloadable fields of the daemon",
+			next: &reloadConfig{
+				GlobalConfig: initial.GlobalConfig,
+				Interval:     "5s",
+				Size:         10,
+			},
+			want: want{
+				reloaded: true,
+				cfg: &reloadConfig{
+					GlobalConfig: initial.GlobalConfig,
+					Interval:     "5s",
+					Size:         10,
+				},
+			},
+		},
+		{
+			name: "apply the logging level",
+			next: &reloadConfig{
+				GlobalConfig: config.GlobalConfig{
+					Version: "v0.0.0",
+					Logging: &config.Logging{
+						Logger: "nop",
+						Level:  "debug",
+					},
+				},
+				Interval: "1s",
+				Size:     10,
+			},
+			want: want{
+				reloaded: true,
+				cfg: &reloadConfig{
+					GlobalConfig: config.GlobalConfig{
+						Version: "v0.0.0",
+						Logging: &config.Logging{
+							Logger: "nop",
+							Level:  "debug",
+						},
+					},
+					Interval: "1s",
+					Size:     10,
+				},
+			},
+		},
+		{
+			name: "reject the unreloadable fields",
+			next: &reloadConfig{
+				GlobalConfig: initial.GlobalConfig,
+				Interval:     "5s",
+				Size:         20,
+			},
+			want: want{
+				err: errors.ErrUnreloadableConfig("size"),
+				cfg: initial,
+			},
+		},
+		{
+			name: "keep the configuration when the daemon fails to apply it",
+			next: &reloadConfig{
+				GlobalConfig: initial.GlobalConfig,
+				Interval:     "invalid",
+				Size:         10,
+			},
+			reloadErr: errors.New("invalid interval"),
+			want: want{
+				err: errors.Wrap(errors.New("invalid interval"), "failed to apply the configuration"),
+				cfg: initial,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			ctx := context.Background()
+			path := file.Join(tt.TempDir(), "config.json")
+			b, err := json.Marshal(test.next)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if _, err = file.OverWriteFile(ctx, path, bytes.NewReader(b), fs.ModePerm); err != nil {
+				tt.Fatal(err)
+			}
+			load := func(path string) (any, *config.GlobalConfig, error) {
+				b, err := file.ReadFile(path)
+				if err != nil {
+					return nil, nil, err
+				}
+				cfg := new(reloadConfig)
+				if err := json.Unmarshal(b, cfg); err != nil {
+					return nil, nil, err
+				}
+				return cfg, &cfg.GlobalConfig, nil
+			}
+			var reloaded bool
+			daemon := &reloadableMock{
+				ReloadableFunc: func() []string {
+					return []string{"interval"}
+				},
+				ReloadFunc: func(_ context.Context, _ any) error {
+					reloaded = test.reloadErr == nil
+					return test.reloadErr
+				},
+			}
+			r := newReloader(daemon, path, load, initial)
+			err = r.apply(ctx)
+			if !errors.Is(err, test.want.err) {
+				tt.Errorf("got_error: %v, want: %v", err, test.want.err)
+			}
+			if reloaded != test.want.reloaded {
+				tt.Errorf("reloaded: %v, want: %v", reloaded, test.want.reloaded)
+			}
+			changes, err := config.Changes(r.cfg, test.want.cfg)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if len(changes) != 0 {
+				tt.Errorf("running configuration differs from the expected one: %v", changes)
+			}
+		})
+	}
+}
+
+func Test_reloader_Start(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	initial := &reloadConfig{
+		GlobalConfig: config.GlobalConfig{
+			Version: "v0.0.0",
+		},
+		Interval: "1s",
+		Size:     10,
+	}
+	write := func(cfg *reloadConfig, path string) {
+		b, err := json.Marshal(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = file.OverWriteFile(ctx, path, bytes.NewReader(b), fs.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := file.Join(t.TempDir(), "config.json")
+	write(initial, path)
+	load := func(path string) (any, *config.GlobalConfig, error) {
+		b, err := file.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg := new(reloadConfig)
+		if err := json.Unmarshal(b, cfg); err != nil {
+			return nil, nil, err
+		}
+		return cfg, &cfg.GlobalConfig, nil
+	}
+	reloaded := make(chan any, 1)
+	daemon := &reloadableMock{
+		runnerMock: runnerMock{
+			StartFunc: func(context.Context) (<-chan error, error) {
+				return make(chan error), nil
+			},
+			StopFunc: func(context.Context) error {
+				return nil
+			},
+		},
+		ReloadableFunc: func() []string {
+			return []string{"interval"}
+		},
+		ReloadFunc: func(_ context.Context, cfg any) error {
+			select {
+			case reloaded <- cfg:
+			default:
+			}
+			return nil
+		},
+	}
+	r := newReloader(daemon, path, load, initial)
+	if _, err := r.Start(ctx); err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	defer func() {
+		if err := r.Stop(ctx); err != nil {
+			t.Errorf("failed to stop: %v", err)
+		}
+	}()
+
+	write(&reloadConfig{
+		GlobalConfig: initial.GlobalConfig,
+		Interval:     "5s",
+		Size:         10,
+	}, path)
+	select {
+	case cfg := <-reloaded:
+		if got := cfg.(*reloadConfig).Interval; got != "5s" {
+			t.Errorf("reloaded interval: %s, want: 5s", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("configuration is not reloaded on file change")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
 
@@ -130,6 +131,7 @@ func Do(ctx context.Context, opts ...Option) error {
 	if err != nil {
 		return err
 	}
+	daemon = newReloader(daemon, p.ConfigFilePath(), r.loadConfig, cfg)
 
 	log.Infof("service %s(version: %s)starting...", r.name, ccfg.Version)
 
@@ -139,12 +141,17 @@ func Do(ctx context.Context, opts ...Option) error {
 }
 
 func Run(ctx context.Context, run Runner, name string) (err error) {
-	sctx, cancel := signal.NotifyContext(ctx,
+	sigs := []os.Signal{
 		syscall.SIGINT,
 		syscall.SIGQUIT,
-		syscall.SIGHUP,
 		syscall.SIGALRM,
-		syscall.SIGTERM)
+		syscall.SIGTERM,
+	}
+	// SIGHUP triggers the configuration reload instead of stopping the daemon.
+	if _, ok := run.(Reloader); !ok {
+		sigs = append(sigs, syscall.SIGHUP)
+	}
+	sctx, cancel := signal.NotifyContext(ctx, sigs...)
 	defer cancel()
 	sctx = errgroup.Init(sctx)
 	log.Info("executing daemon pre-start function")
@@ -23,14 +23,18 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/vdaas/vald/internal/auth"
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/info"
 	"github.com/vdaas/vald/internal/log"
 	"github.com/vdaas/vald/internal/net"
 	"github.com/vdaas/vald/internal/net/control"
 	"github.com/vdaas/vald/internal/net/grpc"
+	authinterceptor "github.com/vdaas/vald/internal/net/grpc/interceptor/server/auth"
+	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/compress"
 	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/logging"
 	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/metric"
+	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/ratelimit"
 	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/recover"
 	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/trace"
 	"github.com/vdaas/vald/internal/net/http/rest"
@@ -662,8 +666,31 @@ func WithGRPCWaitForHandlers(wait bool) Option {
 	}
 }
 
+// WithGRPCCompressor returns the option to compress the response messages with the registered compressor of the name
+// when the client accepts it.
+func WithGRPCCompressor(name string) Option {
+	return func(s *server) error {
+		if name == "" {
+			return nil
+		}
+		if !grpc.IsCompressorRegistered(name) {
+			return errors.NewErrCriticalOption("gRPCCompressor", name, errors.ErrGRPCCompressorNotFound(name))
+		}
+		s.grpc.opts = append(
+			s.grpc.opts,
+			grpc.ChainUnaryInterceptor(compress.CompressInterceptor(name)),
+			grpc.ChainStreamInterceptor(compress.CompressStreamInterceptor(name)),
+		)
+		return nil
+	}
+}
+
 func WithGRPCInterceptors(names ...string) Option {
 	return func(s *server) error {
+		authns, policy, err := s.authInterceptors(names...)
+		if err != nil {
+			return errors.NewErrCriticalOption("gRPCInterceptors", names, err)
+		}
 		for _, name := range names {
 			switch strings.ToLower(name) {
 			case "recoverinterceptor", "recover":
@@ -697,6 +724,120 @@ func WithGRPCInterceptors(names ...string) Option {
 			default:
 			}
 		}
+		if len(authns) != 0 {
+			s.grpc.opts = append(
+				s.grpc.opts,
+				grpc.ChainUnaryInterceptor(authinterceptor.AuthInterceptor(authns...)),
+				grpc.ChainStreamInterceptor(authinterceptor.AuthStreamInterceptor(authns...)),
+			)
+		}
+		if policy != nil {
+			s.grpc.opts = append(
+				s.grpc.opts,
+				grpc.ChainUnaryInterceptor(authinterceptor.AuthorizationInterceptor(policy)),
+				grpc.ChainStreamInterceptor(authinterceptor.AuthorizationStreamInterceptor(policy)),
+			)
+		}
+		// the rate limiter runs after authentication so that the budgets can be kept per identity.
+		for _, name := range names {
+			if !isRateLimitInterceptor(name) {
+				continue
+			}
+			if s.ratelimit == nil {
+				return errors.NewErrCriticalOption("gRPCInterceptors", name, errors.ErrInvalidAPIConfig)
+			}
+			s.grpc.opts = append(
+				s.grpc.opts,
+				grpc.ChainUnaryInterceptor(s.ratelimit.UnaryInterceptor()),
+				grpc.ChainStreamInterceptor(s.ratelimit.StreamInterceptor()),
+			)
+		}
+		return nil
+	}
+}
+
+func isRateLimitInterceptor(name string) bool {
+	switch strings.ToLower(name) {
+	case "ratelimitinterceptor", "ratelimit":
+		return true
+	}
+	return false
+}
+
+// WithRateLimiter returns the option to set the limiter used by the rate limit interceptor.
+func WithRateLimiter(l ratelimit.Limiter) Option {
+	return func(s *server) error {
+		if l != nil {
+			s.ratelimit = l
+		}
 		return nil
 	}
 }
+
+// WithHTTPInterceptors returns the option to select the interceptors of the REST and GraphQL handlers.
+// Only the authentication, authorization and rate limit interceptors apply to HTTP, the names are the same as WithGRPCInterceptors.
+func WithHTTPInterceptors(names ...string) Option {
+	return func(s *server) error {
+		s.auth.http = append(s.auth.http, names...)
+		return nil
+	}
+}
+
+// WithAuthenticator returns the option to register the authenticators the interceptors can select by name.
+func WithAuthenticator(authns ...auth.Authenticator) Option {
+	return func(s *server) error {
+		for _, authn := range authns {
+			if authn == nil {
+				continue
+			}
+			if s.auth.authns == nil {
+				s.auth.authns = make(map[string]auth.Authenticator, len(authns))
+			}
+			s.auth.authns[authn.Name()] = authn
+		}
+		return nil
+	}
+}
+
+// WithAuthorizationPolicy returns the option to set the policy used by the authorization interceptor.
+func WithAuthorizationPolicy(p auth.Policy) Option {
+	return func(s *server) error {
+		if p != nil {
+			s.auth.policy = p
+		}
+		return nil
+	}
+}
+
+// authInterceptors resolves the authenticators and the policy selected by the interceptor names.
+// The authenticators keep the order of the names, the first one accepting the request credentials wins.
+func (s *server) authInterceptors(names ...string) (authns []auth.Authenticator, policy auth.Policy, err error) {
+	for _, name := range names {
+		var authn string
+		switch strings.ToLower(name) {
+		case "mtlsauthinterceptor", "mtlsauth", "mtls":
+			authn = auth.MTLSName
+		case "apikeyauthinterceptor", "apikeyauth", "apikey":
+			authn = auth.APIKeyName
+		case "jwtauthinterceptor", "jwtauth", "jwt":
+			authn = auth.JWTName
+		case "authorizationinterceptor", "authorization", "authz":
+			if s.auth.policy == nil {
+				return nil, nil, errors.ErrUnknownAuthenticator("authorization policy")
+			}
+			policy = s.auth.policy
+			continue
+		default:
+			continue
+		}
+		a, ok := s.auth.authns[authn]
+		if !ok {
+			return nil, nil, errors.ErrUnknownAuthenticator(authn)
+		}
+		authns = append(authns, a)
+	}
+	if policy != nil && len(authns) == 0 {
+		return nil, nil, errors.ErrUnauthenticated
+	}
+	return authns, policy, nil
+}
@@ -2200,6 +2200,72 @@ func TestWithGRPCHeaderTableSize(t *testing.T) {
 	}
 }
 
+func TestWithGRPCCompressor(t *testing.T) {
+	type test struct {
+		name      string
+		cname     string
+		checkFunc func(opt Option) error
+	}
+
+	tests := []test{
+		{
+			name:  "set success when the compressor is registered",
+			cname: "zstd",
+			checkFunc: func(opt Option) error {
+				got := new(server)
+				if err := opt(got); err != nil {
+					return err
+				}
+
+				if len(got.grpc.opts) != 2 {
+					return errors.New("invalid param was set")
+				}
+				return nil
+			},
+		},
+
+		{
+			name: "not set when the name is empty",
+			checkFunc: func(opt Option) error {
+				got := new(server)
+				if err := opt(got); err != nil {
+					return err
+				}
+
+				if len(got.grpc.opts) != 0 {
+					return errors.New("invalid param was set")
+				}
+				return nil
+			},
+		},
+
+		{
+			name:  "return error when the compressor is not registered",
+			cname: "brotli",
+			checkFunc: func(opt Option) error {
+				got := new(server)
+				if err := opt(got); err == nil {
+					return errors.New("error was not returned")
+				}
+
+				if len(got.grpc.opts) != 0 {
+					return errors.New("invalid param was set")
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := WithGRPCCompressor(tt.cname)
+			if err := tt.checkFunc(opt); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 func TestWithGRPCInterceptors(t *testing.T) {
 	type test struct {
 		name      string
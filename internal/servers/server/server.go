@@ -23,10 +23,12 @@ import (
 	"net/http"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/vdaas/vald/internal/auth"
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/file"
 	"github.com/vdaas/vald/internal/log"
@@ -35,8 +37,11 @@ import (
 	"github.com/vdaas/vald/internal/net/grpc"
 	"github.com/vdaas/vald/internal/net/grpc/credentials"
 	"github.com/vdaas/vald/internal/net/grpc/health"
+	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/ratelimit"
 	"github.com/vdaas/vald/internal/net/grpc/keepalive"
 	glog "github.com/vdaas/vald/internal/net/grpc/logger"
+	"github.com/vdaas/vald/internal/net/http/json"
+	"github.com/vdaas/vald/internal/net/http/middleware"
 	"github.com/vdaas/vald/internal/safety"
 	"github.com/vdaas/vald/internal/strings"
 	"github.com/vdaas/vald/internal/sync"
@@ -59,6 +64,9 @@ const (
 	GQL
 )
 
+// graphQLPath is the path of the GraphQL API, whose operations are authorized one by one by the GraphQL handler.
+const graphQLPath = "/graphql"
+
 func (m ServerMode) String() string {
 	switch m {
 	case REST:
@@ -102,6 +110,12 @@ type server struct {
 		opts      []grpc.ServerOption
 		regs      []func(*grpc.Server)
 	}
+	auth struct { // authenticators and policy selectable by the interceptors
+		authns map[string]auth.Authenticator
+		policy auth.Policy
+		http   []string // interceptors applied to the REST and GraphQL handler
+	}
+	ratelimit     ratelimit.Limiter
 	lc            *net.ListenConfig
 	tcfg          *tls.Config
 	pwt           time.Duration // ProbeWaitTime
@@ -178,9 +192,38 @@ func New(opts ...Option) (Server, error) {
 		if srv.it != 0 {
 			srv.http.srv.IdleTimeout = srv.it
 		}
+		if len(srv.auth.http) != 0 {
+			authns, policy, err := srv.authInterceptors(srv.auth.http...)
+			if err != nil {
+				return nil, errors.NewErrCriticalOption("HTTPInterceptors", srv.auth.http, err)
+			}
+			// the requests are authorized and rate limited as the RPCs of their routes, so the paths not routed to any are rejected.
+			rpcs, _ := srv.http.h.(middleware.RPCResolver)
+			var ops []string
+			if srv.mode == GQL {
+				ops = append(ops, graphQLPath)
+			}
+			// the rate limiter is wrapped by the authentication so that the budgets can be kept per identity.
+			if slices.ContainsFunc(srv.auth.http, isRateLimitInterceptor) {
+				if srv.ratelimit == nil {
+					return nil, errors.NewErrCriticalOption("HTTPInterceptors", srv.auth.http, errors.ErrInvalidAPIConfig)
+				}
+				srv.http.h = middleware.NewRateLimitHandler(srv.http.h, rpcs, srv.ratelimit, ops...)
+			}
+			if len(authns) != 0 {
+				srv.http.h = middleware.NewAuthHandler(srv.http.h, rpcs, authns, policy, ops...)
+			}
+		}
 		if srv.http.h != nil {
 			srv.http.srv.Handler = srv.http.h
 		}
+		if srv.http.srv.BaseContext == nil {
+			// streaming handlers extend the read/write deadline per message instead of per request.
+			rt, wt := srv.rt, srv.wt
+			srv.http.srv.BaseContext = func(net.Listener) context.Context {
+				return json.WithStreamTimeout(context.Background(), rt, wt)
+			}
+		}
 		srv.http.starter = srv.http.srv.Serve
 		srv.http.srv.SetKeepAlivesEnabled(true)
 		if srv.tcfg != nil &&
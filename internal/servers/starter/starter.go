@@ -19,8 +19,10 @@ package starter
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/ratelimit"
 	"github.com/vdaas/vald/internal/net/http/metrics"
 	"github.com/vdaas/vald/internal/servers"
 	"github.com/vdaas/vald/internal/servers/server"
@@ -37,6 +39,8 @@ type srvs struct {
 	cfg     *config.Servers
 	pstartf map[string]func() error
 	pstopf  map[string]func() error
+	// authOpts register the authenticators, the authorization policy and the rate limiter of the API servers.
+	authOpts []server.Option
 }
 
 func New(sopts ...Option) (Server, error) {
@@ -74,6 +78,20 @@ func New(sopts ...Option) (Server, error) {
 		}
 	}
 
+	// the authenticators and the rate limiter are registered before the server options so that the interceptors can select them.
+	auth := ss.cfg.Auth
+	if auth == nil {
+		auth = new(config.Auth)
+	}
+	var err error
+	ss.authOpts, err = auth.Opts()
+	if err != nil {
+		return nil, err
+	}
+	if ss.cfg.RateLimit != nil {
+		ss.authOpts = append(ss.authOpts, server.WithRateLimiter(ratelimit.New(ss.cfg.RateLimit.Opts()...)))
+	}
+
 	apiOpts, err := ss.setupAPIs(cfg)
 	if err != nil {
 		return nil, err
@@ -101,7 +119,7 @@ func (s *srvs) setupAPIs(cfg *tls.Config) ([]servers.Option, error) {
 		switch mode := server.Mode(sc.Mode); mode {
 		case server.REST:
 			srv, err := server.New(
-				append(append(sc.Opts(), s.rest(sc)...),
+				append(slices.Concat(s.authOpts, sc.Opts(), s.rest(sc)),
 					server.WithTLSConfig(cfg),
 				)...)
 			if err != nil {
@@ -110,7 +128,7 @@ func (s *srvs) setupAPIs(cfg *tls.Config) ([]servers.Option, error) {
 			opts = append(opts, servers.WithServer(srv))
 		case server.GRPC:
 			srv, err := server.New(
-				append(append(sc.Opts(), s.grpc(sc)...),
+				append(slices.Concat(s.authOpts, sc.Opts(), s.grpc(sc)),
 					server.WithTLSConfig(cfg),
 				)...)
 			if err != nil {
@@ -119,7 +137,7 @@ func (s *srvs) setupAPIs(cfg *tls.Config) ([]servers.Option, error) {
 			opts = append(opts, servers.WithServer(srv))
 		case server.GQL:
 			srv, err := server.New(
-				append(append(sc.Opts(), s.gql(sc)...),
+				append(slices.Concat(s.authOpts, sc.Opts(), s.gql(sc)),
 					server.WithTLSConfig(cfg),
 					server.WithPreStartFunc(func() error {
 						return nil
@@ -163,6 +181,10 @@ func (s *srvs) setupMetrics(cfg *tls.Config) ([]servers.Option, error) {
 		switch strings.ToLower(msc.Name) {
 		case "prof", "pprof", "profile", "profiler":
 			hopt = server.WithHTTPHandler(metrics.NewPProfHandler())
+		case "prometheus", "prom":
+			hopt = server.WithHTTPHandler(metrics.NewPrometheusHandler())
+		case "admin":
+			hopt = server.WithHTTPHandler(metrics.NewAdminHandler())
 		default:
 			continue
 		}
@@ -0,0 +1,278 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cluster
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/rand"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+// AgentServer represents the agent component served by an agent of the cluster.
+type AgentServer interface {
+	// Register registers the gRPC services of the agent to srv.
+	Register(srv *grpc.Server)
+	// Start starts the daemons of the agent, they stop when ctx is canceled.
+	Start(ctx context.Context) <-chan error
+	// Close releases the resources of the agent and saves the index unless it is in-memory.
+	Close(ctx context.Context) error
+}
+
+// AgentFactory represents the function which builds the agent named name storing its index in dir.
+// It is called again with the same name and dir whenever the agent restarts.
+type AgentFactory func(ctx context.Context, name, dir string) (AgentServer, error)
+
+// Agent represents an agent of the cluster and the failures injected into it.
+type Agent interface {
+	// Name returns the name of the agent.
+	Name() string
+	// Addr returns the address the agent listens on.
+	Addr() string
+	// Dir returns the index directory of the agent.
+	Dir() string
+	// IsRunning returns whether the agent is serving.
+	IsRunning() bool
+	// Kill stops the agent immediately, the LB gateway keeps it as a discovered agent.
+	Kill(ctx context.Context) error
+	// Restart starts the agent again on the same address and index directory.
+	Restart(ctx context.Context) error
+	// Leave removes the agent from the discovered agents, like a scaled down agent.
+	Leave(ctx context.Context) error
+	// Join adds the agent to the discovered agents again.
+	Join(ctx context.Context) error
+	// SetLatency delays every RPC served by the agent by d.
+	SetLatency(d time.Duration)
+	// SetError makes every RPC served by the agent fail with err, nil clears it.
+	SetError(err error)
+	// CorruptIndex overwrites the index files of the agent with random bytes and restarts the agent.
+	CorruptIndex(ctx context.Context) error
+}
+
+type agent struct {
+	mu      sync.Mutex
+	name    string
+	addr    string
+	dir     string
+	factory AgentFactory
+	cluster *cluster
+
+	server *grpc.Server
+	as     AgentServer
+	cancel context.CancelFunc
+
+	joined  atomic.Bool
+	latency atomic.Int64
+	err     atomic.Pointer[error]
+}
+
+func (a *agent) Name() string {
+	return a.name
+}
+
+func (a *agent) Addr() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.addr
+}
+
+func (a *agent) Dir() string {
+	return a.dir
+}
+
+func (a *agent) IsRunning() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.server != nil
+}
+
+func (a *agent) start(ctx context.Context) (err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.server != nil {
+		return nil
+	}
+	as, err := a.factory(ctx, a.name, a.dir)
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen(net.TCP.String(), a.addr)
+	if err != nil {
+		return errors.Join(err, as.Close(ctx))
+	}
+	a.addr = lis.Addr().String()
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(a.unaryInterceptor),
+		grpc.ChainStreamInterceptor(a.streamInterceptor),
+	)
+	as.Register(srv)
+
+	actx, cancel := context.WithCancel(ctx)
+	a.cluster.forward(actx, as.Start(actx))
+	a.cluster.serve(actx, a.name, srv, lis)
+
+	a.server, a.as, a.cancel = srv, as, cancel
+	return nil
+}
+
+func (a *agent) Kill(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.kill(ctx)
+}
+
+func (a *agent) kill(ctx context.Context) error {
+	if a.server == nil {
+		return nil
+	}
+	a.server.Stop()
+	a.cancel()
+	err := a.as.Close(ctx)
+	a.server, a.as, a.cancel = nil, nil, nil
+	return err
+}
+
+func (a *agent) Restart(ctx context.Context) error {
+	if err := a.Kill(ctx); err != nil {
+		log.Warnf("failed to close agent %s before restart: %v", a.name, err)
+	}
+	if err := a.start(a.cluster.ctx); err != nil {
+		return err
+	}
+	if a.joined.Load() {
+		return a.cluster.connect(ctx, a.Addr())
+	}
+	return nil
+}
+
+func (a *agent) Leave(ctx context.Context) error {
+	if !a.joined.Swap(false) {
+		return nil
+	}
+	return a.cluster.disconnect(ctx, a.Addr())
+}
+
+func (a *agent) Join(ctx context.Context) error {
+	if a.joined.Swap(true) {
+		return nil
+	}
+	if !a.IsRunning() {
+		return nil
+	}
+	return a.cluster.connect(ctx, a.Addr())
+}
+
+func (a *agent) SetLatency(d time.Duration) {
+	a.latency.Store(int64(d))
+}
+
+func (a *agent) SetError(err error) {
+	if err == nil {
+		a.err.Store(nil)
+		return
+	}
+	a.err.Store(&err)
+}
+
+func (a *agent) CorruptIndex(ctx context.Context) (err error) {
+	a.mu.Lock()
+	running := a.server != nil
+	err = a.kill(ctx)
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	var n int
+	err = filepath.WalkDir(a.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		n++
+		return corrupt(path, fi)
+	})
+	if err == nil && n == 0 {
+		err = errors.ErrIndexFileNotFound
+	}
+	if running {
+		return errors.Join(err, a.Restart(ctx))
+	}
+	return err
+}
+
+// corrupt overwrites the file at path with random bytes of the same size.
+func corrupt(path string, fi fs.FileInfo) error {
+	f, err := file.Open(path, os.O_WRONLY|os.O_TRUNC, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, max(fi.Size(), 1))
+	for i := range buf {
+		buf[i] = byte(rand.Uint32())
+	}
+	_, err = f.Write(buf)
+	return errors.Join(err, f.Close())
+}
+
+// inject applies the latency and error injected into the agent.
+func (a *agent) inject(ctx context.Context) error {
+	if d := time.Duration(a.latency.Load()); d > 0 {
+		tm := time.NewTimer(d)
+		defer tm.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tm.C:
+		}
+	}
+	if err := a.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+func (a *agent) unaryInterceptor(
+	ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	if err := a.inject(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *agent) streamInterceptor(
+	srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	if err := a.inject(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,603 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package cluster provides the in-process Vald cluster for the integration tests.
+// It serves the agents, the LB gateway with a static discoverer and optionally the filter and
+// mirror gateways and the index manager on loopback listeners, runs the index jobs against the agents,
+// and injects failures into the agents.
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"slices"
+
+	"github.com/vdaas/vald/apis/grpc/v1/mirror"
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/client/v1/client/discoverer"
+	"github.com/vdaas/vald/internal/client/v1/client/filter/egress"
+	"github.com/vdaas/vald/internal/client/v1/client/filter/ingress"
+	mclient "github.com/vdaas/vald/internal/client/v1/client/mirror"
+	client "github.com/vdaas/vald/internal/client/v1/client/vald"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+	filterhandler "github.com/vdaas/vald/pkg/gateway/filter/handler/grpc"
+	lbhandler "github.com/vdaas/vald/pkg/gateway/lb/handler/grpc"
+	lbservice "github.com/vdaas/vald/pkg/gateway/lb/service"
+	mirrorhandler "github.com/vdaas/vald/pkg/gateway/mirror/handler/grpc"
+	mirrorservice "github.com/vdaas/vald/pkg/gateway/mirror/service"
+	creation "github.com/vdaas/vald/pkg/index/job/creation/service"
+	save "github.com/vdaas/vald/pkg/index/job/save/service"
+	indexhandler "github.com/vdaas/vald/pkg/manager/index/handler/grpc"
+	indexservice "github.com/vdaas/vald/pkg/manager/index/service"
+)
+
+// Cluster represents the in-process Vald cluster.
+type Cluster interface {
+	// Start starts the agents and the gateways, the returned channel receives their errors.
+	Start(ctx context.Context) (<-chan error, error)
+	// Stop stops every component and removes the temporary index directories.
+	Stop(ctx context.Context) error
+	// Agents returns the agents in the order the static discoverer returns them.
+	Agents() []Agent
+	// Agent returns the idx-th agent.
+	Agent(idx int) (Agent, error)
+	// GatewayAddr returns the address of the LB gateway.
+	GatewayAddr() string
+	// FilterAddr returns the address of the filter gateway, or empty when it is disabled.
+	FilterAddr() string
+	// MirrorAddr returns the address of the mirror gateway, or empty when it is disabled.
+	MirrorAddr() string
+	// IndexManagerAddr returns the address of the index manager, or empty when it is disabled.
+	IndexManagerAddr() string
+	// CreateIndex runs the index creation job once, which calls CreateIndex of every joined agent.
+	CreateIndex(ctx context.Context, poolSize uint32) error
+	// SaveIndex runs the index save job once, which calls SaveIndex of every joined agent.
+	SaveIndex(ctx context.Context) error
+	// Client returns the Vald client connected to addr, it is closed on Stop.
+	Client(ctx context.Context, addr string) (client.Client, error)
+}
+
+type cluster struct {
+	agentNum     int
+	factory      AgentFactory
+	replica      int
+	host         string
+	dir          string
+	topology     func(idx int) *payload.Info_Topology
+	gwOpts       []lbservice.Option
+	lbOpts       []lbhandler.Option
+	enableFilter bool
+	filterOpts   []filterhandler.Option
+	enableMirror bool
+	mirrorPeers  []string
+	enableIndex  bool
+	indexOpts    []indexservice.Option
+	copts        []grpc.Option
+	eg           errgroup.Group
+
+	mu         sync.Mutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	ech        chan error
+	tmpDir     bool
+	agents     []*agent
+	dsc        *staticDiscoverer
+	indexDsc   *staticDiscoverer
+	servers    []*grpc.Server
+	closers    []func(context.Context) error
+	lbAddr     string
+	filterAddr string
+	mirrorAddr string
+	indexAddr  string
+}
+
+// indexJob represents the index creation and save jobs.
+type indexJob interface {
+	StartClient(ctx context.Context) (<-chan error, error)
+	Start(ctx context.Context) error
+}
+
+const (
+	lbGatewayName     = "vald-lb-gateway"
+	filterGatewayName = "vald-filter-gateway"
+	mirrorGatewayName = "vald-mirror-gateway"
+	indexManagerName  = "vald-manager-index"
+)
+
+// New returns the Cluster built with opts, WithAgentFactory is required.
+func New(opts ...Option) (Cluster, error) {
+	c := new(cluster)
+	for _, opt := range append(defaultOptions, opts...) {
+		if err := opt(c); err != nil {
+			oerr := errors.ErrOptionFailed(err, reflect.ValueOf(opt))
+			e := new(errors.ErrCriticalOption)
+			if errors.As(err, &e) {
+				log.Error(oerr)
+				return nil, oerr
+			}
+			log.Warn(oerr)
+		}
+	}
+	if c.factory == nil {
+		return nil, errors.ErrClusterAgentFactoryNotFound
+	}
+	return c, nil
+}
+
+func (c *cluster) Start(ctx context.Context) (_ <-chan error, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ctx != nil {
+		return nil, errors.ErrClusterAlreadyStarted
+	}
+	if len(c.dir) == 0 {
+		c.dir, err = file.MkdirTemp("")
+		if err != nil {
+			return nil, err
+		}
+		c.tmpDir = true
+	}
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	c.ech = make(chan error, 100)
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, c.stop(ctx))
+		}
+	}()
+
+	if err = c.startAgents(c.ctx); err != nil {
+		return nil, err
+	}
+	if err = c.startGateway(c.ctx); err != nil {
+		return nil, err
+	}
+	if c.enableFilter {
+		if err = c.startFilter(c.ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.enableMirror {
+		if err = c.startMirror(c.ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.enableIndex {
+		if err = c.startIndexManager(c.ctx); err != nil {
+			return nil, err
+		}
+	}
+	return c.ech, nil
+}
+
+func (c *cluster) startAgents(ctx context.Context) error {
+	c.agents = make([]*agent, 0, c.agentNum)
+	for i := range c.agentNum {
+		name := fmt.Sprintf("vald-agent-%d", i)
+		a := &agent{
+			name:    name,
+			addr:    net.JoinHostPort(c.host, 0),
+			dir:     file.Join(c.dir, name),
+			factory: c.factory,
+			cluster: c,
+		}
+		a.joined.Store(true)
+		if err := file.MkdirAll(a.dir, fs.ModePerm); err != nil {
+			return err
+		}
+		c.agents = append(c.agents, a)
+		if err := a.start(ctx); err != nil {
+			return err
+		}
+	}
+	c.dsc = c.newDiscoverer(ctx)
+	return nil
+}
+
+// newDiscoverer returns the static discoverer of the agents with its own connection pool to the joined agents.
+func (c *cluster) newDiscoverer(ctx context.Context) *staticDiscoverer {
+	d := &staticDiscoverer{
+		agents:   c.agents,
+		topology: c.topology,
+	}
+	d.client = grpc.New(c.clientOptions(d.GetAddrs(ctx)...)...)
+	return d
+}
+
+// connect connects the discoverers to the joined agent of addr.
+func (c *cluster) connect(ctx context.Context, addr string) (err error) {
+	for _, d := range []*staticDiscoverer{c.dsc, c.indexDsc} {
+		if d != nil {
+			err = errors.Join(err, d.connect(ctx, addr))
+		}
+	}
+	return err
+}
+
+// disconnect disconnects the discoverers from the left agent of addr.
+func (c *cluster) disconnect(ctx context.Context, addr string) (err error) {
+	for _, d := range []*staticDiscoverer{c.dsc, c.indexDsc} {
+		if d != nil {
+			err = errors.Join(err, d.disconnect(ctx, addr))
+		}
+	}
+	return err
+}
+
+func (c *cluster) startGateway(ctx context.Context) error {
+	gw, err := lbservice.NewGateway(append([]lbservice.Option{
+		lbservice.WithErrGroup(c.eg),
+		lbservice.WithDiscoverer(c.dsc),
+		lbservice.WithIndexReplica(c.replica),
+	}, c.gwOpts...)...)
+	if err != nil {
+		return err
+	}
+	ech, err := gw.Start(ctx)
+	if err != nil {
+		return err
+	}
+	c.forward(ctx, ech)
+
+	h := lbhandler.New(append([]lbhandler.Option{
+		lbhandler.WithGateway(gw),
+		lbhandler.WithErrGroup(c.eg),
+		lbhandler.WithReplicationCount(c.replica),
+		lbhandler.WithName(lbGatewayName),
+	}, c.lbOpts...)...)
+
+	lis, err := c.listen()
+	if err != nil {
+		return err
+	}
+	srv := grpc.NewServer()
+	vald.RegisterValdServer(srv, h)
+	vald.RegisterCollectionServer(srv, h)
+	c.serve(ctx, lbGatewayName, srv, lis)
+	c.servers = append(c.servers, srv)
+	c.lbAddr = lis.Addr().String()
+	return nil
+}
+
+func (c *cluster) startFilter(ctx context.Context) error {
+	vc, err := c.client(ctx, c.lbAddr)
+	if err != nil {
+		return err
+	}
+	ic, err := ingress.New(ingress.WithClient(grpc.New(c.clientOptions()...)))
+	if err != nil {
+		return err
+	}
+	ec, err := egress.New(egress.WithClient(grpc.New(c.clientOptions()...)))
+	if err != nil {
+		return err
+	}
+
+	h := filterhandler.New(append([]filterhandler.Option{
+		filterhandler.WithValdClient(vc),
+		filterhandler.WithIngressFilterClient(ic),
+		filterhandler.WithEgressFilterClient(ec),
+		filterhandler.WithErrGroup(c.eg),
+		filterhandler.WithName(filterGatewayName),
+	}, c.filterOpts...)...)
+
+	lis, err := c.listen()
+	if err != nil {
+		return err
+	}
+	srv := grpc.NewServer()
+	vald.RegisterValdServerWithFilter(srv, h)
+	c.serve(ctx, filterGatewayName, srv, lis)
+	c.servers = append(c.servers, srv)
+	c.filterAddr = lis.Addr().String()
+	return nil
+}
+
+func (c *cluster) startMirror(ctx context.Context) error {
+	lis, err := c.listen()
+	if err != nil {
+		return err
+	}
+	addr := lis.Addr().String()
+	addrs := append([]string{c.lbAddr}, c.mirrorPeers...)
+	mc, err := mclient.New(
+		mclient.WithAddrs(addrs...),
+		mclient.WithClient(grpc.New(c.clientOptions(addrs...)...)),
+	)
+	if err != nil {
+		return errors.Join(err, lis.Close())
+	}
+	ech, err := mc.Start(ctx)
+	if err != nil {
+		return errors.Join(err, lis.Close())
+	}
+	c.forward(ctx, ech)
+	c.closers = append(c.closers, mc.Stop)
+
+	gw, err := mirrorservice.NewGateway(
+		mirrorservice.WithErrGroup(c.eg),
+		mirrorservice.WithMirrorClient(mc),
+		mirrorservice.WithPodName(mirrorGatewayName),
+	)
+	if err != nil {
+		return errors.Join(err, lis.Close())
+	}
+	m, err := mirrorservice.NewMirror(
+		mirrorservice.WithErrorGroup(c.eg),
+		mirrorservice.WithGatewayAddrs(c.lbAddr),
+		mirrorservice.WithSelfMirrorAddrs(addr),
+		mirrorservice.WithGateway(gw),
+	)
+	if err != nil {
+		return errors.Join(err, lis.Close())
+	}
+	h, err := mirrorhandler.New(
+		mirrorhandler.WithValdAddr(c.lbAddr),
+		mirrorhandler.WithErrGroup(c.eg),
+		mirrorhandler.WithGateway(gw),
+		mirrorhandler.WithMirror(m),
+		mirrorhandler.WithName(mirrorGatewayName),
+	)
+	if err != nil {
+		return errors.Join(err, lis.Close())
+	}
+
+	srv := grpc.NewServer()
+	vald.RegisterValdServer(srv, h)
+	mirror.RegisterMirrorServer(srv, h)
+	c.serve(ctx, mirrorGatewayName, srv, lis)
+	c.servers = append(c.servers, srv)
+	c.mirrorAddr = addr
+	return nil
+}
+
+func (c *cluster) startIndexManager(ctx context.Context) error {
+	c.indexDsc = c.newDiscoverer(ctx)
+	idx, err := indexservice.New(append([]indexservice.Option{
+		indexservice.WithErrGroup(c.eg),
+		indexservice.WithDiscoverer(c.indexDsc),
+		indexservice.WithIndexReplica(c.replica),
+	}, c.indexOpts...)...)
+	if err != nil {
+		return err
+	}
+	ech, err := idx.Start(ctx)
+	if err != nil {
+		return err
+	}
+	c.forward(ctx, ech)
+
+	lis, err := c.listen()
+	if err != nil {
+		return err
+	}
+	srv := grpc.NewServer()
+	vald.RegisterIndexServer(srv, indexhandler.New(indexhandler.WithIndexer(idx)))
+	c.serve(ctx, indexManagerName, srv, lis)
+	c.servers = append(c.servers, srv)
+	c.indexAddr = lis.Addr().String()
+	return nil
+}
+
+func (c *cluster) CreateIndex(ctx context.Context, poolSize uint32) error {
+	return c.runJob(ctx, func(d discoverer.Client) (indexJob, error) {
+		return creation.New(
+			creation.WithDiscoverer(d),
+			creation.WithCreationPoolSize(poolSize),
+		)
+	})
+}
+
+func (c *cluster) SaveIndex(ctx context.Context) error {
+	return c.runJob(ctx, func(d discoverer.Client) (indexJob, error) {
+		return save.New(save.WithDiscoverer(d))
+	})
+}
+
+// runJob runs the index job built by newJob once with its own discoverer, like the index jobs run by the cron jobs.
+func (c *cluster) runJob(ctx context.Context, newJob func(d discoverer.Client) (indexJob, error)) (err error) {
+	c.mu.Lock()
+	if c.ctx == nil {
+		c.mu.Unlock()
+		return errors.ErrClusterNotStarted
+	}
+	d := c.newDiscoverer(ctx)
+	c.mu.Unlock()
+	defer func() {
+		err = errors.Join(err, d.client.Close(ctx))
+	}()
+
+	job, err := newJob(d)
+	if err != nil {
+		return err
+	}
+	jctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ech, err := job.StartClient(jctx)
+	if err != nil {
+		return err
+	}
+	c.forward(jctx, ech)
+	return job.Start(jctx)
+}
+
+func (c *cluster) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ctx == nil {
+		return errors.ErrClusterNotStarted
+	}
+	return c.stop(ctx)
+}
+
+func (c *cluster) stop(ctx context.Context) (err error) {
+	// the clients are closed before the servers they connect to.
+	for _, stop := range slices.Backward(c.closers) {
+		err = errors.Join(err, stop(ctx))
+	}
+	for _, d := range []*staticDiscoverer{c.dsc, c.indexDsc} {
+		if d != nil {
+			err = errors.Join(err, d.client.Close(ctx))
+		}
+	}
+	for _, srv := range c.servers {
+		srv.Stop()
+	}
+	for _, a := range c.agents {
+		err = errors.Join(err, a.Kill(ctx))
+	}
+	c.cancel()
+	if c.tmpDir {
+		err = errors.Join(err, os.RemoveAll(c.dir))
+		c.dir, c.tmpDir = "", false
+	}
+	c.ctx, c.cancel = nil, nil
+	c.agents, c.dsc, c.indexDsc, c.servers, c.closers = nil, nil, nil, nil, nil
+	c.lbAddr, c.filterAddr, c.mirrorAddr, c.indexAddr = "", "", "", ""
+	return err
+}
+
+func (c *cluster) Agents() []Agent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	agents := make([]Agent, 0, len(c.agents))
+	for _, a := range c.agents {
+		agents = append(agents, a)
+	}
+	return agents
+}
+
+func (c *cluster) Agent(idx int) (Agent, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if idx < 0 || idx >= len(c.agents) {
+		return nil, errors.ErrClusterAgentNotFound(idx)
+	}
+	return c.agents[idx], nil
+}
+
+func (c *cluster) GatewayAddr() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lbAddr
+}
+
+func (c *cluster) FilterAddr() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.filterAddr
+}
+
+func (c *cluster) MirrorAddr() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mirrorAddr
+}
+
+func (c *cluster) IndexManagerAddr() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.indexAddr
+}
+
+func (c *cluster) Client(ctx context.Context, addr string) (client.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ctx == nil {
+		return nil, errors.ErrClusterNotStarted
+	}
+	return c.client(ctx, addr)
+}
+
+func (c *cluster) client(ctx context.Context, addr string) (client.Client, error) {
+	vc, err := client.New(
+		client.WithAddrs(addr),
+		client.WithClient(grpc.New(c.clientOptions(addr)...)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ech, err := vc.Start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.forward(ctx, ech)
+	c.closers = append(c.closers, vc.Stop)
+	return vc, nil
+}
+
+func (c *cluster) clientOptions(addrs ...string) []grpc.Option {
+	return append(slices.Clone(c.copts),
+		grpc.WithAddrs(addrs...),
+		grpc.WithErrGroup(c.eg),
+	)
+}
+
+func (c *cluster) listen() (net.Listener, error) {
+	return net.Listen(net.TCP.String(), net.JoinHostPort(c.host, 0))
+}
+
+func (c *cluster) serve(ctx context.Context, name string, srv *grpc.Server, lis net.Listener) {
+	c.eg.Go(safety.RecoverFunc(func() error {
+		log.Debugf("cluster component %s is serving on %s", name, lis.Addr().String())
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			c.send(ctx, errors.Wrapf(err, "cluster component %s stopped", name))
+		}
+		return nil
+	}))
+}
+
+// forward sends the errors received from ech to the error channel of the cluster until ech is closed.
+func (c *cluster) forward(ctx context.Context, ech <-chan error) {
+	if ech == nil {
+		return
+	}
+	c.eg.Go(safety.RecoverFunc(func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case err, ok := <-ech:
+				if !ok {
+					return nil
+				}
+				if err != nil {
+					c.send(ctx, err)
+				}
+			}
+		}
+	}))
+}
+
+// send sends err to the error channel of the cluster, or logs it when nobody receives the channel.
+func (c *cluster) send(ctx context.Context, err error) {
+	select {
+	case <-ctx.Done():
+	case c.ech <- err:
+	default:
+		log.Warn(err)
+	}
+}
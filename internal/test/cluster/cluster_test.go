@@ -0,0 +1,547 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cluster
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	core "github.com/vdaas/vald/apis/grpc/v1/agent/core"
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/log/level"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/codes"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+	"github.com/vdaas/vald/internal/sync"
+	indexservice "github.com/vdaas/vald/pkg/manager/index/service"
+)
+
+// memAgent is the in-memory agent which saves its vectors to a JSON index file on SaveIndex and Close.
+type memAgent struct {
+	vald.UnimplementedValdServer
+	core.UnimplementedAgentServer
+	name        string
+	path        string
+	mu          sync.Mutex
+	vecs        map[string][]float32
+	uncommitted uint32
+}
+
+func newMemAgent(_ context.Context, name, dir string) (AgentServer, error) {
+	a := &memAgent{
+		name: name,
+		path: filepath.Join(dir, "index.json"),
+		vecs: make(map[string][]float32),
+	}
+	if b, err := os.ReadFile(a.path); err == nil {
+		if err := json.Unmarshal(b, &a.vecs); err != nil {
+			// the broken index is discarded like the agents do
+			a.vecs = make(map[string][]float32)
+		}
+	}
+	return a, nil
+}
+
+func (a *memAgent) Register(srv *grpc.Server) {
+	vald.RegisterValdServer(srv, a)
+	core.RegisterAgentServer(srv, a)
+}
+
+func (*memAgent) Start(context.Context) <-chan error {
+	return nil
+}
+
+func (a *memAgent) Close(context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.save()
+}
+
+func (a *memAgent) save() error {
+	b, err := json.Marshal(a.vecs)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(a.path, b, 0o600)
+}
+
+func (a *memAgent) Exists(_ context.Context, id *payload.Object_ID) (*payload.Object_ID, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.vecs[id.GetId()]; !ok {
+		return nil, status.Error(codes.NotFound, id.GetId()+" not found")
+	}
+	return id, nil
+}
+
+func (a *memAgent) Insert(
+	_ context.Context, req *payload.Insert_Request,
+) (*payload.Object_Location, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.vecs[req.GetVector().GetId()] = req.GetVector().GetVector()
+	a.uncommitted++
+	return &payload.Object_Location{
+		Name: a.name,
+		Uuid: req.GetVector().GetId(),
+		Ips:  []string{"127.0.0.1"},
+	}, nil
+}
+
+func (a *memAgent) CreateIndex(
+	context.Context, *payload.Control_CreateIndexRequest,
+) (*payload.Empty, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.uncommitted = 0
+	return new(payload.Empty), nil
+}
+
+func (a *memAgent) SaveIndex(context.Context, *payload.Empty) (*payload.Empty, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return new(payload.Empty), a.save()
+}
+
+func (a *memAgent) IndexInfo(context.Context, *payload.Empty) (*payload.Info_Index_Count, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return &payload.Info_Index_Count{
+		Stored:      uint32(len(a.vecs)) - a.uncommitted,
+		Uncommitted: a.uncommitted,
+	}, nil
+}
+
+func (a *memAgent) Search(
+	_ context.Context, req *payload.Search_Request,
+) (*payload.Search_Response, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	res := make([]*payload.Object_Distance, 0, len(a.vecs))
+	for id, vec := range a.vecs {
+		var d float32
+		for i, v := range req.GetVector() {
+			d += (v - vec[i]) * (v - vec[i])
+		}
+		res = append(res, &payload.Object_Distance{
+			Id:       id,
+			Distance: d,
+		})
+	}
+	slices.SortFunc(res, func(l, r *payload.Object_Distance) int {
+		switch {
+		case l.GetDistance() < r.GetDistance():
+			return -1
+		case l.GetDistance() > r.GetDistance():
+			return 1
+		}
+		return 0
+	})
+	if n := int(req.GetConfig().GetNum()); len(res) > n {
+		res = res[:n]
+	}
+	return &payload.Search_Response{
+		RequestId: req.GetConfig().GetRequestId(),
+		Results:   res,
+	}, nil
+}
+
+func TestMain(m *testing.M) {
+	log.Init(log.WithLevel(level.ERROR.String()))
+	os.Exit(m.Run())
+}
+
+func testVector(i int) []float32 {
+	return []float32{float32(i), float32(i), float32(i)}
+}
+
+func searchRequest(i int) *payload.Search_Request {
+	return &payload.Search_Request{
+		Vector: testVector(i),
+		Config: &payload.Search_Config{
+			RequestId: strconv.Itoa(i),
+			Num:       3,
+			Timeout:   int64(time.Second),
+		},
+	}
+}
+
+func insert(t *testing.T, ctx context.Context, c vald.Client, ids ...int) {
+	t.Helper()
+	for _, i := range ids {
+		_, err := c.Insert(ctx, &payload.Insert_Request{
+			Vector: &payload.Object_Vector{
+				Id:     strconv.Itoa(i),
+				Vector: testVector(i),
+			},
+			Config: &payload.Insert_Config{
+				SkipStrictExistCheck: true,
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to insert %d: %v", i, err)
+		}
+	}
+}
+
+// stored returns the number of ids stored in the agent.
+func stored(t *testing.T, ctx context.Context, cl Cluster, a Agent, ids ...int) (n int) {
+	t.Helper()
+	c, err := cl.Client(ctx, a.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range ids {
+		if _, err := c.Exists(ctx, &payload.Object_ID{Id: strconv.Itoa(i)}); err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	type test struct {
+		name string
+		opts []Option
+		want error
+	}
+	tests := []test{
+		{
+			name: "return the cluster when the agent factory is set",
+			opts: []Option{
+				WithAgentFactory(newMemAgent),
+			},
+		},
+		{
+			name: "return the cluster with the default agents when the agents are invalid",
+			opts: []Option{
+				WithAgentFactory(newMemAgent),
+				WithAgents(0),
+			},
+		},
+		{
+			name: "return an error when the agent factory is not set",
+			want: errors.ErrClusterAgentFactoryNotFound,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			c, err := New(test.opts...)
+			if !errors.Is(err, test.want) {
+				tt.Fatalf("want error %v, got %v", test.want, err)
+			}
+			if err == nil && c.(*cluster).agentNum != 3 {
+				tt.Errorf("want 3 agents, got %d", c.(*cluster).agentNum)
+			}
+		})
+	}
+}
+
+func Test_cluster(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cl, err := New(
+		WithAgentFactory(newMemAgent),
+		WithAgents(3),
+		WithIndexReplica(2),
+		WithDataDir(t.TempDir()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := cl.Stop(ctx); err != nil {
+			t.Error(err)
+		}
+	}()
+	if _, err := cl.Start(ctx); !errors.Is(err, errors.ErrClusterAlreadyStarted) {
+		t.Errorf("want error %v, got %v", errors.ErrClusterAlreadyStarted, err)
+	}
+
+	c, err := cl.Client(ctx, cl.GatewayAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	insert(t, ctx, c, ids...)
+
+	type test struct {
+		name      string
+		inject    func(t *testing.T) (restore func())
+		checkFunc func(t *testing.T)
+	}
+	search := func(t *testing.T) {
+		t.Helper()
+		res, err := c.Search(ctx, searchRequest(5))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.GetResults(); len(got) == 0 || got[0].GetId() != "5" {
+			t.Errorf("want the nearest id 5, got %v", got)
+		}
+	}
+	agent := func(t *testing.T, idx int) Agent {
+		t.Helper()
+		a, err := cl.Agent(idx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	tests := []test{
+		{
+			name:      "search returns the nearest vector",
+			checkFunc: search,
+		},
+		{
+			name: "search returns the nearest vector while an agent is killed",
+			inject: func(t *testing.T) func() {
+				t.Helper()
+				a := agent(t, 0)
+				if err := a.Kill(ctx); err != nil {
+					t.Fatal(err)
+				}
+				if a.IsRunning() {
+					t.Error("want the killed agent stopped")
+				}
+				return func() {
+					if err := a.Restart(ctx); err != nil {
+						t.Fatal(err)
+					}
+				}
+			},
+			checkFunc: search,
+		},
+		{
+			name: "search returns the nearest vector while an agent is slow",
+			inject: func(t *testing.T) func() {
+				t.Helper()
+				a := agent(t, 1)
+				a.SetLatency(100 * time.Millisecond)
+				return func() {
+					a.SetLatency(0)
+				}
+			},
+			checkFunc: func(t *testing.T) {
+				t.Helper()
+				start := time.Now()
+				search(t)
+				if d := time.Since(start); d < 100*time.Millisecond {
+					t.Errorf("want the search delayed by the slow agent, took %s", d)
+				}
+			},
+		},
+		{
+			name: "search fails when every agent fails",
+			inject: func(t *testing.T) func() {
+				t.Helper()
+				for _, a := range cl.Agents() {
+					a.SetError(status.Error(codes.Unavailable, "injected"))
+				}
+				return func() {
+					for _, a := range cl.Agents() {
+						a.SetError(nil)
+					}
+				}
+			},
+			checkFunc: func(t *testing.T) {
+				t.Helper()
+				if _, err := c.Search(ctx, searchRequest(5)); err == nil {
+					t.Error("want an error from the failing agents")
+				}
+			},
+		},
+		{
+			name: "the left agent receives no insert",
+			inject: func(t *testing.T) func() {
+				t.Helper()
+				a := agent(t, 2)
+				if err := a.Leave(ctx); err != nil {
+					t.Fatal(err)
+				}
+				return func() {
+					if err := a.Join(ctx); err != nil {
+						t.Fatal(err)
+					}
+				}
+			},
+			checkFunc: func(t *testing.T) {
+				t.Helper()
+				insert(t, ctx, c, 10, 11, 12)
+				if n := stored(t, ctx, cl, agent(t, 2), 10, 11, 12); n != 0 {
+					t.Errorf("want no vector stored in the left agent, got %d", n)
+				}
+			},
+		},
+		{
+			name: "the corrupted index is discarded on restart",
+			checkFunc: func(t *testing.T) {
+				t.Helper()
+				a := agent(t, 0)
+				if n := stored(t, ctx, cl, a, ids...); n == 0 {
+					t.Fatal("want vectors stored in the agent before the corruption")
+				}
+				if err := a.CorruptIndex(ctx); err != nil {
+					t.Fatal(err)
+				}
+				if !a.IsRunning() {
+					t.Error("want the agent restarted after the corruption")
+				}
+				if n := stored(t, ctx, cl, a, ids...); n != 0 {
+					t.Errorf("want no vector stored after the corruption, got %d", n)
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if test.inject != nil {
+				defer test.inject(tt)()
+			}
+			test.checkFunc(tt)
+		})
+	}
+}
+
+func Test_cluster_gateways(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cl, err := New(
+		WithAgentFactory(newMemAgent),
+		WithAgents(2),
+		WithIndexReplica(1),
+		WithFilterGateway(),
+		WithMirrorGateway(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := cl.Stop(ctx); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	mc, err := cl.Client(ctx, cl.MirrorAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	insert(t, ctx, mc, 0, 1, 2)
+
+	fc, err := cl.Client(ctx, cl.FilterAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := fc.Search(ctx, searchRequest(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := res.GetResults(); len(got) == 0 || got[0].GetId() != "1" {
+		t.Errorf("want the nearest id 1, got %v", got)
+	}
+}
+
+func Test_cluster_index(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cl, err := New(
+		WithAgentFactory(newMemAgent),
+		WithAgents(2),
+		WithIndexReplica(1),
+		WithIndexManager(
+			indexservice.WithIndexingDuration("50ms"),
+			indexservice.WithMinUncommitted(1),
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := cl.Stop(ctx); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	c, err := cl.Client(ctx, cl.GatewayAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []int{0, 1, 2, 3}
+	insert(t, ctx, c, ids...)
+
+	mc, err := cl.Client(ctx, cl.IndexManagerAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var info *payload.Info_Index_Count
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+		info, err = mc.IndexInfo(ctx, new(payload.Empty))
+		if err == nil && info.GetStored() == uint32(len(ids)) && info.GetUncommitted() == 0 {
+			break
+		}
+	}
+	if info.GetStored() != uint32(len(ids)) || info.GetUncommitted() != 0 {
+		t.Fatalf("want every vector indexed by the index manager, got %v, err: %v", info, err)
+	}
+
+	if err := cl.SaveIndex(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range cl.Agents() {
+		if _, err := os.Stat(filepath.Join(a.Dir(), "index.json")); err != nil {
+			t.Errorf("want the index of %s saved by the save job: %v", a.Name(), err)
+		}
+	}
+
+	insert(t, ctx, c, 4)
+	if err := cl.CreateIndex(ctx, 10); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range cl.Agents() {
+		ac, err := cl.Client(ctx, a.Addr())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info, err := ac.IndexInfo(ctx, new(payload.Empty)); err != nil || info.GetUncommitted() != 0 {
+			t.Errorf("want no uncommitted vector of %s after the creation job, got %v, err: %v", a.Name(), info, err)
+		}
+	}
+}
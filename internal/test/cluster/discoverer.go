@@ -0,0 +1,82 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/client/v1/client/discoverer"
+	"github.com/vdaas/vald/internal/net/grpc"
+)
+
+// staticDiscoverer is the discoverer.Client stub which returns the joined agents of the cluster
+// in the order they were created, instead of asking the discoverer service.
+type staticDiscoverer struct {
+	client   grpc.Client
+	agents   []*agent
+	topology func(idx int) *payload.Info_Topology
+}
+
+var _ discoverer.Client = (*staticDiscoverer)(nil)
+
+func (d *staticDiscoverer) Start(ctx context.Context) (<-chan error, error) {
+	return d.client.StartConnectionMonitor(ctx)
+}
+
+func (d *staticDiscoverer) GetAddrs(context.Context) (addrs []string) {
+	addrs = make([]string, 0, len(d.agents))
+	for _, a := range d.agents {
+		if a.joined.Load() {
+			addrs = append(addrs, a.Addr())
+		}
+	}
+	return addrs
+}
+
+func (d *staticDiscoverer) GetClient() grpc.Client {
+	return d.client
+}
+
+func (d *staticDiscoverer) GetReadClient() grpc.Client {
+	return d.client
+}
+
+func (d *staticDiscoverer) GetTopology(addr string) *payload.Info_Topology {
+	if d.topology == nil {
+		return nil
+	}
+	for i, a := range d.agents {
+		if a.Addr() == addr {
+			return d.topology(i)
+		}
+	}
+	return nil
+}
+
+func (*staticDiscoverer) GetNodeTopology(string) *payload.Info_Topology {
+	return nil
+}
+
+func (d *staticDiscoverer) connect(ctx context.Context, addr string) error {
+	_, err := d.client.Connect(ctx, addr)
+	return err
+}
+
+func (d *staticDiscoverer) disconnect(ctx context.Context, addr string) error {
+	return d.client.Disconnect(ctx, addr)
+}
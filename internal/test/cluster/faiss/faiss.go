@@ -0,0 +1,76 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package faiss provides the Faiss agent factory of the in-process cluster.
+package faiss
+
+import (
+	"context"
+
+	agent "github.com/vdaas/vald/apis/grpc/v1/agent/core"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+	"github.com/vdaas/vald/internal/test/cluster"
+	handler "github.com/vdaas/vald/pkg/agent/core/faiss/handler/grpc"
+	"github.com/vdaas/vald/pkg/agent/core/faiss/service"
+)
+
+type server struct {
+	faiss   service.Faiss
+	handler handler.Server
+}
+
+// New returns the cluster.AgentFactory which builds the Faiss agents configured by cfg.
+// Each agent saves its index in its own directory unless opts enable the in-memory mode.
+func New(cfg *config.Faiss, opts ...service.Option) cluster.AgentFactory {
+	return func(ctx context.Context, name, dir string) (cluster.AgentServer, error) {
+		s, err := service.New(cfg, append([]service.Option{
+			service.WithErrGroup(errgroup.Get()),
+			service.WithIndexPath(dir),
+		}, opts...)...)
+		if err != nil {
+			return nil, err
+		}
+		h, err := handler.New(
+			handler.WithFaiss(s),
+			handler.WithName(name),
+			handler.WithIP("127.0.0.1"),
+		)
+		if err != nil {
+			return nil, errors.Join(err, s.Close(ctx))
+		}
+		return &server{
+			faiss:   s,
+			handler: h,
+		}, nil
+	}
+}
+
+func (s *server) Register(srv *grpc.Server) {
+	agent.RegisterAgentServer(srv, s.handler)
+	vald.RegisterValdServer(srv, s.handler)
+}
+
+func (s *server) Start(ctx context.Context) <-chan error {
+	return s.faiss.Start(ctx)
+}
+
+func (s *server) Close(ctx context.Context) error {
+	return s.faiss.Close(ctx)
+}
@@ -0,0 +1,86 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ngt
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/core/algorithm/ngt"
+	"github.com/vdaas/vald/internal/test/cluster"
+)
+
+func TestNew(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cl, err := cluster.New(
+		cluster.WithAgentFactory(New(&config.NGT{
+			Dimension:    3,
+			DistanceType: ngt.L2.String(),
+			ObjectType:   ngt.Float.String(),
+			KVSDB:        &config.KVSDB{},
+			VQueue:       &config.VQueue{},
+		})),
+		cluster.WithAgents(2),
+		cluster.WithIndexReplica(1),
+		cluster.WithDataDir(t.TempDir()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := cl.Stop(ctx); err != nil {
+			t.Error(err)
+		}
+	}()
+	c, err := cl.Client(ctx, cl.GatewayAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const num = 10
+	for i := range num {
+		_, err := c.Insert(ctx, &payload.Insert_Request{
+			Vector: &payload.Object_Vector{
+				Id:     strconv.Itoa(i),
+				Vector: []float32{float32(i), float32(i), float32(i)},
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to insert %d: %v", i, err)
+		}
+	}
+
+	// the agents save their indexes on the restart and load them again.
+	for _, a := range cl.Agents() {
+		if err := a.Restart(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := range num {
+		if _, err := c.Exists(ctx, &payload.Object_ID{Id: strconv.Itoa(i)}); err != nil {
+			t.Errorf("want %d exists after the restart, got %v", i, err)
+		}
+	}
+}
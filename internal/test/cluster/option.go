@@ -0,0 +1,179 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cluster
+
+import (
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+	filterhandler "github.com/vdaas/vald/pkg/gateway/filter/handler/grpc"
+	lbhandler "github.com/vdaas/vald/pkg/gateway/lb/handler/grpc"
+	lbservice "github.com/vdaas/vald/pkg/gateway/lb/service"
+	indexservice "github.com/vdaas/vald/pkg/manager/index/service"
+)
+
+// Option represents the functional option for cluster.
+type Option func(c *cluster) error
+
+var defaultOptions = []Option{
+	WithAgents(3),
+	WithIndexReplica(2),
+	WithHost("127.0.0.1"),
+	WithErrGroup(errgroup.Get()),
+	WithClientOptions(
+		grpc.WithInsecure(true),
+		grpc.WithConnectionPoolSize(1),
+		grpc.WithResolveDNS(false),
+		grpc.WithHealthCheckDuration("100ms"),
+		grpc.WithBackoffMaxDelay("100ms"),
+		grpc.WithMinConnectTimeout("1s"),
+		grpc.WithOldConnCloseDelay("10ms"),
+	),
+}
+
+// WithAgents returns the option to set the number of the agents.
+func WithAgents(n int) Option {
+	return func(c *cluster) error {
+		if n < 1 {
+			return errors.NewErrInvalidOption("agents", n)
+		}
+		c.agentNum = n
+		return nil
+	}
+}
+
+// WithAgentFactory returns the option to set the factory which builds the agents.
+func WithAgentFactory(f AgentFactory) Option {
+	return func(c *cluster) error {
+		if f == nil {
+			return errors.NewErrCriticalOption("agentFactory", f)
+		}
+		c.factory = f
+		return nil
+	}
+}
+
+// WithIndexReplica returns the option to set the index replica count of the LB gateway.
+func WithIndexReplica(n int) Option {
+	return func(c *cluster) error {
+		if n < 1 {
+			return errors.NewErrInvalidOption("indexReplica", n)
+		}
+		c.replica = n
+		return nil
+	}
+}
+
+// WithHost returns the option to set the loopback host the components listen on.
+func WithHost(host string) Option {
+	return func(c *cluster) error {
+		if len(host) == 0 {
+			return errors.NewErrInvalidOption("host", host)
+		}
+		c.host = host
+		return nil
+	}
+}
+
+// WithDataDir returns the option to set the base directory of the agent indexes.
+// A temporary directory is created and removed on Stop when it is not set.
+func WithDataDir(dir string) Option {
+	return func(c *cluster) error {
+		if len(dir) == 0 {
+			return errors.NewErrInvalidOption("dataDir", dir)
+		}
+		c.dir = dir
+		return nil
+	}
+}
+
+// WithTopology returns the option to set the function which returns the topology of the idx-th agent.
+func WithTopology(f func(idx int) *payload.Info_Topology) Option {
+	return func(c *cluster) error {
+		if f == nil {
+			return errors.NewErrInvalidOption("topology", f)
+		}
+		c.topology = f
+		return nil
+	}
+}
+
+// WithGatewayOptions returns the option to add the LB gateway service options.
+func WithGatewayOptions(opts ...lbservice.Option) Option {
+	return func(c *cluster) error {
+		c.gwOpts = append(c.gwOpts, opts...)
+		return nil
+	}
+}
+
+// WithGatewayHandlerOptions returns the option to add the LB gateway gRPC handler options.
+func WithGatewayHandlerOptions(opts ...lbhandler.Option) Option {
+	return func(c *cluster) error {
+		c.lbOpts = append(c.lbOpts, opts...)
+		return nil
+	}
+}
+
+// WithFilterGateway returns the option to start the filter gateway in front of the LB gateway.
+// The filter gateway has no ingress and egress filters unless opts set them.
+func WithFilterGateway(opts ...filterhandler.Option) Option {
+	return func(c *cluster) error {
+		c.enableFilter = true
+		c.filterOpts = append(c.filterOpts, opts...)
+		return nil
+	}
+}
+
+// WithMirrorGateway returns the option to start the mirror gateway in front of the LB gateway.
+// The requests are also mirrored to the mirror gateways of peers.
+func WithMirrorGateway(peers ...string) Option {
+	return func(c *cluster) error {
+		c.enableMirror = true
+		c.mirrorPeers = append(c.mirrorPeers, peers...)
+		return nil
+	}
+}
+
+// WithIndexManager returns the option to start the index manager, which schedules CreateIndex and SaveIndex of the agents.
+// The index manager has the index replica of the LB gateway unless opts set it.
+func WithIndexManager(opts ...indexservice.Option) Option {
+	return func(c *cluster) error {
+		c.enableIndex = true
+		c.indexOpts = append(c.indexOpts, opts...)
+		return nil
+	}
+}
+
+// WithClientOptions returns the option to add the gRPC client options used to connect the components.
+func WithClientOptions(opts ...grpc.Option) Option {
+	return func(c *cluster) error {
+		c.copts = append(c.copts, opts...)
+		return nil
+	}
+}
+
+// WithErrGroup returns the option to set the errgroup.
+func WithErrGroup(eg errgroup.Group) Option {
+	return func(c *cluster) error {
+		if eg == nil {
+			return errors.NewErrInvalidOption("errgroup", eg)
+		}
+		c.eg = eg
+		return nil
+	}
+}
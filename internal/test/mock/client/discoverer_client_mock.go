@@ -16,6 +16,7 @@ package client
 import (
 	"context"
 
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
 	"github.com/vdaas/vald/internal/client/v1/client/discoverer"
 	"github.com/vdaas/vald/internal/net/grpc"
 )
@@ -23,8 +24,10 @@ import (
 // DiscovererClientMock is the mock for discoverer client.
 type DiscovererClientMock struct {
 	discoverer.Client
-	GetAddrsFunc  func(ctx context.Context) []string
-	GetClientFunc func() grpc.Client
+	GetAddrsFunc        func(ctx context.Context) []string
+	GetClientFunc       func() grpc.Client
+	GetTopologyFunc     func(addr string) *payload.Info_Topology
+	GetNodeTopologyFunc func(name string) *payload.Info_Topology
 }
 
 // GetAddrs calls the GetAddrsFunc object.
@@ -36,3 +39,13 @@ func (dc *DiscovererClientMock) GetAddrs(ctx context.Context) []string {
 func (dc *DiscovererClientMock) GetClient() grpc.Client {
 	return dc.GetClientFunc()
 }
+
+// GetTopology calls GetTopologyFunc object.
+func (dc *DiscovererClientMock) GetTopology(addr string) *payload.Info_Topology {
+	return dc.GetTopologyFunc(addr)
+}
+
+// GetNodeTopology calls GetNodeTopologyFunc object.
+func (dc *DiscovererClientMock) GetNodeTopology(name string) *payload.Info_Topology {
+	return dc.GetNodeTopologyFunc(name)
+}
@@ -23,6 +23,12 @@ import (
 // GRPCClientMock is the mock for gRPC client.
 type GRPCClientMock struct {
 	grpc.Client
+	OrderedRangeFunc func(ctx context.Context,
+		order []string,
+		f func(ctx context.Context,
+			addr string,
+			conn *grpc.ClientConn,
+			copts ...grpc.CallOption) error) error
 	OrderedRangeConcurrentFunc func(ctx context.Context,
 		order []string,
 		concurrency int,
@@ -37,6 +43,18 @@ type GRPCClientMock struct {
 	SetDisableResolveDNSAddrFunc func(addr string, disabled bool)
 }
 
+// OrderedRange calls the OrderedRangeFunc object.
+func (gc *GRPCClientMock) OrderedRange(
+	ctx context.Context,
+	order []string,
+	f func(ctx context.Context,
+		addr string,
+		conn *grpc.ClientConn,
+		copts ...grpc.CallOption) error,
+) error {
+	return gc.OrderedRangeFunc(ctx, order, f)
+}
+
 // OrderedRangeConcurrent calls the OrderedRangeConcurrentFunc object.
 func (gc *GRPCClientMock) OrderedRangeConcurrent(
 	ctx context.Context,
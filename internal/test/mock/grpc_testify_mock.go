@@ -215,3 +215,5 @@ func (c *ClientInternal) Close(ctx context.Context) error {
 }
 
 func (c *ClientInternal) SetDisableResolveDNSAddr(addr string, distributed bool) {}
+
+func (c *ClientInternal) SetPoolSize(ctx context.Context, size int) {}
@@ -17,7 +17,11 @@
 // Package tls provides implementation of Go API for tls certificate provider
 package tls
 
-import "crypto/tls"
+import (
+	"crypto/tls"
+
+	"github.com/vdaas/vald/internal/timeutil"
+)
 
 type Option func(*credentials) error
 
@@ -104,3 +108,20 @@ func WithInsecureSkipVerify(insecure bool) Option {
 		return nil
 	}
 }
+
+// WithReloadInterval returns the option to set the minimum interval between checks for modified certificate files.
+// The certificate, key and CA files are reloaded during the handshake when they have been modified,
+// so short-lived certificates can be rotated without restarting the process. Zero or empty disables reloading.
+func WithReloadInterval(dur string) Option {
+	return func(c *credentials) error {
+		if dur == "" {
+			return nil
+		}
+		d, err := timeutil.Parse(dur)
+		if err != nil {
+			return err
+		}
+		c.reloadInterval = d
+		return nil
+	}
+}
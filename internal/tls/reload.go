@@ -0,0 +1,224 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package tls provides implementation of Go API for tls certificate provider
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"maps"
+	"sync/atomic"
+	"time"
+
+	"github.com/vdaas/vald/internal/file"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+// CertificateStats represents the reload counters and the expiry of the certificate loaded from a file path.
+type CertificateStats struct {
+	// Success is the number of successful reloads.
+	Success uint64
+	// Failure is the number of reloads rejected because the new files could not be loaded.
+	Failure uint64
+	// NotAfter is the expiry of the certificate currently in use.
+	NotAfter time.Time
+}
+
+var (
+	mu    sync.Mutex
+	stats = make(map[string]CertificateStats)
+)
+
+// Metrics returns a snapshot of the certificate statistics keyed by certificate file path.
+func Metrics(context.Context) map[string]CertificateStats {
+	mu.Lock()
+	defer mu.Unlock()
+	return maps.Clone(stats)
+}
+
+func record(path string, leaf *x509.Certificate, err error, initial bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	s := stats[path]
+	switch {
+	case err != nil:
+		s.Failure++
+	case !initial:
+		s.Success++
+	}
+	if leaf != nil {
+		s.NotAfter = leaf.NotAfter
+	}
+	stats[path] = s
+}
+
+// reloader keeps the latest certificate and CA pool loaded from the credential files.
+// The files are checked for modification at most once per interval from the handshake callbacks,
+// and the new material is swapped in atomically only when every file is loaded successfully.
+type reloader struct {
+	cert     string
+	key      string
+	ca       string
+	interval time.Duration
+
+	checked atomic.Int64
+	keyPair atomic.Pointer[tls.Certificate]
+	pool    atomic.Pointer[x509.CertPool]
+
+	mu     sync.Mutex
+	mtimes map[string]time.Time
+}
+
+func newReloader(c *credentials) *reloader {
+	r := &reloader{
+		cert:     c.cert,
+		key:      c.key,
+		ca:       c.ca,
+		interval: c.reloadInterval,
+	}
+	r.checked.Store(time.Now().UnixNano())
+	return r
+}
+
+// load reads the credential files and swaps the loaded material.
+func (r *reloader) load(initial bool) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mtimes := r.modTimes()
+
+	var (
+		keyPair *tls.Certificate
+		leaf    *x509.Certificate
+		pool    *x509.CertPool
+	)
+	if r.cert != "" && r.key != "" {
+		cert, err := tls.LoadX509KeyPair(r.cert, r.key)
+		if err == nil && len(cert.Certificate) != 0 {
+			leaf, err = x509.ParseCertificate(cert.Certificate[0])
+		}
+		if err != nil {
+			record(r.cert, nil, err, initial)
+			return err
+		}
+		keyPair = &cert
+	}
+	if r.ca != "" {
+		pool, err = NewX509CertPool(r.ca)
+		if err != nil {
+			if r.cert != "" {
+				record(r.cert, nil, err, initial)
+			}
+			return err
+		}
+	}
+
+	if keyPair != nil {
+		r.keyPair.Store(keyPair)
+		record(r.cert, leaf, nil, initial)
+	}
+	if pool != nil {
+		r.pool.Store(pool)
+	}
+	r.mtimes = mtimes
+	return nil
+}
+
+// check reloads the credential files when the interval has elapsed since the last check and any of them was modified.
+func (r *reloader) check() {
+	if r.interval <= 0 {
+		return
+	}
+	now := time.Now().UnixNano()
+	last := r.checked.Load()
+	if now-last < int64(r.interval) || !r.checked.CompareAndSwap(last, now) {
+		return
+	}
+	if !r.modified() {
+		return
+	}
+	if err := r.load(false); err != nil {
+		log.Warnf("failed to reload tls credentials, keeping the current certificate: %v", err)
+		return
+	}
+	log.Infof("tls credentials reloaded from cert: %s, key: %s, ca: %s", r.cert, r.key, r.ca)
+}
+
+func (r *reloader) modified() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !maps.Equal(r.mtimes, r.modTimes())
+}
+
+func (r *reloader) modTimes() map[string]time.Time {
+	mtimes := make(map[string]time.Time, 3)
+	for _, path := range []string{r.cert, r.key, r.ca} {
+		if path == "" {
+			continue
+		}
+		if exists, fi, err := file.ExistsWithDetail(path); exists && err == nil && fi != nil {
+			mtimes[path] = fi.ModTime()
+		}
+	}
+	return mtimes
+}
+
+// getConfigForClient returns the server configuration with the latest certificate and client CA pool.
+func (r *reloader) getConfigForClient(base *tls.Config) func(*tls.ClientHelloInfo) (*tls.Config, error) {
+	return func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.check()
+		cfg := base.Clone()
+		cfg.GetConfigForClient = nil
+		if cert := r.keyPair.Load(); cert != nil {
+			cfg.Certificates = []tls.Certificate{*cert}
+		}
+		if pool := r.pool.Load(); pool != nil {
+			cfg.ClientCAs = pool
+		}
+		return cfg, nil
+	}
+}
+
+// getClientCertificate returns the latest client certificate.
+func (r *reloader) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.check()
+	if cert := r.keyPair.Load(); cert != nil {
+		return cert, nil
+	}
+	return new(tls.Certificate), nil
+}
+
+// clients maps the client configurations which reload their root CA pool to their reloaders.
+var clients sync.Map[*Config, *reloader]
+
+// Clone returns a copy of the client configuration cfg to be used for a single handshake.
+// When cfg reloads its credentials, the copy has the latest root CA pool,
+// so the standard verification of the server certificate chain and host name, including IP SANs, uses the rotated CAs.
+func Clone(cfg *Config) *Config {
+	if cfg == nil {
+		return nil
+	}
+	c := cfg.Clone()
+	if r, ok := clients.Load(cfg); ok && r != nil {
+		r.check()
+		if pool := r.pool.Load(); pool != nil {
+			c.RootCAs = pool
+		}
+	}
+	return c
+}
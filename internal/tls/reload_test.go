@@ -0,0 +1,360 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package tls provides implementation of Go API for tls certificate provider
+package tls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/internal/test/goleak"
+)
+
+type testCredential struct {
+	caKey  *ecdsa.PrivateKey
+	caCert *x509.Certificate
+	caPEM  []byte
+}
+
+func newTestCredential(t *testing.T) *testCredential {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "vald test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCredential{
+		caKey:  key,
+		caCert: cert,
+		caPEM:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns the PEM encoded certificate and key for localhost signed by the CA.
+func (tc *testCredential) issue(t *testing.T, serial int64, notAfter time.Time) (cert, key []byte) {
+	t.Helper()
+	return tc.issueFor(t, serial, notAfter, []string{"localhost"}, nil)
+}
+
+// issueFor returns the PEM encoded certificate and key for the host names and IP addresses signed by the CA.
+func (tc *testCredential) issueFor(
+	t *testing.T, serial int64, notAfter time.Time, names []string, ips []net.IP,
+) (cert, key []byte) {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "vald test server"},
+		DNSNames:     names,
+		IPAddresses:  ips,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tc.caCert, &k.PublicKey, tc.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kder, err := x509.MarshalECPrivateKey(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kder})
+}
+
+type testFiles struct {
+	cert string
+	key  string
+	ca   string
+}
+
+// write writes the credential files and moves their modification time forward so that the change is always detected.
+func (f testFiles) write(t *testing.T, mod time.Time, cert, key, ca []byte) {
+	t.Helper()
+	for path, data := range map[string][]byte{f.cert: cert, f.key: key, f.ca: ca} {
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// handshake runs a TLS handshake with localhost over an in-memory connection and returns the server certificate seen by the client.
+func handshake(srv, cli *tls.Config) (*x509.Certificate, error) {
+	return handshakeWith(srv, cli, "localhost")
+}
+
+// handshakeWith runs a TLS handshake with the server name over an in-memory connection
+// using the copy of the client configuration for the handshake, and returns the server certificate seen by the client.
+func handshakeWith(srv, cli *tls.Config, serverName string) (*x509.Certificate, error) {
+	sc, cc := net.Pipe()
+	defer sc.Close()
+	defer cc.Close()
+
+	ech := make(chan error, 1)
+	go func() {
+		s := tls.Server(sc, srv)
+		err := s.Handshake()
+		if err != nil {
+			sc.Close()
+		}
+		ech <- err
+	}()
+
+	cli = Clone(cli)
+	cli.ServerName = serverName
+	c := tls.Client(cc, cli)
+	cerr := c.Handshake()
+	if cerr != nil {
+		cc.Close()
+	}
+	serr := <-ech
+	if cerr != nil {
+		return nil, cerr
+	}
+	if serr != nil {
+		return nil, serr
+	}
+	return c.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestReload(t *testing.T) {
+	type want struct {
+		serial  int64
+		success bool
+		failure bool
+	}
+	type test struct {
+		name     string
+		interval string
+		rotate   func(*testing.T, *testCredential, testFiles)
+		want     want
+	}
+	tests := []test{
+		{
+			name:     "serves the rotated certificate signed by the same CA",
+			interval: "1ns",
+			rotate: func(t *testing.T, cred *testCredential, f testFiles) {
+				t.Helper()
+				cert, key := cred.issue(t, 2, time.Now().Add(2*time.Hour))
+				f.write(t, time.Now().Add(time.Minute), cert, key, cred.caPEM)
+			},
+			want: want{
+				serial:  2,
+				success: true,
+			},
+		},
+		{
+			name:     "serves the rotated certificate signed by the rotated CA",
+			interval: "1ns",
+			rotate: func(t *testing.T, _ *testCredential, f testFiles) {
+				t.Helper()
+				cred := newTestCredential(t)
+				cert, key := cred.issue(t, 2, time.Now().Add(2*time.Hour))
+				f.write(t, time.Now().Add(time.Minute), cert, key, cred.caPEM)
+			},
+			want: want{
+				serial:  2,
+				success: true,
+			},
+		},
+		{
+			name:     "keeps the current certificate when the rotated files are invalid",
+			interval: "1ns",
+			rotate: func(t *testing.T, _ *testCredential, f testFiles) {
+				t.Helper()
+				f.write(t, time.Now().Add(time.Minute), []byte("invalid"), []byte("invalid"), []byte("invalid"))
+			},
+			want: want{
+				serial:  1,
+				failure: true,
+			},
+		},
+		{
+			name:     "keeps the current certificate when reloading is disabled",
+			interval: "",
+			rotate: func(t *testing.T, _ *testCredential, f testFiles) {
+				t.Helper()
+				cred := newTestCredential(t)
+				cert, key := cred.issue(t, 2, time.Now().Add(2*time.Hour))
+				f.write(t, time.Now().Add(time.Minute), cert, key, cred.caPEM)
+			},
+			want: want{
+				serial: 1,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			defer goleak.VerifyNone(tt, goleakIgnoreOptions...)
+			dir := tt.TempDir()
+			f := testFiles{
+				cert: filepath.Join(dir, "tls.crt"),
+				key:  filepath.Join(dir, "tls.key"),
+				ca:   filepath.Join(dir, "ca.crt"),
+			}
+			cred := newTestCredential(tt)
+			cert, key := cred.issue(tt, 1, time.Now().Add(time.Hour))
+			f.write(tt, time.Now(), cert, key, cred.caPEM)
+
+			opts := []Option{
+				WithCert(f.cert),
+				WithKey(f.key),
+				WithCa(f.ca),
+				WithReloadInterval(test.interval),
+			}
+			srv, err := New(opts...)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			cli, err := NewClientConfig(opts...)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			got, err := handshake(srv, cli)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if got.SerialNumber.Int64() != 1 {
+				tt.Fatalf("initial serial = %d, want 1", got.SerialNumber.Int64())
+			}
+
+			test.rotate(tt, cred, f)
+			got, err = handshake(srv, cli)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if got.SerialNumber.Int64() != test.want.serial {
+				tt.Errorf("serial = %d, want %d", got.SerialNumber.Int64(), test.want.serial)
+			}
+
+			stats, ok := Metrics(context.Background())[f.cert]
+			if !ok {
+				tt.Fatalf("no metrics recorded for %s", f.cert)
+			}
+			if !stats.NotAfter.Equal(got.NotAfter) {
+				tt.Errorf("expiry = %v, want %v", stats.NotAfter, got.NotAfter)
+			}
+			if (stats.Success != 0) != test.want.success {
+				tt.Errorf("success = %d, want reloaded: %v", stats.Success, test.want.success)
+			}
+			if (stats.Failure != 0) != test.want.failure {
+				tt.Errorf("failure = %d, want failed: %v", stats.Failure, test.want.failure)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	type test struct {
+		name       string
+		interval   string
+		serverName string
+		wantErr    bool
+	}
+	tests := []test{
+		{
+			name:       "accepts the certificate for the dialed IP address when reloading is enabled",
+			interval:   "1ns",
+			serverName: "10.9.9.9",
+		},
+		{
+			name:       "rejects the certificate for another IP address when reloading is enabled",
+			interval:   "1ns",
+			serverName: "127.0.0.1",
+			wantErr:    true,
+		},
+		{
+			name:       "rejects the certificate for another IP address when reloading is disabled",
+			interval:   "",
+			serverName: "127.0.0.1",
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			defer goleak.VerifyNone(tt, goleakIgnoreOptions...)
+			dir := tt.TempDir()
+			f := testFiles{
+				cert: filepath.Join(dir, "tls.crt"),
+				key:  filepath.Join(dir, "tls.key"),
+				ca:   filepath.Join(dir, "ca.crt"),
+			}
+			cred := newTestCredential(tt)
+			cert, key := cred.issueFor(tt, 1, time.Now().Add(time.Hour), nil, []net.IP{net.ParseIP("10.9.9.9")})
+			f.write(tt, time.Now(), cert, key, cred.caPEM)
+
+			opts := []Option{
+				WithCert(f.cert),
+				WithKey(f.key),
+				WithCa(f.ca),
+				WithReloadInterval(test.interval),
+			}
+			srv, err := New(opts...)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			cli, err := NewClientConfig(opts...)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			_, err = handshakeWith(srv, cli, test.serverName)
+			if (err != nil) != test.wantErr {
+				tt.Errorf("error = %v, want error: %v", err, test.wantErr)
+			}
+		})
+	}
+}
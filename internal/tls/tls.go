@@ -21,6 +21,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"reflect"
+	"time"
 
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/file"
@@ -38,6 +39,8 @@ type credentials struct {
 	key      string
 	ca       string
 	insecure bool
+
+	reloadInterval time.Duration
 }
 
 var (
@@ -60,21 +63,21 @@ func New(opts ...Option) (*Config, error) {
 		return nil, errors.ErrTLSCertOrKeyNotFound
 	}
 
-	c.cfg.Certificates = make([]tls.Certificate, 1)
-	c.cfg.Certificates[0], err = tls.LoadX509KeyPair(c.cert, c.key)
-	if err != nil {
+	r := newReloader(c)
+	if err = r.load(true); err != nil {
 		return nil, err
 	}
 
-	if c.ca != "" {
-		pool, err := NewX509CertPool(c.ca)
-		if err != nil {
-			return nil, err
-		}
+	c.cfg.Certificates = []tls.Certificate{*r.keyPair.Load()}
+	if pool := r.pool.Load(); pool != nil {
 		c.cfg.ClientCAs = pool
 		c.cfg.ClientAuth = tls.RequireAndVerifyClientCert
 	}
 
+	if c.reloadInterval > 0 {
+		c.cfg.GetConfigForClient = r.getConfigForClient(c.cfg)
+	}
+
 	return c.cfg, nil
 }
 
@@ -84,19 +87,29 @@ func NewClientConfig(opts ...Option) (*Config, error) {
 		return nil, err
 	}
 
-	if c.ca != "" {
-		pool, err := NewX509CertPool(c.ca)
-		if err != nil {
-			return nil, err
-		}
+	if c.cert == "" || c.key == "" {
+		c.cert, c.key = "", ""
+	}
+
+	r := newReloader(c)
+	if err = r.load(true); err != nil {
+		return nil, err
+	}
+
+	if pool := r.pool.Load(); pool != nil {
 		c.cfg.RootCAs = pool
 	}
+	if cert := r.keyPair.Load(); cert != nil {
+		c.cfg.Certificates = []tls.Certificate{*cert}
+	}
 
-	if c.cert != "" && c.key != "" {
-		c.cfg.Certificates = make([]tls.Certificate, 1)
-		c.cfg.Certificates[0], err = tls.LoadX509KeyPair(c.cert, c.key)
-		if err != nil {
-			return nil, err
+	if c.reloadInterval > 0 {
+		if c.cert != "" {
+			c.cfg.GetClientCertificate = r.getClientCertificate
+		}
+		if c.ca != "" && !c.insecure {
+			// the rotated root CAs are used by the handshakes of the copies returned by Clone.
+			clients.Store(c.cfg, r)
 		}
 	}
 
@@ -39,7 +39,7 @@ func (s *server) CreateIndex(
 		}
 	}()
 	res = new(payload.Empty)
-	err = s.faiss.CreateIndex(ctx)
+	err = s.core(ctx).CreateIndex(ctx)
 	if err != nil {
 		if errors.Is(err, errors.ErrUncommittedIndexNotFound) {
 			err = status.WrapWithFailedPrecondition(fmt.Sprintf("CreateIndex API failed"), err,
@@ -93,7 +93,7 @@ func (s *server) SaveIndex(ctx context.Context, _ *payload.Empty) (res *payload.
 		}
 	}()
 	res = new(payload.Empty)
-	err = s.faiss.SaveIndex(ctx)
+	err = s.core(ctx).SaveIndex(ctx)
 	if err != nil {
 		log.Error(err)
 		err = status.WrapWithInternal("SaveIndex API failed to save indices", err,
@@ -122,7 +122,7 @@ func (s *server) CreateAndSaveIndex(
 		}
 	}()
 	res = new(payload.Empty)
-	err = s.faiss.CreateAndSaveIndex(ctx)
+	err = s.core(ctx).CreateAndSaveIndex(ctx)
 	if err != nil {
 		if errors.Is(err, errors.ErrUncommittedIndexNotFound) {
 			err = status.WrapWithFailedPrecondition(fmt.Sprintf("CreateAndSaveIndex API failed to create indexes pool_size = %d", c.GetPoolSize()), err,
@@ -178,9 +178,9 @@ func (s *server) IndexInfo(
 	}()
 
 	return &payload.Info_Index_Count{
-		Stored:      uint32(s.faiss.Len()),
-		Uncommitted: uint32(s.faiss.InsertVQueueBufferLen() + s.faiss.DeleteVQueueBufferLen()),
-		Indexing:    s.faiss.IsIndexing(),
-		Saving:      s.faiss.IsSaving(),
+		Stored:      uint32(s.core(ctx).Len()),
+		Uncommitted: uint32(s.core(ctx).InsertVQueueBufferLen() + s.core(ctx).DeleteVQueueBufferLen()),
+		Indexing:    s.core(ctx).IsIndexing(),
+		Saving:      s.core(ctx).IsSaving(),
 	}, nil
 }
@@ -42,8 +42,8 @@ func (s *server) Insert(
 		}
 	}()
 	vec := req.GetVector()
-	if len(vec.GetVector()) != s.faiss.GetDimensionSize() {
-		err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.faiss.GetDimensionSize()))
+	if len(vec.GetVector()) != s.core(ctx).GetDimensionSize() {
+		err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.core(ctx).GetDimensionSize()))
 		err = status.WrapWithInvalidArgument("Insert API Incompatible Dimension Size detected",
 			err,
 			&errdetails.RequestInfo{
@@ -71,7 +71,7 @@ func (s *server) Insert(
 		return nil, err
 	}
 
-	err = s.faiss.InsertWithTime(vec.GetId(), vec.GetVector(), req.GetConfig().GetTimestamp())
+	err = s.core(ctx).InsertWithTime(vec.GetId(), vec.GetVector(), req.GetConfig().GetTimestamp())
 	if err != nil {
 		var attrs []attribute.KeyValue
 
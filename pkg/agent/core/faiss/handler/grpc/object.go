@@ -67,7 +67,7 @@ func (s *server) Exists(
 		log.Warn(err)
 		return nil, err
 	}
-	if _, ok := s.faiss.Exists(uuid); !ok {
+	if _, ok := s.core(ctx).Exists(uuid); !ok {
 		err = errors.ErrObjectIDNotFound(uid.GetId())
 		err = status.WrapWithNotFound(fmt.Sprintf("Exists API meta %s's uuid not found", uid.GetId()), err,
 			&errdetails.RequestInfo{
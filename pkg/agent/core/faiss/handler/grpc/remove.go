@@ -69,7 +69,7 @@ func (s *server) Remove(
 		log.Warn(err)
 		return nil, err
 	}
-	err = s.faiss.DeleteWithTime(uuid, req.GetConfig().GetTimestamp())
+	err = s.core(ctx).DeleteWithTime(uuid, req.GetConfig().GetTimestamp())
 	if err != nil {
 		var attrs []attribute.KeyValue
 		if errors.Is(err, errors.ErrObjectIDNotFound(uuid)) {
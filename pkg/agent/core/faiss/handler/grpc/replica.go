@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package grpc provides grpc server logic
+package grpc
+
+import (
+	"fmt"
+
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/errdetails"
+	"github.com/vdaas/vald/pkg/agent/internal/replica"
+)
+
+// ReplicaInterceptor returns the unary interceptor which rejects the requests with Unavailable
+// while the read replica has not loaded any index generation.
+func (s *server) ReplicaInterceptor() grpc.UnaryServerInterceptor {
+	return replica.UnaryServerInterceptor(s.faiss, s.replicaResource())
+}
+
+// ReplicaStreamInterceptor returns the stream interceptor which rejects the streams with Unavailable
+// while the read replica has not loaded any index generation.
+func (s *server) ReplicaStreamInterceptor() grpc.StreamServerInterceptor {
+	return replica.StreamServerInterceptor(s.faiss, s.replicaResource())
+}
+
+func (s *server) replicaResource() *errdetails.ResourceInfo {
+	return &errdetails.ResourceInfo{
+		ResourceType: faissResourceType + "/faiss.Replica",
+		ResourceName: fmt.Sprintf("%s: %s(%s)", apiName, s.name, s.ip),
+	}
+}
@@ -40,8 +40,8 @@ func (s *server) Search(
 			span.End()
 		}
 	}()
-	if len(req.GetVector()) != s.faiss.GetDimensionSize() {
-		err = errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.faiss.GetDimensionSize()))
+	if len(req.GetVector()) != s.core(ctx).GetDimensionSize() {
+		err = errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.core(ctx).GetDimensionSize()))
 		err = status.WrapWithInvalidArgument("Search API Incompatible Dimension Size detected",
 			err,
 			&errdetails.RequestInfo{
@@ -67,7 +67,7 @@ func (s *server) Search(
 		}
 		return nil, err
 	}
-	res, err = s.faiss.Search(
+	res, err = s.core(ctx).Search(
 		req.GetConfig().GetNum(),
 		req.GetConfig().GetNprobe(),
 		1,
@@ -117,7 +117,7 @@ func (s *server) Search(
 				}, info.Get())
 			log.Error(err)
 			attrs = trace.StatusCodeInternal(err.Error())
-		case errors.Is(err, errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.faiss.GetDimensionSize()))):
+		case errors.Is(err, errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.core(ctx).GetDimensionSize()))):
 			err = status.WrapWithInvalidArgument("Search API Incompatible Dimension Size detected",
 				err,
 				&errdetails.RequestInfo{
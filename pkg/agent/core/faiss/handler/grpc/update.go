@@ -42,8 +42,8 @@ func (s *server) Update(
 	}()
 
 	vec := req.GetVector()
-	if len(vec.GetVector()) != s.faiss.GetDimensionSize() {
-		err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.faiss.GetDimensionSize()))
+	if len(vec.GetVector()) != s.core(ctx).GetDimensionSize() {
+		err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.core(ctx).GetDimensionSize()))
 		err = status.WrapWithInvalidArgument("Update API Incompatible Dimension Size detected",
 			err,
 			&errdetails.RequestInfo{
@@ -95,7 +95,7 @@ func (s *server) Update(
 		return nil, err
 	}
 
-	err = s.faiss.UpdateWithTime(uuid, vec.GetVector(), req.GetConfig().GetTimestamp())
+	err = s.core(ctx).UpdateWithTime(uuid, vec.GetVector(), req.GetConfig().GetTimestamp())
 	if err != nil {
 		var attrs []attribute.KeyValue
 		if errors.Is(err, errors.ErrObjectIDNotFound(vec.GetId())) {
@@ -110,7 +110,7 @@ func (s *server) Update(
 				})
 			log.Warn(err)
 			attrs = trace.StatusCodeNotFound(err.Error())
-		} else if errors.Is(err, errors.ErrUUIDNotFound(0)) || errors.Is(err, errors.ErrInvalidDimensionSize(len(vec.GetVector()), s.faiss.GetDimensionSize())) {
+		} else if errors.Is(err, errors.ErrUUIDNotFound(0)) || errors.Is(err, errors.ErrInvalidDimensionSize(len(vec.GetVector()), s.core(ctx).GetDimensionSize())) {
 			err = status.WrapWithInvalidArgument(fmt.Sprintf("Update API invalid argument for uuid \"%s\" vec \"%v\" detected", vec.GetId(), vec.GetVector()), err,
 				&errdetails.RequestInfo{
 					RequestId:   req.GetVector().GetId(),
@@ -55,6 +55,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				"/",
+				"/",
 				h.Index,
 			},
 			{
@@ -63,6 +64,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				"/search",
+				"/vald.v1.Search/Search",
 				h.Search,
 			},
 			{
@@ -71,6 +73,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				"/id/search",
+				"/vald.v1.Search/SearchByID",
 				h.SearchByID,
 			},
 			{
@@ -79,6 +82,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				"/linearsearch",
+				"/vald.v1.Search/LinearSearch",
 				h.LinearSearch,
 			},
 			{
@@ -87,6 +91,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				"/id/linearsearch",
+				"/vald.v1.Search/LinearSearchByID",
 				h.LinearSearchByID,
 			},
 			{
@@ -95,6 +100,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				"/insert",
+				"/vald.v1.Insert/Insert",
 				h.Insert,
 			},
 			{
@@ -103,6 +109,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				"/insert/multi",
+				"/vald.v1.Insert/MultiInsert",
 				h.MultiInsert,
 			},
 			{
@@ -113,6 +120,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				"/update",
+				"/vald.v1.Update/Update",
 				h.Update,
 			},
 			{
@@ -123,6 +131,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				"/update/multi",
+				"/vald.v1.Update/MultiUpdate",
 				h.MultiUpdate,
 			},
 			{
@@ -131,6 +140,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodDelete,
 				},
 				"/delete",
+				"/vald.v1.Remove/Remove",
 				h.Remove,
 			},
 			{
@@ -140,6 +150,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				"/delete/multi",
+				"/vald.v1.Remove/MultiRemove",
 				h.MultiRemove,
 			},
 			{
@@ -148,6 +159,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				"/index/create",
+				"/core.v1.Agent/CreateIndex",
 				h.CreateIndex,
 			},
 			{
@@ -156,6 +168,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				"/index/save",
+				"/core.v1.Agent/SaveIndex",
 				h.SaveIndex,
 			},
 			{
@@ -164,6 +177,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				"/object/{id}",
+				"/vald.v1.Object/GetObject",
 				h.GetObject,
 			},
 		}...))
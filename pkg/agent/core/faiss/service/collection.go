@@ -0,0 +1,52 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"slices"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/pkg/agent/internal/collection"
+)
+
+// Collections manages the named Faiss indexes hosted next to the default index.
+// Every collection owns its own kvs, vqueue and auto indexing loop.
+type Collections = collection.Collections[Faiss]
+
+// NewCollections returns the collection manager. opts are the options used for the default index,
+// each collection overrides the index path, dimension and distance type.
+func NewCollections(cfg *config.Faiss, opts ...Option) (Collections, error) {
+	return collection.New(collection.Dir(cfg.IndexPath, cfg.EnableInMemoryMode),
+		// the distance type of the collection is used as the metric type,
+		// and the object type is ignored since Faiss stores only float vectors.
+		func(def *payload.Collection_Config, path string) (Faiss, error) {
+			c := *cfg
+			c.Dimension = int(def.GetDimension())
+			if def.GetDistanceType() != "" {
+				c.MetricType = def.GetDistanceType()
+			}
+			opts := slices.Clone(opts)
+			if path == "" {
+				opts = append(opts, WithEnableInMemoryMode(true))
+			} else {
+				opts = append(opts, WithIndexPath(path))
+			}
+			return newFaiss(&c, opts...)
+		})
+}
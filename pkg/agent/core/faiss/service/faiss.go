@@ -44,6 +44,7 @@ import (
 	"github.com/vdaas/vald/pkg/agent/internal/kvs"
 	"github.com/vdaas/vald/pkg/agent/internal/memstore"
 	"github.com/vdaas/vald/pkg/agent/internal/metadata"
+	"github.com/vdaas/vald/pkg/agent/internal/replica"
 	"github.com/vdaas/vald/pkg/agent/internal/vqueue"
 )
 
@@ -65,6 +66,7 @@ type (
 		Train(nb int, vec []float32) (err error)
 		IsIndexing() bool
 		IsSaving() bool
+		IsReplicaPending() bool
 		Len() uint64
 		NumberOfCreateIndexExecution() uint64
 		NumberOfProactiveGCExecution() uint64
@@ -123,6 +125,18 @@ type (
 		dcd               bool          // disable commit daemon
 		idelay            time.Duration // initial delay duration
 		kvsdbConcurrency  int           // kvsdb concurrency
+
+		cfg  *config.Faiss
+		opts []Option
+
+		// read replica
+		isReadReplica bool
+		replicaSource string        // source of the read replica index
+		replicaDur    time.Duration // reload check duration of the index generation pulled from the blob storage
+		replicaBase   string        // index path which the agent sidecar pulls the index generations to
+		replicaGen    string        // ID of the loaded index generation
+		strictLoad    bool          // return the load error instead of starting with the empty index, and never write to the index path
+		rcmu          sync.RWMutex  // held for reading while the index generation is in use, and for writing while it is swapped
 	}
 )
 
@@ -140,6 +154,10 @@ const (
 )
 
 func New(cfg *config.Faiss, opts ...Option) (Faiss, error) {
+	return newFaiss(cfg, opts...)
+}
+
+func newFaiss(cfg *config.Faiss, opts ...Option) (*faiss, error) {
 	var (
 		f = &faiss{
 			fmap:              make(map[string]int64),
@@ -149,6 +167,8 @@ func New(cfg *config.Faiss, opts ...Option) (Faiss, error) {
 			enableProactiveGC: cfg.EnableProactiveGC,
 			enableCopyOnWrite: cfg.EnableCopyOnWrite,
 			kvsdbConcurrency:  cfg.KVSDB.Concurrency,
+			cfg:               cfg,
+			opts:              opts,
 		}
 		err error
 	)
@@ -159,6 +179,10 @@ func New(cfg *config.Faiss, opts ...Option) (Faiss, error) {
 		}
 	}
 
+	if f.isReadReplica && f.replicaSource == replica.SourceBlob {
+		f.resolveReplicaGeneration()
+	}
+
 	if len(f.path) == 0 {
 		f.inMem = true
 	}
@@ -231,6 +255,13 @@ func (f *faiss) initFaiss(opts ...core.Option) error {
 
 	ctx := context.Background()
 	err = f.load(ctx, f.path, opts...)
+	if err != nil && f.strictLoad {
+		if f.core != nil {
+			f.core.Close()
+			f.core = nil
+		}
+		return errors.Wrapf(err, "failed to load index from %s", f.path)
+	}
 	var current uint64
 	if err != nil {
 		if !f.enableCopyOnWrite {
@@ -447,6 +478,9 @@ func (f *faiss) load(ctx context.Context, path string, opts ...core.Option) erro
 		if f.kvs != nil && float64(agentMetadata.Faiss.IndexCount/2) > float64(f.kvs.Len()) {
 			return errors.ErrIndicesAreTooFewComparedToMetadata
 		}
+		if f.strictLoad && f.kvs != nil && f.kvs.Len() != agentMetadata.Faiss.IndexCount {
+			return errors.ErrIndexCountMismatch(f.kvs.Len(), agentMetadata.Faiss.IndexCount)
+		}
 		return nil
 	}))
 
@@ -472,6 +506,9 @@ func (f *faiss) load(ctx context.Context, path string, opts ...core.Option) erro
 		return err
 	case <-ctx.Done():
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			if f.strictLoad {
+				return errors.ErrIndexLoadTimeout
+			}
 			log.Errorf("cannot load index backup data from %s within the timeout %s. the process is going to be killed.", path, timeout)
 			err := metadata.Store(metadataPath,
 				&metadata.Metadata{
@@ -598,7 +635,10 @@ func (f *faiss) mktmp() error {
 }
 
 func (f *faiss) Start(ctx context.Context) <-chan error {
-	if f.dcd {
+	if f.isReadReplica && len(f.replicaBase) != 0 {
+		f.startReplicaReload(ctx)
+	}
+	if f.dcd || f.isReadReplica {
 		return nil
 	}
 
@@ -663,6 +703,9 @@ func (f *faiss) Start(ctx context.Context) <-chan error {
 }
 
 func (f *faiss) Train(nb int, xb []float32) error {
+	if f.isReadReplica {
+		return errors.ErrWriteOperationToReadReplica
+	}
 	err := f.core.Train(nb, xb)
 	if err != nil {
 		log.Errorf("failed to faiss train", err)
@@ -751,6 +794,10 @@ func (f *faiss) CreateIndex(ctx context.Context) error {
 		}
 	}()
 
+	if f.isReadReplica {
+		return errors.ErrWriteOperationToReadReplica
+	}
+
 	ic := f.vq.IVQLen() + f.vq.DVQLen() + (len(f.addVecs) / f.dim)
 	if ic == 0 {
 		return errors.ErrUncommittedIndexNotFound
@@ -884,6 +931,10 @@ func (f *faiss) SaveIndex(ctx context.Context) error {
 		}
 	}()
 
+	if f.isReadReplica {
+		return errors.ErrWriteOperationToReadReplica
+	}
+
 	if !f.inMem {
 		return f.saveIndex(ctx)
 	}
@@ -1122,6 +1173,10 @@ func (f *faiss) CreateAndSaveIndex(ctx context.Context) error {
 		}
 	}()
 
+	if f.isReadReplica {
+		return errors.ErrWriteOperationToReadReplica
+	}
+
 	err := f.CreateIndex(ctx)
 	if errors.IsNot(err, errors.ErrUncommittedIndexNotFound, context.Canceled, context.DeadlineExceeded) {
 		return err
@@ -1140,12 +1195,13 @@ func (f *faiss) Search(
 		nprobe = 1
 	}
 
+	defer f.rlockReplica()()
 	sr, err := f.core.Search(int(k), int(nprobe), int(nq), xq)
 	if err != nil {
 		if f.IsIndexing() {
 			return nil, errors.ErrCreateIndexingIsInProgress
 		}
-		if errors.Is(err, errors.ErrSearchResultEmptyButNoDataStored) && f.Len() == 0 {
+		if errors.Is(err, errors.ErrSearchResultEmptyButNoDataStored) && f.kvs.Len() == 0 {
 			return nil, nil
 		}
 		log.Errorf("cgo error detected during search: faiss api returned error %v", err)
@@ -1184,10 +1240,12 @@ func (f *faiss) delete(uuid string, t int64, validation bool) error {
 }
 
 func (f *faiss) Exists(uuid string) (oid uint32, ok bool) {
+	defer f.rlockReplica()()
 	return memstore.Exists(f.kvs, f.vq, uuid)
 }
 
 func (f *faiss) GetObject(uuid string) (vec []float32, timestamp int64, err error) {
+	defer f.rlockReplica()()
 	return memstore.GetObject(f.kvs, f.vq, uuid, nil)
 }
 
@@ -1202,6 +1260,7 @@ func (f *faiss) IsSaving() bool {
 }
 
 func (f *faiss) UUIDs(ctx context.Context) (uuids []string) {
+	defer f.rlockReplica()()
 	return memstore.UUIDs(ctx, f.kvs, f.vq)
 }
 
@@ -1221,14 +1280,17 @@ func (f *faiss) gc() {
 }
 
 func (f *faiss) Len() uint64 {
+	defer f.rlockReplica()()
 	return f.kvs.Len()
 }
 
 func (f *faiss) InsertVQueueBufferLen() uint64 {
+	defer f.rlockReplica()()
 	return uint64(f.vq.IVQLen())
 }
 
 func (f *faiss) DeleteVQueueBufferLen() uint64 {
+	defer f.rlockReplica()()
 	return uint64(f.vq.DVQLen())
 }
 
@@ -1256,6 +1318,10 @@ func (f *faiss) Close(ctx context.Context) (err error) {
 		}
 	}()
 	if len(f.path) != 0 {
+		if f.isReadReplica {
+			log.Info("skip create and save index operation on close because this is read replica")
+			return err
+		}
 		cerr := f.CreateIndex(ctx)
 		if errors.IsNot(cerr, errors.ErrUncommittedIndexNotFound, context.Canceled, context.DeadlineExceeded) {
 			if err != nil {
@@ -1282,6 +1348,7 @@ func (f *faiss) Close(ctx context.Context) (err error) {
 func (f *faiss) ListObjectFunc(
 	ctx context.Context, fn func(uuid string, oid uint32, ts int64) bool,
 ) {
+	defer f.rlockReplica()()
 	memstore.ListObjectFunc(ctx, f.kvs, f.vq, fn)
 }
 
@@ -22,11 +22,13 @@ import (
 	"os"
 	"time"
 
+	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/file"
 	"github.com/vdaas/vald/internal/rand"
 	"github.com/vdaas/vald/internal/strings"
 	"github.com/vdaas/vald/internal/sync/errgroup"
 	"github.com/vdaas/vald/internal/timeutil"
+	"github.com/vdaas/vald/pkg/agent/internal/replica"
 )
 
 // Option represent the functional option for faiss.
@@ -43,6 +45,7 @@ var defaultOptions = []Option{
 	WithMaxLoadIndexTimeout("10m"),
 	WithLoadIndexTimeoutFactor("1ms"),
 	WithProactiveGC(true),
+	WithReadReplicaReloadDuration("10s"),
 }
 
 // WithErrGroup returns the functional option to set the error group.
@@ -269,3 +272,52 @@ func WithCopyOnWrite(enabled bool) Option {
 		return nil
 	}
 }
+
+// WithIsReadReplica returns the functional option to set the read replica flag.
+func WithIsReadReplica(isReadReplica bool) Option {
+	return func(f *faiss) error {
+		f.isReadReplica = isReadReplica
+		return nil
+	}
+}
+
+// WithReadReplicaSource returns the functional option to set the source of the read replica index.
+// The blob source loads the index generation pulled from the blob storage by the agent sidecar in readreplica mode.
+func WithReadReplicaSource(src string) Option {
+	return func(f *faiss) error {
+		if err := replica.ValidateSource(src); err != nil {
+			return errors.NewErrInvalidOption("readReplicaSource", src, err)
+		}
+		f.replicaSource = src
+		return nil
+	}
+}
+
+// WithReadReplicaReloadDuration returns the functional option to set the duration to check the index generation pulled from the blob storage.
+func WithReadReplicaReloadDuration(dur string) Option {
+	return func(f *faiss) error {
+		if dur == "" {
+			return nil
+		}
+
+		d, err := timeutil.Parse(dur)
+		if err != nil {
+			return err
+		}
+		if d > 0 {
+			f.replicaDur = d
+		}
+
+		return nil
+	}
+}
+
+// withReplicaGeneration returns the functional option to set the index generation loaded by the read replica.
+// The generation is loaded strictly, i.e. the load fails instead of falling back to the empty index and the pulled files are never modified.
+func withReplicaGeneration(id string) Option {
+	return func(f *faiss) error {
+		f.replicaGen = id
+		f.strictLoad = true
+		return nil
+	}
+}
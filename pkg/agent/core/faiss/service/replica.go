@@ -0,0 +1,150 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/pkg/agent/internal/replica"
+)
+
+// resolveReplicaGeneration replaces the index path by the directory of the index generation pulled by the agent sidecar.
+// The read replica starts with the empty in-memory index, which is not served until the first generation is loaded.
+func (f *faiss) resolveReplicaGeneration() {
+	if len(f.path) == 0 {
+		return
+	}
+	f.replicaBase = f.path
+	// the read replica never saves the index, and the generations are loaded as they are backed up with or without CoW.
+	f.enableCopyOnWrite = false
+	if f.replicaGen == "" {
+		var err error
+		f.replicaGen, err = replica.Current(f.replicaBase)
+		if err != nil {
+			log.Warnf("failed to read the current index generation in %s: %v", f.replicaBase, err)
+		}
+	}
+	if f.replicaGen == "" {
+		log.Infof("no index generation is pulled to %s yet, the read replica is unavailable until it is loaded", f.replicaBase)
+		f.path = ""
+		return
+	}
+	f.path = replica.Dir(f.replicaBase, f.replicaGen)
+	log.Infof("read replica loads index generation %s", f.replicaGen)
+}
+
+// startReplicaReload starts the loop to reload the index generation when the agent sidecar points the CURRENT file to a new one.
+func (f *faiss) startReplicaReload(ctx context.Context) {
+	f.eg.Go(safety.RecoverFunc(func() error {
+		tick := time.NewTicker(f.replicaDur)
+		defer tick.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-tick.C:
+			}
+			id, err := replica.Current(f.replicaBase)
+			if err != nil {
+				log.Errorf("failed to read the current index generation in %s: %v", f.replicaBase, err)
+				continue
+			}
+			if id == "" || id == f.replicaGen {
+				continue
+			}
+			if err = f.reloadReplica(id); err != nil {
+				log.Errorf("failed to reload index generation %s: %v", id, err)
+			}
+		}
+	}))
+}
+
+// reloadReplica loads the index generation id to the new instance, and then swaps it with the current one.
+// The searches are served by the current instance while the new one is loading.
+// The swap waits for the in-flight searches of the current instance, so the old instances are closed only after nothing reads them.
+func (f *faiss) reloadReplica(id string) (err error) {
+	nf, err := newFaiss(f.cfg, append(f.opts, withReplicaGeneration(id))...)
+	if err != nil {
+		return err
+	}
+
+	f.rcmu.Lock()
+	f.cimu.Lock()
+	oc, okvs := f.core, f.kvs
+	f.copyFaiss(nf)
+	f.cimu.Unlock()
+	f.rcmu.Unlock()
+
+	oc.Close()
+	if err = okvs.Close(); err != nil {
+		log.Warnf("failed to close the previous index generation: %v", err)
+	}
+	runtime.GC()
+	atomic.AddUint64(&f.nogce, 1)
+
+	log.Infof("read replica switched to index generation %s, %d objects", id, f.kvs.Len())
+	return nil
+}
+
+// IsReplicaPending returns true while the read replica has not loaded any index generation pulled from the blob storage.
+// The index is empty until then, so it must not serve requests.
+func (f *faiss) IsReplicaPending() bool {
+	if len(f.replicaBase) == 0 {
+		return false
+	}
+	f.rcmu.RLock()
+	defer f.rcmu.RUnlock()
+	return f.replicaGen == ""
+}
+
+// rlockReplica locks the index generation of the read replica for reading, and returns the function to unlock it.
+// It does nothing unless the agent reloads the index generations pulled from the blob storage.
+func (f *faiss) rlockReplica() (runlock func()) {
+	if len(f.replicaBase) == 0 {
+		return func() {}
+	}
+	f.rcmu.RLock()
+	return f.rcmu.RUnlock
+}
+
+func (f *faiss) copyFaiss(src *faiss) {
+	// instances
+	f.core = src.core
+	f.kvs = src.kvs
+	f.fmap = src.fmap
+	f.vq = src.vq
+	f.addVecs = src.addVecs
+	f.addIds = src.addIds
+	f.isTrained = src.isTrained
+	f.icnt = src.icnt
+
+	// paths
+	f.inMem = src.inMem
+	f.path = src.path
+	if tmp := src.tmpPath.Load(); tmp != nil {
+		f.tmpPath.Store(tmp)
+	}
+	f.oldPath = src.oldPath
+	f.basePath = src.basePath
+	f.replicaGen = src.replicaGen
+}
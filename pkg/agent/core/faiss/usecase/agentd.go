@@ -42,13 +42,13 @@ type run struct {
 	eg            errgroup.Group
 	cfg           *config.Data
 	faiss         service.Faiss
+	collections   service.Collections
 	server        starter.Server
 	observability observability.Observability
 }
 
 func New(cfg *config.Data) (r runner.Runner, err error) {
-	faiss, err := service.New(
-		cfg.Faiss,
+	serviceOpts := []service.Option{
 		service.WithErrGroup(errgroup.Get()),
 		service.WithEnableInMemoryMode(cfg.Faiss.EnableInMemoryMode),
 		service.WithIndexPath(cfg.Faiss.IndexPath),
@@ -62,13 +62,23 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		service.WithLoadIndexTimeoutFactor(cfg.Faiss.LoadIndexTimeoutFactor),
 		service.WithProactiveGC(cfg.Faiss.EnableProactiveGC),
 		service.WithCopyOnWrite(cfg.Faiss.EnableCopyOnWrite),
-	)
+		service.WithIsReadReplica(cfg.Faiss.IsReadReplica),
+		service.WithReadReplicaSource(cfg.Faiss.ReadReplicaSource),
+		service.WithReadReplicaReloadDuration(cfg.Faiss.ReadReplicaReloadDuration),
+	}
+	faiss, err := service.New(cfg.Faiss, serviceOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := service.NewCollections(cfg.Faiss, serviceOpts...)
 	if err != nil {
 		return nil, err
 	}
 
 	g, err := handler.New(
 		handler.WithFaiss(faiss),
+		handler.WithCollections(collections),
 		handler.WithStreamConcurrency(cfg.Server.GetGRPCStreamConcurrency()),
 	)
 	if err != nil {
@@ -81,7 +91,12 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		server.WithGRPCRegistFunc(func(srv *grpc.Server) {
 			agent.RegisterAgentServer(srv, g)
 			vald.RegisterValdServer(srv, g)
+			vald.RegisterCollectionServer(srv, g)
 		}),
+		server.WithGRPCOption(
+			grpc.ChainUnaryInterceptor(g.ReplicaInterceptor(), g.CollectionInterceptor()),
+			grpc.ChainStreamInterceptor(g.ReplicaStreamInterceptor(), g.CollectionStreamInterceptor()),
+		),
 		server.WithPreStartFunc(func() error {
 			return nil
 		}),
@@ -130,6 +145,7 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 	return &run{
 		eg:            eg,
 		faiss:         faiss,
+		collections:   collections,
 		cfg:           cfg,
 		server:        srv,
 		observability: obs,
@@ -145,14 +161,15 @@ func (r *run) PreStart(ctx context.Context) error {
 }
 
 func (r *run) Start(ctx context.Context) (<-chan error, error) {
-	ech := make(chan error, 3)
-	var oech, nech, sech <-chan error
+	ech := make(chan error, 4)
+	var oech, nech, cech, sech <-chan error
 	r.eg.Go(safety.RecoverFunc(func() (err error) {
 		defer close(ech)
 		if r.observability != nil {
 			oech = r.observability.Start(ctx)
 		}
 		nech = r.faiss.Start(ctx)
+		cech = r.collections.Start(ctx)
 		sech = r.server.ListenAndServe(ctx)
 		for {
 			select {
@@ -160,6 +177,7 @@ func (r *run) Start(ctx context.Context) (<-chan error, error) {
 				return ctx.Err()
 			case err = <-oech:
 			case err = <-nech:
+			case err = <-cech:
 			case err = <-sech:
 			}
 			if err != nil {
@@ -188,6 +206,7 @@ func (r *run) Stop(ctx context.Context) error {
 }
 
 func (r *run) PostStop(ctx context.Context) error {
+	r.collections.Close(ctx)
 	r.faiss.Close(ctx)
 	return nil
 }
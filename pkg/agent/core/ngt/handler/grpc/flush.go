@@ -39,7 +39,7 @@ func (s *server) Flush(
 			span.End()
 		}
 	}()
-	err := s.ngt.RegenerateIndexes(ctx)
+	err := s.core(ctx).RegenerateIndexes(ctx)
 	if err != nil {
 		var attrs []attribute.KeyValue
 		if errors.Is(err, errors.ErrFlushingIsInProgress) {
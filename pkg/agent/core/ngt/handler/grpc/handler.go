@@ -18,6 +18,8 @@
 package grpc
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 
 	agent "github.com/vdaas/vald/apis/grpc/v1/agent/core"
@@ -25,23 +27,32 @@ import (
 	"github.com/vdaas/vald/apis/grpc/v1/vald"
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net/grpc"
 	"github.com/vdaas/vald/internal/sync/errgroup"
 	"github.com/vdaas/vald/pkg/agent/core/ngt/service"
+	"github.com/vdaas/vald/pkg/agent/internal/collection"
 )
 
 type Server interface {
 	agent.AgentServer
 	vald.Server
+	vald.CollectionServer
+	ReplicaInterceptor() grpc.UnaryServerInterceptor
+	ReplicaStreamInterceptor() grpc.StreamServerInterceptor
+	CollectionInterceptor() grpc.UnaryServerInterceptor
+	CollectionStreamInterceptor() grpc.StreamServerInterceptor
 }
 
 type server struct {
 	name              string
 	ip                string
 	ngt               service.NGT
+	collections       service.Collections
 	eg                errgroup.Group
 	streamConcurrency int
 	agent.UnimplementedAgentServer
 	vald.UnimplementedValdServer
+	*collection.Handler[service.NGT]
 }
 
 const (
@@ -66,9 +77,19 @@ func New(opts ...Option) (Server, error) {
 			log.Warn(werr)
 		}
 	}
+	s.Handler = collection.NewHandler[service.NGT](s.collections, apiName, ngtResourceType+"/ngt",
+		fmt.Sprintf("%s: %s(%s)", apiName, s.name, s.ip))
 	return s, nil
 }
 
+// core returns the index selected by the request, the default index is used when no collection is selected.
+func (s *server) core(ctx context.Context) service.NGT {
+	if n, ok := collection.FromContext[service.NGT](ctx); ok && n != nil {
+		return n
+	}
+	return s.ngt
+}
+
 func (s *server) newLocations(uuids ...string) (locs *payload.Object_Locations) {
 	if len(uuids) == 0 {
 		return nil
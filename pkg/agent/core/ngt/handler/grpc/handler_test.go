@@ -75,7 +75,7 @@ func newIndexedNGTService(
 
 		// insert and create index
 		for _, req := range reqs.GetRequests() {
-			err := ngt.Insert(req.GetVector().GetId(), req.GetVector().GetVector())
+			err := ngt.Insert(ctx, req.GetVector().GetId(), req.GetVector().GetVector())
 			if err != nil {
 				return nil, err
 			}
@@ -37,7 +37,7 @@ func (s *server) CreateIndex(
 		}
 	}()
 	res = new(payload.Empty)
-	err = s.ngt.CreateIndex(ctx, c.GetPoolSize())
+	err = s.core(ctx).CreateIndex(ctx, c.GetPoolSize())
 	if err != nil {
 		var (
 			code    codes.Code
@@ -96,7 +96,7 @@ func (s *server) SaveIndex(ctx context.Context, _ *payload.Empty) (res *payload.
 		}
 	}()
 	res = new(payload.Empty)
-	err = s.ngt.SaveIndex(ctx)
+	err = s.core(ctx).SaveIndex(ctx)
 	if err != nil {
 		log.Error(err)
 		err = status.WrapWithInternal("SaveIndex API failed to save indices", err,
@@ -125,7 +125,7 @@ func (s *server) CreateAndSaveIndex(
 		}
 	}()
 	res = new(payload.Empty)
-	err = s.ngt.CreateAndSaveIndex(ctx, c.GetPoolSize())
+	err = s.core(ctx).CreateAndSaveIndex(ctx, c.GetPoolSize())
 	if err != nil {
 		var (
 			code    codes.Code
@@ -184,11 +184,12 @@ func (s *server) IndexInfo(
 			span.End()
 		}
 	}()
+	n := s.core(ctx)
 	return &payload.Info_Index_Count{
-		Stored:      uint32(s.ngt.Len()),
-		Uncommitted: uint32(s.ngt.InsertVQueueBufferLen() + s.ngt.DeleteVQueueBufferLen()),
-		Indexing:    s.ngt.IsIndexing(),
-		Saving:      s.ngt.IsSaving(),
+		Stored:      uint32(n.Len()),
+		Uncommitted: uint32(n.InsertVQueueBufferLen() + n.DeleteVQueueBufferLen()),
+		Indexing:    n.IsIndexing(),
+		Saving:      n.IsSaving(),
 	}, nil
 }
 
@@ -206,11 +207,12 @@ func (s *server) IndexDetail(
 		Replica:    1,
 		LiveAgents: 1,
 	}
+	n := s.core(ctx)
 	res.Counts[s.name] = &payload.Info_Index_Count{
-		Stored:      uint32(s.ngt.Len()),
-		Uncommitted: uint32(s.ngt.InsertVQueueBufferLen() + s.ngt.DeleteVQueueBufferLen()),
-		Indexing:    s.ngt.IsIndexing(),
-		Saving:      s.ngt.IsSaving(),
+		Stored:      uint32(n.Len()),
+		Uncommitted: uint32(n.InsertVQueueBufferLen() + n.DeleteVQueueBufferLen()),
+		Indexing:    n.IsIndexing(),
+		Saving:      n.IsSaving(),
 	}
 	return res, nil
 }
@@ -224,7 +226,7 @@ func (s *server) IndexStatistics(
 			span.End()
 		}
 	}()
-	return s.ngt.IndexStatistics()
+	return s.core(ctx).IndexStatistics()
 }
 
 func (s *server) IndexStatisticsDetail(
@@ -236,7 +238,7 @@ func (s *server) IndexStatisticsDetail(
 			span.End()
 		}
 	}()
-	stats, err := s.ngt.IndexStatistics()
+	stats, err := s.core(ctx).IndexStatistics()
 	if err != nil {
 		return nil, err
 	}
@@ -256,7 +258,7 @@ func (s *server) IndexProperty(
 			span.End()
 		}
 	}()
-	prop, err := s.ngt.IndexProperty()
+	prop, err := s.core(ctx).IndexProperty()
 	if err != nil {
 		return nil, err
 	}
@@ -365,7 +365,7 @@ func Test_server_CreateIndex(t *testing.T) {
 				}
 
 				for _, r := range req.Requests {
-					if err := srv.ngt.Insert(r.Vector.Id, r.Vector.Vector); err != nil {
+					if err := srv.ngt.Insert(ctx, r.Vector.Id, r.Vector.Vector); err != nil {
 						t.Fatal(err)
 					}
 				}
@@ -853,7 +853,7 @@ func Test_server_SaveIndex(t *testing.T) {
 					}
 
 					// insert invalid vector to ngt directly
-					if err := n.Insert("uuid-1", vecs[0]); err != nil {
+					if err := n.Insert(ctx, "uuid-1", vecs[0]); err != nil {
 						t.Error(err)
 					}
 					// we need to create index before saving to store the indexed vector
@@ -1593,7 +1593,7 @@ func Test_server_CreateAndSaveIndex(t *testing.T) {
 					}
 
 					// insert invalid vector to ngt directly
-					if err := n.Insert("uuid-1", vecs[0]); err != nil {
+					if err := n.Insert(ctx, "uuid-1", vecs[0]); err != nil {
 						t.Error(err)
 					}
 				},
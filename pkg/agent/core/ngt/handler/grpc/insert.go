@@ -16,6 +16,7 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/vdaas/vald/apis/grpc/v1/payload"
 	"github.com/vdaas/vald/apis/grpc/v1/vald"
@@ -42,8 +43,8 @@ func (s *server) Insert(
 		}
 	}()
 	vec := req.GetVector()
-	if len(vec.GetVector()) != s.ngt.GetDimensionSize() {
-		err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.ngt.GetDimensionSize()))
+	if len(vec.GetVector()) != s.core(ctx).GetDimensionSize() {
+		err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.core(ctx).GetDimensionSize()))
 		err = status.WrapWithInvalidArgument("Insert API Incompatible Dimension Size detected",
 			err,
 			&errdetails.RequestInfo{
@@ -71,7 +72,7 @@ func (s *server) Insert(
 		return nil, err
 	}
 
-	err = s.ngt.InsertWithTime(vec.GetId(), vec.GetVector(), req.GetConfig().GetTimestamp())
+	err = s.core(ctx).InsertWithTTL(ctx, vec.GetId(), vec.GetVector(), req.GetConfig().GetTimestamp(), time.Duration(req.GetConfig().GetTtl())*time.Second)
 	if err != nil {
 		var attrs []attribute.KeyValue
 		if errors.Is(err, errors.ErrFlushingIsInProgress) {
@@ -203,10 +204,11 @@ func (s *server) MultiInsert(
 	}()
 	uuids := make([]string, 0, len(reqs.GetRequests()))
 	vmap := make(map[string][]float32, len(reqs.GetRequests()))
+	var ttls map[string]time.Duration
 	for _, req := range reqs.GetRequests() {
 		vec := req.GetVector()
-		if len(vec.GetVector()) != s.ngt.GetDimensionSize() {
-			err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.ngt.GetDimensionSize()))
+		if len(vec.GetVector()) != s.core(ctx).GetDimensionSize() {
+			err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.core(ctx).GetDimensionSize()))
 			err = status.WrapWithInvalidArgument("MultiInsert API Incompatible Dimension Size detected",
 				err,
 				&errdetails.RequestInfo{
@@ -235,8 +237,14 @@ func (s *server) MultiInsert(
 		}
 		vmap[vec.GetId()] = vec.GetVector()
 		uuids = append(uuids, vec.GetId())
+		if ttl := req.GetConfig().GetTtl(); ttl != 0 {
+			if ttls == nil {
+				ttls = make(map[string]time.Duration, len(reqs.GetRequests()))
+			}
+			ttls[vec.GetId()] = time.Duration(ttl) * time.Second
+		}
 	}
-	err = s.ngt.InsertMultiple(vmap)
+	err = s.core(ctx).InsertMultipleWithTTL(ctx, vmap, ttls)
 	if err != nil {
 		var attrs []attribute.KeyValue
 		if errors.Is(err, errors.ErrFlushingIsInProgress) {
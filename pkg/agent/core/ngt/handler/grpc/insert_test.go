@@ -1088,7 +1088,7 @@ func Test_server_Insert(t *testing.T) {
 				},
 				beforeFunc: func(t *testing.T, s *server) {
 					t.Helper()
-					s.ngt.Insert(id, bVecs[0])
+					s.ngt.Insert(t.Context(), id, bVecs[0])
 				},
 				want: want{
 					err: status.WrapWithAlreadyExists(fmt.Sprintf("Insert API uuid %s already exists", id), errors.ErrUUIDAlreadyExists(id),
@@ -1137,7 +1137,7 @@ func Test_server_Insert(t *testing.T) {
 				},
 				beforeFunc: func(t *testing.T, s *server) {
 					t.Helper()
-					s.ngt.Insert(bID, intVec)
+					s.ngt.Insert(t.Context(), bID, intVec)
 				},
 				want: want{
 					wantRes: &payload.Object_Location{
@@ -1180,7 +1180,7 @@ func Test_server_Insert(t *testing.T) {
 				},
 				beforeFunc: func(t *testing.T, s *server) {
 					t.Helper()
-					s.ngt.Insert(id, intVec)
+					s.ngt.Insert(t.Context(), id, intVec)
 				},
 				want: want{
 					err: status.WrapWithAlreadyExists(fmt.Sprintf("Insert API uuid %s already exists", id), errors.ErrUUIDAlreadyExists(id),
@@ -1232,7 +1232,7 @@ func Test_server_Insert(t *testing.T) {
 				},
 				beforeFunc: func(t *testing.T, s *server) {
 					t.Helper()
-					s.ngt.Insert(id, bVec[0])
+					s.ngt.Insert(t.Context(), id, bVec[0])
 				},
 				want: want{
 					err: status.WrapWithAlreadyExists(fmt.Sprintf("Insert API uuid %s already exists", id), errors.ErrUUIDAlreadyExists(id),
@@ -1281,7 +1281,7 @@ func Test_server_Insert(t *testing.T) {
 				},
 				beforeFunc: func(t *testing.T, s *server) {
 					t.Helper()
-					s.ngt.Insert(bID, intVec)
+					s.ngt.Insert(t.Context(), bID, intVec)
 				},
 				want: want{
 					wantRes: &payload.Object_Location{
@@ -1324,7 +1324,7 @@ func Test_server_Insert(t *testing.T) {
 				},
 				beforeFunc: func(t *testing.T, s *server) {
 					t.Helper()
-					s.ngt.Insert(id, intVec)
+					s.ngt.Insert(t.Context(), id, intVec)
 				},
 				want: want{
 					err: status.WrapWithAlreadyExists(fmt.Sprintf("Insert API uuid %s already exists", id), errors.ErrUUIDAlreadyExists(id),
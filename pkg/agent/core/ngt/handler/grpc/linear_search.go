@@ -30,6 +30,7 @@ import (
 	"github.com/vdaas/vald/internal/safety"
 	"github.com/vdaas/vald/internal/strings"
 	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/pkg/agent/core/ngt/service"
 )
 
 func (s *server) LinearSearch(
@@ -41,8 +42,8 @@ func (s *server) LinearSearch(
 			span.End()
 		}
 	}()
-	if len(req.GetVector()) != s.ngt.GetDimensionSize() {
-		err = errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.ngt.GetDimensionSize()))
+	if len(req.GetVector()) != s.core(ctx).GetDimensionSize() {
+		err = errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.core(ctx).GetDimensionSize()))
 		err = status.WrapWithInvalidArgument("LinearSearch API Incompatible Dimension Size detected",
 			err,
 			&errdetails.RequestInfo{
@@ -68,7 +69,10 @@ func (s *server) LinearSearch(
 		}
 		return nil, err
 	}
-	res, err = s.ngt.LinearSearch(ctx,
+	if req.GetConfig().GetIncludeUncommitted() {
+		ctx = service.WithUncommittedSearch(ctx)
+	}
+	res, err = s.core(ctx).LinearSearch(ctx,
 		req.GetVector(),
 		req.GetConfig().GetNum())
 	if err == nil && res == nil {
@@ -128,7 +132,7 @@ func (s *server) LinearSearch(
 				}, info.Get())
 			log.Error(err)
 			attrs = trace.StatusCodeInternal(err.Error())
-		case errors.Is(err, errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.ngt.GetDimensionSize()))):
+		case errors.Is(err, errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.core(ctx).GetDimensionSize()))):
 			err = status.WrapWithInvalidArgument("LinearSearch API Incompatible Dimension Size detected",
 				err,
 				&errdetails.RequestInfo{
@@ -209,7 +213,10 @@ func (s *server) LinearSearchByID(
 		}
 		return nil, err
 	}
-	vec, res, err := s.ngt.LinearSearchByID(ctx,
+	if req.GetConfig().GetIncludeUncommitted() {
+		ctx = service.WithUncommittedSearch(ctx)
+	}
+	vec, res, err := s.core(ctx).LinearSearchByID(ctx,
 		uuid,
 		req.GetConfig().GetNum())
 	if err == nil && res == nil {
@@ -282,7 +289,7 @@ func (s *server) LinearSearchByID(
 				}, info.Get())
 			log.Error(err)
 			attrs = trace.StatusCodeInternal(err.Error())
-		case errors.Is(err, errors.ErrIncompatibleDimensionSize(len(vec), int(s.ngt.GetDimensionSize()))):
+		case errors.Is(err, errors.ErrIncompatibleDimensionSize(len(vec), int(s.core(ctx).GetDimensionSize()))):
 			err = status.WrapWithInvalidArgument("LinearSearchByID API Incompatible Dimension Size detected",
 				err,
 				&errdetails.RequestInfo{
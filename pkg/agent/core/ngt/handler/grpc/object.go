@@ -67,7 +67,7 @@ func (s *server) Exists(
 		log.Warn(err)
 		return nil, err
 	}
-	if _, ok := s.ngt.Exists(uuid); !ok {
+	if _, ok := s.core(ctx).Exists(uuid); !ok {
 		err = status.New(codes.NotFound, errors.ErrObjectIDNotFound(uid.GetId()).Error()).Err()
 		if span != nil {
 			span.RecordError(err)
@@ -116,7 +116,7 @@ func (s *server) GetObject(
 		}
 		return nil, err
 	}
-	vec, ts, err := s.ngt.GetObject(uuid)
+	vec, ts, err := s.core(ctx).GetObject(uuid)
 	if err != nil || vec == nil {
 		err = status.New(codes.NotFound, errors.ErrObjectNotFound(err, uuid).Error()).Err()
 		if span != nil {
@@ -196,11 +196,11 @@ func (s *server) StreamListObject(
 	var (
 		mu   sync.Mutex
 		emu  sync.RWMutex
-		errs = make([]error, 0, s.ngt.Len())
+		errs = make([]error, 0, s.core(ctx).Len())
 		emap = make(map[string]struct{})
 	)
-	s.ngt.ListObjectFunc(ctx, func(uuid string, _ uint32, _ int64) bool {
-		vec, ts, err := s.ngt.GetObject(uuid)
+	s.core(ctx).ListObjectFunc(ctx, func(uuid string, _ uint32, _ int64) bool {
+		vec, ts, err := s.core(ctx).GetObject(uuid)
 		var res *payload.Object_List_Response
 		if err != nil {
 			st := status.CreateWithNotFound(fmt.Sprintf("failed to get object with uuid: %s", uuid), err)
@@ -298,7 +298,7 @@ func (s *server) GetTimestamp(
 		}
 		return nil, err
 	}
-	_, ts, err := s.ngt.GetObject(uuid)
+	_, ts, err := s.core(ctx).GetObject(uuid)
 	if err != nil {
 		err = status.New(codes.NotFound, errors.ErrObjectNotFound(err, uuid).Error()).Err()
 		if span != nil {
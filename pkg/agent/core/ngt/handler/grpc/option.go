@@ -77,6 +77,17 @@ func WithNGT(n service.NGT) Option {
 	}
 }
 
+// WithCollections returns the option to set the collection manager for server.
+func WithCollections(c service.Collections) Option {
+	return func(s *server) error {
+		if c == nil {
+			return errors.NewErrInvalidOption("collections", c)
+		}
+		s.collections = c
+		return nil
+	}
+}
+
 // WithStreamConcurrency returns the option to set the stream concurrency for server.
 func WithStreamConcurrency(c int) Option {
 	return func(s *server) error {
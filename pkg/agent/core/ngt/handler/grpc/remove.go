@@ -69,7 +69,7 @@ func (s *server) Remove(
 		log.Warn(err)
 		return nil, err
 	}
-	err = s.ngt.DeleteWithTime(uuid, req.GetConfig().GetTimestamp())
+	err = s.core(ctx).DeleteWithTime(ctx, uuid, req.GetConfig().GetTimestamp())
 	if err != nil {
 		var attrs []attribute.KeyValue
 		if errors.Is(err, errors.ErrFlushingIsInProgress) {
@@ -199,7 +199,7 @@ func (s *server) MultiRemove(
 	for _, req := range reqs.GetRequests() {
 		uuids = append(uuids, req.GetId().GetId())
 	}
-	err = s.ngt.DeleteMultiple(uuids...)
+	err = s.core(ctx).DeleteMultiple(ctx, uuids...)
 	if err != nil {
 		var attrs []attribute.KeyValue
 		if errors.Is(err, errors.ErrFlushingIsInProgress) {
@@ -291,7 +291,7 @@ func (s *server) RemoveByTimestamp(
 	locs = new(payload.Object_Locations)
 
 	timestampOpFn := timestampOpsFunc(req.GetTimestamps())
-	s.ngt.ListObjectFunc(ctx, func(uuid string, oid uint32, timestamp int64) bool {
+	s.core(ctx).ListObjectFunc(ctx, func(uuid string, oid uint32, timestamp int64) bool {
 		if !timestampOpFn(timestamp) {
 			return true
 		}
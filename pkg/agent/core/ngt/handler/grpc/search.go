@@ -30,6 +30,7 @@ import (
 	"github.com/vdaas/vald/internal/safety"
 	"github.com/vdaas/vald/internal/strings"
 	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/pkg/agent/core/ngt/service"
 )
 
 func (s *server) Search(
@@ -41,8 +42,8 @@ func (s *server) Search(
 			span.End()
 		}
 	}()
-	if len(req.GetVector()) != s.ngt.GetDimensionSize() {
-		err = errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.ngt.GetDimensionSize()))
+	if len(req.GetVector()) != s.core(ctx).GetDimensionSize() {
+		err = errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.core(ctx).GetDimensionSize()))
 		err = status.WrapWithInvalidArgument("Search API Incompatible Dimension Size detected",
 			err,
 			&errdetails.RequestInfo{
@@ -68,7 +69,10 @@ func (s *server) Search(
 		}
 		return nil, err
 	}
-	res, err = s.ngt.Search(ctx,
+	if req.GetConfig().GetIncludeUncommitted() {
+		ctx = service.WithUncommittedSearch(ctx)
+	}
+	res, err = s.core(ctx).Search(ctx,
 		req.GetVector(),
 		req.GetConfig().GetNum(),
 		req.GetConfig().GetEpsilon(),
@@ -130,7 +134,7 @@ func (s *server) Search(
 				}, info.Get())
 			log.Error(err)
 			attrs = trace.StatusCodeInternal(err.Error())
-		case errors.Is(err, errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.ngt.GetDimensionSize()))):
+		case errors.Is(err, errors.ErrIncompatibleDimensionSize(len(req.GetVector()), int(s.core(ctx).GetDimensionSize()))):
 			err = status.WrapWithInvalidArgument("Search API Incompatible Dimension Size detected",
 				err,
 				&errdetails.RequestInfo{
@@ -211,7 +215,10 @@ func (s *server) SearchByID(
 		}
 		return nil, err
 	}
-	vec, res, err := s.ngt.SearchByID(ctx,
+	if req.GetConfig().GetIncludeUncommitted() {
+		ctx = service.WithUncommittedSearch(ctx)
+	}
+	vec, res, err := s.core(ctx).SearchByID(ctx,
 		uuid,
 		req.GetConfig().GetNum(),
 		req.GetConfig().GetEpsilon(),
@@ -286,7 +293,7 @@ func (s *server) SearchByID(
 				}, info.Get())
 			log.Error(err)
 			attrs = trace.StatusCodeInternal(err.Error())
-		case errors.Is(err, errors.ErrIncompatibleDimensionSize(len(vec), int(s.ngt.GetDimensionSize()))):
+		case errors.Is(err, errors.ErrIncompatibleDimensionSize(len(vec), int(s.core(ctx).GetDimensionSize()))):
 			err = status.WrapWithInvalidArgument("SearchByID API Incompatible Dimension Size detected",
 				err,
 				&errdetails.RequestInfo{
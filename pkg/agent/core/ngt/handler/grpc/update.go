@@ -16,6 +16,7 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/vdaas/vald/apis/grpc/v1/payload"
 	"github.com/vdaas/vald/apis/grpc/v1/vald"
@@ -40,8 +41,8 @@ func (s *server) Update(
 		}
 	}()
 	vec := req.GetVector()
-	if len(vec.GetVector()) != s.ngt.GetDimensionSize() {
-		err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.ngt.GetDimensionSize()))
+	if len(vec.GetVector()) != s.core(ctx).GetDimensionSize() {
+		err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.core(ctx).GetDimensionSize()))
 		err = status.WrapWithInvalidArgument("Update API Incompatible Dimension Size detected",
 			err,
 			&errdetails.RequestInfo{
@@ -91,7 +92,7 @@ func (s *server) Update(
 		log.Warn(err)
 		return nil, err
 	}
-	err = s.ngt.UpdateWithTime(uuid, vec.GetVector(), req.GetConfig().GetTimestamp())
+	err = s.core(ctx).UpdateWithTTL(ctx, uuid, vec.GetVector(), req.GetConfig().GetTimestamp(), time.Duration(req.GetConfig().GetTtl())*time.Second)
 	if err != nil {
 		var attrs []attribute.KeyValue
 		if errors.Is(err, errors.ErrFlushingIsInProgress) {
@@ -118,7 +119,7 @@ func (s *server) Update(
 				})
 			log.Warn(err)
 			attrs = trace.StatusCodeNotFound(err.Error())
-		} else if errors.Is(err, errors.ErrUUIDNotFound(0)) || errors.Is(err, errors.ErrInvalidDimensionSize(len(vec.GetVector()), s.ngt.GetDimensionSize())) {
+		} else if errors.Is(err, errors.ErrUUIDNotFound(0)) || errors.Is(err, errors.ErrInvalidDimensionSize(len(vec.GetVector()), s.core(ctx).GetDimensionSize())) {
 			err = status.WrapWithInvalidArgument(fmt.Sprintf("Update API invalid argument for uuid \"%s\" vec \"%v\" detected", vec.GetId(), vec.GetVector()), err,
 				&errdetails.RequestInfo{
 					RequestId:   req.GetVector().GetId(),
@@ -232,10 +233,11 @@ func (s *server) MultiUpdate(
 
 	uuids := make([]string, 0, len(reqs.GetRequests()))
 	vmap := make(map[string][]float32, len(reqs.GetRequests()))
+	var ttls map[string]time.Duration
 	for _, req := range reqs.GetRequests() {
 		vec := req.GetVector()
-		if len(vec.GetVector()) != s.ngt.GetDimensionSize() {
-			err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.ngt.GetDimensionSize()))
+		if len(vec.GetVector()) != s.core(ctx).GetDimensionSize() {
+			err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.core(ctx).GetDimensionSize()))
 			err = status.WrapWithInvalidArgument("MultiUpdate API Incompatible Dimension Size detected",
 				err,
 				&errdetails.RequestInfo{
@@ -264,9 +266,15 @@ func (s *server) MultiUpdate(
 		}
 		vmap[vec.GetId()] = vec.GetVector()
 		uuids = append(uuids, vec.GetId())
+		if ttl := req.GetConfig().GetTtl(); ttl != 0 {
+			if ttls == nil {
+				ttls = make(map[string]time.Duration, len(reqs.GetRequests()))
+			}
+			ttls[vec.GetId()] = time.Duration(ttl) * time.Second
+		}
 	}
 
-	err = s.ngt.UpdateMultiple(vmap)
+	err = s.core(ctx).UpdateMultipleWithTTL(ctx, vmap, ttls)
 	if err != nil {
 		var attrs []attribute.KeyValue
 		if errors.Is(err, errors.ErrFlushingIsInProgress) {
@@ -304,7 +312,7 @@ func (s *server) MultiUpdate(
 		} else if invalidDimensionIDs := func() []string {
 			idis := make([]string, 0, len(uuids))
 			for id, vec := range vmap {
-				if errors.Is(err, errors.ErrInvalidDimensionSize(len(vec), s.ngt.GetDimensionSize())) {
+				if errors.Is(err, errors.ErrInvalidDimensionSize(len(vec), s.core(ctx).GetDimensionSize())) {
 					idis = append(idis, id)
 				}
 			}
@@ -427,7 +435,7 @@ func (s *server) UpdateTimestamp(
 		}
 		return nil, err
 	}
-	err = s.ngt.UpdateTimestamp(uuid, ts, req.GetForce())
+	err = s.core(ctx).UpdateTimestamp(ctx, uuid, ts, req.GetForce())
 	if err != nil {
 		var attrs []attribute.KeyValue
 		if errors.Is(err, errors.ErrFlushingIsInProgress) {
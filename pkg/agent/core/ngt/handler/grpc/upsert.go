@@ -40,8 +40,8 @@ func (s *server) Upsert(
 		}
 	}()
 	vec := req.GetVector()
-	if len(vec.GetVector()) != s.ngt.GetDimensionSize() {
-		err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.ngt.GetDimensionSize()))
+	if len(vec.GetVector()) != s.core(ctx).GetDimensionSize() {
+		err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.core(ctx).GetDimensionSize()))
 		err = status.WrapWithInvalidArgument("Upsert API Incompatible Dimension Size detected",
 			err,
 			&errdetails.RequestInfo{
@@ -93,13 +93,14 @@ func (s *server) Upsert(
 	}
 
 	rtName := "/ngt.Upsert"
-	_, exists := s.ngt.Exists(req.GetVector().GetId())
+	_, exists := s.core(ctx).Exists(req.GetVector().GetId())
 	if exists {
 		loc, err = s.Update(ctx, &payload.Update_Request{
 			Vector: req.GetVector(),
 			Config: &payload.Update_Config{
 				Timestamp:            req.GetConfig().GetTimestamp(),
 				SkipStrictExistCheck: true,
+				Ttl:                  req.GetConfig().GetTtl(),
 			},
 		})
 		rtName += "/ngt.Update"
@@ -109,6 +110,7 @@ func (s *server) Upsert(
 			Config: &payload.Insert_Config{
 				Timestamp:            req.GetConfig().GetTimestamp(),
 				SkipStrictExistCheck: true,
+				Ttl:                  req.GetConfig().GetTtl(),
 			},
 		})
 		rtName += "/ngt.Insert"
@@ -196,8 +198,8 @@ func (s *server) MultiUpsert(
 	ids := make([]string, 0, len(reqs.GetRequests()))
 	for _, req := range reqs.GetRequests() {
 		vec := req.GetVector()
-		if len(vec.GetVector()) != s.ngt.GetDimensionSize() {
-			err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.ngt.GetDimensionSize()))
+		if len(vec.GetVector()) != s.core(ctx).GetDimensionSize() {
+			err = errors.ErrIncompatibleDimensionSize(len(vec.GetVector()), int(s.core(ctx).GetDimensionSize()))
 			err = status.WrapWithInvalidArgument("MultiUpsert API Incompatible Dimension Size detected",
 				err,
 				&errdetails.RequestInfo{
@@ -225,13 +227,14 @@ func (s *server) MultiUpsert(
 			return nil, err
 		}
 		ids = append(ids, vec.GetId())
-		_, exists := s.ngt.Exists(vec.GetId())
+		_, exists := s.core(ctx).Exists(vec.GetId())
 		if exists {
 			updateReqs = append(updateReqs, &payload.Update_Request{
 				Vector: vec,
 				Config: &payload.Update_Config{
 					Timestamp:            req.GetConfig().GetTimestamp(),
 					SkipStrictExistCheck: true,
+					Ttl:                  req.GetConfig().GetTtl(),
 				},
 			})
 		} else {
@@ -240,6 +243,7 @@ func (s *server) MultiUpsert(
 				Config: &payload.Insert_Config{
 					Timestamp:            req.GetConfig().GetTimestamp(),
 					SkipStrictExistCheck: true,
+					Ttl:                  req.GetConfig().GetTtl(),
 				},
 			})
 		}
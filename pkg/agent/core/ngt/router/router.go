@@ -55,6 +55,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/",
+				RPC:         "/",
 				HandlerFunc: h.Index,
 			},
 			{
@@ -63,6 +64,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/search",
+				RPC:         "/vald.v1.Search/Search",
 				HandlerFunc: h.Search,
 			},
 			{
@@ -71,6 +73,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/id/search",
+				RPC:         "/vald.v1.Search/SearchByID",
 				HandlerFunc: h.SearchByID,
 			},
 			{
@@ -79,6 +82,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/linearsearch",
+				RPC:         "/vald.v1.Search/LinearSearch",
 				HandlerFunc: h.LinearSearch,
 			},
 			{
@@ -87,6 +91,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/id/linearsearch",
+				RPC:         "/vald.v1.Search/LinearSearchByID",
 				HandlerFunc: h.LinearSearchByID,
 			},
 			{
@@ -95,6 +100,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/insert",
+				RPC:         "/vald.v1.Insert/Insert",
 				HandlerFunc: h.Insert,
 			},
 			{
@@ -103,6 +109,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/insert/multi",
+				RPC:         "/vald.v1.Insert/MultiInsert",
 				HandlerFunc: h.MultiInsert,
 			},
 			{
@@ -113,6 +120,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/update",
+				RPC:         "/vald.v1.Update/Update",
 				HandlerFunc: h.Update,
 			},
 			{
@@ -123,6 +131,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/update/multi",
+				RPC:         "/vald.v1.Update/MultiUpdate",
 				HandlerFunc: h.MultiUpdate,
 			},
 			{
@@ -131,6 +140,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodDelete,
 				},
 				Pattern:     "/delete",
+				RPC:         "/vald.v1.Remove/Remove",
 				HandlerFunc: h.Remove,
 			},
 			{
@@ -140,6 +150,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/delete/multi",
+				RPC:         "/vald.v1.Remove/MultiRemove",
 				HandlerFunc: h.MultiRemove,
 			},
 			{
@@ -148,6 +159,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/index/create",
+				RPC:         "/core.v1.Agent/CreateIndex",
 				HandlerFunc: h.CreateIndex,
 			},
 			{
@@ -156,6 +168,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/index/save",
+				RPC:         "/core.v1.Agent/SaveIndex",
 				HandlerFunc: h.SaveIndex,
 			},
 			{
@@ -164,6 +177,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/object/{id}",
+				RPC:         "/vald.v1.Object/GetObject",
 				HandlerFunc: h.GetObject,
 			},
 		}...))
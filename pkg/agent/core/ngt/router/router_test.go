@@ -46,7 +46,7 @@ func TestNew(t *testing.T) {
 		afterFunc  func(args)
 	}
 	defaultCheckFunc := func(w want, got http.Handler) error {
-		gh, ok := got.(*mux.Router)
+		gh, ok := got.(interface{ Get(name string) *mux.Route })
 		if !ok {
 			return errors.New("type cast got failed")
 		}
@@ -91,6 +91,7 @@ func TestNew(t *testing.T) {
 								http.MethodGet,
 							},
 							Pattern:     "/",
+							RPC:         "/",
 							HandlerFunc: h.Index,
 						},
 						{
@@ -99,6 +100,7 @@ func TestNew(t *testing.T) {
 								http.MethodPost,
 							},
 							Pattern:     "/search",
+							RPC:         "/vald.v1.Search/Search",
 							HandlerFunc: h.Search,
 						},
 						{
@@ -107,6 +109,7 @@ func TestNew(t *testing.T) {
 								http.MethodPost,
 							},
 							Pattern:     "/id/search",
+							RPC:         "/vald.v1.Search/SearchByID",
 							HandlerFunc: h.SearchByID,
 						},
 						{
@@ -115,6 +118,7 @@ func TestNew(t *testing.T) {
 								http.MethodPost,
 							},
 							Pattern:     "/insert",
+							RPC:         "/vald.v1.Insert/Insert",
 							HandlerFunc: h.Insert,
 						},
 						{
@@ -123,6 +127,7 @@ func TestNew(t *testing.T) {
 								http.MethodPost,
 							},
 							Pattern:     "/insert/multi",
+							RPC:         "/vald.v1.Insert/MultiInsert",
 							HandlerFunc: h.MultiInsert,
 						},
 						{
@@ -133,6 +138,7 @@ func TestNew(t *testing.T) {
 								http.MethodPut,
 							},
 							Pattern:     "/update",
+							RPC:         "/vald.v1.Update/Update",
 							HandlerFunc: h.Update,
 						},
 						{
@@ -143,6 +149,7 @@ func TestNew(t *testing.T) {
 								http.MethodPut,
 							},
 							Pattern:     "/update/multi",
+							RPC:         "/vald.v1.Update/MultiUpdate",
 							HandlerFunc: h.MultiUpdate,
 						},
 						{
@@ -151,6 +158,7 @@ func TestNew(t *testing.T) {
 								http.MethodDelete,
 							},
 							Pattern:     "/delete",
+							RPC:         "/vald.v1.Remove/Remove",
 							HandlerFunc: h.Remove,
 						},
 						{
@@ -160,6 +168,7 @@ func TestNew(t *testing.T) {
 								http.MethodPost,
 							},
 							Pattern:     "/delete/multi",
+							RPC:         "/vald.v1.Remove/MultiRemove",
 							HandlerFunc: h.MultiRemove,
 						},
 						{
@@ -168,6 +177,7 @@ func TestNew(t *testing.T) {
 								http.MethodPost,
 							},
 							Pattern:     "/index/create",
+							RPC:         "/core.v1.Agent/CreateIndex",
 							HandlerFunc: h.CreateIndex,
 						},
 						{
@@ -176,6 +186,7 @@ func TestNew(t *testing.T) {
 								http.MethodGet,
 							},
 							Pattern:     "/index/save",
+							RPC:         "/core.v1.Agent/SaveIndex",
 							HandlerFunc: h.SaveIndex,
 						},
 						{
@@ -184,6 +195,7 @@ func TestNew(t *testing.T) {
 								http.MethodGet,
 							},
 							Pattern:     "/object/{id}",
+							RPC:         "/vald.v1.Object/GetObject",
 							HandlerFunc: h.GetObject,
 						},
 					},
@@ -0,0 +1,99 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"slices"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/pkg/agent/internal/collection"
+)
+
+type (
+	// Collections manages the named NGT indexes hosted next to the default index.
+	// Every collection owns its own kvs, vqueue and auto indexing loop.
+	Collections interface {
+		collection.Collections[NGT]
+		ValidateAutoIndexing(cfg *config.NGT) error
+		UpdateAutoIndexing(cfg *config.NGT) error
+	}
+
+	collections struct {
+		collection.Collections[NGT]
+		base *config.NGT
+		mu   sync.RWMutex
+		opts []Option
+		// auto is the auto indexing options of the last reload, which override opts.
+		auto []Option
+	}
+)
+
+// NewCollections returns the collection manager. opts are the options used for the default index,
+// each collection overrides the index path, dimension and distance type.
+func NewCollections(cfg *config.NGT, opts ...Option) (Collections, error) {
+	c := &collections{
+		base: cfg,
+		opts: opts,
+	}
+	var err error
+	c.Collections, err = collection.New(collection.Dir(cfg.IndexPath, cfg.EnableInMemoryMode), c.newNGT)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *collections) newNGT(def *payload.Collection_Config, path string) (NGT, error) {
+	cfg := *c.base
+	cfg.Dimension = int(def.GetDimension())
+	if def.GetDistanceType() != "" {
+		cfg.DistanceType = def.GetDistanceType()
+	}
+	if def.GetObjectType() != "" {
+		cfg.ObjectType = def.GetObjectType()
+	}
+	cfg.EnableExportIndexInfoToK8s = false
+	c.mu.RLock()
+	opts := slices.Concat(c.opts, c.auto)
+	c.mu.RUnlock()
+	if path == "" {
+		opts = append(opts, WithEnableInMemoryMode(true))
+	} else {
+		opts = append(opts, WithIndexPath(path))
+	}
+	return newNGT(&cfg, opts...)
+}
+
+// ValidateAutoIndexing returns the error UpdateAutoIndexing would return for cfg without applying anything.
+func (c *collections) ValidateAutoIndexing(cfg *config.NGT) error {
+	return c.Range(func(_ string, n NGT) error {
+		return n.ValidateAutoIndexing(cfg)
+	})
+}
+
+// UpdateAutoIndexing applies the auto indexing parameters of cfg to every collection, including the ones created later.
+func (c *collections) UpdateAutoIndexing(cfg *config.NGT) error {
+	c.mu.Lock()
+	c.auto = autoIndexingOptions(cfg)
+	c.mu.Unlock()
+	return c.Range(func(_ string, n NGT) error {
+		return n.UpdateAutoIndexing(cfg)
+	})
+}
@@ -18,6 +18,7 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"encoding/gob"
 	"fmt"
@@ -37,6 +38,7 @@ import (
 	"github.com/vdaas/vald/internal/conv"
 	"github.com/vdaas/vald/internal/core/algorithm"
 	core "github.com/vdaas/vald/internal/core/algorithm/ngt"
+	"github.com/vdaas/vald/internal/core/algorithm/quantization"
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/file"
 	"github.com/vdaas/vald/internal/k8s/client"
@@ -50,6 +52,7 @@ import (
 	"github.com/vdaas/vald/pkg/agent/internal/kvs"
 	"github.com/vdaas/vald/pkg/agent/internal/memstore"
 	"github.com/vdaas/vald/pkg/agent/internal/metadata"
+	"github.com/vdaas/vald/pkg/agent/internal/replica"
 	"github.com/vdaas/vald/pkg/agent/internal/vqueue"
 )
 
@@ -60,19 +63,23 @@ type (
 		SearchByID(ctx context.Context, uuid string, size uint32, epsilon, radius float32) ([]float32, *payload.Search_Response, error)
 		LinearSearch(ctx context.Context, vec []float32, size uint32) (*payload.Search_Response, error)
 		LinearSearchByID(ctx context.Context, uuid string, size uint32) ([]float32, *payload.Search_Response, error)
-		Insert(uuid string, vec []float32) (err error)
-		InsertWithTime(uuid string, vec []float32, t int64) (err error)
-		InsertMultiple(vecs map[string][]float32) (err error)
-		InsertMultipleWithTime(vecs map[string][]float32, t int64) (err error)
-		Update(uuid string, vec []float32) (err error)
-		UpdateWithTime(uuid string, vec []float32, t int64) (err error)
-		UpdateMultiple(vecs map[string][]float32) (err error)
-		UpdateMultipleWithTime(vecs map[string][]float32, t int64) (err error)
-		UpdateTimestamp(uuid string, ts int64, force bool) (err error)
-		Delete(uuid string) (err error)
-		DeleteWithTime(uuid string, t int64) (err error)
-		DeleteMultiple(uuids ...string) (err error)
-		DeleteMultipleWithTime(uuids []string, t int64) (err error)
+		Insert(ctx context.Context, uuid string, vec []float32) (err error)
+		InsertWithTime(ctx context.Context, uuid string, vec []float32, t int64) (err error)
+		InsertMultiple(ctx context.Context, vecs map[string][]float32) (err error)
+		InsertMultipleWithTime(ctx context.Context, vecs map[string][]float32, t int64) (err error)
+		InsertWithTTL(ctx context.Context, uuid string, vec []float32, t int64, ttl time.Duration) (err error)
+		InsertMultipleWithTTL(ctx context.Context, vecs map[string][]float32, ttls map[string]time.Duration) (err error)
+		Update(ctx context.Context, uuid string, vec []float32) (err error)
+		UpdateWithTime(ctx context.Context, uuid string, vec []float32, t int64) (err error)
+		UpdateMultiple(ctx context.Context, vecs map[string][]float32) (err error)
+		UpdateMultipleWithTime(ctx context.Context, vecs map[string][]float32, t int64) (err error)
+		UpdateWithTTL(ctx context.Context, uuid string, vec []float32, t int64, ttl time.Duration) (err error)
+		UpdateMultipleWithTTL(ctx context.Context, vecs map[string][]float32, ttls map[string]time.Duration) (err error)
+		UpdateTimestamp(ctx context.Context, uuid string, ts int64, force bool) (err error)
+		Delete(ctx context.Context, uuid string) (err error)
+		DeleteWithTime(ctx context.Context, uuid string, t int64) (err error)
+		DeleteMultiple(ctx context.Context, uuids ...string) (err error)
+		DeleteMultipleWithTime(ctx context.Context, uuids []string, t int64) (err error)
 		RegenerateIndexes(ctx context.Context) (err error)
 		GetObject(uuid string) (vec []float32, timestamp int64, err error)
 		ListObjectFunc(ctx context.Context, f func(uuid string, oid uint32, timestamp int64) bool)
@@ -83,9 +90,11 @@ type (
 		IsIndexing() bool
 		IsFlushing() bool
 		IsSaving() bool
+		IsReplicaPending() bool
 		Len() uint64
 		NumberOfCreateIndexExecution() uint64
 		NumberOfProactiveGCExecution() uint64
+		NumberOfExpiredObjects() uint64
 		UUIDs(context.Context) (uuids []string)
 		InsertVQueueBufferLen() uint64
 		DeleteVQueueBufferLen() uint64
@@ -94,6 +103,9 @@ type (
 		IndexStatistics() (*payload.Info_Index_Statistics, error)
 		IsStatisticsEnabled() bool
 		IndexProperty() (*payload.Info_Index_Property, error)
+		RealtimeIndexStats() (stats RealtimeIndexStats, ok bool)
+		ValidateAutoIndexing(cfg *config.NGT) error
+		UpdateAutoIndexing(cfg *config.NGT) error
 		Close(ctx context.Context) error
 	}
 
@@ -124,6 +136,10 @@ type (
 		cfg  *config.NGT
 		opts []Option
 
+		// auto indexing parameters waiting to be applied by the auto indexing loop
+		pendingAutoIndex atomic.Pointer[autoIndexing]
+		reloadAutoIndex  chan struct{}
+
 		// configurations
 		inMem bool // in-memory mode
 		dim   int  // dimension size
@@ -165,8 +181,41 @@ type (
 		exportIndexInfoDuration time.Duration
 		patcher                 client.Patcher
 
+		// read replica
+		replicaSource string        // source of the read replica index
+		replicaDur    time.Duration // reload check duration of the index generation pulled from the blob storage
+		replicaBase   string        // index path which the agent sidecar pulls the index generations to
+		replicaGen    string        // ID of the loaded index generation
+		strictLoad    bool          // return the load error instead of starting with the empty index, and never write to the index path
+		rcmu          sync.RWMutex  // held for reading while the index generation is in use, and for writing while it is swapped
+
 		enableStatistics bool
 		statisticsCache  atomic.Pointer[payload.Info_Index_Statistics]
+
+		// quantization
+		qtype  quantization.Type     // quantization type
+		quant  *quantization.Scalar  // scalar quantizer
+		raw    *quantization.Store   // raw vector store for the exact re-ranking
+		qdist  quantization.Distance // exact distance function for the re-ranking
+		rerank int                   // re-ranking candidate multiplier
+		qtrain int                   // maximum number of vectors to train the quantizer
+		qcount atomic.Uint64         // number of vectors the quantizer is trained with
+		qhits  atomic.Uint64         // number of re-ranked results found in the quantized top-k
+		qtotal atomic.Uint64         // number of re-ranked results
+
+		uncommittedLimit int // maximum number of queued vectors scanned by the uncommitted search
+
+		// real-time indexing
+		rt         *realtime     // real-time indexing state, nil if disabled
+		rtMode     realtimeMode  // real-time indexing mode
+		rtBatch    int           // micro-batch size of the batch mode
+		rtInterval time.Duration // micro-batch interval of the batch mode
+
+		// ttl expiry
+		ttls       *sync.Map[string, int64] // per-object ttl in nanoseconds, negative if it never expires
+		defaultTTL time.Duration            // ttl of the objects inserted without ttl, disabled if not positive
+		ttlSweep   time.Duration            // expired objects sweep duration
+		noe        atomic.Uint64            // number of expired objects
 	}
 
 	contextSaveIndexTimeKey string
@@ -175,8 +224,11 @@ type (
 const (
 	kvsFileName          = "ngt-meta.kvsdb"
 	kvsTimestampFileName = "ngt-timestamp.kvsdb"
+	rawVectorFileName    = "ngt-raw-vectors.bin"
 	noTimeStampFile      = -1
 
+	defaultRerankFactor = 4
+
 	oldIndexDirName    = "backup"
 	originIndexDirName = "origin"
 	brokenIndexDirName = "broken"
@@ -211,6 +263,7 @@ func newNGT(cfg *config.NGT, opts ...Option) (n *ngt, err error) {
 		enableExportIndexInfo: cfg.EnableExportIndexInfoToK8s,
 		cfg:                   cfg,
 		opts:                  opts,
+		reloadAutoIndex:       make(chan struct{}, 1),
 	}
 
 	for _, opt := range append(defaultOptions, opts...) {
@@ -218,6 +271,10 @@ func newNGT(cfg *config.NGT, opts ...Option) (n *ngt, err error) {
 			return nil, errors.ErrOptionFailed(err, reflect.ValueOf(opt))
 		}
 	}
+	n.ttls = new(sync.Map[string, int64])
+	if n.isReadReplica && n.replicaSource == replica.SourceBlob {
+		n.resolveReplicaGeneration()
+	}
 	if len(n.path) == 0 {
 		log.Info("index path setting is empty, starting vald agent with in-memory mode")
 		n.inMem = true
@@ -232,6 +289,19 @@ func newNGT(cfg *config.NGT, opts ...Option) (n *ngt, err error) {
 		}
 	}
 
+	objectType, err := n.initQuantization(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if n.qdist == nil {
+		// the exact distance is also used to scan the uncommitted vectors, which is disabled for the unsupported distance types.
+		n.qdist, err = quantization.NewDistance(cfg.DistanceType)
+		if err != nil {
+			log.Warnf("uncommitted search is disabled: %v", err)
+			n.qdist = nil
+		}
+	}
+
 	err = n.initNGT(
 		core.WithInMemoryMode(n.inMem),
 		core.WithDefaultPoolSize(n.poolSize),
@@ -239,7 +309,7 @@ func newNGT(cfg *config.NGT, opts ...Option) (n *ngt, err error) {
 		core.WithDefaultEpsilon(n.epsilon),
 		core.WithDimension(cfg.Dimension),
 		core.WithDistanceTypeByString(cfg.DistanceType),
-		core.WithObjectTypeByString(cfg.ObjectType),
+		core.WithObjectTypeByString(objectType),
 		core.WithBulkInsertChunkSize(cfg.BulkInsertChunkSize),
 		core.WithCreationEdgeSize(cfg.CreationEdgeSize),
 		core.WithSearchEdgeSize(cfg.SearchEdgeSize),
@@ -258,6 +328,9 @@ func newNGT(cfg *config.NGT, opts ...Option) (n *ngt, err error) {
 			return nil, err
 		}
 	}
+	if n.rtMode != realtimeDisabled && !n.isReadReplica {
+		n.rt = newRealtime(n.rtMode, n.rtBatch, n.rtInterval)
+	}
 	n.indexing.Store(false)
 	n.saving.Store(false)
 
@@ -270,6 +343,7 @@ func (n *ngt) copyNGT(src *ngt) {
 	n.kvs = src.kvs
 	n.fmap = src.fmap
 	n.vq = src.vq
+	n.ttls = src.ttls
 
 	// counters
 	n.wfci = src.wfci
@@ -282,6 +356,162 @@ func (n *ngt) copyNGT(src *ngt) {
 	n.oldPath = src.oldPath
 	n.basePath = src.basePath
 	n.brokenPath = src.brokenPath
+	n.replicaGen = src.replicaGen
+
+	// quantization
+	n.quant = src.quant
+	n.raw = src.raw
+	n.qcount.Store(src.qcount.Load())
+	n.qhits.Store(0)
+	n.qtotal.Store(0)
+}
+
+// initQuantization prepares the quantizer and the raw vector store, and returns the object type of the core index.
+// When the quantization is enabled, the core index stores the 8 bit codes as uint8 objects
+// and the raw float32 vectors are kept in the file backed store to re-rank the candidates.
+func (n *ngt) initQuantization(cfg *config.NGT) (objectType string, err error) {
+	objectType = cfg.ObjectType
+	if cfg.Quantization == nil {
+		return objectType, nil
+	}
+	n.qtype, err = quantization.ParseType(cfg.Quantization.Type)
+	if err != nil {
+		return "", err
+	}
+	if n.qtype == quantization.None {
+		return objectType, nil
+	}
+	n.qdist, err = quantization.NewDistance(cfg.DistanceType)
+	if err != nil {
+		return "", err
+	}
+	n.rerank = cfg.Quantization.RerankFactor
+	if n.rerank <= 0 {
+		n.rerank = defaultRerankFactor
+	}
+	n.qtrain = cfg.Quantization.TrainingSize
+	n.quant = quantization.NewScalar(n.dim)
+	var path string
+	if !n.inMem {
+		path = file.Join(n.basePath, rawVectorFileName)
+	}
+	n.raw, err = quantization.OpenStore(path, n.dim)
+	if err != nil {
+		return "", err
+	}
+	log.Infof("vald agent starts with %s quantization, re-rank factor: %d", n.qtype.String(), n.rerank)
+	return core.Uint8.String(), nil
+}
+
+// quantizationMetadata returns the quantization metadata to be stored with the index.
+func (n *ngt) quantizationMetadata() *metadata.Quantization {
+	if n.quant == nil {
+		return nil
+	}
+	mins, maxs := n.quant.Ranges()
+	return &metadata.Quantization{
+		Type: n.qtype.String(),
+		Mins: mins,
+		Maxs: maxs,
+
+		TrainedCount: n.qcount.Load(),
+	}
+}
+
+// trainQuantizer learns the quantization ranges from the vectors in the insert vqueue.
+func (n *ngt) trainQuantizer(ctx context.Context) {
+	var cnt int
+	n.vq.Range(ctx, func(_ string, vector []float32, _ int64) bool {
+		n.quant.Observe(vector)
+		cnt++
+		return n.qtrain <= 0 || cnt < n.qtrain
+	})
+	n.quant.Train()
+	n.qcount.Store(uint64(cnt))
+	log.Infof("quantizer trained with %d vectors", cnt)
+}
+
+// needsRetrainQuantizer reports whether the quantizer should be retrained, i.e. the number of the indexed vectors
+// is at least twice the number of the vectors it is trained with and the latter is less than the training size.
+func (n *ngt) needsRetrainQuantizer() bool {
+	if n.quant == nil || !n.quant.Trained() {
+		return false
+	}
+	trained := n.qcount.Load()
+	if n.qtrain > 0 && trained >= uint64(n.qtrain) {
+		return false
+	}
+	return n.kvs.Len() >= 2*max(trained, 1)
+}
+
+// retrainQuantizer learns the quantization ranges again from the raw vectors of the indexed objects and the insert vqueue,
+// and then re-encodes every indexed object with the new ranges. The caller must hold the create index mutex,
+// and must create the index after it since the re-encoded objects are inserted without building the graph.
+func (n *ngt) retrainQuantizer(ctx context.Context) (err error) {
+	q := quantization.NewScalar(n.dim)
+	var (
+		cnt int
+		cmu sync.Mutex
+	)
+	observe := func(vector []float32) bool {
+		cmu.Lock()
+		defer cmu.Unlock()
+		q.Observe(vector)
+		cnt++
+		return n.qtrain <= 0 || cnt < n.qtrain
+	}
+	n.kvs.Range(ctx, func(_ string, oid uint32, _ int64) bool {
+		vec, err := n.raw.Get(oid)
+		if err != nil {
+			log.Warnf("failed to get raw vector to retrain the quantizer, oid: %d, error: %v", oid, err)
+			return true
+		}
+		return observe(vec)
+	})
+	if n.qtrain <= 0 || cnt < n.qtrain {
+		n.vq.Range(ctx, func(_ string, vector []float32, _ int64) bool {
+			return observe(vector)
+		})
+	}
+	q.Train()
+	mins, maxs := q.Ranges()
+	if err = n.quant.SetRanges(mins, maxs); err != nil {
+		return err
+	}
+	n.qcount.Store(uint64(cnt))
+
+	type object struct {
+		uuid string
+		oid  uint32
+		ts   int64
+	}
+	objs := make([]object, 0, n.kvs.Len())
+	var omu sync.Mutex
+	n.kvs.Range(ctx, func(uuid string, oid uint32, ts int64) bool {
+		omu.Lock()
+		objs = append(objs, object{uuid: uuid, oid: oid, ts: ts})
+		omu.Unlock()
+		return true
+	})
+	// the removed object id is reused by the next insert, so the raw vector of an object is overwritten
+	// only after the object itself is re-encoded.
+	var recnt uint64
+	for _, obj := range objs {
+		vec, err := n.raw.Get(obj.oid)
+		if err != nil {
+			log.Warnf("failed to get raw vector to re-encode uuid: %s oid: %d, error: %v", obj.uuid, obj.oid, err)
+			continue
+		}
+		if err = n.core.Remove(uint(obj.oid)); err != nil {
+			log.Warnf("failed to remove object to re-encode uuid: %s oid: %d, error: %v", obj.uuid, obj.oid, err)
+			continue
+		}
+		if n.insertIndex(obj.uuid, vec, obj.ts) {
+			recnt++
+		}
+	}
+	log.Infof("quantizer retrained with %d vectors and %d objects are re-encoded", cnt, recnt)
+	return nil
 }
 
 // migrate migrates the index directory from old to new under the input path if necessary.
@@ -340,7 +570,7 @@ func migrate(ctx context.Context, path string) (err error) {
 
 func (n *ngt) prepareFolders(ctx context.Context) (err error) {
 	// migrate from old index directory to new index directory if necessary
-	if !n.enableCopyOnWrite {
+	if !n.enableCopyOnWrite && !n.strictLoad {
 		err = migrate(ctx, n.path)
 		if err != nil {
 			return err
@@ -364,9 +594,11 @@ func (n *ngt) prepareFolders(ctx context.Context) (err error) {
 	if err != nil {
 		log.Warn(err)
 	}
-	err = file.MkdirAll(n.brokenPath, fs.ModePerm)
-	if err != nil {
-		log.Warnf("failed to create a folder for broken index backup: %v", err)
+	if !n.strictLoad {
+		err = file.MkdirAll(n.brokenPath, fs.ModePerm)
+		if err != nil {
+			log.Warnf("failed to create a folder for broken index backup: %v", err)
+		}
 	}
 
 	// update broken index count
@@ -434,6 +666,25 @@ func (n *ngt) load(ctx context.Context, path string, opts ...core.Option) (err e
 		err = errors.Wrapf(err, "cannot read metadata from path: %s\tmetadata: %s", path, agentMetadata)
 		return err
 	}
+	stored := quantization.None.String()
+	if agentMetadata.NGT.Quantization != nil {
+		stored = agentMetadata.NGT.Quantization.Type
+	}
+	if stored != n.qtype.String() {
+		return errors.ErrQuantizationMismatch(stored, n.qtype.String())
+	}
+	if n.quant != nil {
+		n.quant, err = quantization.LoadScalar(agentMetadata.NGT.Quantization.Mins, agentMetadata.NGT.Quantization.Maxs)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load quantization ranges from metadata: %s", metadataPath)
+		}
+		// the index saved before the retraining is supported is regarded as trained with all of its vectors
+		trained := agentMetadata.NGT.Quantization.TrainedCount
+		if trained == 0 {
+			trained = agentMetadata.NGT.IndexCount
+		}
+		n.qcount.Store(trained)
+	}
 	kvsFilePath := file.Join(path, kvsFileName)
 	log.Debugf("index path: %s and metadata: %s exists and successfully load metadata, now starting to load kvs data from %s", path, metadataPath, kvsFilePath)
 	exist, fi, err = file.ExistsWithDetail(kvsFilePath)
@@ -509,9 +760,32 @@ func (n *ngt) load(ctx context.Context, path string, opts ...core.Option) (err e
 		if n.kvs != nil && float64(agentMetadata.NGT.IndexCount/2) > float64(n.kvs.Len()) {
 			return errors.ErrIndicesAreTooFewComparedToMetadata
 		}
+		if n.strictLoad && n.kvs != nil && n.kvs.Len() != agentMetadata.NGT.IndexCount {
+			return errors.ErrIndexCountMismatch(n.kvs.Len(), agentMetadata.NGT.IndexCount)
+		}
+		return nil
+	}))
+
+	eg.Go(safety.RecoverFunc(func() (err error) {
+		err = n.loadTTL(path)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to load ttl kvsdb data from path: %s", path)
+			return err
+		}
 		return nil
 	}))
 
+	if n.raw != nil {
+		eg.Go(safety.RecoverFunc(func() (err error) {
+			err = n.raw.Restore(ctx, file.Join(path, rawVectorFileName))
+			if err != nil {
+				err = errors.Wrapf(err, "failed to load raw vectors from path: %s", path)
+				return err
+			}
+			return nil
+		}))
+	}
+
 	ech := make(chan error, 1)
 	// NOTE: when it exceeds the timeout while loading,
 	// it should exit this function and leave this goroutine running.
@@ -534,6 +808,9 @@ func (n *ngt) load(ctx context.Context, path string, opts ...core.Option) (err e
 		return err
 	case <-ctx.Done():
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			if n.strictLoad {
+				return errors.ErrIndexLoadTimeout
+			}
 			log.Errorf("cannot load index backup data from %s within the timeout %s. the process is going to be killed.", path, timeout)
 			err := metadata.Store(metadataPath,
 				&metadata.Metadata{
@@ -701,6 +978,13 @@ func (n *ngt) initNGT(opts ...core.Option) (err error) {
 
 	ctx := context.Background()
 	err = n.load(ctx, n.path, opts...)
+	if err != nil && n.strictLoad {
+		if n.core != nil {
+			n.core.Close()
+			n.core = nil
+		}
+		return errors.Wrapf(err, "failed to load index from %s", n.path)
+	}
 	var current uint64
 	if err != nil {
 		if !n.enableCopyOnWrite {
@@ -884,6 +1168,15 @@ func (n *ngt) loadKVS(ctx context.Context, path string, timeout time.Duration) (
 }
 
 func (n *ngt) Start(ctx context.Context) <-chan error {
+	if n.rt != nil && n.rt.mode == realtimeBatch {
+		n.startRealtime(ctx)
+	}
+	if n.ttlSweep > 0 && !n.isReadReplica {
+		n.startTTLSweep(ctx)
+	}
+	if n.isReadReplica && len(n.replicaBase) != 0 {
+		n.startReplicaReload(ctx)
+	}
 	if n.dcd {
 		return nil
 	}
@@ -891,15 +1184,12 @@ func (n *ngt) Start(ctx context.Context) <-chan error {
 	ech := make(chan error, 2)
 	n.eg.Go(safety.RecoverFunc(func() (err error) {
 		defer close(ech)
-		if n.dur <= 0 {
-			n.dur = math.MaxInt64
-		}
-		if n.sdur <= 0 {
-			n.sdur = math.MaxInt64
-		}
-		if n.lim <= 0 {
-			n.lim = math.MaxInt64
-		}
+		n.setAutoIndexing(&autoIndexing{
+			dur:  n.dur,
+			lim:  n.lim,
+			sdur: n.sdur,
+			alen: n.alen,
+		})
 
 		// add initial delay before starting auto indexing
 		if n.idelay > 0 {
@@ -943,6 +1233,14 @@ func (n *ngt) Start(ctx context.Context) <-chan error {
 				if n.enableExportIndexInfo {
 					err = n.exportMetricsOnTick(ctx)
 				}
+			case <-n.reloadAutoIndex:
+				if ai := n.pendingAutoIndex.Swap(nil); ai != nil {
+					n.setAutoIndexing(ai)
+					tick.Reset(n.dur)
+					sTick.Reset(n.sdur)
+					limit.Reset(n.lim)
+					log.Infof("auto indexing parameters updated: check duration = %s, duration limit = %s, save duration = %s, length = %d", n.dur, n.lim, n.sdur, n.alen)
+				}
 			}
 			if err != nil && err != errors.ErrUncommittedIndexNotFound {
 				ech <- err
@@ -954,6 +1252,80 @@ func (n *ngt) Start(ctx context.Context) <-chan error {
 	return ech
 }
 
+// autoIndexing holds the parameters of the auto indexing loop which can be updated at runtime.
+type autoIndexing struct {
+	dur  time.Duration
+	lim  time.Duration
+	sdur time.Duration
+	alen int
+}
+
+// setAutoIndexing sets the auto indexing parameters, non-positive durations disable the corresponding ticker.
+// It must be called only by the auto indexing loop.
+func (n *ngt) setAutoIndexing(ai *autoIndexing) {
+	n.dur, n.lim, n.sdur, n.alen = ai.dur, ai.lim, ai.sdur, ai.alen
+	if n.dur <= 0 {
+		n.dur = math.MaxInt64
+	}
+	if n.sdur <= 0 {
+		n.sdur = math.MaxInt64
+	}
+	if n.lim <= 0 {
+		n.lim = math.MaxInt64
+	}
+}
+
+// autoIndexingOptions returns the options setting the auto indexing durations and length of cfg.
+func autoIndexingOptions(cfg *config.NGT) []Option {
+	return []Option{
+		WithAutoIndexCheckDuration(cfg.AutoIndexCheckDuration),
+		WithAutoIndexDurationLimit(cfg.AutoIndexDurationLimit),
+		WithAutoSaveIndexDuration(cfg.AutoSaveIndexDuration),
+		WithAutoIndexLength(cfg.AutoIndexLength),
+	}
+}
+
+// parseAutoIndexing returns the auto indexing parameters of cfg.
+// It returns an error when a parameter is invalid or the auto indexing would be enabled or disabled.
+func (n *ngt) parseAutoIndexing(cfg *config.NGT) (*autoIndexing, error) {
+	t := new(ngt)
+	for _, opt := range autoIndexingOptions(cfg) {
+		if err := opt(t); err != nil {
+			return nil, errors.ErrOptionFailed(err, reflect.ValueOf(opt))
+		}
+	}
+	if n.dcd != (t.dur == 0 || t.alen == 0) {
+		return nil, errors.ErrAutoIndexingToggled
+	}
+	return &autoIndexing{
+		dur:  t.dur,
+		lim:  t.lim,
+		sdur: t.sdur,
+		alen: t.alen,
+	}, nil
+}
+
+// ValidateAutoIndexing returns the error UpdateAutoIndexing would return for cfg without applying anything.
+func (n *ngt) ValidateAutoIndexing(cfg *config.NGT) error {
+	_, err := n.parseAutoIndexing(cfg)
+	return err
+}
+
+// UpdateAutoIndexing applies the auto indexing durations and length of cfg to the running auto indexing loop.
+// The auto indexing can not be enabled or disabled at runtime.
+func (n *ngt) UpdateAutoIndexing(cfg *config.NGT) error {
+	ai, err := n.parseAutoIndexing(cfg)
+	if err != nil || n.dcd {
+		return err
+	}
+	n.pendingAutoIndex.Store(ai)
+	select {
+	case n.reloadAutoIndex <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
 func (n *ngt) Search(
 	ctx context.Context, vec []float32, size uint32, epsilon, radius float32,
 ) (res *payload.Search_Response, err error) {
@@ -963,19 +1335,112 @@ func (n *ngt) Search(
 	if n.IsIndexing() {
 		return nil, errors.ErrCreateIndexingIsInProgress
 	}
-	sr, err := n.core.Search(ctx, vec, int(size), epsilon, radius)
+	defer n.rlockReplica()()
+	var sr []algorithm.SearchResult
+	if n.quant != nil {
+		sr, err = n.searchQuantized(ctx, vec, size, epsilon, radius)
+	} else {
+		sr, err = n.core.Search(ctx, vec, int(size), epsilon, radius)
+	}
 	if err != nil {
 		if n.IsIndexing() {
 			return nil, errors.ErrCreateIndexingIsInProgress
 		}
-		if errors.Is(err, errors.ErrSearchResultEmptyButNoDataStored) && n.Len() == 0 {
-			return nil, nil
+		if errors.IsAny(err, errors.ErrSearchResultEmptyButNoDataStored, errors.ErrQuantizerNotTrained) && n.kvsLen() == 0 {
+			if radius == 0 {
+				radius = n.radius
+			}
+			return n.mergeUncommitted(ctx, vec, size, radius, nil, nil)
 		}
 		log.Errorf("cgo error detected during search: ngt api returned error %v", err)
 		return nil, err
 	}
 
-	return n.toSearchResponse(sr)
+	res, err = n.toSearchResponse(sr)
+	if radius == 0 {
+		radius = n.radius
+	}
+	return n.mergeUncommitted(ctx, vec, size, radius, res, err)
+}
+
+// searchQuantized searches the quantized index for the candidates and re-ranks them with the exact distance.
+// The radius is applied to the exact distance after the re-ranking.
+func (n *ngt) searchQuantized(
+	ctx context.Context, vec []float32, size uint32, epsilon, radius float32,
+) ([]algorithm.SearchResult, error) {
+	codes, err := n.quant.Encode(vec)
+	if err != nil {
+		return nil, err
+	}
+	sr, err := n.core.Search(ctx, codes, int(size)*n.rerank, epsilon, -1)
+	if err != nil {
+		return nil, err
+	}
+	if radius == 0 {
+		radius = n.radius
+	}
+	return n.rerankResults(vec, sr, int(size), radius), nil
+}
+
+// linearSearchQuantized linear searches the quantized index for the candidates and re-ranks them with the exact distance.
+func (n *ngt) linearSearchQuantized(
+	ctx context.Context, vec []float32, size uint32,
+) ([]algorithm.SearchResult, error) {
+	codes, err := n.quant.Encode(vec)
+	if err != nil {
+		return nil, err
+	}
+	sr, err := n.core.LinearSearch(ctx, codes, int(size)*n.rerank)
+	if err != nil {
+		return nil, err
+	}
+	return n.rerankResults(vec, sr, int(size), -1), nil
+}
+
+// rerankResults recomputes the exact distances of the candidates from the raw vectors and returns the top size results.
+// It also counts how many of the results are ranked in the top size of the quantized candidates to estimate the recall.
+func (n *ngt) rerankResults(
+	vec []float32, sr []algorithm.SearchResult, size int, radius float32,
+) []algorithm.SearchResult {
+	res := make([]algorithm.SearchResult, 0, len(sr))
+	for _, r := range sr {
+		if r.ID == 0 && r.Error != nil {
+			res = append(res, r)
+			continue
+		}
+		raw, err := n.raw.Get(r.ID)
+		if err != nil {
+			log.Warnf("failed to get raw vector for re-ranking, oid: %d, error: %v", r.ID, err)
+			continue
+		}
+		d := n.qdist(vec, raw)
+		if radius >= 0 && d > radius {
+			continue
+		}
+		res = append(res, algorithm.SearchResult{
+			ID:       r.ID,
+			Distance: d,
+		})
+	}
+	slices.SortStableFunc(res, func(a, b algorithm.SearchResult) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+	if len(res) > size {
+		res = res[:size]
+	}
+	top := make(map[uint32]struct{}, size)
+	for i := 0; i < len(sr) && i < size; i++ {
+		top[sr[i].ID] = struct{}{}
+	}
+	var hits uint64
+	for _, r := range res {
+		if _, ok := top[r.ID]; ok && r.ID != 0 {
+			hits++
+		}
+	}
+	n.qhits.Add(hits)
+	n.qtotal.Add(uint64(len(res)))
+	return res
 }
 
 func (n *ngt) SearchByID(
@@ -1007,19 +1472,26 @@ func (n *ngt) LinearSearch(
 	if n.IsIndexing() {
 		return nil, errors.ErrCreateIndexingIsInProgress
 	}
-	sr, err := n.core.LinearSearch(ctx, vec, int(size))
+	defer n.rlockReplica()()
+	var sr []algorithm.SearchResult
+	if n.quant != nil {
+		sr, err = n.linearSearchQuantized(ctx, vec, size)
+	} else {
+		sr, err = n.core.LinearSearch(ctx, vec, int(size))
+	}
 	if err != nil {
 		if n.IsIndexing() {
 			return nil, errors.ErrCreateIndexingIsInProgress
 		}
-		if errors.Is(err, errors.ErrSearchResultEmptyButNoDataStored) && n.Len() == 0 {
-			return nil, nil
+		if errors.IsAny(err, errors.ErrSearchResultEmptyButNoDataStored, errors.ErrQuantizerNotTrained) && n.kvsLen() == 0 {
+			return n.mergeUncommitted(ctx, vec, size, -1, nil, nil)
 		}
 		log.Errorf("cgo error detected during linear search: ngt api returned error %v", err)
 		return nil, err
 	}
 
-	return n.toSearchResponse(sr)
+	res, err = n.toSearchResponse(sr)
+	return n.mergeUncommitted(ctx, vec, size, -1, res, err)
 }
 
 func (n *ngt) LinearSearchByID(
@@ -1042,57 +1514,81 @@ func (n *ngt) LinearSearchByID(
 	return vec, dst, nil
 }
 
-func (n *ngt) Insert(uuid string, vec []float32) (err error) {
+func (n *ngt) Insert(ctx context.Context, uuid string, vec []float32) (err error) {
+	if n.IsFlushing() {
+		return errors.ErrFlushingIsInProgress
+	}
+	return n.commitWrite(ctx, n.insert(uuid, vec, time.Now().UnixNano(), 0, true))
+}
+
+func (n *ngt) InsertWithTime(ctx context.Context, uuid string, vec []float32, t int64) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
-	return n.insert(uuid, vec, time.Now().UnixNano(), true)
+	if t <= 0 {
+		t = time.Now().UnixNano()
+	}
+	return n.commitWrite(ctx, n.insert(uuid, vec, t, 0, true))
 }
 
-func (n *ngt) InsertWithTime(uuid string, vec []float32, t int64) (err error) {
+func (n *ngt) InsertWithTTL(ctx context.Context, uuid string, vec []float32, t int64, ttl time.Duration) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
 	if t <= 0 {
 		t = time.Now().UnixNano()
 	}
-	return n.insert(uuid, vec, t, true)
+	return n.commitWrite(ctx, n.insert(uuid, vec, t, ttl, true))
 }
 
-func (n *ngt) insert(uuid string, vec []float32, t int64, validation bool) (err error) {
+func (n *ngt) insert(uuid string, vec []float32, t int64, ttl time.Duration, validation bool) (err error) {
 	if len(uuid) == 0 {
 		err = errors.ErrUUIDNotFound(0)
 		return err
 	}
+	unlock := n.lockWrite(uuid)
+	defer unlock()
 	if validation {
 		_, ok := n.Exists(uuid)
 		if ok {
 			return errors.ErrUUIDAlreadyExists(uuid)
 		}
 	}
-	return n.vq.PushInsert(uuid, vec, t)
+	err = n.vq.PushInsert(uuid, vec, t)
+	if err != nil {
+		return err
+	}
+	n.setTTL(uuid, ttl)
+	return nil
 }
 
-func (n *ngt) InsertMultiple(vecs map[string][]float32) (err error) {
+func (n *ngt) InsertMultiple(ctx context.Context, vecs map[string][]float32) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
-	return n.insertMultiple(vecs, time.Now().UnixNano(), true)
+	return n.commitWrite(ctx, n.insertMultiple(vecs, time.Now().UnixNano(), nil, true))
 }
 
-func (n *ngt) InsertMultipleWithTime(vecs map[string][]float32, t int64) (err error) {
+func (n *ngt) InsertMultipleWithTime(ctx context.Context, vecs map[string][]float32, t int64) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
 	if t <= 0 {
 		t = time.Now().UnixNano()
 	}
-	return n.insertMultiple(vecs, t, true)
+	return n.commitWrite(ctx, n.insertMultiple(vecs, t, nil, true))
 }
 
-func (n *ngt) insertMultiple(vecs map[string][]float32, now int64, validation bool) (err error) {
+func (n *ngt) InsertMultipleWithTTL(ctx context.Context, vecs map[string][]float32, ttls map[string]time.Duration) (err error) {
+	if n.IsFlushing() {
+		return errors.ErrFlushingIsInProgress
+	}
+	return n.commitWrite(ctx, n.insertMultiple(vecs, time.Now().UnixNano(), ttls, true))
+}
+
+func (n *ngt) insertMultiple(vecs map[string][]float32, now int64, ttls map[string]time.Duration, validation bool) (err error) {
 	for uuid, vec := range vecs {
-		ierr := n.insert(uuid, vec, now, validation)
+		ierr := n.insert(uuid, vec, now, ttls[uuid], validation)
 		if ierr != nil {
 			if err != nil {
 				err = errors.Join(ierr, err)
@@ -1104,25 +1600,35 @@ func (n *ngt) insertMultiple(vecs map[string][]float32, now int64, validation bo
 	return err
 }
 
-func (n *ngt) Update(uuid string, vec []float32) (err error) {
+func (n *ngt) Update(ctx context.Context, uuid string, vec []float32) (err error) {
+	if n.IsFlushing() {
+		return errors.ErrFlushingIsInProgress
+	}
+	return n.commitWrite(ctx, n.update(ctx, uuid, vec, time.Now().UnixNano(), 0))
+}
+
+func (n *ngt) UpdateWithTime(ctx context.Context, uuid string, vec []float32, t int64) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
-	return n.update(uuid, vec, time.Now().UnixNano())
+	if t <= 0 {
+		t = time.Now().UnixNano()
+	}
+	return n.commitWrite(ctx, n.update(ctx, uuid, vec, t, 0))
 }
 
-func (n *ngt) UpdateWithTime(uuid string, vec []float32, t int64) (err error) {
+func (n *ngt) UpdateWithTTL(ctx context.Context, uuid string, vec []float32, t int64, ttl time.Duration) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
 	if t <= 0 {
 		t = time.Now().UnixNano()
 	}
-	return n.update(uuid, vec, t)
+	return n.commitWrite(ctx, n.update(ctx, uuid, vec, t, ttl))
 }
 
-func (n *ngt) update(uuid string, vec []float32, t int64) (err error) {
-	if err = n.readyForUpdate(uuid, vec, t); err != nil {
+func (n *ngt) update(ctx context.Context, uuid string, vec []float32, t int64, ttl time.Duration) (err error) {
+	if err = n.readyForUpdate(ctx, uuid, vec, t); err != nil {
 		return err
 	}
 	err = n.delete(uuid, t, true) // `true` is to return NotFound error with non-existent ID
@@ -1130,30 +1636,37 @@ func (n *ngt) update(uuid string, vec []float32, t int64) (err error) {
 		return err
 	}
 	t++
-	return n.insert(uuid, vec, t, false)
+	return n.insert(uuid, vec, t, ttl, false)
 }
 
-func (n *ngt) UpdateMultiple(vecs map[string][]float32) (err error) {
+func (n *ngt) UpdateMultiple(ctx context.Context, vecs map[string][]float32) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
-	return n.updateMultiple(vecs, time.Now().UnixNano())
+	return n.commitWrite(ctx, n.updateMultiple(ctx, vecs, time.Now().UnixNano(), nil))
 }
 
-func (n *ngt) UpdateMultipleWithTime(vecs map[string][]float32, t int64) (err error) {
+func (n *ngt) UpdateMultipleWithTime(ctx context.Context, vecs map[string][]float32, t int64) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
 	if t <= 0 {
 		t = time.Now().UnixNano()
 	}
-	return n.updateMultiple(vecs, t)
+	return n.commitWrite(ctx, n.updateMultiple(ctx, vecs, t, nil))
+}
+
+func (n *ngt) UpdateMultipleWithTTL(ctx context.Context, vecs map[string][]float32, ttls map[string]time.Duration) (err error) {
+	if n.IsFlushing() {
+		return errors.ErrFlushingIsInProgress
+	}
+	return n.commitWrite(ctx, n.updateMultiple(ctx, vecs, time.Now().UnixNano(), ttls))
 }
 
-func (n *ngt) updateMultiple(vecs map[string][]float32, t int64) (err error) {
+func (n *ngt) updateMultiple(ctx context.Context, vecs map[string][]float32, t int64, ttls map[string]time.Duration) (err error) {
 	uuids := make([]string, 0, len(vecs))
 	for uuid, vec := range vecs {
-		if err = n.readyForUpdate(uuid, vec, t); err != nil {
+		if err = n.readyForUpdate(ctx, uuid, vec, t); err != nil {
 			delete(vecs, uuid)
 		} else {
 			uuids = append(uuids, uuid)
@@ -1164,33 +1677,36 @@ func (n *ngt) updateMultiple(vecs map[string][]float32, t int64) (err error) {
 		return err
 	}
 	t++
-	return n.insertMultiple(vecs, t, false)
+	return n.insertMultiple(vecs, t, ttls, false)
 }
 
-func (n *ngt) UpdateTimestamp(uuid string, ts int64, force bool) (err error) {
+func (n *ngt) UpdateTimestamp(ctx context.Context, uuid string, ts int64, force bool) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
-	return memstore.UpdateTimestamp(n.kvs, n.vq, uuid, ts, force, func(oid uint32) ([]float32, error) {
+	unlock := n.lockWrite(uuid)
+	err = memstore.UpdateTimestamp(n.kvs, n.vq, uuid, ts, force, func(oid uint32) ([]float32, error) {
 		return n.core.GetVector(uint(oid))
 	})
+	unlock()
+	return n.commitWrite(ctx, err)
 }
 
-func (n *ngt) Delete(uuid string) (err error) {
+func (n *ngt) Delete(ctx context.Context, uuid string) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
-	return n.delete(uuid, time.Now().UnixNano(), true)
+	return n.commitWrite(ctx, n.delete(uuid, time.Now().UnixNano(), true))
 }
 
-func (n *ngt) DeleteWithTime(uuid string, t int64) (err error) {
+func (n *ngt) DeleteWithTime(ctx context.Context, uuid string, t int64) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
 	if t <= 0 {
 		t = time.Now().UnixNano()
 	}
-	return n.delete(uuid, t, true)
+	return n.commitWrite(ctx, n.delete(uuid, t, true))
 }
 
 func (n *ngt) delete(uuid string, t int64, validation bool) (err error) {
@@ -1198,6 +1714,8 @@ func (n *ngt) delete(uuid string, t int64, validation bool) (err error) {
 		err = errors.ErrUUIDNotFound(0)
 		return err
 	}
+	unlock := n.lockWrite(uuid)
+	defer unlock()
 	if validation {
 		_, _, ok := n.kvs.Get(uuid)
 		if !ok && func() (ok bool) {
@@ -1210,21 +1728,21 @@ func (n *ngt) delete(uuid string, t int64, validation bool) (err error) {
 	return n.vq.PushDelete(uuid, t)
 }
 
-func (n *ngt) DeleteMultiple(uuids ...string) (err error) {
+func (n *ngt) DeleteMultiple(ctx context.Context, uuids ...string) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
-	return n.deleteMultiple(uuids, time.Now().UnixNano(), true)
+	return n.commitWrite(ctx, n.deleteMultiple(uuids, time.Now().UnixNano(), true))
 }
 
-func (n *ngt) DeleteMultipleWithTime(uuids []string, t int64) (err error) {
+func (n *ngt) DeleteMultipleWithTime(ctx context.Context, uuids []string, t int64) (err error) {
 	if n.IsFlushing() {
 		return errors.ErrFlushingIsInProgress
 	}
 	if t <= 0 {
 		t = time.Now().UnixNano()
 	}
-	return n.deleteMultiple(uuids, t, true)
+	return n.commitWrite(ctx, n.deleteMultiple(uuids, t, true))
 }
 
 func (n *ngt) deleteMultiple(uuids []string, now int64, validation bool) (err error) {
@@ -1299,6 +1817,14 @@ func (n *ngt) RegenerateIndexes(ctx context.Context) (err error) {
 		}
 	}
 
+	// delete raw vectors
+	if n.raw != nil {
+		err = errors.Join(n.raw.Truncate(), n.raw.Close())
+		if err != nil {
+			log.Errorf("failed to flushing vector to ngt index in delete raw vectors. error: %v", err)
+		}
+	}
+
 	// renew instance
 	nn, err := newNGT(n.cfg, n.opts...)
 	if err != nil {
@@ -1328,6 +1854,9 @@ func (n *ngt) CreateIndex(ctx context.Context, poolSize uint32) (err error) {
 	if ic == 0 {
 		return errors.ErrUncommittedIndexNotFound
 	}
+	if n.rt != nil {
+		return n.commitRealtime(ctx)
+	}
 	wf := atomic.AddUint64(&n.wfci, 1)
 	if wf > 1 {
 		atomic.AddUint64(&n.wfci, ^uint64(0))
@@ -1369,57 +1898,31 @@ func (n *ngt) CreateIndex(ctx context.Context, poolSize uint32) (err error) {
 	// This will be added to nopvq after CreateIndex operation succeeds.
 	var vqProcessedCnt uint64
 	n.vq.RangePopDelete(ctx, now, func(uuid string) bool {
-		log.Debugf("start delete operation for kvsdb id: %s", uuid)
-		oid, ok := n.kvs.Delete(uuid)
-		if !ok {
-			log.Warn(errors.ErrObjectIDNotFound(uuid))
-			return true
+		if n.removeIndex(uuid) {
+			vqProcessedCnt++
 		}
-		log.Debugf("start remove operation for ngt index id: %s, oid: %d", uuid, oid)
-		if err = n.core.Remove(uint(oid)); err != nil {
-			log.Errorf("failed to remove oid: %d from ngt index. error: %v", oid, err)
-			n.fmu.Lock()
-			n.fmap[uuid] = int64(oid)
-			n.fmu.Unlock()
-		}
-		log.Debugf("removed from ngt index and kvsdb id: %s, oid: %d", uuid, oid)
-
-		vqProcessedCnt++
 		return true
 	})
 	log.Debug("create index delete phase finished")
 	n.gc()
+	if n.quant != nil && !n.quant.Trained() {
+		log.Debug("create index quantizer training phase started")
+		n.trainQuantizer(ctx)
+		log.Debug("create index quantizer training phase finished")
+	} else if n.needsRetrainQuantizer() {
+		log.Debug("create index quantizer retraining phase started")
+		if err = n.retrainQuantizer(ctx); err != nil {
+			log.Errorf("failed to retrain the quantizer: %v", err)
+		}
+		log.Debug("create index quantizer retraining phase finished")
+	}
 	log.Debug("create index insert phase started")
 	var icnt uint32
 	n.vq.RangePopInsert(ctx, now, func(uuid string, vector []float32, timestamp int64) bool {
-		log.Debugf("start insert operation for ngt index id: %s", uuid)
-		var oid uint
-		oid, err = n.core.Insert(vector)
-		if err != nil {
-			log.Warnf("failed to insert vector uuid: %s vec: %v to ngt index. error: %v", uuid, vector, err)
-			if errors.Is(err, errors.ErrIncompatibleDimensionSize(len(vector), n.dim)) {
-				log.Error(err)
-				return true
-			}
-			oid, err = n.core.Insert(vector)
-			if err != nil {
-				log.Errorf("failed to retry insert vector uuid: %s vec: %v to ngt index. error: %v", uuid, vector, err)
-				return true
-			}
+		if n.insertIndex(uuid, vector, timestamp) {
+			atomic.AddUint32(&icnt, 1)
+			vqProcessedCnt++
 		}
-		log.Debugf("start insert operation for kvsdb id: %s, oid: %d", uuid, oid)
-		n.kvs.Set(uuid, uint32(oid), timestamp)
-		atomic.AddUint32(&icnt, 1)
-
-		n.fmu.Lock()
-		_, ok := n.fmap[uuid]
-		if ok {
-			delete(n.fmap, uuid)
-		}
-		n.fmu.Unlock()
-		log.Debugf("finished to insert ngt index and kvsdb id: %s, oid: %d", uuid, oid)
-
-		vqProcessedCnt++
 		return true
 	})
 	if poolSize <= 0 {
@@ -1453,6 +1956,77 @@ func (n *ngt) CreateIndex(ctx context.Context, poolSize uint32) (err error) {
 	return n.loadStatistics(ctx)
 }
 
+// removeIndex removes the queued delete of uuid from the kvsdb and the ngt index, and reports whether it is processed.
+func (n *ngt) removeIndex(uuid string) bool {
+	log.Debugf("start delete operation for kvsdb id: %s", uuid)
+	oid, ok := n.kvs.Delete(uuid)
+	if !ok {
+		log.Warn(errors.ErrObjectIDNotFound(uuid))
+		return false
+	}
+	if _, ok := n.vq.IVExists(uuid); !ok {
+		// keep the ttl of the update which re-inserts the object after this delete
+		n.ttls.Delete(uuid)
+	}
+	log.Debugf("start remove operation for ngt index id: %s, oid: %d", uuid, oid)
+	if err := n.core.Remove(uint(oid)); err != nil {
+		log.Errorf("failed to remove oid: %d from ngt index. error: %v", oid, err)
+		n.fmu.Lock()
+		n.fmap[uuid] = int64(oid)
+		n.fmu.Unlock()
+	}
+	log.Debugf("removed from ngt index and kvsdb id: %s, oid: %d", uuid, oid)
+	return true
+}
+
+// insertIndex inserts the queued vector of uuid to the ngt index and the kvsdb, and reports whether it is processed.
+func (n *ngt) insertIndex(uuid string, vector []float32, timestamp int64) bool {
+	log.Debugf("start insert operation for ngt index id: %s", uuid)
+	obj := vector
+	var err error
+	if n.quant != nil {
+		obj, err = n.quant.Encode(vector)
+		if err != nil {
+			log.Errorf("failed to quantize vector uuid: %s vec: %v. error: %v", uuid, vector, err)
+			return false
+		}
+	}
+	oid, err := n.core.Insert(obj)
+	if err != nil {
+		log.Warnf("failed to insert vector uuid: %s vec: %v to ngt index. error: %v", uuid, vector, err)
+		if errors.Is(err, errors.ErrIncompatibleDimensionSize(len(vector), n.dim)) {
+			log.Error(err)
+			return false
+		}
+		oid, err = n.core.Insert(obj)
+		if err != nil {
+			log.Errorf("failed to retry insert vector uuid: %s vec: %v to ngt index. error: %v", uuid, vector, err)
+			return false
+		}
+	}
+	n.setIndexed(uuid, oid, vector, timestamp)
+	return true
+}
+
+// setIndexed stores the raw vector and the kvsdb entry of uuid inserted to the ngt index as oid.
+func (n *ngt) setIndexed(uuid string, oid uint, vector []float32, timestamp int64) {
+	if n.raw != nil {
+		if err := n.raw.Put(uint32(oid), vector); err != nil {
+			log.Errorf("failed to store raw vector uuid: %s oid: %d. error: %v", uuid, oid, err)
+		}
+	}
+	log.Debugf("start insert operation for kvsdb id: %s, oid: %d", uuid, oid)
+	n.kvs.Set(uuid, uint32(oid), timestamp)
+
+	n.fmu.Lock()
+	_, ok := n.fmap[uuid]
+	if ok {
+		delete(n.fmap, uuid)
+	}
+	n.fmu.Unlock()
+	log.Debugf("finished to insert ngt index and kvsdb id: %s, oid: %d", uuid, oid)
+}
+
 func (n *ngt) loadStatistics(ctx context.Context) (err error) {
 	if n.IsStatisticsEnabled() {
 		log.Info("loading index statistics to cache")
@@ -1603,6 +2177,11 @@ func (n *ngt) saveIndex(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if n.rt != nil {
+		// pause the real-time commits to save the kvsdb and the ngt index consistently
+		n.rt.mu.Lock()
+		defer n.rt.mu.Unlock()
+	}
 	n.saving.Store(true)
 
 	// number of processed vq before save operation
@@ -1723,6 +2302,12 @@ func (n *ngt) saveIndex(ctx context.Context) (err error) {
 		}))
 	}
 
+	if path != "" {
+		eg.Go(safety.RecoverFunc(func() (err error) {
+			return n.saveTTL(path)
+		}))
+	}
+
 	eg.Go(safety.RecoverFunc(func() (err error) {
 		n.fmu.Lock()
 		fl := len(n.fmap)
@@ -1765,6 +2350,18 @@ func (n *ngt) saveIndex(ctx context.Context) (err error) {
 		return nil
 	}))
 
+	if n.raw != nil && path != "" {
+		eg.Go(safety.RecoverFunc(func() error {
+			log.Debug("start save operation for raw vectors")
+			if err := n.raw.Snapshot(ectx, file.Join(path, rawVectorFileName)); err != nil {
+				log.Warnf("failed to save raw vectors, err: %v\tpath: %s", err, path)
+				return err
+			}
+			log.Debug("save operation for raw vectors finished")
+			return nil
+		}))
+	}
+
 	eg.Go(safety.RecoverFunc(func() error {
 		log.Debug("start save operation for index")
 		if err := n.core.SaveIndexWithPath(path); err != nil {
@@ -1786,7 +2383,8 @@ func (n *ngt) saveIndex(ctx context.Context) (err error) {
 		&metadata.Metadata{
 			IsInvalid: false,
 			NGT: &metadata.NGT{
-				IndexCount: kvsLen,
+				IndexCount:   kvsLen,
+				Quantization: n.quantizationMetadata(),
 			},
 		},
 	)
@@ -1867,16 +2465,21 @@ func (n *ngt) Exists(uuid string) (oid uint32, ok bool) {
 			uuid, errors.ErrFlushingIsInProgress)
 		return 0, false
 	}
+	defer n.rlockReplica()()
 	return memstore.Exists(n.kvs, n.vq, uuid)
 }
 
 func (n *ngt) GetObject(uuid string) (vec []float32, timestamp int64, err error) {
+	defer n.rlockReplica()()
 	return memstore.GetObject(n.kvs, n.vq, uuid, func(oid uint32) ([]float32, error) {
+		if n.raw != nil {
+			return n.raw.Get(oid)
+		}
 		return n.core.GetVector(uint(oid))
 	})
 }
 
-func (n *ngt) readyForUpdate(uuid string, vec []float32, ts int64) (err error) {
+func (n *ngt) readyForUpdate(ctx context.Context, uuid string, vec []float32, ts int64) (err error) {
 	if len(uuid) == 0 {
 		return errors.ErrUUIDNotFound(0)
 	}
@@ -1894,7 +2497,7 @@ func (n *ngt) readyForUpdate(uuid string, vec []float32, ts int64) (err error) {
 	}
 
 	if ots < ts {
-		err = n.UpdateTimestamp(uuid, ts, false)
+		err = n.UpdateTimestamp(ctx, uuid, ts, false)
 		if err != nil {
 			return err
 		}
@@ -1919,6 +2522,7 @@ func (n *ngt) IsFlushing() bool {
 }
 
 func (n *ngt) UUIDs(ctx context.Context) (uuids []string) {
+	defer n.rlockReplica()()
 	return memstore.UUIDs(ctx, n.kvs, n.vq)
 }
 
@@ -1942,6 +2546,12 @@ func (n *ngt) gc() {
 }
 
 func (n *ngt) Len() uint64 {
+	defer n.rlockReplica()()
+	return n.kvsLen()
+}
+
+// kvsLen returns the number of the indexed objects, the index generation must be locked by the caller.
+func (n *ngt) kvsLen() uint64 {
 	if n.kvs != nil && !n.IsFlushing() {
 		return n.kvs.Len()
 	}
@@ -1949,6 +2559,7 @@ func (n *ngt) Len() uint64 {
 }
 
 func (n *ngt) InsertVQueueBufferLen() uint64 {
+	defer n.rlockReplica()()
 	if n.vq != nil && !n.IsFlushing() {
 		return uint64(n.vq.IVQLen())
 	}
@@ -1956,6 +2567,7 @@ func (n *ngt) InsertVQueueBufferLen() uint64 {
 }
 
 func (n *ngt) DeleteVQueueBufferLen() uint64 {
+	defer n.rlockReplica()()
 	if n.vq != nil && !n.IsFlushing() {
 		return uint64(n.vq.DVQLen())
 	}
@@ -1968,6 +2580,13 @@ func (n *ngt) GetDimensionSize() int {
 
 func (n *ngt) Close(ctx context.Context) (err error) {
 	defer n.core.Close()
+	if n.raw != nil {
+		defer func() {
+			if rerr := n.raw.Close(); rerr != nil {
+				err = errors.Join(err, rerr)
+			}
+		}()
+	}
 	defer func() {
 		kerr := n.kvs.Close()
 		if errors.IsNot(kerr, context.Canceled, context.DeadlineExceeded) {
@@ -2011,6 +2630,7 @@ func (n *ngt) BrokenIndexCount() uint64 {
 // Use this function for performing something on each object with caring about the memory usage.
 // If the vector exists in the vqueue, this vector is not indexed so the oid(object ID) is processed as 0.
 func (n *ngt) ListObjectFunc(ctx context.Context, f func(uuid string, oid uint32, ts int64) bool) {
+	defer n.rlockReplica()()
 	memstore.ListObjectFunc(ctx, n.kvs, n.vq, f)
 }
 
@@ -2022,19 +2642,47 @@ func (n *ngt) IndexStatistics() (stats *payload.Info_Index_Statistics, err error
 	if stats == nil {
 		return nil, errors.ErrNGTIndexStatisticsNotReady
 	}
+	defer n.rlockReplica()()
+	if n.quant != nil {
+		stats = stats.CloneVT()
+		stats.QuantizationSavedMemoryBytes = n.quantizationSavedMemory()
+		stats.QuantizationRecallSamples = n.qtotal.Load()
+		if stats.QuantizationRecallSamples > 0 {
+			stats.QuantizationRecall = float64(n.qhits.Load()) / float64(stats.QuantizationRecallSamples)
+		}
+	}
 	return stats, nil
 }
 
+// quantizationSavedMemory returns the memory saved by storing the codes in the index instead of the vectors of the configured object type.
+// The raw vectors of the in-memory mode agent are stored in the temporary file, which is usually memory backed in the container,
+// so their size is not counted as saved.
+func (n *ngt) quantizationSavedMemory() uint64 {
+	size := n.dim * 4
+	switch strings.ToLower(n.cfg.ObjectType) {
+	case "float16":
+		size = n.dim * 2
+	case "uint8":
+		size = n.dim
+	}
+	saved := int64(n.kvsLen()) * int64(size-n.qtype.CodeSize(n.dim))
+	if n.inMem && n.raw != nil {
+		saved -= n.raw.Size()
+	}
+	return uint64(max(saved, 0))
+}
+
 func (n *ngt) IsStatisticsEnabled() bool {
 	return n.enableStatistics
 }
 
 func (n *ngt) IndexProperty() (*payload.Info_Index_Property, error) {
+	defer n.rlockReplica()()
 	p, err := n.core.GetProperty()
 	if err != nil {
 		return nil, err
 	}
-	return &payload.Info_Index_Property{
+	prop := &payload.Info_Index_Property{
 		Dimension:                     p.Dimension,
 		ThreadPoolSize:                p.ThreadPoolSize,
 		ObjectType:                    p.ObjectType.String(),
@@ -2069,7 +2717,13 @@ func (n *ngt) IndexProperty() (*payload.Info_Index_Property, error) {
 		BuildTimeLimit:                p.BuildTimeLimit,
 		OutgoingEdge:                  p.OutgoingEdge,
 		IncomingEdge:                  p.IncomingEdge,
-	}, nil
+		QuantizationType:              n.qtype.String(),
+	}
+	if n.quant != nil {
+		prop.QuantizationRerankFactor = int32(n.rerank)
+		prop.QuantizedObjectSize = int32(n.qtype.CodeSize(n.dim))
+	}
+	return prop, nil
 }
 
 func (n *ngt) toSearchResponse(
@@ -142,7 +142,7 @@ var (
 			sy := systemUnderTest.(*ngtSystem)
 			ngt := sy.ngt
 
-			err := ngt.Insert(idA, vectors[idA][0])
+			err := ngt.Insert(sy.ctx, idA, vectors[idA][0])
 			return &resultContainer{
 				err: err,
 			}
@@ -190,7 +190,7 @@ var (
 			sy := systemUnderTest.(*ngtSystem)
 			ngt := sy.ngt
 
-			err := ngt.Insert(idB, vectors[idB][0])
+			err := ngt.Insert(sy.ctx, idB, vectors[idB][0])
 			return &resultContainer{
 				err: err,
 			}
@@ -239,7 +239,7 @@ var (
 			sy := systemUnderTest.(*ngtSystem)
 			ngt := sy.ngt
 
-			err := ngt.Insert(idC, vectors[idC][0])
+			err := ngt.Insert(sy.ctx, idC, vectors[idC][0])
 			return &resultContainer{
 				err: err,
 			}
@@ -827,7 +827,7 @@ var (
 		) commands.Result {
 			ngt := systemUnderTest.(*ngtSystem).ngt
 
-			err := ngt.Update(idA, vectors[idA][1])
+			err := ngt.Update(systemUnderTest.(*ngtSystem).ctx, idA, vectors[idA][1])
 			return &resultContainer{
 				err: err,
 			}
@@ -874,7 +874,7 @@ var (
 		) commands.Result {
 			ngt := systemUnderTest.(*ngtSystem).ngt
 
-			err := ngt.Update(idB, vectors[idB][1])
+			err := ngt.Update(systemUnderTest.(*ngtSystem).ctx, idB, vectors[idB][1])
 			return &resultContainer{
 				err: err,
 			}
@@ -921,7 +921,7 @@ var (
 		) commands.Result {
 			ngt := systemUnderTest.(*ngtSystem).ngt
 
-			err := ngt.Update(idC, vectors[idC][1])
+			err := ngt.Update(systemUnderTest.(*ngtSystem).ctx, idC, vectors[idC][1])
 			return &resultContainer{
 				err: err,
 			}
@@ -968,7 +968,7 @@ var (
 		) commands.Result {
 			ngt := systemUnderTest.(*ngtSystem).ngt
 
-			err := ngt.Delete(idA)
+			err := ngt.Delete(systemUnderTest.(*ngtSystem).ctx, idA)
 			return &resultContainer{
 				err: err,
 			}
@@ -1015,7 +1015,7 @@ var (
 		) commands.Result {
 			ngt := systemUnderTest.(*ngtSystem).ngt
 
-			err := ngt.Delete(idB)
+			err := ngt.Delete(systemUnderTest.(*ngtSystem).ctx, idB)
 			return &resultContainer{
 				err: err,
 			}
@@ -1062,7 +1062,7 @@ var (
 		) commands.Result {
 			ngt := systemUnderTest.(*ngtSystem).ngt
 
-			err := ngt.Delete(idC)
+			err := ngt.Delete(systemUnderTest.(*ngtSystem).ctx, idC)
 			return &resultContainer{
 				err: err,
 			}
@@ -861,7 +861,7 @@ func testReturnFromVq(t *testing.T) {
 	require.NoError(t, err)
 
 	now := time.Now().UnixNano()
-	err = ngt.InsertWithTime("test-uuid", []float32{1.0, 2.0, 3.0}, now)
+	err = ngt.InsertWithTime(t.Context(), "test-uuid", []float32{1.0, 2.0, 3.0}, now)
 	require.NoError(t, err)
 
 	vec, ts, err := ngt.GetObject("test-uuid")
@@ -877,7 +877,7 @@ func testReturnFromKvs(t *testing.T) {
 	require.NoError(t, err)
 
 	now := time.Now().UnixNano()
-	err = ngt.InsertWithTime("test-uuid", []float32{1.0, 2.0, 3.0}, now)
+	err = ngt.InsertWithTime(t.Context(), "test-uuid", []float32{1.0, 2.0, 3.0}, now)
 	require.NoError(t, err)
 
 	err = ngt.CreateIndex(context.Background(), 10)
@@ -908,7 +908,7 @@ func testFoundInBothIvqAndDvq(t *testing.T) {
 	require.NoError(t, err)
 
 	now := time.Now().UnixNano()
-	err = ngt.InsertWithTime("test-uuid", []float32{1.0, 2.0, 3.0}, now)
+	err = ngt.InsertWithTime(t.Context(), "test-uuid", []float32{1.0, 2.0, 3.0}, now)
 	require.NoError(t, err)
 
 	err = ngt.CreateIndex(context.Background(), 10)
@@ -917,7 +917,7 @@ func testFoundInBothIvqAndDvq(t *testing.T) {
 	buflen := ngt.InsertVQueueBufferLen()
 	require.Equal(t, buflen, uint64(0))
 
-	err = ngt.Delete("test-uuid")
+	err = ngt.Delete(t.Context(), "test-uuid")
 	require.NoError(t, err)
 
 	_, _, err = ngt.GetObject("test-uuid")
@@ -1143,7 +1143,7 @@ func TestExportIndexInfo(t *testing.T) {
 				require.NoError(t, err)
 
 				now := time.Now().UnixNano()
-				err = ngt.InsertWithTime("test-uuid", []float32{1.0, 2.0, 3.0}, now)
+				err = ngt.InsertWithTime(t.Context(), "test-uuid", []float32{1.0, 2.0, 3.0}, now)
 				require.NoError(t, err)
 
 				err = ngt.CreateIndex(context.Background(), 10)
@@ -1177,11 +1177,11 @@ func TestExportIndexInfo(t *testing.T) {
 				require.NoError(t, err)
 
 				time1 := time.Now().UnixNano()
-				err = ngt.InsertWithTime("test-uuid", []float32{1.0, 2.0, 3.0}, time1)
+				err = ngt.InsertWithTime(t.Context(), "test-uuid", []float32{1.0, 2.0, 3.0}, time1)
 				require.NoError(t, err)
 
 				time2 := time.Now().UnixNano()
-				err = ngt.InsertWithTime("test-uuid2", []float32{1.0, 2.0, 3.0}, time2)
+				err = ngt.InsertWithTime(t.Context(), "test-uuid2", []float32{1.0, 2.0, 3.0}, time2)
 				require.NoError(t, err)
 
 				err = ngt.CreateIndex(context.Background(), 10)
@@ -1215,14 +1215,14 @@ func TestExportIndexInfo(t *testing.T) {
 				require.NoError(t, err)
 
 				time1 := time.Now().UnixNano()
-				err = ngt.InsertWithTime("test-uuid", []float32{1.0, 2.0, 3.0}, time1)
+				err = ngt.InsertWithTime(t.Context(), "test-uuid", []float32{1.0, 2.0, 3.0}, time1)
 				require.NoError(t, err)
 
 				err = ngt.CreateIndex(context.Background(), 10)
 				require.NoError(t, err)
 
 				time2 := time.Now().UnixNano()
-				err = ngt.InsertWithTime("test-uuid2", []float32{1.0, 2.0, 3.0}, time2)
+				err = ngt.InsertWithTime(t.Context(), "test-uuid2", []float32{1.0, 2.0, 3.0}, time2)
 				require.NoError(t, err)
 
 				err = ngt.CreateIndex(context.Background(), 10)
@@ -1261,11 +1261,11 @@ func TestExportIndexInfo(t *testing.T) {
 				require.NoError(t, err)
 
 				time1 := time.Now().UnixNano()
-				err = ngt.InsertWithTime("test-uuid", []float32{1.0, 2.0, 3.0}, time1)
+				err = ngt.InsertWithTime(t.Context(), "test-uuid", []float32{1.0, 2.0, 3.0}, time1)
 				require.NoError(t, err)
 
 				time2 := time.Now().UnixNano()
-				err = ngt.InsertWithTime("test-uuid2", []float32{1.0, 2.0, 3.0}, time2)
+				err = ngt.InsertWithTime(t.Context(), "test-uuid2", []float32{1.0, 2.0, 3.0}, time2)
 				require.NoError(t, err)
 
 				ctx := context.Background()
@@ -1318,11 +1318,11 @@ func TestExportIndexInfo(t *testing.T) {
 				require.NoError(t, err)
 
 				time1 := time.Now().UnixNano()
-				err = n.InsertWithTime("test-uuid", []float32{1.0, 2.0, 3.0}, time1)
+				err = n.InsertWithTime(t.Context(), "test-uuid", []float32{1.0, 2.0, 3.0}, time1)
 				require.NoError(t, err)
 
 				time2 := time.Now().UnixNano()
-				err = n.InsertWithTime("test-uuid2", []float32{1.0, 2.0, 3.0}, time2)
+				err = n.InsertWithTime(t.Context(), "test-uuid2", []float32{1.0, 2.0, 3.0}, time2)
 				require.NoError(t, err)
 
 				ctx := context.Background()
@@ -1477,7 +1477,7 @@ func Test_ngt_InsertUpsert(t *testing.T) {
 				tt.Errorf("failed to init ngt service, error = %v", err)
 			}
 			for _, idx := range test.args.indices {
-				err = n.Insert(idx.uuid, idx.vec)
+				err = n.Insert(ctx, idx.uuid, idx.vec)
 				if err := checkFunc(test.want, err); err != nil {
 					tt.Errorf("error = %v", err)
 				}
@@ -1495,8 +1495,8 @@ func Test_ngt_InsertUpsert(t *testing.T) {
 				eg.Go(safety.RecoverFunc(func() error {
 					log.Warnf("started %d-1", idx)
 					for _, idx := range test.args.indices[:len(test.args.indices)/3] {
-						_ = n.Delete(idx.uuid)
-						_ = n.Insert(idx.uuid, idx.vec)
+						_ = n.Delete(ctx, idx.uuid)
+						_ = n.Insert(ctx, idx.uuid, idx.vec)
 					}
 					log.Warnf("finished %d-1", idx)
 					return nil
@@ -1505,8 +1505,8 @@ func Test_ngt_InsertUpsert(t *testing.T) {
 				eg.Go(safety.RecoverFunc(func() error {
 					log.Warnf("started %d-2", idx)
 					for _, idx := range test.args.indices[len(test.args.indices)/3 : 2*len(test.args.indices)/3] {
-						_ = n.Delete(idx.uuid)
-						_ = n.Insert(idx.uuid, idx.vec)
+						_ = n.Delete(ctx, idx.uuid)
+						_ = n.Insert(ctx, idx.uuid, idx.vec)
 					}
 					log.Warnf("finished %d-2", idx)
 					return nil
@@ -1515,8 +1515,8 @@ func Test_ngt_InsertUpsert(t *testing.T) {
 				eg.Go(safety.RecoverFunc(func() error {
 					log.Warnf("started %d-3", idx)
 					for _, idx := range test.args.indices[2*len(test.args.indices)/3:] {
-						_ = n.Delete(idx.uuid)
-						_ = n.Insert(idx.uuid, idx.vec)
+						_ = n.Delete(ctx, idx.uuid)
+						_ = n.Insert(ctx, idx.uuid, idx.vec)
 					}
 					log.Warnf("finished %d-3", idx)
 					return nil
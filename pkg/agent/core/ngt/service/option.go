@@ -30,6 +30,7 @@ import (
 	"github.com/vdaas/vald/internal/strings"
 	"github.com/vdaas/vald/internal/sync/errgroup"
 	"github.com/vdaas/vald/internal/timeutil"
+	"github.com/vdaas/vald/pkg/agent/internal/replica"
 )
 
 // Option represent the functional option for ngt.
@@ -51,6 +52,11 @@ var defaultOptions = []Option{
 	WithProactiveGC(true),
 	WithExportIndexInfoDuration("1m"),
 	WithEnableStatistics(false),
+	WithUncommittedSearchLimit(10000),
+	WithRealtimeIndexBatchSize(100),
+	WithRealtimeIndexBatchInterval("100ms"),
+	WithTTLSweepDuration("1m"),
+	WithReadReplicaReloadDuration("10s"),
 }
 
 // WithErrGroup returns the functional option to set the error group.
@@ -312,6 +318,47 @@ func WithIsReadReplica(isReadReplica bool) Option {
 	}
 }
 
+// WithReadReplicaSource returns the functional option to set the source of the read replica index.
+// The blob source loads the index generation pulled from the blob storage by the agent sidecar in readreplica mode.
+func WithReadReplicaSource(src string) Option {
+	return func(n *ngt) error {
+		if err := replica.ValidateSource(src); err != nil {
+			return errors.NewErrInvalidOption("readReplicaSource", src, err)
+		}
+		n.replicaSource = src
+		return nil
+	}
+}
+
+// WithReadReplicaReloadDuration returns the functional option to set the duration to check the index generation pulled from the blob storage.
+func WithReadReplicaReloadDuration(dur string) Option {
+	return func(n *ngt) error {
+		if dur == "" {
+			return nil
+		}
+
+		d, err := timeutil.Parse(dur)
+		if err != nil {
+			return err
+		}
+		if d > 0 {
+			n.replicaDur = d
+		}
+
+		return nil
+	}
+}
+
+// withReplicaGeneration returns the functional option to set the index generation loaded by the read replica.
+// The generation is loaded strictly, i.e. the load fails instead of falling back to the empty index and the pulled files are never modified.
+func withReplicaGeneration(id string) Option {
+	return func(n *ngt) error {
+		n.replicaGen = id
+		n.strictLoad = true
+		return nil
+	}
+}
+
 // WithExportIndexInfoDuration returns the functional option to set the duration of exporting index info to k8s.
 func WithExportIndexInfoDuration(dur string) Option {
 	return func(n *ngt) error {
@@ -348,3 +395,86 @@ func WithEnableStatistics(enabled bool) Option {
 		return nil
 	}
 }
+
+// WithUncommittedSearchLimit returns the functional option to set the maximum number of the queued vectors scanned by the uncommitted search.
+func WithUncommittedSearchLimit(l int) Option {
+	return func(n *ngt) error {
+		if l > 0 {
+			n.uncommittedLimit = l
+		}
+		return nil
+	}
+}
+
+// WithRealtimeIndexMode returns the functional option to set the real-time indexing mode.
+func WithRealtimeIndexMode(mode string) Option {
+	return func(n *ngt) (err error) {
+		n.rtMode, err = parseRealtimeMode(mode)
+		return err
+	}
+}
+
+// WithRealtimeIndexBatchSize returns the functional option to set the number of queued writes which triggers a micro-batch commit.
+func WithRealtimeIndexBatchSize(size int) Option {
+	return func(n *ngt) error {
+		if size > 0 {
+			n.rtBatch = size
+		}
+		return nil
+	}
+}
+
+// WithRealtimeIndexBatchInterval returns the functional option to set the maximum duration the queued writes wait for a micro-batch commit.
+func WithRealtimeIndexBatchInterval(dur string) Option {
+	return func(n *ngt) error {
+		if dur == "" {
+			return nil
+		}
+
+		d, err := timeutil.Parse(dur)
+		if err != nil {
+			return err
+		}
+		if d > 0 {
+			n.rtInterval = d
+		}
+
+		return nil
+	}
+}
+
+// WithDefaultTTL returns the functional option to set the time to live of the objects inserted without ttl.
+func WithDefaultTTL(dur string) Option {
+	return func(n *ngt) error {
+		if dur == "" {
+			return nil
+		}
+
+		d, err := timeutil.Parse(dur)
+		if err != nil {
+			return err
+		}
+		n.defaultTTL = d
+
+		return nil
+	}
+}
+
+// WithTTLSweepDuration returns the functional option to set the duration to delete the expired objects.
+func WithTTLSweepDuration(dur string) Option {
+	return func(n *ngt) error {
+		if dur == "" {
+			return nil
+		}
+
+		d, err := timeutil.Parse(dur)
+		if err != nil {
+			return err
+		}
+		if d > 0 {
+			n.ttlSweep = d
+		}
+
+		return nil
+	}
+}
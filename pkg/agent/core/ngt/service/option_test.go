@@ -1391,6 +1391,91 @@ func TestWithExportIndexInfoDuration(t *testing.T) {
 	}
 }
 
+func TestWithReadReplicaSource(t *testing.T) {
+	type T = ngt
+	type args struct {
+		src string
+	}
+	type want struct {
+		obj *T
+		err error
+	}
+	type test struct {
+		name       string
+		args       args
+		want       want
+		checkFunc  func(want, *T, error) error
+		beforeFunc func(args)
+		afterFunc  func(*testing.T, args)
+	}
+	defaultCheckFunc := func(w want, obj *T, err error) error {
+		if !errors.Is(err, w.err) {
+			return errors.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, w.err)
+		}
+		if !reflect.DeepEqual(obj, w.obj) {
+			return errors.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", obj, w.obj)
+		}
+		return nil
+	}
+	tests := []test{
+		{
+			name: "set success when source is blob",
+			args: args{
+				src: "blob",
+			},
+			want: want{
+				obj: &T{
+					replicaSource: "blob",
+				},
+			},
+		},
+		{
+			name: "set success when source is snapshot",
+			args: args{
+				src: "snapshot",
+			},
+			want: want{
+				obj: &T{
+					replicaSource: "snapshot",
+				},
+			},
+		},
+		{
+			name: "return error when source is not supported",
+			args: args{
+				src: "volume",
+			},
+			want: want{
+				obj: &T{},
+				err: errors.NewErrInvalidOption("readReplicaSource", "volume", errors.ErrUnsupportedReadReplicaSource("volume")),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			defer goleak.VerifyNone(tt, goleak.IgnoreCurrent())
+			if test.beforeFunc != nil {
+				test.beforeFunc(test.args)
+			}
+			if test.afterFunc != nil {
+				defer test.afterFunc(tt, test.args)
+			}
+			checkFunc := defaultCheckFunc
+			if test.checkFunc != nil {
+				checkFunc = test.checkFunc
+			}
+
+			got := WithReadReplicaSource(test.args.src)
+			obj := new(T)
+			if err := checkFunc(test.want, obj, got(obj)); err != nil {
+				tt.Errorf("error = %v", err)
+			}
+		})
+	}
+}
+
 // NOT IMPLEMENTED BELOW
 //
 // func TestWithIsReadReplica(t *testing.T) {
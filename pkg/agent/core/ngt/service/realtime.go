@@ -0,0 +1,353 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/hash"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/observability/trace"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+type realtimeMode uint8
+
+const (
+	realtimeDisabled realtimeMode = iota
+	realtimeSync
+	realtimeBatch
+)
+
+// realtimeLockShards is the number of the uuid shard locks of the real-time indexing.
+const realtimeLockShards = 64
+
+// RealtimeIndexStats represents the cumulative statistics of the real-time incremental indexing.
+type RealtimeIndexStats struct {
+	Commits        uint64        // number of the succeeded commits
+	Failures       uint64        // number of the failed commits
+	Vectors        uint64        // number of the committed inserts and deletes
+	LastBatchSize  uint64        // number of the inserts and deletes of the last commit
+	CommitDuration time.Duration // total duration of the commits
+	IndexLatency   time.Duration // total duration from the timestamps of the committed inserts to the end of their commits
+}
+
+// realtime holds the state of the real-time incremental indexing which commits the queued writes
+// to the ngt index as they arrive instead of waiting for the auto indexing loop or the index manager.
+type realtime struct {
+	mode     realtimeMode
+	batch    int
+	interval time.Duration
+
+	shards [realtimeLockShards]sync.Mutex // serialize the write and the commit of the same uuid
+	mu     sync.Mutex                     // held by a commit or a save index
+	sig    chan struct{}                  // micro-batch commit signal
+
+	seq     atomic.Uint64 // sequence number of the sync mode writes
+	done    uint64        // last sequence number covered by a commit, guarded by mu
+	err     error         // error of the last commit, guarded by mu
+	unbuilt bool          // whether the graph build of the inserted objects failed and must be retried, guarded by mu
+
+	commits   atomic.Uint64
+	failures  atomic.Uint64
+	vectors   atomic.Uint64
+	lastBatch atomic.Uint64
+	duration  atomic.Int64
+	latency   atomic.Int64
+}
+
+func parseRealtimeMode(mode string) (realtimeMode, error) {
+	switch strings.ToLower(mode) {
+	case "", "disabled":
+		return realtimeDisabled, nil
+	case "sync":
+		return realtimeSync, nil
+	case "batch":
+		return realtimeBatch, nil
+	}
+	return realtimeDisabled, errors.ErrUnsupportedRealtimeIndexMode(mode)
+}
+
+func newRealtime(mode realtimeMode, batch int, interval time.Duration) *realtime {
+	return &realtime{
+		mode:     mode,
+		batch:    batch,
+		interval: interval,
+		sig:      make(chan struct{}, 1),
+	}
+}
+
+// realtimeShard returns the index of the shard lock of uuid.
+func realtimeShard(uuid string) uint64 {
+	return hash.String(uuid) % realtimeLockShards
+}
+
+// lock locks the shard of uuid and returns the function to unlock it.
+func (r *realtime) lock(uuid string) (unlock func()) {
+	mu := &r.shards[realtimeShard(uuid)]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// lockWrite locks the shard of uuid in the real-time indexing mode, so that the validation and the enqueue of a write
+// do not interleave with the commit of the same uuid.
+func (n *ngt) lockWrite(uuid string) (unlock func()) {
+	if n.rt == nil {
+		return func() {}
+	}
+	return n.rt.lock(uuid)
+}
+
+// commitWrite commits the writes queued by a write operation in the real-time indexing mode.
+// The sync mode returns after the write is indexed, and the batch mode signals a micro-batch commit when the queue reaches the batch size.
+// The commit runs even if the write partially failed, since the succeeded part of it is already queued.
+func (n *ngt) commitWrite(ctx context.Context, err error) error {
+	if n.rt == nil {
+		return err
+	}
+	switch n.rt.mode {
+	case realtimeSync:
+		if cerr := n.commitSync(ctx, n.rt.seq.Add(1)); cerr != nil {
+			return errors.Join(err, cerr)
+		}
+	case realtimeBatch:
+		if int(n.vq.IVQLen()+n.vq.DVQLen()) >= n.rt.batch {
+			select {
+			case n.rt.sig <- struct{}{}:
+			default:
+			}
+		}
+	}
+	return err
+}
+
+// commitSync commits the queue unless the write of seq has already been committed by the group commit of a concurrent write.
+// The writers waiting on the commit lock are committed together by the first of them, which keeps the number of the graph builds
+// lower than the number of the writes under the concurrent ingest.
+func (n *ngt) commitSync(ctx context.Context, seq uint64) error {
+	n.rt.mu.Lock()
+	defer n.rt.mu.Unlock()
+	if n.rt.done >= seq {
+		return n.rt.err
+	}
+	return n.commit(ctx)
+}
+
+// commitRealtime commits the queue in the real-time indexing mode.
+func (n *ngt) commitRealtime(ctx context.Context) error {
+	n.rt.mu.Lock()
+	defer n.rt.mu.Unlock()
+	return n.commit(ctx)
+}
+
+// startRealtime starts the micro-batch commit loop of the batch mode, which commits the queue
+// when it reaches the batch size or the batch interval elapsed.
+func (n *ngt) startRealtime(ctx context.Context) {
+	n.eg.Go(safety.RecoverFunc(func() error {
+		tick := time.NewTicker(n.rt.interval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-tick.C:
+			case <-n.rt.sig:
+			}
+			if err := n.commitRealtime(ctx); err != nil {
+				log.Errorf("failed to commit the real-time index micro-batch: %v", err)
+			}
+		}
+	}))
+}
+
+// commit indexes the queued deletes and inserts, and builds the graph of the inserted objects. The caller must hold the commit lock.
+// Unlike CreateIndex it does not set the indexing flag, which blocks the searches, and only the shards of the committed uuids
+// are locked while their inserts are indexed, so the writes of the other uuids are not blocked by the commit.
+// The graph is built in memory only, and it is saved together with the kvsdb by the save index of the auto indexing loop or the index manager.
+// The inserts stored to the index are not reported as failed even if the graph build failed, since the build is retried by the next commit.
+func (n *ngt) commit(ctx context.Context) (err error) {
+	ctx, span := trace.StartSpan(ctx, "vald/agent-ngt/service/NGT.commit")
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	seq := n.rt.seq.Load()
+	defer func() {
+		n.rt.done, n.rt.err = seq, err
+	}()
+	if n.IsFlushing() {
+		return errors.ErrFlushingIsInProgress
+	}
+	if n.vq.IVQLen()+n.vq.DVQLen() == 0 && !n.rt.unbuilt {
+		return nil
+	}
+	start := time.Now()
+	now := start.UnixNano()
+	var cnt uint64
+	n.vq.RangePopDelete(ctx, now, func(uuid string) bool {
+		unlock := n.rt.lock(uuid)
+		defer unlock()
+		if n.removeIndex(uuid) {
+			cnt++
+		}
+		return true
+	})
+	if n.quant != nil && !n.quant.Trained() {
+		n.trainQuantizer(ctx)
+	} else if n.needsRetrainQuantizer() {
+		if rerr := n.retrainRealtimeQuantizer(ctx); rerr != nil {
+			log.Errorf("failed to retrain the quantizer: %v", rerr)
+		}
+	}
+	var shards [realtimeLockShards][]string
+	n.vq.Range(ctx, func(uuid string, _ []float32, ts int64) bool {
+		if ts <= now {
+			i := realtimeShard(uuid)
+			shards[i] = append(shards[i], uuid)
+		}
+		return true
+	})
+	tss, err := n.commitInserts(now, &shards)
+	cnt += uint64(len(tss))
+	var berr error
+	if len(tss) > 0 || n.rt.unbuilt {
+		berr = n.buildRealtime(len(tss))
+		n.rt.unbuilt = berr != nil
+	}
+	end := time.Now()
+	n.rt.duration.Add(int64(end.Sub(start)))
+	if err != nil || berr != nil {
+		n.rt.failures.Add(1)
+		if err != nil {
+			log.Errorf("failed to commit the real-time index inserts: %v", err)
+		}
+		if berr != nil {
+			log.Errorf("failed to build the real-time index graph of %d vectors, it is retried by the next commit: %v", len(tss), berr)
+		}
+	} else {
+		n.rt.commits.Add(1)
+	}
+	if berr == nil {
+		for _, ts := range tss {
+			if lat := end.UnixNano() - ts; lat > 0 {
+				n.rt.latency.Add(lat)
+			}
+		}
+		atomic.AddUint64(&n.nocie, 1)
+	}
+	n.rt.vectors.Add(cnt)
+	n.rt.lastBatch.Store(cnt)
+	n.nopvq.Add(cnt)
+	return err
+}
+
+// commitInserts pops the queued inserts of the uuids grouped by their shards and inserts them to the ngt index and the kvsdb,
+// and returns the timestamps of the inserted objects. The shards are locked in order until the inserts are stored.
+// The inserts which failed to be encoded or inserted are pushed back to the queue, so that they are retried by the next commit,
+// and the inserts whose vector does not match the dimension of the index are dropped, since they can never be indexed.
+func (n *ngt) commitInserts(now int64, shards *[realtimeLockShards][]string) (tss []int64, err error) {
+	for i := range shards {
+		if len(shards[i]) == 0 {
+			continue
+		}
+		mu := &n.rt.shards[i]
+		mu.Lock()
+		defer mu.Unlock()
+		for _, uuid := range shards[i] {
+			vector, timestamp, ok := n.vq.PopInsert(uuid)
+			if !ok {
+				continue
+			}
+			if timestamp > now {
+				// written after the commit started, e.g. the insert of an update whose delete is not committed yet
+				_ = n.vq.PushInsert(uuid, vector, timestamp)
+				continue
+			}
+			if len(vector) != n.dim {
+				derr := errors.ErrIncompatibleDimensionSize(len(vector), n.dim)
+				log.Errorf("failed to insert vector uuid: %s to ngt index. error: %v", uuid, derr)
+				err = errors.Join(err, derr)
+				continue
+			}
+			obj := vector
+			if n.quant != nil {
+				var qerr error
+				obj, qerr = n.quant.Encode(vector)
+				if qerr != nil {
+					log.Errorf("failed to quantize vector uuid: %s. error: %v", uuid, qerr)
+					_ = n.vq.PushInsert(uuid, vector, timestamp)
+					err = errors.Join(err, qerr)
+					continue
+				}
+			}
+			oid, ierr := n.core.Insert(obj)
+			if ierr != nil {
+				log.Errorf("failed to insert vector uuid: %s to ngt index. error: %v", uuid, ierr)
+				_ = n.vq.PushInsert(uuid, vector, timestamp)
+				err = errors.Join(err, ierr)
+				continue
+			}
+			n.setIndexed(uuid, oid, vector, timestamp)
+			tss = append(tss, timestamp)
+		}
+	}
+	return tss, err
+}
+
+// buildRealtime builds the graph of the objects inserted by the real-time commits.
+func (n *ngt) buildRealtime(inserted int) error {
+	poolSize := uint32(inserted)
+	if n.poolSize > 0 && (poolSize == 0 || n.poolSize < poolSize) {
+		poolSize = n.poolSize
+	}
+	return n.core.CreateIndex(poolSize)
+}
+
+// retrainRealtimeQuantizer retrains the quantizer in the real-time indexing mode. The searches are blocked by the indexing flag
+// while the indexed objects are re-encoded, since they are missing from the graph until it is built again.
+func (n *ngt) retrainRealtimeQuantizer(ctx context.Context) error {
+	n.cimu.Lock()
+	defer n.cimu.Unlock()
+	n.indexing.Store(true)
+	defer n.indexing.Store(false)
+	if err := n.retrainQuantizer(ctx); err != nil {
+		return err
+	}
+	return n.core.CreateIndex(n.poolSize)
+}
+
+// RealtimeIndexStats returns the statistics of the real-time incremental indexing, and false if it is disabled.
+func (n *ngt) RealtimeIndexStats() (stats RealtimeIndexStats, ok bool) {
+	if n.rt == nil {
+		return stats, false
+	}
+	return RealtimeIndexStats{
+		Commits:        n.rt.commits.Load(),
+		Failures:       n.rt.failures.Load(),
+		Vectors:        n.rt.vectors.Load(),
+		LastBatchSize:  n.rt.lastBatch.Load(),
+		CommitDuration: time.Duration(n.rt.duration.Load()),
+		IndexLatency:   time.Duration(n.rt.latency.Load()),
+	}, true
+}
@@ -0,0 +1,357 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"testing"
+	"time"
+
+	core "github.com/vdaas/vald/internal/core/algorithm/ngt"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/pkg/agent/internal/kvs"
+	"github.com/vdaas/vald/pkg/agent/internal/vqueue"
+)
+
+func Test_parseRealtimeMode(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		want realtimeMode
+		err  error
+	}
+	type test struct {
+		name string
+		mode string
+		want want
+	}
+	tests := []test{
+		{
+			name: "return disabled when the mode is empty",
+			want: want{
+				want: realtimeDisabled,
+			},
+		},
+		{
+			name: "return disabled when the mode is disabled",
+			mode: "disabled",
+			want: want{
+				want: realtimeDisabled,
+			},
+		},
+		{
+			name: "return sync when the mode is sync",
+			mode: "Sync",
+			want: want{
+				want: realtimeSync,
+			},
+		},
+		{
+			name: "return batch when the mode is batch",
+			mode: "batch",
+			want: want{
+				want: realtimeBatch,
+			},
+		},
+		{
+			name: "return an error when the mode is unsupported",
+			mode: "async",
+			want: want{
+				want: realtimeDisabled,
+				err:  errors.ErrUnsupportedRealtimeIndexMode("async"),
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			got, err := parseRealtimeMode(test.mode)
+			if !errors.Is(err, test.want.err) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want.err)
+			}
+			if got != test.want.want {
+				tt.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", got, test.want.want)
+			}
+		})
+	}
+}
+
+func Test_ngt_commitWrite(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		err error
+	}
+	type fields struct {
+		rt      *realtime
+		inserts int
+	}
+	type want struct {
+		err      error
+		signaled bool
+	}
+	type test struct {
+		name   string
+		args   args
+		fields fields
+		want   want
+	}
+	committed := func(err error) *realtime {
+		rt := newRealtime(realtimeSync, 0, 0)
+		rt.done, rt.err = 1, err
+		return rt
+	}
+	tests := []test{
+		{
+			name: "return the write error when the real-time indexing is disabled",
+			args: args{
+				err: errors.ErrFlushingIsInProgress,
+			},
+			want: want{
+				err: errors.ErrFlushingIsInProgress,
+			},
+		},
+		{
+			name: "signal the micro-batch commit when the queue reaches the batch size",
+			fields: fields{
+				rt:      newRealtime(realtimeBatch, 2, time.Second),
+				inserts: 2,
+			},
+			want: want{
+				signaled: true,
+			},
+		},
+		{
+			name: "do not signal the micro-batch commit when the queue is smaller than the batch size",
+			fields: fields{
+				rt:      newRealtime(realtimeBatch, 2, time.Second),
+				inserts: 1,
+			},
+		},
+		{
+			name: "return nil when the sync write is covered by a succeeded group commit",
+			fields: fields{
+				rt: committed(nil),
+			},
+		},
+		{
+			name: "return the error of the group commit which covers the sync write",
+			args: args{
+				err: errors.ErrUUIDNotFound(0),
+			},
+			fields: fields{
+				rt: committed(errors.ErrFlushingIsInProgress),
+			},
+			want: want{
+				err: errors.Join(errors.ErrUUIDNotFound(0), errors.ErrFlushingIsInProgress),
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			vq, err := vqueue.New()
+			if err != nil {
+				tt.Fatal(err)
+			}
+			for i := range test.fields.inserts {
+				if err := vq.PushInsert(string(rune('a'+i)), []float32{0}, time.Now().UnixNano()); err != nil {
+					tt.Fatal(err)
+				}
+			}
+			n := &ngt{
+				vq: vq,
+				rt: test.fields.rt,
+			}
+			err = n.commitWrite(tt.Context(), test.args.err)
+			if (err == nil) != (test.want.err == nil) || (err != nil && err.Error() != test.want.err.Error()) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want.err)
+			}
+			if n.rt == nil {
+				return
+			}
+			var signaled bool
+			select {
+			case <-n.rt.sig:
+				signaled = true
+			default:
+			}
+			if signaled != test.want.signaled {
+				tt.Errorf("got_signaled: %v,\n\t\t\t\twant: %v", signaled, test.want.signaled)
+			}
+		})
+	}
+}
+
+// commitCore is the core.NGT which records the inserted objects and the graph builds.
+type commitCore struct {
+	core.NGT
+	fail   float32 // value of the vectors which fail to be inserted
+	err    error   // error of the graph builds
+	oid    uint
+	builds int
+}
+
+func (c *commitCore) Insert(vec []float32) (uint, error) {
+	if c.fail != 0 && vec[0] == c.fail {
+		return 0, errors.ErrUUIDNotFound(0)
+	}
+	c.oid++
+	return c.oid, nil
+}
+
+func (c *commitCore) CreateIndex(uint32) error {
+	c.builds++
+	return c.err
+}
+
+func Test_ngt_commitInserts(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		vecs [][]float32
+		fail float32
+	}
+	type want struct {
+		indexed int
+		queued  int
+		err     bool
+	}
+	type test struct {
+		name   string
+		fields fields
+		want   want
+	}
+	tests := []test{
+		{
+			name: "insert the queued vectors",
+			fields: fields{
+				vecs: [][]float32{{1}, {2}, {3}},
+			},
+			want: want{
+				indexed: 3,
+			},
+		},
+		{
+			name: "requeue only the insert which failed",
+			fields: fields{
+				vecs: [][]float32{{1}, {2}, {3}},
+				fail: 2,
+			},
+			want: want{
+				indexed: 2,
+				queued:  1,
+				err:     true,
+			},
+		},
+		{
+			name: "return an error for the insert whose dimension does not match",
+			fields: fields{
+				vecs: [][]float32{{1}, {2, 2}, {3}},
+			},
+			want: want{
+				indexed: 2,
+				err:     true,
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			vq, err := vqueue.New()
+			if err != nil {
+				tt.Fatal(err)
+			}
+			c := &commitCore{
+				fail: test.fields.fail,
+			}
+			n := &ngt{
+				core: c,
+				kvs:  kvs.New(),
+				vq:   vq,
+				rt:   newRealtime(realtimeSync, 0, 0),
+				fmap: make(map[string]int64),
+				dim:  1,
+			}
+			now := time.Now().UnixNano()
+			var shards [realtimeLockShards][]string
+			for i, vec := range test.fields.vecs {
+				uuid := string(rune('a' + i))
+				if err := vq.PushInsert(uuid, vec, now); err != nil {
+					tt.Fatal(err)
+				}
+				shards[realtimeShard(uuid)] = append(shards[realtimeShard(uuid)], uuid)
+			}
+			tss, err := n.commitInserts(now, &shards)
+			if (err != nil) != test.want.err {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant error: %v", err, test.want.err)
+			}
+			if got := len(tss); got != test.want.indexed {
+				tt.Errorf("got_indexed: %d,\n\t\t\t\twant: %d", got, test.want.indexed)
+			}
+			if got := int(n.kvs.Len()); got != test.want.indexed {
+				tt.Errorf("got_kvs_len: %d,\n\t\t\t\twant: %d", got, test.want.indexed)
+			}
+			if got := vq.IVQLen(); got != test.want.queued {
+				tt.Errorf("got_queued: %d,\n\t\t\t\twant: %d", got, test.want.queued)
+			}
+			if c.builds != 0 {
+				tt.Errorf("got_builds: %d,\n\t\t\t\twant: 0", c.builds)
+			}
+		})
+	}
+}
+
+func Test_ngt_commit(t *testing.T) {
+	t.Parallel()
+	vq, err := vqueue.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &commitCore{
+		err: errors.ErrUUIDNotFound(0),
+	}
+	n := &ngt{
+		core: c,
+		kvs:  kvs.New(),
+		vq:   vq,
+		rt:   newRealtime(realtimeSync, 0, 0),
+		fmap: make(map[string]int64),
+		dim:  1,
+	}
+	for i := range 2 {
+		if err := vq.PushInsert(string(rune('a'+i)), []float32{float32(i)}, time.Now().UnixNano()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := n.commit(t.Context()); err != nil {
+		t.Errorf("got_error: \"%#v\" of the stored inserts whose graph build failed, want: nil", err)
+	}
+	if got := n.kvs.Len(); got != 2 {
+		t.Errorf("got_kvs_len: %d,\n\t\t\t\twant: 2", got)
+	}
+	if !n.rt.unbuilt || n.nocie != 0 {
+		t.Errorf("got_unbuilt: %v, got_nocie: %d,\n\t\t\t\twant: true, 0", n.rt.unbuilt, n.nocie)
+	}
+	c.err = nil
+	if err := n.commit(t.Context()); err != nil {
+		t.Errorf("got_error: \"%#v\",\n\t\t\t\twant: nil", err)
+	}
+	if c.builds != 2 || n.rt.unbuilt || n.nocie != 1 {
+		t.Errorf("got_builds: %d, got_unbuilt: %v, got_nocie: %d,\n\t\t\t\twant: 2, false, 1", c.builds, n.rt.unbuilt, n.nocie)
+	}
+}
@@ -0,0 +1,134 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/pkg/agent/internal/replica"
+)
+
+// resolveReplicaGeneration replaces the index path by the directory of the index generation pulled by the agent sidecar.
+// The read replica starts with the empty in-memory index, which is not served until the first generation is loaded.
+func (n *ngt) resolveReplicaGeneration() {
+	if len(n.path) == 0 {
+		return
+	}
+	n.replicaBase = n.path
+	// the read replica never saves the index, and the generations are loaded as they are backed up with or without CoW.
+	n.enableCopyOnWrite = false
+	if n.replicaGen == "" {
+		var err error
+		n.replicaGen, err = replica.Current(n.replicaBase)
+		if err != nil {
+			log.Warnf("failed to read the current index generation in %s: %v", n.replicaBase, err)
+		}
+	}
+	if n.replicaGen == "" {
+		log.Infof("no index generation is pulled to %s yet, the read replica is unavailable until it is loaded", n.replicaBase)
+		n.path = ""
+		return
+	}
+	n.path = replica.Dir(n.replicaBase, n.replicaGen)
+	log.Infof("read replica loads index generation %s", n.replicaGen)
+}
+
+// startReplicaReload starts the loop to reload the index generation when the agent sidecar points the CURRENT file to a new one.
+func (n *ngt) startReplicaReload(ctx context.Context) {
+	n.eg.Go(safety.RecoverFunc(func() error {
+		tick := time.NewTicker(n.replicaDur)
+		defer tick.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-tick.C:
+			}
+			id, err := replica.Current(n.replicaBase)
+			if err != nil {
+				log.Errorf("failed to read the current index generation in %s: %v", n.replicaBase, err)
+				continue
+			}
+			if id == "" || id == n.replicaGen {
+				continue
+			}
+			if err = n.reloadReplica(ctx, id); err != nil {
+				log.Errorf("failed to reload index generation %s: %v", id, err)
+			}
+		}
+	}))
+}
+
+// reloadReplica loads the index generation id to the new instance, and then swaps it with the current one.
+// The searches are served by the current instance while the new one is loading.
+// The swap waits for the in-flight searches of the current instance, so the old instances are closed only after nothing reads them.
+func (n *ngt) reloadReplica(ctx context.Context, id string) (err error) {
+	nn, err := newNGT(n.cfg, append(n.opts, withReplicaGeneration(id))...)
+	if err != nil {
+		return err
+	}
+
+	n.rcmu.Lock()
+	n.cimu.Lock()
+	oc, okvs, oraw := n.core, n.kvs, n.raw
+	n.copyNGT(nn)
+	n.inMem = nn.inMem
+	n.cimu.Unlock()
+	n.rcmu.Unlock()
+
+	oc.Close()
+	err = okvs.Close()
+	if oraw != nil {
+		err = errors.Join(err, oraw.Close())
+	}
+	if err != nil {
+		log.Warnf("failed to close the previous index generation: %v", err)
+	}
+	runtime.GC()
+	atomic.AddUint64(&n.nogce, 1)
+
+	log.Infof("read replica switched to index generation %s, %d objects", id, n.kvs.Len())
+	return n.loadStatistics(ctx)
+}
+
+// IsReplicaPending returns true while the read replica has not loaded any index generation pulled from the blob storage.
+// The index is empty until then, so it must not serve requests.
+func (n *ngt) IsReplicaPending() bool {
+	if len(n.replicaBase) == 0 {
+		return false
+	}
+	n.rcmu.RLock()
+	defer n.rcmu.RUnlock()
+	return n.replicaGen == ""
+}
+
+// rlockReplica locks the index generation of the read replica for reading, and returns the function to unlock it.
+// It does nothing unless the agent reloads the index generations pulled from the blob storage.
+func (n *ngt) rlockReplica() (runlock func()) {
+	if len(n.replicaBase) == 0 {
+		return func() {}
+	}
+	n.rcmu.RLock()
+	return n.rcmu.RUnlock
+}
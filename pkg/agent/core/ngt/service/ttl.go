@@ -0,0 +1,186 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"context"
+	"encoding/gob"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+const kvsTTLFileName = "ngt-ttl.kvsdb"
+
+// setTTL stores the ttl of uuid. The zero ttl removes the stored one and the object expires by the default ttl.
+func (n *ngt) setTTL(uuid string, ttl time.Duration) {
+	if ttl == 0 {
+		n.ttls.Delete(uuid)
+		return
+	}
+	n.ttls.Store(uuid, int64(ttl))
+}
+
+// ttlOf returns the ttl of uuid, which is not positive if the object never expires.
+func (n *ngt) ttlOf(uuid string) time.Duration {
+	if ttl, ok := n.ttls.Load(uuid); ok {
+		return time.Duration(ttl)
+	}
+	return n.defaultTTL
+}
+
+// expired reports whether the object indexed at ts with ttl has expired at now.
+// The objects without the timestamp never expire, since their age is unknown.
+func expired(ts, now int64, ttl time.Duration) bool {
+	return ttl > 0 && ts > 0 && now-ts >= int64(ttl)
+}
+
+// startTTLSweep starts the loop which deletes the expired objects every sweep duration.
+func (n *ngt) startTTLSweep(ctx context.Context) {
+	n.eg.Go(safety.RecoverFunc(func() error {
+		tick := time.NewTicker(n.ttlSweep)
+		defer tick.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-tick.C:
+			}
+			if err := n.sweepExpired(ctx); err != nil {
+				log.Errorf("failed to delete the expired objects: %v", err)
+			}
+		}
+	}))
+}
+
+// sweepExpired queues the deletes of the expired objects in the kvsdb.
+// The deletes are indexed by the next create index as well as the user deletes.
+func (n *ngt) sweepExpired(ctx context.Context) (err error) {
+	if n.IsFlushing() || n.defaultTTL <= 0 && n.ttls.Len() == 0 {
+		return nil
+	}
+	now := time.Now().UnixNano()
+	var (
+		mu    sync.Mutex
+		uuids []string
+	)
+	n.kvs.Range(ctx, func(uuid string, _ uint32, ts int64) bool {
+		if expired(ts, now, n.ttlOf(uuid)) {
+			mu.Lock()
+			uuids = append(uuids, uuid)
+			mu.Unlock()
+		}
+		return true
+	})
+	var cnt uint64
+	for _, uuid := range uuids {
+		if _, ok := n.vq.DVExists(uuid); ok {
+			// already deleted or updated by the user
+			continue
+		}
+		derr := n.delete(uuid, now, false)
+		if derr != nil {
+			err = errors.Join(err, derr)
+			continue
+		}
+		cnt++
+	}
+	if cnt > 0 {
+		n.noe.Add(cnt)
+		log.Infof("%d expired objects are deleted", cnt)
+		err = n.commitWrite(ctx, err)
+	}
+	return err
+}
+
+// NumberOfExpiredObjects returns the number of the objects deleted by the ttl expiry.
+func (n *ngt) NumberOfExpiredObjects() uint64 {
+	return n.noe.Load()
+}
+
+// saveTTL saves the per-object ttls to the ttl kvsdb file in path.
+func (n *ngt) saveTTL(path string) (err error) {
+	mt := make(map[string]int64, n.ttls.Len())
+	n.ttls.Range(func(uuid string, ttl int64) bool {
+		mt[uuid] = ttl
+		return true
+	})
+	f, err := file.Open(
+		file.Join(path, kvsTTLFileName),
+		os.O_WRONLY|os.O_CREATE|os.O_TRUNC,
+		fs.ModePerm,
+	)
+	if err != nil {
+		log.Warnf("failed to create or open ttl kvsdb file, err: %v", err)
+		return err
+	}
+	defer func() {
+		derr := f.Close()
+		if derr != nil {
+			err = errors.Join(err, derr)
+		}
+	}()
+	gob.Register(map[string]int64{})
+	err = gob.NewEncoder(f).Encode(&mt)
+	if err != nil {
+		log.Warnf("failed to encode ttl kvsdb data, err: %v", err)
+		return err
+	}
+	err = f.Sync()
+	if err != nil {
+		log.Warnf("failed to flush all ttl kvsdb data to storage, err: %v", err)
+		return err
+	}
+	return nil
+}
+
+// loadTTL loads the per-object ttls from the ttl kvsdb file in path. The index saved without the ttl kvsdb file has no per-object ttl.
+func (n *ngt) loadTTL(path string) (err error) {
+	fp := file.Join(path, kvsTTLFileName)
+	if !file.Exists(fp) {
+		log.Debugf("ttl kvsdb file does not exists,\tpath: %s", fp)
+		return nil
+	}
+	f, err := file.Open(fp, os.O_RDONLY|os.O_SYNC, fs.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		derr := f.Close()
+		if derr != nil {
+			err = errors.Join(err, derr)
+		}
+	}()
+	mt := make(map[string]int64)
+	gob.Register(map[string]int64{})
+	err = gob.NewDecoder(f).Decode(&mt)
+	if err != nil {
+		log.Errorf("error decoding ttl kvsdb file,\terr: %v", err)
+		return err
+	}
+	for uuid, ttl := range mt {
+		n.ttls.Store(uuid, ttl)
+	}
+	return nil
+}
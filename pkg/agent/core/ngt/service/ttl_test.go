@@ -0,0 +1,228 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/internal/sync"
+)
+
+func Test_expired(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		ts  int64
+		now int64
+		ttl time.Duration
+	}
+	type test struct {
+		name string
+		args args
+		want bool
+	}
+	now := time.Now().UnixNano()
+	tests := []test{
+		{
+			name: "return true when the ttl has elapsed since the timestamp",
+			args: args{
+				ts:  now - int64(time.Minute),
+				now: now,
+				ttl: time.Minute,
+			},
+			want: true,
+		},
+		{
+			name: "return false when the ttl has not elapsed since the timestamp",
+			args: args{
+				ts:  now - int64(time.Second),
+				now: now,
+				ttl: time.Minute,
+			},
+			want: false,
+		},
+		{
+			name: "return false when the ttl is negative",
+			args: args{
+				ts:  now - int64(time.Hour),
+				now: now,
+				ttl: -time.Second,
+			},
+			want: false,
+		},
+		{
+			name: "return false when the ttl is zero",
+			args: args{
+				ts:  now - int64(time.Hour),
+				now: now,
+			},
+			want: false,
+		},
+		{
+			name: "return false when the timestamp is unknown",
+			args: args{
+				now: now,
+				ttl: time.Second,
+			},
+			want: false,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			if got := expired(test.args.ts, test.args.now, test.args.ttl); got != test.want {
+				tt.Errorf("got: %v,\n\t\t\t\twant: %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_ngt_setTTL(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		ttls []time.Duration
+	}
+	type fields struct {
+		defaultTTL time.Duration
+	}
+	type test struct {
+		name   string
+		args   args
+		fields fields
+		want   time.Duration
+	}
+	tests := []test{
+		{
+			name: "return the default ttl when the ttl is not set",
+			fields: fields{
+				defaultTTL: time.Hour,
+			},
+			want: time.Hour,
+		},
+		{
+			name: "return the set ttl instead of the default ttl",
+			args: args{
+				ttls: []time.Duration{time.Minute},
+			},
+			fields: fields{
+				defaultTTL: time.Hour,
+			},
+			want: time.Minute,
+		},
+		{
+			name: "return the negative ttl which disables the default ttl",
+			args: args{
+				ttls: []time.Duration{-1},
+			},
+			fields: fields{
+				defaultTTL: time.Hour,
+			},
+			want: -1,
+		},
+		{
+			name: "return the default ttl when the ttl is reset by zero",
+			args: args{
+				ttls: []time.Duration{time.Minute, 0},
+			},
+			fields: fields{
+				defaultTTL: time.Hour,
+			},
+			want: time.Hour,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			n := &ngt{
+				ttls:       new(sync.Map[string, int64]),
+				defaultTTL: test.fields.defaultTTL,
+			}
+			for _, ttl := range test.args.ttls {
+				n.setTTL("uuid", ttl)
+			}
+			if got := n.ttlOf("uuid"); got != test.want {
+				tt.Errorf("got: %v,\n\t\t\t\twant: %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_ngt_saveTTL(t *testing.T) {
+	t.Parallel()
+	type test struct {
+		name string
+		ttls map[string]time.Duration
+	}
+	tests := []test{
+		{
+			name: "load the saved ttls",
+			ttls: map[string]time.Duration{
+				"a": time.Minute,
+				"b": -1,
+			},
+		},
+		{
+			name: "load the saved empty ttls",
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			path := tt.TempDir()
+			src := &ngt{
+				ttls: new(sync.Map[string, int64]),
+			}
+			for uuid, ttl := range test.ttls {
+				src.setTTL(uuid, ttl)
+			}
+			if err := src.saveTTL(path); err != nil {
+				tt.Fatal(err)
+			}
+			dst := &ngt{
+				ttls: new(sync.Map[string, int64]),
+			}
+			if err := dst.loadTTL(path); err != nil {
+				tt.Fatal(err)
+			}
+			if got, want := dst.ttls.Len(), len(test.ttls); got != want {
+				tt.Errorf("got_len: %d,\n\t\t\t\twant: %d", got, want)
+			}
+			for uuid, ttl := range test.ttls {
+				if got := dst.ttlOf(uuid); got != ttl {
+					tt.Errorf("got: %v,\n\t\t\t\twant: %v", got, ttl)
+				}
+			}
+		})
+	}
+}
+
+func Test_ngt_loadTTL(t *testing.T) {
+	t.Parallel()
+	n := &ngt{
+		ttls: new(sync.Map[string, int64]),
+	}
+	if err := n.loadTTL(t.TempDir()); err != nil {
+		t.Errorf("got_error: \"%#v\",\n\t\t\t\twant: nil", err)
+	}
+	if got := n.ttls.Len(); got != 0 {
+		t.Errorf("got_len: %d,\n\t\t\t\twant: 0", got)
+	}
+}
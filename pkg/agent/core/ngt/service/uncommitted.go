@@ -0,0 +1,93 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+)
+
+type contextUncommittedSearchKey struct{}
+
+// WithUncommittedSearch returns the context with which Search and LinearSearch also scan the uncommitted vectors in the insert queue.
+func WithUncommittedSearch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextUncommittedSearchKey{}, true)
+}
+
+func isUncommittedSearch(ctx context.Context) bool {
+	enabled, ok := ctx.Value(contextUncommittedSearchKey{}).(bool)
+	return ok && enabled
+}
+
+// mergeUncommitted merges the graph search result with the nearest vectors of the insert queue when the context requests it.
+// The graph results pending in the delete queue or replaced by the insert queue are excluded,
+// and at most the uncommitted search limit of the queued vectors are scanned.
+func (n *ngt) mergeUncommitted(
+	ctx context.Context, vec []float32, size uint32, radius float32, res *payload.Search_Response, err error,
+) (*payload.Search_Response, error) {
+	if !isUncommittedSearch(ctx) || n.qdist == nil || (err != nil && !errors.Is(err, errors.ErrEmptySearchResult)) {
+		return res, err
+	}
+	results := make([]*payload.Object_Distance, 0, len(res.GetResults())+int(size))
+	for _, r := range res.GetResults() {
+		if _, ok := n.vq.DVExists(r.GetId()); ok {
+			continue
+		}
+		if _, ok := n.vq.IVExists(r.GetId()); ok {
+			continue
+		}
+		results = append(results, r)
+	}
+	var scanned int
+	n.vq.Range(ctx, func(uuid string, v []float32, _ int64) bool {
+		if scanned >= n.uncommittedLimit {
+			return false
+		}
+		scanned++
+		if len(v) != len(vec) {
+			return true
+		}
+		d := n.qdist(vec, v)
+		if radius >= 0 && d > radius {
+			return true
+		}
+		results = append(results, &payload.Object_Distance{
+			Id:       uuid,
+			Distance: d,
+		})
+		return true
+	})
+	if len(results) == 0 {
+		return res, err
+	}
+	slices.SortStableFunc(results, func(a, b *payload.Object_Distance) int {
+		return cmp.Compare(a.GetDistance(), b.GetDistance())
+	})
+	if len(results) > int(size) {
+		results = results[:size]
+	}
+	if res == nil {
+		res = new(payload.Search_Response)
+	}
+	res.Results = results
+	return res, nil
+}
@@ -0,0 +1,190 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/core/algorithm/quantization"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/test/comparator"
+	"github.com/vdaas/vald/pkg/agent/internal/vqueue"
+)
+
+func Test_ngt_mergeUncommitted(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		ctx    context.Context
+		vec    []float32
+		size   uint32
+		radius float32
+		res    *payload.Search_Response
+		err    error
+	}
+	type fields struct {
+		limit   int
+		inserts map[string][]float32
+		deletes []string
+	}
+	type want struct {
+		want *payload.Search_Response
+		err  error
+	}
+	type test struct {
+		name   string
+		args   args
+		fields fields
+		want   want
+	}
+	committed := &payload.Search_Response{
+		Results: []*payload.Object_Distance{
+			{Id: "c1", Distance: 1},
+			{Id: "c2", Distance: 2},
+			{Id: "c3", Distance: 3},
+		},
+	}
+	tests := []test{
+		{
+			name: "return the graph result when the uncommitted search is not requested",
+			args: args{
+				ctx:    context.Background(),
+				vec:    []float32{0, 0},
+				size:   3,
+				radius: -1,
+				res:    committed,
+			},
+			fields: fields{
+				limit: 10,
+				inserts: map[string][]float32{
+					"u1": {0, 0},
+				},
+			},
+			want: want{
+				want: committed,
+			},
+		},
+		{
+			name: "merge the queued vectors and exclude the deleted and updated graph results",
+			args: args{
+				ctx:    WithUncommittedSearch(context.Background()),
+				vec:    []float32{0, 0},
+				size:   3,
+				radius: -1,
+				res:    committed,
+			},
+			fields: fields{
+				limit: 10,
+				inserts: map[string][]float32{
+					"u1": {0, 0.5},
+					"c3": {0, 2.5},
+				},
+				deletes: []string{"c2"},
+			},
+			want: want{
+				want: &payload.Search_Response{
+					Results: []*payload.Object_Distance{
+						{Id: "u1", Distance: 0.5},
+						{Id: "c1", Distance: 1},
+						{Id: "c3", Distance: 2.5},
+					},
+				},
+			},
+		},
+		{
+			name: "return the queued vectors when the graph result is empty",
+			args: args{
+				ctx:    WithUncommittedSearch(context.Background()),
+				vec:    []float32{0, 0},
+				size:   3,
+				radius: 1,
+				err:    errors.ErrEmptySearchResult,
+			},
+			fields: fields{
+				limit: 10,
+				inserts: map[string][]float32{
+					"u1": {0, 0.5},
+					"u2": {0, 1.5},
+				},
+			},
+			want: want{
+				want: &payload.Search_Response{
+					Results: []*payload.Object_Distance{
+						{Id: "u1", Distance: 0.5},
+					},
+				},
+			},
+		},
+		{
+			name: "return the error of the graph search",
+			args: args{
+				ctx:  WithUncommittedSearch(context.Background()),
+				vec:  []float32{0, 0},
+				size: 3,
+				err:  errors.ErrCreateIndexingIsInProgress,
+			},
+			fields: fields{
+				limit: 10,
+				inserts: map[string][]float32{
+					"u1": {0, 0.5},
+				},
+			},
+			want: want{
+				err: errors.ErrCreateIndexingIsInProgress,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			vq, err := vqueue.New()
+			if err != nil {
+				tt.Fatal(err)
+			}
+			for uuid, vec := range test.fields.inserts {
+				if err := vq.PushInsert(uuid, vec, 2); err != nil {
+					tt.Fatal(err)
+				}
+			}
+			for _, uuid := range test.fields.deletes {
+				if err := vq.PushDelete(uuid, 1); err != nil {
+					tt.Fatal(err)
+				}
+			}
+			dist, err := quantization.NewDistance("l2")
+			if err != nil {
+				tt.Fatal(err)
+			}
+			n := &ngt{
+				vq:               vq,
+				qdist:            dist,
+				uncommittedLimit: test.fields.limit,
+			}
+			got, err := n.mergeUncommitted(test.args.ctx, test.args.vec, test.args.size, test.args.radius, test.args.res.CloneVT(), test.args.err)
+			if !errors.Is(err, test.want.err) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want.err)
+			}
+			if diff := comparator.Diff(test.want.want, got, comparator.IgnoreUnexported(payload.Search_Response{}, payload.Object_Distance{})); diff != "" {
+				tt.Errorf("diff = %s", diff)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	agent "github.com/vdaas/vald/apis/grpc/v1/agent/core"
 	vald "github.com/vdaas/vald/apis/grpc/v1/vald"
 	iconf "github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/k8s/client"
 	"github.com/vdaas/vald/internal/net/grpc"
 	"github.com/vdaas/vald/internal/observability"
@@ -44,6 +45,7 @@ type run struct {
 	eg            errgroup.Group
 	cfg           *config.Data
 	ngt           service.NGT
+	collections   service.Collections
 	server        starter.Server
 	observability observability.Observability
 }
@@ -71,7 +73,19 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		service.WithProactiveGC(cfg.NGT.EnableProactiveGC),
 		service.WithCopyOnWrite(cfg.NGT.EnableCopyOnWrite),
 		service.WithIsReadReplica(cfg.NGT.IsReadReplica),
+		service.WithReadReplicaSource(cfg.NGT.ReadReplicaSource),
+		service.WithReadReplicaReloadDuration(cfg.NGT.ReadReplicaReloadDuration),
 		service.WithEnableStatistics(cfg.NGT.EnableStatistics),
+		service.WithUncommittedSearchLimit(cfg.NGT.UncommittedSearchLimit),
+		service.WithDefaultTTL(cfg.NGT.DefaultTTL),
+		service.WithTTLSweepDuration(cfg.NGT.TTLSweepDuration),
+	}
+	if cfg.NGT.RealtimeIndex != nil {
+		serviceOpts = append(serviceOpts,
+			service.WithRealtimeIndexMode(cfg.NGT.RealtimeIndex.Mode),
+			service.WithRealtimeIndexBatchSize(cfg.NGT.RealtimeIndex.BatchSize),
+			service.WithRealtimeIndexBatchInterval(cfg.NGT.RealtimeIndex.BatchInterval),
+		)
 	}
 	if cfg.NGT.EnableExportIndexInfoToK8s {
 		patcher, err := client.NewPatcher(fieldManager)
@@ -91,8 +105,14 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		return nil, err
 	}
 
+	collections, err := service.NewCollections(cfg.NGT, serviceOpts...)
+	if err != nil {
+		return nil, err
+	}
+
 	g, err := handler.New(
 		handler.WithNGT(ngt),
+		handler.WithCollections(collections),
 		handler.WithStreamConcurrency(cfg.Server.GetGRPCStreamConcurrency()),
 	)
 	if err != nil {
@@ -104,7 +124,12 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		server.WithGRPCRegistFunc(func(srv *grpc.Server) {
 			agent.RegisterAgentServer(srv, g)
 			vald.RegisterValdServer(srv, g)
+			vald.RegisterCollectionServer(srv, g)
 		}),
+		server.WithGRPCOption(
+			grpc.ChainUnaryInterceptor(g.ReplicaInterceptor(), g.CollectionInterceptor()),
+			grpc.ChainStreamInterceptor(g.ReplicaStreamInterceptor(), g.CollectionStreamInterceptor()),
+		),
 		server.WithPreStartFunc(func() error {
 			return nil
 		}),
@@ -155,6 +180,7 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 	return &run{
 		eg:            eg,
 		ngt:           ngt,
+		collections:   collections,
 		cfg:           cfg,
 		server:        srv,
 		observability: obs,
@@ -169,14 +195,15 @@ func (r *run) PreStart(ctx context.Context) error {
 }
 
 func (r *run) Start(ctx context.Context) (<-chan error, error) {
-	ech := make(chan error, 3)
-	var oech, nech, sech <-chan error
+	ech := make(chan error, 4)
+	var oech, nech, cech, sech <-chan error
 	r.eg.Go(safety.RecoverFunc(func() (err error) {
 		defer close(ech)
 		if r.observability != nil {
 			oech = r.observability.Start(ctx)
 		}
 		nech = r.ngt.Start(ctx)
+		cech = r.collections.Start(ctx)
 		sech = r.server.ListenAndServe(ctx)
 		for {
 			select {
@@ -184,6 +211,7 @@ func (r *run) Start(ctx context.Context) (<-chan error, error) {
 				return ctx.Err()
 			case err = <-oech:
 			case err = <-nech:
+			case err = <-cech:
 			case err = <-sech:
 			}
 			if err != nil {
@@ -210,6 +238,37 @@ func (r *run) Stop(ctx context.Context) error {
 }
 
 func (r *run) PostStop(ctx context.Context) error {
+	r.collections.Close(ctx)
 	r.ngt.Close(ctx)
 	return nil
 }
+
+// Reloadable returns the configuration fields which Reload applies without restart.
+func (*run) Reloadable() []string {
+	return []string{
+		"ngt.auto_index_check_duration",
+		"ngt.auto_index_duration_limit",
+		"ngt.auto_save_index_duration",
+		"ngt.auto_index_length",
+	}
+}
+
+// Reload applies the auto indexing parameters to the default index and every collection.
+// The parameters are validated for all the indexes before any of them is updated, so that a rejected
+// configuration is never applied partially.
+func (r *run) Reload(_ context.Context, cfg any) error {
+	c, ok := cfg.(*config.Data)
+	if !ok || c.NGT == nil {
+		return errors.ErrInvalidConfig
+	}
+	if err := r.ngt.ValidateAutoIndexing(c.NGT); err != nil {
+		return err
+	}
+	if err := r.collections.ValidateAutoIndexing(c.NGT); err != nil {
+		return err
+	}
+	if err := r.ngt.UpdateAutoIndexing(c.NGT); err != nil {
+		return err
+	}
+	return r.collections.UpdateAutoIndexing(c.NGT)
+}
@@ -0,0 +1,336 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package collection provides the registry of the named indexes hosted by an agent next to its default index.
+// The registry persists the collection definitions and guards every index against being closed while it is in use.
+package collection
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"slices"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+)
+
+type (
+	// Index represents the index of a collection.
+	Index interface {
+		Start(ctx context.Context) <-chan error
+		Close(ctx context.Context) error
+	}
+
+	// NewFunc returns the index of the collection defined by def.
+	// path is the directory of the index, it is empty when the index is kept in memory.
+	NewFunc[I Index] func(def *payload.Collection_Config, path string) (I, error)
+
+	// Collections manages the named indexes hosted next to the default index.
+	// Every collection owns its own index, which is started and closed with the collection.
+	Collections[I Index] interface {
+		// Start starts every collection, including the ones created later.
+		Start(ctx context.Context) <-chan error
+		// Acquire returns the index of the named collection and the function to release it.
+		// The collection is not dropped or closed until the index is released, so release must always be called.
+		Acquire(name string) (idx I, release func(), ok bool)
+		// Range calls fn with the index of every collection until fn returns an error.
+		Range(fn func(name string, idx I) error) error
+		// Create creates the collection and persists its definition.
+		Create(ctx context.Context, cfg *payload.Collection_Config) error
+		// Drop stops the collection, removes its definition and deletes its index files.
+		Drop(ctx context.Context, name string) error
+		// List returns the definitions of all collections sorted by name.
+		List() []*payload.Collection_Config
+		// Close closes every collection index.
+		Close(ctx context.Context) error
+	}
+
+	collections[I Index] struct {
+		newIndex NewFunc[I]
+		path     string // directory holding the collection indexes, empty in in-memory mode
+		eg       errgroup.Group
+		mu       sync.RWMutex
+		cols     map[string]*collection[I]
+		ctx      context.Context
+		ech      chan error
+		ready    bool
+	}
+
+	collection[I Index] struct {
+		cfg *payload.Collection_Config
+		idx I
+		// mu is held for reading while the index is acquired and for writing while it is closed.
+		mu     sync.RWMutex
+		cancel context.CancelFunc
+	}
+)
+
+const (
+	// DirName is the directory name to store the collection indexes under the index path of the agent.
+	DirName = "collections"
+
+	defsFileName = "collections.json"
+)
+
+// Dir returns the directory of the collection indexes of an agent storing its default index in indexPath.
+// It returns an empty path when the indexes are kept in memory.
+func Dir(indexPath string, inMemory bool) string {
+	if inMemory || indexPath == "" {
+		return ""
+	}
+	return file.Join(strings.TrimSuffix(indexPath, string(os.PathSeparator)), DirName)
+}
+
+// New returns the collection manager storing the collection indexes under path, or in memory when path is empty.
+// The collections persisted under path are loaded by fn.
+func New[I Index](path string, fn NewFunc[I]) (Collections[I], error) {
+	c := &collections[I]{
+		newIndex: fn,
+		path:     path,
+		eg:       errgroup.Get(),
+		cols:     make(map[string]*collection[I]),
+		ech:      make(chan error, 10),
+	}
+	if c.path == "" || !file.Exists(file.Join(c.path, defsFileName)) {
+		return c, nil
+	}
+	b, err := file.ReadFile(file.Join(c.path, defsFileName))
+	if err != nil {
+		return nil, err
+	}
+	var defs []*payload.Collection_Config
+	if err = json.Unmarshal(b, &defs); err != nil {
+		return nil, err
+	}
+	for _, def := range defs {
+		idx, err := c.newCollection(def)
+		if err != nil {
+			return nil, err
+		}
+		c.cols[def.GetName()] = &collection[I]{cfg: def, idx: idx}
+	}
+	return c, nil
+}
+
+func (c *collections[I]) newCollection(def *payload.Collection_Config) (I, error) {
+	if c.path == "" {
+		return c.newIndex(def, "")
+	}
+	return c.newIndex(def, file.Join(c.path, def.GetName()))
+}
+
+// Start starts every collection, including the ones created later.
+func (c *collections[I]) Start(ctx context.Context) <-chan error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ctx = ctx
+	c.ready = true
+	for _, col := range c.cols {
+		c.start(col)
+	}
+	return c.ech
+}
+
+// start must be called with the lock held.
+func (c *collections[I]) start(col *collection[I]) {
+	var ctx context.Context
+	ctx, col.cancel = context.WithCancel(c.ctx)
+	ech := col.idx.Start(ctx)
+	if ech == nil {
+		return
+	}
+	name := col.cfg.GetName()
+	c.eg.Go(safety.RecoverFunc(func() error {
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case err, ok := <-ech:
+				if !ok {
+					return nil
+				}
+				if err == nil {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return nil
+				case c.ech <- errors.Wrapf(err, "collection %s", name):
+				}
+			}
+		}
+	}))
+}
+
+// Acquire returns the index of the named collection and the function to release it.
+func (c *collections[I]) Acquire(name string) (idx I, release func(), ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	col, ok := c.cols[name]
+	if !ok {
+		return idx, nil, false
+	}
+	// the collection is locked before the registry is unlocked, so Drop waits for it after removing the collection.
+	col.mu.RLock()
+	return col.idx, col.mu.RUnlock, true
+}
+
+// Range calls fn with the index of every collection until fn returns an error.
+func (c *collections[I]) Range(fn func(name string, idx I) error) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for name, col := range c.cols {
+		col.mu.RLock()
+		err := fn(name, col.idx)
+		col.mu.RUnlock()
+		if err != nil {
+			return errors.Wrapf(err, "collection %s", name)
+		}
+	}
+	return nil
+}
+
+// Create creates the collection and persists its definition.
+func (c *collections[I]) Create(ctx context.Context, cfg *payload.Collection_Config) error {
+	name := cfg.GetName()
+	if !isValidName(name) {
+		return errors.ErrInvalidCollectionName(name)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.cols[name]; ok {
+		return errors.ErrCollectionAlreadyExists(name)
+	}
+	def := cfg.CloneVT()
+	idx, err := c.newCollection(def)
+	if err != nil {
+		return err
+	}
+	col := &collection[I]{cfg: def, idx: idx}
+	c.cols[name] = col
+	if err = c.save(); err != nil {
+		delete(c.cols, name)
+		return errors.Join(err, idx.Close(ctx))
+	}
+	if c.ready {
+		c.start(col)
+	}
+	log.Infof("collection %s created: dimension=%d", name, def.GetDimension())
+	return nil
+}
+
+// Drop stops the collection, removes its definition and deletes its index files.
+// The index is closed after the in-flight requests using it have released it.
+func (c *collections[I]) Drop(ctx context.Context, name string) (err error) {
+	c.mu.Lock()
+	col, ok := c.cols[name]
+	if !ok {
+		c.mu.Unlock()
+		return errors.ErrCollectionNotFound(name)
+	}
+	delete(c.cols, name)
+	err = c.save()
+	c.mu.Unlock()
+	if cerr := col.close(ctx); cerr != nil {
+		err = errors.Join(err, cerr)
+	}
+	if c.path != "" {
+		if derr := file.DeleteDir(ctx, file.Join(c.path, name)); derr != nil {
+			err = errors.Join(err, derr)
+		}
+	}
+	log.Infof("collection %s dropped", name)
+	return err
+}
+
+// List returns the definitions of all collections sorted by name.
+func (c *collections[I]) List() []*payload.Collection_Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	defs := make([]*payload.Collection_Config, 0, len(c.cols))
+	for _, col := range c.cols {
+		defs = append(defs, col.cfg.CloneVT())
+	}
+	slices.SortFunc(defs, func(a, b *payload.Collection_Config) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return defs
+}
+
+// Close closes every collection index after it has been released, saving it when the index is persistent.
+func (c *collections[I]) Close(ctx context.Context) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, col := range c.cols {
+		if cerr := col.close(ctx); cerr != nil {
+			err = errors.Join(err, errors.Wrapf(cerr, "collection %s", name))
+		}
+	}
+	return err
+}
+
+// close stops the collection and closes its index once every acquired reference has been released.
+func (col *collection[I]) close(ctx context.Context) error {
+	if col.cancel != nil {
+		col.cancel()
+	}
+	col.mu.Lock()
+	defer col.mu.Unlock()
+	return col.idx.Close(ctx)
+}
+
+// save writes the collection definitions, it must be called with the lock held.
+func (c *collections[I]) save() error {
+	if c.path == "" {
+		return nil
+	}
+	defs := make([]*payload.Collection_Config, 0, len(c.cols))
+	for _, col := range c.cols {
+		defs = append(defs, col.cfg)
+	}
+	b, err := json.Marshal(defs)
+	if err != nil {
+		return err
+	}
+	if err = file.MkdirAll(c.path, fs.ModePerm); err != nil {
+		return err
+	}
+	_, err = file.OverWriteFile(context.Background(), file.Join(c.path, defsFileName), bytes.NewReader(b), fs.ModePerm)
+	return err
+}
+
+func isValidName(name string) bool {
+	if name == "" || len(name) > 63 {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
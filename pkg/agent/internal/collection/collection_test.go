@@ -0,0 +1,132 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package collection
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+)
+
+type testIndex struct {
+	path   string
+	closed atomic.Bool
+}
+
+func (*testIndex) Start(context.Context) <-chan error { return nil }
+
+func (i *testIndex) Close(context.Context) error {
+	i.closed.Store(true)
+	return nil
+}
+
+func newTestIndex(_ *payload.Collection_Config, path string) (*testIndex, error) {
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return nil, err
+	}
+	return &testIndex{path: path}, nil
+}
+
+func TestCollections_Drop(t *testing.T) {
+	ctx := context.Background()
+	c, err := New(t.TempDir(), newTestIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Create(ctx, &payload.Collection_Config{Name: "tenant", Dimension: 3}); err != nil {
+		t.Fatal(err)
+	}
+	idx, release, ok := c.Acquire("tenant")
+	if !ok {
+		t.Fatal("collection tenant is not found")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Drop(ctx, "tenant")
+	}()
+	// the dropped collection is not acquired anymore while the in-flight request still uses its index.
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		_, rel, ok := c.Acquire("tenant")
+		if !ok {
+			break
+		}
+		rel()
+		if time.Now().After(deadline) {
+			t.Fatal("collection tenant is not removed")
+		}
+	}
+	select {
+	case err = <-done:
+		t.Fatalf("Drop returned before the index is released: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	if idx.closed.Load() {
+		t.Fatal("index is closed before it is released")
+	}
+
+	release()
+	if err = <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !idx.closed.Load() {
+		t.Error("index is not closed after it is released")
+	}
+	if err = c.Drop(ctx, "tenant"); !errors.Is(err, errors.ErrCollectionNotFound("tenant")) {
+		t.Errorf("got error: %v, want: %v", err, errors.ErrCollectionNotFound("tenant"))
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	c, err := New(dir, newTestIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"b", "a"} {
+		if err = c.Create(ctx, &payload.Collection_Config{Name: name, Dimension: 3}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = c.Create(ctx, &payload.Collection_Config{Name: "a/b"}); !errors.Is(err, errors.ErrInvalidCollectionName("a/b")) {
+		t.Errorf("got error: %v, want: %v", err, errors.ErrInvalidCollectionName("a/b"))
+	}
+
+	// the persisted collections are loaded with their own index directories.
+	c, err = New(dir, newTestIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defs := c.List()
+	if len(defs) != 2 || defs[0].GetName() != "a" || defs[1].GetName() != "b" {
+		t.Fatalf("got collections: %v, want: [a b]", defs)
+	}
+	idx, release, ok := c.Acquire("a")
+	if !ok {
+		t.Fatal("collection a is not found")
+	}
+	defer release()
+	if want := dir + "/a"; idx.path != want {
+		t.Errorf("got path: %s, want: %s", idx.path, want)
+	}
+}
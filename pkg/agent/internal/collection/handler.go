@@ -0,0 +1,243 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package collection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/info"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/errdetails"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+	"github.com/vdaas/vald/internal/observability/attribute"
+	"github.com/vdaas/vald/internal/observability/trace"
+)
+
+// Registry represents the collections served by Handler, Collections implements it.
+type Registry[I any] interface {
+	Acquire(name string) (idx I, release func(), ok bool)
+	Create(ctx context.Context, cfg *payload.Collection_Config) error
+	Drop(ctx context.Context, name string) error
+	List() []*payload.Collection_Config
+}
+
+// Handler serves the Collection RPCs of an agent and routes its requests to the collection selected by the request metadata.
+type Handler[I any] struct {
+	vald.UnimplementedCollectionServer
+	registry     Registry[I]
+	apiName      string
+	resourceType string
+	resourceName string
+}
+
+type contextKey struct{}
+
+// collectionStream overrides the context of a server stream with the collection bound one.
+type collectionStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (cs *collectionStream) Context() context.Context {
+	return cs.ctx
+}
+
+// NewHandler returns the handler serving the collections of registry, which is nil when the agent hosts no collection.
+// apiName is the name of the agent API, and resourceType and resourceName describe the agent index in the error details.
+func NewHandler[I any](registry Registry[I], apiName, resourceType, resourceName string) *Handler[I] {
+	return &Handler[I]{
+		registry:     registry,
+		apiName:      apiName,
+		resourceType: resourceType,
+		resourceName: resourceName,
+	}
+}
+
+// FromContext returns the index of the collection bound to ctx by the interceptors of Handler.
+// It returns false when the request selects no collection, so that the default index serves it.
+func FromContext[I any](ctx context.Context) (idx I, ok bool) {
+	idx, ok = ctx.Value(contextKey{}).(I)
+	return idx, ok
+}
+
+// withCollection resolves the collection selected by the request metadata and binds its index to the context.
+// The returned release function must be called when the request completes, the collection is not dropped until then.
+func (h *Handler[I]) withCollection(ctx context.Context, method string) (context.Context, func(), error) {
+	name := grpc.CollectionFromIncomingContext(ctx)
+	if name == "" {
+		return ctx, func() {}, nil
+	}
+	if h.registry != nil {
+		if idx, release, ok := h.registry.Acquire(name); ok {
+			return context.WithValue(ctx, contextKey{}, idx), release, nil
+		}
+	}
+	err := status.WrapWithNotFound(fmt.Sprintf("%s API collection %s not found", method, name), errors.ErrCollectionNotFound(name),
+		h.resourceInfo("Collection"))
+	log.Debug(err)
+	return nil, nil, err
+}
+
+func (h *Handler[I]) resourceInfo(kind string) *errdetails.ResourceInfo {
+	return &errdetails.ResourceInfo{
+		ResourceType: h.resourceType + "." + kind,
+		ResourceName: h.resourceName,
+	}
+}
+
+// CollectionInterceptor returns the unary interceptor which binds the collection selected by the request to the context.
+func (h *Handler[I]) CollectionInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx, release, err := h.withCollection(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
+
+// CollectionStreamInterceptor returns the stream interceptor which binds the collection selected by the request to the stream context.
+func (h *Handler[I]) CollectionStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, release, err := h.withCollection(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return handler(srv, &collectionStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// CreateCollection creates a new collection hosted by the `vald-agent`.
+func (h *Handler[I]) CreateCollection(
+	ctx context.Context, req *payload.Collection_Config,
+) (res *payload.Empty, err error) {
+	_, span := trace.StartSpan(ctx, h.apiName+"/"+vald.CreateCollectionRPCName)
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	if h.registry == nil {
+		err = errors.ErrCollectionNotFound(req.GetName())
+	} else {
+		err = h.registry.Create(ctx, req)
+	}
+	if err != nil {
+		var attrs []attribute.KeyValue
+		reqInfo := &errdetails.RequestInfo{
+			ServingData: errdetails.Serialize(req),
+		}
+		resInfo := h.resourceInfo("CreateCollection")
+		switch {
+		case errors.Is(err, errors.ErrCollectionAlreadyExists(req.GetName())):
+			err = status.WrapWithAlreadyExists(fmt.Sprintf("CreateCollection API collection %s already exists", req.GetName()), err, reqInfo, resInfo)
+			log.Debug(err)
+			attrs = trace.StatusCodeAlreadyExists(err.Error())
+		case errors.Is(err, errors.ErrInvalidCollectionName(req.GetName())),
+			errors.Is(err, errors.ErrCollectionNotFound(req.GetName())):
+			err = status.WrapWithInvalidArgument(fmt.Sprintf("CreateCollection API invalid collection %s", req.GetName()), err, reqInfo, resInfo)
+			log.Debug(err)
+			attrs = trace.StatusCodeInvalidArgument(err.Error())
+		default:
+			err = status.WrapWithInternal("CreateCollection API failed", err, reqInfo, resInfo, info.Get())
+			log.Error(err)
+			attrs = trace.StatusCodeInternal(err.Error())
+		}
+		if span != nil {
+			span.RecordError(err)
+			span.SetAttributes(attrs...)
+			span.SetStatus(trace.StatusError, err.Error())
+		}
+		return nil, err
+	}
+	return new(payload.Empty), nil
+}
+
+// DropCollection drops the collection and removes its index from the `vald-agent`.
+func (h *Handler[I]) DropCollection(
+	ctx context.Context, req *payload.Collection_Name,
+) (res *payload.Empty, err error) {
+	_, span := trace.StartSpan(ctx, h.apiName+"/"+vald.DropCollectionRPCName)
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	if h.registry == nil {
+		err = errors.ErrCollectionNotFound(req.GetName())
+	} else {
+		err = h.registry.Drop(ctx, req.GetName())
+	}
+	if err != nil {
+		var attrs []attribute.KeyValue
+		reqInfo := &errdetails.RequestInfo{
+			ServingData: errdetails.Serialize(req),
+		}
+		resInfo := h.resourceInfo("DropCollection")
+		if errors.Is(err, errors.ErrCollectionNotFound(req.GetName())) {
+			err = status.WrapWithNotFound(fmt.Sprintf("DropCollection API collection %s not found", req.GetName()), err, reqInfo, resInfo)
+			log.Debug(err)
+			attrs = trace.StatusCodeNotFound(err.Error())
+		} else {
+			err = status.WrapWithInternal("DropCollection API failed", err, reqInfo, resInfo, info.Get())
+			log.Error(err)
+			attrs = trace.StatusCodeInternal(err.Error())
+		}
+		if span != nil {
+			span.RecordError(err)
+			span.SetAttributes(attrs...)
+			span.SetStatus(trace.StatusError, err.Error())
+		}
+		return nil, err
+	}
+	return new(payload.Empty), nil
+}
+
+// ListCollections returns the collections hosted by the `vald-agent`.
+func (h *Handler[I]) ListCollections(
+	ctx context.Context, _ *payload.Empty,
+) (*payload.Collection_List, error) {
+	_, span := trace.StartSpan(ctx, h.apiName+"/"+vald.ListCollectionsRPCName)
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	if h.registry == nil {
+		return new(payload.Collection_List), nil
+	}
+	return &payload.Collection_List{
+		Collections: h.registry.List(),
+	}, nil
+}
@@ -0,0 +1,128 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package collection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/codes"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+)
+
+func TestHandler_CollectionInterceptor(t *testing.T) {
+	ctx := context.Background()
+	c, err := New(t.TempDir(), newTestIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Create(ctx, &payload.Collection_Config{Name: "tenant", Dimension: 3}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		registry   Registry[*testIndex]
+		collection string
+		want       codes.Code
+		wantBound  bool
+	}{
+		{
+			name:     "serve the request selecting no collection by the default index",
+			registry: c,
+			want:     codes.OK,
+		},
+		{
+			name:       "bind the index of the selected collection",
+			registry:   c,
+			collection: "tenant",
+			want:       codes.OK,
+			wantBound:  true,
+		},
+		{
+			name:       "reject the request selecting an unknown collection",
+			registry:   c,
+			collection: "unknown",
+			want:       codes.NotFound,
+		},
+		{
+			name:       "reject the request selecting a collection of the agent hosting no collection",
+			collection: "tenant",
+			want:       codes.NotFound,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			intercept := NewHandler(test.registry, "vald/agent/core/test", "test", "agent").CollectionInterceptor()
+			var bound bool
+			_, err := intercept(grpc.WithIncomingCollection(ctx, test.collection), nil, &grpc.UnaryServerInfo{FullMethod: "/vald.v1.Search/Search"},
+				func(ctx context.Context, _ any) (any, error) {
+					idx, ok := FromContext[*testIndex](ctx)
+					bound = ok && idx != nil
+					return nil, nil
+				})
+			st, _ := status.FromError(err)
+			if st.Code() != test.want {
+				t.Errorf("got code: %v, want: %v, err: %v", st.Code(), test.want, err)
+			}
+			if bound != test.wantBound {
+				t.Errorf("got bound: %v, want: %v", bound, test.wantBound)
+			}
+		})
+	}
+}
+
+func TestHandler_CreateCollection(t *testing.T) {
+	ctx := context.Background()
+	c, err := New(t.TempDir(), newTestIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := func(_ any, err error) codes.Code {
+		st, _ := status.FromError(err)
+		return st.Code()
+	}
+	cfg := &payload.Collection_Config{Name: "tenant", Dimension: 3}
+	name := &payload.Collection_Name{Name: "tenant"}
+
+	h := NewHandler[*testIndex](c, "vald/agent/core/test", "test", "agent")
+	if got := code(h.CreateCollection(ctx, cfg)); got != codes.OK {
+		t.Fatalf("got code: %v, want: %v", got, codes.OK)
+	}
+	if got := code(h.CreateCollection(ctx, cfg)); got != codes.AlreadyExists {
+		t.Errorf("got code: %v, want: %v", got, codes.AlreadyExists)
+	}
+	if res, err := h.ListCollections(ctx, new(payload.Empty)); err != nil || len(res.GetCollections()) != 1 {
+		t.Errorf("got collections: %v, err: %v", res.GetCollections(), err)
+	}
+	if got := code(h.DropCollection(ctx, name)); got != codes.OK {
+		t.Fatalf("got code: %v, want: %v", got, codes.OK)
+	}
+	if got := code(h.DropCollection(ctx, name)); got != codes.NotFound {
+		t.Errorf("got code: %v, want: %v", got, codes.NotFound)
+	}
+
+	h = NewHandler[*testIndex](nil, "vald/agent/core/test", "test", "agent")
+	if got := code(h.CreateCollection(ctx, cfg)); got != codes.InvalidArgument {
+		t.Errorf("got code of the agent hosting no collection: %v, want: %v", got, codes.InvalidArgument)
+	}
+	if res, err := h.ListCollections(ctx, new(payload.Empty)); err != nil || len(res.GetCollections()) != 0 {
+		t.Errorf("got collections of the agent hosting no collection: %v, err: %v", res.GetCollections(), err)
+	}
+}
@@ -38,7 +38,16 @@ type Metadata struct {
 }
 
 type NGT struct {
-	IndexCount uint64 `json:"index_count" yaml:"index_count"`
+	IndexCount   uint64        `json:"index_count"            yaml:"index_count"`
+	Quantization *Quantization `json:"quantization,omitempty" yaml:"quantization"`
+}
+
+type Quantization struct {
+	Type string    `json:"type"           yaml:"type"`
+	Mins []float32 `json:"mins,omitempty" yaml:"mins"`
+	Maxs []float32 `json:"maxs,omitempty" yaml:"maxs"`
+
+	TrainedCount uint64 `json:"trained_count,omitempty" yaml:"trained_count"`
 }
 
 type Faiss struct {
@@ -0,0 +1,77 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package replica
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/errdetails"
+	"github.com/vdaas/vald/internal/net/grpc/health"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+)
+
+// Index represents the index of the agent which may be a read replica.
+type Index interface {
+	// IsReplicaPending returns true while the read replica has not loaded any index generation.
+	IsReplicaPending() bool
+}
+
+// UnaryServerInterceptor returns the unary interceptor which rejects the requests with Unavailable
+// while the read replica of idx has not loaded any index generation. The health checks are always served.
+// res is attached to the error as the resource which is not ready.
+func UnaryServerInterceptor(idx Index, res *errdetails.ResourceInfo) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := pending(idx, info.FullMethod, res); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns the stream interceptor which rejects the streams the same as UnaryServerInterceptor.
+func StreamServerInterceptor(idx Index, res *errdetails.ResourceInfo) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := pending(idx, info.FullMethod, res); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func pending(idx Index, method string, res *errdetails.ResourceInfo) error {
+	if idx == nil || !idx.IsReplicaPending() || health.IsHealthCheck(method) {
+		return nil
+	}
+	err := status.WrapWithUnavailable(fmt.Sprintf("%s API index generation is not loaded yet", method),
+		errors.ErrIndexGenerationNotLoaded, res)
+	log.Debug(err)
+	return err
+}
@@ -0,0 +1,80 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package replica
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/codes"
+	"github.com/vdaas/vald/internal/net/grpc/errdetails"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+)
+
+type index bool
+
+func (i index) IsReplicaPending() bool {
+	return bool(i)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name   string
+		idx    Index
+		method string
+		want   codes.Code
+	}{
+		{
+			name:   "serve the request after the read replica loads a generation",
+			idx:    index(false),
+			method: "/vald.v1.Search/Search",
+			want:   codes.OK,
+		},
+		{
+			name:   "serve the request when the agent has no index",
+			method: "/vald.v1.Search/Search",
+			want:   codes.OK,
+		},
+		{
+			name:   "reject the request while the read replica has not loaded a generation",
+			idx:    index(true),
+			method: "/vald.v1.Search/Search",
+			want:   codes.Unavailable,
+		},
+		{
+			name:   "serve the health check while the read replica has not loaded a generation",
+			idx:    index(true),
+			method: "/grpc.health.v1.Health/Check",
+			want:   codes.OK,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			intercept := UnaryServerInterceptor(test.idx, new(errdetails.ResourceInfo))
+			_, err := intercept(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: test.method},
+				func(context.Context, any) (any, error) {
+					return nil, nil
+				})
+			st, _ := status.FromError(err)
+			if st.Code() != test.want {
+				t.Errorf("got code: %v, want: %v, err: %v", st.Code(), test.want, err)
+			}
+		})
+	}
+}
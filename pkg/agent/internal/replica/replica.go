@@ -0,0 +1,122 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package replica provides the layout of the index generations pulled from the blob storage for the read replica agents.
+// The agent sidecar in readreplica mode restores each generation to its own directory and then points the CURRENT file to it,
+// and the read replica agents load the index from the directory pointed by the CURRENT file.
+package replica
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+const (
+	// SourceSnapshot represents the read replica source which loads the index from the volume restored from the VolumeSnapshot.
+	SourceSnapshot = "snapshot"
+	// SourceBlob represents the read replica source which loads the index generations pulled from the blob storage.
+	SourceBlob = "blob"
+
+	// GenerationsDirName is the directory name to store the index generations under the index path.
+	GenerationsDirName = "generations"
+	// CurrentFileName is the file name which has the ID of the current index generation under the index path.
+	CurrentFileName = "CURRENT"
+)
+
+// ValidateSource returns an error if src is not a supported read replica source. An empty source means SourceSnapshot.
+func ValidateSource(src string) error {
+	switch src {
+	case "", SourceSnapshot, SourceBlob:
+		return nil
+	}
+	return errors.ErrUnsupportedReadReplicaSource(src)
+}
+
+// Dir returns the directory of the index generation id under base.
+func Dir(base, id string) string {
+	return file.Join(base, GenerationsDirName, id)
+}
+
+// Current returns the ID of the current index generation under base. It returns an empty ID if no generation is pulled yet.
+func Current(base string) (id string, err error) {
+	path := file.Join(base, CurrentFileName)
+	if !file.Exists(path) {
+		return "", nil
+	}
+	b, err := file.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	id = string(bytes.TrimSpace(b))
+	if err = validateID(id); err != nil {
+		return "", err
+	}
+	if !file.Exists(Dir(base, id)) {
+		return "", errors.ErrIndexFileNotFound
+	}
+	return id, nil
+}
+
+// SetCurrent points the CURRENT file under base to the index generation id atomically.
+func SetCurrent(base, id string) (err error) {
+	if err = validateID(id); err != nil {
+		return err
+	}
+	path := file.Join(base, CurrentFileName)
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, []byte(id+"\n"), fs.ModePerm)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Prune deletes the index generations under base except the generations listed in keep.
+func Prune(base string, keep ...string) (err error) {
+	dir := file.Join(base, GenerationsDirName)
+	if !file.Exists(dir) {
+		return nil
+	}
+	entries, err := file.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	kept := make(map[string]bool, len(keep))
+	for _, id := range keep {
+		kept[id] = true
+	}
+	for _, e := range entries {
+		if kept[e.Name()] {
+			continue
+		}
+		if rerr := os.RemoveAll(file.Join(dir, e.Name())); rerr != nil {
+			err = errors.Join(err, rerr)
+		}
+	}
+	return err
+}
+
+func validateID(id string) error {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return errors.ErrInvalidIndexGeneration(id)
+	}
+	return nil
+}
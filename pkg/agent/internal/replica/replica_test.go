@@ -0,0 +1,130 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package replica
+
+import (
+	"os"
+	"testing"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+)
+
+func TestValidateSource(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want error
+	}{
+		{name: "return nil when the source is empty", src: ""},
+		{name: "return nil when the source is snapshot", src: SourceSnapshot},
+		{name: "return nil when the source is blob", src: SourceBlob},
+		{
+			name: "return error when the source is not supported",
+			src:  "volume",
+			want: errors.ErrUnsupportedReadReplicaSource("volume"),
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateSource(test.src)
+			if !errors.Is(err, test.want) {
+				t.Errorf("got error: %v, want: %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestCurrent(t *testing.T) {
+	tests := []struct {
+		name       string
+		beforeFunc func(t *testing.T, base string)
+		want       string
+		wantErr    error
+	}{
+		{
+			name: "return empty id when no generation is pulled",
+		},
+		{
+			name: "return the id pointed by SetCurrent",
+			beforeFunc: func(t *testing.T, base string) {
+				t.Helper()
+				if err := os.MkdirAll(Dir(base, "20261018T000000Z"), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := SetCurrent(base, "20261018T000000Z"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "20261018T000000Z",
+		},
+		{
+			name: "return error when the generation directory does not exist",
+			beforeFunc: func(t *testing.T, base string) {
+				t.Helper()
+				if err := SetCurrent(base, "20261018T000000Z"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: errors.ErrIndexFileNotFound,
+		},
+		{
+			name: "return error when the id escapes the generations directory",
+			beforeFunc: func(t *testing.T, base string) {
+				t.Helper()
+				if err := os.WriteFile(file.Join(base, CurrentFileName), []byte("../origin\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: errors.ErrInvalidIndexGeneration("../origin"),
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			base := t.TempDir()
+			if test.beforeFunc != nil {
+				test.beforeFunc(t, base)
+			}
+			got, err := Current(base)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("got error: %v, want: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("got: %s, want: %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	base := t.TempDir()
+	for _, id := range []string{"g1", "g2", "g3"} {
+		if err := os.MkdirAll(Dir(base, id), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Prune(base, "g2", "g3"); err != nil {
+		t.Fatalf("failed to prune: %v", err)
+	}
+	for id, want := range map[string]bool{"g1": false, "g2": true, "g3": true} {
+		if got := file.Exists(Dir(base, id)); got != want {
+			t.Errorf("generation %s exists: %v, want: %v", id, got, want)
+		}
+	}
+}
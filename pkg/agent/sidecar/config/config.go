@@ -28,6 +28,7 @@ type Mode uint8
 const (
 	SIDECAR Mode = 1 + iota
 	INITCONTAINER
+	READREPLICA
 )
 
 func (m Mode) String() string {
@@ -36,6 +37,8 @@ func (m Mode) String() string {
 		return "sidecar"
 	case INITCONTAINER:
 		return "initcontainer"
+	case READREPLICA:
+		return "readreplica"
 	}
 	return "unknown"
 }
@@ -46,6 +49,8 @@ func SidecarMode(m string) Mode {
 		return SIDECAR
 	case "initcontainer":
 		return INITCONTAINER
+	case "readreplica":
+		return READREPLICA
 	}
 	return 0
 }
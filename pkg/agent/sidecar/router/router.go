@@ -47,6 +47,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/",
+				RPC:         "/",
 				HandlerFunc: h.Index,
 			},
 		}...))
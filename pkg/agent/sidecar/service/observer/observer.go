@@ -20,13 +20,17 @@ package observer
 import (
 	"archive/tar"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/vdaas/vald/internal/encoding/json"
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/file"
 	"github.com/vdaas/vald/internal/file/watch"
@@ -64,6 +68,13 @@ type observer struct {
 	ch chan struct{}
 
 	hooks []Hook
+
+	versioning bool
+	retention  storage.Retention
+
+	incremental bool
+	chunkSize   int // average size of the content-defined chunks
+	concurrency int // number of the chunks uploaded concurrently
 }
 
 func New(opts ...Option) (so StorageObserver, err error) {
@@ -427,25 +438,49 @@ func (o *observer) backup(ctx context.Context) (err error) {
 
 	log.Infof("started to backup directory %s", o.dir)
 
-	pr, pw := io.Pipe()
-	defer func() {
-		e := pr.Close()
-		if e != nil {
-			log.Errorf("error on closing pipe reader: %s", e)
+	if o.versioning && o.incremental {
+		err = o.backupIncremental(ctx, bi)
+		if err != nil {
+			return err
 		}
-	}()
+		return o.finishBackup(ctx, bi)
+	}
 
-	sw, err := o.storage.Writer(ctx)
+	var (
+		m   *storage.Manifest
+		gen *storage.Generation
+		sw  io.WriteCloser
+	)
+	if o.versioning {
+		m, gen, err = o.beginGeneration(ctx, bi.StartTime)
+		if err != nil {
+			return err
+		}
+		sw, err = o.storage.GenerationWriter(ctx, gen.ID)
+	} else {
+		sw, err = o.storage.Writer(ctx)
+	}
 	if err != nil {
 		return err
 	}
 	defer func() {
+		if sw == nil {
+			return
+		}
 		e := sw.Close()
 		if e != nil {
 			log.Errorf("error on closing blob-storage writer: %s", e)
 		}
 	}()
 
+	pr, pw := io.Pipe()
+	defer func() {
+		e := pr.Close()
+		if e != nil {
+			log.Errorf("error on closing pipe reader: %s", e)
+		}
+	}()
+
 	wg := new(sync.WaitGroup)
 	wg.Add(1)
 
@@ -529,13 +564,33 @@ func (o *observer) backup(ctx context.Context) (err error) {
 		return err
 	}
 
-	bi.Bytes, err = io.Copy(sw, prr)
+	h := sha256.New()
+	bi.Bytes, err = io.Copy(io.MultiWriter(sw, h), prr)
 	if err != nil {
 		return err
 	}
 
 	wg.Wait()
 
+	if gen != nil {
+		// the generation must be uploaded completely before the verification
+		err = sw.Close()
+		sw = nil
+		if err != nil {
+			return err
+		}
+		gen.Bytes = bi.Bytes
+		gen.Checksum = hex.EncodeToString(h.Sum(nil))
+		err = o.commitGeneration(ctx, m, gen)
+		if err != nil {
+			return err
+		}
+	}
+
+	return o.finishBackup(ctx, bi)
+}
+
+func (o *observer) finishBackup(ctx context.Context, bi *BackupInfo) (err error) {
 	bi.EndTime = time.Now()
 	for _, hook := range o.hooks {
 		err = hook.AfterProcess(ctx, bi)
@@ -548,3 +603,197 @@ func (o *observer) backup(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// backupIncremental splits the files into the content-defined chunks and uploads only the chunks absent from the storage,
+// then stores the snapshot referring to the chunks as the generation and deletes the chunks no generation refers to.
+func (o *observer) backupIncremental(ctx context.Context, bi *BackupInfo) (err error) {
+	m, gen, err := o.beginGeneration(ctx, bi.StartTime)
+	if err != nil {
+		return err
+	}
+	gen.Incremental = true
+
+	hashes, err := o.storage.ListChunks(ctx)
+	if err != nil {
+		return err
+	}
+	stored := make(map[string]struct{}, len(hashes))
+	for _, hash := range hashes {
+		stored[hash] = struct{}{}
+	}
+
+	var uploaded atomic.Int64
+	eg, egctx := errgroup.New(ctx)
+	eg.SetLimit(o.concurrency)
+
+	ss := new(storage.Snapshot)
+	err = filepath.Walk(o.dir, func(path string, fi os.FileInfo, err error) error {
+		select {
+		case <-egctx.Done():
+			return egctx.Err()
+		default:
+		}
+
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(o.dir, path)
+		if err != nil {
+			return err
+		}
+
+		sf := &storage.SnapshotFile{
+			Path: filepath.ToSlash(rel),
+			Mode: uint32(fi.Mode().Perm()),
+			Dir:  fi.IsDir(),
+		}
+		ss.Files = append(ss.Files, sf)
+
+		log.Debug("chunking: ", path)
+
+		if fi.IsDir() {
+			return nil
+		}
+
+		data, err := file.Open(path, os.O_RDONLY, fs.ModePerm)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			e := data.Close()
+			if e != nil {
+				log.Errorf("failed to close %s: %s", path, e)
+			}
+		}()
+
+		d, err := io.NewReaderWithContext(egctx, data)
+		if err != nil {
+			return err
+		}
+
+		c := storage.NewChunker(d, o.chunkSize)
+		for {
+			chunk, err := c.Next()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+
+			hash := storage.ChunkHash(chunk)
+			sf.Size += int64(len(chunk))
+			sf.Chunks = append(sf.Chunks, &storage.Chunk{
+				Hash: hash,
+				Size: int64(len(chunk)),
+			})
+			if _, ok := stored[hash]; ok {
+				continue
+			}
+			stored[hash] = struct{}{}
+
+			eg.Go(safety.RecoverFunc(func() error {
+				err := o.storage.PutChunk(egctx, hash, chunk)
+				if err != nil {
+					return err
+				}
+				uploaded.Add(int64(len(chunk)))
+				return nil
+			}))
+		}
+	})
+	err = errors.Join(err, eg.Wait())
+	if err != nil {
+		return err
+	}
+	bi.Bytes = uploaded.Load()
+
+	sw, err := o.storage.GenerationWriter(ctx, gen.ID)
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	err = json.Encode(io.MultiWriter(sw, h), ss)
+	// the snapshot must be uploaded completely before the verification
+	err = errors.Join(err, sw.Close())
+	if err != nil {
+		return err
+	}
+	for _, sf := range ss.Files {
+		gen.Bytes += sf.Size
+	}
+	gen.Checksum = hex.EncodeToString(h.Sum(nil))
+
+	err = o.commitGeneration(ctx, m, gen)
+	if err != nil {
+		return err
+	}
+
+	return o.collectChunks(ctx, m, stored)
+}
+
+// collectChunks deletes the stored chunks which are not referred by any generation of the manifest.
+// The chunks failed to be deleted are left to be collected by the next backup.
+func (o *observer) collectChunks(ctx context.Context, m *storage.Manifest, stored map[string]struct{}) error {
+	hashes := make([]string, 0, len(stored))
+	for hash := range stored {
+		hashes = append(hashes, hash)
+	}
+	unrefs, err := storage.UnreferencedChunks(ctx, o.storage, m, hashes)
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range unrefs {
+		err = o.storage.DeleteChunk(ctx, hash)
+		if err != nil {
+			log.Warnf("failed to delete unreferenced backup chunk %s: %v", hash, err)
+			continue
+		}
+	}
+	if len(unrefs) > 0 {
+		log.Infof("%d unreferenced backup chunks are collected", len(unrefs))
+	}
+
+	return nil
+}
+
+// beginGeneration adds the invalid generation taken at t to the manifest before its upload,
+// so that the generation is deleted by the retention even if the upload is interrupted.
+func (o *observer) beginGeneration(ctx context.Context, t time.Time) (m *storage.Manifest, gen *storage.Generation, err error) {
+	m, err = storage.LoadManifest(ctx, o.storage)
+	if err != nil {
+		return nil, nil, err
+	}
+	gen = storage.NewGeneration(t)
+	m.Put(gen)
+	err = storage.SaveManifest(ctx, o.storage, m)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, gen, nil
+}
+
+// commitGeneration verifies the uploaded generation by its checksum and the stored chunks it refers to, and marks it valid,
+// then deletes the generations expired by the retention policy and saves the manifest.
+func (o *observer) commitGeneration(ctx context.Context, m *storage.Manifest, gen *storage.Generation) (err error) {
+	err = storage.VerifyGeneration(ctx, o.storage, gen)
+	if err != nil {
+		log.Errorf("failed to verify backup generation %s: %v", gen.ID, err)
+	}
+	gen.Valid = err == nil
+
+	for _, g := range m.Expired(o.retention) {
+		derr := o.storage.DeleteGeneration(ctx, g.ID)
+		if derr != nil {
+			// the generation is left in the manifest to be deleted by the next backup
+			log.Warnf("failed to delete expired backup generation %s: %v", g.ID, derr)
+			continue
+		}
+		log.Infof("expired backup generation %s is deleted", g.ID)
+		m.Remove(g.ID)
+	}
+
+	return errors.Join(err, storage.SaveManifest(ctx, o.storage, m))
+}
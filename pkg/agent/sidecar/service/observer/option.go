@@ -21,6 +21,7 @@ import (
 	"github.com/vdaas/vald/internal/file"
 	"github.com/vdaas/vald/internal/sync/errgroup"
 	"github.com/vdaas/vald/internal/timeutil"
+	"github.com/vdaas/vald/internal/unit"
 	"github.com/vdaas/vald/pkg/agent/internal/metadata"
 	"github.com/vdaas/vald/pkg/agent/sidecar/service/storage"
 )
@@ -33,6 +34,8 @@ var defaultOptions = []Option{
 	WithPostStopTimeout("2m"),
 	WithWatch(true),
 	WithTicker(true),
+	WithChunkSize("1MiB"),
+	WithConcurrency(1),
 }
 
 func WithBackupDuration(dur string) Option {
@@ -125,3 +128,58 @@ func WithHooks(hooks ...Hook) Option {
 		return nil
 	}
 }
+
+func WithVersioning(enabled bool) Option {
+	return func(o *observer) error {
+		o.versioning = enabled
+
+		return nil
+	}
+}
+
+func WithRetention(keepLast, keepDaily, keepWeekly int) Option {
+	return func(o *observer) error {
+		o.retention = storage.Retention{
+			KeepLast:   keepLast,
+			KeepDaily:  keepDaily,
+			KeepWeekly: keepWeekly,
+		}
+
+		return nil
+	}
+}
+
+func WithIncremental(enabled bool) Option {
+	return func(o *observer) error {
+		o.incremental = enabled
+
+		return nil
+	}
+}
+
+func WithChunkSize(size string) Option {
+	return func(o *observer) error {
+		if size == "" {
+			return nil
+		}
+		b, err := unit.ParseBytes(size)
+		if err != nil {
+			return err
+		}
+		if b > 0 {
+			o.chunkSize = int(b)
+		}
+
+		return nil
+	}
+}
+
+func WithConcurrency(c int) Option {
+	return func(o *observer) error {
+		if c > 0 {
+			o.concurrency = c
+		}
+
+		return nil
+	}
+}
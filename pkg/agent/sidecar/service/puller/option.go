@@ -0,0 +1,89 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package puller provides puller service which pulls the latest index generation from the blob storage for the read replica agent
+package puller
+
+import (
+	"github.com/vdaas/vald/internal/sync/errgroup"
+	"github.com/vdaas/vald/internal/timeutil"
+	"github.com/vdaas/vald/pkg/agent/sidecar/service/storage"
+)
+
+type Option func(p *puller) error
+
+var defaultOptions = []Option{
+	WithErrGroup(errgroup.Get()),
+	WithPullDuration("1m"),
+	WithConcurrency(1),
+}
+
+func WithErrGroup(eg errgroup.Group) Option {
+	return func(p *puller) error {
+		if eg != nil {
+			p.eg = eg
+		}
+		return nil
+	}
+}
+
+// WithDir returns the option to set the index directory of the read replica agent which the generations are pulled to.
+func WithDir(dir string) Option {
+	return func(p *puller) error {
+		if dir == "" {
+			return nil
+		}
+
+		p.dir = dir
+
+		return nil
+	}
+}
+
+func WithBlobStorage(storage storage.Storage) Option {
+	return func(p *puller) error {
+		if storage != nil {
+			p.storage = storage
+		}
+		return nil
+	}
+}
+
+// WithPullDuration returns the option to set the duration to check the manifest for a new generation.
+func WithPullDuration(dur string) Option {
+	return func(p *puller) error {
+		if dur == "" {
+			return nil
+		}
+		d, err := timeutil.Parse(dur)
+		if err != nil {
+			return err
+		}
+		if d > 0 {
+			p.pullDuration = d
+		}
+		return nil
+	}
+}
+
+func WithConcurrency(c int) Option {
+	return func(p *puller) error {
+		if c > 0 {
+			p.concurrency = c
+		}
+		return nil
+	}
+}
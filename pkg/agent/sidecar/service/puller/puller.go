@@ -0,0 +1,222 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package puller provides puller service which pulls the latest index generation from the blob storage for the read replica agent
+package puller
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/observability/trace"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+	"github.com/vdaas/vald/pkg/agent/internal/replica"
+	"github.com/vdaas/vald/pkg/agent/sidecar/service/restorer"
+	"github.com/vdaas/vald/pkg/agent/sidecar/service/storage"
+)
+
+type Puller interface {
+	Start(ctx context.Context) (<-chan error, error)
+	PreStop(ctx context.Context) error
+}
+
+type puller struct {
+	dir string
+	eg  errgroup.Group
+
+	storage storage.Storage
+
+	pullDuration time.Duration
+	concurrency  int // number of the chunks downloaded concurrently
+
+	current string // ID of the index generation pointed by the CURRENT file
+}
+
+// stagingSuffix is the suffix of the directory which the generation is restored to before it is published.
+const stagingSuffix = ".tmp"
+
+func New(opts ...Option) (Puller, error) {
+	p := new(puller)
+	for _, opt := range append(defaultOptions, opts...) {
+		if err := opt(p); err != nil {
+			return nil, errors.ErrOptionFailed(err, reflect.ValueOf(opt))
+		}
+	}
+
+	return p, nil
+}
+
+func (p *puller) Start(ctx context.Context) (<-chan error, error) {
+	ech := make(chan error, 2)
+
+	var sech, pech <-chan error
+	var err error
+
+	sech, err = p.storage.Start(ctx)
+	if err != nil {
+		close(ech)
+		return nil, err
+	}
+
+	pech, err = p.startPullLoop(ctx)
+	if err != nil {
+		close(ech)
+		return nil, err
+	}
+
+	p.eg.Go(safety.RecoverFunc(func() (err error) {
+		defer close(ech)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case err = <-sech:
+			case err = <-pech:
+			}
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case ech <- err:
+				}
+			}
+		}
+	}))
+
+	return ech, nil
+}
+
+func (p *puller) PreStop(ctx context.Context) error {
+	if p.storage != nil {
+		return p.storage.Stop(ctx)
+	}
+	return nil
+}
+
+func (p *puller) startPullLoop(ctx context.Context) (<-chan error, error) {
+	ech := make(chan error, 100)
+
+	current, err := replica.Current(p.dir)
+	if err != nil {
+		log.Warnf("failed to read the current index generation in %s, the latest one will be pulled: %v", p.dir, err)
+	}
+	p.current = current
+
+	p.eg.Go(safety.RecoverFunc(func() (err error) {
+		defer close(ech)
+
+		tick := time.NewTicker(p.pullDuration)
+		defer tick.Stop()
+
+		for {
+			err = p.pull(ctx)
+			if err != nil {
+				log.Errorf("failed to pull the latest index generation: %v", err)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case ech <- err:
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-tick.C:
+			}
+		}
+	}))
+
+	return ech, nil
+}
+
+// pull restores the latest valid generation listed in the manifest to its own directory when it differs from the current one.
+// The generation is restored to the staging directory first, and then it is renamed and pointed by the CURRENT file,
+// so that the read replica agent never loads a partially restored generation.
+// The generations older than the previous one are deleted after the switch.
+func (p *puller) pull(ctx context.Context) (err error) {
+	ctx, span := trace.StartSpan(ctx, "vald/agent-sidecar/service/puller/Puller.pull")
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+
+	m, err := storage.LoadManifest(ctx, p.storage)
+	if err != nil {
+		return err
+	}
+	gens := m.Valid(time.Time{})
+	if len(gens) == 0 || gens[0].ID == p.current {
+		return nil
+	}
+	id := gens[0].ID
+	log.Infof("started to pull index generation %s taken at %s", id, gens[0].Timestamp)
+
+	dir := replica.Dir(p.dir, id)
+	staging := dir + stagingSuffix
+	err = os.RemoveAll(staging)
+	if err != nil {
+		return err
+	}
+	err = file.MkdirAll(staging, fs.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	r, err := restorer.New(
+		restorer.WithErrGroup(p.eg),
+		restorer.WithDir(staging),
+		restorer.WithBlobStorage(p.storage),
+		restorer.WithVersioning(true),
+		restorer.WithRestoreGeneration(id),
+		restorer.WithConcurrency(p.concurrency),
+	)
+	if err != nil {
+		return err
+	}
+	err = r.Restore(ctx)
+	if err != nil {
+		return errors.Join(err, os.RemoveAll(staging))
+	}
+
+	err = os.RemoveAll(dir)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(staging, dir)
+	if err != nil {
+		return err
+	}
+	err = replica.SetCurrent(p.dir, id)
+	if err != nil {
+		return err
+	}
+
+	prev := p.current
+	p.current = id
+	log.Infof("finished to pull index generation %s to %s", id, dir)
+
+	return replica.Prune(p.dir, prev, id)
+}
@@ -0,0 +1,155 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package puller
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/file"
+	"github.com/vdaas/vald/internal/io"
+	"github.com/vdaas/vald/pkg/agent/internal/replica"
+	"github.com/vdaas/vald/pkg/agent/sidecar/service/storage"
+)
+
+// memStorage is the in-memory storage which serves the manifest and the uncompressed generation archives.
+type memStorage struct {
+	storage.Storage
+	manifest *storage.Manifest
+	archives map[string][]byte
+}
+
+func (m *memStorage) ManifestReader(context.Context) (io.ReadCloser, error) {
+	b, err := json.Marshal(m.manifest)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (m *memStorage) GenerationReader(_ context.Context, id string) (io.ReadCloser, error) {
+	b, ok := m.archives[id]
+	if !ok {
+		return nil, errors.ErrBackupGenerationNotFound(id)
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+// put adds the valid generation taken at t whose archive has the index file with data.
+func (m *memStorage) put(t *testing.T, ts time.Time, data string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "origin/", Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: "origin/index", Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(data))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	g := storage.NewGeneration(ts)
+	sum, n, err := storage.Checksum(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Checksum, g.Bytes, g.Valid = sum, n, true
+	m.manifest.Put(g)
+	m.archives[g.ID] = buf.Bytes()
+	return g.ID
+}
+
+func Test_puller_pull(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	st := &memStorage{
+		manifest: new(storage.Manifest),
+		archives: make(map[string][]byte),
+	}
+	p, err := New(WithDir(dir), WithBlobStorage(st))
+	if err != nil {
+		t.Fatalf("failed to create puller: %v", err)
+	}
+	pl := p.(*puller)
+
+	check := func(id, data string) {
+		t.Helper()
+		cur, err := replica.Current(dir)
+		if err != nil {
+			t.Fatalf("failed to read the current generation: %v", err)
+		}
+		if cur != id {
+			t.Fatalf("current generation: %s, want: %s", cur, id)
+		}
+		b, err := os.ReadFile(file.Join(replica.Dir(dir, id), "origin", "index"))
+		if err != nil {
+			t.Fatalf("failed to read the pulled index: %v", err)
+		}
+		if string(b) != data {
+			t.Errorf("pulled index: %s, want: %s", b, data)
+		}
+	}
+
+	if err := pl.pull(ctx); err != nil {
+		t.Fatalf("failed to pull without generations: %v", err)
+	}
+	if cur, _ := replica.Current(dir); cur != "" {
+		t.Fatalf("current generation: %s, want empty", cur)
+	}
+
+	now := time.Now()
+	g1 := st.put(t, now.Add(-2*time.Hour), "first")
+	if err := pl.pull(ctx); err != nil {
+		t.Fatalf("failed to pull: %v", err)
+	}
+	check(g1, "first")
+
+	g2 := st.put(t, now.Add(-time.Hour), "second")
+	if err := pl.pull(ctx); err != nil {
+		t.Fatalf("failed to pull: %v", err)
+	}
+	check(g2, "second")
+
+	g3 := st.put(t, now, "third")
+	st.manifest.Generations[len(st.manifest.Generations)-1].Valid = false
+	if err := pl.pull(ctx); err != nil {
+		t.Fatalf("failed to pull: %v", err)
+	}
+	check(g2, "second")
+
+	st.manifest.Generations[len(st.manifest.Generations)-1].Valid = true
+	if err := pl.pull(ctx); err != nil {
+		t.Fatalf("failed to pull: %v", err)
+	}
+	check(g3, "third")
+	if file.Exists(replica.Dir(dir, g1)) {
+		t.Errorf("generation %s is not pruned", g1)
+	}
+	if !file.Exists(replica.Dir(dir, g2)) {
+		t.Errorf("previous generation %s is pruned", g2)
+	}
+}
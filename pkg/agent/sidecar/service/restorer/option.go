@@ -18,6 +18,8 @@
 package restorer
 
 import (
+	"time"
+
 	"github.com/vdaas/vald/internal/backoff"
 	"github.com/vdaas/vald/internal/sync/errgroup"
 	"github.com/vdaas/vald/pkg/agent/sidecar/service/storage"
@@ -28,6 +30,7 @@ type Option func(r *restorer) error
 var defaultOptions = []Option{
 	WithErrGroup(errgroup.Get()),
 	WithBackoff(false),
+	WithConcurrency(1),
 }
 
 func WithErrGroup(eg errgroup.Group) Option {
@@ -78,3 +81,37 @@ func WithBackoffOpts(opts ...backoff.Option) Option {
 		return nil
 	}
 }
+
+func WithVersioning(enabled bool) Option {
+	return func(r *restorer) error {
+		r.versioning = enabled
+		return nil
+	}
+}
+
+func WithRestoreGeneration(id string) Option {
+	return func(r *restorer) error {
+		r.generation = id
+		return nil
+	}
+}
+
+// WithRestoreBefore returns the option to restore the latest valid generation taken before the time in RFC3339 format.
+func WithRestoreBefore(t string) Option {
+	return func(r *restorer) (err error) {
+		if t == "" {
+			return nil
+		}
+		r.before, err = time.Parse(time.RFC3339, t)
+		return err
+	}
+}
+
+func WithConcurrency(c int) Option {
+	return func(r *restorer) error {
+		if c > 0 {
+			r.concurrency = c
+		}
+		return nil
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"reflect"
 	"syscall"
+	"time"
 
 	"github.com/vdaas/vald/internal/backoff"
 	"github.com/vdaas/vald/internal/errors"
@@ -40,6 +41,7 @@ import (
 type Restorer interface {
 	Start(ctx context.Context) (<-chan error, error)
 	PreStop(ctx context.Context) error
+	Restore(ctx context.Context) error
 }
 
 type restorer struct {
@@ -51,8 +53,17 @@ type restorer struct {
 	backoffEnabled bool
 	backoffOpts    []backoff.Option
 	bo             backoff.Backoff
+
+	versioning bool
+	generation string    // generation ID to restore, the latest valid one is restored if empty
+	before     time.Time // the latest valid generation taken before it is restored if not zero
+
+	concurrency int // number of the chunks downloaded concurrently
 }
 
+// latestGeneration is the generation ID which represents the latest valid generation.
+const latestGeneration = "latest"
+
 func New(opts ...Option) (Restorer, error) {
 	r := new(restorer)
 	for _, opt := range append(defaultOptions, opts...) {
@@ -118,6 +129,11 @@ func (r *restorer) PreStop(ctx context.Context) error {
 	return nil
 }
 
+// Restore restores the directory once without terminating the process after the restoration.
+func (r *restorer) Restore(ctx context.Context) error {
+	return r.restore(ctx)
+}
+
 func (r *restorer) startRestore(ctx context.Context) (<-chan error, error) {
 	ech := make(chan error, 100)
 
@@ -182,6 +198,126 @@ func (r *restorer) restore(ctx context.Context) (err error) {
 
 	log.Infof("started to restore directory %s", r.dir)
 
+	if r.versioning {
+		return r.restoreGeneration(ctx)
+	}
+	return r.extract(ctx, r.storage.Reader)
+}
+
+// restoreGeneration restores the generation selected by the generation ID or the time from the manifest.
+// The generations are verified by the checksum before the extraction, and the older valid generations are tried in order
+// when the latest one is selected and it fails the verification.
+func (r *restorer) restoreGeneration(ctx context.Context) (err error) {
+	m, err := storage.LoadManifest(ctx, r.storage)
+	if err != nil {
+		return err
+	}
+	if len(m.Generations) == 0 {
+		log.Infof("no backup generation is listed in the manifest, restoring the backup file stored without the versioning")
+		return r.extract(ctx, r.storage.Reader)
+	}
+
+	var gens []*storage.Generation
+	if r.generation != "" && r.generation != latestGeneration {
+		g, ok := m.Get(r.generation)
+		if !ok {
+			return errors.ErrBackupGenerationNotFound(r.generation)
+		}
+		gens = append(gens, g)
+	} else {
+		gens = m.Valid(r.before)
+	}
+	if len(gens) == 0 {
+		return errors.ErrNoValidBackupGeneration(r.before)
+	}
+
+	for _, g := range gens {
+		verr := storage.VerifyGeneration(ctx, r.storage, g)
+		if verr != nil {
+			log.Warnf("skipped to restore backup generation %s: %v", g.ID, verr)
+			err = errors.Join(err, verr)
+			continue
+		}
+		log.Infof("started to restore backup generation %s taken at %s", g.ID, g.Timestamp)
+		if g.Incremental {
+			return r.restoreSnapshot(ctx, g.ID)
+		}
+		return r.extract(ctx, func(ctx context.Context) (io.ReadCloser, error) {
+			return r.storage.GenerationReader(ctx, g.ID)
+		})
+	}
+	return err
+}
+
+// restoreSnapshot reassembles the files of the incremental backup generation id from their chunks downloaded in parallel.
+func (r *restorer) restoreSnapshot(ctx context.Context, id string) (err error) {
+	ss, err := storage.LoadSnapshot(ctx, r.storage, id)
+	if err != nil {
+		return err
+	}
+
+	eg, egctx := errgroup.New(ctx)
+	eg.SetLimit(r.concurrency)
+
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			e := f.Close()
+			if e != nil {
+				err = errors.Join(err, e)
+			}
+		}
+	}()
+
+	for _, sf := range ss.Files {
+		target := file.Join(r.dir, sf.Path)
+		log.Debug("restoring: ", target)
+		if strings.Contains(target, "..") {
+			continue
+		}
+		if sf.Dir {
+			err = file.MkdirAll(target, fs.ModePerm)
+			if err != nil {
+				return errors.Join(err, eg.Wait())
+			}
+			continue
+		}
+
+		f, err := file.Open(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.FileMode(sf.Mode))
+		if err != nil {
+			return errors.Join(err, eg.Wait())
+		}
+		files = append(files, f)
+
+		var offset int64
+		for _, c := range sf.Chunks {
+			off := offset
+			eg.Go(safety.RecoverFunc(func() error {
+				data, err := r.storage.GetChunk(egctx, c.Hash)
+				if err != nil {
+					return err
+				}
+				if hash := storage.ChunkHash(data); hash != c.Hash {
+					return errors.ErrBackupChecksumMismatch(c.Hash, c.Hash, hash)
+				}
+				_, err = f.WriteAt(data, off)
+				return err
+			}))
+			offset += c.Size
+		}
+	}
+
+	err = eg.Wait()
+	if err != nil {
+		return err
+	}
+
+	log.Infof("finished to restore directory %s from backup generation %s", r.dir, id)
+	return nil
+}
+
+// extract extracts the tar archive read from the reader opened by open to the directory.
+func (r *restorer) extract(ctx context.Context, open func(context.Context) (io.ReadCloser, error)) (err error) {
 	var (
 		pr io.ReadCloser
 		pw io.WriteCloser
@@ -200,7 +336,7 @@ func (r *restorer) restore(ctx context.Context) (err error) {
 	}
 	r.eg.Go(safety.RecoverFunc(func() (err error) {
 		defer pw.Close()
-		sr, err := r.storage.Reader(ctx)
+		sr, err := open(ctx)
 		if err != nil {
 			if errors.Is(err, io.EOF) {
 				return nil
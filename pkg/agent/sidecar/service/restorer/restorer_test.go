@@ -17,6 +17,90 @@
 // Package restorer provides restorer service
 package restorer
 
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+	"github.com/vdaas/vald/pkg/agent/sidecar/service/storage"
+)
+
+// manifestStorage is the storage which stores only the manifest.
+type manifestStorage struct {
+	storage.Storage
+	manifest *storage.Manifest
+}
+
+func (m *manifestStorage) ManifestReader(context.Context) (io.ReadCloser, error) {
+	buf := new(bytes.Buffer)
+	if err := json.Encode(buf, m.manifest); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(buf), nil
+}
+
+func Test_restorer_restoreGeneration(t *testing.T) {
+	t.Parallel()
+	base := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	gens := []*storage.Generation{
+		{ID: "old", Timestamp: base},
+		{ID: "new", Timestamp: base.Add(time.Hour), Valid: true},
+	}
+	tests := []struct {
+		name       string
+		gens       []*storage.Generation
+		generation string
+		before     time.Time
+		want       error
+	}{
+		{
+			name: "no valid generation returns error",
+			gens: gens[:1],
+			want: errors.ErrNoValidBackupGeneration(time.Time{}),
+		},
+		{
+			name:       "no valid generation of the latest returns error",
+			gens:       gens[:1],
+			generation: latestGeneration,
+			want:       errors.ErrNoValidBackupGeneration(time.Time{}),
+		},
+		{
+			name:   "no valid generation before the time returns error",
+			gens:   gens,
+			before: base.Add(time.Minute),
+			want:   errors.ErrNoValidBackupGeneration(base.Add(time.Minute)),
+		},
+		{
+			name:       "unknown generation returns error",
+			gens:       gens,
+			generation: "unknown",
+			want:       errors.ErrBackupGenerationNotFound("unknown"),
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			r := &restorer{
+				dir: tt.TempDir(),
+				storage: &manifestStorage{
+					manifest: &storage.Manifest{Generations: test.gens},
+				},
+				versioning: true,
+				generation: test.generation,
+				before:     test.before,
+			}
+			if err := r.restoreGeneration(context.Background()); !errors.Is(err, test.want) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want)
+			}
+		})
+	}
+}
+
 // NOT IMPLEMENTED BELOW
 //
 // func TestNew(t *testing.T) {
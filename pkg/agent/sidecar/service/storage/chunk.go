@@ -0,0 +1,203 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package storage provides blob storage service
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/bits"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+)
+
+// gear is the table of the random values which the rolling hash adds for each byte.
+var gear = func() (table [256]uint64) {
+	// splitmix64 makes the table deterministic, so that the same content is always split at the same boundaries.
+	x := uint64(0x9e3779b97f4a7c15)
+	for i := range table {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
+
+// Chunker splits a stream into content-defined chunks by the gear rolling hash.
+// The boundaries depend only on the nearby content, so the chunks of the unchanged parts of a file are kept even when the other parts are changed.
+type Chunker struct {
+	r    io.Reader
+	buf  []byte
+	eof  bool
+	min  int
+	max  int
+	mask uint64
+}
+
+// Chunk represents a content-defined chunk of a file.
+type Chunk struct {
+	Hash string `json:"hash"` // hex encoded sha256 of the chunk
+	Size int64  `json:"size"`
+}
+
+// SnapshotFile represents a file of the incremental backup.
+type SnapshotFile struct {
+	Path   string   `json:"path"` // slash separated path relative to the backup directory
+	Mode   uint32   `json:"mode"`
+	Dir    bool     `json:"dir,omitempty"`
+	Size   int64    `json:"size"`
+	Chunks []*Chunk `json:"chunks,omitempty"`
+}
+
+// Snapshot represents the files of the incremental backup generation, which refer to the shared chunks by their hashes.
+type Snapshot struct {
+	Files []*SnapshotFile `json:"files"`
+}
+
+// NewChunker returns the chunker which splits r into the chunks of avg bytes on average.
+// The chunk size is bounded from avg/4 to avg*4.
+func NewChunker(r io.Reader, avg int) *Chunker {
+	avg = 1 << (bits.Len(uint(max(avg, 64))) - 1)
+	return &Chunker{
+		r:    r,
+		buf:  make([]byte, 0, avg*4),
+		min:  avg / 4,
+		max:  avg * 4,
+		mask: ^uint64(0) << (64 - bits.TrailingZeros(uint(avg))),
+	}
+}
+
+// Next returns the next chunk, or io.EOF when all chunks are returned.
+func (c *Chunker) Next() (chunk []byte, err error) {
+	for !c.eof && len(c.buf) < c.max {
+		n, err := c.r.Read(c.buf[len(c.buf):c.max])
+		c.buf = c.buf[:len(c.buf)+n]
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			c.eof = true
+		}
+	}
+	if len(c.buf) == 0 {
+		return nil, io.EOF
+	}
+
+	n := c.cut(c.buf)
+	chunk = make([]byte, n)
+	copy(chunk, c.buf)
+	c.buf = c.buf[:copy(c.buf, c.buf[n:])]
+	return chunk, nil
+}
+
+// cut returns the length of the first chunk of data.
+func (c *Chunker) cut(data []byte) int {
+	if len(data) <= c.min {
+		return len(data)
+	}
+	// the hash depends only on the last 64 bytes, so the bytes before them are skipped.
+	var fp uint64
+	for i := max(c.min-64, 0); i < len(data); i++ {
+		fp = fp<<1 + gear[data[i]]
+		if i+1 >= c.min && fp&c.mask == 0 {
+			return i + 1
+		}
+	}
+	return len(data)
+}
+
+// ChunkHash returns the hex encoded sha256 of the chunk data.
+func ChunkHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadSnapshot loads the snapshot of the incremental backup generation id from s.
+func LoadSnapshot(ctx context.Context, s Storage, id string) (ss *Snapshot, err error) {
+	r, err := s.GenerationReader(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, r.Close())
+	}()
+
+	ss = new(Snapshot)
+	err = json.Decode(r, ss)
+	if err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
+
+// VerifyChunks returns an error when any chunk referred by the snapshot of the incremental backup generation id is not stored in s.
+func VerifyChunks(ctx context.Context, s Storage, id string) error {
+	ss, err := LoadSnapshot(ctx, s, id)
+	if err != nil {
+		return err
+	}
+	hashes, err := s.ListChunks(ctx)
+	if err != nil {
+		return err
+	}
+	stored := make(map[string]struct{}, len(hashes))
+	for _, hash := range hashes {
+		stored[hash] = struct{}{}
+	}
+	var missing []string
+	for _, f := range ss.Files {
+		for _, c := range f.Chunks {
+			if _, ok := stored[c.Hash]; !ok {
+				missing = append(missing, c.Hash)
+			}
+		}
+	}
+	if len(missing) != 0 {
+		return errors.ErrBackupChunkNotFound(id, missing...)
+	}
+	return nil
+}
+
+// UnreferencedChunks returns the hashes of stored which are not referred by any valid incremental generation of m.
+func UnreferencedChunks(ctx context.Context, s Storage, m *Manifest, stored []string) (hashes []string, err error) {
+	refs := make(map[string]struct{}, len(stored))
+	for _, g := range m.Generations {
+		if !g.Valid || !g.Incremental {
+			continue
+		}
+		ss, err := LoadSnapshot(ctx, s, g.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range ss.Files {
+			for _, c := range f.Chunks {
+				refs[c.Hash] = struct{}{}
+			}
+		}
+	}
+	for _, hash := range stored {
+		if _, ok := refs[hash]; !ok {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes, nil
+}
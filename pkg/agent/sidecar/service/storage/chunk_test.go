@@ -0,0 +1,164 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package storage provides blob storage service
+package storage
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+)
+
+func chunks(t *testing.T, data []byte, avg int) (cs [][]byte) {
+	t.Helper()
+	c := NewChunker(bytes.NewReader(data), avg)
+	for {
+		chunk, err := c.Next()
+		if errors.Is(err, io.EOF) {
+			return cs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		cs = append(cs, chunk)
+	}
+}
+
+func TestChunker_Next(t *testing.T) {
+	t.Parallel()
+	const avg = 1024
+	data := make([]byte, 256*avg)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	type test struct {
+		name  string
+		data  []byte
+		check func(*testing.T, [][]byte)
+	}
+	tests := []test{
+		{
+			name: "return nothing when the data is empty",
+			check: func(t *testing.T, cs [][]byte) {
+				if len(cs) != 0 {
+					t.Errorf("got: %d chunks, want: 0 chunks", len(cs))
+				}
+			},
+		},
+		{
+			name: "return the data as a chunk when it is smaller than the minimum size",
+			data: data[:avg/4],
+			check: func(t *testing.T, cs [][]byte) {
+				if len(cs) != 1 || !bytes.Equal(cs[0], data[:avg/4]) {
+					t.Errorf("got: %d chunks, want: the data as a chunk", len(cs))
+				}
+			},
+		},
+		{
+			name: "return the chunks which are bounded and reassembled to the data",
+			data: data,
+			check: func(t *testing.T, cs [][]byte) {
+				for i, c := range cs {
+					if len(c) > avg*4 || (len(c) < avg/4 && i != len(cs)-1) {
+						t.Errorf("chunk %d size %d is out of bounds", i, len(c))
+					}
+				}
+				if got := bytes.Join(cs, nil); !bytes.Equal(got, data) {
+					t.Error("reassembled chunks are not equal to the data")
+				}
+			},
+		},
+		{
+			name: "return the same chunks after the inserted bytes",
+			data: append([]byte("inserted"), data...),
+			check: func(t *testing.T, cs [][]byte) {
+				orig := make(map[string]struct{})
+				for _, c := range chunks(t, data, avg) {
+					orig[ChunkHash(c)] = struct{}{}
+				}
+				var shared int
+				for _, c := range cs {
+					if _, ok := orig[ChunkHash(c)]; ok {
+						shared++
+					}
+				}
+				if shared < len(cs)-2 {
+					t.Errorf("got: %d shared chunks, want: at least %d shared chunks", shared, len(cs)-2)
+				}
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			test.check(tt, chunks(tt, test.data, avg))
+		})
+	}
+}
+
+func TestVerifyChunks(t *testing.T) {
+	t.Parallel()
+	stored := []byte("stored chunk")
+	missing := []byte("missing chunk")
+	tests := []struct {
+		name   string
+		chunks [][]byte
+		want   error
+	}{
+		{
+			name:   "return nil when every chunk is stored",
+			chunks: [][]byte{stored},
+		},
+		{
+			name:   "return error when any chunk is not stored",
+			chunks: [][]byte{stored, missing},
+			want:   errors.ErrBackupChunkNotFound("20261018T000000Z", ChunkHash(missing)),
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := t.Context()
+			s := newLocalStorage(t, t.TempDir())
+			if err := s.PutChunk(ctx, ChunkHash(stored), stored); err != nil {
+				t.Fatal(err)
+			}
+			sf := &SnapshotFile{Path: "ngt-meta.kvsdb"}
+			for _, c := range test.chunks {
+				sf.Chunks = append(sf.Chunks, &Chunk{Hash: ChunkHash(c), Size: int64(len(c))})
+			}
+			w, err := s.GenerationWriter(ctx, "20261018T000000Z")
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = errors.Join(json.Encode(w, &Snapshot{Files: []*SnapshotFile{sf}}), w.Close())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = VerifyChunks(ctx, s, "20261018T000000Z")
+			if !errors.Is(err, test.want) {
+				t.Errorf("got error: %v, want: %v", err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package storage provides blob storage service
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	iblob "github.com/vdaas/vald/internal/db/storage/blob"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/fileblob"
+	"gocloud.dev/gcerrors"
+)
+
+// localBucket is the blob.Bucket implementation which stores the objects in the local directory.
+type localBucket struct {
+	dir    string
+	bucket *blob.Bucket
+}
+
+func (l *localBucket) Open(context.Context) (err error) {
+	l.bucket, err = fileblob.OpenBucket(l.dir, nil)
+	return err
+}
+
+func (l *localBucket) Close() error {
+	return l.bucket.Close()
+}
+
+func (l *localBucket) Reader(ctx context.Context, key string) (io.ReadCloser, error) {
+	r, err := l.bucket.NewReader(ctx, key, nil)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return io.NopCloser(io.NewEOFReader()), nil
+	}
+	return r, err
+}
+
+func (l *localBucket) Writer(ctx context.Context, key string) (io.WriteCloser, error) {
+	return l.bucket.NewWriter(ctx, key, nil)
+}
+
+func (l *localBucket) Delete(ctx context.Context, key string) error {
+	return l.bucket.Delete(ctx, key)
+}
+
+func (l *localBucket) List(ctx context.Context, prefix string) (keys []string, err error) {
+	it := l.bucket.List(&blob.ListOptions{Prefix: prefix})
+	for {
+		obj, err := it.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return keys, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, obj.Key)
+	}
+}
+
+var _ iblob.Bucket = (*localBucket)(nil)
+
+// newLocalStorage returns the storage started with the local bucket of dir.
+func newLocalStorage(t *testing.T, dir string, opts ...Option) Storage {
+	t.Helper()
+	s, err := New(append([]Option{WithFilename("vald-agent-0")}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := s.(*bs)
+	b.bucket = &localBucket{dir: dir}
+	err = b.bucket.Open(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = b.initBuckets(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		b.Stop(context.Background())
+	})
+	return s
+}
+
+func writeKeyFile(t *testing.T, path, content string) string {
+	t.Helper()
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func archive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for name, body := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o600,
+			Size:     int64(len(body)),
+			Typeflag: tar.TypeReg,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write([]byte(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := tw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func readAll(r io.ReadCloser, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(r)
+	return b, errors.Join(err, r.Close())
+}
+
+func TestEncryptedRoundTrip(t *testing.T) {
+	t.Parallel()
+	const (
+		oldKeys     = `{"active": "old", "keys": {"old": "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="}}`
+		rotatedKeys = `{"active": "new", "keys": {"old": "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=", "new": "AgICAgICAgICAgICAgICAg=="}}`
+		newKeys     = `{"active": "new", "keys": {"new": "AgICAgICAgICAgICAgICAg=="}}`
+		embedding   = "raw embeddings of the index"
+	)
+	data := archive(t, map[string]string{
+		"ngt-meta.kvsdb": embedding,
+		"obj":            embedding,
+	})
+
+	type test struct {
+		name   string
+		writer string
+		reader string
+		want   error
+	}
+	tests := []test{
+		{
+			name:   "read the archive encrypted by the same key",
+			writer: oldKeys,
+			reader: oldKeys,
+		},
+		{
+			name:   "read the archive encrypted by the old key after the key rotation",
+			writer: oldKeys,
+			reader: rotatedKeys,
+		},
+		{
+			name:   "read the archive encrypted by the active key after the key rotation",
+			writer: rotatedKeys,
+			reader: rotatedKeys,
+		},
+		{
+			name:   "return an error when the key of the archive is removed",
+			writer: oldKeys,
+			reader: newKeys,
+			want:   errors.ErrEncryptionKeyNotFound("old"),
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			ctx := tt.Context()
+			dir := tt.TempDir()
+			bucket := filepath.Join(dir, "bucket")
+			err := os.Mkdir(bucket, 0o700)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			ws := newLocalStorage(tt, bucket,
+				WithEncryption(true),
+				WithEncryptionKeyFile(writeKeyFile(tt, filepath.Join(dir, "writer.json"), test.writer)),
+			)
+			w, err := ws.Writer(ctx)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			_, err = w.Write(data)
+			err = errors.Join(err, w.Close())
+			if err != nil {
+				tt.Fatal(err)
+			}
+			err = ws.PutChunk(ctx, ChunkHash(data), data)
+			if err != nil {
+				tt.Fatal(err)
+			}
+
+			for _, name := range []string{"vald-agent-0.tar.gz", filepath.Join("vald-agent-0", chunkDirname, ChunkHash(data))} {
+				raw, err := os.ReadFile(filepath.Join(bucket, name))
+				if err != nil {
+					tt.Fatal(err)
+				}
+				if bytes.Contains(raw, []byte(embedding)) {
+					tt.Errorf("%s stored in the bucket contains the raw data", name)
+				}
+			}
+
+			rs := newLocalStorage(tt, bucket,
+				WithEncryption(true),
+				WithEncryptionKeyFile(writeKeyFile(tt, filepath.Join(dir, "reader.json"), test.reader)),
+			)
+			got, err := readAll(rs.Reader(ctx))
+			if !errors.Is(err, test.want) {
+				tt.Fatalf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want)
+			}
+			if test.want == nil && !bytes.Equal(got, data) {
+				tt.Error("the archive read from the bucket is not equal to the written one")
+			}
+
+			chunk, err := rs.GetChunk(ctx, ChunkHash(data))
+			if !errors.Is(err, test.want) {
+				tt.Fatalf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want)
+			}
+			if test.want == nil && !bytes.Equal(chunk, data) {
+				tt.Error("the chunk read from the bucket is not equal to the written one")
+			}
+
+			_, err = rs.GenerationReader(ctx, "missing")
+			if !errors.Is(err, io.EOF) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, io.EOF)
+			}
+			_, err = rs.GetChunk(ctx, ChunkHash([]byte("missing")))
+			if !errors.Is(err, errors.ErrEmptyEncryptedData) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, errors.ErrEmptyEncryptedData)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package storage provides blob storage service
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/io"
+)
+
+const (
+	manifestFilename = "manifest.json"
+	chunkDirname     = "chunks"
+
+	// generationIDFormat is the time layout of the generation ID, which sorts the IDs in time order.
+	generationIDFormat = "20060102T150405.000000000Z"
+)
+
+// Generation represents a timestamped backup listed in the manifest.
+type Generation struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Bytes     int64     `json:"bytes"`
+	Checksum  string    `json:"checksum"` // hex encoded sha256 of the uncompressed archive
+	Valid     bool      `json:"valid"`    // true after the uploaded archive is verified by the checksum
+
+	Incremental bool `json:"incremental,omitempty"` // true when the archive is the snapshot of an incremental backup
+}
+
+// Manifest represents the backup generations ordered from the oldest.
+type Manifest struct {
+	Generations []*Generation `json:"generations"`
+}
+
+// Retention represents the retention policy of the backup generations.
+// All valid generations are kept when none of the fields is positive.
+type Retention struct {
+	KeepLast   int // number of the latest valid generations to keep
+	KeepDaily  int // number of the days whose latest valid generation is kept
+	KeepWeekly int // number of the weeks whose latest valid generation is kept
+}
+
+// NewGeneration returns the invalid generation taken at t.
+func NewGeneration(t time.Time) *Generation {
+	t = t.UTC()
+	return &Generation{
+		ID:        t.Format(generationIDFormat),
+		Timestamp: t,
+	}
+}
+
+// LoadManifest loads the manifest from s, and returns the empty manifest if it is not stored yet.
+func LoadManifest(ctx context.Context, s Storage) (m *Manifest, err error) {
+	r, err := s.ManifestReader(ctx)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.IsErrBlobNoSuchKey(err) {
+			return new(Manifest), nil
+		}
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, r.Close())
+	}()
+
+	m = new(Manifest)
+	err = json.Decode(r, m)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return new(Manifest), nil
+		}
+		return nil, err
+	}
+	m.sort()
+	return m, nil
+}
+
+// SaveManifest saves the manifest to s.
+func SaveManifest(ctx context.Context, s Storage, m *Manifest) (err error) {
+	w, err := s.ManifestWriter(ctx)
+	if err != nil {
+		return err
+	}
+	err = json.Encode(w, m)
+	return errors.Join(err, w.Close())
+}
+
+// Checksum returns the hex encoded sha256 of r and the number of bytes read.
+func Checksum(r io.Reader) (sum string, n int64, err error) {
+	h := sha256.New()
+	n, err = io.Copy(h, r)
+	if err != nil {
+		return "", n, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// VerifyGeneration reads the archive of g from s and verifies it by the checksum of the manifest.
+func VerifyGeneration(ctx context.Context, s Storage, g *Generation) (err error) {
+	r, err := s.GenerationReader(ctx, g.ID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, r.Close())
+	}()
+
+	sum, _, err := Checksum(r)
+	if err != nil {
+		return err
+	}
+	if sum != g.Checksum {
+		return errors.ErrBackupChecksumMismatch(g.ID, g.Checksum, sum)
+	}
+	if g.Incremental {
+		return VerifyChunks(ctx, s, g.ID)
+	}
+	return nil
+}
+
+// Put adds g to the manifest, or replaces the generation of the same ID.
+func (m *Manifest) Put(g *Generation) {
+	for i, gen := range m.Generations {
+		if gen.ID == g.ID {
+			m.Generations[i] = g
+			return
+		}
+	}
+	m.Generations = append(m.Generations, g)
+	m.sort()
+}
+
+// Get returns the generation of id.
+func (m *Manifest) Get(id string) (*Generation, bool) {
+	for _, g := range m.Generations {
+		if g.ID == id {
+			return g, true
+		}
+	}
+	return nil, false
+}
+
+// Remove removes the generation of id from the manifest.
+func (m *Manifest) Remove(id string) {
+	m.Generations = slices.DeleteFunc(m.Generations, func(g *Generation) bool {
+		return g.ID == id
+	})
+}
+
+// Valid returns the valid generations taken before t from the newest. All valid generations are returned when t is zero.
+func (m *Manifest) Valid(t time.Time) (gens []*Generation) {
+	for i := len(m.Generations) - 1; i >= 0; i-- {
+		g := m.Generations[i]
+		if g.Valid && (t.IsZero() || g.Timestamp.Before(t)) {
+			gens = append(gens, g)
+		}
+	}
+	return gens
+}
+
+// Expired returns the generations which are not kept by the retention policy r. The invalid generations are always expired.
+func (m *Manifest) Expired(r Retention) (gens []*Generation) {
+	valid := m.Valid(time.Time{})
+	keep := make(map[string]bool, len(valid))
+	if r.KeepLast <= 0 && r.KeepDaily <= 0 && r.KeepWeekly <= 0 {
+		for _, g := range valid {
+			keep[g.ID] = true
+		}
+	}
+	for i, g := range valid {
+		if i >= r.KeepLast {
+			break
+		}
+		keep[g.ID] = true
+	}
+	keepPeriods(valid, r.KeepDaily, keep, func(t time.Time) string {
+		return t.Format(time.DateOnly)
+	})
+	keepPeriods(valid, r.KeepWeekly, keep, func(t time.Time) string {
+		y, w := t.ISOWeek()
+		return strconv.Itoa(y) + "-" + strconv.Itoa(w)
+	})
+	for _, g := range m.Generations {
+		if !keep[g.ID] {
+			gens = append(gens, g)
+		}
+	}
+	return gens
+}
+
+// keepPeriods keeps the latest generation of each of the latest n periods. gens must be ordered from the newest.
+func keepPeriods(gens []*Generation, n int, keep map[string]bool, period func(time.Time) string) {
+	seen := make(map[string]struct{}, n)
+	for _, g := range gens {
+		if len(seen) >= n {
+			return
+		}
+		p := period(g.Timestamp.UTC())
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		keep[g.ID] = true
+	}
+}
+
+func (m *Manifest) sort() {
+	slices.SortStableFunc(m.Generations, func(a, b *Generation) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+}
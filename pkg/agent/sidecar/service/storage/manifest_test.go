@@ -0,0 +1,195 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package storage provides blob storage service
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+func TestManifest_Valid(t *testing.T) {
+	t.Parallel()
+	base := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	gen := func(h int, valid bool) *Generation {
+		g := NewGeneration(base.Add(time.Duration(h) * time.Hour))
+		g.Valid = valid
+		return g
+	}
+	g0, g1, g2, g3 := gen(0, true), gen(1, false), gen(2, true), gen(3, true)
+	type test struct {
+		name   string
+		before time.Time
+		want   []*Generation
+	}
+	tests := []test{
+		{
+			name: "return all valid generations from the newest when the time is zero",
+			want: []*Generation{g3, g2, g0},
+		},
+		{
+			name:   "return the valid generations taken before the time",
+			before: base.Add(2 * time.Hour),
+			want:   []*Generation{g0},
+		},
+		{
+			name:   "return nothing when no valid generation is taken before the time",
+			before: base,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			m := new(Manifest)
+			for _, g := range []*Generation{g3, g1, g0, g2} {
+				m.Put(g)
+			}
+			if got := m.Valid(test.before); !reflect.DeepEqual(got, test.want) {
+				tt.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", got, test.want)
+			}
+		})
+	}
+}
+
+func TestManifest_Expired(t *testing.T) {
+	t.Parallel()
+	// 2024-01-08 is monday
+	base := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	gen := func(d time.Duration, valid bool) *Generation {
+		g := NewGeneration(base.Add(d))
+		g.Valid = valid
+		return g
+	}
+	day := 24 * time.Hour
+	var (
+		w0d0a = gen(0, true)
+		w0d0b = gen(time.Hour, true)
+		w0d1  = gen(day, true)
+		w1d0  = gen(7*day, true)
+		w1d1a = gen(8*day, true)
+		w1d1b = gen(8*day+time.Hour, false)
+		w1d2  = gen(9*day, true)
+	)
+	gens := []*Generation{w0d0a, w0d0b, w0d1, w1d0, w1d1a, w1d1b, w1d2}
+	ids := func(gens []*Generation) (ids []string) {
+		for _, g := range gens {
+			ids = append(ids, g.ID)
+		}
+		return ids
+	}
+	type test struct {
+		name      string
+		retention Retention
+		want      []*Generation
+	}
+	tests := []test{
+		{
+			name: "expire only the invalid generations when no retention is set",
+			want: []*Generation{w1d1b},
+		},
+		{
+			name: "keep the latest generations",
+			retention: Retention{
+				KeepLast: 2,
+			},
+			want: []*Generation{w0d0a, w0d0b, w0d1, w1d0, w1d1b},
+		},
+		{
+			name: "keep the latest generation of each day",
+			retention: Retention{
+				KeepDaily: 4,
+			},
+			want: []*Generation{w0d0a, w0d0b, w1d1b},
+		},
+		{
+			name: "keep the latest generation of each week",
+			retention: Retention{
+				KeepWeekly: 2,
+			},
+			want: []*Generation{w0d0a, w0d0b, w1d0, w1d1a, w1d1b},
+		},
+		{
+			name: "keep the union of the retention policies",
+			retention: Retention{
+				KeepLast:   1,
+				KeepDaily:  2,
+				KeepWeekly: 2,
+			},
+			want: []*Generation{w0d0a, w0d0b, w1d0, w1d1b},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			m := &Manifest{
+				Generations: gens,
+			}
+			if got, want := ids(m.Expired(test.retention)), ids(test.want); !reflect.DeepEqual(got, want) {
+				tt.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", got, want)
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		sum string
+		n   int64
+		err error
+	}
+	type test struct {
+		name string
+		data string
+		want want
+	}
+	tests := []test{
+		{
+			name: "return the sha256 of the data",
+			data: "vald",
+			want: want{
+				sum: "373cdf938a76db0e67bd9e7e02f98fde020341bde4932df2b5295d835f023429",
+				n:   4,
+			},
+		},
+		{
+			name: "return the sha256 of the empty data",
+			want: want{
+				sum: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			sum, n, err := Checksum(strings.NewReader(test.data))
+			if !errors.Is(err, test.want.err) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want.err)
+			}
+			if sum != test.want.sum || n != test.want.n {
+				tt.Errorf("got: %s, %d,\n\t\t\t\twant: %s, %d", sum, n, test.want.sum, test.want.n)
+			}
+		})
+	}
+}
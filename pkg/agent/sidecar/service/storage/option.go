@@ -32,6 +32,7 @@ var defaultOptions = []Option{
 	WithCompressAlgorithm("gzip"),
 	WithCompressionLevel(-1),
 	WithFilenameSuffix(".tar.gz"),
+	WithConcurrency(1),
 }
 
 func WithErrGroup(eg errgroup.Group) Option {
@@ -132,3 +133,26 @@ func WithCompressionLevel(level int) Option {
 		return nil
 	}
 }
+
+func WithEncryption(enabled bool) Option {
+	return func(b *bs) error {
+		b.encryptionEnabled = enabled
+		return nil
+	}
+}
+
+func WithEncryptionKeyFile(path string) Option {
+	return func(b *bs) error {
+		b.encryptionKeyFile = path
+		return nil
+	}
+}
+
+func WithConcurrency(c int) Option {
+	return func(b *bs) error {
+		if c > 0 {
+			b.concurrency = c
+		}
+		return nil
+	}
+}
@@ -18,6 +18,7 @@
 package storage
 
 import (
+	"bufio"
 	"context"
 	"reflect"
 
@@ -28,8 +29,10 @@ import (
 	"github.com/vdaas/vald/internal/db/storage/blob/cloudstorage/urlopener"
 	"github.com/vdaas/vald/internal/db/storage/blob/s3"
 	"github.com/vdaas/vald/internal/db/storage/blob/s3/session"
+	"github.com/vdaas/vald/internal/encrypt"
 	"github.com/vdaas/vald/internal/errors"
 	"github.com/vdaas/vald/internal/io"
+	"github.com/vdaas/vald/internal/strings"
 	"github.com/vdaas/vald/internal/sync/errgroup"
 )
 
@@ -38,6 +41,15 @@ type Storage interface {
 	Stop(ctx context.Context) error
 	Reader(ctx context.Context) (io.ReadCloser, error)
 	Writer(ctx context.Context) (io.WriteCloser, error)
+	GenerationReader(ctx context.Context, id string) (io.ReadCloser, error)
+	GenerationWriter(ctx context.Context, id string) (io.WriteCloser, error)
+	DeleteGeneration(ctx context.Context, id string) error
+	ManifestReader(ctx context.Context) (io.ReadCloser, error)
+	ManifestWriter(ctx context.Context) (io.WriteCloser, error)
+	GetChunk(ctx context.Context, hash string) ([]byte, error)
+	PutChunk(ctx context.Context, hash string, data []byte) error
+	DeleteChunk(ctx context.Context, hash string) error
+	ListChunks(ctx context.Context) ([]string, error)
 	StorageInfo() *StorageInfo
 }
 
@@ -57,8 +69,16 @@ type bs struct {
 	compressAlgorithm string
 	compressionLevel  int
 
+	encryptionEnabled bool
+	encryptionKeyFile string
+
+	// concurrency is the number of the buckets which transfer the chunks concurrently.
+	concurrency int
+
 	bucket     blob.Bucket
+	buckets    chan blob.Bucket
 	compressor compress.Compressor
+	encryptor  encrypt.Encryptor
 }
 
 func New(opts ...Option) (Storage, error) {
@@ -74,6 +94,11 @@ func New(opts ...Option) (Storage, error) {
 		return nil, err
 	}
 
+	err = b.initEncryptor()
+	if err != nil {
+		return nil, err
+	}
+
 	return b, nil
 }
 
@@ -105,15 +130,28 @@ func (b *bs) initCompressor() (err error) {
 	return err
 }
 
-func (b *bs) initBucket(ctx context.Context) (err error) {
+func (b *bs) initEncryptor() (err error) {
+	// Without encryption
+	if !b.encryptionEnabled {
+		return nil
+	}
+
+	b.encryptor, err = encrypt.NewAESGCM(
+		encrypt.WithAESGCMKeyFile(b.encryptionKeyFile),
+	)
+
+	return err
+}
+
+func (b *bs) initBucket(ctx context.Context) (bucket blob.Bucket, err error) {
 	switch config.AtoBST(b.storageType) {
 	case config.S3:
 		s, err := session.New(b.s3SessionOpts...).Session()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		b.bucket, err = s3.New(
+		bucket, err = s3.New(
 			append(
 				b.s3Opts,
 				s3.WithErrGroup(b.eg),
@@ -122,30 +160,56 @@ func (b *bs) initBucket(ctx context.Context) (err error) {
 			)...,
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	case config.CloudStorage:
 		uoi, err := urlopener.New(b.cloudStorageURLOpenerOpts...)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		uo, err := uoi.URLOpener(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		b.bucket, err = cloudstorage.New(
+		bucket, err = cloudstorage.New(
 			append(
 				b.cloudStorageOpts,
 				cloudstorage.WithURLOpener(uo),
 			)...,
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	default:
-		return errors.ErrInvalidStorageType
+		return nil, errors.ErrInvalidStorageType
+	}
+
+	err = bucket.Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return bucket, nil
+}
+
+// initBuckets initializes the buckets which transfer the chunks.
+// The bucket clients are not safe for concurrent use, so each of the concurrent transfers borrows its own bucket.
+func (b *bs) initBuckets(ctx context.Context) error {
+	if b.concurrency <= 1 {
+		b.buckets = make(chan blob.Bucket, 1)
+		b.buckets <- b.bucket
+		return nil
+	}
+
+	b.buckets = make(chan blob.Bucket, b.concurrency)
+	for i := 0; i < b.concurrency; i++ {
+		bucket, err := b.initBucket(ctx)
+		if err != nil {
+			return err
+		}
+		b.buckets <- bucket
 	}
 
 	return nil
@@ -154,53 +218,235 @@ func (b *bs) initBucket(ctx context.Context) (err error) {
 func (b *bs) Start(ctx context.Context) (<-chan error, error) {
 	ech := make(chan error, 1)
 
-	err := b.initBucket(ctx)
+	bucket, err := b.initBucket(ctx)
 	if err != nil {
 		return nil, err
 	}
+	b.bucket = bucket
 
-	err = b.bucket.Open(ctx)
+	err = b.initBuckets(ctx)
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(err, b.Stop(ctx))
 	}
 
 	return ech, nil
 }
 
-func (b *bs) Stop(context.Context) error {
+func (b *bs) Stop(context.Context) (err error) {
+	if b.buckets != nil && b.concurrency > 1 {
+		close(b.buckets)
+		for bucket := range b.buckets {
+			err = errors.Join(err, bucket.Close())
+		}
+		b.buckets = nil
+	}
 	if b.bucket != nil {
-		return b.bucket.Close()
+		return errors.Join(err, b.bucket.Close())
 	}
-	return nil
+	return err
 }
 
 func (b *bs) Reader(ctx context.Context) (r io.ReadCloser, err error) {
-	r, err = b.bucket.Reader(ctx, b.filename+b.suffix)
+	return b.reader(ctx, b.filename+b.suffix)
+}
+
+func (b *bs) Writer(ctx context.Context) (w io.WriteCloser, err error) {
+	return b.writer(ctx, b.filename+b.suffix)
+}
+
+// GenerationReader returns the reader of the backup generation id.
+func (b *bs) GenerationReader(ctx context.Context, id string) (r io.ReadCloser, err error) {
+	return b.reader(ctx, b.generationKey(id))
+}
+
+// GenerationWriter returns the writer of the backup generation id.
+func (b *bs) GenerationWriter(ctx context.Context, id string) (w io.WriteCloser, err error) {
+	return b.writer(ctx, b.generationKey(id))
+}
+
+// DeleteGeneration deletes the backup generation id.
+func (b *bs) DeleteGeneration(ctx context.Context, id string) error {
+	return b.bucket.Delete(ctx, b.generationKey(id))
+}
+
+// ManifestReader returns the reader of the manifest, which is stored without compression and encryption.
+func (b *bs) ManifestReader(ctx context.Context) (io.ReadCloser, error) {
+	return b.bucket.Reader(ctx, b.filename+"/"+manifestFilename)
+}
+
+// ManifestWriter returns the writer of the manifest, which is stored without compression and encryption.
+func (b *bs) ManifestWriter(ctx context.Context) (io.WriteCloser, error) {
+	return b.bucket.Writer(ctx, b.filename+"/"+manifestFilename)
+}
+
+// GetChunk returns the data of the chunk hash.
+func (b *bs) GetChunk(ctx context.Context, hash string) (data []byte, err error) {
+	bucket, err := b.acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer b.release(bucket)
 
-	if b.compressor != nil {
-		r, err = b.compressor.Reader(r)
+	r, err := bucket.Reader(ctx, b.chunkKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	r, err = b.decode(r)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, r.Close())
+	}()
+
+	return io.ReadAll(r)
+}
+
+// PutChunk stores data as the chunk hash.
+func (b *bs) PutChunk(ctx context.Context, hash string, data []byte) (err error) {
+	bucket, err := b.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer b.release(bucket)
+
+	w, err := bucket.Writer(ctx, b.chunkKey(hash))
+	if err != nil {
+		return err
+	}
+	w, err = b.encode(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return errors.Join(err, w.Close())
+}
+
+// DeleteChunk deletes the chunk hash.
+func (b *bs) DeleteChunk(ctx context.Context, hash string) error {
+	bucket, err := b.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer b.release(bucket)
+
+	return bucket.Delete(ctx, b.chunkKey(hash))
+}
+
+// ListChunks returns the hashes of the stored chunks.
+func (b *bs) ListChunks(ctx context.Context) ([]string, error) {
+	prefix := b.chunkKey("")
+	keys, err := b.bucket.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if hash := strings.TrimPrefix(key, prefix); hash != "" {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return hashes, nil
+}
+
+// acquire borrows a bucket to transfer a chunk, and blocks until any bucket is released.
+func (b *bs) acquire(ctx context.Context) (blob.Bucket, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case bucket := <-b.buckets:
+		return bucket, nil
+	}
+}
+
+func (b *bs) release(bucket blob.Bucket) {
+	b.buckets <- bucket
+}
+
+// chunkKey returns the key of the chunk hash, which is shared by all generations under the filename prefix.
+func (b *bs) chunkKey(hash string) string {
+	return b.filename + "/" + chunkDirname + "/" + hash
+}
+
+// generationKey returns the key of the backup generation id, which is stored under the filename prefix.
+func (b *bs) generationKey(id string) string {
+	return b.filename + "/" + id + b.suffix
+}
+
+func (b *bs) reader(ctx context.Context, key string) (r io.ReadCloser, err error) {
+	r, err = b.bucket.Reader(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.encryptor != nil {
+		// the bucket returns the empty object when the key is not stored,
+		// which is reported as io.EOF since the encryptor rejects the empty data.
+		br := bufio.NewReader(r)
+		_, err = br.Peek(1)
 		if err != nil {
-			return nil, err
+			return nil, errors.Join(err, r.Close())
 		}
+		r = &readCloser{Reader: br, Closer: r}
 	}
 
-	return r, nil
+	return b.decode(r)
 }
 
-func (b *bs) Writer(ctx context.Context) (w io.WriteCloser, err error) {
-	w, err = b.bucket.Writer(ctx, b.filename+b.suffix)
+func (b *bs) writer(ctx context.Context, key string) (w io.WriteCloser, err error) {
+	w, err = b.bucket.Writer(ctx, key)
 	if err != nil {
 		return nil, err
 	}
 
+	return b.encode(w)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// decode wraps r to decrypt and then decompress the stored data.
+func (b *bs) decode(r io.ReadCloser) (io.ReadCloser, error) {
+	if b.encryptor != nil {
+		dr, err := b.encryptor.Reader(r)
+		if err != nil {
+			return nil, errors.Join(err, r.Close())
+		}
+		r = dr
+	}
+
 	if b.compressor != nil {
-		w, err = b.compressor.Writer(w)
+		cr, err := b.compressor.Reader(r)
 		if err != nil {
-			return nil, err
+			return nil, errors.Join(err, r.Close())
+		}
+		r = cr
+	}
+
+	return r, nil
+}
+
+// encode wraps w to compress and then encrypt the data to store.
+func (b *bs) encode(w io.WriteCloser) (io.WriteCloser, error) {
+	if b.encryptor != nil {
+		ew, err := b.encryptor.Writer(w)
+		if err != nil {
+			return nil, errors.Join(err, w.Close())
+		}
+		w = ew
+	}
+
+	if b.compressor != nil {
+		cw, err := b.compressor.Writer(w)
+		if err != nil {
+			return nil, errors.Join(err, w.Close())
 		}
+		w = cw
 	}
 
 	return w, nil
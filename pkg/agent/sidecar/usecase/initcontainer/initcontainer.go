@@ -138,6 +138,9 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		),
 		storage.WithCompressAlgorithm(cfg.AgentSidecar.Compress.CompressAlgorithm),
 		storage.WithCompressionLevel(cfg.AgentSidecar.Compress.CompressionLevel),
+		storage.WithConcurrency(cfg.AgentSidecar.Versioning.Concurrency),
+		storage.WithEncryption(cfg.AgentSidecar.Encryption.Enabled),
+		storage.WithEncryptionKeyFile(cfg.AgentSidecar.Encryption.KeyFile),
 	)
 	if err != nil {
 		return nil, err
@@ -149,6 +152,10 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		restorer.WithBlobStorage(bs),
 		restorer.WithBackoff(cfg.AgentSidecar.RestoreBackoffEnabled),
 		restorer.WithBackoffOpts(cfg.AgentSidecar.RestoreBackoff.Opts()...),
+		restorer.WithVersioning(cfg.AgentSidecar.Versioning.Enabled),
+		restorer.WithRestoreGeneration(cfg.AgentSidecar.Versioning.RestoreGeneration),
+		restorer.WithRestoreBefore(cfg.AgentSidecar.Versioning.RestoreBefore),
+		restorer.WithConcurrency(cfg.AgentSidecar.Versioning.Concurrency),
 	)
 	if err != nil {
 		return nil, err
@@ -138,6 +138,9 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		),
 		storage.WithCompressAlgorithm(cfg.AgentSidecar.Compress.CompressAlgorithm),
 		storage.WithCompressionLevel(cfg.AgentSidecar.Compress.CompressionLevel),
+		storage.WithConcurrency(cfg.AgentSidecar.Versioning.Concurrency),
+		storage.WithEncryption(cfg.AgentSidecar.Encryption.Enabled),
+		storage.WithEncryptionKeyFile(cfg.AgentSidecar.Encryption.KeyFile),
 	)
 	if err != nil {
 		return nil, err
@@ -151,6 +154,15 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		observer.WithPostStopTimeout(cfg.AgentSidecar.PostStopTimeout),
 		observer.WithDir(cfg.AgentSidecar.WatchDir),
 		observer.WithBlobStorage(bs),
+		observer.WithVersioning(cfg.AgentSidecar.Versioning.Enabled),
+		observer.WithRetention(
+			cfg.AgentSidecar.Versioning.KeepLast,
+			cfg.AgentSidecar.Versioning.KeepDaily,
+			cfg.AgentSidecar.Versioning.KeepWeekly,
+		),
+		observer.WithIncremental(cfg.AgentSidecar.Versioning.Incremental),
+		observer.WithChunkSize(cfg.AgentSidecar.Versioning.ChunkSize),
+		observer.WithConcurrency(cfg.AgentSidecar.Versioning.Concurrency),
 	}
 
 	var metricsHook metrics.MetricsHook
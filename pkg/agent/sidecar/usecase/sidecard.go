@@ -20,6 +20,7 @@ import (
 	"github.com/vdaas/vald/internal/runner"
 	"github.com/vdaas/vald/pkg/agent/sidecar/config"
 	"github.com/vdaas/vald/pkg/agent/sidecar/usecase/initcontainer"
+	"github.com/vdaas/vald/pkg/agent/sidecar/usecase/readreplica"
 	"github.com/vdaas/vald/pkg/agent/sidecar/usecase/sidecar"
 )
 
@@ -27,6 +28,8 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 	switch config.SidecarMode(cfg.AgentSidecar.Mode) {
 	case config.INITCONTAINER:
 		return initcontainer.New(cfg)
+	case config.READREPLICA:
+		return readreplica.New(cfg)
 	case config.SIDECAR:
 	default:
 	}
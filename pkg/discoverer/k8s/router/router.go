@@ -55,6 +55,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/",
+				RPC:         "/",
 				HandlerFunc: h.Index,
 			},
 			{
@@ -63,6 +64,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/pods",
+				RPC:         "/discoverer.v1.Discoverer/Pods",
 				HandlerFunc: h.Pods,
 			},
 			{
@@ -71,6 +73,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/nodes",
+				RPC:         "/discoverer.v1.Discoverer/Nodes",
 				HandlerFunc: h.Nodes,
 			},
 		}...))
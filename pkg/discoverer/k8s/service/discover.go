@@ -18,7 +18,6 @@
 package service
 
 import (
-	"cmp"
 	"context"
 	"reflect"
 	"slices"
@@ -36,6 +35,7 @@ import (
 	"github.com/vdaas/vald/internal/k8s/service"
 	"github.com/vdaas/vald/internal/log"
 	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/net/grpc"
 	"github.com/vdaas/vald/internal/safety"
 	"github.com/vdaas/vald/internal/sync"
 	"github.com/vdaas/vald/internal/sync/errgroup"
@@ -46,26 +46,37 @@ type Discoverer interface {
 	GetPods(*payload.Discoverer_Request) (*payload.Info_Pods, error)
 	GetNodes(*payload.Discoverer_Request) (*payload.Info_Nodes, error)
 	GetServices(*payload.Discoverer_Request) (*payload.Info_Services, error)
+	RangeRanks(f func(r Rank) bool)
 }
 
 type discoverer struct {
-	maxPods         int
-	nodes           sync.Map[string, *node.Node]
-	nodeMetrics     sync.Map[string, mnode.Node]
-	pods            sync.Map[string, *[]pod.Pod]
-	podMetrics      sync.Map[string, mpod.Pod]
-	services        sync.Map[string, *service.Service]
-	podsByNode      atomic.Pointer[map[string]map[string]map[string][]*payload.Info_Pod]
-	podsByNamespace atomic.Pointer[map[string]map[string][]*payload.Info_Pod]
-	podsByName      atomic.Pointer[map[string][]*payload.Info_Pod]
-	nodeByName      atomic.Pointer[map[string]*payload.Info_Node]
-	svcsByName      atomic.Pointer[map[string]*payload.Info_Service]
-	ctrl            k8s.Controller
-	namespace       string
-	name            string
-	csd             time.Duration
-	der             net.Dialer
-	eg              errgroup.Group
+	maxPods          int
+	nodes            sync.Map[string, *node.Node]
+	nodeMetrics      sync.Map[string, mnode.Node]
+	pods             sync.Map[string, *[]pod.Pod]
+	podMetrics       sync.Map[string, mpod.Pod]
+	services         sync.Map[string, *service.Service]
+	podsByNode       atomic.Pointer[map[string]map[string]map[string][]*payload.Info_Pod]
+	podsByNamespace  atomic.Pointer[map[string]map[string][]*payload.Info_Pod]
+	podsByName       atomic.Pointer[map[string][]*payload.Info_Pod]
+	nodeByName       atomic.Pointer[map[string]*payload.Info_Node]
+	svcsByName       atomic.Pointer[map[string]*payload.Info_Service]
+	ctrl             k8s.Controller
+	namespace        string
+	name             string
+	csd              time.Duration
+	der              net.Dialer
+	eg               errgroup.Group
+	zoneLabel        string
+	rackLabel        string
+	hostnameLabel    string
+	ranker           Ranker
+	client           grpc.Client
+	agentPort        int
+	probeTimeout     time.Duration
+	probeConcurrency int
+	scores           atomic.Pointer[scores]
+	ranks            atomic.Pointer[[]Rank]
 }
 
 // New returns Discoverer implementation.
@@ -89,6 +100,9 @@ func New(selector *config.Selectors, opts ...Option) (dsc Discoverer, err error)
 	d.podsByName.Store(&podsByName)
 	d.nodeByName.Store(&nodeByName)
 	d.svcsByName.Store(&svcsByName)
+	if d.ranker.NeedsIndexInfo() && d.client == nil {
+		return nil, errors.ErrGRPCClientNotFound
+	}
 
 	var k8sOpts []k8s.Option
 	k8sOpts = append(k8sOpts,
@@ -230,6 +244,13 @@ func (d *discoverer) Start(ctx context.Context) (<-chan error, error) {
 	if err != nil {
 		return nil, err
 	}
+	var cech <-chan error
+	if d.client != nil {
+		cech, err = d.client.StartConnectionMonitor(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
 	ech := make(chan error, 2)
 	d.eg.Go(safety.RecoverFunc(func() (err error) {
 		defer close(ech)
@@ -270,6 +291,9 @@ func (d *discoverer) Start(ctx context.Context) (<-chan error, error) {
 								Pods: make([]*payload.Info_Pod, d.maxPods),
 							},
 						}
+						if topology := d.topology(n.Labels); topology != nil {
+							ni.Topology = topology
+						}
 						nm, ok := d.nodeMetrics.Load(nodeName)
 						if ok {
 							ni.GetCpu().Usage = nm.CPU
@@ -311,6 +335,7 @@ func (d *discoverer) Start(ctx context.Context) (<-chan error, error) {
 								n, ok := nodeByName[p.NodeName]
 								if ok {
 									pi.Node = n
+									pi.Topology = n.GetTopology()
 								}
 								_, ok = podsByNode[p.NodeName]
 								if !ok {
@@ -373,6 +398,8 @@ func (d *discoverer) Start(ctx context.Context) (<-chan error, error) {
 					}
 				})
 				d.svcsByName.Store(&svcsByName)
+				sc := d.rank(ctx, podsByName, nodeByName)
+				d.scores.Store(sc)
 
 				var wg sync.WaitGroup
 				wg.Add(1)
@@ -381,9 +408,7 @@ func (d *discoverer) Start(ctx context.Context) (<-chan error, error) {
 					for nodeName := range podsByNode {
 						for namespace := range podsByNode[nodeName] {
 							for appName, p := range podsByNode[nodeName][namespace] {
-								slices.SortFunc(p, func(left, right *payload.Info_Pod) int {
-									return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
-								})
+								slices.SortFunc(p, sc.comparePods)
 								podsByNode[nodeName][namespace][appName] = p
 								nn, ok := nodeByName[nodeName]
 								if !ok || nn == nil {
@@ -408,9 +433,7 @@ func (d *discoverer) Start(ctx context.Context) (<-chan error, error) {
 						nn, ok := nodeByName[nodeName]
 						if ok && nn.GetPods() != nil && nn.GetPods().GetPods() != nil {
 							p := nn.GetPods().Pods
-							slices.SortFunc(p, func(left, right *payload.Info_Pod) int {
-								return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
-							})
+							slices.SortFunc(p, sc.comparePods)
 							nodeByName[nodeName].GetPods().Pods = p
 						}
 					}
@@ -423,9 +446,7 @@ func (d *discoverer) Start(ctx context.Context) (<-chan error, error) {
 					defer wg.Done()
 					for namespace := range podsByNamespace {
 						for appName, p := range podsByNamespace[namespace] {
-							slices.SortFunc(p, func(left, right *payload.Info_Pod) int {
-								return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
-							})
+							slices.SortFunc(p, sc.comparePods)
 							podsByNamespace[namespace][appName] = p
 						}
 					}
@@ -436,9 +457,7 @@ func (d *discoverer) Start(ctx context.Context) (<-chan error, error) {
 				d.eg.Go(safety.RecoverFunc(func() error {
 					defer wg.Done()
 					for appName, p := range podsByName {
-						slices.SortFunc(p, func(left, right *payload.Info_Pod) int {
-							return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
-						})
+						slices.SortFunc(p, sc.comparePods)
 						podsByName[appName] = p
 					}
 					d.podsByName.Store(&podsByName)
@@ -449,12 +468,29 @@ func (d *discoverer) Start(ctx context.Context) (<-chan error, error) {
 				if err != nil {
 					ech <- err
 				}
+			case err = <-cech:
+				if err != nil {
+					ech <- err
+				}
 			}
 		}
 	}))
 	return ech, nil
 }
 
+// topology returns the topology held by the node labels, or nil when the node has none of them.
+func (d *discoverer) topology(labels map[string]string) *payload.Info_Topology {
+	t := &payload.Info_Topology{
+		Zone:     labels[d.zoneLabel],
+		Rack:     labels[d.rackLabel],
+		Hostname: labels[d.hostnameLabel],
+	}
+	if t.GetZone() == "" && t.GetRack() == "" && t.GetHostname() == "" {
+		return nil
+	}
+	return t
+}
+
 func (d *discoverer) GetPods(req *payload.Discoverer_Request) (pods *payload.Info_Pods, err error) {
 	var (
 		podsByNamespace map[string]map[string][]*payload.Info_Pod
@@ -514,9 +550,7 @@ func (d *discoverer) GetPods(req *payload.Discoverer_Request) (pods *payload.Inf
 			pods.GetPods()[i].GetNode().Pods = nil
 		}
 	}
-	slices.SortFunc(pods.Pods, func(left, right *payload.Info_Pod) int {
-		return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
-	})
+	slices.SortFunc(pods.Pods, d.scores.Load().comparePods)
 	return pods, nil
 }
 
@@ -554,17 +588,13 @@ func (d *discoverer) GetNodes(
 				for i := range ps.Pods {
 					ps.GetPods()[i].Node = nil
 				}
-				slices.SortFunc(ps.Pods, func(left, right *payload.Info_Pod) int {
-					return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
-				})
+				slices.SortFunc(ps.Pods, d.scores.Load().comparePods)
 				n.Pods = ps
 			}
 		}
 		nodes.Nodes = append(nodes.Nodes, n)
 	}
-	slices.SortFunc(nodes.Nodes, func(left, right *payload.Info_Node) int {
-		return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
-	})
+	slices.SortFunc(nodes.Nodes, d.scores.Load().compareNodes)
 	return nodes, nil
 }
 
@@ -20,7 +20,9 @@ package service
 import (
 	"time"
 
+	"github.com/vdaas/vald/internal/config"
 	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/net/grpc"
 	"github.com/vdaas/vald/internal/sync/errgroup"
 	"github.com/vdaas/vald/internal/timeutil"
 )
@@ -30,6 +32,14 @@ type Option func(d *discoverer) error
 var defaultOptions = []Option{
 	WithDiscoverDuration("2s"),
 	WithErrGroup(errgroup.Get()),
+	WithTopologyLabels(&config.TopologyLabels{
+		Zone:     "topology.kubernetes.io/zone",
+		Hostname: "kubernetes.io/hostname",
+	}),
+	WithRanker(defaultRanker()),
+	WithAgentPort(8081),
+	WithProbeTimeout("1s"),
+	WithProbeConcurrency(8),
 }
 
 func WithDialer(der net.Dialer) Option {
@@ -81,3 +91,76 @@ func WithErrGroup(eg errgroup.Group) Option {
 		return nil
 	}
 }
+
+func WithTopologyLabels(t *config.TopologyLabels) Option {
+	return func(d *discoverer) error {
+		if t == nil {
+			return nil
+		}
+		if len(t.Zone) != 0 {
+			d.zoneLabel = t.Zone
+		}
+		if len(t.Rack) != 0 {
+			d.rackLabel = t.Rack
+		}
+		if len(t.Hostname) != 0 {
+			d.hostnameLabel = t.Hostname
+		}
+		return nil
+	}
+}
+
+// WithRanker returns the option to set the strategy which ranks agents.
+func WithRanker(r Ranker) Option {
+	return func(d *discoverer) error {
+		if r != nil {
+			d.ranker = r
+		}
+		return nil
+	}
+}
+
+// WithAgentClient returns the option to set the client used to get the index info of agents.
+func WithAgentClient(c grpc.Client) Option {
+	return func(d *discoverer) error {
+		if c != nil {
+			d.client = c
+		}
+		return nil
+	}
+}
+
+// WithAgentPort returns the option to set the gRPC port of agents.
+func WithAgentPort(port int) Option {
+	return func(d *discoverer) error {
+		if port > 0 {
+			d.agentPort = port
+		}
+		return nil
+	}
+}
+
+// WithProbeTimeout returns the option to set the timeout of an index info request.
+func WithProbeTimeout(dur string) Option {
+	return func(d *discoverer) error {
+		if dur == "" {
+			return nil
+		}
+		pd, err := timeutil.Parse(dur)
+		if err != nil {
+			pd = time.Second
+		}
+		d.probeTimeout = pd
+		return nil
+	}
+}
+
+// WithProbeConcurrency returns the option to set the number of concurrent index info requests.
+func WithProbeConcurrency(n int) Option {
+	return func(d *discoverer) error {
+		if n > 0 {
+			d.probeConcurrency = n
+		}
+		return nil
+	}
+}
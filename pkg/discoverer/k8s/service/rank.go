@@ -0,0 +1,387 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+)
+
+// Names of the built-in ranking strategies.
+const (
+	// RankByMemory ranks agents by their memory usage.
+	RankByMemory = "memory"
+	// RankByMemoryRatio ranks agents by their memory usage relative to their memory limit.
+	RankByMemoryRatio = "memory_ratio"
+	// RankByStored ranks agents by the number of stored objects reported by IndexInfo.
+	RankByStored = "stored"
+	// RankByUncommitted ranks agents by the number of uncommitted objects reported by IndexInfo.
+	RankByUncommitted = "uncommitted"
+	// RankByCPUHeadroom ranks agents by their unused CPU relative to their CPU limit.
+	RankByCPUHeadroom = "cpu_headroom"
+	// RankByWeighted ranks agents by a weighted sum of the other strategies.
+	RankByWeighted = "weighted"
+)
+
+// Metrics represents the values an agent is ranked by.
+type Metrics struct {
+	CPUUsage    float64
+	CPULimit    float64
+	MemoryUsage float64
+	MemoryLimit float64
+	Stored      float64
+	Uncommitted float64
+}
+
+// Rank represents the ranking decision made for an agent pod.
+type Rank struct {
+	Namespace string
+	Name      string
+	Strategy  string
+	Position  int
+	Score     float64
+}
+
+// Ranker scores agents. Agents with lower scores are preferred for new vectors.
+type Ranker interface {
+	// Name returns the name of the strategy.
+	Name() string
+	// NeedsIndexInfo reports whether the strategy uses the index counts reported by the agents.
+	NeedsIndexInfo() bool
+	// Score returns the score of each element of ms in the same order.
+	Score(ms []Metrics) []float64
+}
+
+type ranker struct {
+	name           string
+	needsIndexInfo bool
+	score          func(m, peak Metrics) float64
+}
+
+// NewRanker returns the built-in Ranker named strategy.
+// The weights are used only by the weighted strategy, and every weight defaults to 1 when weights is nil.
+func NewRanker(strategy string, weights *config.RankingWeights) (Ranker, error) {
+	r := &ranker{
+		name: strategy,
+	}
+	switch strategy {
+	case RankByMemory, "":
+		r.name = RankByMemory
+		r.score = func(m, _ Metrics) float64 {
+			return m.MemoryUsage
+		}
+	case RankByMemoryRatio:
+		r.score = func(m, _ Metrics) float64 {
+			return ratio(m.MemoryUsage, m.MemoryLimit)
+		}
+	case RankByStored:
+		r.needsIndexInfo = true
+		r.score = func(m, _ Metrics) float64 {
+			return m.Stored
+		}
+	case RankByUncommitted:
+		r.needsIndexInfo = true
+		r.score = func(m, _ Metrics) float64 {
+			return m.Uncommitted
+		}
+	case RankByCPUHeadroom:
+		r.score = func(m, _ Metrics) float64 {
+			return ratio(m.CPUUsage, m.CPULimit)
+		}
+	case RankByWeighted:
+		w := weights
+		if w == nil {
+			w = &config.RankingWeights{
+				MemoryRatio: 1,
+				Stored:      1,
+				Uncommitted: 1,
+				CPUHeadroom: 1,
+			}
+		}
+		r.needsIndexInfo = w.Stored != 0 || w.Uncommitted != 0
+		r.score = func(m, peak Metrics) float64 {
+			return w.MemoryRatio*ratio(m.MemoryUsage, m.MemoryLimit) +
+				w.Stored*ratio(m.Stored, peak.Stored) +
+				w.Uncommitted*ratio(m.Uncommitted, peak.Uncommitted) +
+				w.CPUHeadroom*ratio(m.CPUUsage, m.CPULimit)
+		}
+	default:
+		return nil, errors.ErrUnknownRankingStrategy(strategy)
+	}
+	return r, nil
+}
+
+// defaultRanker returns the ranker which ranks agents by their memory usage.
+func defaultRanker() Ranker {
+	r, _ := NewRanker(RankByMemory, nil)
+	return r
+}
+
+func (r *ranker) Name() string {
+	return r.name
+}
+
+func (r *ranker) NeedsIndexInfo() bool {
+	return r.needsIndexInfo
+}
+
+// Score scores ms. The index counts are normalized by their maximum among ms,
+// so that the weighted strategy can sum them with the resource ratios.
+func (r *ranker) Score(ms []Metrics) []float64 {
+	var peak Metrics
+	for _, m := range ms {
+		peak.Stored = max(peak.Stored, m.Stored)
+		peak.Uncommitted = max(peak.Uncommitted, m.Uncommitted)
+	}
+	scores := make([]float64, len(ms))
+	for i, m := range ms {
+		scores[i] = r.score(m, peak)
+	}
+	return scores
+}
+
+// ratio returns usage / limit. An agent without a limit is treated as full once it uses anything.
+func ratio(usage, limit float64) float64 {
+	if limit <= 0 {
+		if usage <= 0 {
+			return 0
+		}
+		return 1
+	}
+	return usage / limit
+}
+
+// scores holds the result of a single ranking round.
+type scores struct {
+	pods  map[string]float64 // map[namespace/name]score
+	nodes map[string]float64 // map[name]score
+}
+
+func podKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// comparePods orders pods by their score. It falls back to the memory usage before the first ranking round.
+func (s *scores) comparePods(left, right *payload.Info_Pod) int {
+	if s == nil {
+		return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
+	}
+	return cmp.Compare(s.pods[podKey(left.GetNamespace(), left.GetName())], s.pods[podKey(right.GetNamespace(), right.GetName())])
+}
+
+// compareNodes orders nodes by their score. It falls back to the memory usage before the first ranking round.
+func (s *scores) compareNodes(left, right *payload.Info_Node) int {
+	if s == nil {
+		return cmp.Compare(left.GetMemory().GetUsage(), right.GetMemory().GetUsage())
+	}
+	return cmp.Compare(s.nodes[left.GetName()], s.nodes[right.GetName()])
+}
+
+// rank scores the discovered pods and nodes with the configured strategy and records the decisions.
+func (d *discoverer) rank(
+	ctx context.Context, podsByName map[string][]*payload.Info_Pod, nodeByName map[string]*payload.Info_Node,
+) *scores {
+	var pods []*payload.Info_Pod
+	for _, ps := range podsByName {
+		pods = append(pods, ps...)
+	}
+	var counts map[string]*payload.Info_Index_Count
+	if d.ranker.NeedsIndexInfo() && d.client != nil {
+		counts = d.indexCounts(ctx, pods)
+	}
+
+	var peak payload.Info_Index_Count
+	for _, c := range counts {
+		peak.Stored = max(peak.GetStored(), c.GetStored())
+		peak.Uncommitted = max(peak.GetUncommitted(), c.GetUncommitted())
+	}
+	pms := make([]Metrics, len(pods))
+	nms := make(map[string]*Metrics, len(nodeByName))
+	for name, n := range nodeByName {
+		nms[name] = &Metrics{
+			CPUUsage:    n.GetCpu().GetUsage(),
+			CPULimit:    n.GetCpu().GetLimit(),
+			MemoryUsage: n.GetMemory().GetUsage(),
+			MemoryLimit: n.GetMemory().GetLimit(),
+		}
+	}
+	for i, p := range pods {
+		pms[i] = Metrics{
+			CPUUsage:    p.GetCpu().GetUsage(),
+			CPULimit:    cmp.Or(p.GetCpu().GetLimit(), p.GetNode().GetCpu().GetLimit()),
+			MemoryUsage: p.GetMemory().GetUsage(),
+			MemoryLimit: cmp.Or(p.GetMemory().GetLimit(), p.GetNode().GetMemory().GetLimit()),
+		}
+		if counts != nil {
+			// agents which did not answer are ranked as if they were the fullest ones.
+			c, ok := counts[podKey(p.GetNamespace(), p.GetName())]
+			if !ok {
+				c = &peak
+			}
+			pms[i].Stored = float64(c.GetStored())
+			pms[i].Uncommitted = float64(c.GetUncommitted())
+		}
+		if nm, ok := nms[p.GetNode().GetName()]; ok && p.GetNode() != nil {
+			nm.Stored += pms[i].Stored
+			nm.Uncommitted += pms[i].Uncommitted
+		}
+	}
+
+	sc := &scores{
+		pods:  make(map[string]float64, len(pods)),
+		nodes: make(map[string]float64, len(nms)),
+	}
+	for i, score := range d.ranker.Score(pms) {
+		sc.pods[podKey(pods[i].GetNamespace(), pods[i].GetName())] = score
+	}
+	names := make([]string, 0, len(nms))
+	ns := make([]Metrics, 0, len(nms))
+	for name, m := range nms {
+		names = append(names, name)
+		ns = append(ns, *m)
+	}
+	for i, score := range d.ranker.Score(ns) {
+		sc.nodes[names[i]] = score
+	}
+
+	ranks := make([]Rank, 0, len(pods))
+	for _, p := range pods {
+		ranks = append(ranks, Rank{
+			Namespace: p.GetNamespace(),
+			Name:      p.GetName(),
+			Strategy:  d.ranker.Name(),
+			Score:     sc.pods[podKey(p.GetNamespace(), p.GetName())],
+		})
+	}
+	slices.SortStableFunc(ranks, func(left, right Rank) int {
+		return cmp.Or(
+			cmp.Compare(left.Score, right.Score),
+			cmp.Compare(left.Namespace, right.Namespace),
+			cmp.Compare(left.Name, right.Name),
+		)
+	})
+	for i := range ranks {
+		ranks[i].Position = i
+	}
+	d.ranks.Store(&ranks)
+	log.Debugf("agents ranked by %s strategy: %s", d.ranker.Name(), rankList(ranks))
+	return sc
+}
+
+// rankList formats ranking decisions only when they are logged.
+type rankList []Rank
+
+func (rl rankList) String() string {
+	entries := make([]string, 0, len(rl))
+	for _, r := range rl {
+		entries = append(entries, podKey(r.Namespace, r.Name)+"="+strconv.FormatFloat(r.Score, 'g', 6, 64))
+	}
+	return "[" + strings.Join(entries, ", ") + "]"
+}
+
+// indexCounts returns the index counts reported by the agents, keyed by namespace/name.
+// Agents which do not answer within the probe timeout are left out.
+func (d *discoverer) indexCounts(
+	ctx context.Context, pods []*payload.Info_Pod,
+) map[string]*payload.Info_Index_Count {
+	var (
+		mu     sync.Mutex
+		counts = make(map[string]*payload.Info_Index_Count, len(pods))
+		addrs  = make(map[string]struct{}, len(pods))
+	)
+	eg, egctx := errgroup.New(ctx)
+	eg.SetLimit(d.probeConcurrency)
+	for _, p := range pods {
+		if len(p.GetIp()) == 0 {
+			continue
+		}
+		addr := net.JoinHostPort(p.GetIp(), uint16(d.agentPort))
+		addrs[addr] = struct{}{}
+		key := podKey(p.GetNamespace(), p.GetName())
+		eg.Go(safety.RecoverFunc(func() error {
+			pctx, cancel := context.WithTimeout(egctx, d.probeTimeout)
+			defer cancel()
+			cnt, err := ProbeIndexInfo(pctx, d.client, addr)
+			if err != nil {
+				log.Debugf("failed to get the index info of agent %s at %s: %v", key, addr, err)
+				return nil
+			}
+			mu.Lock()
+			counts[key] = cnt
+			mu.Unlock()
+			return nil
+		}))
+	}
+	_ = eg.Wait()
+	for _, addr := range d.client.ConnectedAddrs(ctx) {
+		if _, ok := addrs[addr]; !ok {
+			if err := d.client.Disconnect(ctx, addr); err != nil {
+				log.Warnf("failed to disconnect agent %s: %v", addr, err)
+			}
+		}
+	}
+	return counts
+}
+
+// ProbeIndexInfo connects to the agent at addr with client when it is not connected yet and returns its index counts.
+func ProbeIndexInfo(
+	ctx context.Context, client grpc.Client, addr string,
+) (*payload.Info_Index_Count, error) {
+	if !client.IsConnected(ctx, addr) {
+		if _, err := client.Connect(ctx, addr); err != nil {
+			return nil, err
+		}
+	}
+	res, err := client.Do(ctx, addr, func(ctx context.Context, conn *grpc.ClientConn, copts ...grpc.CallOption) (any, error) {
+		return vald.NewIndexClient(conn).IndexInfo(ctx, new(payload.Empty), copts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	cnt, ok := res.(*payload.Info_Index_Count)
+	if !ok {
+		return nil, errors.ErrInvalidTypeConversion(res, cnt)
+	}
+	return cnt, nil
+}
+
+// RangeRanks calls f with the ranking decisions of the latest round, the preferred agent first.
+func (d *discoverer) RangeRanks(f func(r Rank) bool) {
+	ranks := d.ranks.Load()
+	if ranks == nil {
+		return
+	}
+	for _, r := range *ranks {
+		if !f(r) {
+			return
+		}
+	}
+}
@@ -0,0 +1,201 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/errors"
+)
+
+func TestNewRanker(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		strategy       string
+		weights        *config.RankingWeights
+		wantName       string
+		needsIndexInfo bool
+		err            error
+	}{
+		{
+			name:     "empty strategy defaults to memory",
+			wantName: RankByMemory,
+		},
+		{
+			name:     "memory ratio does not need index info",
+			strategy: RankByMemoryRatio,
+			wantName: RankByMemoryRatio,
+		},
+		{
+			name:           "stored needs index info",
+			strategy:       RankByStored,
+			wantName:       RankByStored,
+			needsIndexInfo: true,
+		},
+		{
+			name:           "weighted needs index info by default",
+			strategy:       RankByWeighted,
+			wantName:       RankByWeighted,
+			needsIndexInfo: true,
+		},
+		{
+			name:     "weighted without index count weights does not need index info",
+			strategy: RankByWeighted,
+			weights: &config.RankingWeights{
+				MemoryRatio: 1,
+				CPUHeadroom: 0.5,
+			},
+			wantName: RankByWeighted,
+		},
+		{
+			name:     "unknown strategy",
+			strategy: "random",
+			err:      errors.ErrUnknownRankingStrategy("random"),
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			r, err := NewRanker(test.strategy, test.weights)
+			if !errors.Is(err, test.err) {
+				tt.Fatalf("error = %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			if r.Name() != test.wantName {
+				tt.Errorf("Name() = %s, want %s", r.Name(), test.wantName)
+			}
+			if r.NeedsIndexInfo() != test.needsIndexInfo {
+				tt.Errorf("NeedsIndexInfo() = %v, want %v", r.NeedsIndexInfo(), test.needsIndexInfo)
+			}
+		})
+	}
+}
+
+func Test_ranker_Score(t *testing.T) {
+	t.Parallel()
+	ms := []Metrics{
+		// a large agent using much memory but little of its limit, with a long uncommitted queue.
+		{CPUUsage: 1, CPULimit: 8, MemoryUsage: 8 << 30, MemoryLimit: 32 << 30, Stored: 100, Uncommitted: 5000},
+		// a small agent close to its limit.
+		{CPUUsage: 1.5, CPULimit: 2, MemoryUsage: 3 << 30, MemoryLimit: 4 << 30, Stored: 300, Uncommitted: 0},
+		// a mid agent.
+		{CPUUsage: 1, CPULimit: 4, MemoryUsage: 4 << 30, MemoryLimit: 8 << 30, Stored: 200, Uncommitted: 10},
+	}
+	tests := []struct {
+		strategy string
+		weights  *config.RankingWeights
+		want     []int
+	}{
+		{strategy: RankByMemory, want: []int{1, 2, 0}},
+		{strategy: RankByMemoryRatio, want: []int{0, 2, 1}},
+		{strategy: RankByStored, want: []int{0, 2, 1}},
+		{strategy: RankByUncommitted, want: []int{1, 2, 0}},
+		{strategy: RankByCPUHeadroom, want: []int{0, 2, 1}},
+		{
+			strategy: RankByWeighted,
+			weights:  &config.RankingWeights{Uncommitted: 1, MemoryRatio: 1},
+			want:     []int{2, 1, 0},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.strategy, func(tt *testing.T) {
+			tt.Parallel()
+			r, err := NewRanker(test.strategy, test.weights)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			scores := r.Score(ms)
+			got := []int{0, 1, 2}
+			slices.SortStableFunc(got, func(left, right int) int {
+				switch {
+				case scores[left] < scores[right]:
+					return -1
+				case scores[left] > scores[right]:
+					return 1
+				}
+				return 0
+			})
+			if !slices.Equal(got, test.want) {
+				tt.Errorf("order = %v, want %v (scores %v)", got, test.want, scores)
+			}
+		})
+	}
+}
+
+func Test_discoverer_rank(t *testing.T) {
+	t.Parallel()
+	r, err := NewRanker(RankByMemoryRatio, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &discoverer{
+		ranker: r,
+	}
+	nodeA := &payload.Info_Node{
+		Name:   "node-a",
+		Memory: &payload.Info_Memory{Usage: 30, Limit: 100},
+	}
+	nodeB := &payload.Info_Node{
+		Name:   "node-b",
+		Memory: &payload.Info_Memory{Usage: 80, Limit: 100},
+	}
+	podsByName := map[string][]*payload.Info_Pod{
+		"vald-agent": {
+			{
+				Name:      "agent-0",
+				Namespace: "default",
+				Memory:    &payload.Info_Memory{Usage: 20, Limit: 40},
+				Node:      nodeA,
+			},
+			{
+				// a pod without a memory limit is ranked by the limit of its node.
+				Name:      "agent-1",
+				Namespace: "default",
+				Memory:    &payload.Info_Memory{Usage: 10},
+				Node:      nodeB,
+			},
+		},
+	}
+	sc := d.rank(context.Background(), podsByName, map[string]*payload.Info_Node{
+		"node-a": nodeA,
+		"node-b": nodeB,
+	})
+
+	var got []string
+	d.RangeRanks(func(r Rank) bool {
+		if r.Strategy != RankByMemoryRatio {
+			t.Errorf("strategy = %s, want %s", r.Strategy, RankByMemoryRatio)
+		}
+		got = append(got, r.Name)
+		return true
+	})
+	if want := []string{"agent-1", "agent-0"}; !slices.Equal(got, want) {
+		t.Errorf("ranks = %v, want %v", got, want)
+	}
+	if sc.compareNodes(nodeA, nodeB) >= 0 {
+		t.Errorf("node-a should be preferred to node-b, scores %v", sc.nodes)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/vdaas/vald/internal/observability"
 	backoffmetrics "github.com/vdaas/vald/internal/observability/metrics/backoff"
 	cbmetrics "github.com/vdaas/vald/internal/observability/metrics/circuitbreaker"
+	rankmetrics "github.com/vdaas/vald/internal/observability/metrics/discoverer/rank"
 	"github.com/vdaas/vald/internal/runner"
 	"github.com/vdaas/vald/internal/safety"
 	"github.com/vdaas/vald/internal/servers/server"
@@ -47,6 +48,7 @@ type run struct {
 	server        starter.Server
 	observability observability.Observability
 	der           net.Dialer
+	client        grpc.Client
 }
 
 func New(cfg *config.Data) (r runner.Runner, err error) {
@@ -60,14 +62,36 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		log.Error(err)
 		return nil, err
 	}
-	dsc, err := service.New(
-		cfg.Discoverer.Selectors,
+	var client grpc.Client
+	dopts := []service.Option{
 		service.WithDiscoverDuration(cfg.Discoverer.DiscoveryDuration),
 		service.WithErrGroup(eg),
 		service.WithName(cfg.Discoverer.Name),
 		service.WithNamespace(cfg.Discoverer.Namespace),
 		service.WithDialer(der),
-	)
+		service.WithTopologyLabels(cfg.Discoverer.TopologyLabels),
+	}
+	if rc := cfg.Discoverer.Ranking; rc != nil {
+		ranker, err := service.NewRanker(rc.Strategy, rc.Weights)
+		if err != nil {
+			return nil, err
+		}
+		dopts = append(dopts,
+			service.WithRanker(ranker),
+			service.WithAgentPort(rc.AgentPort),
+			service.WithProbeTimeout(rc.ProbeTimeout),
+			service.WithProbeConcurrency(rc.ProbeConcurrency),
+		)
+		if ranker.NeedsIndexInfo() {
+			copts, err := rc.AgentClient.Opts()
+			if err != nil {
+				return nil, err
+			}
+			client = grpc.New(append(copts, grpc.WithErrGroup(eg))...)
+			dopts = append(dopts, service.WithAgentClient(client))
+		}
+	}
+	dsc, err := service.New(cfg.Discoverer.Selectors, dopts...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +122,7 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 			cfg.Observability,
 			backoffmetrics.New(),
 			cbmetrics.New(),
+			rankmetrics.New(dsc),
 		)
 		if err != nil {
 			return nil, err
@@ -137,6 +162,7 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		h:             h,
 		server:        srv,
 		observability: obs,
+		client:        client,
 	}, nil
 }
 
@@ -196,6 +222,11 @@ func (r *run) Stop(ctx context.Context) error {
 	if r.observability != nil {
 		r.observability.Stop(ctx)
 	}
+	if r.client != nil {
+		if err := r.client.Close(ctx); err != nil {
+			log.Error(err)
+		}
+	}
 	return r.server.Shutdown(ctx)
 }
 
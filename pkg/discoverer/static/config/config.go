@@ -0,0 +1,69 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package config providers configuration type and load configuration logic
+package config
+
+import "github.com/vdaas/vald/internal/config"
+
+type GlobalConfig = config.GlobalConfig
+
+// Data represent a application setting data content (config.yaml).
+type Data struct {
+	config.GlobalConfig `json:",inline" yaml:",inline"`
+
+	// Server represent all server configurations
+	Server *config.Servers `json:"server_config" yaml:"server_config"`
+
+	// Observability represent observability configurations
+	Observability *config.Observability `json:"observability" yaml:"observability"`
+
+	// Discoverer represent discovery config
+	Discoverer *config.Discoverer `json:"discoverer" yaml:"discoverer"`
+}
+
+func NewConfig(path string) (cfg *Data, err error) {
+	cfg = new(Data)
+
+	err = config.Read(path, &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg != nil {
+		cfg.Bind()
+	} else {
+		cfg = new(Data)
+	}
+
+	if cfg.Server != nil {
+		cfg.Server = cfg.Server.Bind()
+	}
+
+	if cfg.Observability != nil {
+		cfg.Observability = cfg.Observability.Bind()
+	}
+
+	if cfg.Discoverer != nil {
+		cfg.Discoverer = cfg.Discoverer.Bind()
+	} else {
+		cfg.Discoverer = new(config.Discoverer).Bind()
+	}
+	if cfg.Discoverer.Static == nil {
+		cfg.Discoverer.Static = new(config.StaticDiscoverer).Bind()
+	}
+	return cfg, nil
+}
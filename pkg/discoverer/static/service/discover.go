@@ -0,0 +1,419 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service manages the main logic of server.
+package service
+
+import (
+	"cmp"
+	"context"
+	"reflect"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+	k8s "github.com/vdaas/vald/pkg/discoverer/k8s/service"
+)
+
+// rankByIndexCount is the strategy reported for the static discoverer,
+// which ranks agents by the number of stored and uncommitted objects.
+const rankByIndexCount = "index_count"
+
+// Discoverer represents the static discoverer interface.
+// It serves the same information as the Kubernetes discoverer for agents
+// running on bare-metal hosts or docker-compose.
+type Discoverer interface {
+	Start(context.Context) (<-chan error, error)
+	GetPods(*payload.Discoverer_Request) (*payload.Info_Pods, error)
+	GetNodes(*payload.Discoverer_Request) (*payload.Info_Nodes, error)
+	GetServices(*payload.Discoverer_Request) (*payload.Info_Services, error)
+	RangeRanks(f func(r k8s.Rank) bool)
+}
+
+type discoverer struct {
+	name          string
+	namespace     string
+	inventoryPath string
+	records       []string
+	port          int
+	dur           time.Duration
+	timeout       time.Duration
+	concurrency   int
+	client        grpc.Client
+	eg            errgroup.Group
+	lookupSRV     func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	lookupHost    func(ctx context.Context, host string) ([]string, error)
+	probe         func(ctx context.Context, addr string) (*payload.Info_Index_Count, error)
+	snapshot      atomic.Pointer[snapshot]
+}
+
+// snapshot is the result of a single discovery round.
+// It is never modified after it has been stored, callers receive clones.
+type snapshot struct {
+	pods     []*payload.Info_Pod
+	loads    []uint64 // the number of indexed objects of each pod
+	nodes    []*payload.Info_Node
+	services []*payload.Info_Service
+}
+
+// New returns Discoverer implementation.
+func New(opts ...Option) (dsc Discoverer, err error) {
+	d := new(discoverer)
+	for _, opt := range append(defaultOptions, opts...) {
+		if err := opt(d); err != nil {
+			return nil, errors.ErrOptionFailed(err, reflect.ValueOf(opt))
+		}
+	}
+	if len(d.inventoryPath) == 0 && len(d.records) == 0 {
+		return nil, errors.ErrDiscoveryTargetNotSpecified
+	}
+	if d.probe == nil {
+		if d.client == nil {
+			return nil, errors.ErrGRPCClientNotFound
+		}
+		d.probe = func(ctx context.Context, addr string) (*payload.Info_Index_Count, error) {
+			return k8s.ProbeIndexInfo(ctx, d.client, addr)
+		}
+	}
+	return d, nil
+}
+
+func (d *discoverer) Start(ctx context.Context) (<-chan error, error) {
+	var cech <-chan error
+	if d.client != nil {
+		var err error
+		cech, err = d.client.StartConnectionMonitor(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := d.discover(ctx); err != nil {
+		return nil, err
+	}
+	ech := make(chan error, 2)
+	d.eg.Go(safety.RecoverFunc(func() (err error) {
+		defer close(ech)
+		dt := time.NewTicker(d.dur)
+		defer dt.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-dt.C:
+				err = d.discover(ctx)
+			case err = <-cech:
+			}
+			if err != nil {
+				log.Error(err)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case ech <- err:
+				}
+			}
+		}
+	}))
+	return ech, nil
+}
+
+// discover loads the inventory, resolves the SRV records and probes every agent.
+// Agents which do not answer the probe are excluded from the snapshot.
+func (d *discoverer) discover(ctx context.Context) (err error) {
+	inv, err := loadInventory(d.inventoryPath)
+	if err != nil {
+		return err
+	}
+	ts := d.targets(ctx, inv)
+
+	counts := make([]*payload.Info_Index_Count, len(ts))
+	eg, egctx := errgroup.New(ctx)
+	eg.SetLimit(d.concurrency)
+	for i, t := range ts {
+		eg.Go(safety.RecoverFunc(func() error {
+			pctx, cancel := context.WithTimeout(egctx, d.timeout)
+			defer cancel()
+			cnt, err := d.probe(pctx, t.addr)
+			if err != nil {
+				log.Warnf("failed to probe agent %s at %s: %v", t.pod.GetName(), t.addr, err)
+				return nil
+			}
+			counts[i] = cnt
+			return nil
+		}))
+	}
+	if err = eg.Wait(); err != nil {
+		return err
+	}
+
+	nodes := make([]*payload.Info_Node, 0, len(inv.Nodes))
+	nodeByName := make(map[string]*payload.Info_Node, len(inv.Nodes))
+	for _, n := range inv.Nodes {
+		if n == nil || len(n.Name) == 0 {
+			continue
+		}
+		mem, err := parseMemory(n.Memory)
+		if err != nil {
+			log.Warnf("invalid memory %s of node %s: %v", n.Memory, n.Name, err)
+		}
+		ni := &payload.Info_Node{
+			Name:         n.Name,
+			InternalAddr: n.InternalAddr,
+			ExternalAddr: n.ExternalAddr,
+			Cpu: &payload.Info_CPU{
+				Limit: n.CPU,
+			},
+			Memory: &payload.Info_Memory{
+				Limit: mem,
+			},
+			Topology: &payload.Info_Topology{
+				Zone:     n.Zone,
+				Rack:     n.Rack,
+				Hostname: n.Hostname,
+			},
+		}
+		if len(n.Hostname) == 0 {
+			ni.GetTopology().Hostname = n.Name
+		}
+		nodes = append(nodes, ni)
+		nodeByName[n.Name] = ni
+	}
+
+	type rankedPod struct {
+		pod  *payload.Info_Pod
+		load uint64
+	}
+	ranked := make([]rankedPod, 0, len(ts))
+	nodeLoads := make(map[string]uint64, len(nodes))
+	for i, t := range ts {
+		cnt := counts[i]
+		if cnt == nil {
+			continue
+		}
+		ni, ok := nodeByName[t.node]
+		if !ok {
+			ni = &payload.Info_Node{
+				Name:         t.node,
+				InternalAddr: t.pod.GetIp(),
+				Cpu:          new(payload.Info_CPU),
+				Memory:       new(payload.Info_Memory),
+				Topology: &payload.Info_Topology{
+					Hostname: t.node,
+				},
+			}
+			nodes = append(nodes, ni)
+			nodeByName[t.node] = ni
+		}
+		// agents do not report host metrics, so the number of indexed
+		// objects is used as the load which ranks the agents instead of the memory usage.
+		load := uint64(cnt.GetStored()) + uint64(cnt.GetUncommitted())
+		nodeLoads[ni.GetName()] += load
+		t.pod.Node = ni
+		t.pod.Topology = ni.GetTopology()
+		ranked = append(ranked, rankedPod{pod: t.pod, load: load})
+	}
+	slices.SortStableFunc(ranked, func(l, r rankedPod) int {
+		return cmp.Compare(l.load, r.load)
+	})
+	pods := make([]*payload.Info_Pod, 0, len(ranked))
+	loads := make([]uint64, 0, len(ranked))
+	for _, r := range ranked {
+		pods = append(pods, r.pod)
+		loads = append(loads, r.load)
+	}
+	slices.SortStableFunc(nodes, func(l, r *payload.Info_Node) int {
+		return cmp.Compare(nodeLoads[l.GetName()], nodeLoads[r.GetName()])
+	})
+
+	svcs := make([]*payload.Info_Service, 0, len(inv.Services))
+	for _, s := range inv.Services {
+		if s == nil || len(s.Name) == 0 {
+			continue
+		}
+		ports := make([]*payload.Info_ServicePort, 0, len(s.Ports))
+		for _, p := range s.Ports {
+			if p != nil {
+				ports = append(ports, &payload.Info_ServicePort{
+					Name: p.Name,
+					Port: p.Port,
+				})
+			}
+		}
+		si := &payload.Info_Service{
+			Name:       s.Name,
+			ClusterIps: s.Addrs,
+			Ports:      ports,
+			Labels: &payload.Info_Labels{
+				Labels: s.Labels,
+			},
+			Annotations: &payload.Info_Annotations{
+				Annotations: s.Annotations,
+			},
+		}
+		if len(s.Addrs) != 0 {
+			si.ClusterIp = s.Addrs[0]
+		}
+		svcs = append(svcs, si)
+	}
+
+	d.snapshot.Store(&snapshot{
+		pods:     pods,
+		loads:    loads,
+		nodes:    nodes,
+		services: svcs,
+	})
+	d.disconnect(ctx, ts)
+	return nil
+}
+
+// disconnect closes the connections to agents which are no longer discovered.
+func (d *discoverer) disconnect(ctx context.Context, ts []*target) {
+	if d.client == nil {
+		return
+	}
+	addrs := make(map[string]struct{}, len(ts))
+	for _, t := range ts {
+		addrs[t.addr] = struct{}{}
+	}
+	for _, addr := range d.client.ConnectedAddrs(ctx) {
+		if _, ok := addrs[addr]; !ok {
+			if err := d.client.Disconnect(ctx, addr); err != nil {
+				log.Warnf("failed to disconnect agent %s: %v", addr, err)
+			}
+		}
+	}
+}
+
+func (d *discoverer) GetPods(req *payload.Discoverer_Request) (pods *payload.Info_Pods, err error) {
+	s := d.snapshot.Load()
+	if s == nil {
+		return nil, errors.ErrInvalidDiscoveryCache
+	}
+	node, ns, name := req.GetNode(), req.GetNamespace(), req.GetName()
+	if isSpecified(node) && !slices.ContainsFunc(s.nodes, func(n *payload.Info_Node) bool {
+		return n.GetName() == node
+	}) {
+		return nil, errors.ErrNodeNotFound(node)
+	}
+	ps := make([]*payload.Info_Pod, 0, len(s.pods))
+	for _, p := range s.pods {
+		if (!isSpecified(node) || p.GetNode().GetName() == node) &&
+			(!isSpecified(ns) || p.GetNamespace() == ns) {
+			ps = append(ps, p)
+		}
+	}
+	if isSpecified(ns) && len(ps) == 0 {
+		return nil, errors.ErrNamespaceNotFound(ns)
+	}
+	if isSpecified(name) {
+		ps = slices.DeleteFunc(ps, func(p *payload.Info_Pod) bool {
+			return p.GetAppName() != name
+		})
+		if len(ps) == 0 {
+			return nil, errors.ErrPodNameNotFound(name)
+		}
+	}
+	pods = &payload.Info_Pods{
+		Pods: make([]*payload.Info_Pod, 0, len(ps)),
+	}
+	for _, p := range ps {
+		pods.Pods = append(pods.GetPods(), p.CloneVT())
+	}
+	return pods, nil
+}
+
+func (d *discoverer) GetNodes(
+	req *payload.Discoverer_Request,
+) (nodes *payload.Info_Nodes, err error) {
+	s := d.snapshot.Load()
+	if s == nil {
+		return nil, errors.ErrInvalidDiscoveryCache
+	}
+	nodes = &payload.Info_Nodes{
+		Nodes: make([]*payload.Info_Node, 0, len(s.nodes)),
+	}
+	for _, n := range s.nodes {
+		if isSpecified(req.GetNode()) && n.GetName() != req.GetNode() {
+			continue
+		}
+		ni := n.CloneVT()
+		ps, err := d.GetPods(&payload.Discoverer_Request{
+			Name:      req.GetName(),
+			Namespace: req.GetNamespace(),
+			Node:      n.GetName(),
+		})
+		if err == nil {
+			for _, p := range ps.GetPods() {
+				p.Node = nil
+			}
+			ni.Pods = ps
+		}
+		nodes.Nodes = append(nodes.GetNodes(), ni)
+	}
+	if isSpecified(req.GetNode()) && len(nodes.GetNodes()) == 0 {
+		return nil, errors.ErrNodeNotFound(req.GetNode())
+	}
+	return nodes, nil
+}
+
+// GetServices returns the clones of the services that matches the request.
+func (d *discoverer) GetServices(
+	req *payload.Discoverer_Request,
+) (svcs *payload.Info_Services, err error) {
+	s := d.snapshot.Load()
+	if s == nil {
+		return nil, errors.ErrInvalidDiscoveryCache
+	}
+	svcs = new(payload.Info_Services)
+	for _, svc := range s.services {
+		if !isSpecified(req.GetName()) || svc.GetName() == req.GetName() {
+			svcs.Services = append(svcs.GetServices(), svc.CloneVT())
+		}
+	}
+	if isSpecified(req.GetName()) && len(svcs.GetServices()) == 0 {
+		return nil, errors.ErrSvcNameNotFound(req.GetName())
+	}
+	return svcs, nil
+}
+
+func isSpecified(s string) bool {
+	return s != "" && s != "*"
+}
+
+// RangeRanks calls f with the ranking decisions of the latest discovery, the preferred agent first.
+func (d *discoverer) RangeRanks(f func(r k8s.Rank) bool) {
+	s := d.snapshot.Load()
+	if s == nil {
+		return
+	}
+	for i, p := range s.pods {
+		if !f(k8s.Rank{
+			Namespace: p.GetNamespace(),
+			Name:      p.GetName(),
+			Strategy:  rankByIndexCount,
+			Position:  i,
+			Score:     float64(s.loads[i]),
+		}) {
+			return
+		}
+	}
+}
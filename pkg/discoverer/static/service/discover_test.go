@@ -0,0 +1,324 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/test/goleak"
+	k8s "github.com/vdaas/vald/pkg/discoverer/k8s/service"
+)
+
+const testInventory = `
+nodes:
+  - name: host-a
+    internal_addr: 10.0.0.1
+    cpu: 16
+    memory: 64GiB
+    zone: zone-a
+  - name: host-b
+    internal_addr: 10.0.0.2
+    cpu: 8
+    memory: 32GiB
+    zone: zone-b
+    rack: rack-1
+agents:
+  - name: agent-0
+    node: host-a
+    addr: 10.0.0.11
+    cpu: 4
+    memory: 16GiB
+  - name: agent-1
+    node: host-a
+    addr: agent-1.local
+    port: 8082
+  - name: agent-2
+    node: host-b
+    addr: 10.0.0.21
+  - name: agent-3
+    node: host-b
+    addr: 10.0.0.22
+services:
+  - name: vald-lb-gateway
+    addrs:
+      - 10.0.0.100
+    ports:
+      - name: grpc
+        port: 8081
+`
+
+func newTestDiscoverer(t *testing.T, inventory string, records ...string) *discoverer {
+	t.Helper()
+	var path string
+	if len(inventory) != 0 {
+		path = filepath.Join(t.TempDir(), "inventory.yaml")
+		if err := os.WriteFile(path, []byte(inventory), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	counts := map[string]uint32{
+		"10.0.0.11:8081": 300,
+		"10.0.0.12:8082": 100,
+		"10.0.0.21:8081": 200,
+		"10.0.0.31:9000": 50,
+	}
+	d := &discoverer{
+		name:          "vald-agent",
+		namespace:     "default",
+		inventoryPath: path,
+		records:       records,
+		port:          8081,
+		timeout:       time.Second,
+		concurrency:   2,
+		lookupHost: func(_ context.Context, host string) ([]string, error) {
+			switch host {
+			case "agent-1.local":
+				return []string{"10.0.0.12"}, nil
+			case "agent-srv.local":
+				return []string{"10.0.0.31"}, nil
+			}
+			return nil, errors.ErrAddrCouldNotDiscover(nil, host)
+		},
+		lookupSRV: func(_ context.Context, _, _, name string) (string, []*net.SRV, error) {
+			if name != "_grpc._tcp.vald-agent.local" {
+				return "", nil, errors.ErrAddrCouldNotDiscover(nil, name)
+			}
+			return name, []*net.SRV{
+				{Target: "agent-srv.local.", Port: 9000},
+			}, nil
+		},
+		probe: func(_ context.Context, addr string) (*payload.Info_Index_Count, error) {
+			c, ok := counts[addr]
+			if !ok {
+				return nil, errors.ErrGRPCClientConnNotFound(addr)
+			}
+			return &payload.Info_Index_Count{
+				Stored: c,
+			}, nil
+		},
+	}
+	if err := d.discover(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func podNames(pods *payload.Info_Pods) []string {
+	names := make([]string, 0, len(pods.GetPods()))
+	for _, p := range pods.GetPods() {
+		names = append(names, p.GetName())
+	}
+	return names
+}
+
+func Test_discoverer_GetPods(t *testing.T) {
+	t.Parallel()
+	type test struct {
+		name      string
+		inventory string
+		records   []string
+		req       *payload.Discoverer_Request
+		want      []string
+		err       error
+	}
+	tests := []test{
+		{
+			name:      "return reachable agents ranked by the number of indexed objects",
+			inventory: testInventory,
+			req:       &payload.Discoverer_Request{Name: "vald-agent"},
+			want:      []string{"agent-1", "agent-2", "agent-0"},
+		},
+		{
+			name:      "return agents of the node",
+			inventory: testInventory,
+			req:       &payload.Discoverer_Request{Name: "*", Node: "host-a"},
+			want:      []string{"agent-1", "agent-0"},
+		},
+		{
+			name:      "return agents resolved from srv records and the inventory",
+			inventory: testInventory,
+			records:   []string{"_grpc._tcp.vald-agent.local"},
+			req:       new(payload.Discoverer_Request),
+			want:      []string{"agent-srv.local", "agent-1", "agent-2", "agent-0"},
+		},
+		{
+			name:    "return agents resolved from srv records only",
+			records: []string{"_grpc._tcp.vald-agent.local", "_grpc._tcp.unknown.local"},
+			req:     new(payload.Discoverer_Request),
+			want:    []string{"agent-srv.local"},
+		},
+		{
+			name:      "return error when the node is not found",
+			inventory: testInventory,
+			req:       &payload.Discoverer_Request{Node: "host-c"},
+			err:       errors.ErrNodeNotFound("host-c"),
+		},
+		{
+			name:      "return error when the namespace is not found",
+			inventory: testInventory,
+			req:       &payload.Discoverer_Request{Namespace: "unknown"},
+			err:       errors.ErrNamespaceNotFound("unknown"),
+		},
+		{
+			name:      "return error when the app name is not found",
+			inventory: testInventory,
+			req:       &payload.Discoverer_Request{Name: "vald-lb-gateway"},
+			err:       errors.ErrPodNameNotFound("vald-lb-gateway"),
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			defer goleak.VerifyNone(tt, goleak.IgnoreCurrent())
+			d := newTestDiscoverer(tt, test.inventory, test.records...)
+			got, err := d.GetPods(test.req)
+			if !errors.Is(err, test.err) {
+				tt.Fatalf("error = %v, want %v", err, test.err)
+			}
+			if test.err != nil {
+				return
+			}
+			if names := podNames(got); !slices.Equal(names, test.want) {
+				tt.Errorf("pods = %v, want %v", names, test.want)
+			}
+		})
+	}
+}
+
+func Test_discoverer_GetNodes(t *testing.T) {
+	t.Parallel()
+	d := newTestDiscoverer(t, testInventory)
+
+	got, err := d.GetNodes(&payload.Discoverer_Request{Name: "vald-agent", Namespace: "default", Node: "*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]struct {
+		limit    float64
+		pods     []string
+		topology *payload.Info_Topology
+	}{
+		"host-a": {
+			limit:    64 << 30,
+			pods:     []string{"agent-1", "agent-0"},
+			topology: &payload.Info_Topology{Zone: "zone-a", Hostname: "host-a"},
+		},
+		"host-b": {
+			limit:    32 << 30,
+			pods:     []string{"agent-2"},
+			topology: &payload.Info_Topology{Zone: "zone-b", Rack: "rack-1", Hostname: "host-b"},
+		},
+	}
+	if len(got.GetNodes()) != len(want) {
+		t.Fatalf("len(nodes) = %d, want %d", len(got.GetNodes()), len(want))
+	}
+	if got.GetNodes()[0].GetName() != "host-b" {
+		t.Errorf("first node = %s, want the least loaded host-b", got.GetNodes()[0].GetName())
+	}
+	for _, n := range got.GetNodes() {
+		w := want[n.GetName()]
+		// the memory usage is not reported since the agents do not report host metrics
+		if n.GetMemory().GetUsage() != 0 || n.GetMemory().GetLimit() != w.limit {
+			t.Errorf("node %s memory = %v, want usage 0 limit %v", n.GetName(), n.GetMemory(), w.limit)
+		}
+		if names := podNames(n.GetPods()); !slices.Equal(names, w.pods) {
+			t.Errorf("node %s pods = %v, want %v", n.GetName(), names, w.pods)
+		}
+		if !n.GetTopology().EqualVT(w.topology) {
+			t.Errorf("node %s topology = %v, want %v", n.GetName(), n.GetTopology(), w.topology)
+		}
+		for _, p := range n.GetPods().GetPods() {
+			if !p.GetTopology().EqualVT(w.topology) {
+				t.Errorf("pod %s topology = %v, want %v", p.GetName(), p.GetTopology(), w.topology)
+			}
+		}
+	}
+
+	if _, err := d.GetNodes(&payload.Discoverer_Request{Node: "host-c"}); !errors.Is(err, errors.ErrNodeNotFound("host-c")) {
+		t.Errorf("error = %v, want %v", err, errors.ErrNodeNotFound("host-c"))
+	}
+}
+
+func Test_discoverer_GetServices(t *testing.T) {
+	t.Parallel()
+	d := newTestDiscoverer(t, testInventory)
+
+	got, err := d.GetServices(&payload.Discoverer_Request{Name: "vald-lb-gateway"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.GetServices()) != 1 || got.GetServices()[0].GetClusterIp() != "10.0.0.100" {
+		t.Errorf("services = %v", got.GetServices())
+	}
+	got.GetServices()[0].ClusterIp = "10.0.0.200"
+	got, err = d.GetServices(&payload.Discoverer_Request{Name: "vald-lb-gateway"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetServices()[0].GetClusterIp() != "10.0.0.100" {
+		t.Errorf("the cached service is modified by the caller: %v", got.GetServices())
+	}
+	if _, err := d.GetServices(&payload.Discoverer_Request{Name: "unknown"}); !errors.Is(err, errors.ErrSvcNameNotFound("unknown")) {
+		t.Errorf("error = %v, want %v", err, errors.ErrSvcNameNotFound("unknown"))
+	}
+	if _, err := new(discoverer).GetServices(new(payload.Discoverer_Request)); !errors.Is(err, errors.ErrInvalidDiscoveryCache) {
+		t.Errorf("error = %v, want %v", err, errors.ErrInvalidDiscoveryCache)
+	}
+}
+
+func Test_discoverer_RangeRanks(t *testing.T) {
+	t.Parallel()
+	d := newTestDiscoverer(t, testInventory)
+
+	var (
+		names  []string
+		scores []float64
+	)
+	d.RangeRanks(func(r k8s.Rank) bool {
+		if r.Strategy != rankByIndexCount || r.Position != len(names) {
+			t.Errorf("unexpected rank %#v", r)
+		}
+		names = append(names, r.Name)
+		scores = append(scores, r.Score)
+		return true
+	})
+	if want := []string{"agent-1", "agent-2", "agent-0"}; !slices.Equal(names, want) {
+		t.Errorf("ranks = %v, want %v", names, want)
+	}
+	if want := []float64{100, 200, 300}; !slices.Equal(scores, want) {
+		t.Errorf("scores = %v, want %v", scores, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	if _, err := New(); !errors.Is(err, errors.ErrDiscoveryTargetNotSpecified) {
+		t.Errorf("error = %v, want %v", err, errors.ErrDiscoveryTargetNotSpecified)
+	}
+	if _, err := New(WithSRVRecords("_grpc._tcp.vald-agent.local")); !errors.Is(err, errors.ErrGRPCClientNotFound) {
+		t.Errorf("error = %v, want %v", err, errors.ErrGRPCClientNotFound)
+	}
+}
@@ -0,0 +1,225 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/unit"
+)
+
+// inventory represents the static inventory of the nodes, agents and services of a cluster.
+type inventory struct {
+	Nodes    []*inventoryNode    `json:"nodes"    yaml:"nodes"`
+	Agents   []*inventoryAgent   `json:"agents"   yaml:"agents"`
+	Services []*inventoryService `json:"services" yaml:"services"`
+}
+
+// inventoryNode represents a host running one or more agents.
+type inventoryNode struct {
+	Name         string  `json:"name"          yaml:"name"`
+	InternalAddr string  `json:"internal_addr" yaml:"internal_addr"`
+	ExternalAddr string  `json:"external_addr" yaml:"external_addr"`
+	CPU          float64 `json:"cpu"           yaml:"cpu"`
+	Memory       string  `json:"memory"        yaml:"memory"`
+	Zone         string  `json:"zone"          yaml:"zone"`
+	Rack         string  `json:"rack"          yaml:"rack"`
+	Hostname     string  `json:"hostname"      yaml:"hostname"`
+}
+
+// inventoryAgent represents a single agent process.
+type inventoryAgent struct {
+	Name      string  `json:"name"      yaml:"name"`
+	AppName   string  `json:"app_name"  yaml:"app_name"`
+	Namespace string  `json:"namespace" yaml:"namespace"`
+	Node      string  `json:"node"      yaml:"node"`
+	Addr      string  `json:"addr"      yaml:"addr"`
+	Port      int     `json:"port"      yaml:"port"`
+	CPU       float64 `json:"cpu"       yaml:"cpu"`
+	Memory    string  `json:"memory"    yaml:"memory"`
+}
+
+// inventoryService represents a service answered by the Services RPC.
+type inventoryService struct {
+	Name        string                  `json:"name"        yaml:"name"`
+	Addrs       []string                `json:"addrs"       yaml:"addrs"`
+	Ports       []*inventoryServicePort `json:"ports"       yaml:"ports"`
+	Labels      map[string]string       `json:"labels"      yaml:"labels"`
+	Annotations map[string]string       `json:"annotations" yaml:"annotations"`
+}
+
+type inventoryServicePort struct {
+	Name string `json:"name" yaml:"name"`
+	Port int32  `json:"port" yaml:"port"`
+}
+
+// target represents an agent to be probed.
+type target struct {
+	addr string
+	node string
+	pod  *payload.Info_Pod
+}
+
+func loadInventory(path string) (inv *inventory, err error) {
+	inv = new(inventory)
+	if len(path) == 0 {
+		return inv, nil
+	}
+	if err = config.Read(path, inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func parseMemory(mem string) (float64, error) {
+	if len(mem) == 0 {
+		return 0, nil
+	}
+	b, err := unit.ParseBytes(mem)
+	if err != nil {
+		return 0, err
+	}
+	return float64(b), nil
+}
+
+// targets resolves the agents of the inventory and the SRV records into probe targets.
+// Agents which cannot be resolved are logged and skipped.
+func (d *discoverer) targets(ctx context.Context, inv *inventory) (ts []*target) {
+	ts = make([]*target, 0, len(inv.Agents))
+	seen := make(map[string]struct{}, len(inv.Agents))
+	add := func(t *target) {
+		if _, ok := seen[t.addr]; ok {
+			return
+		}
+		seen[t.addr] = struct{}{}
+		ts = append(ts, t)
+	}
+	for _, a := range inv.Agents {
+		if a == nil || len(a.Addr) == 0 {
+			continue
+		}
+		ip, err := d.resolve(ctx, a.Addr)
+		if err != nil {
+			log.Warnf("failed to resolve agent %s address %s: %v", a.Name, a.Addr, err)
+			continue
+		}
+		mem, err := parseMemory(a.Memory)
+		if err != nil {
+			log.Warnf("invalid memory %s of agent %s: %v", a.Memory, a.Name, err)
+		}
+		port := a.Port
+		if port == 0 {
+			port = d.port
+		}
+		name := a.Name
+		if len(name) == 0 {
+			name = a.Addr
+		}
+		add(&target{
+			addr: net.JoinHostPort(ip, uint16(port)),
+			node: d.nodeName(a.Node, a.Addr),
+			pod: &payload.Info_Pod{
+				AppName:   d.appName(a.AppName),
+				Name:      name,
+				Namespace: d.namespaceName(a.Namespace),
+				Ip:        ip,
+				Cpu: &payload.Info_CPU{
+					Limit: a.CPU,
+				},
+				Memory: &payload.Info_Memory{
+					Limit: mem,
+				},
+			},
+		})
+	}
+	for _, rec := range d.records {
+		_, srvs, err := d.lookupSRV(ctx, "", "", rec)
+		if err != nil {
+			log.Warnf("failed to lookup SRV record %s: %v", rec, err)
+			continue
+		}
+		for _, srv := range srvs {
+			host := strings.TrimSuffix(srv.Target, ".")
+			ip, err := d.resolve(ctx, host)
+			if err != nil {
+				log.Warnf("failed to resolve SRV target %s of %s: %v", host, rec, err)
+				continue
+			}
+			add(&target{
+				addr: net.JoinHostPort(ip, srv.Port),
+				node: host,
+				pod: &payload.Info_Pod{
+					AppName:   d.appName(""),
+					Name:      host,
+					Namespace: d.namespaceName(""),
+					Ip:        ip,
+					Cpu:       new(payload.Info_CPU),
+					Memory:    new(payload.Info_Memory),
+				},
+			})
+		}
+	}
+	return ts
+}
+
+// resolve returns the first IPv4 address of host, or host itself when it is already an IP address.
+func (d *discoverer) resolve(ctx context.Context, host string) (string, error) {
+	if _, err := netip.ParseAddr(host); err == nil {
+		return host, nil
+	}
+	addrs, err := d.lookupHost(ctx, host)
+	if err != nil {
+		return "", err
+	}
+	for _, addr := range addrs {
+		if ip, err := netip.ParseAddr(addr); err == nil && ip.Is4() {
+			return addr, nil
+		}
+	}
+	if len(addrs) != 0 {
+		return addrs[0], nil
+	}
+	return "", errors.ErrAddrCouldNotDiscover(nil, host)
+}
+
+func (d *discoverer) appName(name string) string {
+	if len(name) != 0 {
+		return name
+	}
+	return d.name
+}
+
+func (d *discoverer) namespaceName(ns string) string {
+	if len(ns) != 0 {
+		return ns
+	}
+	return d.namespace
+}
+
+func (*discoverer) nodeName(node, addr string) string {
+	if len(node) != 0 {
+		return node
+	}
+	return addr
+}
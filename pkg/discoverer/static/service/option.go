@@ -0,0 +1,143 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package service
+
+import (
+	"time"
+
+	"github.com/vdaas/vald/internal/net"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+	"github.com/vdaas/vald/internal/timeutil"
+)
+
+type Option func(d *discoverer) error
+
+var defaultOptions = []Option{
+	WithDiscoverDuration("2s"),
+	WithProbeTimeout("1s"),
+	WithProbeConcurrency(8),
+	WithAgentPort(8081),
+	WithErrGroup(errgroup.Get()),
+	WithResolver(net.DefaultResolver),
+}
+
+func WithName(name string) Option {
+	return func(d *discoverer) error {
+		if len(name) != 0 {
+			d.name = name
+		}
+		return nil
+	}
+}
+
+func WithNamespace(ns string) Option {
+	return func(d *discoverer) error {
+		if len(ns) != 0 {
+			d.namespace = ns
+		}
+		return nil
+	}
+}
+
+func WithDiscoverDuration(dur string) Option {
+	return func(d *discoverer) error {
+		if dur == "" {
+			return nil
+		}
+		pd, err := timeutil.Parse(dur)
+		if err != nil {
+			pd = time.Second
+		}
+		d.dur = pd
+		return nil
+	}
+}
+
+func WithInventoryPath(path string) Option {
+	return func(d *discoverer) error {
+		d.inventoryPath = path
+		return nil
+	}
+}
+
+func WithSRVRecords(records ...string) Option {
+	return func(d *discoverer) error {
+		d.records = append(d.records, records...)
+		return nil
+	}
+}
+
+func WithAgentPort(port int) Option {
+	return func(d *discoverer) error {
+		if port > 0 {
+			d.port = port
+		}
+		return nil
+	}
+}
+
+func WithProbeTimeout(dur string) Option {
+	return func(d *discoverer) error {
+		if dur == "" {
+			return nil
+		}
+		pd, err := timeutil.Parse(dur)
+		if err != nil {
+			pd = time.Second
+		}
+		d.timeout = pd
+		return nil
+	}
+}
+
+func WithProbeConcurrency(c int) Option {
+	return func(d *discoverer) error {
+		if c > 0 {
+			d.concurrency = c
+		}
+		return nil
+	}
+}
+
+func WithClient(c grpc.Client) Option {
+	return func(d *discoverer) error {
+		if c != nil {
+			d.client = c
+		}
+		return nil
+	}
+}
+
+func WithResolver(r *net.Resolver) Option {
+	return func(d *discoverer) error {
+		if r != nil {
+			d.lookupSRV = r.LookupSRV
+			d.lookupHost = r.LookupHost
+		}
+		return nil
+	}
+}
+
+func WithErrGroup(eg errgroup.Group) Option {
+	return func(d *discoverer) error {
+		if eg != nil {
+			d.eg = eg
+		}
+		return nil
+	}
+}
@@ -0,0 +1,205 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package usecase
+
+import (
+	"context"
+
+	"github.com/vdaas/vald/apis/grpc/v1/discoverer"
+	iconf "github.com/vdaas/vald/internal/config"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/observability"
+	backoffmetrics "github.com/vdaas/vald/internal/observability/metrics/backoff"
+	cbmetrics "github.com/vdaas/vald/internal/observability/metrics/circuitbreaker"
+	rankmetrics "github.com/vdaas/vald/internal/observability/metrics/discoverer/rank"
+	"github.com/vdaas/vald/internal/runner"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/servers/server"
+	"github.com/vdaas/vald/internal/servers/starter"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+	handler "github.com/vdaas/vald/pkg/discoverer/k8s/handler/grpc"
+	"github.com/vdaas/vald/pkg/discoverer/k8s/handler/rest"
+	"github.com/vdaas/vald/pkg/discoverer/k8s/router"
+	"github.com/vdaas/vald/pkg/discoverer/static/config"
+	"github.com/vdaas/vald/pkg/discoverer/static/service"
+)
+
+type run struct {
+	eg            errgroup.Group
+	cfg           *config.Data
+	dsc           service.Discoverer
+	client        grpc.Client
+	h             handler.DiscovererServer
+	server        starter.Server
+	observability observability.Observability
+}
+
+func New(cfg *config.Data) (r runner.Runner, err error) {
+	eg := errgroup.Get()
+	st := cfg.Discoverer.Static
+	copts, err := st.AgentClient.Opts()
+	if err != nil {
+		return nil, err
+	}
+	client := grpc.New(append(copts, grpc.WithErrGroup(eg))...)
+	dsc, err := service.New(
+		service.WithDiscoverDuration(cfg.Discoverer.DiscoveryDuration),
+		service.WithErrGroup(eg),
+		service.WithName(cfg.Discoverer.Name),
+		service.WithNamespace(cfg.Discoverer.Namespace),
+		service.WithInventoryPath(st.InventoryPath),
+		service.WithSRVRecords(st.SRVRecords...),
+		service.WithAgentPort(st.AgentPort),
+		service.WithProbeTimeout(st.ProbeTimeout),
+		service.WithProbeConcurrency(st.ProbeConcurrency),
+		service.WithClient(client),
+	)
+	if err != nil {
+		return nil, err
+	}
+	h, err := handler.New(
+		handler.WithDiscoverer(dsc),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcServerOptions := []server.Option{
+		server.WithGRPCRegistFunc(func(srv *grpc.Server) {
+			discoverer.RegisterDiscovererServer(srv, h)
+		}),
+		server.WithPreStartFunc(func() error {
+			return nil
+		}),
+		server.WithPreStopFunction(func() error {
+			return nil
+		}),
+	}
+
+	var obs observability.Observability
+	if cfg.Observability.Enabled {
+		obs, err = observability.NewWithConfig(
+			cfg.Observability,
+			backoffmetrics.New(),
+			cbmetrics.New(),
+			rankmetrics.New(dsc),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	srv, err := starter.New(
+		starter.WithConfig(cfg.Server),
+		starter.WithREST(func(sc *iconf.Server) []server.Option {
+			return []server.Option{
+				server.WithHTTPHandler(
+					router.New(
+						router.WithTimeout(sc.HTTP.HandlerTimeout),
+						router.WithErrGroup(eg),
+						router.WithHandler(
+							rest.New(
+								rest.WithDiscoverer(h),
+							),
+						),
+					)),
+			}
+		}),
+		starter.WithGRPC(func(sc *iconf.Server) []server.Option {
+			return grpcServerOptions
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &run{
+		eg:            eg,
+		cfg:           cfg,
+		dsc:           dsc,
+		client:        client,
+		h:             h,
+		server:        srv,
+		observability: obs,
+	}, nil
+}
+
+func (r *run) PreStart(ctx context.Context) error {
+	if r.observability != nil {
+		return r.observability.PreStart(ctx)
+	}
+	return nil
+}
+
+func (r *run) Start(ctx context.Context) (<-chan error, error) {
+	ech := make(chan error, 3)
+	var oech, dech, sech <-chan error
+	r.eg.Go(safety.RecoverFunc(func() (err error) {
+		defer close(ech)
+		if r.observability != nil {
+			oech = r.observability.Start(ctx)
+		}
+		dech, err = r.dsc.Start(ctx)
+		if err != nil {
+			ech <- err
+			return err
+		}
+
+		r.h.Start(ctx)
+
+		sech = r.server.ListenAndServe(ctx)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case err = <-oech:
+			case err = <-dech:
+			case err = <-sech:
+			}
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case ech <- err:
+				}
+			}
+		}
+	}))
+	return ech, nil
+}
+
+func (*run) PreStop(context.Context) error {
+	return nil
+}
+
+func (r *run) Stop(ctx context.Context) error {
+	if r.observability != nil {
+		r.observability.Stop(ctx)
+	}
+	if r.client != nil {
+		if err := r.client.Close(ctx); err != nil {
+			log.Error(err)
+		}
+	}
+	return r.server.Shutdown(ctx)
+}
+
+func (*run) PostStop(context.Context) error {
+	return nil
+}
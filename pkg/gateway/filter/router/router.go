@@ -47,6 +47,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/",
+				RPC:         "/",
 				HandlerFunc: h.Index,
 			},
 			{
@@ -55,6 +56,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/search",
+				RPC:         "/vald.v1.Search/Search",
 				HandlerFunc: h.Search,
 			},
 			{
@@ -63,6 +65,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/search/{id}",
+				RPC:         "/vald.v1.Search/SearchByID",
 				HandlerFunc: h.SearchByID,
 			},
 			{
@@ -71,6 +74,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/search/multi",
+				RPC:         "/vald.v1.Search/MultiSearch",
 				HandlerFunc: h.MultiSearch,
 			},
 			{
@@ -79,6 +83,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/search/multi/{id}",
+				RPC:         "/vald.v1.Search/MultiSearchByID",
 				HandlerFunc: h.MultiSearchByID,
 			},
 			{
@@ -87,6 +92,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/linearsearch",
+				RPC:         "/vald.v1.Search/LinearSearch",
 				HandlerFunc: h.LinearSearch,
 			},
 			{
@@ -95,6 +101,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/linearsearch/{id}",
+				RPC:         "/vald.v1.Search/SearchByID",
 				HandlerFunc: h.SearchByID,
 			},
 			{
@@ -103,6 +110,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/linearsearch/multi",
+				RPC:         "/vald.v1.Search/MultiLinearSearch",
 				HandlerFunc: h.MultiLinearSearch,
 			},
 			{
@@ -111,6 +119,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/linearsearch/multi/{id}",
+				RPC:         "/vald.v1.Search/MultiLinearSearchByID",
 				HandlerFunc: h.MultiLinearSearchByID,
 			},
 			{
@@ -119,6 +128,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/insert",
+				RPC:         "/vald.v1.Insert/Insert",
 				HandlerFunc: h.Insert,
 			},
 			{
@@ -127,6 +137,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/insert/multi",
+				RPC:         "/vald.v1.Insert/MultiInsert",
 				HandlerFunc: h.MultiInsert,
 			},
 			{
@@ -137,6 +148,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/update",
+				RPC:         "/vald.v1.Update/Update",
 				HandlerFunc: h.Update,
 			},
 			{
@@ -147,6 +159,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/update/multi",
+				RPC:         "/vald.v1.Update/MultiUpdate",
 				HandlerFunc: h.MultiUpdate,
 			},
 			{
@@ -157,6 +170,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/upsert",
+				RPC:         "/vald.v1.Upsert/Upsert",
 				HandlerFunc: h.Upsert,
 			},
 			{
@@ -167,6 +181,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/upsert/multi",
+				RPC:         "/vald.v1.Upsert/MultiUpsert",
 				HandlerFunc: h.MultiUpsert,
 			},
 			{
@@ -175,6 +190,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodDelete,
 				},
 				Pattern:     "/delete/{id}",
+				RPC:         "/vald.v1.Remove/Remove",
 				HandlerFunc: h.Remove,
 			},
 			{
@@ -184,6 +200,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/delete/multi",
+				RPC:         "/vald.v1.Remove/MultiRemove",
 				HandlerFunc: h.MultiRemove,
 			},
 			{
@@ -192,6 +209,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodDelete,
 				},
 				Pattern:     "/flush",
+				RPC:         "/vald.v1.Flush/Flush",
 				HandlerFunc: h.Flush,
 			},
 			{
@@ -200,6 +218,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/object/{id}",
+				RPC:         "/vald.v1.Object/GetObject",
 				HandlerFunc: h.GetObject,
 			},
 		}...))
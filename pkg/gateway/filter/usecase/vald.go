@@ -29,6 +29,7 @@ import (
 	"github.com/vdaas/vald/internal/observability"
 	backoffmetrics "github.com/vdaas/vald/internal/observability/metrics/backoff"
 	cbmetrics "github.com/vdaas/vald/internal/observability/metrics/circuitbreaker"
+	ratelimitmetrics "github.com/vdaas/vald/internal/observability/metrics/ratelimit"
 	"github.com/vdaas/vald/internal/runner"
 	"github.com/vdaas/vald/internal/safety"
 	"github.com/vdaas/vald/internal/servers/server"
@@ -177,6 +178,7 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 			cfg.Observability,
 			backoffmetrics.New(),
 			cbmetrics.New(),
+			ratelimitmetrics.New(),
 		)
 		if err != nil {
 			return nil, err
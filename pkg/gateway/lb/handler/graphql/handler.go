@@ -0,0 +1,254 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package graphql provides graphql api logic
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/vdaas/vald/apis/grpc/v1/meta"
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/auth"
+	"github.com/vdaas/vald/internal/encoding/json"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/interceptor/server/ratelimit"
+	"github.com/vdaas/vald/internal/net/http/graphql"
+	"github.com/vdaas/vald/internal/safety"
+	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/internal/sync/errgroup"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Handler represents the GraphQL handler of the LB gateway.
+type Handler interface {
+	Query(w http.ResponseWriter, r *http.Request) (code int, err error)
+	Schema(w http.ResponseWriter, r *http.Request) (code int, err error)
+}
+
+type handler struct {
+	vald   vald.Server
+	meta   grpc.Client
+	schema *graphql.Schema
+}
+
+const payloadPrefix = "payload.v1."
+
+// New returns the GraphQL handler whose schema exposes the unary vald APIs.
+// Search and index information APIs are query fields, and the write APIs are mutation fields.
+func New(opts ...Option) Handler {
+	h := new(handler)
+
+	for _, opt := range append(defaultOptions, opts...) {
+		opt(h)
+	}
+	h.schema = h.newSchema()
+	return h
+}
+
+// Query executes the GraphQL request with a metadata loader, which fetches the metadata of the objects returned by the request at once.
+func (h *handler) Query(w http.ResponseWriter, r *http.Request) (int, error) {
+	if h.meta != nil {
+		r = r.WithContext(withMetaLoader(r.Context(), newMetaLoader(h.getMetas)))
+	}
+	return h.schema.Handler(w, r)
+}
+
+func (h *handler) Schema(w http.ResponseWriter, r *http.Request) (int, error) {
+	return h.schema.SDLHandler(w, r)
+}
+
+func (h *handler) newSchema() *graphql.Schema {
+	t := graphql.NewTypes(payloadPrefix)
+	s := h.vald
+
+	// metadata is joined to the search results and objects by their IDs.
+	for _, m := range []proto.Message{
+		new(payload.Object_Distance),
+		new(payload.Object_Vector),
+	} {
+		t.Object(m).AddField("meta", &graphql.Field{
+			Description: "metadata of the object, null when the metadata service is not configured",
+			Type:        graphql.JSON,
+			Resolve:     h.resolveMeta,
+		})
+	}
+
+	query := graphql.NewObject("Query", "vald read APIs")
+	query.AddField("exists", rpc(t, method(vald.ObjectRPCServiceName, vald.ExistsRPCName), s.Exists)).
+		AddField("search", rpc(t, method(vald.SearchRPCServiceName, vald.SearchRPCName), s.Search)).
+		AddField("searchByID", rpc(t, method(vald.SearchRPCServiceName, vald.SearchByIDRPCName), s.SearchByID)).
+		AddField("multiSearch", rpc(t, method(vald.SearchRPCServiceName, vald.MultiSearchRPCName), s.MultiSearch)).
+		AddField("multiSearchByID", rpc(t, method(vald.SearchRPCServiceName, vald.MultiSearchByIDRPCName), s.MultiSearchByID)).
+		AddField("linearSearch", rpc(t, method(vald.SearchRPCServiceName, vald.LinearSearchRPCName), s.LinearSearch)).
+		AddField("linearSearchByID", rpc(t, method(vald.SearchRPCServiceName, vald.LinearSearchByIDRPCName), s.LinearSearchByID)).
+		AddField("multiLinearSearch", rpc(t, method(vald.SearchRPCServiceName, vald.MultiLinearSearchRPCName), s.MultiLinearSearch)).
+		AddField("multiLinearSearchByID", rpc(t, method(vald.SearchRPCServiceName, vald.MultiLinearSearchByIDRPCName), s.MultiLinearSearchByID)).
+		AddField("getObject", rpc(t, method(vald.ObjectRPCServiceName, vald.GetObjectRPCName), s.GetObject)).
+		AddField("getTimestamp", rpc(t, method(vald.ObjectRPCServiceName, vald.GetTimestampRPCName), s.GetTimestamp)).
+		AddField("indexInfo", rpc(t, method(vald.IndexRPCServiceName, vald.IndexInfoRPCName), s.IndexInfo)).
+		AddField("indexDetail", rpc(t, method(vald.IndexRPCServiceName, vald.IndexDetailRPCName), s.IndexDetail)).
+		AddField("indexStatistics", rpc(t, method(vald.IndexRPCServiceName, vald.IndexStatisticsRPCName), s.IndexStatistics)).
+		AddField("indexStatisticsDetail", rpc(t, method(vald.IndexRPCServiceName, vald.IndexStatisticsDetailRPCName), s.IndexStatisticsDetail)).
+		AddField("indexProperty", rpc(t, method(vald.IndexRPCServiceName, vald.IndexPropertyRPCName), s.IndexProperty))
+
+	mutation := graphql.NewObject("Mutation", "vald write APIs")
+	mutation.AddField("insert", rpc(t, method(vald.InsertRPCServiceName, vald.InsertRPCName), s.Insert)).
+		AddField("multiInsert", rpc(t, method(vald.InsertRPCServiceName, vald.MultiInsertRPCName), s.MultiInsert)).
+		AddField("update", rpc(t, method(vald.UpdateRPCServiceName, vald.UpdateRPCName), s.Update)).
+		AddField("multiUpdate", rpc(t, method(vald.UpdateRPCServiceName, vald.MultiUpdateRPCName), s.MultiUpdate)).
+		AddField("updateTimestamp", rpc(t, method(vald.UpdateRPCServiceName, vald.UpdateTimestampRPCName), s.UpdateTimestamp)).
+		AddField("upsert", rpc(t, method(vald.UpsertRPCServiceName, vald.UpsertRPCName), s.Upsert)).
+		AddField("multiUpsert", rpc(t, method(vald.UpsertRPCServiceName, vald.MultiUpsertRPCName), s.MultiUpsert)).
+		AddField("remove", rpc(t, method(vald.RemoveRPCServiceName, vald.RemoveRPCName), s.Remove)).
+		AddField("multiRemove", rpc(t, method(vald.RemoveRPCServiceName, vald.MultiRemoveRPCName), s.MultiRemove)).
+		AddField("removeByTimestamp", rpc(t, method(vald.RemoveRPCServiceName, vald.RemoveByTimestampRPCName), s.RemoveByTimestamp)).
+		AddField("flush", rpc(t, method(vald.FlushRPCServiceName, vald.FlushRPCName), s.Flush))
+
+	return &graphql.Schema{
+		Query:    query,
+		Mutation: mutation,
+	}
+}
+
+// method returns the gRPC full method name of the RPC name of the vald service.
+func method(service, name string) string {
+	return "/" + vald.PackageName + "." + service + "/" + name
+}
+
+// rpc returns the field which calls the unary API fn with the request built from the field arguments.
+// The vectors of the request sent in a compact encoding are decoded, the same as the gRPC codec does.
+// The field is authorized as the gRPC full method m of fn with the IDs of the request, and the request is charged
+// to the rate limit budget of the client as m, the same as the gRPC API.
+func rpc[Q any, R proto.Message, PQ interface {
+	*Q
+	proto.Message
+}](t *graphql.Types, m string, fn func(context.Context, PQ) (R, error),
+) *graphql.Field {
+	var res R
+	return &graphql.Field{
+		Type: t.Object(res),
+		Args: t.Args(PQ(new(Q))),
+		Resolve: func(ctx context.Context, p graphql.ResolveParams) (any, error) {
+			req := PQ(new(Q))
+			if err := graphql.UnmarshalArgs(p.Args, req); err != nil {
+				return nil, err
+			}
+			if v, ok := any(req).(interface{ DecodeVectors() error }); ok {
+				if err := v.DecodeVectors(); err != nil {
+					return nil, err
+				}
+			}
+			if err := auth.AuthorizeOperation(ctx, m, auth.RequestIDs(req)); err != nil {
+				return nil, err
+			}
+			if err := ratelimit.Charge(ctx, m, req); err != nil {
+				return nil, err
+			}
+			res, err := fn(ctx, req)
+			if err == nil {
+				primeMeta(ctx, res)
+			}
+			return res, err
+		},
+	}
+}
+
+func (h *handler) resolveMeta(ctx context.Context, p graphql.ResolveParams) (any, error) {
+	if h.meta == nil {
+		return nil, nil
+	}
+	var id string
+	switch src := p.Source.(type) {
+	case *payload.Object_Distance:
+		id = src.GetId()
+	case *payload.Object_Vector:
+		id = src.GetId()
+	}
+	if id == "" {
+		return nil, nil
+	}
+	var (
+		v   *payload.Meta_Value
+		err error
+	)
+	if l := metaLoaderFrom(ctx); l != nil {
+		v, err = l.load(ctx, id)
+	} else {
+		var (
+			vs   map[string]*payload.Meta_Value
+			errs map[string]error
+		)
+		vs, errs, err = h.getMetas(ctx, []string{id})
+		v = vs[id]
+		if err == nil {
+			err = errs[id]
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if v.GetValue() == nil {
+		return nil, nil
+	}
+	// the value is decoded as JSON when its type is known, otherwise the raw bytes are returned.
+	if b, err := protojson.Marshal(v.GetValue()); err == nil {
+		var m any
+		if err = json.Unmarshal(b, &m); err == nil {
+			return m, nil
+		}
+	}
+	return map[string]any{
+		"@type": v.GetValue().GetTypeUrl(),
+		"value": base64.StdEncoding.EncodeToString(v.GetValue().GetValue()),
+	}, nil
+}
+
+// getMetas fetches the metadata of keys from a metadata service connection, up to metaConcurrency keys at a time.
+// The errors of the keys which failed to be fetched are returned in errs, and err is the error of the connection.
+func (h *handler) getMetas(
+	ctx context.Context, keys []string,
+) (vs map[string]*payload.Meta_Value, errs map[string]error, err error) {
+	vs = make(map[string]*payload.Meta_Value, len(keys))
+	errs = make(map[string]error)
+	_, err = h.meta.RoundRobin(ctx, func(ctx context.Context,
+		conn *grpc.ClientConn, copts ...grpc.CallOption,
+	) (any, error) {
+		var mu sync.Mutex
+		client := meta.NewMetaClient(conn)
+		eg, egctx := errgroup.New(ctx)
+		eg.SetLimit(metaConcurrency)
+		for _, key := range keys {
+			eg.Go(safety.RecoverFunc(func() error {
+				v, err := client.Get(egctx, &payload.Meta_Key{Key: key}, copts...)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs[key] = err
+					return nil
+				}
+				vs[key] = v
+				return nil
+			}))
+		}
+		return nil, eg.Wait()
+	})
+	return vs, errs, err
+}
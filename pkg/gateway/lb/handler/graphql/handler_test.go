@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/auth"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+type valdServer struct {
+	vald.UnimplementedValdServer
+}
+
+func (*valdServer) Search(_ context.Context, req *payload.Search_Request) (*payload.Search_Response, error) {
+	return &payload.Search_Response{
+		RequestId: req.GetConfig().GetRequestId(),
+		Results: []*payload.Object_Distance{
+			{Id: "vald", Distance: 0.5},
+		},
+	}, nil
+}
+
+func (*valdServer) IndexInfo(context.Context, *payload.Empty) (*payload.Info_Index_Count, error) {
+	return &payload.Info_Index_Count{Stored: 10, Uncommitted: 1}, nil
+}
+
+func Test_handler_Query(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		code int
+		body string
+	}
+	type test struct {
+		name   string
+		method string
+		target string
+		body   string
+		want   want
+	}
+	tests := []test{
+		{
+			name:   "returns search results and index info in one request",
+			method: http.MethodPost,
+			target: "/graphql",
+			body: `{"query":"{ search(vector: [0.1], config: {requestId: \"r\", num: 1}) { requestId results { id distance meta } } ` +
+				`indexInfo { stored uncommitted } }"}`,
+			want: want{
+				code: http.StatusOK,
+				body: `{"data":{"search":{"requestId":"r","results":[{"id":"vald","distance":0.5,"meta":null}]},` +
+					`"indexInfo":{"stored":10,"uncommitted":1}}}` + "\n",
+			},
+		},
+		{
+			name:   "returns responses of the batched requests in order",
+			method: http.MethodPost,
+			target: "/graphql",
+			body:   `[{"query":"{ indexInfo { stored } }"},{"query":"{ exists(id: \"vald\") { id } }"}]`,
+			want: want{
+				code: http.StatusOK,
+				body: `[{"data":{"indexInfo":{"stored":10}}},` +
+					`{"data":{"exists":null},"errors":[{"message":"rpc error: code = Unimplemented desc = method Exists not implemented","path":["exists"]}]}]` + "\n",
+			},
+		},
+		{
+			name:   "returns index info for GET request",
+			method: http.MethodGet,
+			target: "/graphql?query=%7BindexInfo%7Buncommitted%7D%7D",
+			want: want{
+				code: http.StatusOK,
+				body: `{"data":{"indexInfo":{"uncommitted":1}}}` + "\n",
+			},
+		},
+	}
+
+	h := New(WithVald(new(valdServer)))
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			r := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+			code, err := h.Query(w, r)
+			if err != nil {
+				tt.Fatal(err)
+			}
+			if code != test.want.code {
+				tt.Errorf("code not equals. want: %d, got: %d", test.want.code, code)
+			}
+			if got := w.Body.String(); got != test.want.body {
+				tt.Errorf("body not equals. want: %s, got: %s", test.want.body, got)
+			}
+		})
+	}
+}
+
+func Test_handler_Query_authorization(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		body string
+	}
+	type test struct {
+		name string
+		body string
+		want want
+	}
+	tests := []test{
+		{
+			name: "resolve the field whose method is granted",
+			body: `{"query":"{ indexInfo { stored } }"}`,
+			want: want{
+				body: `{"data":{"indexInfo":{"stored":10}}}` + "\n",
+			},
+		},
+		{
+			name: "return the error of the field whose method is not granted",
+			body: `{"query":"{ indexInfo { stored } search(vector: [0.1], config: {num: 1}) { requestId } }"}`,
+			want: want{
+				body: `{"data":{"indexInfo":{"stored":10},"search":null},` +
+					`"errors":[{"message":"permission denied: /vald.v1.Search/Search","path":["search"]}]}` + "\n",
+			},
+		},
+	}
+
+	h := New(WithVald(new(valdServer)))
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(test.body))
+			r = r.WithContext(auth.NewAuthorizerContext(r.Context(), func(method string, _ []string) error {
+				if method != "/vald.v1.Index/IndexInfo" {
+					return errors.New("permission denied: " + method)
+				}
+				return nil
+			}))
+			w := httptest.NewRecorder()
+			if _, err := h.Query(w, r); err != nil {
+				tt.Fatal(err)
+			}
+			if got := w.Body.String(); got != test.want.body {
+				tt.Errorf("body not equals. want: %s, got: %s", test.want.body, got)
+			}
+		})
+	}
+}
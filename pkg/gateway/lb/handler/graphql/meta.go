@@ -0,0 +1,133 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package graphql provides graphql api logic
+package graphql
+
+import (
+	"context"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/sync"
+	"google.golang.org/protobuf/proto"
+)
+
+// metaConcurrency is the maximum number of the metadata fetched concurrently by a batch of the metadata loader.
+const metaConcurrency = 16
+
+type metaLoaderKey struct{}
+
+// metaLoader batches the metadata lookups of a GraphQL request.
+// The IDs of the objects returned by the APIs are primed to the loader, and the first lookup fetches the metadata
+// of all the primed IDs by a batch, so that the metadata of a search response is not fetched one object by one.
+type metaLoader struct {
+	get metaGetter
+
+	mu      sync.Mutex
+	primed  map[string]struct{}
+	fetched map[string]*payload.Meta_Value
+	err     map[string]error
+}
+
+// metaGetter fetches the metadata of keys, and returns the errors of the keys failed to be fetched and the error of the whole fetch.
+type metaGetter func(ctx context.Context, keys []string) (vs map[string]*payload.Meta_Value, errs map[string]error, err error)
+
+func newMetaLoader(get metaGetter) *metaLoader {
+	return &metaLoader{
+		get:     get,
+		primed:  make(map[string]struct{}),
+		fetched: make(map[string]*payload.Meta_Value),
+		err:     make(map[string]error),
+	}
+}
+
+func withMetaLoader(ctx context.Context, l *metaLoader) context.Context {
+	return context.WithValue(ctx, metaLoaderKey{}, l)
+}
+
+func metaLoaderFrom(ctx context.Context) *metaLoader {
+	l, _ := ctx.Value(metaLoaderKey{}).(*metaLoader)
+	return l
+}
+
+// primeMeta primes the IDs of the objects of the API response res to the metadata loader of ctx.
+func primeMeta(ctx context.Context, res proto.Message) {
+	l := metaLoaderFrom(ctx)
+	if l == nil {
+		return
+	}
+	switch x := res.(type) {
+	case *payload.Search_Response:
+		for _, r := range x.GetResults() {
+			l.prime(r.GetId())
+		}
+	case *payload.Search_Responses:
+		for _, res := range x.GetResponses() {
+			for _, r := range res.GetResults() {
+				l.prime(r.GetId())
+			}
+		}
+	case *payload.Object_Vector:
+		l.prime(x.GetId())
+	}
+}
+
+func (l *metaLoader) prime(id string) {
+	if id == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.fetched[id]; ok {
+		return
+	}
+	if _, ok := l.err[id]; ok {
+		return
+	}
+	l.primed[id] = struct{}{}
+}
+
+// load returns the metadata of id. When it is not fetched yet, it is fetched together with all the primed IDs.
+func (l *metaLoader) load(ctx context.Context, id string) (*payload.Meta_Value, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if v, ok := l.fetched[id]; ok {
+		return v, nil
+	}
+	if err, ok := l.err[id]; ok {
+		return nil, err
+	}
+	l.primed[id] = struct{}{}
+	keys := make([]string, 0, len(l.primed))
+	for key := range l.primed {
+		keys = append(keys, key)
+	}
+	clear(l.primed)
+	vs, errs, err := l.get(ctx, keys)
+	for _, key := range keys {
+		if v, ok := vs[key]; ok {
+			l.fetched[key] = v
+		} else if err != nil {
+			l.err[key] = err
+		} else {
+			l.err[key] = errs[key]
+		}
+	}
+	if v, ok := l.fetched[id]; ok {
+		return v, nil
+	}
+	return nil, l.err[id]
+}
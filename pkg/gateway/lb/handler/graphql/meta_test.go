@@ -0,0 +1,119 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package graphql provides graphql api logic
+package graphql
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func Test_metaLoader_load(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		calls [][]string
+		found []string
+		err   map[string]error
+	}
+	type test struct {
+		name  string
+		prime []string
+		load  []string
+		want  want
+	}
+	tests := []test{
+		{
+			name:  "fetch the metadata of all the primed IDs by the first load",
+			prime: []string{"a", "b", "c"},
+			load:  []string{"a", "b", "c"},
+			want: want{
+				calls: [][]string{{"a", "b", "c"}},
+				found: []string{"a", "b", "c"},
+			},
+		},
+		{
+			name: "fetch the metadata of the ID which is not primed by its load",
+			load: []string{"a", "b"},
+			want: want{
+				calls: [][]string{{"a"}, {"b"}},
+				found: []string{"a", "b"},
+			},
+		},
+		{
+			name:  "return the error of the ID which failed to be fetched without fetching it again",
+			prime: []string{"a", "missing"},
+			load:  []string{"a", "missing", "missing"},
+			want: want{
+				calls: [][]string{{"a", "missing"}},
+				found: []string{"a"},
+				err: map[string]error{
+					"missing": errors.ErrInvalidRequest,
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			var calls [][]string
+			l := newMetaLoader(func(_ context.Context, keys []string) (map[string]*payload.Meta_Value, map[string]error, error) {
+				keys = slices.Clone(keys)
+				slices.Sort(keys)
+				calls = append(calls, keys)
+				vs := make(map[string]*payload.Meta_Value)
+				errs := make(map[string]error)
+				for _, key := range keys {
+					if key == "missing" {
+						errs[key] = errors.ErrInvalidRequest
+						continue
+					}
+					vs[key] = &payload.Meta_Value{Value: &anypb.Any{TypeUrl: key}}
+				}
+				return vs, errs, nil
+			})
+			for _, id := range test.prime {
+				l.prime(id)
+			}
+			var found []string
+			for _, id := range test.load {
+				v, err := l.load(tt.Context(), id)
+				if !errors.Is(err, test.want.err[id]) {
+					tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want.err[id])
+				}
+				if err != nil {
+					continue
+				}
+				if v.GetValue().GetTypeUrl() != id {
+					tt.Errorf("got_value: %v,\n\t\t\t\twant: %s", v, id)
+				}
+				found = append(found, id)
+			}
+			if !slices.EqualFunc(calls, test.want.calls, slices.Equal) {
+				tt.Errorf("got_calls: %v,\n\t\t\t\twant: %v", calls, test.want.calls)
+			}
+			if !slices.Equal(found, test.want.found) {
+				tt.Errorf("got_found: %v,\n\t\t\t\twant: %v", found, test.want.found)
+			}
+		})
+	}
+}
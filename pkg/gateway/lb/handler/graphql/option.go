@@ -0,0 +1,42 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package graphql provides graphql api logic
+package graphql
+
+import (
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/net/grpc"
+)
+
+type Option func(*handler)
+
+var defaultOptions = []Option{}
+
+func WithVald(v vald.Server) Option {
+	return func(h *handler) {
+		h.vald = v
+	}
+}
+
+// WithMetaClient returns the option to set the client of the metadata service used to join metadata to the results.
+func WithMetaClient(c grpc.Client) Option {
+	return func(h *handler) {
+		if c != nil {
+			h.meta = c
+		}
+	}
+}
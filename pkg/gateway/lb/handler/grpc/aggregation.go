@@ -50,10 +50,14 @@ type DistPayload struct {
 	distance *big.Float
 }
 
+// aggregationSearch broadcasts the search to the agents of kind and aggregates their results.
+// When answered is not nil, the agents which answered the search, including the agents which found no result, are stored in it.
 func (s *server) aggregationSearch(
 	ctx context.Context,
 	aggr Aggregator,
+	kind service.BroadCastKind,
 	bcfg *payload.Search_Config, // Base Config of Request
+	answered *sync.Map[string, struct{}],
 	f func(ctx context.Context,
 		fcfg *payload.Search_Config, // Forwarding Config to Agent
 		vc vald.Client, copts ...grpc.CallOption) (*payload.Search_Response, error),
@@ -76,16 +80,22 @@ func (s *server) aggregationSearch(
 	if to := bcfg.GetTimeout(); to != 0 {
 		timeout = time.Duration(to)
 	} else {
-		timeout = s.timeout
+		timeout = time.Duration(s.timeout.Load())
 	}
 
 	fcfg := bcfg.CloneVT() // Forwarding Config to Agent, this config need to modify like below so it should be cloned
 	fcfg.Num = uint32(aggr.GetFnum())
 	fcfg.MinNum = 0
 
+	answer := func(target string) {
+		if answered != nil {
+			answered.Store(target, struct{}{})
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	aggr.Start(ctx)
-	err = s.gateway.BroadCast(ctx, service.READ, func(ctx context.Context, target string, vc vald.Client, copts ...grpc.CallOption) error {
+	err = s.gateway.BroadCast(ctx, kind, func(ctx context.Context, target string, vc vald.Client, copts ...grpc.CallOption) error {
 		sctx, sspan := trace.StartSpan(grpc.WrapGRPCMethod(ctx, "BroadCast/"+target), apiName+"/aggregationSearch/"+target)
 		defer func() {
 			if sspan != nil {
@@ -134,8 +144,10 @@ func (s *server) aggregationSearch(
 					codes.ResourceExhausted:
 					log.Warn(err)
 					return err
-				case codes.NotFound,
-					codes.Aborted,
+				case codes.NotFound:
+					answer(target)
+					return nil
+				case codes.Aborted,
 					codes.InvalidArgument:
 					return nil
 				}
@@ -156,6 +168,7 @@ func (s *server) aggregationSearch(
 					sspan.SetAttributes(trace.StatusCodeNotFound(err.Error())...)
 					sspan.SetStatus(trace.StatusError, err.Error())
 				}
+				answer(target)
 				return nil
 			default:
 				r, err = f(sctx, fcfg, vc, copts...)
@@ -200,8 +213,10 @@ func (s *server) aggregationSearch(
 							codes.ResourceExhausted:
 							log.Warn(err)
 							return err
-						case codes.NotFound,
-							codes.Aborted,
+						case codes.NotFound:
+							answer(target)
+							return nil
+						case codes.Aborted,
 							codes.InvalidArgument:
 							return nil
 						}
@@ -221,10 +236,12 @@ func (s *server) aggregationSearch(
 						sspan.SetStatus(trace.StatusError, err.Error())
 					}
 					log.Debug(err)
+					answer(target)
 					return nil
 				}
 			}
 		}
+		answer(target)
 		aggr.Send(sctx, r)
 		return nil
 	})
@@ -0,0 +1,218 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package grpc provides grpc server logic
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/codes"
+	"github.com/vdaas/vald/internal/net/grpc/errdetails"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+	"github.com/vdaas/vald/internal/observability/trace"
+	"github.com/vdaas/vald/internal/strings"
+	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/pkg/gateway/lb/service"
+)
+
+// CreateCollection creates the collection on every agent.
+// Agents which already host the collection are treated as successful so that a partially failed request can be retried.
+func (s *server) CreateCollection(
+	ctx context.Context, req *payload.Collection_Config,
+) (res *payload.Empty, err error) {
+	ctx, span := trace.StartSpan(grpc.WithGRPCMethod(ctx, vald.PackageName+"."+vald.CollectionRPCServiceName+"/"+vald.CreateCollectionRPCName), apiName+"/"+vald.CreateCollectionRPCName)
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	var created, exists uint32
+	err = s.broadCastCollection(ctx, service.WRITE, vald.CreateCollectionRPCName, req, codes.AlreadyExists,
+		func(ctx context.Context, cc vald.CollectionClient, copts ...grpc.CallOption) error {
+			_, err := cc.CreateCollection(ctx, req, copts...)
+			if err != nil {
+				if st, ok := status.FromError(err); ok && st.Code() == codes.AlreadyExists {
+					atomic.AddUint32(&exists, 1)
+				}
+				return err
+			}
+			atomic.AddUint32(&created, 1)
+			return nil
+		})
+	if err == nil && atomic.LoadUint32(&created) == 0 && atomic.LoadUint32(&exists) > 0 {
+		err = status.WrapWithAlreadyExists(
+			fmt.Sprintf("%s API collection %s already exists", vald.CreateCollectionRPCName, req.GetName()),
+			errors.ErrCollectionAlreadyExists(req.GetName()),
+			&errdetails.RequestInfo{
+				ServingData: errdetails.Serialize(req),
+			},
+			&errdetails.ResourceInfo{
+				ResourceType: errdetails.ValdGRPCResourceTypePrefix + "/vald.v1." + vald.CreateCollectionRPCName,
+				ResourceName: fmt.Sprintf("%s: %s(%s) to %v", apiName, s.name, s.ip, s.gateway.Addrs(ctx)),
+			})
+	}
+	if err != nil {
+		st, _ := status.FromError(err)
+		if span != nil {
+			span.RecordError(err)
+			span.SetAttributes(trace.FromGRPCStatus(st.Code(), st.Message())...)
+			span.SetStatus(trace.StatusError, err.Error())
+		}
+		return nil, err
+	}
+	return new(payload.Empty), nil
+}
+
+// DropCollection drops the collection from every agent.
+func (s *server) DropCollection(
+	ctx context.Context, req *payload.Collection_Name,
+) (res *payload.Empty, err error) {
+	ctx, span := trace.StartSpan(grpc.WithGRPCMethod(ctx, vald.PackageName+"."+vald.CollectionRPCServiceName+"/"+vald.DropCollectionRPCName), apiName+"/"+vald.DropCollectionRPCName)
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	var dropped uint32
+	err = s.broadCastCollection(ctx, service.WRITE, vald.DropCollectionRPCName, req, codes.NotFound,
+		func(ctx context.Context, cc vald.CollectionClient, copts ...grpc.CallOption) error {
+			_, err := cc.DropCollection(ctx, req, copts...)
+			if err != nil {
+				return err
+			}
+			atomic.AddUint32(&dropped, 1)
+			return nil
+		})
+	if err == nil && atomic.LoadUint32(&dropped) == 0 {
+		err = status.WrapWithNotFound(
+			fmt.Sprintf("%s API collection %s not found", vald.DropCollectionRPCName, req.GetName()),
+			errors.ErrCollectionNotFound(req.GetName()),
+			&errdetails.RequestInfo{
+				ServingData: errdetails.Serialize(req),
+			},
+			&errdetails.ResourceInfo{
+				ResourceType: errdetails.ValdGRPCResourceTypePrefix + "/vald.v1." + vald.DropCollectionRPCName,
+				ResourceName: fmt.Sprintf("%s: %s(%s) to %v", apiName, s.name, s.ip, s.gateway.Addrs(ctx)),
+			})
+	}
+	if err != nil {
+		st, _ := status.FromError(err)
+		if span != nil {
+			span.RecordError(err)
+			span.SetAttributes(trace.FromGRPCStatus(st.Code(), st.Message())...)
+			span.SetStatus(trace.StatusError, err.Error())
+		}
+		return nil, err
+	}
+	return new(payload.Empty), nil
+}
+
+// ListCollections returns the union of the collections hosted by the agents.
+func (s *server) ListCollections(
+	ctx context.Context, req *payload.Empty,
+) (res *payload.Collection_List, err error) {
+	ctx, span := trace.StartSpan(grpc.WithGRPCMethod(ctx, vald.PackageName+"."+vald.CollectionRPCServiceName+"/"+vald.ListCollectionsRPCName), apiName+"/"+vald.ListCollectionsRPCName)
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	var cols sync.Map[string, *payload.Collection_Config]
+	err = s.broadCastCollection(ctx, service.READ, vald.ListCollectionsRPCName, req, codes.Unimplemented,
+		func(ctx context.Context, cc vald.CollectionClient, copts ...grpc.CallOption) error {
+			list, err := cc.ListCollections(ctx, req, copts...)
+			if err != nil {
+				return err
+			}
+			for _, col := range list.GetCollections() {
+				cols.LoadOrStore(col.GetName(), col)
+			}
+			return nil
+		})
+	if err != nil {
+		st, _ := status.FromError(err)
+		if span != nil {
+			span.RecordError(err)
+			span.SetAttributes(trace.FromGRPCStatus(st.Code(), st.Message())...)
+			span.SetStatus(trace.StatusError, err.Error())
+		}
+		return nil, err
+	}
+	res = new(payload.Collection_List)
+	cols.Range(func(_ string, col *payload.Collection_Config) bool {
+		res.Collections = append(res.GetCollections(), col)
+		return true
+	})
+	slices.SortFunc(res.Collections, func(a, b *payload.Collection_Config) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return res, nil
+}
+
+// broadCastCollection calls f on every agent, agent errors with the ignore code are not reported.
+func (s *server) broadCastCollection(
+	ctx context.Context,
+	kind service.BroadCastKind,
+	rpc string,
+	req any,
+	ignore codes.Code,
+	f func(ctx context.Context, cc vald.CollectionClient, copts ...grpc.CallOption) error,
+) error {
+	now := time.Now().UnixNano()
+	return s.gateway.BroadCastCollection(ctx, kind, func(ctx context.Context, target string, cc vald.CollectionClient, copts ...grpc.CallOption) error {
+		ctx, span := trace.StartSpan(ctx, apiName+"."+rpc+"/"+target)
+		defer func() {
+			if span != nil {
+				span.End()
+			}
+		}()
+		err := f(ctx, cc, copts...)
+		if err == nil {
+			return nil
+		}
+		st, msg, err := status.ParseError(err, codes.Internal,
+			"failed to parse "+rpc+" gRPC error response",
+			&errdetails.RequestInfo{
+				RequestId:   strconv.FormatInt(now, 10),
+				ServingData: errdetails.Serialize(req),
+			},
+			&errdetails.ResourceInfo{
+				ResourceType: errdetails.ValdGRPCResourceTypePrefix + "/vald.v1." + rpc,
+				ResourceName: fmt.Sprintf("%s: %s(%s) to %s", apiName, s.name, s.ip, target),
+			})
+		if span != nil {
+			span.RecordError(err)
+			span.SetAttributes(trace.FromGRPCStatus(st.Code(), msg)...)
+			span.SetStatus(trace.StatusError, err.Error())
+		}
+		if st.Code() == ignore {
+			return nil
+		}
+		log.Error(err)
+		return err
+	})
+}
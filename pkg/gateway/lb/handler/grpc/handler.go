@@ -18,6 +18,7 @@
 package grpc
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/vdaas/vald/apis/grpc/v1/vald"
@@ -25,21 +26,36 @@ import (
 	"github.com/vdaas/vald/pkg/gateway/lb/service"
 )
 
+// Server represents the LB gateway gRPC server which also serves the collection admin RPCs.
+type Server interface {
+	vald.Server
+	vald.CollectionServer
+	// SetTimeout replaces the default timeout of the search requests which do not set their own timeout.
+	// A non-positive timeout restores the built-in default.
+	SetTimeout(timeout time.Duration)
+}
+
 type server struct {
 	eg                errgroup.Group
 	gateway           service.Gateway
-	timeout           time.Duration
+	timeout           atomic.Int64 // default search timeout, it is replaced when the configuration is reloaded
 	replica           int
 	streamConcurrency int
 	multiConcurrency  int
 	name              string
 	ip                string
 	vald.UnimplementedValdServer
+	vald.UnimplementedCollectionServer
 }
 
-const apiName = "vald/gateway/lb"
+const (
+	apiName = "vald/gateway/lb"
+
+	// defaultTimeout is the default timeout of the search requests used when it is not configured.
+	defaultTimeout = 5 * time.Second
+)
 
-func New(opts ...Option) vald.Server {
+func New(opts ...Option) Server {
 	s := new(server)
 
 	for _, opt := range append(defaultOptions, opts...) {
@@ -47,3 +63,12 @@ func New(opts ...Option) vald.Server {
 	}
 	return s
 }
+
+// SetTimeout replaces the default timeout of the search requests which do not set their own timeout.
+// A non-positive timeout restores the built-in default.
+func (s *server) SetTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	s.timeout.Store(int64(timeout))
+}
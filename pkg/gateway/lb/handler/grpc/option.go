@@ -36,7 +36,7 @@ var defaultOptions = []Option{
 	WithReplicationCount(3),
 	WithStreamConcurrency(runtime.GOMAXPROCS(-1) * 10),
 	WithMultiConcurrency(runtime.GOMAXPROCS(-1) * 10),
-	WithTimeout("5s"),
+	WithTimeout(defaultTimeout.String()),
 	WithName(func() string {
 		name, err := os.Hostname()
 		if err != nil {
@@ -83,11 +83,14 @@ func WithErrGroup(eg errgroup.Group) Option {
 
 func WithTimeout(dur string) Option {
 	return func(s *server) {
+		if len(dur) == 0 {
+			return
+		}
 		d, err := timeutil.Parse(dur)
 		if err != nil {
 			d = time.Second * 10
 		}
-		s.timeout = d
+		s.timeout.Store(int64(d))
 	}
 }
 
@@ -27,6 +27,7 @@ import (
 	"github.com/vdaas/vald/apis/grpc/v1/vald"
 	"github.com/vdaas/vald/internal/core/algorithm"
 	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
 	"github.com/vdaas/vald/internal/net/grpc"
 	"github.com/vdaas/vald/internal/net/grpc/codes"
 	"github.com/vdaas/vald/internal/net/grpc/errdetails"
@@ -35,6 +36,7 @@ import (
 	"github.com/vdaas/vald/internal/observability/trace"
 	"github.com/vdaas/vald/internal/safety"
 	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/pkg/gateway/lb/service"
 )
 
 func (s *server) Search(
@@ -232,7 +234,18 @@ func (s *server) doSearch(
 		fnum = num
 	}
 
-	return s.aggregationSearch(ctx, selectAggregator(cfg.GetAggregationAlgorithm(), num, fnum, replica), cfg, f)
+	if addrs := s.gateway.LocalZoneAddrs(ctx); len(addrs) != 0 {
+		var answered sync.Map[string, struct{}]
+		res, attrs, err = s.aggregationSearch(ctx, selectAggregator(cfg.GetAggregationAlgorithm(), num, fnum, replica), service.SEARCH, cfg, &answered, f)
+		if answered.Len() >= len(addrs) || ctx.Err() != nil {
+			// every vector has a replica in the local zone, so the results of the local zone are complete once all of its agents answered.
+			return res, attrs, err
+		}
+		// the vectors stored in the agents which did not answer are missed, so all agents are searched again.
+		log.Debugf("local zone search was answered by %d of %d agents, falling back to all agents: %v", answered.Len(), len(addrs), err)
+	}
+
+	return s.aggregationSearch(ctx, selectAggregator(cfg.GetAggregationAlgorithm(), num, fnum, replica), service.READ, cfg, nil, f)
 }
 
 func selectAggregator(algo payload.Search_AggregationAlgorithm, num, fnum, replica int) Aggregator {
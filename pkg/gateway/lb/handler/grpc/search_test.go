@@ -13,6 +13,178 @@
 // limitations under the License.
 package grpc
 
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/sync"
+	"github.com/vdaas/vald/pkg/gateway/lb/service"
+)
+
+type responseKey struct{}
+
+// zoneGateway is the service.Gateway which returns the responses of each broadcast kind.
+type zoneGateway struct {
+	service.Gateway
+	local     bool
+	responses map[service.BroadCastKind][]*payload.Search_Response
+
+	mu    sync.Mutex
+	kinds []service.BroadCastKind
+}
+
+func (g *zoneGateway) GetAgentCount(context.Context) int {
+	return 4
+}
+
+func (g *zoneGateway) Addrs(context.Context) []string {
+	return []string{"a1", "a2", "b1", "b2"}
+}
+
+func (g *zoneGateway) LocalZoneAddrs(context.Context) []string {
+	if g.local {
+		return []string{"a1", "a2"}
+	}
+	return nil
+}
+
+func (g *zoneGateway) BroadCast(ctx context.Context, kind service.BroadCastKind,
+	f func(ctx context.Context, target string, ac vald.Client, copts ...grpc.CallOption) error,
+) (err error) {
+	g.mu.Lock()
+	g.kinds = append(g.kinds, kind)
+	g.mu.Unlock()
+	for i, res := range g.responses[kind] {
+		err = errors.Join(err, f(context.WithValue(ctx, responseKey{}, res), g.Addrs(ctx)[i], nil))
+	}
+	return err
+}
+
+func Test_server_doSearch(t *testing.T) {
+	t.Parallel()
+	local := []*payload.Search_Response{
+		{Results: []*payload.Object_Distance{{Id: "a", Distance: 0.1}}},
+		{Results: []*payload.Object_Distance{{Id: "b", Distance: 0.3}}},
+	}
+	all := append(slices.Clone(local), &payload.Search_Response{
+		Results: []*payload.Object_Distance{{Id: "c", Distance: 0.2}},
+	})
+	type want struct {
+		ids   []string
+		kinds []service.BroadCastKind
+	}
+	type test struct {
+		name      string
+		num       uint32
+		local     bool
+		responses map[service.BroadCastKind][]*payload.Search_Response
+		want      want
+	}
+	tests := []test{
+		{
+			name:  "return the local zone results when every agent in the local zone answered",
+			num:   2,
+			local: true,
+			responses: map[service.BroadCastKind][]*payload.Search_Response{
+				service.SEARCH: local,
+				service.READ:   all,
+			},
+			want: want{
+				ids:   []string{"a", "b"},
+				kinds: []service.BroadCastKind{service.SEARCH},
+			},
+		},
+		{
+			name:  "return the local zone results even if they are fewer than required when every agent in the local zone answered",
+			num:   3,
+			local: true,
+			responses: map[service.BroadCastKind][]*payload.Search_Response{
+				service.SEARCH: local,
+				service.READ:   all,
+			},
+			want: want{
+				ids:   []string{"a", "b"},
+				kinds: []service.BroadCastKind{service.SEARCH},
+			},
+		},
+		{
+			name:  "search all agents when an agent in the local zone did not answer",
+			num:   2,
+			local: true,
+			responses: map[service.BroadCastKind][]*payload.Search_Response{
+				service.SEARCH: local[:1],
+				service.READ:   all,
+			},
+			want: want{
+				ids:   []string{"a", "c"},
+				kinds: []service.BroadCastKind{service.SEARCH, service.READ},
+			},
+		},
+		{
+			name:  "search all agents when the agents in the local zone are down",
+			num:   2,
+			local: true,
+			responses: map[service.BroadCastKind][]*payload.Search_Response{
+				service.READ: all,
+			},
+			want: want{
+				ids:   []string{"a", "c"},
+				kinds: []service.BroadCastKind{service.SEARCH, service.READ},
+			},
+		},
+		{
+			name: "search all agents once when the search is not limited to the local zone",
+			num:  2,
+			responses: map[service.BroadCastKind][]*payload.Search_Response{
+				service.READ: all,
+			},
+			want: want{
+				ids:   []string{"a", "c"},
+				kinds: []service.BroadCastKind{service.READ},
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			g := &zoneGateway{
+				local:     test.local,
+				responses: test.responses,
+			}
+			s := &server{
+				gateway: g,
+				replica: 1,
+			}
+			s.SetTimeout(time.Second)
+			res, _, err := s.doSearch(tt.Context(), &payload.Search_Config{Num: test.num},
+				func(ctx context.Context, _ *payload.Search_Config, _ vald.Client, _ ...grpc.CallOption) (*payload.Search_Response, error) {
+					return ctx.Value(responseKey{}).(*payload.Search_Response), nil
+				})
+			if err != nil {
+				tt.Fatal(err)
+			}
+			ids := make([]string, 0, len(res.GetResults()))
+			for _, r := range res.GetResults() {
+				ids = append(ids, r.GetId())
+			}
+			if !reflect.DeepEqual(ids, test.want.ids) {
+				tt.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", ids, test.want.ids)
+			}
+			if !reflect.DeepEqual(g.kinds, test.want.kinds) {
+				tt.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", g.kinds, test.want.kinds)
+			}
+		})
+	}
+}
+
 // NOT IMPLEMENTED BELOW
 //
 // func Test_server_Search(t *testing.T) {
@@ -203,6 +203,7 @@ func (s *server) Update(
 						SkipStrictExistCheck: true,
 						Filters:              req.GetConfig().GetFilters(),
 						Timestamp:            req.GetConfig().GetTimestamp(),
+						Ttl:                  req.GetConfig().GetTtl(),
 					},
 				}, copts...)
 				if err != nil {
@@ -336,6 +337,7 @@ func (s *server) Update(
 			SkipStrictExistCheck: true,
 			Filters:              req.GetConfig().GetFilters(),
 			Timestamp:            now,
+			Ttl:                  req.GetConfig().GetTtl(),
 		},
 	}
 	res, err = s.Insert(ctx, ireq)
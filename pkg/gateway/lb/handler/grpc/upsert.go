@@ -161,6 +161,7 @@ func (s *server) Upsert(
 				SkipStrictExistCheck: true,
 				Filters:              req.GetConfig().GetFilters(),
 				Timestamp:            req.GetConfig().GetTimestamp(),
+				Ttl:                  req.GetConfig().GetTtl(),
 			},
 		})
 	} else {
@@ -172,6 +173,7 @@ func (s *server) Upsert(
 				Filters:               req.GetConfig().GetFilters(),
 				Timestamp:             req.GetConfig().GetTimestamp(),
 				DisableBalancedUpdate: req.GetConfig().GetDisableBalancedUpdate(),
+				Ttl:                   req.GetConfig().GetTtl(),
 			},
 		})
 	}
@@ -22,8 +22,15 @@ import (
 
 	"github.com/vdaas/vald/apis/grpc/v1/payload"
 	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/apis/swagger"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/info"
+	"github.com/vdaas/vald/internal/io"
 	"github.com/vdaas/vald/internal/net/http/dump"
 	"github.com/vdaas/vald/internal/net/http/json"
+	"github.com/vdaas/vald/internal/net/http/rest"
+	"github.com/vdaas/vald/internal/net/http/routing"
+	"github.com/vdaas/vald/internal/sync"
 )
 
 type Handler interface {
@@ -47,12 +54,42 @@ type Handler interface {
 	MultiRemove(w http.ResponseWriter, r *http.Request) (int, error)
 	Flush(w http.ResponseWriter, r *http.Request) (int, error)
 	GetObject(w http.ResponseWriter, r *http.Request) (int, error)
+	GetTimestamp(w http.ResponseWriter, r *http.Request) (int, error)
+	ListObject(w http.ResponseWriter, r *http.Request) (int, error)
+	UpdateTimestamp(w http.ResponseWriter, r *http.Request) (int, error)
+	RemoveByTimestamp(w http.ResponseWriter, r *http.Request) (int, error)
+	IndexInfo(w http.ResponseWriter, r *http.Request) (int, error)
+	IndexDetail(w http.ResponseWriter, r *http.Request) (int, error)
+	IndexStatistics(w http.ResponseWriter, r *http.Request) (int, error)
+	IndexStatisticsDetail(w http.ResponseWriter, r *http.Request) (int, error)
+	IndexProperty(w http.ResponseWriter, r *http.Request) (int, error)
+	StreamSearch(w http.ResponseWriter, r *http.Request) (int, error)
+	StreamSearchByID(w http.ResponseWriter, r *http.Request) (int, error)
+	StreamLinearSearch(w http.ResponseWriter, r *http.Request) (int, error)
+	StreamLinearSearchByID(w http.ResponseWriter, r *http.Request) (int, error)
+	StreamInsert(w http.ResponseWriter, r *http.Request) (int, error)
+	StreamUpdate(w http.ResponseWriter, r *http.Request) (int, error)
+	StreamUpsert(w http.ResponseWriter, r *http.Request) (int, error)
+	StreamRemove(w http.ResponseWriter, r *http.Request) (int, error)
+	StreamGetObject(w http.ResponseWriter, r *http.Request) (int, error)
+	CreateCollection(w http.ResponseWriter, r *http.Request) (int, error)
+	DropCollection(w http.ResponseWriter, r *http.Request) (int, error)
+	ListCollections(w http.ResponseWriter, r *http.Request) (int, error)
+	OpenAPI(w http.ResponseWriter, r *http.Request) (int, error)
 }
 
 type handler struct {
-	vald vald.Server
+	vald       vald.Server
+	collection vald.CollectionServer
+
+	// openapi document is merged from the generated swagger documents on first request.
+	docOnce sync.Once
+	doc     []byte
+	docErr  error
 }
 
+const apiTitle = "vald LB gateway"
+
 func New(opts ...Option) Handler {
 	h := new(handler)
 
@@ -193,6 +230,13 @@ func (h *handler) Flush(w http.ResponseWriter, r *http.Request) (code int, err e
 func (h *handler) GetObject(w http.ResponseWriter, r *http.Request) (code int, err error) {
 	var req *payload.Object_VectorRequest
 	return json.Handler(w, r, &req, func() (any, error) {
+		if req == nil {
+			req = &payload.Object_VectorRequest{
+				Id: &payload.Object_ID{
+					Id: routing.Vars(r)["id"],
+				},
+			}
+		}
 		return h.vald.GetObject(r.Context(), req)
 	})
 }
@@ -200,6 +244,190 @@ func (h *handler) GetObject(w http.ResponseWriter, r *http.Request) (code int, e
 func (h *handler) Exists(w http.ResponseWriter, r *http.Request) (code int, err error) {
 	var req *payload.Object_ID
 	return json.Handler(w, r, &req, func() (any, error) {
+		if req == nil {
+			req = &payload.Object_ID{
+				Id: routing.Vars(r)["id"],
+			}
+		}
 		return h.vald.Exists(r.Context(), req)
 	})
 }
+
+func (h *handler) GetTimestamp(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var req *payload.Object_TimestampRequest
+	return json.Handler(w, r, &req, func() (any, error) {
+		if req == nil {
+			req = &payload.Object_TimestampRequest{
+				Id: &payload.Object_ID{
+					Id: routing.Vars(r)["id"],
+				},
+			}
+		}
+		return h.vald.GetTimestamp(r.Context(), req)
+	})
+}
+
+// ListObject streams every vector as newline-delimited JSON or Server-Sent Events.
+func (h *handler) ListObject(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	return json.StreamHandler(w, r, func(s *json.ServerStream[payload.Object_List_Request, payload.Object_List_Response]) error {
+		req, err := s.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			req = new(payload.Object_List_Request)
+		}
+		return h.vald.StreamListObject(req, s)
+	})
+}
+
+func (h *handler) UpdateTimestamp(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var req *payload.Update_TimestampRequest
+	return json.Handler(w, r, &req, func() (any, error) {
+		return h.vald.UpdateTimestamp(r.Context(), req)
+	})
+}
+
+func (h *handler) RemoveByTimestamp(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var req *payload.Remove_TimestampRequest
+	return json.Handler(w, r, &req, func() (any, error) {
+		return h.vald.RemoveByTimestamp(r.Context(), req)
+	})
+}
+
+func (h *handler) IndexInfo(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var req *payload.Empty
+	return json.Handler(w, r, &req, func() (any, error) {
+		return h.vald.IndexInfo(r.Context(), req)
+	})
+}
+
+func (h *handler) IndexDetail(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var req *payload.Empty
+	return json.Handler(w, r, &req, func() (any, error) {
+		return h.vald.IndexDetail(r.Context(), req)
+	})
+}
+
+func (h *handler) IndexStatistics(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var req *payload.Empty
+	return json.Handler(w, r, &req, func() (any, error) {
+		return h.vald.IndexStatistics(r.Context(), req)
+	})
+}
+
+func (h *handler) IndexStatisticsDetail(
+	w http.ResponseWriter, r *http.Request,
+) (code int, err error) {
+	var req *payload.Empty
+	return json.Handler(w, r, &req, func() (any, error) {
+		return h.vald.IndexStatisticsDetail(r.Context(), req)
+	})
+}
+
+func (h *handler) IndexProperty(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var req *payload.Empty
+	return json.Handler(w, r, &req, func() (any, error) {
+		return h.vald.IndexProperty(r.Context(), req)
+	})
+}
+
+func (h *handler) StreamSearch(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	return json.StreamHandler(w, r, func(s *json.ServerStream[payload.Search_Request, payload.Search_StreamResponse]) error {
+		return h.vald.StreamSearch(s)
+	})
+}
+
+func (h *handler) StreamSearchByID(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	return json.StreamHandler(w, r, func(s *json.ServerStream[payload.Search_IDRequest, payload.Search_StreamResponse]) error {
+		return h.vald.StreamSearchByID(s)
+	})
+}
+
+func (h *handler) StreamLinearSearch(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	return json.StreamHandler(w, r, func(s *json.ServerStream[payload.Search_Request, payload.Search_StreamResponse]) error {
+		return h.vald.StreamLinearSearch(s)
+	})
+}
+
+func (h *handler) StreamLinearSearchByID(
+	w http.ResponseWriter, r *http.Request,
+) (code int, err error) {
+	return json.StreamHandler(w, r, func(s *json.ServerStream[payload.Search_IDRequest, payload.Search_StreamResponse]) error {
+		return h.vald.StreamLinearSearchByID(s)
+	})
+}
+
+func (h *handler) StreamInsert(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	return json.StreamHandler(w, r, func(s *json.ServerStream[payload.Insert_Request, payload.Object_StreamLocation]) error {
+		return h.vald.StreamInsert(s)
+	})
+}
+
+func (h *handler) StreamUpdate(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	return json.StreamHandler(w, r, func(s *json.ServerStream[payload.Update_Request, payload.Object_StreamLocation]) error {
+		return h.vald.StreamUpdate(s)
+	})
+}
+
+func (h *handler) StreamUpsert(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	return json.StreamHandler(w, r, func(s *json.ServerStream[payload.Upsert_Request, payload.Object_StreamLocation]) error {
+		return h.vald.StreamUpsert(s)
+	})
+}
+
+func (h *handler) StreamRemove(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	return json.StreamHandler(w, r, func(s *json.ServerStream[payload.Remove_Request, payload.Object_StreamLocation]) error {
+		return h.vald.StreamRemove(s)
+	})
+}
+
+func (h *handler) StreamGetObject(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	return json.StreamHandler(w, r, func(s *json.ServerStream[payload.Object_VectorRequest, payload.Object_StreamVector]) error {
+		return h.vald.StreamGetObject(s)
+	})
+}
+
+func (h *handler) CreateCollection(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var req *payload.Collection_Config
+	return json.Handler(w, r, &req, func() (any, error) {
+		return h.collection.CreateCollection(r.Context(), req)
+	})
+}
+
+func (h *handler) DropCollection(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var req *payload.Collection_Name
+	return json.Handler(w, r, &req, func() (any, error) {
+		if req == nil {
+			req = &payload.Collection_Name{
+				Name: routing.Vars(r)["name"],
+			}
+		}
+		return h.collection.DropCollection(r.Context(), req)
+	})
+}
+
+func (h *handler) ListCollections(w http.ResponseWriter, r *http.Request) (code int, err error) {
+	var req *payload.Empty
+	return json.Handler(w, r, &req, func() (any, error) {
+		return h.collection.ListCollections(r.Context(), req)
+	})
+}
+
+// OpenAPI serves the OpenAPI v2 document merged from apis/swagger.
+func (h *handler) OpenAPI(w http.ResponseWriter, _ *http.Request) (code int, err error) {
+	h.docOnce.Do(func() {
+		h.doc, h.docErr = swagger.Merge(swagger.FS, apiTitle, info.Version, swagger.ValdServices...)
+	})
+	if h.docErr != nil {
+		return http.StatusInternalServerError, h.docErr
+	}
+	w.Header().Add(rest.ContentType, rest.ApplicationJSON)
+	w.Header().Add(rest.ContentType, rest.CharsetUTF8)
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(h.doc)
+	if err != nil {
+		return http.StatusServiceUnavailable, err
+	}
+	return http.StatusOK, nil
+}
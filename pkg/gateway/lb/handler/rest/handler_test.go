@@ -13,6 +13,190 @@
 // limitations under the License.
 package rest
 
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/net/grpc/codes"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+	"github.com/vdaas/vald/internal/strings"
+)
+
+type valdServer struct {
+	vald.UnimplementedValdServer
+}
+
+func (*valdServer) Search(_ context.Context, req *payload.Search_Request) (*payload.Search_Response, error) {
+	return &payload.Search_Response{
+		RequestId: req.GetConfig().GetRequestId(),
+		Results: []*payload.Object_Distance{
+			{Id: "vald", Distance: 0.5},
+		},
+	}, nil
+}
+
+func (*valdServer) Exists(_ context.Context, req *payload.Object_ID) (*payload.Object_ID, error) {
+	if req.GetId() != "vald" {
+		return nil, status.WrapWithNotFound("object not found", errors.ErrObjectIDNotFound(req.GetId()))
+	}
+	return req, nil
+}
+
+type collectionServer struct {
+	vald.UnimplementedCollectionServer
+}
+
+func (*collectionServer) DropCollection(_ context.Context, req *payload.Collection_Name) (*payload.Empty, error) {
+	if req.GetName() != "images" {
+		return nil, status.WrapWithNotFound("collection not found", errors.ErrCollectionNotFound(req.GetName()))
+	}
+	return new(payload.Empty), nil
+}
+
+func Test_handler(t *testing.T) {
+	t.Parallel()
+	type want struct {
+		code    int
+		body    string
+		errCode codes.Code
+	}
+	type test struct {
+		name   string
+		handle func(Handler) func(http.ResponseWriter, *http.Request) (int, error)
+		body   string
+		vars   map[string]string
+		want   want
+	}
+	tests := []test{
+		{
+			name: "return the search response of the decoded request",
+			handle: func(h Handler) func(http.ResponseWriter, *http.Request) (int, error) {
+				return h.Search
+			},
+			body: `{"vector":[0.1,0.2],"config":{"request_id":"r","num":1}}`,
+			want: want{
+				code: http.StatusOK,
+				body: `{"requestId":"r","results":[{"id":"vald","distance":0.5}]}`,
+			},
+		},
+		{
+			name: "return bad request when the request is not decoded",
+			handle: func(h Handler) func(http.ResponseWriter, *http.Request) (int, error) {
+				return h.Search
+			},
+			body: `{"vector":`,
+			want: want{
+				code:    http.StatusBadRequest,
+				errCode: codes.Unknown,
+			},
+		},
+		{
+			name: "return the object id of the path when the request has no body",
+			handle: func(h Handler) func(http.ResponseWriter, *http.Request) (int, error) {
+				return h.Exists
+			},
+			vars: map[string]string{"id": "vald"},
+			want: want{
+				code: http.StatusOK,
+				body: `{"id":"vald"}`,
+			},
+		},
+		{
+			name: "return the object id of the body prior to the path",
+			handle: func(h Handler) func(http.ResponseWriter, *http.Request) (int, error) {
+				return h.Exists
+			},
+			body: `{"id":"vald"}`,
+			vars: map[string]string{"id": "other"},
+			want: want{
+				code: http.StatusOK,
+				body: `{"id":"vald"}`,
+			},
+		},
+		{
+			name: "return internal server error with the status of the server",
+			handle: func(h Handler) func(http.ResponseWriter, *http.Request) (int, error) {
+				return h.Exists
+			},
+			vars: map[string]string{"id": "other"},
+			want: want{
+				code:    http.StatusInternalServerError,
+				errCode: codes.NotFound,
+			},
+		},
+		{
+			name: "drop the collection of the path",
+			handle: func(h Handler) func(http.ResponseWriter, *http.Request) (int, error) {
+				return h.DropCollection
+			},
+			vars: map[string]string{"name": "images"},
+			want: want{
+				code: http.StatusOK,
+				body: `{}`,
+			},
+		},
+		{
+			name: "return internal server error when the collection is not dropped",
+			handle: func(h Handler) func(http.ResponseWriter, *http.Request) (int, error) {
+				return h.DropCollection
+			},
+			vars: map[string]string{"name": "texts"},
+			want: want{
+				code:    http.StatusInternalServerError,
+				errCode: codes.NotFound,
+			},
+		},
+		{
+			name: "return internal server error when the method is not implemented",
+			handle: func(h Handler) func(http.ResponseWriter, *http.Request) (int, error) {
+				return h.IndexInfo
+			},
+			want: want{
+				code:    http.StatusInternalServerError,
+				errCode: codes.Unimplemented,
+			},
+		},
+	}
+
+	h := New(WithVald(new(valdServer)), WithCollection(new(collectionServer)))
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			if test.vars != nil {
+				r = mux.SetURLVars(r, test.vars)
+			}
+			w := httptest.NewRecorder()
+			code, err := test.handle(h)(w, r)
+			if code != test.want.code {
+				tt.Errorf("code not equals. want: %d, got: %d", test.want.code, code)
+			}
+			if test.want.errCode == codes.OK {
+				if err != nil {
+					tt.Fatal(err)
+				}
+				if got := strings.TrimSpace(w.Body.String()); got != test.want.body {
+					tt.Errorf("body not equals. want: %s, got: %s", test.want.body, got)
+				}
+				return
+			}
+			if err == nil {
+				tt.Fatal("error is nil")
+			}
+			if st, _ := status.FromError(err); st.Code() != test.want.errCode {
+				tt.Errorf("error code not equals. want: %s, got: %s", test.want.errCode, st.Code())
+			}
+		})
+	}
+}
+
 // NOT IMPLEMENTED BELOW
 //
 // func TestNew(t *testing.T) {
@@ -28,3 +28,9 @@ func WithVald(v vald.Server) Option {
 		h.vald = v
 	}
 }
+
+func WithCollection(c vald.CollectionServer) Option {
+	return func(h *handler) {
+		h.collection = c
+	}
+}
@@ -17,7 +17,10 @@
 // Package router provides implementation of Go API for routing http Handler wrapped by rest.Func
 package router
 
-import "github.com/vdaas/vald/pkg/gateway/lb/handler/rest"
+import (
+	"github.com/vdaas/vald/pkg/gateway/lb/handler/graphql"
+	"github.com/vdaas/vald/pkg/gateway/lb/handler/rest"
+)
 
 type Option func(*router)
 
@@ -31,6 +34,13 @@ func WithHandler(h rest.Handler) Option {
 	}
 }
 
+// WithGraphQLHandler returns the option to set the handler of the GraphQL routes.
+func WithGraphQLHandler(h graphql.Handler) Option {
+	return func(r *router) {
+		r.gql = h
+	}
+}
+
 func WithTimeout(timeout string) Option {
 	return func(r *router) {
 		r.timeout = timeout
@@ -20,12 +20,15 @@ package router
 import (
 	"net/http"
 
+	"github.com/vdaas/vald/internal/net/http/middleware"
 	"github.com/vdaas/vald/internal/net/http/routing"
+	"github.com/vdaas/vald/pkg/gateway/lb/handler/graphql"
 	"github.com/vdaas/vald/pkg/gateway/lb/handler/rest"
 )
 
 type router struct {
 	handler rest.Handler
+	gql     graphql.Handler
 	timeout string
 }
 
@@ -40,6 +43,7 @@ func New(opts ...Option) http.Handler {
 	h := r.handler
 
 	return routing.New(
+		routing.WithMiddleware(middleware.NewCollection()),
 		routing.WithRoutes([]routing.Route{
 			{
 				Name: "Index",
@@ -47,47 +51,160 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/",
+				RPC:         "/",
 				HandlerFunc: h.Index,
 			},
+			{
+				Name: "OpenAPI",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/openapi.json",
+				RPC:         "/openapi.json",
+				HandlerFunc: h.OpenAPI,
+			},
 			{
 				Name: "Search",
 				Methods: []string{
 					http.MethodPost,
 				},
 				Pattern:     "/search",
+				RPC:         "/vald.v1.Search/Search",
 				HandlerFunc: h.Search,
 			},
 			{
-				Name: "Search By ID",
+				Name: "Search By ID (OpenAPI)",
 				Methods: []string{
-					http.MethodGet,
+					http.MethodPost,
 				},
-				Pattern:     "/search/{id}",
+				Pattern:     "/search/id",
+				RPC:         "/vald.v1.Search/SearchByID",
 				HandlerFunc: h.SearchByID,
 			},
-
 			{
 				Name: "Multi Search",
 				Methods: []string{
 					http.MethodPost,
 				},
 				Pattern:     "/search/multi",
+				RPC:         "/vald.v1.Search/MultiSearch",
 				HandlerFunc: h.MultiSearch,
 			},
+			{
+				Name: "Multi Search (OpenAPI)",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/search/multiple",
+				RPC:         "/vald.v1.Search/MultiSearch",
+				HandlerFunc: h.MultiSearch,
+			},
+			{
+				Name: "Multi Search By ID (OpenAPI)",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/search/id/multiple",
+				RPC:         "/vald.v1.Search/MultiSearchByID",
+				HandlerFunc: h.MultiSearchByID,
+			},
+			{
+				Name: "Stream Search",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/search/stream",
+				RPC:         "/vald.v1.Search/StreamSearch",
+				HandlerFunc: h.StreamSearch,
+			},
+			{
+				Name: "Stream Search By ID",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/search/id/stream",
+				RPC:         "/vald.v1.Search/StreamSearchByID",
+				HandlerFunc: h.StreamSearchByID,
+			},
+			{
+				Name: "Search By ID",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/search/{id}",
+				RPC:         "/vald.v1.Search/SearchByID",
+				HandlerFunc: h.SearchByID,
+			},
 			{
 				Name: "Multi Search By ID",
 				Methods: []string{
 					http.MethodGet,
 				},
 				Pattern:     "/search/multi/{id}",
+				RPC:         "/vald.v1.Search/MultiSearchByID",
 				HandlerFunc: h.MultiSearchByID,
 			},
+			{
+				Name: "Linear Search",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/linearsearch",
+				RPC:         "/vald.v1.Search/LinearSearch",
+				HandlerFunc: h.LinearSearch,
+			},
+			{
+				Name: "Linear Search By ID",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/linearsearch/id",
+				RPC:         "/vald.v1.Search/LinearSearchByID",
+				HandlerFunc: h.LinearSearchByID,
+			},
+			{
+				Name: "Multi Linear Search",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/linearsearch/multiple",
+				RPC:         "/vald.v1.Search/MultiLinearSearch",
+				HandlerFunc: h.MultiLinearSearch,
+			},
+			{
+				Name: "Multi Linear Search By ID",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/linearsearch/id/multiple",
+				RPC:         "/vald.v1.Search/MultiLinearSearchByID",
+				HandlerFunc: h.MultiLinearSearchByID,
+			},
+			{
+				Name: "Stream Linear Search",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/linearsearch/stream",
+				RPC:         "/vald.v1.Search/StreamLinearSearch",
+				HandlerFunc: h.StreamLinearSearch,
+			},
+			{
+				Name: "Stream Linear Search By ID",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/linearsearch/id/stream",
+				RPC:         "/vald.v1.Search/StreamLinearSearchByID",
+				HandlerFunc: h.StreamLinearSearchByID,
+			},
 			{
 				Name: "Insert",
 				Methods: []string{
 					http.MethodPost,
 				},
 				Pattern:     "/insert",
+				RPC:         "/vald.v1.Insert/Insert",
 				HandlerFunc: h.Insert,
 			},
 			{
@@ -96,8 +213,27 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/insert/multi",
+				RPC:         "/vald.v1.Insert/MultiInsert",
+				HandlerFunc: h.MultiInsert,
+			},
+			{
+				Name: "Multiple Insert (OpenAPI)",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/insert/multiple",
+				RPC:         "/vald.v1.Insert/MultiInsert",
 				HandlerFunc: h.MultiInsert,
 			},
+			{
+				Name: "Stream Insert",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/insert/stream",
+				RPC:         "/vald.v1.Insert/StreamInsert",
+				HandlerFunc: h.StreamInsert,
+			},
 			{
 				Name: "Update",
 				Methods: []string{
@@ -106,6 +242,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/update",
+				RPC:         "/vald.v1.Update/Update",
 				HandlerFunc: h.Update,
 			},
 			{
@@ -116,8 +253,40 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/update/multi",
+				RPC:         "/vald.v1.Update/MultiUpdate",
+				HandlerFunc: h.MultiUpdate,
+			},
+			{
+				Name: "Multiple Update (OpenAPI)",
+				Methods: []string{
+					http.MethodPost,
+					http.MethodPatch,
+					http.MethodPut,
+				},
+				Pattern:     "/update/multiple",
+				RPC:         "/vald.v1.Update/MultiUpdate",
 				HandlerFunc: h.MultiUpdate,
 			},
+			{
+				Name: "Update Timestamp",
+				Methods: []string{
+					http.MethodPost,
+					http.MethodPatch,
+					http.MethodPut,
+				},
+				Pattern:     "/update/timestamp",
+				RPC:         "/vald.v1.Update/UpdateTimestamp",
+				HandlerFunc: h.UpdateTimestamp,
+			},
+			{
+				Name: "Stream Update",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/update/stream",
+				RPC:         "/vald.v1.Update/StreamUpdate",
+				HandlerFunc: h.StreamUpdate,
+			},
 			{
 				Name: "Upsert",
 				Methods: []string{
@@ -126,6 +295,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/upsert",
+				RPC:         "/vald.v1.Upsert/Upsert",
 				HandlerFunc: h.Upsert,
 			},
 			{
@@ -136,16 +306,65 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/upsert/multi",
+				RPC:         "/vald.v1.Upsert/MultiUpsert",
 				HandlerFunc: h.MultiUpsert,
 			},
 			{
-				Name: "Remove",
+				Name: "Multiple Upsert (OpenAPI)",
 				Methods: []string{
-					http.MethodDelete,
+					http.MethodPost,
+					http.MethodPatch,
+					http.MethodPut,
 				},
-				Pattern:     "/delete/{id}",
+				Pattern:     "/upsert/multiple",
+				RPC:         "/vald.v1.Upsert/MultiUpsert",
+				HandlerFunc: h.MultiUpsert,
+			},
+			{
+				Name: "Stream Upsert",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/upsert/stream",
+				RPC:         "/vald.v1.Upsert/StreamUpsert",
+				HandlerFunc: h.StreamUpsert,
+			},
+			{
+				Name: "Remove (OpenAPI)",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/remove",
+				RPC:         "/vald.v1.Remove/Remove",
 				HandlerFunc: h.Remove,
 			},
+			{
+				Name: "Multiple Remove (OpenAPI)",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/remove/multiple",
+				RPC:         "/vald.v1.Remove/MultiRemove",
+				HandlerFunc: h.MultiRemove,
+			},
+			{
+				Name: "Remove By Timestamp",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/remove/timestamp",
+				RPC:         "/vald.v1.Remove/RemoveByTimestamp",
+				HandlerFunc: h.RemoveByTimestamp,
+			},
+			{
+				Name: "Stream Remove",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/remove/stream",
+				RPC:         "/vald.v1.Remove/StreamRemove",
+				HandlerFunc: h.StreamRemove,
+			},
 			{
 				Name: "Multiple Remove",
 				Methods: []string{
@@ -153,23 +372,176 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/delete/multi",
+				RPC:         "/vald.v1.Remove/MultiRemove",
 				HandlerFunc: h.MultiRemove,
 			},
+			{
+				Name: "Remove",
+				Methods: []string{
+					http.MethodDelete,
+				},
+				Pattern:     "/delete/{id}",
+				RPC:         "/vald.v1.Remove/Remove",
+				HandlerFunc: h.Remove,
+			},
 			{
 				Name: "Flush",
 				Methods: []string{
 					http.MethodDelete,
 				},
 				Pattern:     "/flush",
+				RPC:         "/vald.v1.Flush/Flush",
 				HandlerFunc: h.Flush,
 			},
+			{
+				Name: "Exists",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/exists/{id}",
+				RPC:         "/vald.v1.Object/Exists",
+				HandlerFunc: h.Exists,
+			},
+			{
+				Name: "List Object",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/object/list",
+				RPC:         "/vald.v1.Object/StreamListObject",
+				HandlerFunc: h.ListObject,
+			},
+			{
+				Name: "Stream GetObject",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/object/stream",
+				RPC:         "/vald.v1.Object/StreamGetObject",
+				HandlerFunc: h.StreamGetObject,
+			},
+			{
+				Name: "GetTimestamp",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/object/meta/{id}",
+				RPC:         "/vald.v1.Object/GetTimestamp",
+				HandlerFunc: h.GetTimestamp,
+			},
 			{
 				Name: "GetObject",
 				Methods: []string{
 					http.MethodGet,
 				},
 				Pattern:     "/object/{id}",
+				RPC:         "/vald.v1.Object/GetObject",
 				HandlerFunc: h.GetObject,
 			},
+			{
+				Name: "Index Info",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/index/info",
+				RPC:         "/vald.v1.Index/IndexInfo",
+				HandlerFunc: h.IndexInfo,
+			},
+			{
+				Name: "Index Detail",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/index/detail",
+				RPC:         "/vald.v1.Index/IndexDetail",
+				HandlerFunc: h.IndexDetail,
+			},
+			{
+				Name: "Index Statistics",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/index/statistics",
+				RPC:         "/vald.v1.Index/IndexStatistics",
+				HandlerFunc: h.IndexStatistics,
+			},
+			{
+				Name: "Index Statistics Detail",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/index/statistics/detail",
+				RPC:         "/vald.v1.Index/IndexStatisticsDetail",
+				HandlerFunc: h.IndexStatisticsDetail,
+			},
+			{
+				Name: "Index Property",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/index/property",
+				RPC:         "/vald.v1.Index/IndexProperty",
+				HandlerFunc: h.IndexProperty,
+			},
+			{
+				Name: "List Collections",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/collection",
+				RPC:         "/vald.v1.Collection/ListCollections",
+				HandlerFunc: h.ListCollections,
+			},
+			{
+				Name: "Create Collection",
+				Methods: []string{
+					http.MethodPost,
+				},
+				Pattern:     "/collection",
+				RPC:         "/vald.v1.Collection/CreateCollection",
+				HandlerFunc: h.CreateCollection,
+			},
+			{
+				Name: "Drop Collection",
+				Methods: []string{
+					http.MethodDelete,
+				},
+				Pattern:     "/collection/{name}",
+				RPC:         "/vald.v1.Collection/DropCollection",
+				HandlerFunc: h.DropCollection,
+			},
+		}...))
+}
+
+// NewGraphQL returns GraphQL route&method information from handler interface.
+func NewGraphQL(opts ...Option) http.Handler {
+	r := new(router)
+
+	for _, opt := range append(defaultOptions, opts...) {
+		opt(r)
+	}
+
+	h := r.gql
+
+	return routing.New(
+		routing.WithRoutes([]routing.Route{
+			{
+				Name: "GraphQL",
+				Methods: []string{
+					http.MethodGet,
+					http.MethodPost,
+				},
+				Pattern:     "/graphql",
+				HandlerFunc: h.Query,
+			},
+			{
+				Name: "GraphQL Schema",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/graphql/schema",
+				RPC:         "/graphql/schema",
+				HandlerFunc: h.Schema,
+			},
 		}...))
 }
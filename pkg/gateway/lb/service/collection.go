@@ -0,0 +1,132 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service represents gateway's service logic
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/net/grpc/codes"
+	"github.com/vdaas/vald/internal/net/grpc/status"
+)
+
+// SyncCollections creates the collections hosted by the known agents on the agents discovered since the last call.
+// The agents of the first call are only recorded, and the agents which failed to sync are retried on the next call.
+func (g *gateway) SyncCollections(ctx context.Context, addrs []string) error {
+	g.smu.Lock()
+	defer g.smu.Unlock()
+	if g.synced == nil {
+		g.synced = make(map[string]struct{}, len(addrs))
+		for _, addr := range addrs {
+			g.synced[addr] = struct{}{}
+		}
+		return nil
+	}
+	client := g.client.GetClient()
+	synced, err := reconcileCollections(ctx, g.synced, addrs,
+		func(ctx context.Context, addr string) (cols []*payload.Collection_Config, err error) {
+			_, err = client.Do(ctx, addr, func(ctx context.Context, conn *grpc.ClientConn, copts ...grpc.CallOption) (any, error) {
+				list, err := vald.NewCollectionClient(conn).ListCollections(ctx, new(payload.Empty), copts...)
+				cols = list.GetCollections()
+				return list, err
+			})
+			return cols, err
+		},
+		func(ctx context.Context, addr string, cfg *payload.Collection_Config) error {
+			_, err := client.Do(ctx, addr, func(ctx context.Context, conn *grpc.ClientConn, copts ...grpc.CallOption) (any, error) {
+				return vald.NewCollectionClient(conn).CreateCollection(ctx, cfg, copts...)
+			})
+			if st, ok := status.FromError(err); ok && st.Code() == codes.AlreadyExists {
+				return nil
+			}
+			return err
+		})
+	g.synced = synced
+	return err
+}
+
+// reconcileCollections creates the union of the collections hosted by the known agents on the agents of addrs which are not known yet.
+// It returns the agents of addrs which host all of the collections.
+func reconcileCollections(
+	ctx context.Context,
+	known map[string]struct{},
+	addrs []string,
+	list func(ctx context.Context, addr string) ([]*payload.Collection_Config, error),
+	create func(ctx context.Context, addr string, cfg *payload.Collection_Config) error,
+) (synced map[string]struct{}, err error) {
+	synced = make(map[string]struct{}, len(addrs))
+	added := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if _, ok := known[addr]; ok {
+			synced[addr] = struct{}{}
+		} else {
+			added = append(added, addr)
+		}
+	}
+	if len(added) == 0 {
+		return synced, nil
+	}
+
+	cols := make(map[string]*payload.Collection_Config)
+	for addr := range synced {
+		cs, lerr := list(ctx, addr)
+		if lerr != nil {
+			// the new agents are not synced since the collections of the agent are unknown
+			return synced, errors.Join(err, lerr)
+		}
+		for _, cfg := range cs {
+			if _, ok := cols[cfg.GetName()]; !ok {
+				cols[cfg.GetName()] = cfg
+			}
+		}
+	}
+	for _, addr := range added {
+		cs, lerr := list(ctx, addr)
+		if lerr != nil {
+			err = errors.Join(err, lerr)
+			continue
+		}
+		var cerr error
+		for name, cfg := range cols {
+			if i := slices.IndexFunc(cs, func(c *payload.Collection_Config) bool {
+				return c.GetName() == name
+			}); i >= 0 {
+				if !cs[i].EqualVT(cfg) {
+					log.Warnf("collection %s of agent %s differs from the other agents: %v, want %v", name, addr, cs[i], cfg)
+				}
+				continue
+			}
+			if e := create(ctx, addr, cfg); e != nil {
+				cerr = errors.Join(cerr, e)
+				continue
+			}
+			log.Infof("collection %s created on the discovered agent %s", name, addr)
+		}
+		if cerr != nil {
+			err = errors.Join(err, cerr)
+			continue
+		}
+		synced[addr] = struct{}{}
+	}
+	return synced, err
+}
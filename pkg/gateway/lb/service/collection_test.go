@@ -0,0 +1,195 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service
+package service
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+)
+
+func Test_reconcileCollections(t *testing.T) {
+	t.Parallel()
+	var (
+		foo = &payload.Collection_Config{Name: "foo", Dimension: 3}
+		bar = &payload.Collection_Config{Name: "bar", Dimension: 4}
+	)
+	type want struct {
+		synced  []string
+		created map[string][]string
+		err     bool
+	}
+	tests := []struct {
+		name      string
+		known     []string
+		addrs     []string
+		hosted    map[string][]*payload.Collection_Config
+		listErr   map[string]error
+		createErr map[string]error
+		want      want
+	}{
+		{
+			name:  "no new agent creates nothing",
+			known: []string{"a", "b"},
+			addrs: []string{"a"},
+			hosted: map[string][]*payload.Collection_Config{
+				"a": {foo},
+			},
+			want: want{
+				synced:  []string{"a"},
+				created: map[string][]string{},
+			},
+		},
+		{
+			name:  "new agent gets the union of the collections of the known agents",
+			known: []string{"a", "b"},
+			addrs: []string{"a", "b", "c"},
+			hosted: map[string][]*payload.Collection_Config{
+				"a": {foo},
+				"b": {bar},
+			},
+			want: want{
+				synced: []string{"a", "b", "c"},
+				created: map[string][]string{
+					"c": {"bar", "foo"},
+				},
+			},
+		},
+		{
+			name:  "collection already hosted by the new agent is not created",
+			known: []string{"a"},
+			addrs: []string{"a", "c"},
+			hosted: map[string][]*payload.Collection_Config{
+				"a": {foo, bar},
+				"c": {foo},
+			},
+			want: want{
+				synced: []string{"a", "c"},
+				created: map[string][]string{
+					"c": {"bar"},
+				},
+			},
+		},
+		{
+			name:  "known agent which has gone is not a source of the collections",
+			known: []string{"a", "b"},
+			addrs: []string{"a", "c"},
+			hosted: map[string][]*payload.Collection_Config{
+				"a": {foo},
+				"b": {bar},
+			},
+			want: want{
+				synced: []string{"a", "c"},
+				created: map[string][]string{
+					"c": {"foo"},
+				},
+			},
+		},
+		{
+			name:  "failed create leaves the new agent unsynced",
+			known: []string{"a"},
+			addrs: []string{"a", "c", "d"},
+			hosted: map[string][]*payload.Collection_Config{
+				"a": {foo},
+			},
+			createErr: map[string]error{
+				"c": errors.New("unavailable"),
+			},
+			want: want{
+				synced: []string{"a", "d"},
+				created: map[string][]string{
+					"d": {"foo"},
+				},
+				err: true,
+			},
+		},
+		{
+			name:  "failed list of a known agent leaves every new agent unsynced",
+			known: []string{"a", "b"},
+			addrs: []string{"a", "b", "c"},
+			hosted: map[string][]*payload.Collection_Config{
+				"a": {foo},
+			},
+			listErr: map[string]error{
+				"b": errors.New("unavailable"),
+			},
+			want: want{
+				synced:  []string{"a", "b"},
+				created: map[string][]string{},
+				err:     true,
+			},
+		},
+		{
+			name:  "failed list of a new agent leaves the agent unsynced",
+			known: []string{"a"},
+			addrs: []string{"a", "c"},
+			hosted: map[string][]*payload.Collection_Config{
+				"a": {foo},
+			},
+			listErr: map[string]error{
+				"c": errors.New("unavailable"),
+			},
+			want: want{
+				synced:  []string{"a"},
+				created: map[string][]string{},
+				err:     true,
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			known := make(map[string]struct{}, len(test.known))
+			for _, addr := range test.known {
+				known[addr] = struct{}{}
+			}
+			created := make(map[string][]string)
+			synced, err := reconcileCollections(context.Background(), known, test.addrs,
+				func(_ context.Context, addr string) ([]*payload.Collection_Config, error) {
+					if err := test.listErr[addr]; err != nil {
+						return nil, err
+					}
+					return test.hosted[addr], nil
+				},
+				func(_ context.Context, addr string, cfg *payload.Collection_Config) error {
+					if err := test.createErr[addr]; err != nil {
+						return err
+					}
+					created[addr] = append(created[addr], cfg.GetName())
+					return nil
+				})
+			if (err != nil) != test.want.err {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant_error: %v", err, test.want.err)
+			}
+			if got := slices.Sorted(maps.Keys(synced)); !slices.Equal(got, test.want.synced) {
+				tt.Errorf("got_synced: \"%#v\",\n\t\t\t\twant: \"%#v\"", got, test.want.synced)
+			}
+			for addr := range created {
+				slices.Sort(created[addr])
+			}
+			if !maps.EqualFunc(created, test.want.created, slices.Equal) {
+				tt.Errorf("got_created: \"%#v\",\n\t\t\t\twant: \"%#v\"", created, test.want.created)
+			}
+		})
+	}
+}
@@ -40,6 +40,10 @@ type Gateway interface {
 		f func(ctx context.Context, target string, ac vald.Client, copts ...grpc.CallOption) error) error
 	BroadCast(ctx context.Context, kind BroadCastKind,
 		f func(ctx context.Context, target string, ac vald.Client, copts ...grpc.CallOption) error) error
+	BroadCastCollection(ctx context.Context, kind BroadCastKind,
+		f func(ctx context.Context, target string, cc vald.CollectionClient, copts ...grpc.CallOption) error) error
+	LocalZoneAddrs(ctx context.Context) []string
+	SyncCollections(ctx context.Context, addrs []string) error
 }
 
 type BroadCastKind int
@@ -47,15 +51,28 @@ type BroadCastKind int
 const (
 	READ BroadCastKind = iota
 	WRITE
+	// SEARCH behaves like READ but is limited to the agents in the local zone
+	// when every zone is guaranteed to hold a replica of each vector.
+	SEARCH
 )
 
 type gateway struct {
-	client discoverer.Client
-	eg     errgroup.Group
+	client         discoverer.Client
+	eg             errgroup.Group
+	indexReplica   int
+	spreadReplicas bool
+	preferSameZone bool
+	zone           string
+	nodeName       string
+
+	smu    *sync.Mutex
+	synced map[string]struct{} // agents hosting all of the collections
 }
 
 func NewGateway(opts ...Option) (gw Gateway, err error) {
-	g := new(gateway)
+	g := &gateway{
+		smu: new(sync.Mutex),
+	}
 	for _, opt := range append(defaultGWOpts, opts...) {
 		if err := opt(g); err != nil {
 			return nil, errors.ErrOptionFailed(err, reflect.ValueOf(opt))
@@ -73,29 +90,71 @@ func (g *gateway) BroadCast(
 	kind BroadCastKind,
 	f func(ctx context.Context, target string, ac vald.Client, copts ...grpc.CallOption) error,
 ) (err error) {
-	fctx, span := trace.StartSpan(ctx, "vald/gateway-lb/service/Gateway.BroadCast")
+	fctx, span := trace.StartSpan(grpc.ForwardCollection(ctx), "vald/gateway-lb/service/Gateway.BroadCast")
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	return g.broadCast(fctx, kind, func(ctx context.Context, addr string, conn *grpc.ClientConn, copts ...grpc.CallOption) error {
+		return f(ctx, addr, vc.NewValdClient(conn), copts...)
+	})
+}
+
+// BroadCastCollection calls f with the collection admin client of every agent.
+func (g *gateway) BroadCastCollection(
+	ctx context.Context,
+	kind BroadCastKind,
+	f func(ctx context.Context, target string, cc vald.CollectionClient, copts ...grpc.CallOption) error,
+) (err error) {
+	fctx, span := trace.StartSpan(ctx, "vald/gateway-lb/service/Gateway.BroadCastCollection")
 	defer func() {
 		if span != nil {
 			span.End()
 		}
 	}()
+	return g.broadCast(fctx, kind, func(ctx context.Context, addr string, conn *grpc.ClientConn, copts ...grpc.CallOption) error {
+		return f(ctx, addr, vald.NewCollectionClient(conn), copts...)
+	})
+}
 
+func (g *gateway) broadCast(
+	ctx context.Context,
+	kind BroadCastKind,
+	f func(ctx context.Context, addr string, conn *grpc.ClientConn, copts ...grpc.CallOption) error,
+) (err error) {
 	var client grpc.Client
 	switch kind {
 	case READ:
 		client = g.client.GetReadClient()
 	case WRITE:
 		client = g.client.GetClient()
+	case SEARCH:
+		client = g.client.GetReadClient()
+		if client == g.client.GetClient() {
+			if addrs := g.localAddrs(ctx); len(addrs) != 0 {
+				return client.OrderedRangeConcurrent(ctx, addrs, len(addrs), func(ictx context.Context,
+					addr string, conn *grpc.ClientConn, copts ...grpc.CallOption,
+				) (err error) {
+					select {
+					case <-ictx.Done():
+						return nil
+					default:
+						return f(ictx, addr, conn, copts...)
+					}
+				})
+			}
+		}
 	}
 
-	return client.RangeConcurrent(fctx, -1, func(ictx context.Context,
+	return client.RangeConcurrent(ctx, -1, func(ictx context.Context,
 		addr string, conn *grpc.ClientConn, copts ...grpc.CallOption,
 	) (err error) {
 		select {
 		case <-ictx.Done():
 			return nil
 		default:
-			err = f(ictx, addr, vc.NewValdClient(conn), copts...)
+			err = f(ictx, addr, conn, copts...)
 			if err != nil {
 				return err
 			}
@@ -104,12 +163,108 @@ func (g *gateway) BroadCast(
 	})
 }
 
+// LocalZoneAddrs returns the agents which the SEARCH broadcast is limited to, or nil when it searches every agent.
+// The caller should search all agents again unless every returned agent answered,
+// since the vectors stored in the agents which did not answer have no other replica in the local zone.
+func (g *gateway) LocalZoneAddrs(ctx context.Context) []string {
+	if g.client.GetReadClient() != g.client.GetClient() {
+		return nil
+	}
+	return g.localAddrs(ctx)
+}
+
+// localAddrs returns the agents in the same zone as the gateway. It returns nil unless
+// the replicas are spread across zones and there are at least as many replicas as zones,
+// because only then is every vector guaranteed to have a copy in the local zone.
+func (g *gateway) localAddrs(ctx context.Context) (addrs []string) {
+	if !g.preferSameZone || !g.spreadReplicas {
+		return nil
+	}
+	zone := g.zone
+	if len(zone) == 0 {
+		zone = g.client.GetNodeTopology(g.nodeName).GetZone()
+		if len(zone) == 0 {
+			return nil
+		}
+	}
+	zones := make(map[string]struct{})
+	for _, addr := range g.client.GetAddrs(ctx) {
+		z := g.client.GetTopology(addr).GetZone()
+		if len(z) == 0 {
+			return nil
+		}
+		zones[z] = struct{}{}
+		if z == zone {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(zones) > g.indexReplica {
+		return nil
+	}
+	return addrs
+}
+
+// spread reorders addrs so that consecutive agents land in distinct zones, racks and hosts
+// as far as possible. Agents with equal spread keep their original, resource based order.
+func (g *gateway) spread(addrs []string) []string {
+	if len(addrs) < 2 {
+		return addrs
+	}
+	type domain struct {
+		zone, rack, host string
+	}
+	domains := make([]domain, len(addrs))
+	for i, addr := range addrs {
+		t := g.client.GetTopology(addr)
+		domains[i] = domain{
+			zone: t.GetZone(),
+			host: t.GetHostname(),
+		}
+		if len(t.GetRack()) != 0 {
+			domains[i].rack = t.GetZone() + "/" + t.GetRack()
+		}
+	}
+	var (
+		zones = make(map[string]int)
+		racks = make(map[string]int)
+		hosts = make(map[string]int)
+		used  = make([]bool, len(addrs))
+		count = func(m map[string]int, key string) int {
+			if len(key) == 0 {
+				return 0
+			}
+			return m[key]
+		}
+	)
+	ordered := make([]string, 0, len(addrs))
+	for len(ordered) < len(addrs) {
+		best := -1
+		var bz, br, bh int
+		for i, d := range domains {
+			if used[i] {
+				continue
+			}
+			z, r, h := count(zones, d.zone), count(racks, d.rack), count(hosts, d.host)
+			if best == -1 || z < bz || (z == bz && (r < br || (r == br && h < bh))) {
+				best, bz, br, bh = i, z, r, h
+			}
+		}
+		used[best] = true
+		d := domains[best]
+		zones[d.zone]++
+		racks[d.rack]++
+		hosts[d.host]++
+		ordered = append(ordered, addrs[best])
+	}
+	return ordered
+}
+
 func (g *gateway) DoMulti(
 	ctx context.Context,
 	num int,
 	f func(ctx context.Context, target string, ac vald.Client, copts ...grpc.CallOption) error,
 ) (err error) {
-	sctx, span := trace.StartSpan(ctx, "vald/gateway-lb/service/Gateway.DoMulti")
+	sctx, span := trace.StartSpan(grpc.ForwardCollection(ctx), "vald/gateway-lb/service/Gateway.DoMulti")
 	defer func() {
 		if span != nil {
 			span.End()
@@ -117,25 +272,56 @@ func (g *gateway) DoMulti(
 	}()
 	var cur uint32 = 0
 	addrs := g.client.GetAddrs(sctx)
+	if g.spreadReplicas && num > 1 {
+		addrs = g.spread(addrs)
+	}
 	var limit uint32
 	if len(addrs) < num {
 		limit = uint32(len(addrs))
 	} else {
 		limit = uint32(num)
 	}
-	var visited sync.Map[string, any]
+	// OrderedRange calls f for the agents one by one, so the zone state below is not guarded.
+	var (
+		visited sync.Map[string, any]
+		zones   = g.zones(addrs)
+		used    = make(map[string]struct{}, len(zones))
+		tried   = make(map[string]struct{}, len(addrs))
+	)
+	// reused reports whether addr would store a second replica in a zone
+	// while an agent in a zone without the replica is not tried yet.
+	reused := func(addr string) bool {
+		if _, ok := used[zones[addr]]; !ok || len(zones[addr]) == 0 {
+			return false
+		}
+		for _, a := range addrs {
+			if _, ok := tried[a]; ok || len(zones[a]) == 0 {
+				continue
+			}
+			if _, ok := used[zones[a]]; !ok {
+				return true
+			}
+		}
+		return false
+	}
+	do := func(ictx context.Context, addr string, conn *grpc.ClientConn, copts ...grpc.CallOption) (err error) {
+		tried[addr] = struct{}{}
+		err = f(ictx, addr, vc.NewValdClient(conn), copts...)
+		if err != nil {
+			return err
+		}
+		atomic.AddUint32(&cur, 1)
+		visited.Store(addr, struct{}{})
+		used[zones[addr]] = struct{}{}
+		return nil
+	}
 	err = g.client.GetClient().OrderedRange(sctx, addrs, func(ictx context.Context,
 		addr string,
 		conn *grpc.ClientConn,
 		copts ...grpc.CallOption,
 	) (err error) {
-		if atomic.LoadUint32(&cur) < limit {
-			err = f(ictx, addr, vc.NewValdClient(conn), copts...)
-			if err != nil {
-				return err
-			}
-			atomic.AddUint32(&cur, 1)
-			visited.Store(addr, struct{}{})
+		if atomic.LoadUint32(&cur) < limit && !reused(addr) {
+			return do(ictx, addr, conn, copts...)
 		}
 		return nil
 	})
@@ -147,12 +333,8 @@ func (g *gateway) DoMulti(
 		) (err error) {
 			if atomic.LoadUint32(&cur) < limit {
 				_, ok := visited.Load(addr)
-				if !ok {
-					err = f(ictx, addr, vc.NewValdClient(conn), copts...)
-					if err != nil {
-						return err
-					}
-					atomic.AddUint32(&cur, 1)
+				if !ok && !reused(addr) {
+					return do(ictx, addr, conn, copts...)
 				}
 			}
 			return nil
@@ -164,6 +346,18 @@ func (g *gateway) DoMulti(
 	return nil
 }
 
+// zones returns the zones of addrs when the replicas are spread across zones, and nil otherwise.
+func (g *gateway) zones(addrs []string) map[string]string {
+	if !g.spreadReplicas {
+		return nil
+	}
+	zones := make(map[string]string, len(addrs))
+	for _, addr := range addrs {
+		zones[addr] = g.client.GetTopology(addr).GetZone()
+	}
+	return zones
+}
+
 func (g *gateway) GetAgentCount(ctx context.Context) int {
 	return len(g.Addrs(ctx))
 }
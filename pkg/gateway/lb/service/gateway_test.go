@@ -17,6 +17,230 @@
 // Package service
 package service
 
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/apis/grpc/v1/vald"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/test/mock/client"
+	grpcmock "github.com/vdaas/vald/internal/test/mock/grpc"
+)
+
+func newTopologyClient(addrs []string, topologies map[string]*payload.Info_Topology) *client.DiscovererClientMock {
+	return &client.DiscovererClientMock{
+		GetAddrsFunc: func(context.Context) []string {
+			return addrs
+		},
+		GetTopologyFunc: func(addr string) *payload.Info_Topology {
+			return topologies[addr]
+		},
+		GetNodeTopologyFunc: func(name string) *payload.Info_Topology {
+			if name == "node-a" {
+				return &payload.Info_Topology{Zone: "zone-a"}
+			}
+			return nil
+		},
+	}
+}
+
+func Test_gateway_spread(t *testing.T) {
+	t.Parallel()
+	topologies := map[string]*payload.Info_Topology{
+		"a1": {Zone: "zone-a", Rack: "rack-1", Hostname: "host-1"},
+		"a2": {Zone: "zone-a", Rack: "rack-1", Hostname: "host-1"},
+		"a3": {Zone: "zone-a", Rack: "rack-2", Hostname: "host-2"},
+		"b1": {Zone: "zone-b", Rack: "rack-1", Hostname: "host-3"},
+		"c1": {Zone: "zone-c", Hostname: "host-4"},
+	}
+	tests := []struct {
+		name  string
+		addrs []string
+		want  []string
+	}{
+		{
+			name:  "distinct zones come first",
+			addrs: []string{"a1", "a2", "a3", "b1", "c1"},
+			want:  []string{"a1", "b1", "c1", "a3", "a2"},
+		},
+		{
+			name:  "distinct racks are preferred within a zone",
+			addrs: []string{"a1", "a2", "a3"},
+			want:  []string{"a1", "a3", "a2"},
+		},
+		{
+			name:  "unknown topology keeps the resource based order",
+			addrs: []string{"x1", "x2", "x3"},
+			want:  []string{"x1", "x2", "x3"},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			g := &gateway{
+				client: newTopologyClient(test.addrs, topologies),
+			}
+			if got := g.spread(slices.Clone(test.addrs)); !slices.Equal(got, test.want) {
+				tt.Errorf("spread() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_gateway_localAddrs(t *testing.T) {
+	t.Parallel()
+	addrs := []string{"a1", "b1", "a2", "b2"}
+	topologies := map[string]*payload.Info_Topology{
+		"a1": {Zone: "zone-a"},
+		"a2": {Zone: "zone-a"},
+		"b1": {Zone: "zone-b"},
+		"b2": {Zone: "zone-b"},
+	}
+	tests := []struct {
+		name  string
+		g     gateway
+		addrs []string
+		want  []string
+	}{
+		{
+			name: "local zone agents are returned when every zone holds a replica",
+			g: gateway{
+				indexReplica:   2,
+				spreadReplicas: true,
+				preferSameZone: true,
+				zone:           "zone-b",
+			},
+			want: []string{"b1", "b2"},
+		},
+		{
+			name: "zone is looked up from the node name",
+			g: gateway{
+				indexReplica:   2,
+				spreadReplicas: true,
+				preferSameZone: true,
+				nodeName:       "node-a",
+			},
+			want: []string{"a1", "a2"},
+		},
+		{
+			name: "fewer replicas than zones searches every agent",
+			g: gateway{
+				indexReplica:   1,
+				spreadReplicas: true,
+				preferSameZone: true,
+				zone:           "zone-a",
+			},
+		},
+		{
+			name: "replicas which are not spread searches every agent",
+			g: gateway{
+				indexReplica:   2,
+				preferSameZone: true,
+				zone:           "zone-a",
+			},
+		},
+		{
+			name: "unknown zone searches every agent",
+			g: gateway{
+				indexReplica:   2,
+				spreadReplicas: true,
+				preferSameZone: true,
+				nodeName:       "node-x",
+			},
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			g := test.g
+			g.client = newTopologyClient(addrs, topologies)
+			if got := g.localAddrs(context.Background()); !slices.Equal(got, test.want) {
+				tt.Errorf("localAddrs() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_gateway_DoMulti(t *testing.T) {
+	t.Parallel()
+	addrs := []string{"a1", "a2", "b1", "b2", "c1"}
+	topologies := map[string]*payload.Info_Topology{
+		"a1": {Zone: "zone-a", Hostname: "host-1"},
+		"a2": {Zone: "zone-a", Hostname: "host-2"},
+		"b1": {Zone: "zone-b", Hostname: "host-3"},
+		"b2": {Zone: "zone-b", Hostname: "host-4"},
+		"c1": {Zone: "zone-c", Hostname: "host-5"},
+	}
+	tests := []struct {
+		name   string
+		num    int
+		failed []string
+		want   []string
+		err    bool
+	}{
+		{
+			name: "replicas are stored in distinct zones",
+			num:  3,
+			want: []string{"a1", "b1", "c1"},
+		},
+		{
+			name:   "replica of the failed agent is stored in the other agent of the same zone",
+			num:    3,
+			failed: []string{"b1"},
+			want:   []string{"a1", "c1", "b2"},
+		},
+		{
+			name:   "replica is stored in a used zone only when every agent of the other zones failed",
+			num:    3,
+			failed: []string{"b1", "b2"},
+			want:   []string{"a1", "c1", "a2"},
+			err:    true,
+		},
+	}
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			c := newTopologyClient(addrs, topologies)
+			c.GetClientFunc = func() grpc.Client {
+				return &grpcmock.GRPCClientMock{
+					OrderedRangeFunc: func(ctx context.Context, order []string,
+						f func(ctx context.Context, addr string, conn *grpc.ClientConn, copts ...grpc.CallOption) error,
+					) (err error) {
+						for _, addr := range order {
+							err = errors.Join(err, f(ctx, addr, nil))
+						}
+						return err
+					},
+				}
+			}
+			g := &gateway{
+				client:         c,
+				spreadReplicas: true,
+			}
+			var got []string
+			err := g.DoMulti(context.Background(), test.num, func(_ context.Context, target string, _ vald.Client, _ ...grpc.CallOption) error {
+				if slices.Contains(test.failed, target) {
+					return errors.ErrUUIDNotFound(0)
+				}
+				got = append(got, target)
+				return nil
+			})
+			if (err != nil) != test.err {
+				tt.Errorf("DoMulti() error = %v, want error: %v", err, test.err)
+			}
+			if !slices.Equal(got, test.want) {
+				tt.Errorf("DoMulti() stored to %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
 // NOT IMPLEMENTED BELOW
 //
 // func TestNewGateway(t *testing.T) {
@@ -45,3 +45,45 @@ func WithErrGroup(eg errgroup.Group) Option {
 		return nil
 	}
 }
+
+// WithIndexReplica returns the option to set the number of replicas written for each vector.
+func WithIndexReplica(n int) Option {
+	return func(g *gateway) error {
+		if n > 0 {
+			g.indexReplica = n
+		}
+		return nil
+	}
+}
+
+// WithSpreadReplicas returns the option to spread the replicas across distinct failure domains.
+func WithSpreadReplicas(enabled bool) Option {
+	return func(g *gateway) error {
+		g.spreadReplicas = enabled
+		return nil
+	}
+}
+
+// WithPreferSameZone returns the option to search only the agents in the gateway's zone when it is safe.
+func WithPreferSameZone(enabled bool) Option {
+	return func(g *gateway) error {
+		g.preferSameZone = enabled
+		return nil
+	}
+}
+
+// WithZone returns the option to set the zone the gateway runs in.
+func WithZone(zone string) Option {
+	return func(g *gateway) error {
+		g.zone = zone
+		return nil
+	}
+}
+
+// WithNodeName returns the option to set the node the gateway runs on, used to look up its zone.
+func WithNodeName(name string) Option {
+	return func(g *gateway) error {
+		g.nodeName = name
+		return nil
+	}
+}
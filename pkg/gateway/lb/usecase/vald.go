@@ -19,19 +19,25 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/vdaas/vald/apis/grpc/v1/vald"
 	"github.com/vdaas/vald/internal/client/v1/client/discoverer"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/log"
 	"github.com/vdaas/vald/internal/net/grpc"
 	"github.com/vdaas/vald/internal/observability"
 	backoffmetrics "github.com/vdaas/vald/internal/observability/metrics/backoff"
 	cbmetrics "github.com/vdaas/vald/internal/observability/metrics/circuitbreaker"
+	ratelimitmetrics "github.com/vdaas/vald/internal/observability/metrics/ratelimit"
 	"github.com/vdaas/vald/internal/runner"
 	"github.com/vdaas/vald/internal/safety"
 	"github.com/vdaas/vald/internal/servers/server"
 	"github.com/vdaas/vald/internal/servers/starter"
 	"github.com/vdaas/vald/internal/sync/errgroup"
+	"github.com/vdaas/vald/internal/timeutil"
 	"github.com/vdaas/vald/pkg/gateway/lb/config"
+	"github.com/vdaas/vald/pkg/gateway/lb/handler/graphql"
 	handler "github.com/vdaas/vald/pkg/gateway/lb/handler/grpc"
 	"github.com/vdaas/vald/pkg/gateway/lb/handler/rest"
 	"github.com/vdaas/vald/pkg/gateway/lb/router"
@@ -44,10 +50,14 @@ type run struct {
 	server        starter.Server
 	observability observability.Observability
 	gateway       service.Gateway
+	handler       handler.Server
+	client        discoverer.Client
+	meta          grpc.Client
 }
 
 func discovererClient(
 	cfg *config.Data, dopts, aopts []grpc.Option, eg errgroup.Group,
+	onDiscover func(ctx context.Context, c discoverer.Client, addrs []string) error,
 ) (discoverer.Client, error) {
 	var discovererOpts []discoverer.Option
 	discovererOpts = append(discovererOpts,
@@ -61,6 +71,7 @@ func discovererClient(
 		discoverer.WithOptions(aopts...),
 		discoverer.WithNodeName(cfg.Gateway.NodeName),
 		discoverer.WithReadReplicaReplicas(cfg.Gateway.ReadReplicaReplicas),
+		discoverer.WithOnDiscoverFunc(onDiscover),
 	)
 
 	rrOpts, err := cfg.Gateway.ReadReplicaClient.Client.Opts()
@@ -102,15 +113,34 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		acOpts,
 		grpc.WithErrGroup(eg))
 
-	client, err := discovererClient(cfg, dopts, aopts, eg)
+	client, err := discovererClient(cfg, dopts, aopts, eg,
+		func(ctx context.Context, _ discoverer.Client, addrs []string) error {
+			// the collections are created on the discovered agents, and the failed agents are retried on the next discovery
+			if gateway != nil {
+				if err := gateway.SyncCollections(ctx, addrs); err != nil {
+					log.Warnf("failed to sync the collections to the discovered agents: %v", err)
+				}
+			}
+			return nil
+		})
 	if err != nil {
 		return nil, err
 	}
 
-	gateway, err = service.NewGateway(
+	gopts := []service.Option{
 		service.WithErrGroup(eg),
 		service.WithDiscoverer(client),
-	)
+		service.WithIndexReplica(cfg.Gateway.IndexReplica),
+	}
+	if t := cfg.Gateway.Topology; t != nil {
+		gopts = append(gopts,
+			service.WithSpreadReplicas(t.SpreadReplicas),
+			service.WithPreferSameZone(t.PreferSameZone),
+			service.WithZone(t.Zone),
+			service.WithNodeName(t.NodeName),
+		)
+	}
+	gateway, err = service.NewGateway(gopts...)
 	if err != nil {
 		return nil, err
 	}
@@ -121,11 +151,22 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		handler.WithReplicationCount(cfg.Gateway.IndexReplica),
 		handler.WithStreamConcurrency(cfg.Server.GetGRPCStreamConcurrency()),
 		handler.WithMultiConcurrency(cfg.Gateway.MultiOperationConcurrency),
+		handler.WithTimeout(cfg.Gateway.SearchTimeout),
 	)
 
+	var meta grpc.Client
+	if cfg.Gateway.Meta != nil && len(cfg.Gateway.Meta.Addrs) != 0 {
+		mopts, err := cfg.Gateway.Meta.Opts()
+		if err != nil {
+			return nil, err
+		}
+		meta = grpc.New(append(mopts, grpc.WithErrGroup(eg))...)
+	}
+
 	grpcServerOptions := []server.Option{
 		server.WithGRPCRegistFunc(func(srv *grpc.Server) {
 			vald.RegisterValdServer(srv, v)
+			vald.RegisterCollectionServer(srv, v)
 		}),
 		server.WithPreStopFunction(func() error {
 			// TODO notify another gateway and scheduler
@@ -139,6 +180,7 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 			cfg.Observability,
 			backoffmetrics.New(),
 			cbmetrics.New(),
+			ratelimitmetrics.New(),
 		)
 		if err != nil {
 			return nil, err
@@ -154,6 +196,21 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 						router.WithHandler(
 							rest.New(
 								rest.WithVald(v),
+								rest.WithCollection(v),
+							),
+						),
+					),
+				),
+			}
+		}),
+		starter.WithGQL(func(sc *config.Server) []server.Option {
+			return []server.Option{
+				server.WithHTTPHandler(
+					router.NewGraphQL(
+						router.WithGraphQLHandler(
+							graphql.New(
+								graphql.WithVald(v),
+								graphql.WithMetaClient(meta),
 							),
 						),
 					),
@@ -174,6 +231,9 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		server:        srv,
 		observability: obs,
 		gateway:       gateway,
+		handler:       v,
+		client:        client,
+		meta:          meta,
 	}, nil
 }
 
@@ -186,7 +246,7 @@ func (r *run) PreStart(ctx context.Context) error {
 
 func (r *run) Start(ctx context.Context) (<-chan error, error) {
 	ech := make(chan error, 6)
-	var gech, sech, oech <-chan error
+	var gech, sech, oech, mech <-chan error
 	var err error
 	if r.observability != nil {
 		oech = r.observability.Start(ctx)
@@ -198,6 +258,13 @@ func (r *run) Start(ctx context.Context) (<-chan error, error) {
 			return nil, err
 		}
 	}
+	if r.meta != nil {
+		mech, err = r.meta.StartConnectionMonitor(ctx)
+		if err != nil {
+			close(ech)
+			return nil, err
+		}
+	}
 	sech = r.server.ListenAndServe(ctx)
 	r.eg.Go(safety.RecoverFunc(func() (err error) {
 		defer close(ech)
@@ -208,6 +275,7 @@ func (r *run) Start(ctx context.Context) (<-chan error, error) {
 			case err = <-oech:
 			case err = <-gech:
 			case err = <-sech:
+			case err = <-mech:
 			}
 			if err != nil {
 				select {
@@ -229,9 +297,49 @@ func (r *run) Stop(ctx context.Context) error {
 	if r.observability != nil {
 		r.observability.Stop(ctx)
 	}
+	if r.meta != nil {
+		if err := r.meta.Close(ctx); err != nil {
+			log.Error(err)
+		}
+	}
 	return r.server.Shutdown(ctx)
 }
 
 func (*run) PostStop(context.Context) error {
 	return nil
 }
+
+// Reloadable returns the configuration fields which Reload applies without restart.
+func (*run) Reloadable() []string {
+	return []string{
+		"gateway.search_timeout",
+		"gateway.discoverer.agent_client_options.connection_pool.size",
+	}
+}
+
+// Reload applies the default search timeout and the connection pool size of the agent clients.
+// Both of them are validated before any of them is applied, so that a rejected configuration is never applied partially.
+func (r *run) Reload(ctx context.Context, cfg any) error {
+	c, ok := cfg.(*config.Data)
+	if !ok || c.Gateway == nil {
+		return errors.ErrInvalidConfig
+	}
+	var timeout time.Duration
+	if to := c.Gateway.SearchTimeout; len(to) != 0 {
+		d, err := timeutil.Parse(to)
+		if err != nil || d <= 0 {
+			return errors.ErrInvalidReloadedConfig("gateway.search_timeout", to)
+		}
+		timeout = d
+	}
+	var size int
+	if d := c.Gateway.Discoverer; d != nil && d.AgentClientOptions != nil && d.AgentClientOptions.ConnectionPool != nil {
+		size = d.AgentClientOptions.ConnectionPool.Size
+	}
+	if size < 0 {
+		return errors.ErrInvalidReloadedConfig("gateway.discoverer.agent_client_options.connection_pool.size", size)
+	}
+	r.handler.SetTimeout(timeout)
+	r.client.GetClient().SetPoolSize(ctx, size)
+	return nil
+}
@@ -102,7 +102,7 @@ func Test_discovererClient(t *testing.T) {
 		test := tt
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
-			client, err := discovererClient(test.cfg, test.dopts, test.aopts, errgroup.Get())
+			client, err := discovererClient(test.cfg, test.dopts, test.aopts, errgroup.Get(), nil)
 			test.assert(t, client, err)
 		})
 	}
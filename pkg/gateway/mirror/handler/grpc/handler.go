@@ -1208,6 +1208,7 @@ func (s *server) handleInsert(
 		Vector: req.GetVector(),
 		Config: &payload.Update_Config{
 			Timestamp: req.GetConfig().GetTimestamp(),
+			Ttl:       req.GetConfig().GetTtl(),
 		},
 	}, &result)
 	if err != nil {
@@ -1768,6 +1769,7 @@ func (s *server) handleUpdate(
 		Vector: req.GetVector(),
 		Config: &payload.Insert_Config{
 			Timestamp: req.GetConfig().GetTimestamp(),
+			Ttl:       req.GetConfig().GetTtl(),
 		},
 	}, &result)
 	if err != nil {
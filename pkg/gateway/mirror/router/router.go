@@ -43,6 +43,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/",
+				RPC:         "/",
 				HandlerFunc: h.Index,
 			},
 			{
@@ -51,6 +52,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/register",
+				RPC:         "/mirror.v1.Mirror/Register",
 				HandlerFunc: h.Register,
 			},
 			{
@@ -59,6 +61,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/search",
+				RPC:         "/vald.v1.Search/Search",
 				HandlerFunc: h.Search,
 			},
 			{
@@ -67,6 +70,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/search/{id}",
+				RPC:         "/vald.v1.Search/SearchByID",
 				HandlerFunc: h.SearchByID,
 			},
 
@@ -76,6 +80,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/search/multi",
+				RPC:         "/vald.v1.Search/MultiSearch",
 				HandlerFunc: h.MultiSearch,
 			},
 			{
@@ -84,6 +89,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/search/multi/{id}",
+				RPC:         "/vald.v1.Search/MultiSearchByID",
 				HandlerFunc: h.MultiSearchByID,
 			},
 			{
@@ -92,6 +98,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/insert",
+				RPC:         "/vald.v1.Insert/Insert",
 				HandlerFunc: h.Insert,
 			},
 			{
@@ -100,6 +107,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/insert/multi",
+				RPC:         "/vald.v1.Insert/MultiInsert",
 				HandlerFunc: h.MultiInsert,
 			},
 			{
@@ -110,6 +118,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/update",
+				RPC:         "/vald.v1.Update/Update",
 				HandlerFunc: h.Update,
 			},
 			{
@@ -120,6 +129,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/update/multi",
+				RPC:         "/vald.v1.Update/MultiUpdate",
 				HandlerFunc: h.MultiUpdate,
 			},
 			{
@@ -130,6 +140,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/upsert",
+				RPC:         "/vald.v1.Upsert/Upsert",
 				HandlerFunc: h.Upsert,
 			},
 			{
@@ -140,6 +151,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPut,
 				},
 				Pattern:     "/upsert/multi",
+				RPC:         "/vald.v1.Upsert/MultiUpsert",
 				HandlerFunc: h.MultiUpsert,
 			},
 			{
@@ -148,6 +160,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodDelete,
 				},
 				Pattern:     "/delete/{id}",
+				RPC:         "/vald.v1.Remove/Remove",
 				HandlerFunc: h.Remove,
 			},
 			{
@@ -156,6 +169,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodDelete,
 				},
 				Pattern:     "/delete/timestamp",
+				RPC:         "/vald.v1.Remove/RemoveByTimestamp",
 				HandlerFunc: h.RemoveByTimestamp,
 			},
 			{
@@ -165,6 +179,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodPost,
 				},
 				Pattern:     "/delete/multi",
+				RPC:         "/vald.v1.Remove/MultiRemove",
 				HandlerFunc: h.MultiRemove,
 			},
 			{
@@ -173,6 +188,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/object/{id}",
+				RPC:         "/vald.v1.Object/GetObject",
 				HandlerFunc: h.GetObject,
 			},
 		}...))
@@ -26,6 +26,7 @@ import (
 	backoffmetrics "github.com/vdaas/vald/internal/observability/metrics/backoff"
 	cbmetrics "github.com/vdaas/vald/internal/observability/metrics/circuitbreaker"
 	mirrormetrics "github.com/vdaas/vald/internal/observability/metrics/gateway/mirror"
+	ratelimitmetrics "github.com/vdaas/vald/internal/observability/metrics/ratelimit"
 	"github.com/vdaas/vald/internal/runner"
 	"github.com/vdaas/vald/internal/safety"
 	"github.com/vdaas/vald/internal/servers/server"
@@ -137,6 +138,7 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 			cfg.Observability,
 			backoffmetrics.New(),
 			cbmetrics.New(),
+			ratelimitmetrics.New(),
 			mirrormetrics.New(m),
 		)
 		if err != nil {
@@ -67,3 +67,15 @@ func (s *server) IndexDetail(
 	}()
 	return s.indexer.LoadIndexDetail(), nil
 }
+
+func (s *server) IndexSchedule(
+	ctx context.Context, _ *payload.Empty,
+) (res *payload.Info_Index_Schedule, err error) {
+	ctx, span := trace.StartSpan(ctx, "vald/manager-index.IndexSchedule")
+	defer func() {
+		if span != nil {
+			span.End()
+		}
+	}()
+	return s.indexer.LoadSchedule(), nil
+}
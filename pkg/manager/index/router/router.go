@@ -47,6 +47,7 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/",
+				RPC:         "/",
 				HandlerFunc: h.Index,
 			},
 			{
@@ -55,7 +56,17 @@ func New(opts ...Option) http.Handler {
 					http.MethodGet,
 				},
 				Pattern:     "/index",
+				RPC:         "/vald.v1.Index/IndexInfo",
 				HandlerFunc: h.IndexInfo,
 			},
+			{
+				Name: "IndexSchedule",
+				Methods: []string{
+					http.MethodGet,
+				},
+				Pattern:     "/index/schedule",
+				RPC:         "/vald.v1.Index/IndexSchedule",
+				HandlerFunc: h.IndexSchedule,
+			},
 		}...))
 }
@@ -46,6 +46,7 @@ type Indexer interface {
 	IsIndexing() bool
 	IsSaving() bool
 	LoadIndexDetail() *payload.Info_Index_Detail
+	LoadSchedule() *payload.Info_Index_Schedule
 }
 
 type index struct {
@@ -65,6 +66,10 @@ type index struct {
 	minUncommitted         uint32
 	uuidsCount             uint32
 	uncommittedUUIDsCount  uint32
+	indexReplica           int
+	placement              func(addr string) string
+	groupMu                sync.Mutex
+	schedule               schedule
 }
 
 func New(opts ...Option) (idx Indexer, err error) {
@@ -208,15 +213,14 @@ func (idx *index) createIndex(ctx context.Context, enableLowIndexSkip bool) (err
 	}
 	idx.indexing.Store(true)
 	defer idx.indexing.Store(false)
-	return errors.Join(idx.client.GetClient().OrderedRangeConcurrent(ctx, idx.client.GetAddrs(ctx),
-		idx.createIndexConcurrency,
+	return errors.Join(idx.execute(ctx, createIndexOperation, idx.createIndexConcurrency,
+		func(addr string) bool {
+			info, ok := idx.indexInfos.Load(addr)
+			return !ok || (info.GetUncommitted() != 0 && (!enableLowIndexSkip || info.GetUncommitted() >= idx.minUncommitted))
+		},
 		func(ctx context.Context,
 			addr string, conn *grpc.ClientConn, copts ...grpc.CallOption,
 		) (err error) {
-			info, ok := idx.indexInfos.Load(addr)
-			if ok && (info.GetUncommitted() == 0 || (enableLowIndexSkip && info.GetUncommitted() < idx.minUncommitted)) {
-				return nil
-			}
 			_, err = agent.NewAgentClient(conn).CreateIndex(ctx, &payload.Control_CreateIndexRequest{
 				PoolSize: idx.creationPoolSize,
 			}, copts...)
@@ -229,7 +233,7 @@ func (idx *index) createIndex(ctx context.Context, enableLowIndexSkip bool) (err
 				log.Warnf("an error occurred while calling CreateIndex of %s: %s", addr, err)
 				return err
 			}
-			_, ok = idx.shouldSaveList.LoadOrStore(addr, true)
+			_, ok := idx.shouldSaveList.LoadOrStore(addr, true)
 			if ok {
 				log.Debugf("addr %s already queued for saveIndex", addr)
 				return nil
@@ -251,15 +255,15 @@ func (idx *index) saveIndex(ctx context.Context, force bool) (err error) {
 	}
 	idx.saving.Store(true)
 	defer idx.saving.Store(false)
-	return idx.client.GetClient().OrderedRangeConcurrent(ctx, idx.client.GetAddrs(ctx),
-		idx.saveIndexConcurrency,
+	return idx.execute(ctx, saveIndexOperation, idx.saveIndexConcurrency,
+		func(addr string) bool {
+			_, ok := idx.shouldSaveList.Load(addr)
+			return ok || force
+		},
 		func(ctx context.Context,
 			addr string, conn *grpc.ClientConn, copts ...grpc.CallOption,
 		) (err error) {
-			_, ok := idx.shouldSaveList.LoadAndDelete(addr)
-			if !ok && !force {
-				return nil
-			}
+			idx.shouldSaveList.Delete(addr)
 			_, err = agent.NewAgentClient(conn).SaveIndex(ctx, new(payload.Empty), copts...)
 			if err != nil {
 				st, ok := status.FromError(err)
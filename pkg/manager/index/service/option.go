@@ -33,6 +33,7 @@ var defaultOptions = []Option{
 	WithSaveIndexDurationLimit("3h"),
 	WithMinUncommitted(100),
 	WithCreationPoolSize(10000),
+	WithIndexReplica(1),
 }
 
 func WithIndexingConcurrency(c int) Option {
@@ -130,3 +131,24 @@ func WithErrGroup(eg errgroup.Group) Option {
 		return nil
 	}
 }
+
+func WithIndexReplica(r int) Option {
+	return func(idx *index) error {
+		if r > 0 {
+			idx.indexReplica = r
+		}
+		return nil
+	}
+}
+
+// WithPlacement returns the option to set the function to get the placement of the agent.
+// The replicas of a vector must be stored in distinct placements whenever there are at least as many placements as the index replica,
+// and the empty placement means that the placement of the agent is unknown.
+func WithPlacement(f func(addr string) string) Option {
+	return func(idx *index) error {
+		if f != nil {
+			idx.placement = f
+		}
+		return nil
+	}
+}
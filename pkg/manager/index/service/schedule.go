@@ -0,0 +1,232 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service
+package service
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/sync"
+)
+
+const (
+	createIndexOperation = "CreateIndex"
+	saveIndexOperation   = "SaveIndex"
+)
+
+// schedule holds the agents which are running or waiting for CreateIndex and SaveIndex.
+type schedule struct {
+	mu      sync.Mutex
+	running map[string]*payload.Info_Index_Schedule_Entry
+	queue   map[string][]*payload.Info_Index_Schedule_Entry
+}
+
+func (s *schedule) enqueue(op string, entries []*payload.Info_Index_Schedule_Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.queue == nil {
+		s.queue = make(map[string][]*payload.Info_Index_Schedule_Entry, 2)
+	}
+	s.queue[op] = entries
+}
+
+func (s *schedule) start(entry *payload.Info_Index_Schedule_Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running == nil {
+		s.running = make(map[string]*payload.Info_Index_Schedule_Entry)
+	}
+	s.queue[entry.GetOperation()] = slices.DeleteFunc(s.queue[entry.GetOperation()],
+		func(e *payload.Info_Index_Schedule_Entry) bool {
+			return e.GetAddr() == entry.GetAddr()
+		})
+	s.running[entry.GetOperation()+"/"+entry.GetAddr()] = entry
+}
+
+func (s *schedule) done(entry *payload.Info_Index_Schedule_Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, entry.GetOperation()+"/"+entry.GetAddr())
+}
+
+func (s *schedule) clear(op string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.queue, op)
+}
+
+func (s *schedule) load() (running, queue []*payload.Info_Index_Schedule_Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	running = make([]*payload.Info_Index_Schedule_Entry, 0, len(s.running))
+	for _, e := range s.running {
+		running = append(running, e.CloneVT())
+	}
+	slices.SortFunc(running, func(l, r *payload.Info_Index_Schedule_Entry) int {
+		return cmp.Or(cmp.Compare(l.GetOperation(), r.GetOperation()), cmp.Compare(l.GetAddr(), r.GetAddr()))
+	})
+	for _, op := range [...]string{createIndexOperation, saveIndexOperation} {
+		for _, e := range s.queue[op] {
+			queue = append(queue, e.CloneVT())
+		}
+	}
+	return running, queue
+}
+
+// plan returns the agents to execute the operation in the order of execution.
+// The agents are ordered by the number of uncommitted vectors in descending order.
+// When the index replica is more than 1, the agents are split into groups which never hold two replicas of the same vector,
+// and each group must be executed after the previous group finished,
+// so that the other replicas of the vectors stored in the running group are still searchable without the indexing delay.
+// The agents are grouped by their placement only when the placements of all agents are known and there are at least as many
+// placements as the index replica, otherwise some placements must hold several replicas of the same vector,
+// so that every agent is executed alone.
+func (idx *index) plan(
+	op string, addrs []string, filter func(addr string) bool,
+) (groups [][]*payload.Info_Index_Schedule_Entry) {
+	placements := make(map[string]struct{}, len(addrs))
+	separable := true
+	entries := make([]*payload.Info_Index_Schedule_Entry, 0, len(addrs))
+	for _, addr := range addrs {
+		placement := idx.placementOf(addr)
+		if placement == "" {
+			separable = false
+		}
+		placements[placement] = struct{}{}
+		if filter != nil && !filter(addr) {
+			continue
+		}
+		var uncommitted uint32
+		if info, ok := idx.indexInfos.Load(addr); ok {
+			uncommitted = info.GetUncommitted()
+		}
+		entries = append(entries, &payload.Info_Index_Schedule_Entry{
+			Addr:        addr,
+			Placement:   placement,
+			Operation:   op,
+			Uncommitted: uncommitted,
+		})
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(entries, func(l, r *payload.Info_Index_Schedule_Entry) int {
+		return cmp.Compare(r.GetUncommitted(), l.GetUncommitted())
+	})
+	if idx.indexReplica <= 1 {
+		return [][]*payload.Info_Index_Schedule_Entry{entries}
+	}
+	if !separable || len(placements) < idx.indexReplica {
+		groups = make([][]*payload.Info_Index_Schedule_Entry, 0, len(entries))
+		for _, e := range entries {
+			groups = append(groups, []*payload.Info_Index_Schedule_Entry{e})
+		}
+		return groups
+	}
+	pos := make(map[string]int, len(entries))
+	totals := make([]uint64, 0, len(entries))
+	for _, e := range entries {
+		i, ok := pos[e.GetPlacement()]
+		if !ok {
+			i = len(groups)
+			pos[e.GetPlacement()] = i
+			groups = append(groups, nil)
+			totals = append(totals, 0)
+		}
+		groups[i] = append(groups[i], e)
+		totals[i] += uint64(e.GetUncommitted())
+	}
+	order := make([]int, len(groups))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(l, r int) int {
+		return cmp.Compare(totals[r], totals[l])
+	})
+	sorted := make([][]*payload.Info_Index_Schedule_Entry, 0, len(groups))
+	for _, i := range order {
+		sorted = append(sorted, groups[i])
+	}
+	return sorted
+}
+
+// execute executes f for the agents selected by filter following the plan of the operation.
+func (idx *index) execute(
+	ctx context.Context,
+	op string,
+	concurrency int,
+	filter func(addr string) bool,
+	f func(ctx context.Context, addr string, conn *grpc.ClientConn, copts ...grpc.CallOption) error,
+) (err error) {
+	groups := idx.plan(op, idx.client.GetAddrs(ctx), filter)
+	defer idx.schedule.clear(op)
+	idx.schedule.enqueue(op, slices.Concat(groups...))
+	for _, group := range groups {
+		entries := make(map[string]*payload.Info_Index_Schedule_Entry, len(group))
+		addrs := make([]string, 0, len(group))
+		for _, e := range group {
+			entries[e.GetAddr()] = e
+			addrs = append(addrs, e.GetAddr())
+		}
+		run := func() error {
+			return idx.client.GetClient().OrderedRangeConcurrent(ctx, addrs, concurrency,
+				func(ctx context.Context,
+					addr string, conn *grpc.ClientConn, copts ...grpc.CallOption,
+				) error {
+					e := entries[addr]
+					idx.schedule.start(e)
+					defer idx.schedule.done(e)
+					return f(ctx, addr, conn, copts...)
+				})
+		}
+		if len(groups) > 1 {
+			idx.groupMu.Lock()
+			err = errors.Join(err, run())
+			idx.groupMu.Unlock()
+		} else {
+			err = errors.Join(err, run())
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		default:
+		}
+	}
+	return err
+}
+
+// placementOf returns the placement of the agent, or the empty string when it is unknown.
+func (idx *index) placementOf(addr string) string {
+	if idx.placement == nil {
+		return ""
+	}
+	return idx.placement(addr)
+}
+
+func (idx *index) LoadSchedule() *payload.Info_Index_Schedule {
+	running, queue := idx.schedule.load()
+	return &payload.Info_Index_Schedule{
+		Running: running,
+		Queue:   queue,
+		Replica: uint32(max(idx.indexReplica, 1)),
+	}
+}
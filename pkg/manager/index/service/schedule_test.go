@@ -0,0 +1,366 @@
+//
+// Copyright (C) 2019-2025 vdaas.org vald team <vald@vdaas.org>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package service
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/vdaas/vald/apis/grpc/v1/payload"
+	"github.com/vdaas/vald/internal/errors"
+	"github.com/vdaas/vald/internal/net/grpc"
+	"github.com/vdaas/vald/internal/test/goleak"
+	clientmock "github.com/vdaas/vald/internal/test/mock/client"
+	grpcmock "github.com/vdaas/vald/internal/test/mock/grpc"
+)
+
+func zonePlacement(addr string) string {
+	return addr[:1]
+}
+
+func Test_index_plan(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		addrs  []string
+		filter func(addr string) bool
+	}
+	type fields struct {
+		indexReplica int
+		placement    func(addr string) string
+		uncommitted  map[string]uint32
+	}
+	type want struct {
+		want [][]string
+	}
+	type test struct {
+		name      string
+		args      args
+		fields    fields
+		want      want
+		checkFunc func(want, [][]*payload.Info_Index_Schedule_Entry) error
+	}
+	defaultCheckFunc := func(w want, got [][]*payload.Info_Index_Schedule_Entry) error {
+		addrs := make([][]string, 0, len(got))
+		for _, group := range got {
+			g := make([]string, 0, len(group))
+			for _, e := range group {
+				g = append(g, e.GetAddr())
+			}
+			addrs = append(addrs, g)
+		}
+		if len(addrs) == 0 {
+			addrs = nil
+		}
+		if !reflect.DeepEqual(addrs, w.want) {
+			return errors.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", addrs, w.want)
+		}
+		return nil
+	}
+	tests := []test{
+		{
+			name: "return all agents in one group ordered by uncommitted when the replica is 1",
+			args: args{
+				addrs: []string{"a1", "a2", "b1", "b2"},
+			},
+			fields: fields{
+				indexReplica: 1,
+				placement:    zonePlacement,
+				uncommitted: map[string]uint32{
+					"a1": 10,
+					"a2": 30,
+					"b1": 20,
+				},
+			},
+			want: want{
+				want: [][]string{{"a2", "b1", "a1", "b2"}},
+			},
+		},
+		{
+			name: "return the groups of the placements ordered by the total uncommitted when the replica is 2",
+			args: args{
+				addrs: []string{"a1", "a2", "b1", "b2"},
+			},
+			fields: fields{
+				indexReplica: 2,
+				placement:    zonePlacement,
+				uncommitted: map[string]uint32{
+					"a1": 10,
+					"a2": 30,
+					"b1": 20,
+					"b2": 50,
+				},
+			},
+			want: want{
+				want: [][]string{{"b2", "b1"}, {"a2", "a1"}},
+			},
+		},
+		{
+			name: "return every agent alone when the placement is not configured",
+			args: args{
+				addrs: []string{"a1", "a2", "b1", "b2"},
+			},
+			fields: fields{
+				indexReplica: 2,
+				uncommitted: map[string]uint32{
+					"a1": 10,
+					"a2": 30,
+					"b1": 20,
+					"b2": 50,
+				},
+			},
+			want: want{
+				want: [][]string{{"b2"}, {"a2"}, {"b1"}, {"a1"}},
+			},
+		},
+		{
+			name: "return every agent alone when the placement of any agent is unknown",
+			args: args{
+				addrs: []string{"a1", "a2", "b1", "b2"},
+			},
+			fields: fields{
+				indexReplica: 2,
+				placement: func(addr string) string {
+					if addr == "b2" {
+						return ""
+					}
+					return zonePlacement(addr)
+				},
+				uncommitted: map[string]uint32{
+					"a1": 10,
+					"a2": 30,
+					"b1": 20,
+					"b2": 50,
+				},
+			},
+			want: want{
+				want: [][]string{{"b2"}, {"a2"}, {"b1"}, {"a1"}},
+			},
+		},
+		{
+			name: "return every agent alone when there are fewer placements than the replica",
+			args: args{
+				addrs: []string{"a1", "a2", "b1", "b2"},
+			},
+			fields: fields{
+				indexReplica: 3,
+				placement:    zonePlacement,
+				uncommitted: map[string]uint32{
+					"a1": 10,
+					"a2": 30,
+					"b1": 20,
+					"b2": 50,
+				},
+			},
+			want: want{
+				want: [][]string{{"b2"}, {"a2"}, {"b1"}, {"a1"}},
+			},
+		},
+		{
+			name: "return every agent alone when all agents are in a single placement",
+			args: args{
+				addrs: []string{"a1", "a2"},
+			},
+			fields: fields{
+				indexReplica: 2,
+				placement:    zonePlacement,
+				uncommitted: map[string]uint32{
+					"a1": 10,
+					"a2": 30,
+				},
+			},
+			want: want{
+				want: [][]string{{"a2"}, {"a1"}},
+			},
+		},
+		{
+			name: "return the agents selected by the filter",
+			args: args{
+				addrs: []string{"a1", "a2", "b1", "b2"},
+				filter: func(addr string) bool {
+					return addr != "b2"
+				},
+			},
+			fields: fields{
+				indexReplica: 2,
+				placement:    zonePlacement,
+				uncommitted: map[string]uint32{
+					"a1": 10,
+					"a2": 30,
+					"b1": 20,
+					"b2": 50,
+				},
+			},
+			want: want{
+				want: [][]string{{"a2", "a1"}, {"b1"}},
+			},
+		},
+		{
+			name: "return nil when no agent is selected",
+			args: args{
+				addrs: []string{"a1"},
+				filter: func(string) bool {
+					return false
+				},
+			},
+			fields: fields{
+				indexReplica: 2,
+				placement:    zonePlacement,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			defer goleak.VerifyNone(tt, goleak.IgnoreCurrent())
+			checkFunc := test.checkFunc
+			if test.checkFunc == nil {
+				checkFunc = defaultCheckFunc
+			}
+			idx := &index{
+				indexReplica: test.fields.indexReplica,
+				placement:    test.fields.placement,
+			}
+			for addr, n := range test.fields.uncommitted {
+				idx.indexInfos.Store(addr, &payload.Info_Index_Count{Uncommitted: n})
+			}
+
+			got := idx.plan(createIndexOperation, test.args.addrs, test.args.filter)
+			if err := checkFunc(test.want, got); err != nil {
+				tt.Errorf("error = %v", err)
+			}
+		})
+	}
+}
+
+func Test_index_execute(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		addrs []string
+	}
+	type fields struct {
+		indexReplica int
+		placement    func(addr string) string
+	}
+	type want struct {
+		calls [][]string
+		err   error
+	}
+	type test struct {
+		name   string
+		args   args
+		fields fields
+		want   want
+	}
+	tests := []test{
+		{
+			name: "call the agents at once when the replica is 1",
+			args: args{
+				addrs: []string{"a1", "b1", "a2"},
+			},
+			fields: fields{
+				indexReplica: 1,
+				placement:    zonePlacement,
+			},
+			want: want{
+				calls: [][]string{{"a1", "b1", "a2"}},
+			},
+		},
+		{
+			name: "call the agents for each placement when the replica is 2",
+			args: args{
+				addrs: []string{"a1", "b1", "a2"},
+			},
+			fields: fields{
+				indexReplica: 2,
+				placement:    zonePlacement,
+			},
+			want: want{
+				calls: [][]string{{"a1", "a2"}, {"b1"}},
+			},
+		},
+		{
+			name: "call the agents one by one when the placement is not configured",
+			args: args{
+				addrs: []string{"a1", "b1", "a2"},
+			},
+			fields: fields{
+				indexReplica: 2,
+			},
+			want: want{
+				calls: [][]string{{"a1"}, {"b1"}, {"a2"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		test := tc
+		t.Run(test.name, func(tt *testing.T) {
+			tt.Parallel()
+			defer goleak.VerifyNone(tt, goleak.IgnoreCurrent())
+			idx := &index{
+				indexReplica: test.fields.indexReplica,
+				placement:    test.fields.placement,
+			}
+			var (
+				calls     [][]string
+				schedules []*payload.Info_Index_Schedule
+			)
+			idx.client = &clientmock.DiscovererClientMock{
+				GetAddrsFunc: func(context.Context) []string {
+					return test.args.addrs
+				},
+				GetClientFunc: func() grpc.Client {
+					return &grpcmock.GRPCClientMock{
+						OrderedRangeConcurrentFunc: func(ctx context.Context, order []string, _ int,
+							f func(ctx context.Context, addr string, conn *grpc.ClientConn, copts ...grpc.CallOption) error,
+						) (err error) {
+							calls = append(calls, order)
+							for _, addr := range order {
+								err = errors.Join(err, f(ctx, addr, nil))
+							}
+							return err
+						},
+					}
+				},
+			}
+
+			err := idx.execute(context.Background(), createIndexOperation, 1, nil,
+				func(context.Context, string, *grpc.ClientConn, ...grpc.CallOption) error {
+					schedules = append(schedules, idx.LoadSchedule())
+					return nil
+				})
+			if !errors.Is(err, test.want.err) {
+				tt.Errorf("got_error: \"%#v\",\n\t\t\t\twant: \"%#v\"", err, test.want.err)
+			}
+			if !reflect.DeepEqual(calls, test.want.calls) {
+				tt.Errorf("got: \"%#v\",\n\t\t\t\twant: \"%#v\"", calls, test.want.calls)
+			}
+			for i, s := range schedules {
+				if len(s.GetRunning()) != 1 || len(s.GetQueue()) != len(test.args.addrs)-i-1 {
+					tt.Errorf("unexpected schedule %d: %v", i, s)
+				}
+			}
+			if s := idx.LoadSchedule(); len(s.GetRunning()) != 0 || len(s.GetQueue()) != 0 {
+				tt.Errorf("schedule is not cleared: %v", s)
+			}
+		})
+	}
+}
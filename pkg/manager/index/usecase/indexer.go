@@ -91,7 +91,7 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 	if err != nil {
 		return nil, err
 	}
-	indexer, err = service.New(
+	opts := []service.Option{
 		service.WithErrGroup(eg),
 		service.WithDiscoverer(client),
 		service.WithIndexingConcurrency(cfg.Indexer.Concurrency),
@@ -100,7 +100,15 @@ func New(cfg *config.Data) (r runner.Runner, err error) {
 		service.WithSaveIndexDurationLimit(cfg.Indexer.AutoSaveIndexDurationLimit),
 		service.WithCreationPoolSize(cfg.Indexer.CreationPoolSize),
 		service.WithMinUncommitted(cfg.Indexer.AutoIndexLength),
-	)
+		service.WithIndexReplica(cfg.Indexer.IndexReplica),
+	}
+	if cfg.Indexer.IndexByZone {
+		opts = append(opts, service.WithPlacement(func(addr string) string {
+			// the replicas are stored in distinct zones reported by the discoverer
+			return client.GetTopology(addr).GetZone()
+		}))
+	}
+	indexer, err = service.New(opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +140,9 @@ pub struct Config {
         /// Search nprobe.
         #[prost(uint32, tag="11")]
         pub nprobe: u32,
+        /// Include the uncommitted vectors in the insert queue in the search result.
+        #[prost(bool, tag="12")]
+        pub include_uncommitted: bool,
     }
 impl ::prost::Name for Config {
 const NAME: &'static str = "Config";
@@ -353,6 +356,10 @@ pub struct Config {
         /// Insert timestamp.
         #[prost(int64, tag="3")]
         pub timestamp: i64,
+        /// Time to live of the vector in seconds from the timestamp.
+        /// 0 uses the default TTL of the agent, and a negative value disables the expiry.
+        #[prost(int64, tag="4")]
+        pub ttl: i64,
     }
 impl ::prost::Name for Config {
 const NAME: &'static str = "Config";
@@ -462,6 +469,10 @@ pub struct Config {
         /// during update operation.
         #[prost(bool, tag="4")]
         pub disable_balanced_update: bool,
+        /// Time to live of the vector in seconds from the timestamp.
+        /// 0 uses the default TTL of the agent, and a negative value disables the expiry.
+        #[prost(int64, tag="5")]
+        pub ttl: i64,
     }
 impl ::prost::Name for Config {
 const NAME: &'static str = "Config";
@@ -553,6 +564,10 @@ pub struct Config {
         /// during update operation.
         #[prost(bool, tag="4")]
         pub disable_balanced_update: bool,
+        /// Time to live of the vector in seconds from the timestamp.
+        /// 0 uses the default TTL of the agent, and a negative value disables the expiry.
+        #[prost(int64, tag="5")]
+        pub ttl: i64,
     }
 impl ::prost::Name for Config {
 const NAME: &'static str = "Config";
@@ -805,12 +820,61 @@ pub struct Vector {
         /// The vector ID.
         #[prost(string, tag="1")]
         pub id: ::prost::alloc::string::String,
-        /// The vector.
+        /// The vector. It is empty when the vector is sent in the data field.
         #[prost(float, repeated, packed="false", tag="2")]
         pub vector: ::prost::alloc::vec::Vec<f32>,
         /// timestamp represents when this vector inserted.
         #[prost(int64, tag="3")]
         pub timestamp: i64,
+        /// The encoding of the vector.
+        #[prost(enumeration="vector::Encoding", tag="4")]
+        pub encoding: i32,
+        /// The encoded vector, used unless the encoding is FLOAT32.
+        #[prost(bytes="vec", tag="5")]
+        pub data: ::prost::alloc::vec::Vec<u8>,
+        /// The scale of the int8 values, used only by the INT8 encoding.
+        #[prost(float, tag="6")]
+        pub scale: f32,
+    }
+    /// Nested message and enum types in `Vector`.
+    pub mod vector {
+        /// Encoding is enum of each wire encoding of a vector.
+        #[derive(Clone, Copy, Debug, PartialEq, Eq, Hash, PartialOrd, Ord, ::prost::Enumeration)]
+        #[repr(i32)]
+        pub enum Encoding {
+            /// The vector is sent in the vector field.
+            Float32 = 0,
+            /// The vector is sent in the data field as little-endian IEEE 754 half precision floats.
+            Float16 = 1,
+            /// The vector is sent in the data field as int8 values, each multiplied by scale.
+            Int8 = 2,
+            /// The vector is sent in the data field as little-endian IEEE 754 single precision floats.
+            Bytes = 3,
+        }
+        impl Encoding {
+            /// String value of the enum field names used in the ProtoBuf definition.
+            ///
+            /// The values are not transformed in any way and thus are considered stable
+            /// (if the ProtoBuf definition does not change) and safe for programmatic use.
+            pub fn as_str_name(&self) -> &'static str {
+                match self {
+                    Encoding::Float32 => "FLOAT32",
+                    Encoding::Float16 => "FLOAT16",
+                    Encoding::Int8 => "INT8",
+                    Encoding::Bytes => "BYTES",
+                }
+            }
+            /// Creates an enum from field names used in the ProtoBuf definition.
+            pub fn from_str_name(value: &str) -> ::core::option::Option<Self> {
+                match value {
+                    "FLOAT32" => Some(Self::Float32),
+                    "FLOAT16" => Some(Self::Float16),
+                    "INT8" => Some(Self::Int8),
+                    "BYTES" => Some(Self::Bytes),
+                    _ => None,
+                }
+            }
+        }
     }
 impl ::prost::Name for Vector {
 const NAME: &'static str = "Vector";
@@ -1140,6 +1204,48 @@ impl ::prost::Name for Detail {
 const NAME: &'static str = "Detail";
 const PACKAGE: &'static str = "payload.v1";
 fn full_name() -> ::prost::alloc::string::String { "payload.v1.Info.Index.Detail".into() }fn type_url() -> ::prost::alloc::string::String { "/payload.v1.Info.Index.Detail".into() }}
+        /// Represent the index operation schedule of the index manager.
+        #[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+        pub struct Schedule {
+            /// The agents running an index operation.
+            #[prost(message, repeated, tag="1")]
+            pub running: ::prost::alloc::vec::Vec<schedule::Entry>,
+            /// The agents waiting for an index operation in the scheduled order.
+            #[prost(message, repeated, tag="2")]
+            pub queue: ::prost::alloc::vec::Vec<schedule::Entry>,
+            /// index replica of vald cluster, the replicas of a placement except one are kept out of indexing when it is more than one
+            #[prost(uint32, tag="3")]
+            pub replica: u32,
+        }
+        /// Nested message and enum types in `Schedule`.
+        pub mod schedule {
+            /// Represent an agent running or waiting for an index operation.
+            #[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+            pub struct Entry {
+                /// The agent address.
+                #[prost(string, tag="1")]
+                pub addr: ::prost::alloc::string::String,
+                /// The placement group of the agent.
+                #[prost(string, tag="2")]
+                pub placement: ::prost::alloc::string::String,
+                /// The index operation, CreateIndex or SaveIndex.
+                #[prost(string, tag="3")]
+                pub operation: ::prost::alloc::string::String,
+                /// The uncommitted index count of the agent when it was scheduled.
+                #[prost(uint32, tag="4")]
+                pub uncommitted: u32,
+            }
+impl ::prost::Name for Entry {
+const NAME: &'static str = "Entry";
+const PACKAGE: &'static str = "payload.v1";
+fn full_name() -> ::prost::alloc::string::String { "payload.v1.Info.Index.Schedule.Entry".into() }fn type_url() -> ::prost::alloc::string::String { "/payload.v1.Info.Index.Schedule.Entry".into() }}
+        }
+impl ::prost::Name for Schedule {
+const NAME: &'static str = "Schedule";
+const PACKAGE: &'static str = "payload.v1";
+fn full_name() -> ::prost::alloc::string::String { "payload.v1.Info.Index.Schedule".into() }fn type_url() -> ::prost::alloc::string::String { "/payload.v1.Info.Index.Schedule".into() }}
         /// Represent the UUID message.
         #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, Copy, PartialEq, ::prost::Message)]
@@ -1244,6 +1350,12 @@ pub struct Statistics {
             pub outdegree_histogram: ::prost::alloc::vec::Vec<u64>,
             #[prost(uint64, repeated, tag="33")]
             pub indegree_histogram: ::prost::alloc::vec::Vec<u64>,
+            #[prost(uint64, tag="34")]
+            pub quantization_saved_memory_bytes: u64,
+            #[prost(double, tag="35")]
+            pub quantization_recall: f64,
+            #[prost(uint64, tag="36")]
+            pub quantization_recall_samples: u64,
         }
 impl ::prost::Name for Statistics {
 const NAME: &'static str = "Statistics";
@@ -1333,6 +1445,12 @@ pub struct Property {
             pub outgoing_edge: i32,
             #[prost(int32, tag="34")]
             pub incoming_edge: i32,
+            #[prost(string, tag="35")]
+            pub quantization_type: ::prost::alloc::string::String,
+            #[prost(int32, tag="36")]
+            pub quantization_rerank_factor: i32,
+            #[prost(int32, tag="37")]
+            pub quantized_object_size: i32,
         }
 impl ::prost::Name for Property {
 const NAME: &'static str = "Property";
@@ -1379,6 +1497,9 @@ pub struct Pod {
         /// The node information of the pod.
         #[prost(message, optional, tag="7")]
         pub node: ::core::option::Option<Node>,
+        /// The topology of the node the pod runs on.
+        #[prost(message, optional, tag="8")]
+        pub topology: ::core::option::Option<Topology>,
     }
 impl ::prost::Name for Pod {
 const NAME: &'static str = "Pod";
@@ -1406,11 +1527,32 @@ pub struct Node {
         /// The pod information of the node.
         #[prost(message, optional, tag="6")]
         pub pods: ::core::option::Option<Pods>,
+        /// The topology of the node.
+        #[prost(message, optional, tag="7")]
+        pub topology: ::core::option::Option<Topology>,
     }
 impl ::prost::Name for Node {
 const NAME: &'static str = "Node";
 const PACKAGE: &'static str = "payload.v1";
 fn full_name() -> ::prost::alloc::string::String { "payload.v1.Info.Node".into() }fn type_url() -> ::prost::alloc::string::String { "/payload.v1.Info.Node".into() }}
+    /// Represent the failure domains of a node.
+    #[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+    pub struct Topology {
+        /// The availability zone of the node.
+        #[prost(string, tag="1")]
+        pub zone: ::prost::alloc::string::String,
+        /// The rack of the node.
+        #[prost(string, tag="2")]
+        pub rack: ::prost::alloc::string::String,
+        /// The hostname of the node.
+        #[prost(string, tag="3")]
+        pub hostname: ::prost::alloc::string::String,
+    }
+impl ::prost::Name for Topology {
+const NAME: &'static str = "Topology";
+const PACKAGE: &'static str = "payload.v1";
+fn full_name() -> ::prost::alloc::string::String { "payload.v1.Info.Topology".into() }fn type_url() -> ::prost::alloc::string::String { "/payload.v1.Info.Topology".into() }}
     /// Represent the service information message.
     #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
@@ -1645,6 +1787,63 @@ impl ::prost::Name for Meta {
 const NAME: &'static str = "Meta";
 const PACKAGE: &'static str = "payload.v1";
 fn full_name() -> ::prost::alloc::string::String { "payload.v1.Meta".into() }fn type_url() -> ::prost::alloc::string::String { "/payload.v1.Meta".into() }}
+/// Collection related messages.
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, Copy, PartialEq, ::prost::Message)]
+pub struct Collection {
+}
+/// Nested message and enum types in `Collection`.
+pub mod collection {
+    /// Represent the collection definition.
+    #[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+    pub struct Config {
+        /// The collection name.
+        #[prost(string, tag="1")]
+        pub name: ::prost::alloc::string::String,
+        /// The vector dimension of the collection.
+        #[prost(uint32, tag="2")]
+        pub dimension: u32,
+        /// The distance type of the collection.
+        #[prost(string, tag="3")]
+        pub distance_type: ::prost::alloc::string::String,
+        /// The object type of the collection.
+        #[prost(string, tag="4")]
+        pub object_type: ::prost::alloc::string::String,
+    }
+impl ::prost::Name for Config {
+const NAME: &'static str = "Config";
+const PACKAGE: &'static str = "payload.v1";
+fn full_name() -> ::prost::alloc::string::String { "payload.v1.Collection.Config".into() }fn type_url() -> ::prost::alloc::string::String { "/payload.v1.Collection.Config".into() }}
+    /// Represent the collection name.
+    #[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+    pub struct Name {
+        /// The collection name.
+        #[prost(string, tag="1")]
+        pub name: ::prost::alloc::string::String,
+    }
+impl ::prost::Name for Name {
+const NAME: &'static str = "Name";
+const PACKAGE: &'static str = "payload.v1";
+fn full_name() -> ::prost::alloc::string::String { "payload.v1.Collection.Name".into() }fn type_url() -> ::prost::alloc::string::String { "/payload.v1.Collection.Name".into() }}
+    /// Represent the multiple collection definitions.
+    #[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+    pub struct List {
+        /// The collection definitions.
+        #[prost(message, repeated, tag="1")]
+        pub collections: ::prost::alloc::vec::Vec<Config>,
+    }
+impl ::prost::Name for List {
+const NAME: &'static str = "List";
+const PACKAGE: &'static str = "payload.v1";
+fn full_name() -> ::prost::alloc::string::String { "payload.v1.Collection.List".into() }fn type_url() -> ::prost::alloc::string::String { "/payload.v1.Collection.List".into() }}
+}
+impl ::prost::Name for Collection {
+const NAME: &'static str = "Collection";
+const PACKAGE: &'static str = "payload.v1";
+fn full_name() -> ::prost::alloc::string::String { "payload.v1.Collection".into() }fn type_url() -> ::prost::alloc::string::String { "/payload.v1.Collection".into() }}
 /// Represent an empty message.
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, Copy, PartialEq, ::prost::Message)]
@@ -56,7 +56,7 @@ func registerVector(ctx context.Context, n service.NGT) error {
 	for i := int64(0); i < maxIDNum; i++ {
 		uuid := strconv.FormatInt(i, 10)
 
-		err := n.Insert(uuid, []float32{float32(i), float32(i)})
+		err := n.Insert(ctx, uuid, []float32{float32(i), float32(i)})
 		if err != nil {
 			return err
 		}
@@ -108,12 +108,12 @@ func Test_ngt_parallel_delete_and_insert(t *testing.T) {
 
 				uuid := strconv.FormatInt(i, 10)
 
-				err := n.Delete(uuid)
+				err := n.Delete(ctx, uuid)
 				if err != nil && !errors.Is(err, errors.ErrObjectIDNotFound(uuid)) {
 					t.Error(err)
 				}
 
-				err = n.Insert(uuid, []float32{float32(i), float32(i)})
+				err = n.Insert(ctx, uuid, []float32{float32(i), float32(i)})
 				if err != nil && !errors.Is(err, errors.ErrUUIDAlreadyExists(uuid)) {
 					t.Error(err)
 				}
@@ -156,7 +156,7 @@ func Test_ngt_parallel_delete_and_insert(t *testing.T) {
 		if err != nil || len(vec) == 0 {
 			t.Error(errors.ErrObjectNotFound(err, uuid))
 		}
-		err = n.Insert(uuid, []float32{1, 2})
+		err = n.Insert(ctx, uuid, []float32{1, 2})
 		if err == nil {
 			t.Error(err)
 		}
@@ -191,12 +191,12 @@ func Test_ngt_parallel_insert_and_delete(t *testing.T) {
 
 				uuid := strconv.FormatInt(i, 10)
 
-				err := n.Insert(uuid, []float32{float32(i), float32(i)})
+				err := n.Insert(ctx, uuid, []float32{float32(i), float32(i)})
 				if err != nil && !errors.Is(err, errors.ErrUUIDAlreadyExists(uuid)) {
 					t.Error(err)
 				}
 
-				err = n.Delete(uuid)
+				err = n.Delete(ctx, uuid)
 				if err != nil && !errors.Is(err, errors.ErrObjectIDNotFound(uuid)) {
 					t.Error(err)
 				}
@@ -235,7 +235,7 @@ func Test_ngt_parallel_insert_and_delete(t *testing.T) {
 
 	for i := int64(0); i < maxIDNum; i++ {
 		uuid := strconv.FormatInt(i, 10)
-		if err := n.Insert(uuid, []float32{float32(i), float32(i)}); err != nil {
+		if err := n.Insert(ctx, uuid, []float32{float32(i), float32(i)}); err != nil {
 			t.Error(err)
 		}
 	}
@@ -152,7 +152,7 @@ func TestMaxDimInsert(t *testing.T) {
 					return err
 				}
 				vec := vector.GaussianDistributedFloat32VectorGenerator(1, dim)[0]
-				err = ngt.Insert(strconv.Itoa(dim), vec)
+				err = ngt.Insert(ctx, strconv.Itoa(dim), vec)
 				if err != nil {
 					t.Errorf("Insert error: %#v", err)
 					return err